@@ -0,0 +1,62 @@
+package main
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "go/tutorial/grpc/internal/store"
+  "log"
+  "net/http"
+  "time"
+
+  "golang.org/x/crypto/acme"
+  "golang.org/x/crypto/acme/autocert"
+)
+
+/*
+  AUTOMATIC TLS
+
+  A server on the internet needs a certificate browsers and gRPC clients trust, and Let's Encrypt hands them out for free through the ACME protocol. With -acme-domains the server gets its own certificate for those domains and renews it 30 days before it expires, nothing to copy around nor to restart (see secrets.go for certificates obtained otherwise):
+
+    go run . -addr :443 -acme-domains blog.example.com -acme-email ops@example.com
+
+  Before giving out a certificate Let's Encrypt checks the server owns the domain, by connecting to it:
+
+    - on port 443 (TLS-ALPN-01), which the server answers itself when -addr is :443
+    - on port 80 (HTTP-01) with -acme-http-addr :80, for a server listening on another port behind a port forward. Everything else sent there is redirected to https
+
+  Only the domains of -acme-domains get a certificate, a connection asking for another name fails its handshake rather than costing a request to Let's Encrypt. The account key and the certificates are kept with the posts, in blog.db or in the acme directory, see internal/store/certs.go, and encrypted with the keys of encryption.go when there are some.
+
+  Let's Encrypt limits how many certificates a domain gets a week. -acme-directory points the server to its staging environment while trying things out, its certificates aren't trusted but aren't limited either:
+
+    go run . -acme-domains blog.example.com -acme-directory https://acme-staging-v02.api.letsencrypt.org/directory
+*/
+const acmeCacheDir = "acme"
+
+func newACMEManager(domains []string, email, directory string, cache *store.CertCache) *autocert.Manager {
+  return &autocert.Manager{
+    Prompt:     autocert.AcceptTOS,
+    HostPolicy: autocert.HostWhitelist(domains...),
+    Email:      email,
+    Cache:      cache,
+    Client:     &acme.Client{DirectoryURL: directory},
+  }
+}
+
+// serveACMEChallenges answers the HTTP-01 challenges on addr until ctx is done.
+func serveACMEChallenges(manager *autocert.Manager, addr string) func(ctx context.Context) error {
+  return func(ctx context.Context) error {
+    srv := &http.Server{Addr: addr, Handler: manager.HTTPHandler(nil), ReadHeaderTimeout: 10 * time.Second}
+    go func() {
+      <-ctx.Done()
+      srv.Shutdown(context.Background())
+    }()
+
+    log.Printf("answering ACME challenges on %s", addr)
+    if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+      return fmt.Errorf("failed to answer ACME challenges: %w", err)
+    }
+
+    return nil
+  }
+}
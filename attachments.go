@@ -0,0 +1,225 @@
+package main
+
+import (
+  "errors"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+  "io"
+  "io/fs"
+  "os"
+  "path/filepath"
+
+  "google.golang.org/grpc"
+)
+
+const (
+  // gRPC limits messages to 4MB by default, we stay well below that when sending chunks back to the client.
+  attachmentChunkSize = 32 * 1024
+  maxAttachmentSize   = 20 * 1024 * 1024
+)
+
+/*
+  ATTACHMENT STORAGE
+
+  The handlers don't care where the bytes end up, they only need something they can write to and read from. Hiding that behind an interface means the files can live on disk today and in a blob storage service tomorrow without touching the RPCs. blobs.go has the bucket version.
+*/
+type attachmentStore interface {
+  Create(postID, attachmentID string) (io.WriteCloser, error)
+  Open(postID, attachmentID string) (io.ReadCloser, error)
+  Delete(postID, attachmentID string) error
+}
+
+// diskAttachmentStore keeps every attachment in <dir>/<post id>/<attachment id>. The original filename is only kept in the metadata so a client can't pick the path we write to.
+type diskAttachmentStore struct {
+  dir string
+}
+
+func newDiskAttachmentStore(dir string) *diskAttachmentStore {
+  return &diskAttachmentStore{dir: dir}
+}
+
+func (d *diskAttachmentStore) Create(postID, attachmentID string) (io.WriteCloser, error) {
+  if err := os.MkdirAll(filepath.Join(d.dir, postID), 0755); err != nil {
+    return nil, err
+  }
+
+  return os.Create(filepath.Join(d.dir, postID, attachmentID))
+}
+
+func (d *diskAttachmentStore) Open(postID, attachmentID string) (io.ReadCloser, error) {
+  return os.Open(filepath.Join(d.dir, postID, attachmentID))
+}
+
+func (d *diskAttachmentStore) Delete(postID, attachmentID string) error {
+  return os.Remove(filepath.Join(d.dir, postID, attachmentID))
+}
+
+/*
+  CLIENT STREAMING
+
+  Instead of a request message, client streaming handlers receive a stream. We call Recv until it returns io.EOF, which means the client called CloseSend, and then answer once with SendAndClose.
+*/
+func (s *server) UploadAttachment(stream grpc.ClientStreamingServer[pb.UploadAttachmentRequest, pb.Attachment]) error {
+  first, err := stream.Recv()
+  if err != nil {
+    return err
+  }
+
+  meta := first.GetMetadata()
+  if meta == nil {
+    return apperr.Errorf(apperr.ErrInvalidArgument, "the first message must contain the attachment metadata")
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err = loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return err
+  }
+
+  if _, err := findPost(posts, meta.GetPostId()); err != nil {
+    return err
+  }
+
+  attachment := &pb.Attachment{
+    Id:          store.NewID(),
+    Filename:    filepath.Base(meta.GetFilename()),
+    ContentType: meta.GetContentType(),
+    CreatedAt:   serverClock.Now().Format("2006-01-02"),
+  }
+
+  w, err := s.attachments.Create(meta.GetPostId(), attachment.Id)
+  if err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to store attachment: %w", err)
+  }
+
+  if err := receiveChunks(stream, w, attachment); err != nil {
+    w.Close()
+    s.attachments.Delete(meta.GetPostId(), attachment.Id)
+    return err
+  }
+
+  if err := w.Close(); err != nil {
+    s.attachments.Delete(meta.GetPostId(), attachment.Id)
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to store attachment: %w", err)
+  }
+
+  // The file is stored, now link it to the post. We reload the posts since they could have changed while the upload was in progress.
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return err
+  }
+
+  post, err := findPost(posts, meta.GetPostId())
+  if err != nil {
+    s.attachments.Delete(meta.GetPostId(), attachment.Id)
+    return err
+  }
+
+  post.Attachments = append(post.Attachments, attachment)
+
+  if err := savePosts(posts); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+
+  return stream.SendAndClose(attachment)
+}
+
+func receiveChunks(stream grpc.ClientStreamingServer[pb.UploadAttachmentRequest, pb.Attachment], w io.Writer, attachment *pb.Attachment) error {
+  for {
+    req, err := stream.Recv()
+    if err == io.EOF {
+      return nil
+    }
+    if err != nil {
+      return err
+    }
+
+    chunk := req.GetChunk()
+    attachment.Size += int64(len(chunk))
+    if attachment.Size > maxAttachmentSize {
+      return apperr.Errorf(apperr.ErrInvalidArgument, "attachment is bigger than %d bytes", maxAttachmentSize)
+    }
+
+    if _, err := w.Write(chunk); err != nil {
+      return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to store attachment: %w", err)
+    }
+  }
+}
+
+func findAttachment(posts *pb.Posts, postID, attachmentID string) (*pb.Post, *pb.Attachment, error) {
+  post, err := findPost(posts, postID)
+  if err != nil {
+    return nil, nil, err
+  }
+
+  for _, a := range post.Attachments {
+    if a.Id == attachmentID {
+      return post, a, nil
+    }
+  }
+
+  return nil, nil, apperr.Errorf(apperr.ErrAttachmentNotFound, "attachment %q not found", attachmentID)
+}
+
+/*
+  SERVER STREAMING
+
+  Server streaming handlers get the request plus a stream to Send as many messages as they want. Returning from the function ends the stream, with an OK status if we return nil.
+*/
+func (s *server) DownloadAttachment(req *pb.DownloadAttachmentRequest, stream grpc.ServerStreamingServer[pb.DownloadAttachmentResponse]) error {
+  // Only the post of the attachment is read, see openPosts.
+  posts, err := readPosts(store.Query{IDs: []string{req.GetPostId()}})
+  if err != nil {
+    return err
+  }
+
+  post, attachment, err := findAttachment(posts, req.GetPostId(), req.GetAttachmentId())
+  if err != nil {
+    return err
+  }
+
+  r, err := s.attachments.Open(post.Id, attachment.Id)
+  if errors.Is(err, fs.ErrNotExist) {
+    return apperr.Errorf(apperr.ErrAttachmentNotFound, "attachment %q not found", req.GetAttachmentId())
+  }
+  if err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to open attachment: %w", err)
+  }
+  defer r.Close()
+
+  metadata := &pb.DownloadAttachmentResponse{
+    Data: &pb.DownloadAttachmentResponse_Metadata{Metadata: attachment},
+  }
+  if err := stream.Send(metadata); err != nil {
+    return err
+  }
+
+  // Send marshals the message before returning, so every chunk goes out in the same message and buffer.
+  buf := make([]byte, attachmentChunkSize)
+  data := &pb.DownloadAttachmentResponse_Chunk{}
+  chunk := &pb.DownloadAttachmentResponse{Data: data}
+  for {
+    n, err := r.Read(buf)
+    if n > 0 {
+      data.Chunk = buf[:n]
+      if err := stream.Send(chunk); err != nil {
+        return err
+      }
+    }
+    if err == io.EOF {
+      return nil
+    }
+    if err != nil {
+      return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read attachment: %w", err)
+    }
+  }
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+  "bufio"
+  "context"
+  "encoding/json"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/reqctx"
+  "io/fs"
+  "log"
+  "os"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/encoding/protojson"
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  AUDIT LOG
+
+  Every call to a mutating RPC is recorded in audit.jsonl: who made it (identity and network address), what it was (method, post and the full request) and when, along with the status code it finished with. Failed calls are recorded too, an audit log that only shows what worked hides the interesting parts.
+
+  The file has one JSON object per line (JSON Lines), so recording an entry is a cheap append instead of rewriting the whole file like we do with posts.json.
+
+  Recording happens in interceptors rather than in the handlers, so a new mutating RPC only needs to be added to auditedMethods. The unary calls are recorded by unaryInterceptor and the client streams by streamInterceptor, which records UploadAttachment with its metadata as the request: the chunks that follow are the file, they would make the log as big as the attachments.
+
+  Some RPCs change things and are still left out on purpose. MarkAsRead and MarkNotificationRead only change the caller's own reading history and inbox, nobody else sees the change. Recording them, or RecordView, would turn the audit log into a list of what every reader read, which is more than the people reading the log should know.
+
+  The requests are recorded without the secrets they carry, the one of a webhook is replaced by [redacted]: the audit log is read by more people than the ones who could see the secret.
+
+  Left alone the file grows forever. With -audit-retention the compact-audit task (see cron.go) rewrites it without the entries older than that, 0 keeps them all:
+
+    go run . -audit-retention 2160h
+*/
+
+var auditedMethods = map[string]bool{
+  pb.Blog_CreatePost_FullMethodName:             true,
+  pb.Blog_UpdatePost_FullMethodName:             true,
+  pb.Blog_DeletePost_FullMethodName:             true,
+  pb.Blog_RestoreRevision_FullMethodName:        true,
+  pb.Blog_UploadAttachment_FullMethodName:       true,
+  pb.Blog_DeletePosts_FullMethodName:            true,
+  pb.Blog_ArchivePosts_FullMethodName:           true,
+  pb.Blog_PinPost_FullMethodName:                true,
+  pb.Blog_UnpinPost_FullMethodName:              true,
+  pb.Blog_CreateTemplate_FullMethodName:         true,
+  pb.Blog_UpdateTemplate_FullMethodName:         true,
+  pb.Blog_DeleteTemplate_FullMethodName:         true,
+  pb.Blog_CreatePostFromTemplate_FullMethodName: true,
+  pb.Blog_CreateSeries_FullMethodName:           true,
+  pb.Blog_AddToSeries_FullMethodName:            true,
+  pb.Blog_RemoveFromSeries_FullMethodName:       true,
+  pb.Blog_ReorderSeries_FullMethodName:          true,
+  pb.Blog_DeleteSeries_FullMethodName:           true,
+  pb.Blog_AddComment_FullMethodName:             true,
+  pb.Blog_ApproveComment_FullMethodName:         true,
+  pb.Blog_RejectComment_FullMethodName:          true,
+  pb.Blog_ReportPost_FullMethodName:             true,
+  pb.Blog_ReportComment_FullMethodName:          true,
+  pb.Blog_ResolveReport_FullMethodName:          true,
+  pb.Blog_SetAuthorBio_FullMethodName:           true,
+  pb.Blog_RegisterWebhook_FullMethodName:        true,
+  pb.Blog_UnregisterWebhook_FullMethodName:      true,
+  pb.Blog_SubscribeByEmail_FullMethodName:       true,
+  pb.Blog_UnsubscribeByEmail_FullMethodName:     true,
+  pb.Admin_SetDebugLogging_FullMethodName:       true,
+  pb.Admin_SetMaintenance_FullMethodName:        true,
+  pb.Admin_ReloadConfig_FullMethodName:          true,
+  pb.Admin_FlushStorage_FullMethodName:          true,
+  pb.Admin_ReencryptStorage_FullMethodName:      true,
+  pb.Admin_RunScheduledTask_FullMethodName:      true,
+  pb.Admin_AddBan_FullMethodName:                true,
+  pb.Admin_RemoveBan_FullMethodName:             true,
+}
+
+type auditLog struct {
+  path      string
+  retention time.Duration
+
+  mu sync.Mutex
+}
+
+func newAuditLog(path string, retention time.Duration) *auditLog {
+  return &auditLog{path: path, retention: retention}
+}
+
+func (a *auditLog) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  resp, err := handler(ctx, req)
+
+  if auditedMethods[info.FullMethod] {
+    a.record(ctx, info.FullMethod, req, resp, err)
+  }
+
+  return resp, err
+}
+
+// streamInterceptor records the client streams of auditedMethods once they finish, with the first message the client sent as the request.
+func (a *auditLog) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  if !info.IsClientStream || !auditedMethods[info.FullMethod] {
+    return handler(srv, ss)
+  }
+
+  stream := &auditedStream{ServerStream: ss}
+  err := handler(srv, stream)
+  a.record(ss.Context(), info.FullMethod, stream.req, stream.resp, err)
+
+  return err
+}
+
+// auditedStream keeps the first message of the client and the response of the server.
+type auditedStream struct {
+  grpc.ServerStream
+
+  req, resp any
+}
+
+func (s *auditedStream) RecvMsg(m any) error {
+  err := s.ServerStream.RecvMsg(m)
+  if err == nil && s.req == nil {
+    // Only the metadata of an upload is recorded, the chunks after it are the file.
+    if upload, ok := m.(*pb.UploadAttachmentRequest); ok {
+      s.req = upload.GetMetadata()
+    } else {
+      s.req = m
+    }
+  }
+
+  return err
+}
+
+func (s *auditedStream) SendMsg(m any) error {
+  s.resp = m
+  return s.ServerStream.SendMsg(m)
+}
+
+func (a *auditLog) record(ctx context.Context, method string, req, resp any, err error) {
+  entry := &pb.AuditEntry{
+    Time:     serverClock.Now().UTC().Format(time.RFC3339),
+    Identity: reqctx.Identity(ctx),
+    Method:   method,
+    PostId:   auditPostID(req, resp),
+    Code:     status.Code(err).String(),
+    Peer:     reqctx.Peer(ctx),
+  }
+
+  if m, ok := req.(proto.Message); ok {
+    if data, err := protojson.Marshal(redactRequest(m)); err == nil {
+      entry.Request = string(data)
+    }
+  }
+
+  // The call already happened, so failing to record it shouldn't change its outcome for the caller.
+  if err := a.append(entry); err != nil {
+    log.Printf("failed to write audit entry: %v", err)
+  }
+}
+
+// redactRequest returns the request as it is recorded, a copy without its secrets when it has some.
+func redactRequest(m proto.Message) proto.Message {
+  if r, ok := m.(*pb.RegisterWebhookRequest); ok && r.GetSecret() != "" {
+    r = proto.Clone(r).(*pb.RegisterWebhookRequest)
+    r.Secret = "[redacted]"
+    return r
+  }
+
+  return m
+}
+
+// auditPostID finds the post a call was about: created posts only have an ID in the response.
+func auditPostID(req, resp any) string {
+  if post, ok := resp.(*pb.Post); ok && post != nil {
+    return post.GetId()
+  }
+  // The Id of a moderated comment is the comment's.
+  if comment, ok := resp.(*pb.Comment); ok && comment != nil {
+    return comment.GetPostId()
+  }
+  // So is the Id of a resolved report, and comments can be reported too.
+  if report, ok := resp.(*pb.Report); ok && report != nil {
+    return report.GetPostId()
+  }
+
+  switch r := req.(type) {
+  // The Id of a webhook isn't a post's.
+  case *pb.UnregisterWebhookRequest:
+    return ""
+  case interface{ GetId() string }:
+    return r.GetId()
+  case interface{ GetPostId() string }:
+    return r.GetPostId()
+  }
+
+  return ""
+}
+
+func (a *auditLog) append(entry *pb.AuditEntry) error {
+  data, err := json.Marshal(entry)
+  if err != nil {
+    return err
+  }
+
+  a.mu.Lock()
+  defer a.mu.Unlock()
+
+  f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+
+  _, err = f.Write(append(data, '\n'))
+  return err
+}
+
+// compact is the compact-audit task: it rewrites the file without the entries older than the retention.
+func (a *auditLog) compact(context.Context) (string, error) {
+  if a.retention == 0 {
+    return "kept every entry, -audit-retention is 0", nil
+  }
+  oldest := serverClock.Now().Add(-a.retention)
+
+  a.mu.Lock()
+  defer a.mu.Unlock()
+
+  f, err := os.Open(a.path)
+  if errors.Is(err, fs.ErrNotExist) {
+    return "no audit log yet", nil
+  }
+  if err != nil {
+    return "", err
+  }
+  defer f.Close()
+
+  tmp, err := os.OpenFile(a.path+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+  if err != nil {
+    return "", err
+  }
+  defer os.Remove(tmp.Name())
+  w := bufio.NewWriter(tmp)
+
+  kept, dropped := 0, 0
+  scanner := bufio.NewScanner(f)
+  scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+  for scanner.Scan() {
+    entry := &pb.AuditEntry{}
+    // Entries that can't be read are kept, compacting isn't the place to lose them.
+    if json.Unmarshal(scanner.Bytes(), entry) == nil {
+      if at, err := time.Parse(time.RFC3339, entry.Time); err == nil && at.Before(oldest) {
+        dropped++
+        continue
+      }
+    }
+    w.Write(scanner.Bytes())
+    w.WriteByte('\n')
+    kept++
+  }
+  if err := scanner.Err(); err != nil {
+    tmp.Close()
+    return "", err
+  }
+  if err := w.Flush(); err != nil {
+    tmp.Close()
+    return "", err
+  }
+  if err := tmp.Close(); err != nil {
+    return "", err
+  }
+  if dropped == 0 {
+    return fmt.Sprintf("kept all %d entries", kept), nil
+  }
+  if err := os.Rename(tmp.Name(), a.path); err != nil {
+    return "", err
+  }
+
+  return fmt.Sprintf("dropped %d entries older than %s, kept %d", dropped, oldest.UTC().Format(time.RFC3339), kept), nil
+}
+
+func (a *auditLog) query(req *pb.QueryAuditLogRequest) (*pb.AuditEntries, error) {
+  var since, until time.Time
+  var err error
+
+  if req.GetSince() != "" {
+    if since, err = time.Parse(time.RFC3339, req.GetSince()); err != nil {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Since must be an RFC 3339 timestamp: %w", err)
+    }
+  }
+  if req.GetUntil() != "" {
+    if until, err = time.Parse(time.RFC3339, req.GetUntil()); err != nil {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Until must be an RFC 3339 timestamp: %w", err)
+    }
+  }
+
+  limit := int(req.GetLimit())
+  if limit <= 0 {
+    limit = 100
+  }
+
+  a.mu.Lock()
+  defer a.mu.Unlock()
+
+  f, err := os.Open(a.path)
+  if errors.Is(err, fs.ErrNotExist) {
+    return &pb.AuditEntries{}, nil
+  }
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read audit log: %w", err)
+  }
+  defer f.Close()
+
+  entries := make([]*pb.AuditEntry, 0)
+  scanner := bufio.NewScanner(f)
+  // Requests can be large, allow lines of up to 4MB which is gRPC's default maximum message size.
+  scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+  for scanner.Scan() {
+    entry := &pb.AuditEntry{}
+    if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+      return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to parse audit log: %w", err)
+    }
+
+    if req.GetPostId() != "" && entry.PostId != req.GetPostId() {
+      continue
+    }
+    if req.GetIdentity() != "" && entry.Identity != req.GetIdentity() {
+      continue
+    }
+
+    at, _ := time.Parse(time.RFC3339, entry.Time)
+    if !since.IsZero() && at.Before(since) {
+      continue
+    }
+    if !until.IsZero() && at.After(until) {
+      continue
+    }
+
+    entries = append(entries, entry)
+  }
+
+  if err := scanner.Err(); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read audit log: %w", err)
+  }
+
+  // The file is in chronological order, so the most recent entries are at the end.
+  if len(entries) > limit {
+    entries = entries[len(entries)-limit:]
+  }
+
+  return &pb.AuditEntries{Entries: entries}, nil
+}
+
+func (s *server) QueryAuditLog(ctx context.Context, req *pb.QueryAuditLogRequest) (*pb.AuditEntries, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  return s.audit.query(req)
+}
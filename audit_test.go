@@ -0,0 +1,94 @@
+package main
+
+import (
+  "context"
+  "encoding/base64"
+  "encoding/json"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+
+  "google.golang.org/grpc"
+  "google.golang.org/protobuf/proto"
+)
+
+func TestAuditRedactsWebhookSecrets(t *testing.T) {
+  audit := newAuditLog(filepath.Join(t.TempDir(), "audit.jsonl"), 0)
+  req := &pb.RegisterWebhookRequest{Url: "https://example.com/hook", Secret: "hunter2"}
+  _, err := audit.unaryInterceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: pb.Blog_RegisterWebhook_FullMethodName}, func(context.Context, any) (any, error) {
+    return &pb.Webhook{Id: "w1", Url: req.Url}, nil
+  })
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  data, err := os.ReadFile(audit.path)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if strings.Contains(string(data), "hunter2") || !strings.Contains(string(data), "example.com/hook") {
+    t.Errorf("got the entry %s, want the URL without the secret", data)
+  }
+  if req.Secret != "hunter2" {
+    t.Errorf("the request of the handler lost its secret")
+  }
+}
+
+// uploadStream plays the client of UploadAttachment: it sends msgs and keeps what the server sends back.
+type uploadStream struct {
+  grpc.ServerStream
+
+  msgs []*pb.UploadAttachmentRequest
+}
+
+func (s *uploadStream) Context() context.Context { return context.Background() }
+
+func (s *uploadStream) RecvMsg(m any) error {
+  if len(s.msgs) == 0 {
+    return io.EOF
+  }
+  proto.Merge(m.(proto.Message), s.msgs[0])
+  s.msgs = s.msgs[1:]
+  return nil
+}
+
+func (s *uploadStream) SendMsg(any) error { return nil }
+
+func TestAuditRecordsUploads(t *testing.T) {
+  audit := newAuditLog(filepath.Join(t.TempDir(), "audit.jsonl"), 0)
+  ss := &uploadStream{msgs: []*pb.UploadAttachmentRequest{
+    {Data: &pb.UploadAttachmentRequest_Metadata{Metadata: &pb.AttachmentMetadata{PostId: "p1", Filename: "plan.txt"}}},
+    {Data: &pb.UploadAttachmentRequest_Chunk{Chunk: []byte("the whole file")}},
+  }}
+  info := &grpc.StreamServerInfo{FullMethod: pb.Blog_UploadAttachment_FullMethodName, IsClientStream: true}
+  err := audit.streamInterceptor(nil, ss, info, func(_ any, stream grpc.ServerStream) error {
+    for {
+      if err := stream.RecvMsg(&pb.UploadAttachmentRequest{}); err == io.EOF {
+        return stream.SendMsg(&pb.Attachment{Id: "a1", Filename: "plan.txt"})
+      } else if err != nil {
+        return err
+      }
+    }
+  })
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  data, err := os.ReadFile(audit.path)
+  if err != nil {
+    t.Fatal(err)
+  }
+  var entry pb.AuditEntry
+  if err := json.Unmarshal(data, &entry); err != nil {
+    t.Fatal(err)
+  }
+  if entry.Method != pb.Blog_UploadAttachment_FullMethodName || entry.PostId != "p1" || !strings.Contains(entry.Request, "plan.txt") {
+    t.Errorf("got the entry %s, want the upload of plan.txt to p1", data)
+  }
+  if strings.Contains(string(data), "the whole file") || strings.Contains(string(data), base64.StdEncoding.EncodeToString([]byte("the whole file"))) {
+    t.Errorf("got the entry %s, want it without the chunks", data)
+  }
+}
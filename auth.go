@@ -0,0 +1,114 @@
+package main
+
+import (
+  "context"
+  "crypto/subtle"
+  "go/tutorial/grpc/internal/reqctx"
+  "log"
+  "strings"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  METADATA AND IDENTITY
+
+  gRPC metadata is the equivalent of HTTP headers: key/value pairs sent along with a call. Just like on the web, credentials usually travel in the "authorization" key:
+
+    authorization: Bearer <token>
+
+  The server knows the admin, authenticated with the token given in -admin-token, and the callers with an access token of an OpenID Connect provider when -oidc-issuer is given (see oidc.go). Machine clients signing their calls with a key of -signing-keys are hmac:<key ID> (see signing.go), workloads presenting an SVID their SPIFFE ID (see spiffe.go). Readers with a session token in the x-session key are session:<viewer ID> (see sessions.go), which gives them no rights, only a name. Everybody else is anonymous. Admin only RPCs, like QueryAuditLog, are disabled altogether when there is neither, nor an admin signing key.
+*/
+
+const (
+  anonymousIdentity = "anonymous"
+  adminIdentity     = "admin"
+)
+
+type authenticator struct {
+  // nil without -admin-token or -admin-token-from, see secrets.go
+  adminToken *secret
+  // nil without -oidc-issuer.
+  oidc *oidcVerifier
+  // Checks the session tokens, nil leaves every reader anonymous.
+  sessions *sessions
+}
+
+func newAuthenticator(adminToken *secret, oidc *oidcVerifier, sessions *sessions) *authenticator {
+  return &authenticator{adminToken: adminToken, oidc: oidc, sessions: sessions}
+}
+
+// isAdmin tells whether the identity may call the admin RPCs: the admin of the token, or an admin of the OIDC provider.
+func isAdmin(identity string) bool {
+  return identity == adminIdentity || strings.HasPrefix(identity, adminIdentity+":")
+}
+
+// identity returns who is making the call based on the incoming metadata. The reqctx interceptor calls it once per call, everything else reads the result with reqctx.Identity.
+func (a *authenticator) identity(ctx context.Context) string {
+  // The signature of a signed call was checked before, see signing.go
+  if identity, ok := signedIdentity(ctx); ok {
+    return identity
+  }
+  // So was the SVID, see spiffe.go
+  if identity, ok := spiffeIdentity(ctx); ok {
+    return identity
+  }
+
+  md, ok := metadata.FromIncomingContext(ctx)
+  if !ok {
+    return anonymousIdentity
+  }
+
+  for _, value := range md.Get("authorization") {
+    token, found := strings.CutPrefix(value, "Bearer ")
+    if !found {
+      continue
+    }
+    // ConstantTimeCompare takes the same time whether the tokens share a prefix or not, so the token can't be guessed one character at a time by measuring response times.
+    if admin := a.adminToken.get(); len(admin) > 0 && subtle.ConstantTimeCompare([]byte(token), admin) == 1 {
+      return adminIdentity
+    }
+    if a.oidc != nil {
+      identity, err := a.oidc.identity(ctx, token)
+      if err == nil {
+        return identity
+      }
+      // The caller only finds out it is anonymous, the log tells the operator why.
+      log.Printf("oidc: refused token: %v", err)
+    }
+  }
+  // An authorization that didn't check out doesn't fall back to the session, the caller meant to be somebody else.
+  if a.sessions != nil && len(md.Get("authorization")) == 0 {
+    if identity, ok := a.sessions.identity(md); ok {
+      return identity
+    }
+  }
+
+  return anonymousIdentity
+}
+
+/*
+  Unauthenticated and PermissionDenied are the gRPC counterparts of HTTP 401 and 403: the first one means "we don't know who you are", the second one "we know who you are and you can't do this".
+*/
+func (a *authenticator) requireAdmin(ctx context.Context) error {
+  // The admin keys of -signing-keys and the admin rules of -spiffe-policy are admins whatever the other flags say, see signing.go and spiffe.go
+  switch identity := reqctx.Identity(ctx); {
+  case isAdmin(identity):
+  case strings.HasPrefix(identity, "hmac:"):
+    // Signed, but not with an admin key.
+    return status.Errorf(codes.PermissionDenied, "this RPC requires an admin signing key")
+  case strings.HasPrefix(identity, "spiffe://"):
+    return status.Errorf(codes.PermissionDenied, "this RPC requires a SPIFFE ID with an admin rule in -spiffe-policy")
+  case a.adminToken == nil && a.oidc == nil:
+    return status.Errorf(codes.PermissionDenied, "admin RPCs are disabled, start the server with -admin-token or -oidc-issuer")
+  case strings.HasPrefix(identity, "oidc:"):
+    // Known, but without the admin scope.
+    return status.Errorf(codes.PermissionDenied, "this RPC requires the admin token or the %s scope", a.oidc.adminScope)
+  default:
+    return status.Errorf(codes.Unauthenticated, "this RPC requires the admin token")
+  }
+
+  return nil
+}
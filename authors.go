@@ -0,0 +1,287 @@
+package main
+
+import (
+  "cmp"
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "slices"
+  "strings"
+  "sync"
+  "time"
+)
+
+/*
+  AUTHOR PROFILES
+
+  The page of an author shows a bio, how many posts they wrote and how often these were read, the latest ones and the tags they write about. Put together from GetPosts that is every post of the blog sent to the frontend, and a call per post for the rest. GetAuthorProfile answers with all of it:
+
+    go run ./client author -name Ana
+    go run ./client author -name Ana -set-bio "Writes about RPCs" -token secret
+
+  Going through every post on every call is what a frontend would do, only closer to the storage. The server instead caches the published posts grouped by author, without their content, kept up to date by runIndex (see watch.go). A profile is then summed from the posts of one author.
+
+  Views don't go through the broker, RecordView tells the cache the new ViewCount of the post itself. With Redis the counts of the storage lag behind the ones of Redis (see redis.go), so the cache never lowers a count it has: views only ever go up.
+
+  Authors aren't accounts, only the names of the posts, so a bio is set for a name with SetAuthorBio, by an admin. The bios are kept in authors.json, and a name with a bio has a profile before its first post.
+*/
+const (
+  authorBiosPath      = "authors.json"
+  authorsRebuildEvery = 10 * time.Minute
+  maxAuthorTopTags    = 10
+  defaultRecentPosts  = 5
+)
+
+type authorIndex struct {
+  broker   *postBroker
+  biosPath string
+
+  mu sync.Mutex
+  // posts[post ID] is the published post, without its content.
+  posts map[string]*authorPost
+  // byAuthor[author] holds the IDs of the published posts of the author.
+  byAuthor map[string]map[string]bool
+  bios     map[string]string
+  // next is the position of the next post seen, the order posts were created in.
+  next int64
+}
+
+type authorPost struct {
+  post  *pb.Post
+  order int64
+}
+
+func newAuthorIndex(broker *postBroker, biosPath string) (*authorIndex, error) {
+  x := &authorIndex{
+    broker:   broker,
+    biosPath: biosPath,
+    posts:    make(map[string]*authorPost),
+    byAuthor: make(map[string]map[string]bool),
+    bios:     make(map[string]string),
+  }
+  if err := readJSON(biosPath, &x.bios); err != nil {
+    return nil, err
+  }
+
+  return x, nil
+}
+
+// summary is the post as the profile shows it, the fields of a list of posts.
+func summary(post *pb.Post) *pb.Post {
+  return &pb.Post{
+    Id:             post.Id,
+    Title:          post.Title,
+    Author:         post.Author,
+    CreatedAt:      post.CreatedAt,
+    ViewCount:      post.ViewCount,
+    Tags:           post.Tags,
+    Slug:           post.Slug,
+    ReadingMinutes: post.ReadingMinutes,
+    CreatedBy:      post.CreatedBy,
+  }
+}
+
+// put caches the post, moving it if its author changed. Posts that aren't published are only removed.
+func (x *authorIndex) put(post *pb.Post) {
+  x.mu.Lock()
+  defer x.mu.Unlock()
+
+  cached, known := x.posts[post.Id]
+  x.remove(post.Id)
+  if post.Status != pb.PostStatus_PUBLISHED {
+    return
+  }
+
+  if known {
+    x.insert(post, cached.order, cached.post.ViewCount)
+  } else {
+    x.insert(post, x.next, 0)
+    x.next++
+  }
+}
+
+// insert adds a published post at the given position, with at least the given views. x.mu must be held.
+func (x *authorIndex) insert(post *pb.Post, order, views int64) {
+  entry := &authorPost{post: summary(post), order: order}
+  entry.post.ViewCount = max(entry.post.ViewCount, views)
+
+  x.posts[post.Id] = entry
+  if x.byAuthor[post.Author] == nil {
+    x.byAuthor[post.Author] = make(map[string]bool)
+  }
+  x.byAuthor[post.Author][post.Id] = true
+}
+
+// drop removes a deleted post.
+func (x *authorIndex) drop(id string) {
+  x.mu.Lock()
+  defer x.mu.Unlock()
+
+  x.remove(id)
+}
+
+// remove drops the post, x.mu must be held. Its order is forgotten, a post published again comes last.
+func (x *authorIndex) remove(id string) {
+  entry, ok := x.posts[id]
+  if !ok {
+    return
+  }
+
+  delete(x.byAuthor[entry.post.Author], id)
+  if len(x.byAuthor[entry.post.Author]) == 0 {
+    delete(x.byAuthor, entry.post.Author)
+  }
+  delete(x.posts, id)
+}
+
+// viewed sets the ViewCount of a post after RecordView counted a view.
+func (x *authorIndex) viewed(id string, views int64) {
+  x.mu.Lock()
+  defer x.mu.Unlock()
+
+  if entry, ok := x.posts[id]; ok {
+    entry.post.ViewCount = max(entry.post.ViewCount, views)
+  }
+}
+
+func (x *authorIndex) rebuild(posts []*pb.Post) {
+  x.mu.Lock()
+  defer x.mu.Unlock()
+
+  previous := x.posts
+  x.posts, x.byAuthor, x.next = make(map[string]*authorPost), make(map[string]map[string]bool), 0
+  for _, post := range posts {
+    if post.Status != pb.PostStatus_PUBLISHED {
+      continue
+    }
+    var views int64
+    if cached, ok := previous[post.Id]; ok {
+      views = cached.post.ViewCount
+    }
+    x.insert(post, x.next, views)
+    x.next++
+  }
+}
+
+// load rebuilds the cache from the storage.
+func (x *authorIndex) load() error {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return err
+  }
+  x.rebuild(posts.Posts)
+
+  return nil
+}
+
+func (x *authorIndex) run(ctx context.Context) error {
+  return runIndex(ctx, x.broker, x, authorsRebuildEvery)
+}
+
+// profile sums the profile of the author, leaving out the posts created by the hidden identities. ok is false for an author without posts or bio.
+func (x *authorIndex) profile(author string, recent int, hidden map[string]bool) (profile *pb.AuthorProfile, ok bool) {
+  x.mu.Lock()
+  defer x.mu.Unlock()
+
+  profile = &pb.AuthorProfile{Author: author}
+  bio, hasBio := x.bios[author]
+  profile.Bio = bio
+
+  var posts []*authorPost
+  tags := make(map[string]int32)
+  for id := range x.byAuthor[author] {
+    entry := x.posts[id]
+    if hidden[entry.post.CreatedBy] {
+      continue
+    }
+    posts = append(posts, entry)
+    profile.TotalViews += entry.post.ViewCount
+    for _, tag := range entry.post.Tags {
+      tags[tag]++
+    }
+  }
+  if len(posts) == 0 && !hasBio {
+    return nil, false
+  }
+  profile.PostCount = int32(len(posts))
+
+  // CreatedAt is a day, the posts of a day come in the order they were created.
+  slices.SortFunc(posts, func(a, b *authorPost) int {
+    return cmp.Or(strings.Compare(b.post.CreatedAt, a.post.CreatedAt), cmp.Compare(b.order, a.order))
+  })
+  for _, entry := range posts[:min(recent, len(posts))] {
+    // A copy, views keep changing the cached one.
+    profile.RecentPosts = append(profile.RecentPosts, summary(entry.post))
+  }
+
+  for tag, n := range tags {
+    profile.TopTags = append(profile.TopTags, &pb.TagUse{Tag: tag, Posts: n})
+  }
+  slices.SortFunc(profile.TopTags, func(a, b *pb.TagUse) int {
+    return cmp.Or(cmp.Compare(b.Posts, a.Posts), cmp.Compare(a.Tag, b.Tag))
+  })
+  profile.TopTags = profile.TopTags[:min(maxAuthorTopTags, len(profile.TopTags))]
+
+  return profile, true
+}
+
+func (s *server) GetAuthorProfile(_ context.Context, req *pb.GetAuthorProfileRequest) (*pb.AuthorProfile, error) {
+  recent := int(req.GetRecentPosts())
+  if recent == 0 {
+    recent = defaultRecentPosts
+  }
+  // With -hide-banned the posts of the banned authors are left out, like from GetPosts, see bans.go
+  var hidden map[string]bool
+  if s.hideBanned {
+    hidden = s.bans.bannedAuthors()
+  }
+
+  profile, ok := s.authors.profile(req.GetAuthor(), recent, hidden)
+  if !ok {
+    return nil, apperr.Errorf(apperr.ErrAuthorNotFound, "author %q not found", req.GetAuthor())
+  }
+
+  return profile, nil
+}
+
+func (s *server) SetAuthorBio(ctx context.Context, req *pb.SetAuthorBioRequest) (*pb.AuthorProfile, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  x := s.authors
+  x.mu.Lock()
+  bios := make(map[string]string, len(x.bios)+1)
+  for author, bio := range x.bios {
+    bios[author] = bio
+  }
+  if bio := strings.TrimSpace(req.GetBio()); bio != "" {
+    bios[req.GetAuthor()] = bio
+  } else {
+    delete(bios, req.GetAuthor())
+  }
+  err := replaceFile(x.biosPath, bios)
+  if err == nil {
+    x.bios = bios
+  }
+  x.mu.Unlock()
+
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save the bios: %w", err)
+  }
+
+  profile, ok := x.profile(req.GetAuthor(), defaultRecentPosts, nil)
+  if !ok {
+    // The bio of an author without posts was removed, what is left is an empty profile.
+    profile = &pb.AuthorProfile{Author: req.GetAuthor()}
+  }
+
+  return profile, nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/reqctx"
+  "path/filepath"
+  "slices"
+  "testing"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+func TestAuthorProfileFollowsTheWrites(t *testing.T) {
+  x, err := newAuthorIndex(newPostBroker(), filepath.Join(t.TempDir(), "authors.json"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  x.rebuild([]*pb.Post{
+    {Id: "p1", Title: "Hello gRPC", Author: "Ana", CreatedAt: "2025-06-01", ViewCount: 10, Tags: []string{"grpc"}},
+    {Id: "p2", Title: "Streaming", Author: "Ana", CreatedAt: "2025-06-03", ViewCount: 5, Tags: []string{"grpc", "streaming"}},
+    {Id: "p3", Title: "Soon", Author: "Ana", CreatedAt: "2025-06-03", Status: pb.PostStatus_SCHEDULED},
+    {Id: "p4", Title: "Deadlines", Author: "Chen", CreatedAt: "2025-06-02", ViewCount: 7},
+  })
+
+  // Created the same day as p2, after it.
+  x.put(&pb.Post{Id: "p5", Title: "Retries", Author: "Ana", CreatedAt: "2025-06-03", Tags: []string{"retries"}})
+  x.viewed("p5", 3)
+  // Moved to Chen.
+  x.put(&pb.Post{Id: "p1", Title: "Hello gRPC", Author: "Chen", CreatedAt: "2025-06-01", ViewCount: 10, Tags: []string{"grpc"}})
+
+  ana, ok := x.profile("Ana", 5, nil)
+  if !ok {
+    t.Fatal("Ana has no profile")
+  }
+  var recent []string
+  for _, post := range ana.RecentPosts {
+    recent = append(recent, post.Id)
+  }
+  if ana.PostCount != 2 || ana.TotalViews != 8 || !slices.Equal(recent, []string{"p5", "p2"}) {
+    t.Errorf("got %d posts, %d views and the recent posts %v, want 2, 8 and [p5 p2]", ana.PostCount, ana.TotalViews, recent)
+  }
+  if len(ana.TopTags) != 3 || ana.TopTags[0].Tag != "grpc" || ana.TopTags[1].Tag != "retries" {
+    t.Errorf("got the tags %v, want grpc then retries and streaming", ana.TopTags)
+  }
+
+  // A rebuild from a storage lagging behind the views keeps the counts of the cache.
+  x.rebuild([]*pb.Post{{Id: "p4", Author: "Chen", ViewCount: 7}, {Id: "p1", Author: "Chen", ViewCount: 2}})
+  if chen, _ := x.profile("Chen", 5, nil); chen.TotalViews != 17 {
+    t.Errorf("Chen has %d views after the rebuild, want 17", chen.TotalViews)
+  }
+  if _, ok := x.profile("Ana", 5, nil); ok {
+    t.Error("Ana has a profile without posts or bio")
+  }
+}
+
+func TestGetAuthorProfile(t *testing.T) {
+  authors, err := newAuthorIndex(newPostBroker(), filepath.Join(t.TempDir(), "authors.json"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  auth := newAuthenticator(staticSecret(testAdminToken), nil, nil)
+  s := &server{auth: auth, authors: authors}
+  // setBio calls SetAuthorBio behind the identity interceptor, like the server does.
+  setBio := func(token string, req *pb.SetAuthorBioRequest) (*pb.AuthorProfile, error) {
+    ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+    res, err := reqctx.UnaryServerInterceptor(auth.identity)(ctx, req, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+      return s.SetAuthorBio(ctx, req.(*pb.SetAuthorBioRequest))
+    })
+    profile, _ := res.(*pb.AuthorProfile)
+    return profile, err
+  }
+
+  _, err = s.GetAuthorProfile(context.Background(), &pb.GetAuthorProfileRequest{Author: "Ana"})
+  if status.Code(err) != codes.NotFound {
+    t.Fatalf("got %v for an unknown author, want NotFound", err)
+  }
+
+  if _, err := setBio("wrong", &pb.SetAuthorBioRequest{Author: "Ana", Bio: "Writes about RPCs"}); status.Code(err) != codes.Unauthenticated {
+    t.Fatalf("got %v setting a bio without the token, want Unauthenticated", err)
+  }
+  profile, err := setBio(testAdminToken, &pb.SetAuthorBioRequest{Author: "Ana", Bio: " Writes about RPCs "})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if profile.Bio != "Writes about RPCs" {
+    t.Errorf("got the bio %q", profile.Bio)
+  }
+
+  // The bios are read back on the next start.
+  reloaded, err := newAuthorIndex(newPostBroker(), authors.biosPath)
+  if err != nil {
+    t.Fatal(err)
+  }
+  s.authors = reloaded
+  if profile, err := s.GetAuthorProfile(context.Background(), &pb.GetAuthorProfileRequest{Author: "Ana"}); err != nil || profile.Bio != "Writes about RPCs" {
+    t.Errorf("got %v, %v after a restart, want the bio", profile, err)
+  }
+}
+
+func TestAuthorIndexFollowsTheBroker(t *testing.T) {
+  useFileStore(t, []*pb.Post{{Id: "p1", Title: "Hello gRPC", Author: "Ana", CreatedAt: "2025-06-01"}})
+  broker := newPostBroker()
+  x, err := newAuthorIndex(broker, filepath.Join(t.TempDir(), "authors.json"))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  ctx, cancel := context.WithCancel(context.Background())
+  done := make(chan error)
+  go func() { done <- x.run(ctx) }()
+  defer func() {
+    cancel()
+    if err := <-done; err != nil {
+      t.Error(err)
+    }
+  }()
+
+  // The index subscribes before loading the posts, the created post is published until it shows.
+  postCount := func() int32 {
+    ana, _ := x.profile("Ana", 5, nil)
+    return ana.GetPostCount()
+  }
+  deadline := time.Now().Add(5 * time.Second)
+  for postCount() != 2 {
+    if time.Now().After(deadline) {
+      t.Fatalf("Ana has %d posts, want the loaded and the created one", postCount())
+    }
+    broker.publish(pb.PostEventType_POST_CREATED, &pb.Post{Id: "p2", Title: "Streaming", Author: "Ana", CreatedAt: "2025-06-02"})
+    time.Sleep(10 * time.Millisecond)
+  }
+
+  broker.publish(pb.PostEventType_POST_DELETED, &pb.Post{Id: "p1", Author: "Ana"})
+  for postCount() != 1 {
+    if time.Now().After(deadline) {
+      t.Fatal("the deleted post is still in the profile")
+    }
+    time.Sleep(10 * time.Millisecond)
+  }
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/store"
+  "net"
+  "net/netip"
+  "slices"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  BANS
+
+  Some callers are better kept out: a spammer, a bot hammering CreatePost. The ban list holds the identities (see auth.go) and the IP addresses that can't change anything anymore. Admins manage it with the Admin RPCs AddBan, RemoveBan and ListBans, and a moderator resolving a report with AUTHOR_BANNED adds the author to it (see reports.go):
+
+    go run ./client bans add -token secret -identity session:<viewer> -reason spam
+    go run ./client bans add -token secret -ip 203.0.113.7 -reason "bot"
+    go run ./client bans list -token secret
+    go run ./client bans remove -token secret -id <ban id>
+
+  The bans are checked in an interceptor rather than in every handler, like the audit log: a banned caller, by identity or by address, gets PermissionDenied from the mutating RPCs, the ones in auditedMethods, and from the uploads. Reading stays open, there is no stopping anyone from reading a public blog anyway. Admins are never turned away, even from a banned address, so nobody locks the moderators out.
+
+  With -hide-banned GetPosts also leaves out the posts created by banned identities. An address isn't kept with the posts, so the banned addresses only stop new posts.
+
+  The list is small and checked on every call, so it is kept in memory and written to bans.json whenever it changes.
+*/
+const bansPath = "bans.json"
+
+type banList struct {
+  path string
+
+  mu   sync.Mutex
+  bans []*pb.Ban
+}
+
+func newBanList(path string) (*banList, error) {
+  b := &banList{path: path}
+  if err := readJSON(path, &b.bans); err != nil {
+    return nil, err
+  }
+
+  return b, nil
+}
+
+func (a *adminServer) AddBan(ctx context.Context, req *pb.AddBanRequest) (*pb.Ban, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  ban := &pb.Ban{Identity: req.GetIdentity(), Reason: req.GetReason(), BannedBy: reqctx.Identity(ctx)}
+  if req.GetIp() != "" {
+    ip, err := netip.ParseAddr(req.GetIp())
+    if err != nil {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Ip %q is not an IP address", req.GetIp())
+    }
+    ban.Ip = ip.Unmap().String()
+  }
+
+  return a.bans.add(ban)
+}
+
+func (a *adminServer) RemoveBan(ctx context.Context, req *pb.RemoveBanRequest) (*pb.RemoveBanResponse, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  b := a.bans
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  i := slices.IndexFunc(b.bans, func(ban *pb.Ban) bool { return ban.Id == req.GetId() })
+  if i == -1 {
+    return nil, apperr.Errorf(apperr.ErrBanNotFound, "ban %q not found", req.GetId())
+  }
+  bans := slices.Delete(slices.Clone(b.bans), i, i+1)
+  if err := b.save(bans); err != nil {
+    return nil, err
+  }
+  b.bans = bans
+
+  return &pb.RemoveBanResponse{}, nil
+}
+
+func (a *adminServer) ListBans(ctx context.Context, _ *pb.ListBansRequest) (*pb.Bans, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  b := a.bans
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  return &pb.Bans{Bans: slices.Clone(b.bans)}, nil
+}
+
+// add bans the identity or the address of the ban. Banning them again returns the ban they already have.
+func (b *banList) add(ban *pb.Ban) (*pb.Ban, error) {
+  switch {
+  case (ban.Identity == "") == (ban.Ip == ""):
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "give either an Identity or an Ip")
+  case ban.Identity == anonymousIdentity:
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "anonymous callers can only be banned by Ip")
+  case isAdmin(ban.Identity):
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "admins can't be banned")
+  }
+
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  for _, existing := range b.bans {
+    if existing.Identity == ban.Identity && existing.Ip == ban.Ip {
+      return existing, nil
+    }
+  }
+
+  ban.Id = store.NewID()
+  ban.CreatedAt = serverClock.Now().UTC().Format(time.RFC3339)
+  bans := append(slices.Clone(b.bans), ban)
+  if err := b.save(bans); err != nil {
+    return nil, err
+  }
+  b.bans = bans
+
+  return ban, nil
+}
+
+// banned tells whether the identity, or the address a call comes from, is banned.
+func (b *banList) banned(identity, peer string) bool {
+  ip := peerIP(peer)
+
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  for _, ban := range b.bans {
+    if (ban.Identity != "" && ban.Identity == identity) || (ban.Ip != "" && ban.Ip == ip) {
+      return true
+    }
+  }
+
+  return false
+}
+
+// bannedAuthors returns the banned identities, the ones -hide-banned leaves the posts of out.
+func (b *banList) bannedAuthors() map[string]bool {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  authors := make(map[string]bool)
+  for _, ban := range b.bans {
+    if ban.Identity != "" {
+      authors[ban.Identity] = true
+    }
+  }
+
+  return authors
+}
+
+// check turns the call away when its caller is banned.
+func (b *banList) check(ctx context.Context) error {
+  identity := reqctx.Identity(ctx)
+  if isAdmin(identity) || !b.banned(identity, reqctx.Peer(ctx)) {
+    return nil
+  }
+
+  return status.Errorf(codes.PermissionDenied, "you are banned from making changes on this blog")
+}
+
+func (b *banList) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  if auditedMethods[info.FullMethod] {
+    if err := b.check(ctx); err != nil {
+      return nil, err
+    }
+  }
+
+  return handler(ctx, req)
+}
+
+// Of the streams only the ones the client sends on upload something, UploadAttachment.
+func (b *banList) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  if info.IsClientStream {
+    if err := b.check(ss.Context()); err != nil {
+      return err
+    }
+  }
+
+  return handler(srv, ss)
+}
+
+func (b *banList) save(bans []*pb.Ban) error {
+  if err := replaceFile(b.path, bans); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save bans: %w", err)
+  }
+
+  return nil
+}
+
+// dropAuthors returns the posts without the ones created by the authors.
+func dropAuthors(posts *pb.Posts, authors map[string]bool) *pb.Posts {
+  if len(authors) == 0 {
+    return posts
+  }
+
+  kept := &pb.Posts{Posts: make([]*pb.Post, 0, len(posts.Posts))}
+  for _, post := range posts.Posts {
+    if !authors[post.CreatedBy] {
+      kept.Posts = append(kept.Posts, post)
+    }
+  }
+
+  return kept
+}
+
+// peerIP returns the IP address of a peer like 127.0.0.1:53210, as the bans keep it.
+func peerIP(peer string) string {
+  host, _, err := net.SplitHostPort(peer)
+  if err != nil {
+    host = peer
+  }
+  ip, err := netip.ParseAddr(host)
+  if err != nil {
+    return host
+  }
+
+  return ip.Unmap().String()
+}
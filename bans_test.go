@@ -0,0 +1,41 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/reqctx"
+  "net"
+  "path/filepath"
+  "testing"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/peer"
+  "google.golang.org/grpc/status"
+)
+
+func TestBannedAddressCantSubscribe(t *testing.T) {
+  bans, err := newBanList(filepath.Join(t.TempDir(), "bans.json"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  bans.bans = []*pb.Ban{{Id: "b1", Ip: "203.0.113.7", Reason: "spam"}}
+
+  // call goes through the interceptors of the server, from the banned address.
+  call := func(method string, req any) error {
+    ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 51234}})
+    _, err := reqctx.UnaryServerInterceptor(nil)(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+      return bans.unaryInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(context.Context, any) (any, error) {
+        return nil, nil
+      })
+    })
+    return err
+  }
+
+  if err := call(pb.Blog_SubscribeByEmail_FullMethodName, &pb.SubscribeByEmailRequest{Email: "spam@example.com"}); status.Code(err) != codes.PermissionDenied {
+    t.Errorf("got %v subscribing from a banned address, want PermissionDenied", err)
+  }
+  if err := call(pb.Blog_GetPosts_FullMethodName, &pb.GetPostsRequest{}); err != nil {
+    t.Errorf("got %v reading from a banned address, want reading to stay open", err)
+  }
+}
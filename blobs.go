@@ -0,0 +1,188 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "io"
+  "io/fs"
+  "time"
+
+  "github.com/minio/minio-go/v7"
+  "github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+/*
+  OBJECT STORAGE
+
+  A disk only belongs to one machine. With -attachments-backend s3 the attachments go to a bucket instead, where any number of servers can reach them:
+
+    AWS_ACCESS_KEY_ID=... AWS_SECRET_ACCESS_KEY=... go run . -attachments-backend s3 -s3-bucket my-blog -s3-region eu-west-1
+
+  The client speaks the S3 API, which is not only Amazon's: MinIO, Cloudflare R2 and Google Cloud Storage (whose XML API accepts S3 requests signed with HMAC keys) all understand it, so -s3-endpoint is the only thing that changes:
+
+    go run . -attachments-backend s3 -s3-endpoint storage.googleapis.com -s3-bucket my-blog         # GCS
+    go run . -attachments-backend s3 -s3-endpoint localhost:9000 -s3-insecure -s3-bucket my-blog    # a local MinIO
+
+  Uploads are streamed: the bytes coming in through UploadAttachment go through an io.Pipe into a multipart upload, which sends the object to the bucket in parts of 5MB, so the server never holds a whole attachment in memory.
+
+  A bucket can also hand out signed URLs: links anyone can download an object with until they expire, verified by the bucket itself with a signature made from our credentials. GetAttachmentURL returns one, so big downloads don't have to go through the server at all.
+
+  bucketStore works with plain object keys rather than posts and attachments, attachments are kept under attachments/<post id>/<attachment id>. This leaves room for anything else worth keeping in the bucket, like exported archives, under a prefix of its own.
+*/
+type bucketStore struct {
+  client *minio.Client
+  bucket string
+}
+
+func newBucketStore(endpoint, bucket, region string, secure bool) (*bucketStore, error) {
+  if bucket == "" {
+    return nil, fmt.Errorf("-s3-bucket is required with -attachments-backend s3")
+  }
+
+  // Credentials are looked up like the AWS tools do: environment variables first, then ~/.aws/credentials, then the role of the machine we run on.
+  creds := credentials.NewChainCredentials([]credentials.Provider{
+    &credentials.EnvAWS{},
+    &credentials.FileAWSCredentials{},
+    &credentials.IAM{},
+  })
+
+  client, err := minio.New(endpoint, &minio.Options{Creds: creds, Secure: secure, Region: region})
+  if err != nil {
+    return nil, err
+  }
+
+  return &bucketStore{client: client, bucket: bucket}, nil
+}
+
+// Create starts a streaming upload. The object only exists once Close returns without an error.
+func (b *bucketStore) Create(key string) (io.WriteCloser, error) {
+  pr, pw := io.Pipe()
+  w := &bucketWriter{pw: pw, done: make(chan error, 1)}
+
+  go func() {
+    // A size of -1 tells the client it doesn't know how much is coming, so it uploads in parts until the pipe is closed.
+    _, err := b.client.PutObject(context.Background(), b.bucket, key, pr, -1, minio.PutObjectOptions{PartSize: 5 * 1024 * 1024})
+    pr.CloseWithError(err)
+    w.done <- err
+  }()
+
+  return w, nil
+}
+
+type bucketWriter struct {
+  pw   *io.PipeWriter
+  done chan error
+}
+
+func (w *bucketWriter) Write(p []byte) (int, error) {
+  return w.pw.Write(p)
+}
+
+func (w *bucketWriter) Close() error {
+  w.pw.Close()
+  return <-w.done
+}
+
+// Open returns fs.ErrNotExist for missing objects, like os.Open does for missing files.
+func (b *bucketStore) Open(key string) (io.ReadCloser, error) {
+  obj, err := b.client.GetObject(context.Background(), b.bucket, key, minio.GetObjectOptions{})
+  if err != nil {
+    return nil, err
+  }
+
+  // GetObject doesn't talk to the bucket until the first read, Stat makes sure the object is there.
+  if _, err := obj.Stat(); err != nil {
+    obj.Close()
+    if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+      return nil, fs.ErrNotExist
+    }
+    return nil, err
+  }
+
+  return obj, nil
+}
+
+func (b *bucketStore) Delete(key string) error {
+  return b.client.RemoveObject(context.Background(), b.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (b *bucketStore) SignedURL(key string, ttl time.Duration) (string, error) {
+  u, err := b.client.PresignedGetObject(context.Background(), b.bucket, key, ttl, nil)
+  if err != nil {
+    return "", err
+  }
+
+  return u.String(), nil
+}
+
+// bucketAttachmentStore is the attachmentStore on top of a bucket.
+type bucketAttachmentStore struct {
+  bucket *bucketStore
+}
+
+func attachmentKey(postID, attachmentID string) string {
+  return "attachments/" + postID + "/" + attachmentID
+}
+
+func (s *bucketAttachmentStore) Create(postID, attachmentID string) (io.WriteCloser, error) {
+  return s.bucket.Create(attachmentKey(postID, attachmentID))
+}
+
+func (s *bucketAttachmentStore) Open(postID, attachmentID string) (io.ReadCloser, error) {
+  return s.bucket.Open(attachmentKey(postID, attachmentID))
+}
+
+func (s *bucketAttachmentStore) Delete(postID, attachmentID string) error {
+  return s.bucket.Delete(attachmentKey(postID, attachmentID))
+}
+
+func (s *bucketAttachmentStore) SignedURL(postID, attachmentID string, ttl time.Duration) (string, error) {
+  return s.bucket.SignedURL(attachmentKey(postID, attachmentID), ttl)
+}
+
+// attachmentURLSigner is implemented by the attachment stores that can hand out download links, the disk doesn't.
+type attachmentURLSigner interface {
+  SignedURL(postID, attachmentID string, ttl time.Duration) (string, error)
+}
+
+func (s *server) GetAttachmentURL(_ context.Context, req *pb.GetAttachmentURLRequest) (*pb.AttachmentURL, error) {
+  signer, ok := s.attachments.(attachmentURLSigner)
+  if !ok {
+    return nil, apperr.Errorf(apperr.ErrFeatureDisabled, "attachment URLs need the attachments in a bucket, start the server with -attachments-backend s3")
+  }
+
+  ttl := 15 * time.Minute
+  if req.GetExpiresInSeconds() != 0 {
+    ttl = time.Duration(req.GetExpiresInSeconds()) * time.Second
+  }
+  // S3 refuses signatures valid for more than 7 days.
+  if ttl < time.Second || ttl > 7*24*time.Hour {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "ExpiresInSeconds must be between 1 second and 7 days")
+  }
+
+  posts := &pb.Posts{Posts: make([]*pb.Post, 0)}
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+  if err != nil {
+    return nil, err
+  }
+
+  post, attachment, err := findAttachment(posts, req.GetPostId(), req.GetAttachmentId())
+  if err != nil {
+    return nil, err
+  }
+
+  u, err := signer.SignedURL(post.Id, attachment.Id, ttl)
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to sign attachment URL: %w", err)
+  }
+
+  return &pb.AttachmentURL{
+    Url:       u,
+    ExpiresAt: time.Now().Add(ttl).UTC().Format(time.RFC3339),
+  }, nil
+}
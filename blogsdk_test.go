@@ -0,0 +1,142 @@
+package main
+
+import (
+  "context"
+  "errors"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen/blogsdk"
+  "go/tutorial/grpc/internal/reqctx"
+  "net"
+  "sync/atomic"
+  "testing"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/credentials/insecure"
+  "google.golang.org/grpc/status"
+  "google.golang.org/grpc/test/bufconn"
+)
+
+const testAdminToken = "secret"
+
+// startAuthServer serves the blog behind the identity interceptor of the real server, with testAdminToken as the admin token, and returns a connection to it.
+func startAuthServer(t *testing.T) *grpc.ClientConn {
+  t.Helper()
+
+  previous := postStore
+  postStore = &memoryStore{}
+  t.Cleanup(func() { postStore = previous })
+
+  auth := newAuthenticator(staticSecret(testAdminToken), nil, nil)
+  lis := bufconn.Listen(1 << 20)
+  srv := grpc.NewServer(
+    grpc.ChainUnaryInterceptor(reqctx.UnaryServerInterceptor(auth.identity)),
+    grpc.ChainStreamInterceptor(reqctx.StreamServerInterceptor(auth.identity)),
+  )
+  pb.RegisterBlogServer(srv, &server{auth: auth})
+  go srv.Serve(lis)
+  t.Cleanup(srv.Stop)
+
+  conn, err := grpc.NewClient("passthrough:///bufconn",
+    grpc.WithTransportCredentials(insecure.NewCredentials()),
+    grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+  )
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Cleanup(func() { conn.Close() })
+
+  return conn
+}
+
+// adminCall makes a call only the admin may make.
+func adminCall(ctx context.Context, client *blogsdk.Client) error {
+  _, err := client.Stub().GetPublishingSchedule(ctx, &pb.GetPublishingScheduleRequest{})
+  return err
+}
+
+// countingSource hands out the tokens in order, the last one over and over, and counts the calls.
+func countingSource(calls *atomic.Int32, tokens ...blogsdk.Token) blogsdk.TokenSource {
+  return func(context.Context) (blogsdk.Token, error) {
+    n := int(calls.Add(1))
+    return tokens[min(n, len(tokens))-1], nil
+  }
+}
+
+func TestClientToken(t *testing.T) {
+  conn := startAuthServer(t)
+  ctx := context.Background()
+
+  if err := adminCall(ctx, blogsdk.New(conn)); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("without a token: got %v, want Unauthenticated", err)
+  }
+  if err := adminCall(ctx, blogsdk.New(conn, blogsdk.WithToken("wrong"))); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("with a wrong token: got %v, want Unauthenticated", err)
+  }
+  if err := adminCall(ctx, blogsdk.New(conn, blogsdk.WithToken(testAdminToken))); err != nil {
+    t.Errorf("with the admin token: %v", err)
+  }
+  // The token of the call wins over the one of the client.
+  if err := adminCall(blogsdk.WithAdminToken(ctx, testAdminToken), blogsdk.New(conn, blogsdk.WithToken("wrong"))); err != nil {
+    t.Errorf("with the admin token for the call: %v", err)
+  }
+}
+
+func TestTokenSourceRefresh(t *testing.T) {
+  conn := startAuthServer(t)
+  ctx := context.Background()
+
+  t.Run("kept until it expires", func(t *testing.T) {
+    var calls atomic.Int32
+    client := blogsdk.New(conn, blogsdk.WithTokenSource(countingSource(&calls, blogsdk.Token{Value: testAdminToken, Expiry: time.Now().Add(time.Hour)})))
+    for range 3 {
+      if err := adminCall(ctx, client); err != nil {
+        t.Fatalf("admin call: %v", err)
+      }
+    }
+    if n := calls.Load(); n != 1 {
+      t.Errorf("the source was called %d times, want 1", n)
+    }
+  })
+
+  t.Run("refreshed when about to expire", func(t *testing.T) {
+    var calls atomic.Int32
+    client := blogsdk.New(conn, blogsdk.WithTokenSource(countingSource(&calls, blogsdk.Token{Value: testAdminToken, Expiry: time.Now().Add(time.Second)})))
+    for range 3 {
+      if err := adminCall(ctx, client); err != nil {
+        t.Fatalf("admin call: %v", err)
+      }
+    }
+    if n := calls.Load(); n != 3 {
+      t.Errorf("the source was called %d times, want 3", n)
+    }
+  })
+
+  t.Run("refreshed when refused", func(t *testing.T) {
+    var calls atomic.Int32
+    client := blogsdk.New(conn, blogsdk.WithTokenSource(countingSource(&calls, blogsdk.Token{Value: "revoked"}, blogsdk.Token{Value: testAdminToken})))
+    if err := adminCall(ctx, client); err != nil {
+      t.Fatalf("admin call: %v", err)
+    }
+    if n := calls.Load(); n != 2 {
+      t.Errorf("the source was called %d times, want 2", n)
+    }
+  })
+
+  t.Run("fixed token not asked again", func(t *testing.T) {
+    // WithToken has nothing else to give, the refused call fails right away.
+    if err := adminCall(ctx, blogsdk.New(conn, blogsdk.WithToken("revoked"))); status.Code(err) != codes.Unauthenticated {
+      t.Errorf("got %v, want Unauthenticated", err)
+    }
+  })
+
+  t.Run("error of the source", func(t *testing.T) {
+    client := blogsdk.New(conn, blogsdk.WithTokenSource(func(context.Context) (blogsdk.Token, error) {
+      return blogsdk.Token{}, errors.New("login service down")
+    }))
+    if err := adminCall(ctx, client); status.Code(err) != codes.Unauthenticated {
+      t.Errorf("got %v, want Unauthenticated", err)
+    }
+  })
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+)
+
+/*
+  BULK OPERATIONS
+
+  DeletePosts and ArchivePosts act on every post matching a filter, the same filter GetPosts takes (see filter.go), instead of one post at a time:
+
+    go run ./client bulk archive -token secret -author alice -until 2024-12-31 -dry-run
+    go run ./client bulk delete -token secret -tags spam
+
+  Both load the posts once, change every match and save once, so with the SQLite storage the whole operation is a single transaction: either every post changes or none does. With DryRun nothing is changed, the response lists the posts that would be, which is worth doing before deleting anything.
+
+  Deleting works like DeletePost, the posts become tombstones. Archiving hides published and scheduled posts from readers but keeps everything but their pin, with the status ARCHIVED. An empty filter is refused rather than taken as "every post".
+*/
+func (s *server) DeletePosts(ctx context.Context, req *pb.BulkPostsRequest) (*pb.BulkPostsResponse, error) {
+  return s.bulkPosts(ctx, req, func(post *pb.Post) bool {
+    return post.Status != pb.PostStatus_DELETED
+  }, func(posts *pb.Posts, matches []*pb.Post) (func(), error) {
+    deleted, err := s.removePosts(posts, matches)
+    if err != nil {
+      return nil, err
+    }
+
+    return func() { s.publishDeleted(deleted) }, nil
+  })
+}
+
+func (s *server) ArchivePosts(ctx context.Context, req *pb.BulkPostsRequest) (*pb.BulkPostsResponse, error) {
+  return s.bulkPosts(ctx, req, func(post *pb.Post) bool {
+    return post.Status == pb.PostStatus_PUBLISHED || post.Status == pb.PostStatus_SCHEDULED
+  }, func(posts *pb.Posts, matches []*pb.Post) (func(), error) {
+    return s.archive(posts, matches), nil
+  })
+}
+
+// archive archives the matches among the posts and returns what to do once they are saved. Reports hide posts this way too, see reports.go
+func (s *server) archive(posts *pb.Posts, matches []*pb.Post) func() {
+  // Readers only heard about the published ones, see watch.go
+  var archived []*pb.Post
+  sequence := nextSequence(posts)
+  for _, post := range matches {
+    if post.Status == pb.PostStatus_PUBLISHED {
+      archived = append(archived, post)
+    }
+    post.Status = pb.PostStatus_ARCHIVED
+    // Only published posts stay pinned, see pins.go
+    post.Pinned = false
+    post.PinPosition = 0
+    post.Sequence = sequence
+    sequence++
+  }
+
+  return func() {
+    for _, post := range archived {
+      s.broker.publish(pb.PostEventType_POST_ARCHIVED, post)
+    }
+  }
+}
+
+// bulkPosts runs change on the posts matching the filter of the request that eligible accepts, then saves them. Change returns what to do once the posts are saved.
+func (s *server) bulkPosts(ctx context.Context, req *pb.BulkPostsRequest, eligible func(*pb.Post) bool, change func(*pb.Posts, []*pb.Post) (func(), error)) (*pb.BulkPostsResponse, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  filter, err := parseFilter(req.GetFilter())
+  if err != nil {
+    return nil, err
+  }
+  if filter.empty() {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Filter can't be empty, set at least one of Authors, Since, Until or Tags")
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return nil, err
+  }
+
+  res := &pb.BulkPostsResponse{Ids: make([]string, 0), DryRun: req.GetDryRun()}
+  var matches []*pb.Post
+  for _, post := range posts.Posts {
+    if eligible(post) && filter.match(post) {
+      matches = append(matches, post)
+      res.Ids = append(res.Ids, post.Id)
+    }
+  }
+  res.Count = int32(len(matches))
+
+  if req.GetDryRun() || len(matches) == 0 {
+    return res, nil
+  }
+
+  saved, err := change(posts, matches)
+  if err != nil {
+    return nil, err
+  }
+
+  if err := savePosts(posts); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+  saved()
+
+  return res, nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "strings"
+  "time"
+
+  "github.com/nats-io/nats.go"
+  "github.com/segmentio/kafka-go"
+)
+
+/*
+  EVENT BUS
+
+  Webhooks work well for a handful of receivers, but when many services want to follow the blog it is easier to hand the events to a message bus once and let every service consume them at its own pace. With -event-bus the server publishes every post event (the same PostEvent JSON the webhooks get) to Kafka or NATS:
+
+    go run . -event-bus kafka -event-bus-url localhost:9092 -event-bus-topic blog.posts
+    go run . -event-bus nats -event-bus-url nats://localhost:4222 -event-bus-topic blog.posts
+
+  The two buses name things differently, so each adapter maps the events its own way:
+    - Kafka: every event goes to the topic, keyed by the post ID. Kafka keeps the messages with the same key in order, so consumers see the changes of a post in the order they happened.
+    - NATS: every event type gets its own subject under the topic (blog.posts.created, blog.posts.published, ...), so a consumer can subscribe to blog.posts.published only, or to blog.posts.> for everything.
+  Both also put the event type in a Blog-Event header.
+
+  Like the webhooks and the emails, the publisher is a background job subscribed to the broker (see watch.go), so a slow or unreachable bus never holds up an RPC. Events that can't be published are logged and dropped.
+*/
+type eventBus interface {
+  Publish(ctx context.Context, event *pb.PostEvent, payload []byte) error
+  Close() error
+}
+
+// newEventBus returns nil when kind is empty, no events are published then.
+func newEventBus(kind, url, topic string) (eventBus, error) {
+  switch kind {
+  case "":
+    return nil, nil
+  case "kafka":
+    return &kafkaBus{writer: &kafka.Writer{
+      Addr:  kafka.TCP(strings.Split(url, ",")...),
+      Topic: topic,
+      // The hash balancer sends every key to the same partition, which is what keeps the events of a post in order.
+      Balancer:               &kafka.Hash{},
+      AllowAutoTopicCreation: true,
+    }}, nil
+  case "nats":
+    // nats.Connect keeps reconnecting on its own when the connection drops.
+    conn, err := nats.Connect(url, nats.Name("blog"), nats.MaxReconnects(-1))
+    if err != nil {
+      return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+    }
+    return &natsBus{conn: conn, topic: topic}, nil
+  default:
+    return nil, fmt.Errorf("unknown event bus %q, expected kafka or nats", kind)
+  }
+}
+
+type kafkaBus struct {
+  writer *kafka.Writer
+}
+
+func (b *kafkaBus) Publish(ctx context.Context, event *pb.PostEvent, payload []byte) error {
+  return b.writer.WriteMessages(ctx, kafka.Message{
+    Key:     []byte(event.GetPost().GetId()),
+    Value:   payload,
+    Headers: []kafka.Header{{Key: "Blog-Event", Value: []byte(event.Type.String())}},
+  })
+}
+
+func (b *kafkaBus) Close() error {
+  return b.writer.Close()
+}
+
+type natsBus struct {
+  conn  *nats.Conn
+  topic string
+}
+
+func (b *natsBus) Publish(_ context.Context, event *pb.PostEvent, payload []byte) error {
+  // POST_PUBLISHED -> blog.posts.published
+  msg := nats.NewMsg(b.topic + "." + strings.ToLower(strings.TrimPrefix(event.Type.String(), "POST_")))
+  msg.Header.Set("Blog-Event", event.Type.String())
+  msg.Data = payload
+
+  return b.conn.PublishMsg(msg)
+}
+
+func (b *natsBus) Close() error {
+  // Drain sends whatever is still buffered before closing.
+  return b.conn.Drain()
+}
+
+// publishEvents is the background job that forwards every post event to the bus.
+func publishEvents(broker *postBroker, bus eventBus) func(context.Context) error {
+  return func(ctx context.Context) error {
+    events, unsubscribe := broker.subscribe(&pb.WatchPostsRequest{})
+    defer unsubscribe()
+
+    for {
+      select {
+      case <-ctx.Done():
+        return nil
+      case event, ok := <-events:
+        if !ok {
+          return nil
+        }
+
+        payload, err := marshalPostEvent(event)
+        if err != nil {
+          log.Printf("event bus: failed to encode event: %v", err)
+          continue
+        }
+
+        publishCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+        if err := bus.Publish(publishCtx, event, payload); err != nil {
+          log.Printf("event bus: failed to publish %s of post %s: %v", event.Type, event.GetPost().GetId(), err)
+        }
+        cancel()
+      }
+    }
+  }
+}
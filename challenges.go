@@ -0,0 +1,152 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/pow"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/store"
+  "strconv"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  CHALLENGES
+
+  A blog open to anonymous posts is open to spam bots too. Moderation (see moderation.go) catches some of what they post, bans (see bans.go) keep out the ones already caught, neither makes posting a thousand times cost more than posting once. A proof of work does: with -challenge-bits every anonymous CreatePost, readers with a session included, comes with a puzzle solved beforehand (see internal/pow).
+
+    go run . -challenge-bits 20
+
+  The client asks for a challenge with GetChallenge, finds its nonce and sends both in the x-challenge metadata of CreatePost:
+
+    x-challenge: <token>:<nonce>
+
+  blogctl does all of it on its own (see challenge.go in the client). Admins and the users of an OIDC provider are known, they never get a challenge. Without -challenge-bits GetChallenge answers with an empty Token and CreatePost asks for nothing.
+
+  Like a session token a challenge is signed with session.key, <random>.<expiry>.<bits>.<signature>, so the server doesn't keep the challenges it hands out, only the ones solved: a challenge lasts challengeTTL and is accepted once, otherwise a bot would solve one and post with it forever. The solved ones are forgotten once they expire.
+*/
+const (
+  challengeHeader  = "x-challenge"
+  challengeTTL     = 5 * time.Minute
+  maxChallengeBits = 32
+)
+
+type challenges struct {
+  bits int
+  // Signs the challenges with the key of the session tokens.
+  sessions *sessions
+
+  mu sync.Mutex
+  // used[token] is when a solved challenge expires.
+  used map[string]time.Time
+}
+
+func newChallenges(bits int, sessions *sessions) (*challenges, error) {
+  if bits < 0 || bits > maxChallengeBits {
+    return nil, fmt.Errorf("-challenge-bits must be between 0 and %d", maxChallengeBits)
+  }
+
+  return &challenges{bits: bits, sessions: sessions, used: make(map[string]time.Time)}, nil
+}
+
+func (s *server) GetChallenge(_ context.Context, _ *pb.GetChallengeRequest) (*pb.Challenge, error) {
+  return s.challenges.issue(), nil
+}
+
+func (c *challenges) issue() *pb.Challenge {
+  if c == nil || c.bits == 0 {
+    return &pb.Challenge{}
+  }
+
+  expiresAt := serverClock.Now().Add(challengeTTL).UTC().Truncate(time.Second)
+  payload := store.NewID() + "." + strconv.FormatInt(expiresAt.Unix(), 10) + "." + strconv.Itoa(c.bits)
+
+  return &pb.Challenge{Token: payload + "." + c.sign(payload), Bits: int32(c.bits), ExpiresAt: expiresAt.Format(time.RFC3339)}
+}
+
+// The prefix keeps a challenge from ever passing for a session token, and the other way around.
+func (c *challenges) sign(payload string) string {
+  return c.sessions.sign("challenge." + payload)
+}
+
+// verify checks the solved challenge an anonymous caller sent with CreatePost. A nil challenges, like one without -challenge-bits, checks nothing.
+func (c *challenges) verify(ctx context.Context) error {
+  if c == nil || c.bits == 0 {
+    return nil
+  }
+  identity := reqctx.Identity(ctx)
+  if identity != anonymousIdentity && !strings.HasPrefix(identity, sessionIdentity+":") {
+    return nil
+  }
+
+  md, _ := metadata.FromIncomingContext(ctx)
+  values := md.Get(challengeHeader)
+  if len(values) == 0 {
+    return status.Errorf(codes.PermissionDenied, "CreatePost needs a solved challenge in the %s metadata, see GetChallenge", challengeHeader)
+  }
+  token, nonceText, _ := strings.Cut(values[0], ":")
+  nonce, err := strconv.ParseUint(nonceText, 10, 64)
+  if err != nil {
+    return status.Errorf(codes.PermissionDenied, "%s must be <token>:<nonce>", challengeHeader)
+  }
+
+  expiresAt, bits, ok := c.parse(token)
+  if !ok || serverClock.Now().After(expiresAt) {
+    return status.Errorf(codes.PermissionDenied, "the challenge is invalid or expired, get a new one with GetChallenge")
+  }
+  // A challenge handed out before -challenge-bits was raised is too easy now.
+  if bits < c.bits || !pow.Check(token, nonce, bits) {
+    return status.Errorf(codes.PermissionDenied, "the nonce doesn't solve the challenge")
+  }
+
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  now := serverClock.Now()
+  for used, expiry := range c.used {
+    if now.After(expiry) {
+      delete(c.used, used)
+    }
+  }
+  if _, ok := c.used[token]; ok {
+    return status.Errorf(codes.PermissionDenied, "the challenge was used already, get a new one with GetChallenge")
+  }
+  c.used[token] = expiresAt
+
+  return nil
+}
+
+// parse returns the expiry and the difficulty of a challenge, false when it isn't one of ours.
+func (c *challenges) parse(token string) (time.Time, int, bool) {
+  i := strings.LastIndex(token, ".")
+  if i < 0 {
+    return time.Time{}, 0, false
+  }
+  payload, signature := token[:i], token[i+1:]
+  // Challenges handed out before a rotation of the session key stay valid too.
+  if !c.sessions.signedBy("challenge."+payload, signature) {
+    return time.Time{}, 0, false
+  }
+
+  parts := strings.Split(payload, ".")
+  if len(parts) != 3 {
+    return time.Time{}, 0, false
+  }
+  unix, err := strconv.ParseInt(parts[1], 10, 64)
+  if err != nil {
+    return time.Time{}, 0, false
+  }
+  bits, err := strconv.Atoi(parts[2])
+  if err != nil {
+    return time.Time{}, 0, false
+  }
+
+  return time.Unix(unix, 0), bits, true
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/reqctx"
+  "log"
+  "math/rand/v2"
+  "strconv"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  FAULT INJECTION
+
+  Retries and backoff are only seen at work when calls fail, and a server on localhost hardly ever fails. -chaos makes it fail on purpose, to watch how the clients cope:
+
+    go run . -chaos latency=50ms-500ms,unavailable=0.2,truncate=0.3
+
+    latency=200ms       every call waits that long before running, latency=50ms-500ms a random time in between
+    unavailable=0.2     a fifth of the calls fail with Unavailable before running
+    truncate=0.3        three out of ten server streams (StreamPosts, WatchPosts, DownloadAttachment...) fail with Unavailable after a few messages
+
+  A call failing before it ran looks like one to a server that restarts: the SDK tries the reads again after a growing delay and gives up on CreatePost, which may have gone through (see gen/blogsdk/retry.go). A stream cut short is a connection dropping in the middle, ListAll resumes from the cursor of the last post it got and WatchPosts from its last event. The latency shows how timeouts behave, the client's and the server's (see timeouts.go).
+
+  Only the Blog service is concerned. The Admin RPCs, the health checks and reflection keep working, so the faults can be turned off without a restart: chaos is one of the settings of a reload, empty for no faults (see config.go). The injected failures say so in their message and in the log, nobody should go looking for a bug that isn't there.
+
+  It is a tool for testing clients, not for a server anyone depends on.
+*/
+const (
+  // A truncated stream sends between 1 and chaosMaxMessages messages before failing.
+  chaosMaxMessages = 10
+  chaosMessage     = "injected by -chaos"
+)
+
+type chaosFaults struct {
+  minLatency, maxLatency time.Duration
+  // unavailable and truncate are the shares of the calls and the streams that fail, between 0 and 1.
+  unavailable, truncate float64
+}
+
+func (f chaosFaults) enabled() bool {
+  return f.maxLatency > 0 || f.unavailable > 0 || f.truncate > 0
+}
+
+// parseChaos reads faults written like "latency=50ms-500ms,unavailable=0.2,truncate=0.3", empty for none.
+func parseChaos(spec string) (chaosFaults, error) {
+  var faults chaosFaults
+  for _, entry := range splitList(spec) {
+    key, value, ok := strings.Cut(entry, "=")
+    if !ok {
+      return chaosFaults{}, fmt.Errorf("invalid fault %q in -chaos, expected latency=, unavailable= or truncate=", entry)
+    }
+
+    switch key {
+    case "latency":
+      low, high, isRange := strings.Cut(value, "-")
+      min, err := time.ParseDuration(low)
+      max := min
+      if err == nil && isRange {
+        max, err = time.ParseDuration(high)
+      }
+      if err != nil || min < 0 || max < min {
+        return chaosFaults{}, fmt.Errorf("invalid latency %q in -chaos, expected a duration like 200ms or a range like 50ms-500ms", value)
+      }
+      faults.minLatency, faults.maxLatency = min, max
+    case "unavailable", "truncate":
+      share, err := strconv.ParseFloat(value, 64)
+      if err != nil || share < 0 || share > 1 {
+        return chaosFaults{}, fmt.Errorf("invalid %s %q in -chaos, expected a share of the calls between 0 and 1", key, value)
+      }
+      if key == "unavailable" {
+        faults.unavailable = share
+      } else {
+        faults.truncate = share
+      }
+    default:
+      return chaosFaults{}, fmt.Errorf("unknown fault %q in -chaos, expected latency, unavailable or truncate", key)
+    }
+  }
+
+  return faults, nil
+}
+
+// chaosInjector holds the faults the interceptors inject, which can be replaced while the server runs (see config.go).
+type chaosInjector struct {
+  mu     sync.RWMutex
+  faults chaosFaults
+}
+
+func newChaosInjector(faults chaosFaults) *chaosInjector {
+  return &chaosInjector{faults: faults}
+}
+
+func (c *chaosInjector) set(faults chaosFaults) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  c.faults = faults
+}
+
+// lookup returns the faults of the method, false when it gets none.
+func (c *chaosInjector) lookup(fullMethod string) (chaosFaults, bool) {
+  c.mu.RLock()
+  faults := c.faults
+  c.mu.RUnlock()
+
+  if !faults.enabled() || !strings.HasPrefix(fullMethod, "/"+pb.Blog_ServiceDesc.ServiceName+"/") {
+    return chaosFaults{}, false
+  }
+
+  return faults, true
+}
+
+// beforeCall waits for the latency and fails the call with the share of unavailable.
+func (c *chaosInjector) beforeCall(ctx context.Context, fullMethod string, faults chaosFaults) error {
+  if latency := faults.minLatency + rand.N(faults.maxLatency-faults.minLatency+1); latency > 0 {
+    timer := time.NewTimer(latency)
+    defer timer.Stop()
+    select {
+    case <-timer.C:
+    case <-ctx.Done():
+      return status.FromContextError(ctx.Err()).Err()
+    }
+  }
+
+  if rand.Float64() < faults.unavailable {
+    log.Printf("chaos: %s (request %s) failed with Unavailable", fullMethod, reqctx.RequestID(ctx))
+    return status.Errorf(codes.Unavailable, "%s: the server is unavailable", chaosMessage)
+  }
+
+  return nil
+}
+
+func (c *chaosInjector) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  faults, ok := c.lookup(info.FullMethod)
+  if !ok {
+    return handler(ctx, req)
+  }
+  if err := c.beforeCall(ctx, info.FullMethod, faults); err != nil {
+    return nil, err
+  }
+
+  return handler(ctx, req)
+}
+
+func (c *chaosInjector) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  faults, ok := c.lookup(info.FullMethod)
+  if !ok {
+    return handler(srv, ss)
+  }
+  if err := c.beforeCall(ss.Context(), info.FullMethod, faults); err != nil {
+    return err
+  }
+  if !info.IsServerStream || rand.Float64() >= faults.truncate {
+    return handler(srv, ss)
+  }
+
+  stream := &truncatedStream{ServerStream: ss, left: 1 + rand.IntN(chaosMaxMessages)}
+  err := handler(srv, stream)
+  if !stream.cut {
+    // The stream ended before its cut.
+    return err
+  }
+  log.Printf("chaos: %s (request %s) cut after %d messages", info.FullMethod, reqctx.RequestID(ss.Context()), stream.sent)
+
+  return status.Errorf(codes.Unavailable, "%s: the stream was cut after %d messages", chaosMessage, stream.sent)
+}
+
+// truncatedStream fails the messages sent after the first left ones, and whatever the handler makes of that, the client gets Unavailable.
+type truncatedStream struct {
+  grpc.ServerStream
+  left, sent int
+  cut        bool
+}
+
+func (s *truncatedStream) SendMsg(m any) error {
+  if s.left == 0 {
+    s.cut = true
+    return status.Error(codes.Unavailable, chaosMessage)
+  }
+  if err := s.ServerStream.SendMsg(m); err != nil {
+    return err
+  }
+  s.left--
+  s.sent++
+
+  return nil
+}
@@ -0,0 +1,619 @@
+package main
+
+import (
+  "context"
+  "errors"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "log"
+  "os"
+  "os/signal"
+  "strings"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  ADMIN SERVICE
+
+  The Admin service lives next to the Blog one on the same server, so the client reaches it over the same connection with pb.NewAdminClient. Like every admin RPC it needs the admin token:
+
+    go run ./client debug-log -token secret              # show the current settings
+    go run ./client debug-log -token secret on
+    go run ./client debug-log -token secret -redact Email,authorization off
+    go run ./client storage-stats -token secret
+    go run ./client maintenance -token secret -reason "moving to SQLite" -wait on
+    go run ./client reload-config -token secret
+    go run ./client flush-storage -token secret
+    go run ./client reencrypt-storage -token secret
+    go run ./client cron list -token secret
+    go run ./client cron run -token secret compact-audit
+    go run ./client bans add -token secret -ip 203.0.113.7 -reason bot
+    go run ./client stats -token secret -interval 2s -methods 3
+    go run ./client export -token secret -dataset views -format parquet -since 2025-06-01 -o views.parquet
+*/
+func setupDebugLog(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  redact := fs.String("redact", "", "comma separated fields and metadata keys to hide from the log, empty keeps the server's list")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if fs.NArg() > 1 || (fs.NArg() == 1 && fs.Arg(0) != "on" && fs.Arg(0) != "off") {
+      log.Fatalf("usage: debug-log -token <admin token> [-redact fields] [on|off]")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    c := pb.NewAdminClient(conn)
+
+    var settings *pb.DebugLogging
+    if fs.NArg() == 0 {
+      settings, err = c.GetDebugLogging(ctx, &pb.GetDebugLoggingRequest{})
+    } else {
+      settings, err = c.SetDebugLogging(ctx, &pb.SetDebugLoggingRequest{Enabled: fs.Arg(0) == "on", Redact: splitList(*redact)})
+    }
+    if err != nil {
+      log.Fatalf("could not get debug logging: %v", err)
+    }
+
+    state := "off"
+    if settings.GetEnabled() {
+      state = "on"
+    }
+    fmt.Printf("Debug logging is %s, redacting %s\n", state, strings.Join(settings.GetRedact(), ", "))
+  }
+}
+
+// storage-stats prints what GetStorageStats says about the posts, see internal/store/compress.go
+func setupStorageStats(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    stats, err := pb.NewAdminClient(conn).GetStorageStats(ctx, &pb.GetStorageStatsRequest{})
+    if err != nil {
+      log.Fatalf("could not get storage stats: %v", err)
+    }
+
+    threshold := "off"
+    if stats.GetCompressThreshold() > 0 {
+      threshold = fmt.Sprintf("from %d bytes", stats.GetCompressThreshold())
+    }
+    fmt.Printf("Storage: %s, %d bytes on disk\n", stats.GetBackend(), stats.GetStorageBytes())
+    fmt.Printf("Posts: %d, %d with compressed content (compression %s)\n", stats.GetPosts(), stats.GetCompressedPosts(), threshold)
+    fmt.Printf("Content: %d bytes, %d stored", stats.GetContentBytes(), stats.GetStoredContentBytes())
+    if stats.GetContentBytes() > 0 {
+      fmt.Printf(" (%.0f%%)", 100*float64(stats.GetStoredContentBytes())/float64(stats.GetContentBytes()))
+    }
+    fmt.Println()
+    if stats.GetEncryptionKey() != "" {
+      fmt.Printf("Encryption: %d posts encrypted, new content with key %s\n", stats.GetEncryptedPosts(), stats.GetEncryptionKey())
+    } else if stats.GetEncryptedPosts() > 0 {
+      fmt.Printf("Encryption: off, %d posts still encrypted\n", stats.GetEncryptedPosts())
+    }
+    if stats.GetPendingSaves() > 0 {
+      fmt.Printf("Pending: %d saves not written yet, see flush-storage\n", stats.GetPendingSaves())
+    }
+  }
+}
+
+// maintenance shows or switches maintenance mode, see maintenance.go in the server. With -wait it only returns once the server is drained.
+func setupMaintenance(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  reason := fs.String("reason", "", "on: why the server is down, shown to the clients")
+  retryAfter := fs.Duration("retry-after", 30*time.Second, "on: when clients are told to try again")
+  wait := fs.Bool("wait", false, "on: wait for the running calls to finish, up to a minute")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if fs.NArg() > 1 || (fs.NArg() == 1 && fs.Arg(0) != "on" && fs.Arg(0) != "off") {
+      log.Fatalf("usage: maintenance -token <admin token> [-reason text] [-retry-after duration] [-wait] [on|off]")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Minute))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    c := pb.NewAdminClient(conn)
+
+    var state *pb.Maintenance
+    if fs.NArg() == 0 {
+      state, err = c.GetMaintenance(ctx, &pb.GetMaintenanceRequest{})
+    } else {
+      state, err = c.SetMaintenance(ctx, &pb.SetMaintenanceRequest{
+        Enabled:           fs.Arg(0) == "on",
+        Reason:            *reason,
+        RetryAfterSeconds: int32(retryAfter.Seconds()),
+        Wait:              *wait,
+      })
+    }
+    if err != nil {
+      log.Fatalf("could not get maintenance mode: %v", err)
+    }
+
+    if !state.GetEnabled() {
+      fmt.Printf("Maintenance is off, %d calls running\n", state.GetInFlight())
+      return
+    }
+    fmt.Printf("Maintenance is on since %s (%s), clients retry after %ds, %d calls still running\n", state.GetSince(), state.GetReason(), state.GetRetryAfterSeconds(), state.GetInFlight())
+  }
+}
+
+// reload-config makes the server read its -config file again, see config.go in the server.
+func setupReloadConfig(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    reload, err := pb.NewAdminClient(conn).ReloadConfig(ctx, &pb.ReloadConfigRequest{})
+    if err != nil {
+      log.Fatalf("could not reload the config: %v", err)
+    }
+
+    if len(reload.GetApplied()) == 0 && len(reload.GetRequiresRestart()) == 0 {
+      fmt.Printf("Nothing changed in %s\n", reload.GetPath())
+      return
+    }
+    fmt.Printf("Reloaded %s\n", reload.GetPath())
+    for _, change := range reload.GetApplied() {
+      fmt.Printf("  %s: %s -> %s\n", change.GetName(), change.GetOld(), change.GetNew())
+    }
+    for _, change := range reload.GetRequiresRestart() {
+      fmt.Printf("  %s: %s -> %s not applied, %s\n", change.GetName(), change.GetOld(), change.GetNew(), change.GetNote())
+    }
+  }
+}
+
+// flush-storage writes the saves the server holds back with -write-delay, see internal/store/batch.go in the server.
+func setupFlushStorage(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(5*time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    flush, err := pb.NewAdminClient(conn).FlushStorage(ctx, &pb.FlushStorageRequest{})
+    if err != nil {
+      log.Fatalf("could not flush the storage: %v", err)
+    }
+
+    if flush.GetSaves() == 0 {
+      fmt.Println("Nothing to write, the storage is up to date")
+      return
+    }
+    fmt.Printf("Wrote %d saves at once\n", flush.GetSaves())
+  }
+}
+
+// reencrypt-storage saves every post again with the current encryption key, see encryption.go in the server.
+func setupReencryptStorage(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(30*time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    reencryption, err := pb.NewAdminClient(conn).ReencryptStorage(ctx, &pb.ReencryptStorageRequest{})
+    if err != nil {
+      log.Fatalf("could not re-encrypt the storage: %v", err)
+    }
+
+    fmt.Printf("%d posts encrypted with key %s, %d of them re-encrypted, and %d revisions\n", reencryption.GetPosts(), reencryption.GetKey(), reencryption.GetReencrypted(), reencryption.GetRevisions())
+  }
+}
+
+// cron lists the recurring tasks of the server or runs one of them, see cron.go in the server.
+func setupCron(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+
+  return func(args []string) {
+    if len(args) == 0 || (args[0] != "list" && args[0] != "run") {
+      log.Fatalf("usage: cron list|run -token <admin token> [task]")
+    }
+
+    fs.Init("cron "+args[0], flag.ExitOnError)
+    fs.Parse(args[1:])
+
+    if args[0] == "run" && fs.NArg() != 1 {
+      log.Fatalf("usage: cron run -token <admin token> <task>")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    // A task run on demand takes as long as it takes, compacting a big audit log included.
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Minute))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    c := pb.NewAdminClient(conn)
+
+    if args[0] == "run" {
+      task, err := c.RunScheduledTask(ctx, &pb.RunScheduledTaskRequest{Name: fs.Arg(0)})
+      if err != nil {
+        log.Fatalf("could not run %s: %v", fs.Arg(0), err)
+      }
+      if task.GetLastError() != "" {
+        log.Fatalf("%s failed after %.1fs: %s", task.GetName(), task.GetLastDurationSeconds(), task.GetLastError())
+      }
+      fmt.Printf("%s done in %.1fs: %s\n", task.GetName(), task.GetLastDurationSeconds(), task.GetLastResult())
+      return
+    }
+
+    tasks, err := c.ListScheduledTasks(ctx, &pb.ListScheduledTasksRequest{})
+    if err != nil {
+      log.Fatalf("could not list the tasks: %v", err)
+    }
+    for _, task := range tasks.GetTasks() {
+      schedule := task.GetSchedule()
+      if schedule == "" {
+        schedule = "on demand only"
+      }
+      fmt.Printf("%s (%s): %s\n", task.GetName(), schedule, task.GetDescription())
+      if task.GetNextRun() != "" {
+        fmt.Printf("  next run: %s\n", task.GetNextRun())
+      }
+      switch {
+      case task.GetRunning():
+        fmt.Println("  running")
+      case task.GetLastError() != "":
+        fmt.Printf("  last run: %s, failed: %s\n", task.GetLastRun(), task.GetLastError())
+      case task.GetLastRun() != "":
+        fmt.Printf("  last run: %s, %s\n", task.GetLastRun(), task.GetLastResult())
+      }
+    }
+  }
+}
+
+// bans manages the identities and addresses that can't change anything on the server, see bans.go in the server.
+func setupBans(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  identity := fs.String("identity", "", "add: identity to ban, like oidc:jane or session:<viewer>")
+  ip := fs.String("ip", "", "add: IP address to ban")
+  reason := fs.String("reason", "", "add: why, kept with the ban")
+  id := fs.String("id", "", "remove: ID of the ban")
+
+  return func(args []string) {
+    if len(args) == 0 {
+      log.Fatalf("usage: bans add|remove|list -token <admin token> [flags]")
+    }
+
+    fs.Init("bans "+args[0], flag.ExitOnError)
+    fs.Parse(args[1:])
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    c := pb.NewAdminClient(conn)
+
+    switch args[0] {
+    case "add":
+      ban, err := c.AddBan(ctx, &pb.AddBanRequest{Identity: *identity, Ip: *ip, Reason: *reason})
+      if err != nil {
+        log.Fatalf("could not add the ban: %v", err)
+      }
+      fmt.Printf("Banned %s%s (%s)\n", ban.GetIdentity(), ban.GetIp(), ban.GetId())
+    case "remove":
+      if _, err := c.RemoveBan(ctx, &pb.RemoveBanRequest{Id: *id}); err != nil {
+        log.Fatalf("could not remove the ban: %v", err)
+      }
+      fmt.Printf("Removed ban %s\n", *id)
+    case "list":
+      bans, err := c.ListBans(ctx, &pb.ListBansRequest{})
+      if err != nil {
+        log.Fatalf("could not list the bans: %v", err)
+      }
+      if len(bans.GetBans()) == 0 {
+        fmt.Println("Nobody is banned")
+        return
+      }
+      for _, ban := range bans.GetBans() {
+        fmt.Printf("%s  %s%s by %s (%s)", ban.GetCreatedAt(), ban.GetIdentity(), ban.GetIp(), ban.GetBannedBy(), ban.GetId())
+        if ban.GetReportId() != "" {
+          fmt.Printf(" for report %s", ban.GetReportId())
+        }
+        fmt.Println()
+        if ban.GetReason() != "" {
+          fmt.Printf("  %s\n", ban.GetReason())
+        }
+      }
+    default:
+      log.Fatalf("unknown bans command %q, expected add, remove or list", args[0])
+    }
+  }
+}
+
+// connections prints the connections open on the server, see connections.go in the server.
+func setupConnections(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  list := fs.Bool("list", false, "list every open connection rather than the totals")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    c := pb.NewAdminClient(conn)
+
+    if *list {
+      conns, err := c.ListConnections(ctx, &pb.ListConnectionsRequest{})
+      if err != nil {
+        log.Fatalf("could not list the connections: %v", err)
+      }
+      for _, conn := range conns.GetConnections() {
+        fmt.Printf("%s via %s, open since %s\n", conn.GetRemoteAddress(), conn.GetLocalAddress(), conn.GetOpenedAt())
+        fmt.Printf("  %d calls, %d running, %d bytes in, %d bytes out", conn.GetCalls(), conn.GetActiveStreams(), conn.GetBytesReceived(), conn.GetBytesSent())
+        if conn.GetLastCallAt() != "" {
+          fmt.Printf(", last call %s", conn.GetLastCallAt())
+        }
+        fmt.Println()
+      }
+      return
+    }
+
+    stats, err := c.GetConnectionStats(ctx, &pb.GetConnectionStatsRequest{})
+    if err != nil {
+      log.Fatalf("could not get the connection stats: %v", err)
+    }
+    fmt.Printf("Connections: %d open, %d calls running, %d accepted and %d closed since the start\n", stats.GetOpenConnections(), stats.GetActiveStreams(), stats.GetAcceptedConnections(), stats.GetClosedConnections())
+    fmt.Printf("Limits per IP: %s connections, %s calls, %d connections and %d calls turned away\n", limitText(stats.GetMaxConnectionsPerIp()), limitText(stats.GetMaxCallsPerIp()), stats.GetRejectedConnections(), stats.GetRejectedCalls())
+    for _, lis := range stats.GetListeners() {
+      fmt.Printf("Listener %s: %d open, %d accepted\n", lis.GetAddress(), lis.GetOpenConnections(), lis.GetAcceptedConnections())
+    }
+    for _, peer := range stats.GetPeers() {
+      fmt.Printf("Peer %s: %d connections, %d calls, %d running, %d bytes in, %d bytes out", peer.GetAddress(), peer.GetOpenConnections(), peer.GetCalls(), peer.GetActiveStreams(), peer.GetBytesReceived(), peer.GetBytesSent())
+      if peer.GetLastCallAt() != "" {
+        fmt.Printf(", last call %s", peer.GetLastCallAt())
+      }
+      fmt.Println()
+    }
+  }
+}
+
+// limitText prints a limit where 0 means none.
+func limitText(limit int32) string {
+  if limit == 0 {
+    return "no limit on"
+  }
+
+  return fmt.Sprint(limit)
+}
+
+// stats prints a line of the live stats of the server every interval, like vmstat does, see serverstats.go in the server.
+func setupStats(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  interval := fs.Duration("interval", 5*time.Second, "time between two reports, in whole seconds")
+  count := fs.Int("n", 0, "number of reports to print, 0 prints them until Ctrl+C")
+  methods := fs.Int("methods", 0, "also print the most called methods of every report, this many of them")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *interval < time.Second || *interval%time.Second != 0 {
+      log.Fatalf("-interval must be a whole number of seconds, got %s", *interval)
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+    defer stop()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    stream, err := pb.NewAdminClient(conn).StreamServerStats(ctx, &pb.StreamServerStatsRequest{IntervalSeconds: int32(*interval / time.Second)})
+    if err != nil {
+      log.Fatalf("could not follow the stats: %v", err)
+    }
+
+    fmt.Printf("%-8s %9s %7s %7s %8s %8s %8s %8s %9s %10s %4s\n", "TIME", "CALLS/S", "ERRORS", "RUNNING", "P50 MS", "P90 MS", "P99 MS", "MAX MS", "HEAP MB", "GOROUTINES", "GC")
+    for i := 0; *count == 0 || i < *count; i++ {
+      stats, err := stream.Recv()
+      if errors.Is(err, io.EOF) || ctx.Err() != nil {
+        return
+      }
+      if err != nil {
+        log.Fatalf("stats stream failed: %v", err)
+      }
+
+      at := stats.GetTime()
+      if t, err := time.Parse(time.RFC3339, at); err == nil {
+        at = t.Local().Format(time.TimeOnly)
+      }
+      fmt.Printf("%-8s %9.1f %7d %7d %8.1f %8.1f %8.1f %8.1f %9.1f %10d %4d\n", at, stats.GetCallsPerSecond(), stats.GetErrors(), stats.GetInFlight(),
+        stats.GetLatencyP50Ms(), stats.GetLatencyP90Ms(), stats.GetLatencyP99Ms(), stats.GetLatencyMaxMs(),
+        float64(stats.GetHeapAllocBytes())/(1<<20), stats.GetGoroutines(), stats.GetGcRuns())
+      for _, method := range stats.GetMethods()[:min(*methods, len(stats.GetMethods()))] {
+        fmt.Printf("  %-30s %6d calls %6d errors %8.1f ms\n", method.GetMethod(), method.GetCalls(), method.GetErrors(), method.GetMeanLatencyMs())
+      }
+    }
+  }
+}
+
+// exportTime turns a date of -since or -until into the RFC 3339 timestamp of its midnight in UTC, a timestamp is kept as is.
+func exportTime(name, value string) string {
+  if value == "" {
+    return ""
+  }
+  if day, err := time.Parse(time.DateOnly, value); err == nil {
+    return day.Format(time.RFC3339)
+  }
+  if _, err := time.Parse(time.RFC3339, value); err != nil {
+    log.Fatalf("-%s must be a date (2025-06-01) or an RFC 3339 timestamp, got %q", name, value)
+  }
+
+  return value
+}
+
+func setupExport(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  dataset := fs.String("dataset", "audit", "what to export, audit or views")
+  format := fs.String("format", "csv", "format of the file, csv or parquet")
+  since := fs.String("since", "", "date or RFC 3339 timestamp of the first events, empty starts at the oldest")
+  until := fs.String("until", "", "date or RFC 3339 timestamp the events stop before, empty goes to now")
+  output := fs.String("o", "", "file to write, empty writes to stdout")
+  bucket := fs.Bool("bucket", false, "write the file to the bucket of the server rather than here")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    req := &pb.ExportEventsRequest{Since: exportTime("since", *since), Until: exportTime("until", *until), ToBucket: *bucket}
+    switch *dataset {
+    case "audit":
+      req.Dataset = pb.ExportDataset_EXPORT_AUDIT_LOG
+    case "views":
+      req.Dataset = pb.ExportDataset_EXPORT_VIEWS
+    default:
+      log.Fatalf("unknown dataset %q, expected audit or views", *dataset)
+    }
+    switch *format {
+    case "csv":
+      req.Format = pb.ExportFormat_EXPORT_CSV
+    case "parquet":
+      req.Format = pb.ExportFormat_EXPORT_PARQUET
+    default:
+      log.Fatalf("unknown format %q, expected csv or parquet", *format)
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+    defer stop()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    stream, err := pb.NewAdminClient(conn).ExportEvents(ctx, req)
+    if err != nil {
+      log.Fatalf("could not export the %s: %v", *dataset, err)
+    }
+
+    // The summary goes where it doesn't mix with the file, with -bucket the file isn't sent.
+    out, summary := io.Writer(os.Stdout), os.Stderr
+    if *bucket {
+      summary = os.Stdout
+    } else if *output != "" {
+      f, err := os.Create(*output)
+      if err != nil {
+        log.Fatalf("failed to create %s: %v", *output, err)
+      }
+      defer f.Close()
+      out, summary = f, os.Stdout
+    }
+
+    for {
+      chunk, err := stream.Recv()
+      if errors.Is(err, io.EOF) {
+        return
+      }
+      if err != nil {
+        if *output != "" {
+          os.Remove(*output)
+        }
+        log.Fatalf("export failed: %v", err)
+      }
+      if _, err := out.Write(chunk.GetData()); err != nil {
+        log.Fatalf("failed to write the export: %v", err)
+      }
+
+      switch {
+      case chunk.GetBucketKey() != "":
+        fmt.Fprintf(summary, "Exported %d rows (%d bytes) to %s in the bucket\n", chunk.GetRows(), chunk.GetBytes(), chunk.GetBucketKey())
+      case chunk.GetRows() > 0 || chunk.GetBytes() > 0:
+        fmt.Fprintf(summary, "Exported %d rows (%d bytes)\n", chunk.GetRows(), chunk.GetBytes())
+      }
+    }
+  }
+}
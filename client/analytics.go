@@ -0,0 +1,218 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "strings"
+  "time"
+)
+
+/*
+  VIEWS, TRENDING POSTS AND ANALYTICS
+
+  view counts a view of a post with RecordView, the way a reader app would when a post is opened. Without -viewer the server counts the view for our identity or address, and views of the same viewer close to each other only count once (see views.go on the server):
+
+    go run ./client view -id <post id> -viewer alice
+
+  The server records when every view happens, the other two commands read it back:
+
+    go run ./client trending -window 1h -n 5
+    go run ./client analytics -id <post id> -since 2025-06-04T00:00:00Z -bucket 15m
+
+  analytics draws the views of every bucket as a bar, scaled to the busiest bucket of the range.
+
+  summary prints the views of a day or a week next to the period before, with the most viewed posts and authors. The server keeps these long after the views themselves:
+
+    go run ./client summary
+    go run ./client summary -date 2025-06-02 -week -n 5
+*/
+func setupView(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post")
+  viewer := fs.String("viewer", "", "ID of the viewer, empty lets the server pick")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *id == "" {
+      log.Fatalf("usage: view -id <post id> [-viewer id]")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    res, err := pb.NewBlogClient(conn).RecordView(ctx, &pb.RecordViewRequest{PostId: *id, ViewerId: *viewer})
+    if err != nil {
+      log.Fatalf("could not record the view: %v", err)
+    }
+
+    if !res.GetCounted() {
+      fmt.Print("Already viewed recently, not counted. ")
+    }
+    fmt.Printf("%d views by %d viewers\n", res.GetViewCount(), res.GetUniqueViewers())
+  }
+}
+
+func setupTrending(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  window := fs.Duration("window", 24*time.Hour, "how far back views are counted")
+  limit := fs.Int("n", 10, "number of posts to show")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    trending, err := pb.NewBlogClient(conn).GetTrendingPosts(ctx, &pb.GetTrendingPostsRequest{
+      WindowSeconds: int64(*window / time.Second),
+      Limit:         int32(*limit),
+    })
+    if err != nil {
+      log.Fatalf("could not get trending posts: %v", err)
+    }
+
+    if len(trending.GetPosts()) == 0 {
+      fmt.Printf("No views in the last %s\n", *window)
+      return
+    }
+
+    for i, t := range trending.GetPosts() {
+      fmt.Printf("%2d. %s by %s, %d views (%d in total)\n", i+1, t.GetPost().GetTitle(), t.GetPost().GetAuthor(), t.GetViews(), t.GetPost().GetViewCount())
+    }
+  }
+}
+
+func setupAnalytics(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post")
+  since := fs.String("since", "", "RFC 3339 timestamp to start at, empty is 24 hours before -until")
+  until := fs.String("until", "", "RFC 3339 timestamp to end at, empty is now")
+  bucket := fs.Duration("bucket", time.Hour, "width of every bucket, a whole number of minutes")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *id == "" {
+      log.Fatalf("usage: analytics -id <post id> [-since time] [-until time] [-bucket 1h]")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    analytics, err := pb.NewBlogClient(conn).GetPostAnalytics(ctx, &pb.GetPostAnalyticsRequest{
+      PostId:        *id,
+      Since:         *since,
+      Until:         *until,
+      BucketSeconds: int64(*bucket / time.Second),
+    })
+    if err != nil {
+      log.Fatalf("could not get post analytics: %v", err)
+    }
+
+    var busiest int64
+    for _, b := range analytics.GetBuckets() {
+      busiest = max(busiest, b.GetViews())
+    }
+
+    const barWidth = 40
+    for _, b := range analytics.GetBuckets() {
+      bar := 0
+      if busiest > 0 {
+        bar = int(b.GetViews() * barWidth / busiest)
+      }
+      start, _ := time.Parse(time.RFC3339, b.GetStart())
+      fmt.Printf("%s %6d %s\n", start.Local().Format("2006-01-02 15:04"), b.GetViews(), strings.Repeat("█", bar))
+    }
+    fmt.Printf("\n%d views in %d buckets of %s\n", analytics.GetViews(), len(analytics.GetBuckets()), *bucket)
+  }
+}
+
+// delta formats a change of views, with its sign.
+func delta(n int64) string {
+  return fmt.Sprintf("%+d", n)
+}
+
+func setupSummary(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  date := fs.String("date", "", "day to summarize, YYYY-MM-DD in UTC, empty is yesterday")
+  week := fs.Bool("week", false, "summarize the week, Monday to Sunday, of -date rather than the day")
+  limit := fs.Int("n", 10, "number of posts and authors to show")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    req := &pb.GetDailySummaryRequest{Date: *date, Limit: int32(*limit)}
+    period, previous := "Day", "the day before"
+    if *week {
+      req.Period = pb.SummaryPeriod_SUMMARY_WEEK
+      period, previous = "Week", "the week before"
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    summary, err := pb.NewBlogClient(conn).GetDailySummary(ctx, req)
+    if err != nil {
+      log.Fatalf("could not get the summary: %v", err)
+    }
+
+    fmt.Printf("%s %s", period, summary.GetStart())
+    if *week {
+      fmt.Printf(" to %s", summary.GetEnd())
+    }
+    if !summary.GetComplete() {
+      fmt.Print(" (so far)")
+    }
+    fmt.Printf(": %d views, %s", summary.GetViews(), delta(summary.GetDelta()))
+    if summary.GetPreviousViews() > 0 {
+      fmt.Printf(" (%+.1f%%)", summary.GetDeltaPercent())
+    }
+    fmt.Printf(" on %s\n", previous)
+
+    if len(summary.GetTopPosts()) > 0 {
+      fmt.Println("\nTop posts:")
+    }
+    for i, post := range summary.GetTopPosts() {
+      title := post.GetTitle()
+      if title == "" {
+        title = post.GetPostId() + " (deleted)"
+      }
+      fmt.Printf("%2d. %s by %s, %d views, %s\n", i+1, title, post.GetAuthor(), post.GetViews(), delta(post.GetDelta()))
+    }
+
+    if len(summary.GetTopAuthors()) > 0 {
+      fmt.Println("\nTop authors:")
+    }
+    for i, author := range summary.GetTopAuthors() {
+      fmt.Printf("%2d. %s, %d views, %s\n", i+1, author.GetAuthor(), author.GetViews(), delta(author.GetDelta()))
+    }
+  }
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "log"
+  "mime"
+  "os"
+  "path/filepath"
+  "time"
+)
+
+/*
+  ATTACHMENTS
+
+  The upload and download subcommands exercise the two streaming RPCs:
+
+    go run ./client upload -post <post id> ./cat.png
+    go run ./client download -post <post id> -id <attachment id> -o cat.png
+
+  When the server keeps the attachments in a bucket, attachment-url prints a link that downloads the file straight from the bucket until it expires:
+
+    go run ./client attachment-url -post <post id> -id <attachment id> -expires 1h
+*/
+
+func setupUpload(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  postID := fs.String("post", "", "ID of the post the file is attached to")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *postID == "" || fs.NArg() != 1 {
+      log.Fatalf("usage: upload -post <post id> <file>")
+    }
+
+    f, err := os.Open(fs.Arg(0))
+    if err != nil {
+      log.Fatalf("could not open file: %v", err)
+    }
+    defer f.Close()
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+    defer cancel()
+
+    // Opening the stream doesn't send anything yet, it gives us a handle we can Send messages on.
+    stream, err := pb.NewBlogClient(conn).UploadAttachment(ctx)
+    if err != nil {
+      log.Fatalf("could not start upload: %v", err)
+    }
+
+    err = stream.Send(&pb.UploadAttachmentRequest{
+      Data: &pb.UploadAttachmentRequest_Metadata{
+        Metadata: &pb.AttachmentMetadata{
+          PostId:      *postID,
+          Filename:    filepath.Base(f.Name()),
+          ContentType: mime.TypeByExtension(filepath.Ext(f.Name())),
+        },
+      },
+    })
+    if err != nil {
+      log.Fatalf("could not send metadata: %v", err)
+    }
+
+    buf := make([]byte, 32*1024)
+    for {
+      n, err := f.Read(buf)
+      if n > 0 {
+        chunk := &pb.UploadAttachmentRequest{
+          Data: &pb.UploadAttachmentRequest_Chunk{Chunk: buf[:n]},
+        }
+        // Send returns io.EOF when the server already closed the stream, the actual error comes from CloseAndRecv below.
+        if err := stream.Send(chunk); err != nil {
+          break
+        }
+      }
+      if err == io.EOF {
+        break
+      }
+      if err != nil {
+        log.Fatalf("could not read file: %v", err)
+      }
+    }
+
+    // CloseAndRecv tells the server we are done sending and waits for its single response.
+    attachment, err := stream.CloseAndRecv()
+    if err != nil {
+      log.Fatalf("could not upload attachment: %v", err)
+    }
+
+    fmt.Printf("Uploaded %s (%d bytes) with ID %s\n", attachment.GetFilename(), attachment.GetSize(), attachment.GetId())
+  }
+}
+
+func setupDownload(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  postID := fs.String("post", "", "ID of the post the file is attached to")
+  attachmentID := fs.String("id", "", "ID of the attachment")
+  out := fs.String("o", "", "file to write to, defaults to the original filename")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *postID == "" || *attachmentID == "" {
+      log.Fatalf("usage: download -post <post id> -id <attachment id> [-o file]")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+    defer cancel()
+
+    stream, err := pb.NewBlogClient(conn).DownloadAttachment(ctx, &pb.DownloadAttachmentRequest{
+      PostId:       *postID,
+      AttachmentId: *attachmentID,
+    })
+    if err != nil {
+      log.Fatalf("could not start download: %v", err)
+    }
+
+    // The first message carries the metadata, which tells us the filename to use.
+    first, err := stream.Recv()
+    if err != nil {
+      log.Fatalf("could not download attachment: %v", err)
+    }
+
+    attachment := first.GetMetadata()
+    if *out == "" {
+      *out = attachment.GetFilename()
+    }
+
+    f, err := os.Create(*out)
+    if err != nil {
+      log.Fatalf("could not create file: %v", err)
+    }
+    defer f.Close()
+
+    // Recv returns io.EOF once the server is done sending.
+    for {
+      res, err := stream.Recv()
+      if err == io.EOF {
+        break
+      }
+      if err != nil {
+        log.Fatalf("could not download attachment: %v", err)
+      }
+
+      if _, err := f.Write(res.GetChunk()); err != nil {
+        log.Fatalf("could not write file: %v", err)
+      }
+    }
+
+    fmt.Printf("Downloaded %s (%d bytes) to %s\n", attachment.GetFilename(), attachment.GetSize(), *out)
+  }
+}
+
+func setupAttachmentURL(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  postID := fs.String("post", "", "ID of the post the file is attached to")
+  attachmentID := fs.String("id", "", "ID of the attachment")
+  expires := fs.Duration("expires", 15*time.Minute, "how long the link works, at most 7 days")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *postID == "" || *attachmentID == "" {
+      log.Fatalf("usage: attachment-url -post <post id> -id <attachment id> [-expires 15m]")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(10*time.Second))
+    defer cancel()
+
+    res, err := pb.NewBlogClient(conn).GetAttachmentURL(ctx, &pb.GetAttachmentURLRequest{
+      PostId:           *postID,
+      AttachmentId:     *attachmentID,
+      ExpiresInSeconds: int64(expires.Seconds()),
+    })
+    if err != nil {
+      log.Fatalf("could not get attachment URL: %v", err)
+    }
+
+    fmt.Printf("%s\n(expires %s)\n", res.GetUrl(), res.GetExpiresAt())
+  }
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  AUDIT LOG
+
+  QueryAuditLog is restricted to admins, so the call needs to carry the admin token the server was started with:
+
+    go run ./client audit -token secret -post <post id>
+
+  metadata.AppendToOutgoingContext attaches key/value pairs to every call made with the returned context, the gRPC equivalent of setting a request header.
+*/
+func setupAudit(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  postID := fs.String("post", "", "only show entries about this post")
+  identity := fs.String("identity", "", "only show entries made by this identity")
+  since := fs.String("since", "", "only show entries after this RFC 3339 timestamp")
+  limit := fs.Int("limit", 20, "number of entries to show")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+
+    entries, err := pb.NewBlogClient(conn).QueryAuditLog(ctx, &pb.QueryAuditLogRequest{
+      PostId:   *postID,
+      Identity: *identity,
+      Since:    *since,
+      Limit:    int32(*limit),
+    })
+    if err != nil {
+      log.Fatalf("could not query audit log: %v", err)
+    }
+
+    for _, e := range entries.GetEntries() {
+      fmt.Printf("%s %s (%s) %s post=%s %s\n", e.GetTime(), e.GetIdentity(), e.GetPeer(), e.GetMethod(), e.GetPostId(), e.GetCode())
+    }
+  }
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "strings"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  AUTHOR PROFILES
+
+  author prints what the page of an author shows, from a single GetAuthorProfile (see authors.go on the server). -set-bio changes the bio first, which requires the admin token, and an empty one removes it:
+
+    go run ./client author -name Ana
+    go run ./client author -name Ana -set-bio "Writes about RPCs" -token secret
+*/
+func setupAuthor(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  name := fs.String("name", "", "name of the author, as in the Author of the posts")
+  recent := fs.Int("n", 5, "number of recent posts to show")
+  bio := fs.String("set-bio", "", "bio to set before printing the profile, requires the admin token")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *name == "" {
+      log.Fatalf("usage: author -name <author> [-n 5] [-set-bio text -token <admin token>]")
+    }
+    setBio := false
+    fs.Visit(func(f *flag.Flag) { setBio = setBio || f.Name == "set-bio" })
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    client := pb.NewBlogClient(conn)
+    if setBio {
+      adminCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+      if _, err := client.SetAuthorBio(adminCtx, &pb.SetAuthorBioRequest{Author: *name, Bio: *bio}); err != nil {
+        log.Fatalf("could not set the bio: %v", err)
+      }
+    }
+
+    profile, err := client.GetAuthorProfile(ctx, &pb.GetAuthorProfileRequest{Author: *name, RecentPosts: int32(*recent)})
+    if err != nil {
+      log.Fatalf("could not get the profile: %v", err)
+    }
+
+    fmt.Println(profile.GetAuthor())
+    if profile.GetBio() != "" {
+      fmt.Println(profile.GetBio())
+    }
+    fmt.Printf("\n%d posts, %d views\n", profile.GetPostCount(), profile.GetTotalViews())
+
+    if len(profile.GetTopTags()) > 0 {
+      tags := make([]string, 0, len(profile.GetTopTags()))
+      for _, tag := range profile.GetTopTags() {
+        tags = append(tags, fmt.Sprintf("%s (%d)", tag.GetTag(), tag.GetPosts()))
+      }
+      fmt.Printf("Tags: %s\n", strings.Join(tags, ", "))
+    }
+
+    if len(profile.GetRecentPosts()) > 0 {
+      fmt.Println("\nRecent posts:")
+    }
+    for _, post := range profile.GetRecentPosts() {
+      fmt.Printf("  %s  %s (%s), %d views\n", post.GetCreatedAt(), post.GetTitle(), post.GetId(), post.GetViewCount())
+    }
+  }
+}
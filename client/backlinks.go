@@ -0,0 +1,47 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "time"
+)
+
+// backlinks prints the published posts whose content links to a post, by its ID or its slug (see internal/store/links.go on the server).
+func setupBacklinks(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *id == "" {
+      log.Fatalf("usage: backlinks -id <post id>")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    backlinks, err := pb.NewBlogClient(conn).GetBacklinks(ctx, &pb.GetBacklinksRequest{PostId: *id})
+    if err != nil {
+      log.Fatalf("could not get backlinks: %v", err)
+    }
+
+    if len(backlinks.GetPosts()) == 0 {
+      fmt.Println("No posts link here")
+      return
+    }
+
+    for _, post := range backlinks.GetPosts() {
+      fmt.Printf("%s by %s (%s)\n", post.GetTitle(), post.GetAuthor(), post.GetId())
+    }
+  }
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "math/rand"
+  "os"
+  "sort"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc/status"
+)
+
+/*
+  LOAD TESTING
+
+  The bench subcommand fires concurrent CreatePost/GetPosts traffic at the server and reports requests per second, latency percentiles and error rates.
+
+    go run ./client bench -c 20 -n 2000 -create-ratio 0.1
+
+  A single gRPC connection multiplexes many concurrent calls over one HTTP/2 connection, so all the workers below share the same *grpc.ClientConn. This is one of the main performance characteristics of gRPC compared to HTTP/1.1, where every in-flight request needs its own TCP connection.
+*/
+
+// benchResult holds what a single RPC call produced.
+type benchResult struct {
+  method  string
+  latency time.Duration
+  err     error
+}
+
+func setupBench(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  concurrency := fs.Int("c", 10, "number of concurrent workers")
+  total := fs.Int("n", 1000, "total number of requests to send (ignored if -d is set)")
+  duration := fs.Duration("d", 0, "run for this long instead of a fixed number of requests")
+  createRatio := fs.Float64("create-ratio", 0.2, "fraction of requests that are CreatePost, the rest are GetPosts")
+  timeout := fs.Duration("timeout", time.Second, "per request timeout")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *concurrency < 1 {
+      log.Fatalf("-c must be at least 1")
+    }
+
+    conn, err := dial(*addr)
+
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+
+    defer conn.Close()
+
+    c := pb.NewBlogClient(conn)
+
+    /*
+      GOROUTINES AND CHANNELS
+
+      Every worker is a goroutine that pulls work from the jobs channel until it gets closed. The results channel collects what each call produced so only one goroutine (the collector) ever touches the results slice, which means we don't need a mutex around it.
+    */
+    jobs := make(chan struct{})
+    results := make(chan benchResult, *concurrency)
+
+    var wg sync.WaitGroup
+    for i := 0; i < *concurrency; i++ {
+      wg.Add(1)
+      go func() {
+        defer wg.Done()
+        for range jobs {
+          results <- benchCall(c, *createRatio, *timeout)
+        }
+      }()
+    }
+
+    collected := make([]benchResult, 0, *total)
+    done := make(chan struct{})
+    go func() {
+      for r := range results {
+        collected = append(collected, r)
+      }
+      close(done)
+    }()
+
+    start := time.Now()
+    if *duration > 0 {
+      deadline := time.After(*duration)
+    loop:
+      for {
+        select {
+        case <-deadline:
+          break loop
+        case jobs <- struct{}{}:
+        }
+      }
+    } else {
+      for i := 0; i < *total; i++ {
+        jobs <- struct{}{}
+      }
+    }
+    close(jobs)
+    wg.Wait()
+    elapsed := time.Since(start)
+    close(results)
+    <-done
+
+    printBenchReport(collected, elapsed, *concurrency)
+  }
+}
+
+// benchCall performs a single request, picking between CreatePost and GetPosts according to the create ratio.
+func benchCall(c pb.BlogClient, createRatio float64, timeout time.Duration) benchResult {
+  ctx, cancel := context.WithTimeout(context.Background(), timeout)
+  defer cancel()
+
+  start := time.Now()
+  if rand.Float64() < createRatio {
+    _, err := c.CreatePost(ctx, &pb.CreatePostRequest{
+      Title:   "Benchmark Post",
+      Content: "This post was created by the bench subcommand",
+      Author:  "bench client",
+    })
+    return benchResult{method: "CreatePost", latency: time.Since(start), err: err}
+  }
+
+  _, err := c.GetPosts(ctx, &pb.GetPostsRequest{})
+  return benchResult{method: "GetPosts", latency: time.Since(start), err: err}
+}
+
+func printBenchReport(results []benchResult, elapsed time.Duration, concurrency int) {
+  if len(results) == 0 {
+    fmt.Fprintln(os.Stderr, "no requests were sent")
+    return
+  }
+
+  byMethod := make(map[string][]benchResult)
+  for _, r := range results {
+    byMethod[r.method] = append(byMethod[r.method], r)
+  }
+
+  fmt.Printf("Requests:    %d\n", len(results))
+  fmt.Printf("Concurrency: %d\n", concurrency)
+  fmt.Printf("Duration:    %s\n", elapsed.Round(time.Millisecond))
+  fmt.Printf("RPS:         %.1f\n\n", float64(len(results))/elapsed.Seconds())
+
+  methods := make([]string, 0, len(byMethod))
+  for m := range byMethod {
+    methods = append(methods, m)
+  }
+  sort.Strings(methods)
+
+  for _, m := range methods {
+    rs := byMethod[m]
+    latencies := make([]time.Duration, 0, len(rs))
+    // The status code is what tells us why a call failed, e.g. DeadlineExceeded when the server is too slow.
+    codes := make(map[string]int)
+    errCount := 0
+    for _, r := range rs {
+      latencies = append(latencies, r.latency)
+      if r.err != nil {
+        errCount++
+        codes[status.Code(r.err).String()]++
+      }
+    }
+    sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+    fmt.Printf("%s\n", m)
+    fmt.Printf("  count: %d  errors: %d (%.2f%%)\n", len(rs), errCount, 100*float64(errCount)/float64(len(rs)))
+    fmt.Printf("  p50: %s  p90: %s  p99: %s  max: %s\n",
+      percentile(latencies, 50),
+      percentile(latencies, 90),
+      percentile(latencies, 99),
+      latencies[len(latencies)-1],
+    )
+    for code, n := range codes {
+      fmt.Printf("  %s: %d\n", code, n)
+    }
+  }
+}
+
+// percentile expects latencies to be sorted in ascending order.
+func percentile(latencies []time.Duration, p int) time.Duration {
+  if len(latencies) == 0 {
+    return 0
+  }
+  i := (len(latencies)*p + 99) / 100
+  if i > 0 {
+    i--
+  }
+  return latencies[i]
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  BULK OPERATIONS
+
+  bulk deletes or archives every post matching a filter in one call. The filter flags are the same as list's, so list shows what bulk is about to touch, and -dry-run asks the server for the posts without changing them:
+
+    go run ./client list -author alice -until 2024-12-31
+    go run ./client bulk archive -token secret -author alice -until 2024-12-31 -dry-run
+    go run ./client bulk archive -token secret -author alice -until 2024-12-31
+
+  Both RPCs are restricted to admins and refuse an empty filter.
+*/
+func setupBulk(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  filter := filterFlags(fs)
+  dryRun := fs.Bool("dry-run", false, "list the posts that would change without changing them")
+
+  return func(args []string) {
+    if len(args) == 0 {
+      log.Fatalf("usage: bulk delete|archive -token <admin token> [filter flags] [-dry-run]")
+    }
+
+    fs.Init("bulk "+args[0], flag.ExitOnError)
+    fs.Parse(args[1:])
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(5*time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    c := pb.NewBlogClient(conn)
+    req := &pb.BulkPostsRequest{Filter: filter(), DryRun: *dryRun}
+
+    var res *pb.BulkPostsResponse
+    var verb string
+    switch args[0] {
+    case "delete":
+      res, err = c.DeletePosts(ctx, req)
+      verb = "deleted"
+    case "archive":
+      res, err = c.ArchivePosts(ctx, req)
+      verb = "archived"
+    default:
+      log.Fatalf("unknown bulk command %q, expected delete or archive", args[0])
+    }
+    if err != nil {
+      log.Fatalf("could not %s posts: %v", args[0], err)
+    }
+
+    for _, id := range res.GetIds() {
+      fmt.Println(id)
+    }
+    if res.GetDryRun() {
+      fmt.Printf("%d posts would be %s, run again without -dry-run to go ahead\n", res.GetCount(), verb)
+    } else {
+      fmt.Printf("%d posts %s\n", res.GetCount(), verb)
+    }
+  }
+}
+
+// filterFlags declares the flags of a PostFilter on fs and returns the function building it once the flags are parsed.
+func filterFlags(fs *flag.FlagSet) func() *pb.PostFilter {
+  authors := fs.String("author", "", "comma separated authors, the posts by any of them")
+  since := fs.String("since", "", "YYYY-MM-DD, the posts created on that day or later")
+  until := fs.String("until", "", "YYYY-MM-DD, the posts created on that day or earlier")
+  tags := fs.String("tags", "", "comma separated tags, the posts tagged with all of them")
+  minReading := fs.Int("min-reading", 0, "the posts taking at least this many minutes to read")
+  maxReading := fs.Int("max-reading", 0, "the posts taking at most this many minutes to read, 0 doesn't bound it")
+
+  return func() *pb.PostFilter {
+    filter := &pb.PostFilter{Authors: splitList(*authors), Since: *since, Until: *until, Tags: splitList(*tags), MinReadingMinutes: int32(*minReading), MaxReadingMinutes: int32(*maxReading)}
+    if len(filter.Authors) == 0 && filter.Since == "" && filter.Until == "" && len(filter.Tags) == 0 && filter.MinReadingMinutes == 0 && filter.MaxReadingMinutes == 0 {
+      return nil
+    }
+    return filter
+  }
+}
@@ -0,0 +1,454 @@
+package main
+
+import (
+  "context"
+  "encoding/binary"
+  "encoding/json"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "os"
+  "path/filepath"
+  "sort"
+  "strconv"
+  "strings"
+  "time"
+
+  bolt "go.etcd.io/bbolt"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  OFFLINE CACHE
+
+  The client keeps a copy of the posts it has seen in a local bolt database (~/.cache/blogctl/cache.db on Linux), so it stays useful when the server can't be reached:
+    - list and get print the cached posts, with a note saying how old they are
+    - create queues the post instead of failing
+    - sync sends the queued posts once the server is back
+
+    go run ./client list                    # online: refreshes the cache
+    go run ./client get -id <post id>       # online or offline
+    go run ./client create -title Draft     # offline: queued
+    go run ./client sync                    # pushes the queue
+
+  bolt is a key/value store in a single file, with transactions: the cache is never left half written, even when the client is killed in the middle of a sync. Keys are sorted bytes, which we use for the queue: its keys are big endian sequence numbers, so iterating the bucket returns the posts in the order they were queued. The buckets are:
+    - posts: post ID -> the post, in protobuf wire format
+    - queue: sequence number -> a queuedPost, in JSON
+    - meta: the SyncChanges cursor of the cache and when it was last refreshed
+
+  The cache is refreshed whenever the server answers: list stores every post GetPosts returned, get and sync call SyncChanges with the cursor stored in the cache so only what changed since the last refresh travels (the same way search keeps its index, see search.go).
+
+  CONFLICTS
+
+  While we were offline somebody else may have written the same post, or the post may have reached the server on a previous sync that was interrupted before it could be removed from the queue. Either way sending it again would publish it twice. Every queued post remembers the cursor the cache was at when it was queued, and sync looks for a post with the same title that reached the server after that cursor. Those are reported as conflicts and stay in the queue: run sync -force to send them anyway, or sync -drop <n> to forget one.
+
+  Only the server being unreachable (codes.Unavailable or a call that timed out) falls back to the cache. Any other error, like an invalid title, is a real answer from the server and is reported as usual.
+*/
+var (
+  postsBucket = []byte("posts")
+  queueBucket = []byte("queue")
+  metaBucket  = []byte("meta")
+
+  cursorKey   = []byte("cursor")
+  syncedAtKey = []byte("synced-at")
+)
+
+type postCache struct {
+  db *bolt.DB
+}
+
+type queuedPost struct {
+  // Number is the position in the queue, it is the key of the entry and isn't stored in the value.
+  Number         uint64    `json:"-"`
+  Title          string    `json:"title"`
+  Content        string    `json:"content"`
+  Author         string    `json:"author"`
+  PublishAt      string    `json:"publishAt"`
+  Tags           []string  `json:"tags,omitempty"`
+  AllowDuplicate bool      `json:"allowDuplicate,omitempty"`
+  Draft          bool      `json:"draft,omitempty"`
+  BaseCursor     int64     `json:"baseCursor"`
+  QueuedAt       time.Time `json:"queuedAt"`
+}
+
+func defaultCachePath() string {
+  dir, err := os.UserCacheDir()
+  if err != nil {
+    dir = os.TempDir()
+  }
+
+  return filepath.Join(dir, "blogctl", "cache.db")
+}
+
+// openCache opens the cache, creating it if needed. bolt locks the file, the timeout keeps a second client from waiting forever on the first one.
+func openCache(path string) (*postCache, error) {
+  if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+    return nil, fmt.Errorf("could not create cache directory: %w", err)
+  }
+
+  db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+  if err != nil {
+    return nil, fmt.Errorf("could not open cache %s: %w", path, err)
+  }
+
+  err = db.Update(func(tx *bolt.Tx) error {
+    for _, name := range [][]byte{postsBucket, queueBucket, metaBucket} {
+      if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+        return err
+      }
+    }
+    return nil
+  })
+  if err != nil {
+    db.Close()
+    return nil, fmt.Errorf("could not initialize cache: %w", err)
+  }
+
+  return &postCache{db: db}, nil
+}
+
+func (c *postCache) Close() error {
+  return c.db.Close()
+}
+
+// ReplacePosts makes posts the whole content of the cache, they are the complete list GetPosts returned. The cursor is kept since it is still valid for the posts that didn't change.
+func (c *postCache) ReplacePosts(posts []*pb.Post) error {
+  return c.db.Update(func(tx *bolt.Tx) error {
+    if err := tx.DeleteBucket(postsBucket); err != nil {
+      return err
+    }
+    bucket, err := tx.CreateBucket(postsBucket)
+    if err != nil {
+      return err
+    }
+
+    for _, post := range posts {
+      if err := putPost(bucket, post); err != nil {
+        return err
+      }
+    }
+
+    return touch(tx)
+  })
+}
+
+// Apply stores the changes returned by SyncChanges along with the new cursor.
+func (c *postCache) Apply(res *pb.SyncChangesResponse) error {
+  return c.db.Update(func(tx *bolt.Tx) error {
+    bucket := tx.Bucket(postsBucket)
+    for _, post := range res.GetPosts() {
+      if err := putPost(bucket, post); err != nil {
+        return err
+      }
+    }
+    for _, id := range res.GetDeletedIds() {
+      if err := bucket.Delete([]byte(id)); err != nil {
+        return err
+      }
+    }
+
+    if err := tx.Bucket(metaBucket).Put(cursorKey, []byte(strconv.FormatInt(res.GetCursor(), 10))); err != nil {
+      return err
+    }
+
+    return touch(tx)
+  })
+}
+
+func putPost(bucket *bolt.Bucket, post *pb.Post) error {
+  data, err := proto.Marshal(post)
+  if err != nil {
+    return err
+  }
+
+  return bucket.Put([]byte(post.GetId()), data)
+}
+
+// touch records that the cache was just refreshed from the server.
+func touch(tx *bolt.Tx) error {
+  return tx.Bucket(metaBucket).Put(syncedAtKey, []byte(time.Now().UTC().Format(time.RFC3339)))
+}
+
+// Cursor returns the SyncChanges cursor of the cache, 0 until the first sync.
+func (c *postCache) Cursor() (int64, error) {
+  var cursor int64
+  err := c.db.View(func(tx *bolt.Tx) error {
+    value := tx.Bucket(metaBucket).Get(cursorKey)
+    if value == nil {
+      return nil
+    }
+
+    var err error
+    cursor, err = strconv.ParseInt(string(value), 10, 64)
+    return err
+  })
+
+  return cursor, err
+}
+
+// SyncedAt returns when the cache was last refreshed, the zero time if it never was.
+func (c *postCache) SyncedAt() time.Time {
+  var t time.Time
+  c.db.View(func(tx *bolt.Tx) error {
+    t, _ = time.Parse(time.RFC3339, string(tx.Bucket(metaBucket).Get(syncedAtKey)))
+    return nil
+  })
+
+  return t
+}
+
+// Posts returns the cached posts in the order they reached the server.
+func (c *postCache) Posts() ([]*pb.Post, error) {
+  var posts []*pb.Post
+  err := c.db.View(func(tx *bolt.Tx) error {
+    return tx.Bucket(postsBucket).ForEach(func(_, data []byte) error {
+      post := &pb.Post{}
+      if err := proto.Unmarshal(data, post); err != nil {
+        return err
+      }
+      posts = append(posts, post)
+      return nil
+    })
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  sort.SliceStable(posts, func(i, j int) bool { return posts[i].GetSequence() < posts[j].GetSequence() })
+
+  return posts, nil
+}
+
+// Post returns the cached post with the given ID, nil when it isn't cached.
+func (c *postCache) Post(id string) (*pb.Post, error) {
+  var post *pb.Post
+  err := c.db.View(func(tx *bolt.Tx) error {
+    data := tx.Bucket(postsBucket).Get([]byte(id))
+    if data == nil {
+      return nil
+    }
+
+    post = &pb.Post{}
+    return proto.Unmarshal(data, post)
+  })
+
+  return post, err
+}
+
+// Enqueue adds a post to send on the next sync and returns its number in the queue.
+func (c *postCache) Enqueue(q queuedPost) (uint64, error) {
+  err := c.db.Update(func(tx *bolt.Tx) error {
+    bucket := tx.Bucket(queueBucket)
+
+    var err error
+    if q.Number, err = bucket.NextSequence(); err != nil {
+      return err
+    }
+
+    data, err := json.Marshal(q)
+    if err != nil {
+      return err
+    }
+
+    return bucket.Put(queueKey(q.Number), data)
+  })
+
+  return q.Number, err
+}
+
+// Queued returns the queued posts, oldest first.
+func (c *postCache) Queued() ([]queuedPost, error) {
+  var queue []queuedPost
+  err := c.db.View(func(tx *bolt.Tx) error {
+    return tx.Bucket(queueBucket).ForEach(func(key, data []byte) error {
+      q := queuedPost{Number: binary.BigEndian.Uint64(key)}
+      if err := json.Unmarshal(data, &q); err != nil {
+        return err
+      }
+      queue = append(queue, q)
+      return nil
+    })
+  })
+
+  return queue, err
+}
+
+// Dequeue removes a post from the queue, and stores the post the server created from it when there is one.
+func (c *postCache) Dequeue(number uint64, created *pb.Post) error {
+  return c.db.Update(func(tx *bolt.Tx) error {
+    bucket := tx.Bucket(queueBucket)
+    if bucket.Get(queueKey(number)) == nil {
+      return fmt.Errorf("no post number %d in the queue", number)
+    }
+    if err := bucket.Delete(queueKey(number)); err != nil {
+      return err
+    }
+
+    if created == nil {
+      return nil
+    }
+    return putPost(tx.Bucket(postsBucket), created)
+  })
+}
+
+func queueKey(number uint64) []byte {
+  return binary.BigEndian.AppendUint64(nil, number)
+}
+
+// unreachable tells the errors of a server we couldn't talk to, the only ones the cache stands in for.
+func unreachable(err error) bool {
+  code := status.Code(err)
+  return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// refreshCache brings the cache up to date with the changes made on the server since its cursor.
+func refreshCache(conn *grpc.ClientConn, cache *postCache) error {
+  cursor, err := cache.Cursor()
+  if err != nil {
+    return err
+  }
+
+  ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+  defer cancel()
+
+  res, err := pb.NewBlogClient(conn).SyncChanges(ctx, &pb.SyncChangesRequest{Cursor: cursor})
+  if err != nil {
+    return err
+  }
+
+  return cache.Apply(res)
+}
+
+// cacheAge tells how stale the cached posts are, for the note printed when we fall back to them.
+func cacheAge(cache *postCache) string {
+  syncedAt := cache.SyncedAt()
+  if syncedAt.IsZero() {
+    return "the cache is empty until list, get or sync reach the server"
+  }
+
+  return fmt.Sprintf("last synced %s ago", time.Since(syncedAt).Round(time.Second))
+}
+
+// queuePost keeps a post create couldn't send, along with the cursor sync uses to detect conflicts.
+func queuePost(cachePath string, q queuedPost) {
+  cache, err := openCache(cachePath)
+  if err != nil {
+    log.Fatalf("server unreachable and %v", err)
+  }
+  defer cache.Close()
+
+  if q.BaseCursor, err = cache.Cursor(); err != nil {
+    log.Fatalf("server unreachable and could not read the offline cache: %v", err)
+  }
+  q.QueuedAt = time.Now().UTC()
+
+  number, err := cache.Enqueue(q)
+  if err != nil {
+    log.Fatalf("server unreachable and could not queue the post: %v", err)
+  }
+
+  fmt.Printf("Server unreachable, queued the post as number %d. Run sync to send it once the server is back.\n", number)
+}
+
+func setupSync(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  cachePath := fs.String("cache", defaultCachePath(), "path of the offline cache")
+  force := fs.Bool("force", false, "send the queued posts even when they conflict with a post on the server")
+  drop := fs.Uint64("drop", 0, "remove the queued post with this number without sending it")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    cache, err := openCache(*cachePath)
+    if err != nil {
+      log.Fatalf("%v", err)
+    }
+    defer cache.Close()
+
+    // Dropping a post doesn't need the server, it works offline too.
+    if *drop > 0 {
+      if err := cache.Dequeue(*drop, nil); err != nil {
+        log.Fatalf("could not drop queued post: %v", err)
+      }
+      fmt.Printf("Dropped queued post %d\n", *drop)
+      return
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    // Refresh first, conflicts are found among the posts the server has now.
+    if err := refreshCache(conn, cache); err != nil {
+      log.Fatalf("could not sync posts: %v", err)
+    }
+
+    queue, err := cache.Queued()
+    if err != nil {
+      log.Fatalf("could not read the queue: %v", err)
+    }
+    posts, err := cache.Posts()
+    if err != nil {
+      log.Fatalf("could not read the offline cache: %v", err)
+    }
+
+    sent, kept := 0, 0
+    for _, q := range queue {
+      if conflict := findConflict(posts, q); conflict != nil && !*force {
+        fmt.Printf("Conflict: queued post %d %q, post %s with the same title reached the server after it was queued. Run sync -force to send it anyway or sync -drop %d to forget it.\n", q.Number, q.Title, conflict.GetId(), q.Number)
+        kept++
+        continue
+      }
+
+      ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+      post, err := pb.NewBlogClient(conn).CreatePost(ctx, &pb.CreatePostRequest{
+        Title:          q.Title,
+        Content:        q.Content,
+        Author:         q.Author,
+        PublishAt:      q.PublishAt,
+        Tags:           q.Tags,
+        AllowDuplicate: q.AllowDuplicate,
+        Draft:          q.Draft,
+      })
+      cancel()
+      if unreachable(err) {
+        log.Fatalf("server unreachable, %d posts are still queued", len(queue)-sent)
+      }
+      if err != nil {
+        fmt.Printf("Could not send queued post %d %q: %v\n", q.Number, q.Title, status.Convert(err).Message())
+        kept++
+        continue
+      }
+
+      // The post is in the cache as soon as it leaves the queue, so a post queued twice conflicts with the first copy.
+      if err := cache.Dequeue(q.Number, post); err != nil {
+        log.Fatalf("post %d was sent as %s but could not be removed from the queue: %v", q.Number, post.GetId(), err)
+      }
+      posts = append(posts, post)
+      sent++
+      fmt.Printf("Sent queued post %d %q as %s\n", q.Number, q.Title, post.GetId())
+    }
+
+    fmt.Printf("Cache up to date, %d queued posts sent, %d still queued\n", sent, kept)
+    if kept > 0 {
+      os.Exit(1)
+    }
+  }
+}
+
+// findConflict returns a post with the title of q that reached the server after q was queued.
+func findConflict(posts []*pb.Post, q queuedPost) *pb.Post {
+  for _, post := range posts {
+    if post.GetSequence() > q.BaseCursor && strings.EqualFold(strings.TrimSpace(post.GetTitle()), strings.TrimSpace(q.Title)) {
+      return post
+    }
+  }
+
+  return nil
+}
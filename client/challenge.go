@@ -0,0 +1,47 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/pow"
+  "strconv"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  CHALLENGES
+
+  A server run with -challenge-bits wants a solved proof of work with every anonymous CreatePost (see challenges.go in the server). challengeUnaryInterceptor gets one with GetChallenge right before the call and solves it, so every command creating posts works the same against an open server:
+
+    go run ./client create -title "Hello" -content "from nowhere"
+
+  Calls with a token, or with the SVID of a spiffe profile (see config.go), skip it, the server asks nothing of known callers. A server without challenges, or one too old to know GetChallenge, gets the CreatePost as it is.
+*/
+const challengeHeader = "x-challenge"
+
+func challengeUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+  if method != pb.Blog_CreatePost_FullMethodName {
+    return invoker(ctx, method, req, reply, cc, opts...)
+  }
+  if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get("authorization")) > 0 || profile.SPIFFE != nil {
+    return invoker(ctx, method, req, reply, cc, opts...)
+  }
+
+  challenge, err := pb.NewBlogClient(cc).GetChallenge(ctx, &pb.GetChallengeRequest{})
+  if status.Code(err) == codes.Unimplemented {
+    return invoker(ctx, method, req, reply, cc, opts...)
+  }
+  if err != nil {
+    return err
+  }
+  if challenge.GetToken() != "" {
+    nonce := pow.Solve(challenge.GetToken(), int(challenge.GetBits()))
+    ctx = metadata.AppendToOutgoingContext(ctx, challengeHeader, challenge.GetToken()+":"+strconv.FormatUint(nonce, 10))
+  }
+
+  return invoker(ctx, method, req, reply, cc, opts...)
+}
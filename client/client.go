@@ -3,8 +3,9 @@ package main
 import (
   "context"
   "fmt"
-  pb "go/tutorial/grpc/gen"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
   "log"
+  "os"
   "time"
 
   "google.golang.org/grpc"
@@ -16,6 +17,68 @@ import (
 */
 
 func main() {
+  /*
+    SUBCOMMANDS
+
+    Running the client without arguments executes the demo below. Extra tools live behind subcommands, each one with its own flag set:
+      - bench: fires concurrent traffic at the server and reports latency stats (see bench.go)
+      - search: full-text search over a local index kept in sync with the server (see search.go)
+      - upload/download: send and fetch post attachments using streaming RPCs (see attachments.go)
+      - attachment-url: prints a signed download link for an attachment kept in a bucket (see attachments.go)
+      - render: prints the HTML version of a post (see render.go)
+      - list: prints the posts as text, a table, JSON or through a template, optionally filtered and with only some fields (see posts.go and format.go)
+      - get/sync: print a single post, and send the posts created while the server was unreachable (see posts.go and cache.go)
+      - create/watch: create (or schedule) a post and follow posts as they get published (see posts.go)
+      - schedule: prints the calendar of the posts waiting to be published, requires the admin token (see schedule.go)
+      - update/revisions: edit a post, list its previous versions and restore one of them (see posts.go)
+      - delete: deletes a post (see posts.go)
+      - bulk: deletes or archives every post matching a filter, requires the admin token (see bulk.go)
+      - tui: an interactive full screen client that lists posts as they get published and edits them (see tui.go)
+      - related: lists the posts similar to a post, by tags and words (see related.go)
+      - view/trending/analytics: count a view of a post, the most viewed posts right now and the views of a post over time (see analytics.go)
+      - summary: prints the views of a day or a week next to the period before, with the top posts and authors (see analytics.go)
+      - author: prints the page of an author, its bio, numbers, latest posts and tags, setting the bio requires the admin token (see authors.go)
+      - stream: lists the posts over a stream that resumes where it left off when it drops (see stream.go)
+      - audit: shows who changed what, requires the admin token (see audit.go)
+      - webhooks: registers the URLs called on post events, requires the admin token (see webhooks.go)
+      - subscribe/unsubscribe: get an email for every new post (see email.go)
+      - debug-log: switches the server's request logging on or off, requires the admin token (see admin.go)
+      - maintenance: turns the server's maintenance mode on or off, requires the admin token (see admin.go)
+      - reload-config: makes the server read its -config file again, requires the admin token (see admin.go)
+      - cron: lists the recurring tasks of the server or runs one, requires the admin token (see admin.go)
+      - flush-storage: writes the saves the server holds back with -write-delay, requires the admin token (see admin.go)
+      - reencrypt-storage: saves every post again with the current encryption key, requires the admin token (see admin.go)
+      - storage-stats: prints how much room the posts take and what compression saves, requires the admin token (see admin.go)
+      - stats: prints the calls per second, latency and memory of the server every few seconds, requires the admin token (see admin.go)
+      - export: writes the audit log or the views to a CSV or Parquet file, here or in the bucket of the server, requires the admin token (see admin.go)
+      - migrate-data: copies posts.json into a SQLite database (see migrate.go)
+      - completion/docs: print the shell completion script and write the man pages (see completion.go and docs.go)
+
+    The subcommands are listed in commands.go, a new one only has to be added there.
+
+    Every subcommand also takes --profile to pick the server from ~/.blogctl.yaml (see config.go).
+  */
+  // The completions load the profile on their own, see completion.go.
+  if len(os.Args) > 1 && os.Args[1] == "__complete" {
+    runComplete(os.Args[2:])
+    return
+  }
+
+  args, err := loadProfile(os.Args[1:])
+  if err != nil {
+    log.Fatalf("%v", err)
+  }
+  os.Args = append(os.Args[:1], args...)
+
+  if len(os.Args) > 1 {
+    cmd := findCommand(os.Args[1])
+    if cmd == nil {
+      log.Fatalf("unknown subcommand %q", os.Args[1])
+    }
+    cmd.run(os.Args[2:])
+    return
+  }
+
   /*
     We create a new connection and bind it to localhost:3000 (the same port used on the server side).
 
@@ -26,10 +89,10 @@ func main() {
     creds := credentials.NewTLS(&tls.Config{...})
     conn := grpc.Dial(address, grpc.WithTransportCredentials(creds))
   */
-  conn, err := grpc.NewClient("localhost:3000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+  conn, err := grpc.NewClient("localhost:3000", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUserAgent(userAgent))
 
   if err != nil {
-    log.Fatalf("failed to connect to grpc server")
+    log.Fatalf("failed to connect to grpc server: %v", err)
   }
 
   // Make sure that we close the connection at the end of execution.
@@ -71,8 +134,12 @@ func main() {
     Author:  "gRPC client",
   }
 
+  // Metadata is the gRPC version of HTTP headers, see metadata.go. Here we tell the server which language we prefer and ask for the response headers so we can print the request ID the server assigned to the call.
+  ctx = withCallMetadata(ctx, callMetadata{Locale: "en"})
+  var md responseMetadata
+
   // We call the client CreatePost function passing context and the CreatePostRequest
-  post, err := c.CreatePost(ctx, newPost)
+  post, err := c.CreatePost(ctx, newPost, md.callOptions()...)
 
   if err != nil {
     /*
@@ -84,20 +151,24 @@ func main() {
     log.Fatalf("could not create post: %v", err)
   }
 
-  fmt.Printf("Created Post: %v", post)
+  fmt.Printf("Created Post: %v\nRequest ID: %s", post, md.requestID())
 
-  // We call the client GetPosts function passing context and the GetPostsRequest
-  posts, err := c.GetPosts(ctx, &pb.GetPostsRequest{})
+  // We call the client GetPosts function passing context and the GetPostsRequest. This time we are interested in the trailers, where the server reports how the call went.
+  var getMD responseMetadata
+  posts, err := c.GetPosts(ctx, &pb.GetPostsRequest{}, getMD.callOptions()...)
 
   if err != nil {
     log.Fatalf("could not get posts: %v", err)
   }
 
+  fmt.Printf("\nGetPosts took %s on the server (storage: %s)\n", getMD.get("x-processing-time"), getMD.get("x-storage-backend"))
+
   fmt.Println("\n All Posts:")
 
   // We printout the posts to std out for confirmation.
   for _, p := range posts.Posts {
-    fmt.Printf("Title: %s\nAuthor: %s\nContent: %s\nView Count: %d\n\n",
+    fmt.Printf("ID: %s\nTitle: %s\nAuthor: %s\nContent: %s\nView Count: %d\n\n",
+      p.GetId(),
       p.GetTitle(),
       p.GetAuthor(),
       p.GetContent(),
@@ -0,0 +1,127 @@
+package main
+
+import (
+  "flag"
+  "sort"
+)
+
+/*
+  SUBCOMMAND TABLE
+
+  Every subcommand is listed once below, with the one line summary shown by the man pages. main picks the command to run from this table, the completion and docs commands (see completion.go and docs.go) walk it to know which commands and flags exist, so a new command only has to be added here.
+
+  The flags are declared by the setup function of each command, next to the code using them: setup declares them on the flag set it is given and returns the function running the command, which parses them. run gives setup a new flag set and calls what it returns, flags only calls setup, so completion and docs list the flags of a command without running any of it.
+*/
+type command struct {
+  name    string
+  summary string
+  setup   func(fs *flag.FlagSet) func(args []string)
+  // verbs are the words a command expects before its flags, like webhooks add|list|remove.
+  verbs []string
+  // postFlags take the ID of a post, their values are completed with the IDs on the server.
+  postFlags []string
+  // hidden commands are left out of completions and man pages.
+  hidden bool
+}
+
+// allCommands is a function rather than a variable because completion and docs use the table themselves, Go doesn't allow a variable whose value refers back to it.
+func allCommands() []*command {
+  return []*command{
+    {name: "bench", summary: "fire concurrent traffic at the server and report latency stats", setup: setupBench},
+    {name: "search", summary: "full-text search over a local index kept in sync with the server", setup: setupSearch},
+    {name: "upload", summary: "attach a file to a post", setup: setupUpload, postFlags: []string{"post"}},
+    {name: "download", summary: "download an attachment of a post", setup: setupDownload, postFlags: []string{"post"}},
+    {name: "attachment-url", summary: "print a signed download link for an attachment kept in a bucket", setup: setupAttachmentURL, postFlags: []string{"post"}},
+    {name: "render", summary: "print the HTML version of a post", setup: setupRender, postFlags: []string{"id"}},
+    {name: "list", summary: "print the posts as text, a table, JSON or through a template", setup: setupList},
+    {name: "get", summary: "print a post, from the offline cache when the server can't be reached", setup: setupGet, postFlags: []string{"id"}},
+    {name: "sync", summary: "refresh the offline cache and send the posts created while offline", setup: setupSync},
+    {name: "create", summary: "create or schedule a post", setup: setupCreate},
+    {name: "templates", summary: "keep the templates recurring posts are made from, changing them requires the admin token", setup: setupTemplates, verbs: []string{"add", "list", "get", "update", "remove"}},
+    {name: "create-from-template", summary: "create a post from a template, filling its placeholders", setup: setupCreateFromTemplate},
+    {name: "series", summary: "group posts meant to be read in order, changing a series requires the admin token", setup: setupSeries, verbs: []string{"create", "list", "get", "add", "remove", "reorder", "delete"}, postFlags: []string{"post"}},
+    {name: "schedule", summary: "print the posts waiting to be published, day by day, requires the admin token", setup: setupSchedule},
+    {name: "watch", summary: "follow posts as they are created, changed and published", setup: setupWatch},
+    {name: "update", summary: "edit a post", setup: setupUpdate, postFlags: []string{"id"}},
+    {name: "revisions", summary: "list the previous versions of a post and restore one of them", setup: setupRevisions, postFlags: []string{"id"}},
+    {name: "delete", summary: "delete a post", setup: setupDelete, postFlags: []string{"id"}},
+    {name: "pin", summary: "keep a post at the top of the list, requires the admin token", setup: setupPin, postFlags: []string{"id"}},
+    {name: "unpin", summary: "stop keeping a post at the top of the list, requires the admin token", setup: setupUnpin, postFlags: []string{"id"}},
+    {name: "bulk", summary: "delete or archive every post matching a filter, requires the admin token", setup: setupBulk, verbs: []string{"delete", "archive"}},
+    {name: "tui", summary: "browse, write and edit posts full screen, new posts show up live", setup: setupTUI},
+    {name: "related", summary: "list the posts similar to a post", setup: setupRelated, postFlags: []string{"id"}},
+    {name: "backlinks", summary: "list the posts linking to a post", setup: setupBacklinks, postFlags: []string{"id"}},
+    {name: "sitemap", summary: "print the sitemap.xml of the published posts", setup: setupSitemap},
+    {name: "view", summary: "count a view of a post, repeat views of a viewer count once", setup: setupView, postFlags: []string{"id"}},
+    {name: "session", summary: "start a session so views are counted for you rather than your address, or renew it", setup: setupSession},
+    {name: "history", summary: "list the posts viewed in the session, the most recent first", setup: setupHistory},
+    {name: "comments", summary: "comment on posts and answer comments, approving and rejecting them requires the admin token", setup: setupComments, verbs: []string{"add", "list", "approve", "reject"}, postFlags: []string{"post"}},
+    {name: "reports", summary: "report posts and comments to the moderators, listing and resolving the reports requires the admin token", setup: setupReports, verbs: []string{"post", "comment", "list", "resolve"}, postFlags: []string{"post"}},
+    {name: "notifications", summary: "list the notifications of the session, mark them as read or follow the new ones", setup: setupNotifications},
+    {name: "read", summary: "mark a post as read in the session, list -unread leaves it out", setup: setupRead, postFlags: []string{"id"}},
+    {name: "trending", summary: "list the most viewed posts of a recent window", setup: setupTrending},
+    {name: "analytics", summary: "chart the views of a post over time", setup: setupAnalytics, postFlags: []string{"id"}},
+    {name: "summary", summary: "print the views of a day or a week next to the period before, with the top posts and authors", setup: setupSummary},
+    {name: "author", summary: "print the bio, numbers, latest posts and tags of an author, setting the bio requires the admin token", setup: setupAuthor},
+    {name: "stream", summary: "list the posts over a stream that resumes where it left off when it drops", setup: setupStream},
+    {name: "audit", summary: "show who changed what, requires the admin token", setup: setupAudit, postFlags: []string{"post"}},
+    {name: "webhooks", summary: "register the URLs called on post events, requires the admin token", setup: setupWebhooks, verbs: []string{"add", "list", "remove"}},
+    {name: "subscribe", summary: "get an email for every new post", setup: setupSubscribe},
+    {name: "unsubscribe", summary: "stop the emails of a subscription", setup: setupUnsubscribe},
+    {name: "storage-stats", summary: "print how much room the posts take and what compression saves, requires the admin token", setup: setupStorageStats},
+    {name: "maintenance", summary: "turn the server's maintenance mode on or off, requires the admin token", setup: setupMaintenance},
+    {name: "reload-config", summary: "make the server read its config file again, requires the admin token", setup: setupReloadConfig},
+    {name: "flush-storage", summary: "write the saves the server holds back, requires the admin token", setup: setupFlushStorage},
+    {name: "reencrypt-storage", summary: "save every post again with the current encryption key, requires the admin token", setup: setupReencryptStorage},
+    {name: "cron", summary: "list the recurring tasks of the server or run one, requires the admin token", setup: setupCron, verbs: []string{"list", "run"}},
+    {name: "bans", summary: "keep authors and addresses from changing anything, requires the admin token", setup: setupBans, verbs: []string{"add", "remove", "list"}},
+    {name: "connections", summary: "show the connections open on the server and their traffic, requires the admin token", setup: setupConnections},
+    {name: "stats", summary: "print the calls per second, latency and memory of the server every few seconds, requires the admin token", setup: setupStats},
+    {name: "export", summary: "write the audit log or the views to a CSV or Parquet file, requires the admin token", setup: setupExport},
+    {name: "debug-log", summary: "switch the server's request logging on or off, requires the admin token", setup: setupDebugLog},
+    {name: "migrate-data", summary: "copy posts.json into a SQLite database", setup: setupMigrateData},
+    {name: "completion", summary: "print the shell completion script for bash, zsh or fish", setup: setupCompletion, verbs: []string{"bash", "zsh", "fish"}},
+    {name: "docs", summary: "write the man pages of blogctl", setup: setupDocs},
+    {name: "__complete", summary: "print the completions of a command line, used by the completion scripts", setup: noFlags(runComplete), hidden: true},
+  }
+}
+
+func findCommand(name string) *command {
+  for _, cmd := range allCommands() {
+    if cmd.name == name {
+      return cmd
+    }
+  }
+
+  return nil
+}
+
+// run runs the command with the arguments following its name.
+func (cmd *command) run(args []string) {
+  cmd.setup(flag.NewFlagSet(cmd.name, flag.ExitOnError))(args)
+}
+
+// flags returns the flags of the command sorted by name, without running it.
+func (cmd *command) flags() []*flag.Flag {
+  fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+  cmd.setup(fs)
+
+  var flags []*flag.Flag
+  fs.VisitAll(func(f *flag.Flag) {
+    flags = append(flags, f)
+  })
+  sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+
+  return flags
+}
+
+// noFlags is the setup of the commands that parse their arguments themselves.
+func noFlags(run func(args []string)) func(fs *flag.FlagSet) func(args []string) {
+  return func(*flag.FlagSet) func(args []string) { return run }
+}
+
+// isBoolFlag tells flags that don't take a value, like -dry-run, from the ones that do.
+func isBoolFlag(f *flag.Flag) bool {
+  b, ok := f.Value.(interface{ IsBoolFlag() bool })
+  return ok && b.IsBoolFlag()
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "strings"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  COMMENTS
+
+  Anybody can comment on a post and answer a comment with -parent. New comments may wait for a moderator before others see them (see comments.go in the server), list shows yours with their status in the meantime:
+
+    go run ./client comments add -post <post id> -author Jane -content "Great post"
+    go run ./client comments add -post <post id> -parent <comment id> -author John -content "Agreed"
+    go run ./client comments list -post <post id>
+
+  Approving and rejecting comments takes the admin token, with it list shows the comments of every status, -status narrows them down:
+
+    go run ./client comments list -token secret -post <post id> -status pending
+    go run ./client comments approve -token secret -id <comment id>
+    go run ./client comments reject -token secret -id <comment id> -reason spam
+*/
+func setupComments(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  postID := fs.String("post", "", "add, list: ID of the post")
+  parent := fs.String("parent", "", "add: ID of the comment to answer")
+  author := fs.String("author", "", "add: name shown with the comment")
+  content := fs.String("content", "", "add: text of the comment")
+  statuses := fs.String("status", "", "list: comma separated statuses to show: pending, approved or rejected. Empty shows every comment you may see")
+  id := fs.String("id", "", "approve, reject: ID of the comment")
+  reason := fs.String("reason", "", "approve, reject: why, shown to the author")
+
+  return func(args []string) {
+    if len(args) == 0 {
+      log.Fatalf("usage: comments add|list|approve|reject [flags]")
+    }
+
+    fs.Init("comments "+args[0], flag.ExitOnError)
+    fs.Parse(args[1:])
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    // Only the moderators need the token, readers are told apart by their session.
+    if *token != "" {
+      ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    }
+    c := pb.NewBlogClient(conn)
+
+    switch args[0] {
+    case "add":
+      comment, err := c.AddComment(ctx, &pb.AddCommentRequest{PostId: *postID, ParentId: *parent, Author: *author, Content: *content})
+      if err != nil {
+        log.Fatalf("could not add the comment: %v", err)
+      }
+      fmt.Printf("Added comment %s (%s)\n", comment.GetId(), comment.GetStatus())
+    case "list":
+      req := &pb.GetCommentsRequest{PostId: *postID}
+      for _, name := range splitList(*statuses) {
+        s, ok := pb.CommentStatus_value[strings.ToUpper(name)]
+        if !ok {
+          log.Fatalf("unknown comment status %q, expected pending, approved or rejected", name)
+        }
+        req.Statuses = append(req.Statuses, pb.CommentStatus(s))
+      }
+
+      comments, err := c.GetComments(ctx, req)
+      if err != nil {
+        log.Fatalf("could not get the comments: %v", err)
+      }
+      if len(comments.GetComments()) == 0 {
+        fmt.Println("No comments")
+        return
+      }
+      printThreads(comments.GetComments())
+    case "approve", "reject":
+      req := &pb.ModerateCommentRequest{Id: *id, Reason: *reason}
+      call := c.ApproveComment
+      if args[0] == "reject" {
+        call = c.RejectComment
+      }
+      comment, err := call(ctx, req)
+      if err != nil {
+        log.Fatalf("could not %s the comment: %v", args[0], err)
+      }
+      fmt.Printf("Comment %s is %s\n", comment.GetId(), comment.GetStatus())
+    default:
+      log.Fatalf("unknown comments command %q, expected add, list, approve or reject", args[0])
+    }
+  }
+}
+
+// printThreads prints every comment under the one it answers. Replies to a comment that isn't in the list, a rejected one, are printed at the top.
+func printThreads(comments []*pb.Comment) {
+  listed := make(map[string]bool)
+  replies := make(map[string][]*pb.Comment)
+  for _, comment := range comments {
+    listed[comment.GetId()] = true
+  }
+  var roots []*pb.Comment
+  for _, comment := range comments {
+    if comment.GetParentId() != "" && listed[comment.GetParentId()] {
+      replies[comment.GetParentId()] = append(replies[comment.GetParentId()], comment)
+    } else {
+      roots = append(roots, comment)
+    }
+  }
+
+  var print func(comment *pb.Comment, depth int)
+  print = func(comment *pb.Comment, depth int) {
+    indent := strings.Repeat("  ", depth)
+    fmt.Printf("%s%s on %s (%s)", indent, comment.GetAuthor(), comment.GetCreatedAt(), comment.GetId())
+    if comment.GetStatus() != pb.CommentStatus_APPROVED {
+      fmt.Printf(" [%s]", comment.GetStatus())
+    }
+    if comment.GetModerationReason() != "" {
+      fmt.Printf(" %s", comment.GetModerationReason())
+    }
+    fmt.Printf("\n%s  %s\n", indent, comment.GetContent())
+    for _, reply := range replies[comment.GetId()] {
+      print(reply, depth+1)
+    }
+  }
+  for _, comment := range roots {
+    if comment.GetParentId() != "" {
+      fmt.Println("In reply to a removed comment:")
+    }
+    print(comment, 0)
+  }
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "os"
+  "slices"
+  "sort"
+  "strings"
+  "time"
+)
+
+/*
+  SHELL COMPLETION
+
+  blogctl can complete its own command lines in bash, zsh and fish. Once the client is installed as blogctl (go build -o blogctl ./client, somewhere on your PATH), load the script of your shell from its startup file:
+
+    source <(blogctl completion bash)       # ~/.bashrc
+    source <(blogctl completion zsh)        # ~/.zshrc
+    blogctl completion fish | source        # ~/.config/fish/config.fish
+
+  The scripts are tiny on purpose: they pass the words typed so far to the hidden __complete command and show whatever it prints, one completion per line with an optional description after a tab. All the knowledge stays in Go, so the completions never fall behind the commands, and the three shells behave the same:
+
+    blogctl <TAB>                  the subcommands
+    blogctl webhooks <TAB>         add, list and remove
+    blogctl list -<TAB>            the flags of list
+    blogctl render -id <TAB>       the IDs of the posts on the server, with their titles
+    blogctl --profile <TAB>        the profiles in ~/.blogctl.yaml
+
+  Post IDs are fetched with SyncChanges from the server of -addr, or of the profile. When the server can't be reached within a second there is simply nothing to complete. When __complete has nothing to offer the shells fall back to completing file names, which is what upload and -json expect.
+*/
+const (
+  bashCompletion = `# bash completion for blogctl, load it with: source <(blogctl completion bash)
+_blogctl() {
+  local IFS=$'\n'
+  COMPREPLY=($(blogctl __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null | cut -f1))
+}
+complete -o default -F _blogctl blogctl
+`
+
+  zshCompletion = `#compdef blogctl
+# zsh completion for blogctl, load it with: source <(blogctl completion zsh)
+_blogctl() {
+  local -a completions
+  local line
+  for line in "${(@f)$(blogctl __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}"; do
+    [[ -n $line ]] || continue
+    if [[ $line == *$'\t'* ]]; then
+      completions+=("${${line%%$'\t'*}//:/\\:}:${line#*$'\t'}")
+    else
+      completions+=("${line//:/\\:}")
+    fi
+  done
+
+  if (( ${#completions} )); then
+    _describe blogctl completions
+  else
+    _files
+  fi
+}
+compdef _blogctl blogctl
+`
+
+  fishCompletion = `# fish completion for blogctl, load it with: blogctl completion fish | source
+function __blogctl_complete
+  set -l args (commandline -opc)[2..-1] (commandline -ct)
+  set -l completions (blogctl __complete $args 2>/dev/null)
+  if test (count $completions) -eq 0
+    __fish_complete_path (commandline -ct)
+    return
+  end
+  printf '%s\n' $completions
+end
+complete -c blogctl -f -a '(__blogctl_complete)'
+`
+)
+
+func setupCompletion(fs *flag.FlagSet) func(args []string) {
+
+  return func(args []string) {
+    if len(args) == 0 {
+      log.Fatalf("usage: completion bash|zsh|fish")
+    }
+
+    fs.Init("completion "+args[0], flag.ExitOnError)
+    fs.Parse(args[1:])
+
+    scripts := map[string]string{"bash": bashCompletion, "zsh": zshCompletion, "fish": fishCompletion}
+    script, ok := scripts[args[0]]
+    if !ok {
+      log.Fatalf("unknown shell %q, expected bash, zsh or fish", args[0])
+    }
+    os.Stdout.WriteString(script)
+  }
+}
+
+// runComplete prints the completions of the last word of args, the words typed after blogctl. It runs before the profile is loaded (see client.go) since the words may name a profile that doesn't exist yet, halfway through typing it.
+func runComplete(args []string) {
+  if len(args) == 0 {
+    args = []string{""}
+  }
+  current, typed := args[len(args)-1], args[:len(args)-1]
+
+  if len(typed) > 0 && (typed[len(typed)-1] == "-profile" || typed[len(typed)-1] == "--profile") {
+    completeProfiles(current)
+    return
+  }
+
+  // A profile that doesn't load leaves the defaults in place, which is as good as completions get.
+  if rest, err := loadProfile(typed); err == nil {
+    typed = rest
+  }
+
+  if len(typed) == 0 {
+    for _, cmd := range allCommands() {
+      if !cmd.hidden && strings.HasPrefix(cmd.name, current) {
+        fmt.Printf("%s\t%s\n", cmd.name, cmd.summary)
+      }
+    }
+    return
+  }
+
+  cmd := findCommand(typed[0])
+  if cmd == nil || cmd.hidden {
+    return
+  }
+
+  if len(cmd.verbs) > 0 && len(typed) == 1 {
+    for _, verb := range cmd.verbs {
+      if strings.HasPrefix(verb, current) {
+        fmt.Println(verb)
+      }
+    }
+    return
+  }
+
+  flags := cmd.flags()
+  lookup := func(arg string) *flag.Flag {
+    name := strings.TrimLeft(arg, "-")
+    for _, f := range flags {
+      if f.Name == name {
+        return f
+      }
+    }
+    return nil
+  }
+
+  // The word after a flag is its value.
+  if prev := typed[len(typed)-1]; strings.HasPrefix(prev, "-") && !strings.Contains(prev, "=") {
+    if f := lookup(prev); f != nil && !isBoolFlag(f) {
+      if slices.Contains(cmd.postFlags, f.Name) {
+        completePostIDs(serverAddr(typed, lookup("addr")), current)
+      }
+      return
+    }
+  }
+
+  if !strings.HasPrefix(current, "-") {
+    return
+  }
+
+  // Go flags take one dash or two, we complete the way the word was started.
+  dashes := "-"
+  if strings.HasPrefix(current, "--") {
+    dashes = "--"
+  }
+  for _, f := range flags {
+    if strings.HasPrefix(dashes+f.Name, current) {
+      fmt.Printf("%s%s\t%s\n", dashes, f.Name, f.Usage)
+    }
+  }
+  if strings.HasPrefix(dashes+"profile", current) {
+    fmt.Printf("%sprofile\tname of the server profile in ~/.blogctl.yaml\n", dashes)
+  }
+}
+
+func completeProfiles(current string) {
+  config, _, err := readConfig()
+  if err != nil {
+    return
+  }
+
+  names := make([]string, 0, len(config.Profiles))
+  for name := range config.Profiles {
+    if strings.HasPrefix(name, current) {
+      names = append(names, name)
+    }
+  }
+  sort.Strings(names)
+
+  for _, name := range names {
+    if p := config.Profiles[name]; p != nil && p.Address != "" {
+      fmt.Printf("%s\t%s\n", name, p.Address)
+    } else {
+      fmt.Println(name)
+    }
+  }
+}
+
+// serverAddr returns the -addr typed on the command line, or the flag's default (the profile's address) when there is none.
+func serverAddr(typed []string, addr *flag.Flag) string {
+  value := "localhost:3000"
+  if addr != nil {
+    value = addr.DefValue
+  }
+
+  for i, arg := range typed {
+    switch {
+    case (arg == "-addr" || arg == "--addr") && i+1 < len(typed):
+      value = typed[i+1]
+    case strings.HasPrefix(arg, "-addr=") || strings.HasPrefix(arg, "--addr="):
+      _, value, _ = strings.Cut(arg, "=")
+    }
+  }
+
+  return value
+}
+
+func completePostIDs(addr, current string) {
+  conn, err := dial(addr)
+  if err != nil {
+    return
+  }
+  defer conn.Close()
+
+  ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+  defer cancel()
+
+  res, err := pb.NewBlogClient(conn).SyncChanges(ctx, &pb.SyncChangesRequest{})
+  if err != nil {
+    return
+  }
+
+  for _, post := range res.GetPosts() {
+    if strings.HasPrefix(post.GetId(), current) {
+      // Titles are descriptions, they have to stay on one line.
+      fmt.Printf("%s\t%s\n", post.GetId(), truncate(post.GetTitle(), 60))
+    }
+  }
+}
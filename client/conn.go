@@ -0,0 +1,136 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  "go/tutorial/grpc/internal/compression"
+  "log"
+  "strings"
+  "time"
+
+  "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen/blogsdk"
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  _ "google.golang.org/grpc/encoding/gzip"
+  "google.golang.org/grpc/status"
+)
+
+// gRPC sends its own user agent (grpc-go/<version>) on every call, WithUserAgent puts ours in front of it.
+const userAgent = "blogctl/0.1"
+
+// dial connects to the server the same way the demo in client.go does, see the comments over there. The profile decides whether the connection uses TLS, see config.go.
+func dial(addr string) (*grpc.ClientConn, error) {
+  if strings.HasPrefix(addr, "xds:") && !xdsSupported {
+    return nil, fmt.Errorf("%s needs xDS, which this blogctl was built without, build it with -tags xds", addr)
+  }
+  creds, err := transportCredentials()
+  if err != nil {
+    return nil, err
+  }
+
+  opts := []grpc.DialOption{
+    grpc.WithTransportCredentials(creds),
+    grpc.WithUserAgent(userAgent),
+    // dns:/// and static:/// targets, see SEVERAL SERVERS below.
+    grpc.WithResolvers(blogsdk.StaticResolver()),
+    grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"round_robin": {}}]}`),
+    // Retried during maintenance (see below), with the session token and, for CreatePost, a solved challenge (see challenge.go)
+    grpc.WithChainUnaryInterceptor(retryMaintenanceInterceptor, sessionUnaryInterceptor, challengeUnaryInterceptor),
+    // The token of the session command, see session.go
+    grpc.WithChainStreamInterceptor(sessionStreamInterceptor),
+  }
+  // Through the proxy of the profile, see proxy.go
+  proxy, addr, err := proxyOptions(profile.Proxy, addr)
+  if err != nil {
+    return nil, err
+  }
+  opts = append(opts, proxy...)
+  // The server answers with the compressor of the call, see compression.go in the server.
+  if profile.Compressor != "" {
+    opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(profile.Compressor)))
+  }
+
+  return grpc.NewClient(addr, opts...)
+}
+
+/*
+  SEVERAL SERVERS
+
+  -addr takes a gRPC target and blogctl spreads its calls over every server the target resolves to, round robin, like the SDK does (see resolver.go in gen/blogsdk):
+
+    blogctl list -addr dns:///blog.internal:3000
+    blogctl list -addr static:///10.0.0.1:3000,10.0.0.2:3000
+
+  dns:/// connects to every address of the name, one per pod for a headless Kubernetes service, static:/// to the servers listed, which is what the addresses of a profile become (see config.go).
+*/
+
+/*
+  SERVICE DISCOVERY WITH XDS
+
+  A fleet of blog servers behind a service mesh comes and goes, no address stays good for long. With an xds:/// address blogctl asks the control plane of the mesh, Istio, Traffic Director or any other xDS server, which servers there are and how to balance the calls between them, instead of connecting to a fixed host:
+
+    GRPC_XDS_BOOTSTRAP=xds-bootstrap.json blogctl list -addr xds:///blog.example.com
+
+  The name after xds:/// is the listener the control plane knows the blog by. The bootstrap file of GRPC_XDS_BOOTSTRAP says where the control plane is and who is asking, xds-bootstrap.example.json next to this file is a starting point. The same address goes in the address of a profile (see config.go), the tls and spiffe sections still apply to the connections to the servers. The policy, round robin, least requests, a ring hash, comes from the control plane along with the servers, nothing to set on this side.
+
+  The servers need nothing either, the mesh registers them. xDS is only built in with the xds tag, see xds.go.
+*/
+// xdsSupported is set by xds.go when blogctl is built with the xds tag.
+var xdsSupported bool
+
+// Compressors must be registered before the first call, gzip registers itself when imported.
+func init() {
+  compression.RegisterZstd()
+}
+
+/*
+  RETRYING DURING MAINTENANCE
+
+  A server in maintenance turns calls away with Unavailable and a RetryInfo saying when to come back (see maintenance.go in the server). The call never reached a handler, so trying it again is safe even for a CreatePost. retryMaintenanceInterceptor waits as long as the server asks and tries again, a few times at most and never past the deadline of the call: a command with a one second timeout fails right away rather than hanging for the thirty seconds of a maintenance.
+
+  Other Unavailable errors, like the server being busy or the storage failing, aren't retried here, nothing says the call didn't get anywhere.
+*/
+const maxMaintenanceRetries = 3
+
+func retryMaintenanceInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+  for attempt := 0; ; attempt++ {
+    err := invoker(ctx, method, req, reply, cc, opts...)
+
+    delay, ok := maintenanceRetryDelay(err)
+    if !ok || attempt == maxMaintenanceRetries {
+      return err
+    }
+    if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+      return err
+    }
+
+    log.Printf("%v, retrying in %s", status.Convert(err).Message(), delay)
+    select {
+    case <-ctx.Done():
+      return err
+    case <-time.After(delay):
+    }
+  }
+}
+
+// maintenanceRetryDelay tells whether err comes from a server in maintenance, and how long it asked to wait.
+func maintenanceRetryDelay(err error) (time.Duration, bool) {
+  st := status.Convert(err)
+  if st.Code() != codes.Unavailable {
+    return 0, false
+  }
+
+  var maintenance bool
+  var delay time.Duration
+  for _, detail := range st.Details() {
+    switch d := detail.(type) {
+    case *errdetails.ErrorInfo:
+      maintenance = d.GetReason() == "MAINTENANCE"
+    case *errdetails.RetryInfo:
+      delay = d.GetRetryDelay().AsDuration()
+    }
+  }
+
+  return delay, maintenance
+}
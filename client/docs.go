@@ -0,0 +1,120 @@
+package main
+
+import (
+  "flag"
+  "fmt"
+  "io"
+  "log"
+  "os"
+  "path/filepath"
+  "strings"
+  "time"
+)
+
+/*
+  MAN PAGES
+
+  The docs command writes a man page for blogctl and one for every subcommand, generated from the table in commands.go and the flags each command declares, so they always match the binary they came from:
+
+    go run ./client docs -dir man
+    man ./man/blogctl-render.1
+
+  Copy the directory to a man1 folder on your MANPATH (e.g. /usr/local/share/man/man1) to read them with man blogctl.
+
+  The pages are written in roff, the format man reads, with the usual sections: NAME, SYNOPSIS, DESCRIPTION, OPTIONS and SEE ALSO. Only a handful of roff requests are needed: .TH starts the page, .SH a section, .TP a flag with its description indented below it, and .B/.I set bold and italic text.
+*/
+func setupDocs(fs *flag.FlagSet) func(args []string) {
+  dir := fs.String("dir", "man", "directory to write the man pages to")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    // The pages document the built-in defaults, not the ones of whatever profile is loaded.
+    profile = &clientProfile{}
+
+    if err := os.MkdirAll(*dir, 0o755); err != nil {
+      log.Fatalf("could not create %s: %v", *dir, err)
+    }
+
+    date := time.Now().Format("January 2006")
+    commands := allCommands()
+
+    writePage := func(name string, write func(w io.Writer)) {
+      path := filepath.Join(*dir, name+".1")
+      f, err := os.Create(path)
+      if err != nil {
+        log.Fatalf("could not create %s: %v", path, err)
+      }
+      write(f)
+      if err := f.Close(); err != nil {
+        log.Fatalf("could not write %s: %v", path, err)
+      }
+      fmt.Println("wrote", path)
+    }
+
+    writePage("blogctl", func(w io.Writer) {
+      fmt.Fprintf(w, ".TH BLOGCTL 1 %q\n", date)
+      fmt.Fprintf(w, ".SH NAME\nblogctl \\- command line client of the gRPC blog server\n")
+      fmt.Fprintf(w, ".SH SYNOPSIS\n.B blogctl\n[\\fIcommand\\fR] [\\fIoptions\\fR]\n")
+      fmt.Fprintf(w, ".SH DESCRIPTION\nWithout a command, blogctl creates a post on localhost:3000 and lists every post. Every command also takes \\fB\\-\\-profile\\fR to pick the server from ~/.blogctl.yaml.\n")
+      fmt.Fprintf(w, ".SH COMMANDS\n")
+      for _, cmd := range commands {
+        if !cmd.hidden {
+          fmt.Fprintf(w, ".TP\n.B %s\n%s\n", roff(cmd.name), roff(cmd.summary))
+        }
+      }
+      fmt.Fprintf(w, ".SH SEE ALSO\n")
+      var pages []string
+      for _, cmd := range commands {
+        if !cmd.hidden {
+          pages = append(pages, fmt.Sprintf(".BR blogctl\\-%s (1)", roff(cmd.name)))
+        }
+      }
+      fmt.Fprintln(w, strings.Join(pages, ",\n"))
+    })
+
+    for _, cmd := range commands {
+      if cmd.hidden {
+        continue
+      }
+
+      writePage("blogctl-"+cmd.name, func(w io.Writer) {
+        fmt.Fprintf(w, ".TH BLOGCTL\\-%s 1 %q\n", strings.ToUpper(roff(cmd.name)), date)
+        fmt.Fprintf(w, ".SH NAME\nblogctl\\-%s \\- %s\n", roff(cmd.name), roff(cmd.summary))
+        fmt.Fprintf(w, ".SH SYNOPSIS\n.B blogctl %s\n", roff(cmd.name))
+        if len(cmd.verbs) > 0 {
+          fmt.Fprintf(w, "%s\n", strings.Join(cmd.verbs, "|"))
+        }
+        fmt.Fprintf(w, "[\\fIoptions\\fR]\n")
+        fmt.Fprintf(w, ".SH DESCRIPTION\n%s.\n", roff(strings.ToUpper(cmd.summary[:1])+cmd.summary[1:]))
+
+        fmt.Fprintf(w, ".SH OPTIONS\n")
+        for _, f := range cmd.flags() {
+          if isBoolFlag(f) {
+            fmt.Fprintf(w, ".TP\n.B \\-%s\n", roff(f.Name))
+          } else {
+            fmt.Fprintf(w, ".TP\n.BI \\-%s \" value\"\n", roff(f.Name))
+          }
+          fmt.Fprintf(w, "%s", roff(f.Usage))
+          if f.DefValue != "" && f.DefValue != "false" && f.DefValue != "0" {
+            fmt.Fprintf(w, " (default: %s)", roff(f.DefValue))
+          }
+          fmt.Fprintln(w)
+        }
+        fmt.Fprintf(w, ".TP\n.BI \\-\\-profile \" name\"\nname of the server profile in ~/.blogctl.yaml\n")
+
+        fmt.Fprintf(w, ".SH SEE ALSO\n.BR blogctl (1)\n")
+      })
+    }
+  }
+}
+
+// roff escapes the characters roff would read as markup: backslashes, dashes (plain ones become hyphens) and dots or quotes starting a line.
+func roff(s string) string {
+  s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+  if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+    s = `\&` + s
+  }
+
+  return s
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "time"
+)
+
+/*
+  EMAIL SUBSCRIPTIONS
+
+    go run ./client subscribe -email me@example.com
+    go run ./client unsubscribe -token <token from the email>
+
+  The server only answers these when it was started with an SMTP server, see email.go on the server side.
+*/
+func setupSubscribe(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  email := fs.String("email", "", "address to send the new posts to")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(10*time.Second))
+    defer cancel()
+
+    if _, err := pb.NewBlogClient(conn).SubscribeByEmail(ctx, &pb.SubscribeByEmailRequest{Email: *email}); err != nil {
+      log.Fatalf("could not subscribe: %v", err)
+    }
+
+    fmt.Printf("Subscribed %s, check your inbox\n", *email)
+  }
+}
+
+func setupUnsubscribe(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := fs.String("token", "", "unsubscribe token found in every email")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    if _, err := pb.NewBlogClient(conn).UnsubscribeByEmail(ctx, &pb.UnsubscribeByEmailRequest{Token: *token}); err != nil {
+      log.Fatalf("could not unsubscribe: %v", err)
+    }
+
+    fmt.Println("Unsubscribed, you won't get any more emails")
+  }
+}
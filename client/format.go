@@ -0,0 +1,107 @@
+package main
+
+import (
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "strings"
+  "text/tabwriter"
+  "text/template"
+
+  "google.golang.org/protobuf/encoding/protojson"
+)
+
+/*
+  OUTPUT FORMATS
+
+  The subcommands that print posts (list, stream and search) take a -format flag:
+    - text: a few lines per post, the default
+    - table: one aligned row per post, handy to skim many posts
+    - json: one JSON object per line (JSON Lines), the same encoding the server uses in its logs, to pipe into jq and friends
+    - anything else is a Go text/template executed for every post, with the fields of pb.Post available by name
+
+    go run ./client list -format table
+    go run ./client list -format json | jq .Title
+    go run ./client list -format '{{.Title}} by {{.Author}} ({{.ViewCount}} views)'
+
+  The template gets a newline added when it doesn't end with one, so every post lands on its own line.
+*/
+type postPrinter struct {
+  w     io.Writer
+  print func(post *pb.Post) error
+  // flush is set for the formats that buffer their output.
+  flush func() error
+}
+
+func newPostPrinter(w io.Writer, format string) (*postPrinter, error) {
+  p := &postPrinter{w: w}
+
+  switch format {
+  case "", "text":
+    p.print = func(post *pb.Post) error {
+      pinned := ""
+      if post.GetPinned() {
+        pinned = " (pinned)"
+      }
+      _, err := fmt.Fprintf(w, "Title: %s%s\nAuthor: %s\n\n", post.GetTitle(), pinned, post.GetAuthor())
+      return err
+    }
+  case "table":
+    // tabwriter only knows how wide the columns are once it has seen every row, so nothing is written until flush.
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(tw, "ID\tTITLE\tAUTHOR\tTAGS\tSTATUS\tVIEWS\tREAD\tCREATED")
+    p.print = func(post *pb.Post) error {
+      _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%d min\t%s\n", post.GetId(), truncate(post.GetTitle(), 40), truncate(post.GetAuthor(), 20), truncate(strings.Join(post.GetTags(), ","), 30), post.GetStatus(), post.GetViewCount(), post.GetReadingMinutes(), post.GetCreatedAt())
+      return err
+    }
+    p.flush = tw.Flush
+  case "json":
+    p.print = func(post *pb.Post) error {
+      data, err := protojson.Marshal(post)
+      if err != nil {
+        return err
+      }
+      _, err = fmt.Fprintf(w, "%s\n", data)
+      return err
+    }
+  default:
+    if !strings.Contains(format, "{{") {
+      return nil, fmt.Errorf("unknown format %q, expected text, table, json or a template like '{{.Title}}'", format)
+    }
+    if !strings.HasSuffix(format, "\n") {
+      format += "\n"
+    }
+    tmpl, err := template.New("post").Option("missingkey=error").Parse(format)
+    if err != nil {
+      return nil, fmt.Errorf("invalid -format template: %w", err)
+    }
+    p.print = func(post *pb.Post) error {
+      return tmpl.Execute(w, post)
+    }
+  }
+
+  return p, nil
+}
+
+func (p *postPrinter) Print(post *pb.Post) error {
+  return p.print(post)
+}
+
+// Flush writes whatever the format held back, it must be called once every post was printed.
+func (p *postPrinter) Flush() error {
+  if p.flush == nil {
+    return nil
+  }
+
+  return p.flush()
+}
+
+// truncate keeps table rows on a single line, the full values are one -format json away.
+func truncate(s string, max int) string {
+  s = strings.Join(strings.Fields(s), " ")
+  if r := []rune(s); len(r) > max {
+    return string(r[:max-1]) + "…"
+  }
+
+  return s
+}
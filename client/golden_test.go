@@ -0,0 +1,271 @@
+package main
+
+import (
+  "bytes"
+  "context"
+  "encoding/json"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "net"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+  "time"
+
+  "google.golang.org/grpc"
+)
+
+/*
+  GOLDEN FILES
+
+  What blogctl prints is read by people and parsed by scripts, a column moving or a field renamed breaks either. The tests below run the subcommands against a server giving canned answers and compare their output with the files of testdata/:
+
+    go test ./client -run Golden
+    go test ./client -run Golden -update   rewrite the files with the output of today
+
+  A change of output is meant to show in the diff of a commit: run -update, read what changed in testdata/ and commit it with the code.
+
+  protojson adds spaces at random to its output so nobody comes to rely on it (see the json format in format.go), the JSON lines are compacted before being compared.
+*/
+var update = flag.Bool("update", false, "rewrite the golden files of testdata/ with the output of the commands")
+
+// TestMain prints the times of the goldens in UTC. time.Local is read from TZ the first time it is used, so TZ is set before anything runs: changing time.Local in a test would race with the goroutines of gRPC reading it.
+func TestMain(m *testing.M) {
+  os.Setenv("TZ", "UTC")
+  if _, offset := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local).Zone(); offset != 0 {
+    fmt.Fprintln(os.Stderr, "time.Local was read before TZ was set, the times wouldn't be printed in UTC")
+    os.Exit(1)
+  }
+
+  os.Exit(m.Run())
+}
+
+// The posts of cannedBlog: pinned, tagged, long enough to be truncated in a table, and one without views.
+var cannedPosts = []*pb.Post{
+  {Id: "post-1", Title: "Hello gRPC", Content: "The first post.", Author: "Ana", CreatedAt: "2025-06-01T09:00:00Z", ViewCount: 42, Status: pb.PostStatus_PUBLISHED, Tags: []string{"go", "grpc"}, Pinned: true, PinPosition: 1, ReadingMinutes: 1, Slug: "hello-grpc"},
+  {Id: "post-2", Title: "A title long enough to be cut short in the table", Content: "More words.", Author: "Bartholomew Fitzgerald-Smith", CreatedAt: "2025-06-02T14:30:00Z", ViewCount: 7, Status: pb.PostStatus_PUBLISHED, Tags: []string{"streaming", "interceptors", "deadlines"}, ReadingMinutes: 12, Slug: "a-title-long-enough"},
+  {Id: "post-3", Title: "Draft\twith a tab", Author: "Chen", CreatedAt: "2025-06-03T08:15:00Z", Status: pb.PostStatus_DRAFT, Slug: "draft-with-a-tab"},
+}
+
+type cannedBlog struct {
+  pb.UnimplementedBlogServer
+}
+
+func (cannedBlog) GetPosts(ctx context.Context, req *pb.GetPostsRequest) (*pb.Posts, error) {
+  return &pb.Posts{Posts: cannedPosts}, nil
+}
+
+func (cannedBlog) GetTrendingPosts(ctx context.Context, req *pb.GetTrendingPostsRequest) (*pb.TrendingPosts, error) {
+  return &pb.TrendingPosts{Posts: []*pb.TrendingPost{{Post: cannedPosts[0], Views: 30}, {Post: cannedPosts[1], Views: 5}}}, nil
+}
+
+func (cannedBlog) GetPostAnalytics(ctx context.Context, req *pb.GetPostAnalyticsRequest) (*pb.PostAnalytics, error) {
+  return &pb.PostAnalytics{PostId: req.GetPostId(), Views: 15, Buckets: []*pb.ViewBucket{
+    {Start: "2025-06-01T09:00:00Z", Views: 10},
+    {Start: "2025-06-01T10:00:00Z", Views: 0},
+    {Start: "2025-06-01T11:00:00Z", Views: 5},
+  }}, nil
+}
+
+func (cannedBlog) GetDailySummary(ctx context.Context, req *pb.GetDailySummaryRequest) (*pb.DailySummary, error) {
+  if req.GetPeriod() == pb.SummaryPeriod_SUMMARY_WEEK {
+    return &pb.DailySummary{Period: req.GetPeriod(), Start: "2025-06-02", End: "2025-06-08", Views: 40, Delta: 40, TopPosts: []*pb.PostSummary{
+      {PostId: "post-9", Author: "Ana", Views: 40, Delta: 40},
+    }, TopAuthors: []*pb.AuthorSummary{{Author: "Ana", Views: 40, Delta: 40}}}, nil
+  }
+  return &pb.DailySummary{Start: "2025-06-01", End: "2025-06-01", Views: 36, PreviousViews: 30, Delta: 6, DeltaPercent: 20, Complete: true, TopPosts: []*pb.PostSummary{
+    {PostId: "post-1", Title: "Hello gRPC", Author: "Ana", Views: 30, PreviousViews: 20, Delta: 10},
+    {PostId: "post-2", Title: "Streaming", Author: "Chen", Views: 6, PreviousViews: 10, Delta: -4},
+  }, TopAuthors: []*pb.AuthorSummary{
+    {Author: "Ana", Views: 30, PreviousViews: 20, Delta: 10},
+    {Author: "Chen", Views: 6, PreviousViews: 10, Delta: -4},
+  }}, nil
+}
+
+func (cannedBlog) GetAuthorProfile(ctx context.Context, req *pb.GetAuthorProfileRequest) (*pb.AuthorProfile, error) {
+  return &pb.AuthorProfile{Author: req.GetAuthor(), Bio: "Writes about RPCs", PostCount: 3, TotalViews: 120, RecentPosts: []*pb.Post{
+    {Id: "post-3", Title: "Deadlines", CreatedAt: "2025-06-03", ViewCount: 10},
+    {Id: "post-1", Title: "Hello gRPC", CreatedAt: "2025-06-01", ViewCount: 100},
+  }, TopTags: []*pb.TagUse{{Tag: "grpc", Posts: 3}, {Tag: "go", Posts: 1}}}, nil
+}
+
+func (cannedBlog) GetPublishingSchedule(ctx context.Context, req *pb.GetPublishingScheduleRequest) (*pb.PublishingSchedule, error) {
+  return &pb.PublishingSchedule{TimeZone: req.GetTimeZone(), Days: []*pb.ScheduledDay{
+    {Date: "2025-06-04", Posts: []*pb.ScheduledPost{
+      {Id: "post-4", Title: "Deadlines", Author: "Ana", PublishAt: "2025-06-04T09:00:00+02:00"},
+      {Id: "post-5", Title: "Retries", Author: "Chen", PublishAt: "2025-06-04T17:30:00+02:00"},
+    }},
+    {Date: "2025-06-10", Posts: []*pb.ScheduledPost{{Id: "post-6", Title: "Load balancing", Author: "Ana", PublishAt: "2025-06-10T11:00:00+02:00"}}},
+  }}, nil
+}
+
+type cannedAdmin struct {
+  pb.UnimplementedAdminServer
+}
+
+func (cannedAdmin) GetConnectionStats(ctx context.Context, req *pb.GetConnectionStatsRequest) (*pb.ConnectionStats, error) {
+  return &pb.ConnectionStats{
+    OpenConnections:     2,
+    ActiveStreams:       1,
+    AcceptedConnections: 10,
+    ClosedConnections:   8,
+    Listeners:           []*pb.ListenerStats{{Address: "[::]:3000", OpenConnections: 2, AcceptedConnections: 10}},
+    Peers: []*pb.PeerTraffic{
+      {Address: "10.0.0.7", OpenConnections: 1, ActiveStreams: 1, Calls: 12, BytesReceived: 2048, BytesSent: 65536, LastCallAt: "2025-06-01T09:30:00Z"},
+      {Address: "10.0.0.8", OpenConnections: 1},
+    },
+    RejectedCalls: 3,
+    MaxCallsPerIp: 20,
+  }, nil
+}
+
+func (cannedAdmin) ListConnections(ctx context.Context, req *pb.ListConnectionsRequest) (*pb.Connections, error) {
+  return &pb.Connections{Connections: []*pb.Connection{
+    {RemoteAddress: "10.0.0.7:51234", LocalAddress: "[::]:3000", OpenedAt: "2025-06-01T09:00:00Z", ActiveStreams: 1, Calls: 12, BytesReceived: 2048, BytesSent: 65536, LastCallAt: "2025-06-01T09:30:00Z"},
+    {RemoteAddress: "10.0.0.8:40000", LocalAddress: "[::]:3000", OpenedAt: "2025-06-01T09:10:00Z"},
+  }}, nil
+}
+
+func (cannedAdmin) StreamServerStats(req *pb.StreamServerStatsRequest, stream grpc.ServerStreamingServer[pb.ServerStats]) error {
+  for _, stats := range []*pb.ServerStats{
+    {Time: "2025-06-01T09:00:02Z", IntervalSeconds: 2, Calls: 25, Errors: 1, CallsPerSecond: 12.5, InFlight: 3, LatencyP50Ms: 1.25, LatencyP90Ms: 4, LatencyP99Ms: 18.5, LatencyMaxMs: 20, HeapAllocBytes: 12 << 20, SysBytes: 32 << 20, Goroutines: 41, GcRuns: 1, GcPauseMs: 0.2, Methods: []*pb.MethodStats{
+      {Method: "GetPosts", Calls: 20, MeanLatencyMs: 1.5},
+      {Method: "CreatePost", Calls: 4, Errors: 1, MeanLatencyMs: 9.75},
+      {Method: "WatchPosts", Calls: 1},
+    }},
+    {Time: "2025-06-01T09:00:04Z", IntervalSeconds: 2, InFlight: 1, HeapAllocBytes: 11 << 20, SysBytes: 32 << 20, Goroutines: 38},
+  } {
+    if err := stream.Send(stats); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+func (cannedAdmin) ExportEvents(req *pb.ExportEventsRequest, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+  if req.GetToBucket() {
+    return stream.Send(&pb.ExportChunk{Rows: 2, Bytes: 120, BucketKey: "exports/audit-20250601T090000Z.csv"})
+  }
+  for _, data := range []string{"time,identity,method\n2025-06-01T09:00:00Z,admin,", "DeletePost\n2025-06-01T09:05:00Z,ana,UpdatePost\n"} {
+    if err := stream.Send(&pb.ExportChunk{Data: []byte(data)}); err != nil {
+      return err
+    }
+  }
+  return stream.Send(&pb.ExportChunk{Rows: 2, Bytes: 86})
+}
+
+// startCannedServer serves cannedBlog and cannedAdmin on a port of localhost and returns its address.
+func startCannedServer(t *testing.T) string {
+  lis, err := net.Listen("tcp", "127.0.0.1:0")
+  if err != nil {
+    t.Fatal(err)
+  }
+  s := grpc.NewServer()
+  pb.RegisterBlogServer(s, cannedBlog{})
+  pb.RegisterAdminServer(s, cannedAdmin{})
+  go s.Serve(lis)
+  t.Cleanup(s.Stop)
+
+  return lis.Addr().String()
+}
+
+// runCommand runs a subcommand like blogctl does and returns what it printed on stdout.
+func runCommand(t *testing.T, args []string) string {
+  cmd := findCommand(args[0])
+  if cmd == nil {
+    t.Fatalf("unknown command %q", args[0])
+  }
+
+  out, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer out.Close()
+  stdout := os.Stdout
+  os.Stdout = out
+  defer func() { os.Stdout = stdout }()
+
+  cmd.run(args[1:])
+
+  data, err := os.ReadFile(out.Name())
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  return string(data)
+}
+
+// compactJSON compacts every line of output that is JSON, see GOLDEN FILES.
+func compactJSON(output string) string {
+  lines := strings.Split(output, "\n")
+  for i, line := range lines {
+    var buf bytes.Buffer
+    if json.Compact(&buf, []byte(line)) == nil {
+      lines[i] = buf.String()
+    }
+  }
+
+  return strings.Join(lines, "\n")
+}
+
+func checkGolden(t *testing.T, name, got string) {
+  path := filepath.Join("testdata", name+".golden")
+  if *update {
+    if err := os.MkdirAll("testdata", 0o755); err != nil {
+      t.Fatal(err)
+    }
+    if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+      t.Fatal(err)
+    }
+    return
+  }
+
+  want, err := os.ReadFile(path)
+  if err != nil {
+    t.Fatalf("%v, run go test -update to write it", err)
+  }
+  if got != string(want) {
+    t.Errorf("the output changed, run go test -update if it is meant to.\ngot:\n%s\nwant (%s):\n%s", got, path, want)
+  }
+}
+
+func TestGoldenOutput(t *testing.T) {
+  // The defaults of the flags come from the environment: no profile, session or cache of the machine running the tests. The times are printed in UTC, see TestMain.
+  home := t.TempDir()
+  t.Setenv("HOME", home)
+  t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+  t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+
+  addr := startCannedServer(t)
+  for _, test := range []struct {
+    golden string
+    args   []string
+  }{
+    {"list", []string{"list"}},
+    {"list-table", []string{"list", "-format", "table"}},
+    {"list-json", []string{"list", "-format", "json"}},
+    {"list-template", []string{"list", "-format", "{{.Title}} by {{.Author}} ({{.ViewCount}} views)"}},
+    {"trending", []string{"trending"}},
+    {"analytics", []string{"analytics", "-id", "post-1"}},
+    {"summary", []string{"summary"}},
+    {"author", []string{"author", "-name", "Ana", "-n", "2"}},
+    {"summary-week", []string{"summary", "-date", "2025-06-04", "-week"}},
+    {"schedule", []string{"schedule", "-tz", "Europe/Paris", "-token", "secret"}},
+    {"connections", []string{"connections", "-token", "secret"}},
+    {"connections-list", []string{"connections", "-list", "-token", "secret"}},
+    {"stats", []string{"stats", "-token", "secret", "-interval", "2s", "-methods", "2"}},
+    {"export", []string{"export", "-token", "secret", "-since", "2025-06-01"}},
+    {"export-bucket", []string{"export", "-token", "secret", "-bucket"}},
+  } {
+    t.Run(test.golden, func(t *testing.T) {
+      args := append([]string{test.args[0], "-addr", addr}, test.args[1:]...)
+      if test.args[0] == "list" {
+        args = append(args, "-cache", filepath.Join(t.TempDir(), "cache.db"))
+      }
+
+      checkGolden(t, test.golden, compactJSON(runCommand(t, args)))
+    })
+  }
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+  "context"
+  "go/tutorial/grpc/internal/reqctx"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  CALL METADATA
+
+  Metadata travels in both directions:
+    - Outgoing: metadata.AppendToOutgoingContext adds key/value pairs to every call made with the returned context.
+    - Incoming: the server can answer with headers (sent before the response) and trailers (sent after it). The grpc.Header and grpc.Trailer call options tell the generated client where to store them once the call is done.
+
+  The user agent is the odd one out: it is set once per connection with grpc.WithUserAgent (see conn.go) since gRPC doesn't let a single call override it.
+*/
+
+// callMetadata is what we can attach to an outgoing call, empty fields are not sent.
+type callMetadata struct {
+  // Preferred language for the server messages, e.g. "es" or "en-US".
+  Locale string
+  // Lets the caller choose the request ID, the server assigns one otherwise.
+  RequestID string
+}
+
+func withCallMetadata(ctx context.Context, m callMetadata) context.Context {
+  var kv []string
+  if m.Locale != "" {
+    kv = append(kv, reqctx.LocaleHeader, m.Locale)
+  }
+  if m.RequestID != "" {
+    kv = append(kv, reqctx.RequestIDHeader, m.RequestID)
+  }
+
+  return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// responseMetadata collects the headers and trailers the server sent back for a single call.
+type responseMetadata struct {
+  Header  metadata.MD
+  Trailer metadata.MD
+}
+
+// callOptions must be passed to the call whose metadata we want, e.g. c.GetPosts(ctx, req, md.callOptions()...)
+func (r *responseMetadata) callOptions() []grpc.CallOption {
+  return []grpc.CallOption{grpc.Header(&r.Header), grpc.Trailer(&r.Trailer)}
+}
+
+// get returns the first value of a key, looking at the headers first and the trailers second.
+func (r *responseMetadata) get(key string) string {
+  if values := r.Header.Get(key); len(values) > 0 {
+    return values[0]
+  }
+  if values := r.Trailer.Get(key); len(values) > 0 {
+    return values[0]
+  }
+
+  return ""
+}
+
+func (r *responseMetadata) requestID() string {
+  return r.get(reqctx.RequestIDHeader)
+}
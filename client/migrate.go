@@ -0,0 +1,127 @@
+package main
+
+import (
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/store"
+  "log"
+  "time"
+)
+
+/*
+  MOVING POSTS TO SQLITE
+
+  migrate-data copies the posts of a posts.json file into a SQLite database, so a blog that started on the file backend can switch to -storage sqlite without losing anything:
+
+    go run ./client migrate-data --from json --to sqlite -json posts.json -db blog.db -dry-run
+    go run ./client migrate-data --from json --to sqlite -json posts.json -db blog.db
+
+  It works on the files directly instead of going through the server, so stop the server first. On the way over the posts are cleaned up:
+    - posts written before IDs existed get one, the same backfill the server does when it loads them
+    - dates like 2025-06-03 become RFC 3339 timestamps (2025-06-03T00:00:00Z), the format the rest of the API already uses
+
+  -dry-run prints what would be migrated without creating the database. Both commands use the storage code of the server (internal/store), so the database gets exactly the schema the server expects.
+*/
+func setupMigrateData(fs *flag.FlagSet) func(args []string) {
+  from := fs.String("from", "json", "backend to read the posts from, only json is supported")
+  to := fs.String("to", "sqlite", "backend to write the posts to, only sqlite is supported")
+  jsonPath := fs.String("json", "posts.json", "posts file to read")
+  dbPath := fs.String("db", "blog.db", "SQLite database to write")
+  dryRun := fs.Bool("dry-run", false, "show what would be migrated without writing anything")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *from != "json" || *to != "sqlite" {
+      log.Fatalf("only --from json --to sqlite is supported")
+    }
+
+    posts, err := (&store.FileStore{Path: *jsonPath}).Load()
+    if err != nil {
+      log.Fatalf("could not read posts: %v", err)
+    }
+
+    for _, post := range posts {
+      change := ""
+      if post.Id == "" {
+        change = " (new ID)"
+      }
+      fmt.Printf("%s%s\n", post.GetTitle(), change)
+
+      if err := convertDates(post); err != nil {
+        log.Fatalf("post %q: %v", post.GetTitle(), err)
+      }
+    }
+    store.Backfill(posts)
+
+    if *dryRun {
+      fmt.Printf("\nWould migrate %d posts from %s to %s\n", len(posts), *jsonPath, *dbPath)
+      return
+    }
+
+    db, err := store.OpenSQLite(*dbPath)
+    if err != nil {
+      log.Fatalf("could not open database: %v", err)
+    }
+    defer db.Close()
+
+    if err := store.Migrate(db, "up"); err != nil {
+      log.Fatalf("could not migrate database: %v", err)
+    }
+
+    dst := &store.SQLiteStore{DB: db}
+    existing, err := dst.Load()
+    if err != nil {
+      log.Fatalf("could not read database: %v", err)
+    }
+    // Saving replaces every row, so merging into a database that is already in use would wipe its posts.
+    if len(existing) > 0 {
+      log.Fatalf("%s already has %d posts, refusing to overwrite them", *dbPath, len(existing))
+    }
+
+    if err := dst.Save(posts); err != nil {
+      log.Fatalf("could not write posts: %v", err)
+    }
+
+    fmt.Printf("\nMigrated %d posts from %s to %s\n", len(posts), *jsonPath, *dbPath)
+  }
+}
+
+// convertDates turns the dates of a post and its attachments into RFC 3339 timestamps, printing every change.
+func convertDates(post *pb.Post) error {
+  fields := []*string{&post.CreatedAt, &post.LastViewed}
+  for _, a := range post.Attachments {
+    fields = append(fields, &a.CreatedAt)
+  }
+
+  for _, field := range fields {
+    converted, err := toTimestamp(*field)
+    if err != nil {
+      return err
+    }
+    if converted != *field {
+      fmt.Printf("  %s -> %s\n", *field, converted)
+      *field = converted
+    }
+  }
+
+  return nil
+}
+
+// toTimestamp leaves empty values and timestamps alone and converts plain dates to midnight UTC.
+func toTimestamp(value string) (string, error) {
+  if value == "" {
+    return value, nil
+  }
+  if _, err := time.Parse(time.RFC3339, value); err == nil {
+    return value, nil
+  }
+
+  date, err := time.Parse("2006-01-02", value)
+  if err != nil {
+    return "", fmt.Errorf("unrecognized date %q", value)
+  }
+
+  return date.UTC().Format(time.RFC3339), nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+  "context"
+  "errors"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "log"
+  "os"
+  "os/signal"
+  "time"
+)
+
+/*
+  NOTIFICATIONS
+
+  The inbox of the session (see session.go): the posts created in it getting published. notifications lists it, -read marks a notification as read, -read-all all of them, and -follow prints the new ones as they come until Ctrl+C:
+
+    go run ./client session
+    go run ./client create -title "Out tomorrow" -publish-at 2025-06-05T09:00:00Z
+    go run ./client notifications -follow
+*/
+func setupNotifications(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  unread := fs.Bool("unread", false, "only the notifications not read yet")
+  limit := fs.Int("n", 20, "number of notifications to show, 0 shows all of them")
+  read := fs.String("read", "", "mark the notification with this ID as read")
+  readAll := fs.Bool("read-all", false, "mark every notification as read")
+  follow := fs.Bool("follow", false, "print the new notifications as they come")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+    c := pb.NewBlogClient(conn)
+
+    if *follow {
+      ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+      defer stop()
+
+      stream, err := c.StreamNotifications(ctx, &pb.StreamNotificationsRequest{})
+      if err != nil {
+        log.Fatalf("could not follow the notifications: %v", err)
+      }
+      for {
+        notification, err := stream.Recv()
+        if errors.Is(err, io.EOF) || ctx.Err() != nil {
+          return
+        }
+        if err != nil {
+          log.Fatalf("notifications stream failed: %v", err)
+        }
+        printNotification(notification)
+      }
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    if *read != "" || *readAll {
+      res, err := c.MarkNotificationRead(ctx, &pb.MarkNotificationReadRequest{Id: *read, All: *readAll})
+      if err != nil {
+        log.Fatalf("could not mark the notifications: %v", err)
+      }
+      fmt.Printf("Marked %d as read, %d unread left\n", len(res.GetNotifications()), res.GetUnreadCount())
+      return
+    }
+
+    res, err := c.ListNotifications(ctx, &pb.ListNotificationsRequest{UnreadOnly: *unread, Limit: int32(*limit)})
+    if err != nil {
+      log.Fatalf("could not list the notifications: %v", err)
+    }
+    if len(res.GetNotifications()) == 0 {
+      fmt.Println("No notifications")
+      return
+    }
+    for _, notification := range res.GetNotifications() {
+      printNotification(notification)
+    }
+    fmt.Printf("%d unread\n", res.GetUnreadCount())
+  }
+}
+
+func printNotification(n *pb.Notification) {
+  mark := "*"
+  if n.GetRead() {
+    mark = " "
+  }
+
+  var what string
+  switch n.GetType() {
+  case pb.NotificationType_NOTIFICATION_POST_PUBLISHED:
+    what = fmt.Sprintf("%q was published", n.GetPostTitle())
+  case pb.NotificationType_NOTIFICATION_NEW_COMMENT:
+    what = fmt.Sprintf("new comment on %q", n.GetPostTitle())
+  default:
+    what = fmt.Sprintf("%s on %q", n.GetType(), n.GetPostTitle())
+  }
+
+  fmt.Printf("%s %s  %s (%s)\n", mark, n.GetCreatedAt(), what, n.GetId())
+}
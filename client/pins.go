@@ -0,0 +1,78 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  PINNED POSTS
+
+  pin keeps a post at the top of list, above the posts that aren't pinned. -position puts it among the pinned posts, 1 is the first, and pinning a post that is pinned already moves it:
+
+    go run ./client pin -token secret -id <post id>
+    go run ./client pin -token secret -id <post id> -position 1
+    go run ./client unpin -token secret -id <post id>
+
+  The server caps how many posts are pinned at once (-max-pinned), past it a post has to be unpinned before pinning another one.
+*/
+func setupPin(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  id := fs.String("id", "", "ID of the post to pin")
+  position := fs.Int("position", 0, "where the post goes among the pinned posts, 1 is the first, 0 puts it last")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    post, err := callPins(*addr, *token, func(ctx context.Context, c pb.BlogClient) (*pb.Post, error) {
+      return c.PinPost(ctx, &pb.PinPostRequest{Id: *id, Position: int32(*position)})
+    })
+    if err != nil {
+      log.Fatalf("could not pin post: %v", err)
+    }
+
+    fmt.Printf("Pinned post %s at position %d\n", post.GetId(), post.GetPinPosition())
+  }
+}
+
+func setupUnpin(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  id := fs.String("id", "", "ID of the post to unpin")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    post, err := callPins(*addr, *token, func(ctx context.Context, c pb.BlogClient) (*pb.Post, error) {
+      return c.UnpinPost(ctx, &pb.UnpinPostRequest{Id: *id})
+    })
+    if err != nil {
+      log.Fatalf("could not unpin post: %v", err)
+    }
+
+    fmt.Printf("Unpinned post %s\n", post.GetId())
+  }
+}
+
+// callPins makes the call of pin or unpin with the admin token.
+func callPins(addr, token string, call func(ctx context.Context, c pb.BlogClient) (*pb.Post, error)) (*pb.Post, error) {
+  conn, err := dial(addr)
+  if err != nil {
+    log.Fatalf("failed to connect to grpc server: %v", err)
+  }
+  defer conn.Close()
+
+  ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+  defer cancel()
+
+  ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+
+  return call(ctx, pb.NewBlogClient(conn))
+}
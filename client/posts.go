@@ -0,0 +1,498 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "log"
+  "os"
+  "os/signal"
+  "strconv"
+  "strings"
+  "time"
+
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+/*
+  LISTING, CREATING AND WATCHING POSTS
+
+    go run ./client list -format table
+    go run ./client list -fields Id,Title
+    go run ./client list -sort reading-time-desc -min-reading 3
+    go run ./client get -id <post id>
+    go run ./client create -title "Hello" -content "..." -author me -publish-at 2025-06-04T09:00:00Z
+    go run ./client watch
+    go run ./client watch -types published -authors me,you
+    go run ./client watch -cursor 42
+
+  watch keeps the WatchPosts stream open and prints every change to a post as it happens. Try it in one terminal while scheduling a post a minute from now in another one, the POST_PUBLISHED event shows up when the minute is over.
+
+  When the stream closes for a maintenance, watch reconnects from the cursor of the last event it printed, so the changes made in between are printed too. -cursor does the same by hand, with a cursor printed by an earlier run.
+*/
+
+// list calls GetPosts. The posts are kept in the offline cache, which is what list prints when the server can't be reached (see cache.go).
+func setupList(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  format := fs.String("format", "text", "output format: text, table, json or a Go template, see format.go")
+  cachePath := fs.String("cache", defaultCachePath(), "path of the offline cache")
+  offline := fs.Bool("offline", false, "print the cached posts without contacting the server")
+  filter := filterFlags(fs)
+  fields := fs.String("fields", "", "comma separated Post fields to ask for, e.g. Id,Title, the others are left out by the server")
+  sortBy := fs.String("sort", "created", "order of the posts: created, reading-time or reading-time-desc, pinned posts always come first")
+  unread := fs.Bool("unread", false, "leave out the posts marked as read in the session, see session.go")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    // The cache can't be filtered, it only knows about the posts it holds. See bulk.go for the filter flags.
+    req := &pb.GetPostsRequest{Filter: filter(), UnreadOnly: *unread}
+    if paths := splitList(*fields); len(paths) > 0 {
+      req.ReadMask = &fieldmaskpb.FieldMask{Paths: paths}
+    }
+    // Only the unread posts are some of the posts too.
+    filtered := req.Filter != nil || req.UnreadOnly
+    if filtered && *offline {
+      log.Fatalf("the filter flags need the server, they can't be used with -offline")
+    }
+    switch *sortBy {
+    case "created":
+    case "reading-time":
+      req.OrderBy = pb.PostOrder_ORDER_READING_TIME
+    case "reading-time-desc":
+      req.OrderBy = pb.PostOrder_ORDER_READING_TIME_DESC
+    default:
+      log.Fatalf("unknown -sort %q, expected created, reading-time or reading-time-desc", *sortBy)
+    }
+
+    printer, err := newPostPrinter(os.Stdout, *format)
+    if err != nil {
+      log.Fatalf("%v", err)
+    }
+
+    cache, err := openCache(*cachePath)
+    if err != nil {
+      log.Fatalf("%v", err)
+    }
+    defer cache.Close()
+
+    var posts []*pb.Post
+    fromCache := *offline
+    if !*offline {
+      conn, err := dial(*addr)
+      if err != nil {
+        log.Fatalf("failed to connect to grpc server: %v", err)
+      }
+      defer conn.Close()
+
+      ctx, cancel := context.WithTimeout(context.Background(), callTimeout(5*time.Second))
+      defer cancel()
+
+      res, err := pb.NewBlogClient(conn).GetPosts(ctx, req)
+      switch {
+      case err == nil:
+        posts = res.GetPosts()
+        // A filtered list is only some of the posts and a projected one only some of their fields, replacing the cache with either would lose the rest. The cache keeps the posts in the order they were created.
+        if !filtered && req.ReadMask == nil && req.OrderBy == pb.PostOrder_ORDER_CREATED {
+          if err := cache.ReplacePosts(posts); err != nil {
+            log.Printf("could not update the offline cache: %v", err)
+          }
+        }
+      case unreachable(err) && filtered:
+        log.Fatalf("could not get posts, the server is unreachable and the cache can't be filtered: %v", err)
+      case unreachable(err):
+        log.Printf("server unreachable, showing the cached posts (%s)", cacheAge(cache))
+        fromCache = true
+      default:
+        log.Fatalf("could not get posts: %v", err)
+      }
+    }
+
+    if fromCache {
+      if posts, err = cache.Posts(); err != nil {
+        log.Fatalf("could not read the offline cache: %v", err)
+      }
+    }
+
+    for _, post := range posts {
+      if err := printer.Print(post); err != nil {
+        log.Fatalf("could not print post: %v", err)
+      }
+    }
+    if err := printer.Flush(); err != nil {
+      log.Fatalf("could not print posts: %v", err)
+    }
+  }
+}
+
+// get prints a single post from the offline cache, brought up to date with SyncChanges first when the server is reachable. With -slug the post is looked up with GetPostBySlug, the way a web frontend resolves a pretty URL, and the cache is only read when the server is unreachable.
+func setupGet(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post")
+  slug := fs.String("slug", "", "slug of the post, instead of -id")
+  format := fs.String("format", "text", "output format: text, table, json or a Go template, see format.go")
+  cachePath := fs.String("cache", defaultCachePath(), "path of the offline cache")
+  offline := fs.Bool("offline", false, "read the cache without contacting the server")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if (*id == "") == (*slug == "") {
+      log.Fatalf("usage: get -id <post id> | -slug <slug>")
+    }
+
+    printer, err := newPostPrinter(os.Stdout, *format)
+    if err != nil {
+      log.Fatalf("%v", err)
+    }
+
+    cache, err := openCache(*cachePath)
+    if err != nil {
+      log.Fatalf("%v", err)
+    }
+    defer cache.Close()
+
+    if !*offline {
+      conn, err := dial(*addr)
+      if err != nil {
+        log.Fatalf("failed to connect to grpc server: %v", err)
+      }
+      defer conn.Close()
+
+      if *slug != "" {
+        ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+        post, err := pb.NewBlogClient(conn).GetPostBySlug(ctx, &pb.GetPostBySlugRequest{Slug: *slug})
+        cancel()
+        if err == nil {
+          printPost(printer, post)
+          return
+        }
+        if !unreachable(err) {
+          log.Fatalf("could not get post: %v", err)
+        }
+      }
+
+      switch err := refreshCache(conn, cache); {
+      case err == nil:
+      case unreachable(err):
+        log.Printf("server unreachable, showing the cached post (%s)", cacheAge(cache))
+      default:
+        log.Fatalf("could not sync posts: %v", err)
+      }
+    }
+
+    var post *pb.Post
+    if *slug != "" {
+      posts, err := cache.Posts()
+      if err != nil {
+        log.Fatalf("could not read the offline cache: %v", err)
+      }
+      for _, p := range posts {
+        if p.GetSlug() == *slug {
+          post = p
+        }
+      }
+    } else {
+      post, err = cache.Post(*id)
+      if err != nil {
+        log.Fatalf("could not read the offline cache: %v", err)
+      }
+    }
+    if post == nil {
+      log.Fatalf("post %q not found", *id+*slug)
+    }
+
+    printPost(printer, post)
+  }
+}
+
+func printPost(printer *postPrinter, post *pb.Post) {
+  if err := printer.Print(post); err != nil {
+    log.Fatalf("could not print post: %v", err)
+  }
+  if err := printer.Flush(); err != nil {
+    log.Fatalf("could not print post: %v", err)
+  }
+}
+
+func setupCreate(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  title := fs.String("title", "", "title of the post")
+  content := fs.String("content", "", "content of the post, in Markdown")
+  author := fs.String("author", "", "author of the post")
+  publishAt := fs.String("publish-at", "", "RFC 3339 timestamp to publish the post at, empty to publish right away")
+  tags := fs.String("tags", "", "comma separated tags of the post")
+  allowDuplicate := fs.Bool("allow-duplicate", false, "create the post even if the server finds it repeats a recent one")
+  draft := fs.Bool("draft", false, "save the post as a draft, update -publish publishes it")
+  cachePath := fs.String("cache", defaultCachePath(), "path of the offline cache the post is queued in when the server is unreachable")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    var md responseMetadata
+    post, err := pb.NewBlogClient(conn).CreatePost(ctx, &pb.CreatePostRequest{
+      Title:          *title,
+      Content:        *content,
+      Author:         *author,
+      PublishAt:      *publishAt,
+      Tags:           splitList(*tags),
+      AllowDuplicate: *allowDuplicate,
+      Draft:          *draft,
+    }, md.callOptions()...)
+    if unreachable(err) {
+      queuePost(*cachePath, queuedPost{Title: *title, Content: *content, Author: *author, PublishAt: *publishAt, Tags: splitList(*tags), AllowDuplicate: *allowDuplicate, Draft: *draft})
+      return
+    }
+    if id := duplicateOf(err); id != "" {
+      log.Fatalf("post %s already says the same, pass -allow-duplicate to create this one anyway", id)
+    }
+    if err != nil {
+      log.Fatalf("could not create post: %v", err)
+    }
+
+    printCreated(post, &md)
+  }
+}
+
+// printCreated tells what became of a new post, create and create-from-template share it.
+func printCreated(post *pb.Post, md *responseMetadata) {
+  if post.GetStatus() == pb.PostStatus_DRAFT {
+    fmt.Printf("Created draft %s, update -id %s -publish publishes it\n", post.GetId(), post.GetId())
+  } else {
+    fmt.Printf("Created post %s (%s, publishing at %s)\n", post.GetId(), post.GetStatus(), post.GetPublishAt())
+  }
+  if id := md.get("x-duplicate-of"); id != "" {
+    fmt.Printf("Warning: the post looks like a duplicate of post %s\n", id)
+  }
+  if post.GetFlagged() {
+    fmt.Printf("The post was flagged for review: %s\n", post.GetFlagReason())
+  }
+}
+
+// duplicateOf returns the ID of the existing post a CreatePost was rejected for, from the ResourceInfo detail of the status.
+func duplicateOf(err error) string {
+  st, ok := status.FromError(err)
+  if !ok || st.Code() != codes.AlreadyExists {
+    return ""
+  }
+
+  for _, detail := range st.Details() {
+    if info, ok := detail.(*errdetails.ResourceInfo); ok {
+      return info.GetResourceName()
+    }
+  }
+
+  return ""
+}
+
+func setupWatch(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  types := fs.String("types", "", "comma separated event types to watch: created, updated, deleted, published, archived, unpublished. Empty watches all of them")
+  authors := fs.String("authors", "", "comma separated authors to watch, empty watches everyone")
+  cursor := fs.Int64("cursor", 0, "replay the changes made after the event with this cursor first")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    req := &pb.WatchPostsRequest{Cursor: *cursor}
+    for _, name := range splitList(*types) {
+      // The flag takes the short names, the enum values are prefixed with POST_.
+      t, ok := pb.PostEventType_value["POST_"+strings.ToUpper(name)]
+      if !ok {
+        log.Fatalf("unknown event type %q", name)
+      }
+      req.Types = append(req.Types, pb.PostEventType(t))
+    }
+    req.Authors = splitList(*authors)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    // No timeout here since the stream is meant to stay open, Ctrl+C cancels the context and closes it.
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+    defer stop()
+
+    for {
+      stream, err := pb.NewBlogClient(conn).WatchPosts(ctx, req)
+      if err != nil {
+        log.Fatalf("could not watch posts: %v", err)
+      }
+
+      err = watchStream(stream, req)
+      if err == io.EOF || ctx.Err() != nil {
+        return
+      }
+
+      // A server going into maintenance closes the stream saying when to come back, see conn.go
+      delay, ok := maintenanceRetryDelay(err)
+      if !ok {
+        log.Fatalf("watch stream failed: %v", err)
+      }
+      log.Printf("stream closed (%s): %v, reconnecting in %s from cursor %d", strings.Join(stream.Trailer().Get("x-close-reason"), ","), status.Convert(err).Message(), delay, req.Cursor)
+      select {
+      case <-ctx.Done():
+        return
+      case <-time.After(delay):
+      }
+    }
+  }
+}
+
+// watchStream prints the events of the stream until it ends, and returns why it did. The cursor of the request follows the events, for the next stream to resume from.
+func watchStream(stream grpc.ServerStreamingClient[pb.PostEvent], req *pb.WatchPostsRequest) error {
+  // Before the first event the cursor is the one the server started the stream at.
+  if header, err := stream.Header(); err == nil && len(header.Get("x-watch-cursor")) > 0 {
+    if cursor, err := strconv.ParseInt(header.Get("x-watch-cursor")[0], 10, 64); err == nil {
+      req.Cursor = max(req.Cursor, cursor)
+    }
+  }
+
+  for {
+    event, err := stream.Recv()
+    if err != nil {
+      return err
+    }
+    req.Cursor = max(req.Cursor, event.GetCursor())
+
+    post := event.GetPost()
+    fmt.Printf("%s: %s by %s (%s, cursor %d)\n", event.GetType(), post.GetTitle(), post.GetAuthor(), post.GetId(), event.GetCursor())
+  }
+}
+
+// splitList splits a comma separated flag value, ignoring empty entries.
+func splitList(value string) []string {
+  var items []string
+  for _, item := range strings.Split(value, ",") {
+    if item = strings.TrimSpace(item); item != "" {
+      items = append(items, item)
+    }
+  }
+
+  return items
+}
+
+func setupUpdate(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post to update")
+  title := fs.String("title", "", "new title, empty keeps the current one")
+  content := fs.String("content", "", "new content, empty keeps the current one")
+  author := fs.String("author", "", "new author, empty keeps the current one")
+  publishAt := fs.String("publish-at", "", "reschedule the post to this RFC 3339 timestamp")
+  tags := fs.String("tags", "", "comma separated tags replacing the current ones, empty keeps them")
+  publish := fs.Bool("publish", false, "publish the draft, at -publish-at when given")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    post, err := pb.NewBlogClient(conn).UpdatePost(ctx, &pb.UpdatePostRequest{
+      Id:        *id,
+      Title:     *title,
+      Content:   *content,
+      Author:    *author,
+      PublishAt: *publishAt,
+      Tags:      splitList(*tags),
+      Publish:   *publish,
+    })
+    if err != nil {
+      log.Fatalf("could not update post: %v", err)
+    }
+
+    if *publish {
+      fmt.Printf("Published draft %s (%s, publishing at %s)\n", post.GetId(), post.GetStatus(), post.GetPublishAt())
+      return
+    }
+    fmt.Printf("Updated post %s\n", post.GetId())
+  }
+}
+
+// setupRevisions lists the revisions of a post, or restores one of them when -restore is set.
+func setupRevisions(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post")
+  restore := fs.Int64("restore", 0, "number of the revision to restore")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    c := pb.NewBlogClient(conn)
+
+    if *restore != 0 {
+      post, err := c.RestoreRevision(ctx, &pb.RestoreRevisionRequest{PostId: *id, Number: *restore})
+      if err != nil {
+        log.Fatalf("could not restore revision: %v", err)
+      }
+
+      fmt.Printf("Restored revision %d of post %s\n", *restore, post.GetId())
+      return
+    }
+
+    revisions, err := c.ListRevisions(ctx, &pb.ListRevisionsRequest{PostId: *id})
+    if err != nil {
+      log.Fatalf("could not list revisions: %v", err)
+    }
+
+    for _, r := range revisions.GetRevisions() {
+      fmt.Printf("#%d %s\nTitle: %s\nAuthor: %s\n\n", r.GetNumber(), r.GetCreatedAt(), r.GetTitle(), r.GetAuthor())
+    }
+  }
+}
+
+func setupDelete(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post to delete")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    if _, err := pb.NewBlogClient(conn).DeletePost(ctx, &pb.DeletePostRequest{Id: *id}); err != nil {
+      log.Fatalf("could not delete post: %v", err)
+    }
+
+    fmt.Printf("Deleted post %s\n", *id)
+  }
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "strings"
+  "time"
+)
+
+// related prints the posts the server finds similar to a post, with the tags they share (see related.go on the server).
+func setupRelated(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post")
+  limit := fs.Int("n", 5, "number of posts to show")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *id == "" {
+      log.Fatalf("usage: related -id <post id> [-n 5]")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    related, err := pb.NewBlogClient(conn).GetRelatedPosts(ctx, &pb.GetRelatedPostsRequest{PostId: *id, Limit: int32(*limit)})
+    if err != nil {
+      log.Fatalf("could not get related posts: %v", err)
+    }
+
+    if len(related.GetPosts()) == 0 {
+      fmt.Println("No related posts")
+      return
+    }
+
+    for i, r := range related.GetPosts() {
+      fmt.Printf("%2d. %s by %s (score %.2f)", i+1, r.GetPost().GetTitle(), r.GetPost().GetAuthor(), r.GetScore())
+      if len(r.GetSharedTags()) > 0 {
+        fmt.Printf(", also tagged %s", strings.Join(r.GetSharedTags(), ", "))
+      }
+      fmt.Println()
+    }
+  }
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "html"
+  "log"
+  "time"
+)
+
+// setupRender prints the HTML the server produced for a post, e.g. go run ./client render -id <post id>
+func setupRender(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post to render")
+  lang := fs.String("lang", "", "language of the date and the error messages, e.g. es or fr")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *id == "" {
+      log.Fatalf("usage: render -id <post id>")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = withCallMetadata(ctx, callMetadata{Locale: *lang})
+
+    post, err := pb.NewBlogClient(conn).RenderPost(ctx, &pb.RenderPostRequest{Id: *id})
+    if err != nil {
+      log.Fatalf("could not render post: %v", err)
+    }
+
+    // Only the content is rendered by the server, the title is plain text so we escape it ourselves.
+    fmt.Printf("<h1>%s</h1>\n<p><time>%s</time></p>\n%s", html.EscapeString(post.GetTitle()), html.EscapeString(post.GetPublishedOn()), post.GetHtml())
+  }
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "strings"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  REPORTS
+
+  Anybody can report a published post or an approved comment that breaks the rules, with a reason, spam, harassment, offensive or other, and some details:
+
+    go run ./client reports post -post <post id> -reason spam
+    go run ./client reports comment -comment <comment id> -reason harassment -details "keeps insulting John"
+
+  The moderators go through them with the admin token. list shows the open reports, -resolved the resolved ones, and resolve closes one by dismissing it, hiding the content or also banning its author (see reports.go in the server):
+
+    go run ./client reports list -token secret
+    go run ./client reports resolve -token secret -id <report id> -action hide -note "off topic"
+*/
+var reportActions = map[string]pb.ReportResolution{
+  "dismiss": pb.ReportResolution_DISMISSED,
+  "hide":    pb.ReportResolution_CONTENT_HIDDEN,
+  "ban":     pb.ReportResolution_AUTHOR_BANNED,
+}
+
+func setupReports(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  postID := fs.String("post", "", "post: ID of the post to report, list: only the reports about this post")
+  commentID := fs.String("comment", "", "comment: ID of the comment to report")
+  reason := fs.String("reason", "other", "post, comment: spam, harassment, offensive or other")
+  details := fs.String("details", "", "post, comment: what is wrong, for the moderators")
+  resolved := fs.Bool("resolved", false, "list: the resolved reports instead of the open ones")
+  id := fs.String("id", "", "resolve: ID of the report")
+  action := fs.String("action", "", "resolve: dismiss, hide or ban")
+  note := fs.String("note", "", "resolve: why, kept with the report")
+
+  return func(args []string) {
+    if len(args) == 0 {
+      log.Fatalf("usage: reports post|comment|list|resolve [flags]")
+    }
+
+    fs.Init("reports "+args[0], flag.ExitOnError)
+    fs.Parse(args[1:])
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    if *token != "" {
+      ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    }
+    c := pb.NewBlogClient(conn)
+
+    switch args[0] {
+    case "post", "comment":
+      r, ok := pb.ReportReason_value["REPORT_"+strings.ToUpper(*reason)]
+      if !ok {
+        log.Fatalf("unknown reason %q, expected spam, harassment, offensive or other", *reason)
+      }
+
+      var report *pb.Report
+      if args[0] == "post" {
+        report, err = c.ReportPost(ctx, &pb.ReportPostRequest{PostId: *postID, Reason: pb.ReportReason(r), Details: *details})
+      } else {
+        report, err = c.ReportComment(ctx, &pb.ReportCommentRequest{CommentId: *commentID, Reason: pb.ReportReason(r), Details: *details})
+      }
+      if err != nil {
+        log.Fatalf("could not report the %s: %v", args[0], err)
+      }
+      fmt.Printf("Reported, thank you. The moderators will look at report %s\n", report.GetId())
+    case "list":
+      reports, err := c.ListReports(ctx, &pb.ListReportsRequest{Resolved: *resolved, PostId: *postID})
+      if err != nil {
+        log.Fatalf("could not list the reports: %v", err)
+      }
+      if len(reports.GetReports()) == 0 {
+        fmt.Println("No reports")
+        return
+      }
+      for _, report := range reports.GetReports() {
+        printReport(report)
+      }
+    case "resolve":
+      resolution, ok := reportActions[*action]
+      if !ok {
+        log.Fatalf("unknown action %q, expected dismiss, hide or ban", *action)
+      }
+      report, err := c.ResolveReport(ctx, &pb.ResolveReportRequest{Id: *id, Resolution: resolution, Note: *note})
+      if err != nil {
+        log.Fatalf("could not resolve the report: %v", err)
+      }
+      fmt.Printf("Report %s is %s\n", report.GetId(), report.GetResolution())
+    default:
+      log.Fatalf("unknown reports command %q, expected post, comment, list or resolve", args[0])
+    }
+  }
+}
+
+func printReport(report *pb.Report) {
+  what := "post " + report.GetPostId()
+  if report.GetCommentId() != "" {
+    what = fmt.Sprintf("comment %s on post %s", report.GetCommentId(), report.GetPostId())
+  }
+  by := report.GetReportedBy()
+  if by == "" {
+    by = "anonymous"
+  }
+
+  fmt.Printf("%s  %s: %s by %s (%s)\n", report.GetCreatedAt(), what, strings.TrimPrefix(report.GetReason().String(), "REPORT_"), by, report.GetId())
+  if report.GetDetails() != "" {
+    fmt.Printf("  %s\n", report.GetDetails())
+  }
+  if report.GetResolution() != pb.ReportResolution_UNRESOLVED {
+    fmt.Printf("  %s by %s on %s %s\n", report.GetResolution(), report.GetResolvedBy(), report.GetResolvedAt(), report.GetNote())
+  }
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "os"
+  "strings"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  PUBLISHING CALENDAR
+
+  schedule prints the posts waiting to be published, day by day. Scheduled posts are hidden from readers, so it takes the admin token:
+
+    go run ./client schedule -token secret
+    go run ./client schedule -token secret -from 2025-06-01 -to 2025-06-30 -tz America/Montreal
+
+  -tz decides which day a post falls on and the times printed, it defaults to the time zone of this machine.
+*/
+func setupSchedule(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  from := fs.String("from", "", "first day, as YYYY-MM-DD. Empty is today")
+  to := fs.String("to", "", "last day, as YYYY-MM-DD. Empty is 30 days after -from")
+  tz := fs.String("tz", localTimeZone(), "IANA time zone of the calendar, e.g. Europe/Paris")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+
+    schedule, err := pb.NewBlogClient(conn).GetPublishingSchedule(ctx, &pb.GetPublishingScheduleRequest{
+      From:     *from,
+      To:       *to,
+      TimeZone: *tz,
+    })
+    if err != nil {
+      log.Fatalf("could not get the publishing schedule: %v", err)
+    }
+
+    if len(schedule.GetDays()) == 0 {
+      fmt.Println("Nothing scheduled")
+      return
+    }
+
+    for _, day := range schedule.GetDays() {
+      date, _ := time.Parse("2006-01-02", day.GetDate())
+      fmt.Printf("%s\n", date.Format("Monday, January 2 2006"))
+      for _, post := range day.GetPosts() {
+        at, _ := time.Parse(time.RFC3339, post.GetPublishAt())
+        fmt.Printf("  %s  %s by %s (%s)\n", at.Format("15:04"), post.GetTitle(), post.GetAuthor(), post.GetId())
+      }
+    }
+    fmt.Printf("\nTimes are in %s\n", schedule.GetTimeZone())
+  }
+}
+
+// localTimeZone returns the IANA name of the time zone of this machine, UTC when it can't tell. time.Local can't help: it is always called "Local", so we read TZ and where /etc/localtime points like the C library does.
+func localTimeZone() string {
+  if tz := os.Getenv("TZ"); tz != "" {
+    return tz
+  }
+  if target, err := os.Readlink("/etc/localtime"); err == nil {
+    if _, name, ok := strings.Cut(target, "zoneinfo/"); ok {
+      return name
+    }
+  }
+
+  return "UTC"
+}
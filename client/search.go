@@ -0,0 +1,275 @@
+package main
+
+import (
+  "context"
+  "encoding/json"
+  "errors"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io/fs"
+  "log"
+  "os"
+  "path/filepath"
+  "sort"
+  "strings"
+  "time"
+  "unicode"
+)
+
+/*
+  OFFLINE SEARCH
+
+  The search subcommand keeps a small full-text index of the blog on disk. Every online search first calls the SyncChanges RPC with the cursor stored in the index, so only the posts created or changed since the last sync travel over the wire. With -offline the server isn't contacted at all and the query runs against whatever was synced last.
+
+    go run ./client search grpc tutorial
+    go run ./client search -offline grpc tutorial
+
+  The index is an inverted index: for every word we store the positions of the posts that contain it. Looking up a query is then a matter of intersecting the lists of its words instead of scanning every post.
+*/
+type localIndex struct {
+  Cursor int64            `json:"cursor"`
+  Posts  []indexedPost    `json:"posts"`
+  Terms  map[string][]int `json:"terms"`
+}
+
+type indexedPost struct {
+  Id      string `json:"id"`
+  Title   string `json:"title"`
+  Author  string `json:"author"`
+  Content string `json:"content"`
+}
+
+func setupSearch(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  offline := fs.Bool("offline", false, "don't sync with the server, only query the local index")
+  indexPath := fs.String("index", defaultIndexPath(), "path of the local index file")
+  format := fs.String("format", "text", "output format: text, table, json or a Go template, see format.go")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    printer, err := newPostPrinter(os.Stdout, *format)
+    if err != nil {
+      log.Fatalf("%v", err)
+    }
+
+    query := strings.Join(fs.Args(), " ")
+    if query == "" {
+      log.Fatalf("usage: search [-offline] <query>")
+    }
+
+    idx, err := loadIndex(*indexPath)
+    if err != nil {
+      log.Fatalf("could not load index: %v", err)
+    }
+
+    if !*offline {
+      if err := syncIndex(*addr, idx); err != nil {
+        log.Fatalf("could not sync index: %v", err)
+      }
+
+      if err := saveIndex(*indexPath, idx); err != nil {
+        log.Fatalf("could not save index: %v", err)
+      }
+    }
+
+    results := idx.search(query)
+    if len(results) == 0 {
+      fmt.Println("No posts found.")
+      return
+    }
+
+    // The index only keeps what search needs, the other fields of the posts are left empty.
+    for _, p := range results {
+      if err := printer.Print(&pb.Post{Id: p.Id, Title: p.Title, Author: p.Author, Content: p.Content}); err != nil {
+        log.Fatalf("could not print post: %v", err)
+      }
+    }
+    if err := printer.Flush(); err != nil {
+      log.Fatalf("could not print posts: %v", err)
+    }
+  }
+}
+
+// defaultIndexPath places the index in the user's cache directory, e.g. ~/.cache/blogctl/index.json on Linux.
+func defaultIndexPath() string {
+  dir, err := os.UserCacheDir()
+  if err != nil {
+    dir = os.TempDir()
+  }
+
+  return filepath.Join(dir, "blogctl", "index.json")
+}
+
+func loadIndex(path string) (*localIndex, error) {
+  idx := &localIndex{Terms: make(map[string][]int)}
+
+  data, err := os.ReadFile(path)
+  // A missing index isn't an error, it just means we have never synced.
+  if errors.Is(err, fs.ErrNotExist) {
+    return idx, nil
+  }
+  if err != nil {
+    return nil, err
+  }
+
+  if err := json.Unmarshal(data, idx); err != nil {
+    return nil, err
+  }
+
+  return idx, nil
+}
+
+func saveIndex(path string, idx *localIndex) error {
+  if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+    return err
+  }
+
+  data, err := json.Marshal(idx)
+  if err != nil {
+    return err
+  }
+
+  return os.WriteFile(path, data, 0644)
+}
+
+func syncIndex(addr string, idx *localIndex) error {
+  conn, err := dial(addr)
+  if err != nil {
+    return err
+  }
+  defer conn.Close()
+
+  ctx, cancel := context.WithTimeout(context.Background(), callTimeout(5*time.Second))
+  defer cancel()
+
+  res, err := pb.NewBlogClient(conn).SyncChanges(ctx, &pb.SyncChangesRequest{Cursor: idx.Cursor})
+  if err != nil {
+    return err
+  }
+
+  for _, p := range res.GetPosts() {
+    idx.add(p)
+  }
+  for _, id := range res.GetDeletedIds() {
+    idx.remove(id)
+  }
+  idx.Cursor = res.GetCursor()
+
+  return nil
+}
+
+// add indexes a post, replacing the previous version if the post has been synced before.
+func (idx *localIndex) add(p *pb.Post) {
+  post := indexedPost{
+    Id:      p.GetId(),
+    Title:   p.GetTitle(),
+    Author:  p.GetAuthor(),
+    Content: p.GetContent(),
+  }
+
+  doc := -1
+  for i, existing := range idx.Posts {
+    if existing.Id == post.Id {
+      doc = i
+    }
+  }
+
+  if doc == -1 {
+    doc = len(idx.Posts)
+    idx.Posts = append(idx.Posts, post)
+  } else {
+    idx.removeTerms(doc)
+    idx.Posts[doc] = post
+  }
+
+  for _, term := range post.terms() {
+    idx.Terms[term] = append(idx.Terms[term], doc)
+  }
+}
+
+// remove drops a deleted post. Every post after it moves one position up, so the term lists are rebuilt from scratch.
+func (idx *localIndex) remove(id string) {
+  for i, existing := range idx.Posts {
+    if existing.Id != id {
+      continue
+    }
+
+    idx.Posts = append(idx.Posts[:i], idx.Posts[i+1:]...)
+    idx.Terms = make(map[string][]int)
+    for doc, post := range idx.Posts {
+      for _, term := range post.terms() {
+        idx.Terms[term] = append(idx.Terms[term], doc)
+      }
+    }
+    return
+  }
+}
+
+func (idx *localIndex) removeTerms(doc int) {
+  for _, term := range idx.Posts[doc].terms() {
+    docs := idx.Terms[term]
+    for i, d := range docs {
+      if d == doc {
+        idx.Terms[term] = append(docs[:i], docs[i+1:]...)
+        break
+      }
+    }
+    if len(idx.Terms[term]) == 0 {
+      delete(idx.Terms, term)
+    }
+  }
+}
+
+func (p indexedPost) terms() []string {
+  return tokenize(p.Title + " " + p.Author + " " + p.Content)
+}
+
+// search returns the posts that contain every word of the query, most recent first.
+func (idx *localIndex) search(query string) []indexedPost {
+  terms := tokenize(query)
+  if len(terms) == 0 {
+    return nil
+  }
+
+  matches := make(map[int]int)
+  for _, term := range terms {
+    for _, doc := range idx.Terms[term] {
+      matches[doc]++
+    }
+  }
+
+  docs := make([]int, 0, len(matches))
+  for doc, n := range matches {
+    if n == len(terms) {
+      docs = append(docs, doc)
+    }
+  }
+  sort.Sort(sort.Reverse(sort.IntSlice(docs)))
+
+  results := make([]indexedPost, 0, len(docs))
+  for _, doc := range docs {
+    results = append(results, idx.Posts[doc])
+  }
+
+  return results
+}
+
+// tokenize lower cases the text and splits it on anything that isn't a letter or a digit, dropping duplicates.
+func tokenize(text string) []string {
+  words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+    return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+  })
+
+  seen := make(map[string]bool)
+  terms := make([]string, 0, len(words))
+  for _, w := range words {
+    if !seen[w] {
+      seen[w] = true
+      terms = append(terms, w)
+    }
+  }
+
+  return terms
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  SERIES
+
+  series groups posts meant to be read in order, changing a series takes the admin token:
+
+    go run ./client series create -token secret -title "gRPC from scratch" -posts <id>,<id>
+    go run ./client series list
+    go run ./client series add -token secret -id <series id> -post <post id> -position 2
+    go run ./client series remove -token secret -id <series id> -post <post id>
+    go run ./client series reorder -token secret -id <series id> -posts <id>,<id>,<id>
+    go run ./client series get -id <series id>
+    go run ./client series delete -token secret -id <series id>
+
+  get prints the published posts of the series in their order, with the posts before and after each of them.
+*/
+func setupSeries(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  id := fs.String("id", "", "get, add, remove, reorder, delete: ID of the series")
+  title := fs.String("title", "", "create: title of the series")
+  description := fs.String("description", "", "create: what the series is about")
+  posts := fs.String("posts", "", "create, reorder: comma separated IDs of the posts, in their order")
+  post := fs.String("post", "", "add, remove: ID of the post")
+  position := fs.Int("position", 0, "add: where the post goes in the series, 1 is the first, 0 puts it last")
+
+  return func(args []string) {
+    if len(args) == 0 {
+      log.Fatalf("usage: series create|list|get|add|remove|reorder|delete [flags]")
+    }
+
+    fs.Init("series "+args[0], flag.ExitOnError)
+    fs.Parse(args[1:])
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    c := pb.NewBlogClient(conn)
+
+    var series *pb.Series
+    var action string
+    switch args[0] {
+    case "create":
+      action = "create the series"
+      series, err = c.CreateSeries(ctx, &pb.CreateSeriesRequest{Title: *title, Description: *description, PostIds: splitList(*posts)})
+    case "list":
+      all, err := c.ListSeries(ctx, &pb.ListSeriesRequest{})
+      if err != nil {
+        log.Fatalf("could not list series: %v", err)
+      }
+      for _, series := range all.GetSeries() {
+        fmt.Printf("%s %q (%d posts)\n", series.GetId(), series.GetTitle(), len(series.GetPostIds()))
+      }
+      return
+    case "get":
+      res, err := c.GetSeries(ctx, &pb.GetSeriesRequest{Id: *id})
+      if err != nil {
+        log.Fatalf("could not get series: %v", err)
+      }
+      fmt.Printf("%s\n", res.GetSeries().GetTitle())
+      if description := res.GetSeries().GetDescription(); description != "" {
+        fmt.Printf("%s\n", description)
+      }
+      fmt.Println()
+      for _, entry := range res.GetPosts() {
+        fmt.Printf("%d. %s (%s)\n", entry.GetPosition(), entry.GetPost().GetTitle(), entry.GetPost().GetId())
+        if entry.GetPreviousId() != "" {
+          fmt.Printf("   previous: %s\n", entry.GetPreviousId())
+        }
+        if entry.GetNextId() != "" {
+          fmt.Printf("   next: %s\n", entry.GetNextId())
+        }
+      }
+      return
+    case "add":
+      action = "add the post to the series"
+      series, err = c.AddToSeries(ctx, &pb.AddToSeriesRequest{SeriesId: *id, PostId: *post, Position: int32(*position)})
+    case "remove":
+      action = "remove the post from the series"
+      series, err = c.RemoveFromSeries(ctx, &pb.RemoveFromSeriesRequest{SeriesId: *id, PostId: *post})
+    case "reorder":
+      action = "reorder the series"
+      series, err = c.ReorderSeries(ctx, &pb.ReorderSeriesRequest{SeriesId: *id, PostIds: splitList(*posts)})
+    case "delete":
+      if _, err := c.DeleteSeries(ctx, &pb.DeleteSeriesRequest{Id: *id}); err != nil {
+        log.Fatalf("could not delete series: %v", err)
+      }
+      fmt.Printf("Deleted series %s\n", *id)
+      return
+    default:
+      log.Fatalf("unknown series command %q, expected create, list, get, add, remove, reorder or delete", args[0])
+    }
+    if err != nil {
+      log.Fatalf("could not %s: %v", action, err)
+    }
+
+    fmt.Printf("Series %s %q:\n", series.GetId(), series.GetTitle())
+    for i, postID := range series.GetPostIds() {
+      fmt.Printf("%d. %s\n", i+1, postID)
+    }
+  }
+}
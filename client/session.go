@@ -0,0 +1,187 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "os"
+  "path/filepath"
+  "strings"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  SESSIONS
+
+  The server tells anonymous readers apart with a session token (see sessions.go in the server). The session command asks for one and keeps it in the cache directory, ~/.cache/blogctl/session on Linux, and from then on dial sends it in the x-session metadata of every call: views are counted for the session and the history command lists what was viewed.
+
+    go run ./client session
+    go run ./client view -id <post id>
+    go run ./client history -n 10
+
+  read marks a post as read to the end, list -unread leaves those out and history -in-progress only shows the posts viewed but not read yet:
+
+    go run ./client read -id <post id>
+    go run ./client list -unread
+    go run ./client history -in-progress
+
+  Running session again renews the token for the same viewer. -forget deletes it, the next calls are anonymous again.
+*/
+const sessionHeader = "x-session"
+
+func sessionPath() string {
+  dir, err := os.UserCacheDir()
+  if err != nil {
+    dir = os.TempDir()
+  }
+
+  return filepath.Join(dir, "blogctl", "session")
+}
+
+// sessionToken returns the saved token, empty when there is none.
+func sessionToken() string {
+  data, err := os.ReadFile(sessionPath())
+  if err != nil {
+    return ""
+  }
+
+  return strings.TrimSpace(string(data))
+}
+
+func withSession(ctx context.Context) context.Context {
+  if token := sessionToken(); token != "" {
+    return metadata.AppendToOutgoingContext(ctx, sessionHeader, token)
+  }
+
+  return ctx
+}
+
+// sessionUnaryInterceptor and sessionStreamInterceptor add the saved token to every call of dial.
+func sessionUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+  return invoker(withSession(ctx), method, req, reply, cc, opts...)
+}
+
+func sessionStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+  return streamer(withSession(ctx), desc, cc, method, opts...)
+}
+
+// session starts a session, or renews the saved one, and saves the token.
+func setupSession(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  forget := fs.Bool("forget", false, "delete the saved session instead")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *forget {
+      if err := os.Remove(sessionPath()); err != nil && !os.IsNotExist(err) {
+        log.Fatalf("could not delete the session: %v", err)
+      }
+      fmt.Println("Session forgotten")
+      return
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    session, err := pb.NewBlogClient(conn).StartSession(ctx, &pb.StartSessionRequest{})
+    if err != nil {
+      log.Fatalf("could not start a session: %v", err)
+    }
+
+    path := sessionPath()
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+      log.Fatalf("could not create the cache directory: %v", err)
+    }
+    // The token is as good as the reader's name, only the user may read it.
+    if err := os.WriteFile(path, []byte(session.GetToken()+"\n"), 0o600); err != nil {
+      log.Fatalf("could not save the session: %v", err)
+    }
+
+    fmt.Printf("Viewer %s, the session lasts until %s\n", session.GetViewerId(), session.GetExpiresAt())
+  }
+}
+
+// history prints the posts viewed in the session, the most recent first.
+func setupHistory(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  limit := fs.Int("n", 20, "number of posts to show, 0 shows all of them")
+  inProgress := fs.Bool("in-progress", false, "only the posts viewed but not marked as read")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    history, err := pb.NewBlogClient(conn).GetReadingHistory(ctx, &pb.GetReadingHistoryRequest{Limit: int32(*limit), InProgress: *inProgress})
+    if err != nil {
+      log.Fatalf("could not get the reading history: %v", err)
+    }
+
+    if len(history.GetEntries()) == 0 {
+      fmt.Println("No posts viewed yet")
+      return
+    }
+
+    for _, entry := range history.GetEntries() {
+      fmt.Printf("%s  %s by %s (%s)", entry.GetViewedAt(), entry.GetPost().GetTitle(), entry.GetPost().GetAuthor(), entry.GetPost().GetId())
+      if entry.GetRead() {
+        fmt.Print(", read")
+      }
+      fmt.Println()
+    }
+  }
+}
+
+// read marks a post as read in the session, or with -unread as not read.
+func setupRead(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  id := fs.String("id", "", "ID of the post")
+  unread := fs.Bool("unread", false, "mark the post as not read instead")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    if *id == "" {
+      log.Fatalf("usage: read -id <post id> [-unread]")
+    }
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    entry, err := pb.NewBlogClient(conn).MarkAsRead(ctx, &pb.MarkAsReadRequest{PostId: *id, Unread: *unread})
+    if err != nil {
+      log.Fatalf("could not mark the post: %v", err)
+    }
+
+    if entry.GetRead() {
+      fmt.Printf("Marked %q as read\n", entry.GetPost().GetTitle())
+    } else {
+      fmt.Printf("Marked %q as not read\n", entry.GetPost().GetTitle())
+    }
+  }
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "time"
+)
+
+// sitemap prints the sitemap.xml of the server, which needs -site-url (see sitemap.go on the server).
+func setupSitemap(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    sitemap, err := pb.NewBlogClient(conn).GetSitemap(ctx, &pb.GetSitemapRequest{})
+    if err != nil {
+      log.Fatalf("could not get the sitemap: %v", err)
+    }
+
+    fmt.Print(sitemap.GetXml())
+  }
+}
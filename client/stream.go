@@ -0,0 +1,98 @@
+package main
+
+import (
+  "context"
+  "flag"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "log"
+  "os"
+  "os/signal"
+  "time"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  RESUMING A STREAM
+
+  stream lists the posts through StreamPosts and remembers the cursor of the last post it printed. When the stream breaks with codes.Unavailable, which is what a restarting server or a dropped connection look like, it opens a new stream with that cursor instead of starting over:
+
+    go run ./client stream
+    go run ./client stream -cursor <cursor>     # continue a listing from an earlier run
+
+  The cursor of every post is printed with -v, so a listing can also be continued by hand.
+*/
+func setupStream(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  cursor := fs.String("cursor", "", "resume after the post with this cursor")
+  retries := fs.Int("retries", 5, "how many times to resume a dropped stream before giving up")
+  verbose := fs.Bool("v", false, "print the cursor of every post on stderr")
+  format := fs.String("format", "text", "output format: text, table, json or a Go template, see format.go")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    printer, err := newPostPrinter(os.Stdout, *format)
+    if err != nil {
+      log.Fatalf("%v", err)
+    }
+    // A table is only written once the stream is over, whichever way it ends.
+    defer printer.Flush()
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+    defer stop()
+
+    c := pb.NewBlogClient(conn)
+    last := *cursor
+
+    for attempt := 0; ; attempt++ {
+      err := streamPosts(ctx, c, printer, &last, *verbose)
+      if err == nil || ctx.Err() != nil {
+        return
+      }
+
+      if status.Code(err) != codes.Unavailable || attempt >= *retries {
+        log.Fatalf("stream failed: %v (resume with -cursor %q)", err, last)
+      }
+
+      log.Printf("stream dropped (%v), resuming", err)
+      time.Sleep(time.Second)
+    }
+  }
+}
+
+// streamPosts prints posts until the stream ends, keeping cursor pointed at the last post printed.
+func streamPosts(ctx context.Context, c pb.BlogClient, printer *postPrinter, cursor *string, verbose bool) error {
+  stream, err := c.StreamPosts(ctx, &pb.StreamPostsRequest{Cursor: *cursor})
+  if err != nil {
+    return err
+  }
+
+  for {
+    res, err := stream.Recv()
+    if err == io.EOF {
+      return nil
+    }
+    if err != nil {
+      return err
+    }
+
+    // The cursor goes to stderr so it doesn't get in the way of the output format.
+    if verbose {
+      log.Printf("cursor: %s", res.GetCursor())
+    }
+    if err := printer.Print(res.GetPost()); err != nil {
+      log.Fatalf("could not print post: %v", err)
+    }
+
+    *cursor = res.GetCursor()
+  }
+}
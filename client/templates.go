@@ -0,0 +1,165 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "strings"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  POST TEMPLATES
+
+  templates keeps the templates of the server, changing them takes the admin token. The placeholders, like {{author}} or {{date}}, are filled by create-from-template:
+
+    go run ./client templates add -token secret -name weekly -title "Weekly digest of {{date}}" -content "This week {{author}} picked {{pick}}"
+    go run ./client templates list
+    go run ./client templates get -id weekly
+    go run ./client templates update -token secret -id weekly -tags digest,weekly
+    go run ./client templates remove -token secret -id weekly
+    go run ./client create-from-template -template weekly -author alice -value pick="the new gRPC release"
+
+  -id and -template take the ID or the name of a template. -value is repeated once per placeholder, author and date are filled by the server.
+*/
+func setupTemplates(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  id := fs.String("id", "", "get, update, remove: ID or name of the template")
+  name := fs.String("name", "", "add, update: name of the template")
+  title := fs.String("title", "", "add, update: title of the posts, with placeholders")
+  content := fs.String("content", "", "add, update: content of the posts, in Markdown with placeholders")
+  tags := fs.String("tags", "", "add, update: comma separated tags of the posts")
+
+  return func(args []string) {
+    if len(args) == 0 {
+      log.Fatalf("usage: templates add|list|get|update|remove [flags]")
+    }
+
+    fs.Init("templates "+args[0], flag.ExitOnError)
+    fs.Parse(args[1:])
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    c := pb.NewBlogClient(conn)
+
+    switch args[0] {
+    case "add":
+      tmpl, err := c.CreateTemplate(ctx, &pb.CreateTemplateRequest{Name: *name, Title: *title, Content: *content, Tags: splitList(*tags)})
+      if err != nil {
+        log.Fatalf("could not create template: %v", err)
+      }
+      fmt.Printf("Created template %s (%s)\n", tmpl.GetName(), tmpl.GetId())
+      printPlaceholders(tmpl)
+    case "list":
+      templates, err := c.ListTemplates(ctx, &pb.ListTemplatesRequest{})
+      if err != nil {
+        log.Fatalf("could not list templates: %v", err)
+      }
+      for _, tmpl := range templates.GetTemplates() {
+        fmt.Printf("%s %s %q\n", tmpl.GetId(), tmpl.GetName(), tmpl.GetTitle())
+        printPlaceholders(tmpl)
+      }
+    case "get":
+      tmpl, err := c.GetTemplate(ctx, &pb.GetTemplateRequest{Id: *id})
+      if err != nil {
+        log.Fatalf("could not get template: %v", err)
+      }
+      fmt.Printf("Name: %s\nTitle: %s\nTags: %s\n\n%s\n", tmpl.GetName(), tmpl.GetTitle(), strings.Join(tmpl.GetTags(), ","), tmpl.GetContent())
+    case "update":
+      tmpl, err := c.UpdateTemplate(ctx, &pb.UpdateTemplateRequest{Id: *id, Name: *name, Title: *title, Content: *content, Tags: splitList(*tags)})
+      if err != nil {
+        log.Fatalf("could not update template: %v", err)
+      }
+      fmt.Printf("Updated template %s\n", tmpl.GetName())
+      printPlaceholders(tmpl)
+    case "remove":
+      if _, err := c.DeleteTemplate(ctx, &pb.DeleteTemplateRequest{Id: *id}); err != nil {
+        log.Fatalf("could not delete template: %v", err)
+      }
+      fmt.Printf("Deleted template %s\n", *id)
+    default:
+      log.Fatalf("unknown templates command %q, expected add, list, get, update or remove", args[0])
+    }
+  }
+}
+
+func printPlaceholders(tmpl *pb.PostTemplate) {
+  if len(tmpl.GetPlaceholders()) > 0 {
+    fmt.Printf("Placeholders: {{%s}}\n", strings.Join(tmpl.GetPlaceholders(), "}} {{"))
+  }
+}
+
+func setupCreateFromTemplate(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  template := fs.String("template", "", "ID or name of the template")
+  author := fs.String("author", "", "author of the post, the value of {{author}}")
+  publishAt := fs.String("publish-at", "", "RFC 3339 timestamp to publish the post at, empty to publish right away")
+  draft := fs.Bool("draft", false, "save the post as a draft, update -publish publishes it")
+  values := valuesFlag{}
+  fs.Var(values, "value", "name=value of a placeholder, repeat it for every placeholder")
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    var md responseMetadata
+    post, err := pb.NewBlogClient(conn).CreatePostFromTemplate(ctx, &pb.CreatePostFromTemplateRequest{
+      Template:  *template,
+      Author:    *author,
+      Values:    values,
+      PublishAt: *publishAt,
+      Draft:     *draft,
+    }, md.callOptions()...)
+    if id := duplicateOf(err); id != "" {
+      log.Fatalf("post %s already says the same", id)
+    }
+    if err != nil {
+      log.Fatalf("could not create post: %v", err)
+    }
+
+    printCreated(post, &md)
+  }
+}
+
+// valuesFlag collects the name=value pairs of a repeated flag.
+type valuesFlag map[string]string
+
+func (v valuesFlag) String() string {
+  pairs := make([]string, 0, len(v))
+  for name, value := range v {
+    pairs = append(pairs, name+"="+value)
+  }
+
+  return strings.Join(pairs, ",")
+}
+
+func (v valuesFlag) Set(pair string) error {
+  name, value, ok := strings.Cut(pair, "=")
+  if !ok || name == "" {
+    return fmt.Errorf("expected name=value, got %q", pair)
+  }
+  v[name] = value
+
+  return nil
+}
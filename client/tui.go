@@ -0,0 +1,376 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "log"
+  "strings"
+  "time"
+
+  "github.com/charmbracelet/bubbles/textarea"
+  "github.com/charmbracelet/bubbles/textinput"
+  tea "github.com/charmbracelet/bubbletea"
+  "github.com/charmbracelet/lipgloss"
+)
+
+/*
+  INTERACTIVE MODE
+
+  The tui subcommand is a full screen client: the posts on the left, the selected one on the right, and new posts showing up as they are published without refreshing anything.
+
+    go run ./client tui
+
+    ↑/↓ or j/k   select a post
+    n            write a new post
+    e            edit the selected post
+    ctrl+s       save the post being written
+    tab          next field of the editor
+    esc          leave the editor without saving
+    q            quit
+
+  The screen is drawn with Bubble Tea, which follows the Elm architecture: the whole state lives in one model, Update receives every event (a key press, a resize, a response from the server) as a message and returns the new model, and View turns the model into the text on screen. Nothing else touches the model, which keeps a program that does several things at once free of locks.
+
+  That matters here because two things talk to the server at the same time. The posts are loaded once with SyncChanges, then a goroutine keeps a WatchPosts stream open and hands every event to the program with Send. Update applies them to the list the same way it applies the posts we save ourselves, our own changes come back through the stream too.
+
+  Calls made from Update, like saving a post, run as commands: functions Bubble Tea calls in their own goroutine, whose result comes back to Update as one more message. Update never blocks, so the screen keeps up with the stream while a call is in flight.
+*/
+func setupTUI(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+
+  return func(args []string) {
+    fs.Parse(args)
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    client := pb.NewBlogClient(conn)
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(5*time.Second))
+    res, err := client.SyncChanges(ctx, &pb.SyncChangesRequest{})
+    cancel()
+    if err != nil {
+      log.Fatalf("could not load posts: %v", err)
+    }
+
+    m := newTUIModel(client, res.GetPosts())
+    p := tea.NewProgram(m, tea.WithAltScreen())
+
+    // The stream lives as long as the program, cancelling it when we quit ends the goroutine.
+    ctx, stop := context.WithCancel(context.Background())
+    defer stop()
+    go watchForTUI(ctx, client, p)
+
+    if _, err := p.Run(); err != nil {
+      log.Fatalf("tui failed: %v", err)
+    }
+  }
+}
+
+func watchForTUI(ctx context.Context, client pb.BlogClient, p *tea.Program) {
+  stream, err := client.WatchPosts(ctx, &pb.WatchPostsRequest{})
+  if err != nil {
+    p.Send(errMsg{fmt.Errorf("could not watch posts: %w", err)})
+    return
+  }
+
+  for {
+    event, err := stream.Recv()
+    if ctx.Err() != nil {
+      return
+    }
+    if err == io.EOF {
+      p.Send(errMsg{fmt.Errorf("the server closed the watch stream, new posts won't show up")})
+      return
+    }
+    if err != nil {
+      p.Send(errMsg{fmt.Errorf("watch stream failed: %w", err)})
+      return
+    }
+    p.Send(eventMsg{event})
+  }
+}
+
+// The messages Update receives on top of Bubble Tea's own (keys and window sizes).
+type (
+  eventMsg struct{ event *pb.PostEvent }
+  savedMsg struct{ post *pb.Post }
+  errMsg   struct{ err error }
+)
+
+type tuiModel struct {
+  client pb.BlogClient
+
+  // posts are the newest first, selected is an index into them.
+  posts    []*pb.Post
+  selected int
+  status   string
+
+  // editing is set while the editor is open, editID is the post being edited, empty for a new one.
+  editing bool
+  editID  string
+  fields  []textinput.Model
+  content textarea.Model
+  // focus is the index of the focused field, len(fields) is the content.
+  focus int
+
+  width, height int
+}
+
+func newTUIModel(client pb.BlogClient, posts []*pb.Post) *tuiModel {
+  m := &tuiModel{client: client, status: fmt.Sprintf("%d posts, watching for new ones", len(posts))}
+
+  // SyncChanges returns the oldest first.
+  for i := len(posts) - 1; i >= 0; i-- {
+    m.posts = append(m.posts, posts[i])
+  }
+
+  for _, placeholder := range []string{"Title", "Author"} {
+    field := textinput.New()
+    field.Placeholder = placeholder
+    m.fields = append(m.fields, field)
+  }
+  m.content = textarea.New()
+  m.content.Placeholder = "Content, in Markdown"
+
+  return m
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+  return nil
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+  switch msg := msg.(type) {
+  case tea.WindowSizeMsg:
+    m.width, m.height = msg.Width, msg.Height
+    for i := range m.fields {
+      m.fields[i].Width = msg.Width - 12
+    }
+    m.content.SetWidth(msg.Width - 2)
+    m.content.SetHeight(max(msg.Height-8, 3))
+    return m, nil
+
+  case eventMsg:
+    m.apply(msg.event)
+    return m, nil
+
+  case savedMsg:
+    m.editing = false
+    m.status = fmt.Sprintf("saved %q", msg.post.GetTitle())
+    return m, nil
+
+  case errMsg:
+    m.status = "error: " + msg.err.Error()
+    return m, nil
+
+  case tea.KeyMsg:
+    if msg.String() == "ctrl+c" {
+      return m, tea.Quit
+    }
+    if m.editing {
+      return m.updateEditor(msg)
+    }
+    return m.updateList(msg)
+  }
+
+  return m, nil
+}
+
+// apply keeps the list in sync with the events of the watch stream.
+func (m *tuiModel) apply(event *pb.PostEvent) {
+  post := event.GetPost()
+
+  i := m.index(post.GetId())
+  switch {
+  case event.GetType() == pb.PostEventType_POST_DELETED, event.GetType() == pb.PostEventType_POST_ARCHIVED, event.GetType() == pb.PostEventType_POST_UNPUBLISHED:
+    if i >= 0 {
+      m.posts = append(m.posts[:i], m.posts[i+1:]...)
+      m.selected = min(m.selected, max(len(m.posts)-1, 0))
+    }
+  case i >= 0:
+    m.posts[i] = post
+  default:
+    m.posts = append([]*pb.Post{post}, m.posts...)
+    // Keep the same post selected while new ones push it down.
+    if len(m.posts) > 1 {
+      m.selected++
+    }
+  }
+
+  m.status = fmt.Sprintf("%s: %s", strings.ToLower(strings.TrimPrefix(event.GetType().String(), "POST_")), post.GetTitle())
+}
+
+func (m *tuiModel) index(id string) int {
+  for i, post := range m.posts {
+    if post.GetId() == id {
+      return i
+    }
+  }
+
+  return -1
+}
+
+func (m *tuiModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+  switch msg.String() {
+  case "q":
+    return m, tea.Quit
+  case "up", "k":
+    m.selected = max(m.selected-1, 0)
+  case "down", "j":
+    m.selected = min(m.selected+1, max(len(m.posts)-1, 0))
+  case "n":
+    return m, m.openEditor(&pb.Post{})
+  case "e":
+    if len(m.posts) > 0 {
+      return m, m.openEditor(m.posts[m.selected])
+    }
+  }
+
+  return m, nil
+}
+
+func (m *tuiModel) openEditor(post *pb.Post) tea.Cmd {
+  m.editing, m.editID = true, post.GetId()
+  m.fields[0].SetValue(post.GetTitle())
+  m.fields[1].SetValue(post.GetAuthor())
+  m.content.SetValue(post.GetContent())
+
+  return m.focusField(0)
+}
+
+func (m *tuiModel) focusField(focus int) tea.Cmd {
+  m.focus = focus
+  for i := range m.fields {
+    m.fields[i].Blur()
+  }
+  m.content.Blur()
+
+  if focus < len(m.fields) {
+    return m.fields[focus].Focus()
+  }
+  return m.content.Focus()
+}
+
+func (m *tuiModel) updateEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+  switch msg.String() {
+  case "esc":
+    m.editing = false
+    return m, nil
+  case "tab":
+    return m, m.focusField((m.focus + 1) % (len(m.fields) + 1))
+  case "shift+tab":
+    return m, m.focusField((m.focus + len(m.fields)) % (len(m.fields) + 1))
+  case "ctrl+s":
+    m.status = "saving..."
+    return m, m.save()
+  }
+
+  var cmd tea.Cmd
+  if m.focus < len(m.fields) {
+    m.fields[m.focus], cmd = m.fields[m.focus].Update(msg)
+  } else {
+    m.content, cmd = m.content.Update(msg)
+  }
+
+  return m, cmd
+}
+
+// save returns the command that sends the editor's post to the server, the values are read now since the command runs later.
+func (m *tuiModel) save() tea.Cmd {
+  client, id := m.client, m.editID
+  title, author, content := m.fields[0].Value(), m.fields[1].Value(), m.content.Value()
+
+  return func() tea.Msg {
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    var post *pb.Post
+    var err error
+    if id == "" {
+      post, err = client.CreatePost(ctx, &pb.CreatePostRequest{Title: title, Author: author, Content: content})
+    } else {
+      post, err = client.UpdatePost(ctx, &pb.UpdatePostRequest{Id: id, Title: title, Author: author, Content: content})
+    }
+    if err != nil {
+      return errMsg{err}
+    }
+
+    return savedMsg{post}
+  }
+}
+
+var (
+  selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+  titleStyle    = lipgloss.NewStyle().Bold(true)
+  dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+  paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("62")).Padding(0, 1)
+)
+
+func (m *tuiModel) View() string {
+  // Nothing fits before the first WindowSizeMsg, which Bubble Tea sends right away.
+  if m.width == 0 {
+    return ""
+  }
+
+  if m.editing {
+    return m.editorView()
+  }
+
+  // Two panes with their borders, then the help and status lines.
+  height := max(m.height-4, 1)
+  listWidth := m.width * 2 / 5
+  postWidth := m.width - listWidth - 4
+
+  // Scroll the list so the selected post stays on screen.
+  first := max(m.selected-height+1, 0)
+  var rows []string
+  for i := first; i < len(m.posts) && i < first+height; i++ {
+    row := truncate(m.posts[i].GetTitle(), max(listWidth-4, 2))
+    if i == m.selected {
+      rows = append(rows, selectedStyle.Render("> "+row))
+    } else {
+      rows = append(rows, "  "+row)
+    }
+  }
+  if len(m.posts) == 0 {
+    rows = append(rows, dimStyle.Render("no posts yet, press n to write one"))
+  }
+
+  var post string
+  if len(m.posts) > 0 {
+    p := m.posts[m.selected]
+    post = titleStyle.Render(p.GetTitle()) + "\n" +
+      dimStyle.Render(fmt.Sprintf("by %s · %s · %d views by %d viewers", p.GetAuthor(), strings.ToLower(p.GetStatus().String()), p.GetViewCount(), p.GetUniqueViewers())) +
+      "\n\n" + p.GetContent()
+  }
+
+  panes := lipgloss.JoinHorizontal(lipgloss.Top,
+    paneStyle.Width(listWidth-2).Height(height).Render(strings.Join(rows, "\n")),
+    paneStyle.Width(postWidth-2).Height(height).MaxHeight(height+2).Render(post),
+  )
+
+  return panes + "\n" + dimStyle.Render("↑/↓ select · n new · e edit · q quit") + "\n" + m.status
+}
+
+func (m *tuiModel) editorView() string {
+  heading := "New post"
+  if m.editID != "" {
+    heading = "Editing " + m.editID
+  }
+
+  var b strings.Builder
+  b.WriteString(titleStyle.Render(heading) + "\n\n")
+  for i, label := range []string{"Title", "Author"} {
+    fmt.Fprintf(&b, "%-8s %s\n", label, m.fields[i].View())
+  }
+  b.WriteString("\n" + m.content.View() + "\n")
+  b.WriteString(dimStyle.Render("tab next field · ctrl+s save · esc cancel") + "\n" + m.status)
+
+  return b.String()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "strings"
+  "time"
+
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  MANAGING WEBHOOKS
+
+  The webhook RPCs are restricted to admins, so every webhooks command takes the admin token:
+
+    go run ./client webhooks add -token secret -url https://example.com/hooks/blog -types created,published
+    go run ./client webhooks list -token secret
+    go run ./client webhooks remove -token secret -id <webhook id>
+
+  add prints the signing secret, keep it: the server never shows it again.
+*/
+func setupWebhooks(fs *flag.FlagSet) func(args []string) {
+  addr := addrFlag(fs)
+  token := tokenFlag(fs)
+  hookURL := fs.String("url", "", "add: URL to deliver the events to")
+  secret := fs.String("secret", "", "add: signing secret, empty lets the server generate one")
+  types := fs.String("types", "created,published", "add: comma separated event types to deliver, empty delivers all of them")
+  id := fs.String("id", "", "remove: ID of the webhook")
+
+  return func(args []string) {
+    if len(args) == 0 {
+      log.Fatalf("usage: webhooks add|list|remove [flags]")
+    }
+
+    fs.Init("webhooks "+args[0], flag.ExitOnError)
+    fs.Parse(args[1:])
+
+    conn, err := dial(*addr)
+    if err != nil {
+      log.Fatalf("failed to connect to grpc server: %v", err)
+    }
+    defer conn.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout(time.Second))
+    defer cancel()
+
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+    c := pb.NewBlogClient(conn)
+
+    switch args[0] {
+    case "add":
+      req := &pb.RegisterWebhookRequest{Url: *hookURL, Secret: *secret}
+      for _, name := range splitList(*types) {
+        t, ok := pb.PostEventType_value["POST_"+strings.ToUpper(name)]
+        if !ok {
+          log.Fatalf("unknown event type %q", name)
+        }
+        req.Types = append(req.Types, pb.PostEventType(t))
+      }
+
+      hook, err := c.RegisterWebhook(ctx, req)
+      if err != nil {
+        log.Fatalf("could not register webhook: %v", err)
+      }
+      fmt.Printf("Registered webhook %s\nSecret: %s\n", hook.GetId(), hook.GetSecret())
+    case "list":
+      hooks, err := c.ListWebhooks(ctx, &pb.ListWebhooksRequest{})
+      if err != nil {
+        log.Fatalf("could not list webhooks: %v", err)
+      }
+      for _, hook := range hooks.GetWebhooks() {
+        fmt.Printf("%s %s %v (since %s)\n", hook.GetId(), hook.GetUrl(), hook.GetTypes(), hook.GetCreatedAt())
+      }
+    case "remove":
+      if _, err := c.UnregisterWebhook(ctx, &pb.UnregisterWebhookRequest{Id: *id}); err != nil {
+        log.Fatalf("could not unregister webhook: %v", err)
+      }
+      fmt.Printf("Unregistered webhook %s\n", *id)
+    default:
+      log.Fatalf("unknown webhooks command %q, expected add, list or remove", args[0])
+    }
+  }
+}
@@ -0,0 +1,16 @@
+//go:build xds
+
+package main
+
+/*
+  The xDS resolver and balancers pull in the Envoy APIs and their dependencies, a lot for a command line tool, so they are only built in with the xds tag, see conn.go:
+
+    go build -tags xds -o blogctl ./client
+
+  go.mod requires them all the same, and TestBuildWithXDS of xds_test.go builds with the tag so they stay there.
+*/
+import _ "google.golang.org/grpc/xds"
+
+func init() {
+  xdsSupported = true
+}
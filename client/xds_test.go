@@ -0,0 +1,21 @@
+package main
+
+import (
+  "os"
+  "os/exec"
+  "testing"
+)
+
+// TestBuildWithXDS builds blogctl with the xds tag, which the other tests don't: the Envoy APIs it pulls in must stay in go.mod and go.sum. See xds.go
+func TestBuildWithXDS(t *testing.T) {
+  if testing.Short() {
+    t.Skip("building with the xds tag takes a while")
+  }
+
+  build := exec.Command("go", "build", "-tags", "xds", "-o", os.DevNull, ".")
+  // -mod=readonly fails on a missing requirement instead of adding it.
+  build.Env = append(os.Environ(), "GOFLAGS=-mod=readonly")
+  if out, err := build.CombinedOutput(); err != nil {
+    t.Fatalf("go build -tags xds: %v\n%s", err, out)
+  }
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/clock"
+  "path/filepath"
+  "slices"
+  "testing"
+  "time"
+)
+
+// useFakeClock stops the clock of the server at now for the test, see clock.go
+func useFakeClock(tb testing.TB, now time.Time) *clock.Fake {
+  tb.Helper()
+
+  fake := clock.NewFake(now)
+  previous := serverClock
+  serverClock = fake
+  tb.Cleanup(func() { serverClock = previous })
+
+  return fake
+}
+
+// waitForTimers waits until n timers wait for the clock, a loop had to go to sleep before Advance can wake it up.
+func waitForTimers(t *testing.T, fake *clock.Fake, n int) {
+  t.Helper()
+
+  deadline := time.Now().Add(5 * time.Second)
+  for fake.Timers() != n {
+    if time.Now().After(deadline) {
+      t.Fatalf("%d timers wait for the clock, want %d", fake.Timers(), n)
+    }
+    time.Sleep(time.Millisecond)
+  }
+}
+
+func TestSchedulerFollowsTheClock(t *testing.T) {
+  fake := useFakeClock(t, time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC))
+
+  post := &pb.Post{Id: "later", Title: "Later"}
+  if err := schedulePost(post, "2025-06-04T10:00:00Z"); err != nil {
+    t.Fatal(err)
+  }
+  useFileStore(t, []*pb.Post{post})
+
+  s := &server{broker: newPostBroker(), scheduleChanged: make(chan struct{}, 1)}
+  events, unsubscribe := s.broker.subscribe(&pb.WatchPostsRequest{})
+  defer unsubscribe()
+
+  ctx, cancel := context.WithCancel(context.Background())
+  done := make(chan error)
+  go func() { done <- s.runScheduler(ctx) }()
+  defer func() {
+    cancel()
+    if err := <-done; err != nil {
+      t.Error(err)
+    }
+  }()
+
+  // A minute early the scheduler wakes up for nothing and goes back to sleep.
+  waitForTimers(t, fake, 1)
+  fake.Advance(59 * time.Minute)
+  waitForTimers(t, fake, 1)
+  select {
+  case event := <-events:
+    t.Fatalf("got %v at 9:59, the post is due at 10:00", event)
+  default:
+  }
+
+  fake.Advance(time.Minute)
+  select {
+  case event := <-events:
+    if event.Type != pb.PostEventType_POST_PUBLISHED || event.Post.GetId() != "later" {
+      t.Fatalf("got %v, want later published", event)
+    }
+  case <-time.After(5 * time.Second):
+    t.Fatal("the post wasn't published at 10:00")
+  }
+}
+
+func TestMirrorCacheExpiresWithTheClock(t *testing.T) {
+  fake := useFakeClock(t, time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC))
+  useFileStore(t, []*pb.Post{{Id: "first", Title: "First"}})
+
+  m := &mirrorServer{ttl: time.Minute}
+  snapshot := func() int {
+    t.Helper()
+
+    posts, err := m.snapshot(context.Background())
+    if err != nil {
+      t.Fatal(err)
+    }
+    return len(posts.Posts)
+  }
+
+  if got := snapshot(); got != 1 {
+    t.Fatalf("got %d posts, want 1", got)
+  }
+  if err := postStore.Save([]*pb.Post{{Id: "first", Title: "First"}, {Id: "second", Title: "Second"}}); err != nil {
+    t.Fatal(err)
+  }
+
+  fake.Advance(59 * time.Second)
+  if got := snapshot(); got != 1 {
+    t.Fatalf("got %d posts before the ttl, want the cached 1", got)
+  }
+  fake.Advance(time.Second)
+  if got := snapshot(); got != 2 {
+    t.Fatalf("got %d posts after the ttl, want 2", got)
+  }
+}
+
+func TestMirrorSyncChangesReportsDeletedPosts(t *testing.T) {
+  fake := useFakeClock(t, time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC))
+  useFileStore(t, []*pb.Post{{Id: "first", Title: "First", Sequence: 1}, {Id: "second", Title: "Second", Sequence: 2}})
+
+  m := &mirrorServer{ttl: time.Minute}
+  synced, err := m.SyncChanges(context.Background(), &pb.SyncChangesRequest{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(synced.Posts) != 2 || synced.Cursor != 2 {
+    t.Fatalf("got %d posts up to %d, want 2 up to 2", len(synced.Posts), synced.Cursor)
+  }
+
+  if err := postStore.Save([]*pb.Post{{Id: "first", Title: "First", Sequence: 1}, {Id: "second", Title: "Second", Sequence: 3, Status: pb.PostStatus_DELETED}}); err != nil {
+    t.Fatal(err)
+  }
+  fake.Advance(time.Minute)
+
+  changes, err := m.SyncChanges(context.Background(), &pb.SyncChangesRequest{Cursor: synced.Cursor})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(changes.Posts) != 0 || !slices.Equal(changes.DeletedIds, []string{"second"}) || changes.Cursor != 3 {
+    t.Errorf("got %v, want second deleted up to 3", changes)
+  }
+  if posts, err := m.GetPosts(context.Background(), &pb.GetPostsRequest{}); err != nil || len(posts.Posts) != 1 {
+    t.Errorf("GetPosts returned %v, %v, want first alone", posts, err)
+  }
+}
+
+func TestRescheduledPostIsUnpublished(t *testing.T) {
+  useFakeClock(t, time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC))
+  useFileStore(t, []*pb.Post{{Id: "p1", Title: "Launch", Content: "Soon.", Author: "Ana", Sequence: 1}})
+  previous := revisionsPath
+  revisionsPath = filepath.Join(t.TempDir(), "revisions.json")
+  t.Cleanup(func() { revisionsPath = previous })
+
+  s := &server{broker: newPostBroker(), scheduleChanged: make(chan struct{}, 1)}
+  events, unsubscribe := s.broker.subscribe(&pb.WatchPostsRequest{})
+  defer unsubscribe()
+
+  if _, err := s.UpdatePost(context.Background(), &pb.UpdatePostRequest{Id: "p1", Content: "Not yet.", PublishAt: "2025-06-05T09:00:00Z"}); err != nil {
+    t.Fatal(err)
+  }
+  select {
+  case event := <-events:
+    if event.Type != pb.PostEventType_POST_UNPUBLISHED || event.Post.Id != "p1" || event.Post.Content != "" {
+      t.Errorf("got %v, want p1 unpublished without its content", event)
+    }
+  default:
+    t.Fatal("no event when the published post was scheduled again")
+  }
+
+  changes, err := s.SyncChanges(context.Background(), &pb.SyncChangesRequest{Cursor: 1})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(changes.Posts) != 0 || !slices.Equal(changes.DeletedIds, []string{"p1"}) {
+    t.Errorf("got %v, want p1 dropped from the offline copies", changes)
+  }
+}
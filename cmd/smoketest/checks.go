@@ -0,0 +1,1165 @@
+package main
+
+import (
+  "bytes"
+  "context"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen/blogsdk"
+  "io"
+  "slices"
+  "strings"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+// check is one step of the smoke test. The ones marked required stop the smoke test when they fail, the checks after them need what they made.
+type check struct {
+  name     string
+  run      func(ctx context.Context) error
+  required bool
+}
+
+type results struct {
+  passed, skipped, failed int
+  // stopped is set when a required check failed and the others didn't run.
+  stopped bool
+}
+
+// checks holds the clients of the smoke test and what its checks made, for the checks after them and the cleanup.
+type checks struct {
+  blog  pb.BlogClient
+  admin pb.AdminClient
+  token string
+  // tag is on everything the smoke test creates, so it finds it again and removes it.
+  tag    string
+  author string
+
+  cursor    int64
+  posts     []*pb.Post
+  scheduled *pb.Post
+  session   string
+  comment   *pb.Comment
+  cleanups  []func(ctx context.Context) error
+}
+
+func newChecks(conn *grpc.ClientConn, token string) *checks {
+  tag := "smoketest-" + randomHex(4)
+
+  return &checks{
+    blog:   pb.NewBlogClient(conn),
+    admin:  pb.NewAdminClient(conn),
+    token:  token,
+    tag:    tag,
+    author: tag,
+  }
+}
+
+func (c *checks) list() []check {
+  return []check{
+    {"auth", c.checkAuth, true},
+    {"create posts", c.checkCreate, true},
+    {"list posts", c.checkList, false},
+    {"slugs", c.checkSlugs, false},
+    {"updates and revisions", c.checkRevisions, false},
+    {"watch posts", c.checkWatch, false},
+    {"stream posts", c.checkStream, false},
+    {"render", c.checkRender, false},
+    {"views", c.checkViews, false},
+    {"author profile", c.checkAuthorProfile, false},
+    {"related posts and backlinks", c.checkLinks, false},
+    {"publishing schedule", c.checkSchedule, false},
+    {"sitemap", c.checkSitemap, false},
+    {"sessions and reading history", c.checkSessions, false},
+    {"challenges", c.checkChallenge, false},
+    {"comments", c.checkComments, false},
+    {"notifications", c.checkNotifications, false},
+    {"reports", c.checkReports, false},
+    {"pins", c.checkPins, false},
+    {"attachments", c.checkAttachments, false},
+    {"templates", c.checkTemplates, false},
+    {"series", c.checkSeries, false},
+    {"webhooks", c.checkWebhooks, false},
+    {"emails", c.checkEmails, false},
+    {"bans", c.checkBans, false},
+    {"audit log", c.checkAudit, false},
+    {"debug log, maintenance and config", c.checkSettings, false},
+    {"storage", c.checkStorage, false},
+    {"scheduled tasks", c.checkTasks, false},
+    {"connections", c.checkConnections, false},
+    {"live stats", c.checkServerStats, false},
+    {"export the audit log", c.checkExport, false},
+    {"delete a post", c.checkDelete, false},
+    {"bulk archive and delete", c.checkBulk, false},
+  }
+}
+
+func (c *checks) run(verbose bool) results {
+  var r results
+  for _, check := range c.list() {
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+    err := check.run(ctx)
+    cancel()
+
+    switch {
+    case err == nil:
+      r.passed++
+      if verbose {
+        fmt.Printf("ok    %s\n", check.name)
+      }
+    case errors.Is(err, errSkipped):
+      r.skipped++
+      fmt.Printf("skip  %s: %v\n", check.name, err)
+    default:
+      r.failed++
+      fmt.Printf("FAIL  %s: %v\n", check.name, err)
+    }
+    if err != nil && check.required {
+      fmt.Printf("the checks after %q need it, stopping\n", check.name)
+      r.stopped = true
+      break
+    }
+  }
+
+  // Removing what was made, the last first. What a check removed already is NotFound.
+  for _, cleanup := range slices.Backward(c.cleanups) {
+    ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+    err := cleanup(ctx)
+    cancel()
+    if err != nil && status.Code(err) != codes.NotFound {
+      r.failed++
+      fmt.Printf("FAIL  cleanup: %v\n", err)
+    }
+  }
+
+  return r
+}
+
+// cleanup adds a step removing something a check made, run once the checks are done.
+func (c *checks) cleanup(step func(ctx context.Context) error) {
+  c.cleanups = append(c.cleanups, step)
+}
+
+// asAdmin returns a context whose calls carry the admin token.
+func (c *checks) asAdmin(ctx context.Context) context.Context {
+  return blogsdk.WithAdminToken(ctx, c.token)
+}
+
+// asReader returns a context whose calls carry the session token of checkSessions, a reader without any rights.
+func (c *checks) asReader(ctx context.Context) context.Context {
+  return metadata.AppendToOutgoingContext(ctx, "x-session", c.session)
+}
+
+func ids(posts []*pb.Post) []string {
+  ids := make([]string, len(posts))
+  for i, post := range posts {
+    ids[i] = post.GetId()
+  }
+
+  return ids
+}
+
+// containsAll fails when one of want is missing from got.
+func containsAll(what string, got []string, want ...string) error {
+  for _, id := range want {
+    if !slices.Contains(got, id) {
+      return fmt.Errorf("%s returned %v, without %s", what, got, id)
+    }
+  }
+
+  return nil
+}
+
+func (c *checks) checkAuth(ctx context.Context) error {
+  if _, err := c.admin.GetStorageStats(c.asAdmin(ctx), &pb.GetStorageStatsRequest{}); err != nil {
+    return fmt.Errorf("GetStorageStats with the admin token: %w", err)
+  }
+  if _, err := c.admin.GetStorageStats(ctx, &pb.GetStorageStatsRequest{}); expectCode(err, codes.Unauthenticated) != nil {
+    return fmt.Errorf("GetStorageStats without a token: %w", expectCode(err, codes.Unauthenticated))
+  }
+  wrong := blogsdk.WithAdminToken(ctx, "not-"+c.token)
+  if _, err := c.admin.GetStorageStats(wrong, &pb.GetStorageStatsRequest{}); expectCode(err, codes.Unauthenticated) != nil {
+    return fmt.Errorf("GetStorageStats with a wrong token: %w", expectCode(err, codes.Unauthenticated))
+  }
+  if _, err := c.blog.QueryAuditLog(wrong, &pb.QueryAuditLogRequest{}); expectCode(err, codes.Unauthenticated) != nil {
+    return fmt.Errorf("QueryAuditLog with a wrong token: %w", expectCode(err, codes.Unauthenticated))
+  }
+  if _, err := c.blog.GetReadingHistory(ctx, &pb.GetReadingHistoryRequest{}); expectCode(err, codes.Unauthenticated) != nil {
+    return fmt.Errorf("GetReadingHistory without a session: %w", expectCode(err, codes.Unauthenticated))
+  }
+  if _, err := c.blog.CreatePost(c.asAdmin(ctx), &pb.CreatePostRequest{Content: "no title"}); expectCode(err, codes.InvalidArgument) != nil {
+    return fmt.Errorf("CreatePost without a title: %w", expectCode(err, codes.InvalidArgument))
+  }
+
+  return nil
+}
+
+func (c *checks) checkCreate(ctx context.Context) error {
+  synced, err := c.blog.SyncChanges(ctx, &pb.SyncChangesRequest{})
+  if err != nil {
+    return fmt.Errorf("SyncChanges: %w", err)
+  }
+  c.cursor = synced.GetCursor()
+
+  // Every post is removed by the bulk delete of the tag, or by the cleanup when the smoke test stopped before.
+  c.cleanup(func(ctx context.Context) error {
+    _, err := c.blog.DeletePosts(c.asAdmin(ctx), &pb.BulkPostsRequest{Filter: &pb.PostFilter{Tags: []string{c.tag}}})
+    return err
+  })
+  create := func(req *pb.CreatePostRequest) (*pb.Post, error) {
+    req.Author, req.Tags = c.author, append(req.Tags, c.tag)
+    post, err := c.blog.CreatePost(c.asAdmin(ctx), req)
+    if err != nil {
+      return nil, fmt.Errorf("CreatePost %q: %w", req.Title, err)
+    }
+    if post.GetId() == "" || post.GetSlug() == "" {
+      return nil, fmt.Errorf("CreatePost returned %v, without an ID or a slug", post)
+    }
+    return post, nil
+  }
+
+  for i, content := range []string{
+    "The first post of the smoke test, with **Markdown** in it.",
+    "The second post, the one that gets viewed.",
+    "",
+  } {
+    req := &pb.CreatePostRequest{
+      Title:   fmt.Sprintf("Smoke test %s, part %d", c.tag, i+1),
+      Content: content,
+      Tags:    []string{"smoketest"},
+    }
+    // The last one links to the first, for GetBacklinks.
+    if i == 2 {
+      req.Content = "Following up on /posts/" + c.posts[0].GetSlug()
+    }
+    post, err := create(req)
+    if err != nil {
+      return err
+    }
+    c.posts = append(c.posts, post)
+  }
+
+  c.scheduled, err = create(&pb.CreatePostRequest{
+    Title:     fmt.Sprintf("Smoke test %s, scheduled", c.tag),
+    Content:   "Published in two days, unless the smoke test deletes it first.",
+    PublishAt: time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339),
+  })
+
+  return err
+}
+
+func (c *checks) checkList(ctx context.Context) error {
+  res, err := c.blog.GetPosts(ctx, &pb.GetPostsRequest{Filter: &pb.PostFilter{Tags: []string{c.tag}}})
+  if err != nil {
+    return fmt.Errorf("GetPosts: %w", err)
+  }
+
+  return containsAll("GetPosts of the tag", ids(res.GetPosts()), ids(c.posts)...)
+}
+
+func (c *checks) checkSlugs(ctx context.Context) error {
+  post, err := c.blog.GetPostBySlug(ctx, &pb.GetPostBySlugRequest{Slug: c.posts[0].GetSlug()})
+  if err != nil {
+    return fmt.Errorf("GetPostBySlug: %w", err)
+  }
+  if post.GetId() != c.posts[0].GetId() {
+    return fmt.Errorf("GetPostBySlug(%q) returned %s, want %s", c.posts[0].GetSlug(), post.GetId(), c.posts[0].GetId())
+  }
+
+  return nil
+}
+
+func (c *checks) checkRevisions(ctx context.Context) error {
+  original := c.posts[0].GetContent()
+  updated, err := c.blog.UpdatePost(c.asAdmin(ctx), &pb.UpdatePostRequest{Id: c.posts[0].GetId(), Content: original + " Updated by the smoke test."})
+  if err != nil {
+    return fmt.Errorf("UpdatePost: %w", err)
+  }
+  if updated.GetContent() == original {
+    return fmt.Errorf("UpdatePost returned the content it had before")
+  }
+
+  revisions, err := c.blog.ListRevisions(c.asAdmin(ctx), &pb.ListRevisionsRequest{PostId: c.posts[0].GetId()})
+  if err != nil {
+    return fmt.Errorf("ListRevisions: %w", err)
+  }
+  if len(revisions.GetRevisions()) == 0 {
+    return fmt.Errorf("ListRevisions returned no revision after an update")
+  }
+  last := revisions.GetRevisions()[len(revisions.GetRevisions())-1]
+  restored, err := c.blog.RestoreRevision(c.asAdmin(ctx), &pb.RestoreRevisionRequest{PostId: c.posts[0].GetId(), Number: last.GetNumber()})
+  if err != nil {
+    return fmt.Errorf("RestoreRevision: %w", err)
+  }
+  if restored.GetContent() != original {
+    return fmt.Errorf("RestoreRevision of revision %d returned the content %q, want %q", last.GetNumber(), restored.GetContent(), original)
+  }
+  c.posts[0] = restored
+
+  return nil
+}
+
+func (c *checks) checkWatch(ctx context.Context) error {
+  ctx, cancel := context.WithCancel(ctx)
+  defer cancel()
+
+  stream, err := c.blog.WatchPosts(ctx, &pb.WatchPostsRequest{Authors: []string{c.author}})
+  if err != nil {
+    return fmt.Errorf("WatchPosts: %w", err)
+  }
+  // The server sends the headers once it is subscribed, no event made after that is missed.
+  if _, err := stream.Header(); err != nil {
+    return fmt.Errorf("WatchPosts: %w", err)
+  }
+
+  id := c.posts[1].GetId()
+  if _, err := c.blog.UpdatePost(c.asAdmin(ctx), &pb.UpdatePostRequest{Id: id, Title: c.posts[1].GetTitle() + " (watched)"}); err != nil {
+    return fmt.Errorf("UpdatePost: %w", err)
+  }
+  for {
+    event, err := stream.Recv()
+    if err != nil {
+      return fmt.Errorf("WatchPosts ended before the update of %s: %w", id, err)
+    }
+    if event.GetType() == pb.PostEventType_POST_UPDATED && event.GetPost().GetId() == id {
+      return nil
+    }
+  }
+}
+
+func (c *checks) checkStream(ctx context.Context) error {
+  stream, err := c.blog.StreamPosts(ctx, &pb.StreamPostsRequest{})
+  if err != nil {
+    return fmt.Errorf("StreamPosts: %w", err)
+  }
+  var streamed []string
+  for {
+    res, err := stream.Recv()
+    if errors.Is(err, io.EOF) {
+      break
+    }
+    if err != nil {
+      return fmt.Errorf("StreamPosts: %w", err)
+    }
+    streamed = append(streamed, res.GetPost().GetId())
+  }
+
+  return containsAll("StreamPosts", streamed, ids(c.posts)...)
+}
+
+func (c *checks) checkRender(ctx context.Context) error {
+  rendered, err := c.blog.RenderPost(ctx, &pb.RenderPostRequest{Id: c.posts[0].GetId()})
+  if err != nil {
+    return fmt.Errorf("RenderPost: %w", err)
+  }
+  if !strings.Contains(rendered.GetHtml(), "<strong>Markdown</strong>") {
+    return fmt.Errorf("RenderPost returned %q, without the Markdown rendered", rendered.GetHtml())
+  }
+
+  return nil
+}
+
+func (c *checks) checkViews(ctx context.Context) error {
+  id := c.posts[1].GetId()
+  // Twice by the same viewer, which counts once.
+  for _, viewer := range []string{c.tag + "-viewer-1", c.tag + "-viewer-2", c.tag + "-viewer-1"} {
+    if _, err := c.blog.RecordView(ctx, &pb.RecordViewRequest{PostId: id, ViewerId: viewer}); err != nil {
+      return fmt.Errorf("RecordView: %w", err)
+    }
+  }
+
+  trending, err := c.blog.GetTrendingPosts(ctx, &pb.GetTrendingPostsRequest{WindowSeconds: 3600, Limit: 100})
+  if err != nil {
+    return fmt.Errorf("GetTrendingPosts: %w", err)
+  }
+  var trendingIDs []string
+  for _, t := range trending.GetPosts() {
+    trendingIDs = append(trendingIDs, t.GetPost().GetId())
+  }
+  if err := containsAll("GetTrendingPosts", trendingIDs, id); err != nil {
+    return err
+  }
+
+  analytics, err := c.blog.GetPostAnalytics(ctx, &pb.GetPostAnalyticsRequest{PostId: id})
+  if err != nil {
+    return fmt.Errorf("GetPostAnalytics: %w", err)
+  }
+  if analytics.GetViews() != 2 {
+    return fmt.Errorf("GetPostAnalytics counted %d views, want 2 by 2 viewers", analytics.GetViews())
+  }
+
+  // Today isn't rolled up, its summary is summed from the views so far.
+  summary, err := c.blog.GetDailySummary(ctx, &pb.GetDailySummaryRequest{Date: time.Now().UTC().Format(time.DateOnly), Limit: 100})
+  if err != nil {
+    return fmt.Errorf("GetDailySummary: %w", err)
+  }
+  for _, post := range summary.GetTopPosts() {
+    if post.GetPostId() == id && post.GetViews() == 2 {
+      return nil
+    }
+  }
+
+  return fmt.Errorf("GetDailySummary of today doesn't have the 2 views of %s: %v", id, summary.GetTopPosts())
+}
+
+// checkAuthorProfile reads the profile of the author of the smoke test, whose posts all carry its tag, with a bio removed afterwards.
+func (c *checks) checkAuthorProfile(ctx context.Context) error {
+  if _, err := c.blog.SetAuthorBio(c.asAdmin(ctx), &pb.SetAuthorBioRequest{Author: c.author, Bio: "Made by the smoke test"}); err != nil {
+    return fmt.Errorf("SetAuthorBio: %w", err)
+  }
+  c.cleanup(func(ctx context.Context) error {
+    _, err := c.blog.SetAuthorBio(c.asAdmin(ctx), &pb.SetAuthorBioRequest{Author: c.author})
+    return err
+  })
+
+  profile, err := c.blog.GetAuthorProfile(ctx, &pb.GetAuthorProfileRequest{Author: c.author, RecentPosts: 50})
+  if err != nil {
+    return fmt.Errorf("GetAuthorProfile: %w", err)
+  }
+  if profile.GetBio() != "Made by the smoke test" || profile.GetTotalViews() < 2 {
+    return fmt.Errorf("GetAuthorProfile returned the bio %q and %d views, want the bio just set and the 2 views of the views check", profile.GetBio(), profile.GetTotalViews())
+  }
+  var recent []string
+  for _, post := range profile.GetRecentPosts() {
+    recent = append(recent, post.GetId())
+  }
+  if err := containsAll("GetAuthorProfile", recent, c.posts[1].GetId()); err != nil {
+    return err
+  }
+  for _, tag := range profile.GetTopTags() {
+    if tag.GetTag() == c.tag && tag.GetPosts() == profile.GetPostCount() {
+      return nil
+    }
+  }
+
+  return fmt.Errorf("GetAuthorProfile returned the tags %v, want %s on all the %d posts", profile.GetTopTags(), c.tag, profile.GetPostCount())
+}
+
+func (c *checks) checkLinks(ctx context.Context) error {
+  related, err := c.blog.GetRelatedPosts(ctx, &pb.GetRelatedPostsRequest{PostId: c.posts[0].GetId()})
+  if err != nil {
+    return fmt.Errorf("GetRelatedPosts: %w", err)
+  }
+  var relatedIDs []string
+  for _, r := range related.GetPosts() {
+    relatedIDs = append(relatedIDs, r.GetPost().GetId())
+  }
+  // They share their tags.
+  if err := containsAll("GetRelatedPosts", relatedIDs, c.posts[1].GetId()); err != nil {
+    return err
+  }
+
+  backlinks, err := c.blog.GetBacklinks(ctx, &pb.GetBacklinksRequest{PostId: c.posts[0].GetId()})
+  if err != nil {
+    return fmt.Errorf("GetBacklinks: %w", err)
+  }
+
+  return containsAll("GetBacklinks", ids(backlinks.GetPosts()), c.posts[2].GetId())
+}
+
+func (c *checks) checkSchedule(ctx context.Context) error {
+  schedule, err := c.blog.GetPublishingSchedule(c.asAdmin(ctx), &pb.GetPublishingScheduleRequest{TimeZone: "UTC"})
+  if err != nil {
+    return fmt.Errorf("GetPublishingSchedule: %w", err)
+  }
+  var scheduled []string
+  for _, day := range schedule.GetDays() {
+    for _, post := range day.GetPosts() {
+      scheduled = append(scheduled, post.GetId())
+    }
+  }
+
+  return containsAll("GetPublishingSchedule", scheduled, c.scheduled.GetId())
+}
+
+func (c *checks) checkSitemap(ctx context.Context) error {
+  sitemap, err := c.blog.GetSitemap(c.asAdmin(ctx), &pb.GetSitemapRequest{})
+  if err != nil {
+    return skipDisabled(fmt.Errorf("GetSitemap: %w", err))
+  }
+  if !strings.Contains(sitemap.GetXml(), c.posts[0].GetSlug()) {
+    return fmt.Errorf("the sitemap of %d URLs doesn't have %s", sitemap.GetUrlCount(), c.posts[0].GetSlug())
+  }
+
+  return nil
+}
+
+func (c *checks) checkSessions(ctx context.Context) error {
+  session, err := c.blog.StartSession(ctx, &pb.StartSessionRequest{})
+  if err != nil {
+    return fmt.Errorf("StartSession: %w", err)
+  }
+  if session.GetToken() == "" {
+    return fmt.Errorf("StartSession returned no token")
+  }
+  c.session = session.GetToken()
+
+  // A session gives a name, not the rights of an admin.
+  if _, err := c.admin.GetStorageStats(c.asReader(ctx), &pb.GetStorageStatsRequest{}); expectCode(err, codes.Unauthenticated) != nil {
+    return fmt.Errorf("GetStorageStats with a session: %w", expectCode(err, codes.Unauthenticated))
+  }
+
+  if _, err := c.blog.MarkAsRead(c.asReader(ctx), &pb.MarkAsReadRequest{PostId: c.posts[0].GetId()}); err != nil {
+    return fmt.Errorf("MarkAsRead: %w", err)
+  }
+  history, err := c.blog.GetReadingHistory(c.asReader(ctx), &pb.GetReadingHistoryRequest{})
+  if err != nil {
+    return fmt.Errorf("GetReadingHistory: %w", err)
+  }
+  var read []string
+  for _, entry := range history.GetEntries() {
+    if entry.GetRead() {
+      read = append(read, entry.GetPost().GetId())
+    }
+  }
+
+  return containsAll("GetReadingHistory", read, c.posts[0].GetId())
+}
+
+func (c *checks) checkChallenge(ctx context.Context) error {
+  challenge, err := c.blog.GetChallenge(ctx, &pb.GetChallengeRequest{})
+  if err != nil {
+    return fmt.Errorf("GetChallenge: %w", err)
+  }
+  // Without -challenge-bits there is nothing to solve, and no token.
+  if (challenge.GetBits() > 0) != (challenge.GetToken() != "") {
+    return fmt.Errorf("GetChallenge returned a challenge of %d bits with the token %q", challenge.GetBits(), challenge.GetToken())
+  }
+
+  return nil
+}
+
+// addComment comments the first post as the reader of the session.
+func (c *checks) addComment(ctx context.Context, content string) (*pb.Comment, error) {
+  comment, err := c.blog.AddComment(c.asReader(ctx), &pb.AddCommentRequest{PostId: c.posts[0].GetId(), Author: c.author, Content: content})
+  if err != nil {
+    return nil, fmt.Errorf("AddComment: %w", err)
+  }
+
+  return comment, nil
+}
+
+func (c *checks) checkComments(ctx context.Context) error {
+  approved, err := c.addComment(ctx, "A comment the smoke test approves.")
+  if err != nil {
+    return err
+  }
+  rejected, err := c.addComment(ctx, "A comment the smoke test rejects.")
+  if err != nil {
+    return err
+  }
+
+  if approved, err = c.blog.ApproveComment(c.asAdmin(ctx), &pb.ModerateCommentRequest{Id: approved.GetId()}); err != nil {
+    return fmt.Errorf("ApproveComment: %w", err)
+  }
+  if _, err := c.blog.RejectComment(c.asAdmin(ctx), &pb.ModerateCommentRequest{Id: rejected.GetId(), Reason: "smoke test"}); err != nil {
+    return fmt.Errorf("RejectComment: %w", err)
+  }
+  c.comment = approved
+
+  // Readers only see the approved comments.
+  comments, err := c.blog.GetComments(ctx, &pb.GetCommentsRequest{PostId: c.posts[0].GetId()})
+  if err != nil {
+    return fmt.Errorf("GetComments: %w", err)
+  }
+  var shown []string
+  for _, comment := range comments.GetComments() {
+    shown = append(shown, comment.GetId())
+  }
+  if slices.Contains(shown, rejected.GetId()) {
+    return fmt.Errorf("GetComments returned the rejected comment %s", rejected.GetId())
+  }
+
+  return containsAll("GetComments", shown, approved.GetId())
+}
+
+func (c *checks) checkNotifications(ctx context.Context) error {
+  ctx, cancel := context.WithCancel(ctx)
+  defer cancel()
+
+  // The admin created the posts, an approved comment on one of them lands in its inbox.
+  stream, err := c.blog.StreamNotifications(c.asAdmin(ctx), &pb.StreamNotificationsRequest{})
+  if err != nil {
+    return fmt.Errorf("StreamNotifications: %w", err)
+  }
+  received := make(chan *pb.Notification)
+  go func() {
+    defer close(received)
+    for {
+      notification, err := stream.Recv()
+      if err != nil {
+        return
+      }
+      if notification.GetType() == pb.NotificationType_NOTIFICATION_NEW_COMMENT && notification.GetPostId() == c.posts[0].GetId() {
+        received <- notification
+        return
+      }
+    }
+  }()
+
+  // Unlike WatchPosts the stream doesn't say when it is subscribed, a comment approved before is in the inbox only: the next one is sent.
+  var notification *pb.Notification
+  for attempt := 0; notification == nil; attempt++ {
+    if attempt == 3 {
+      return fmt.Errorf("StreamNotifications sent nothing for %d approved comments", attempt)
+    }
+    comment, err := c.addComment(ctx, fmt.Sprintf("Comment %d for the notifications.", attempt+1))
+    if err != nil {
+      return err
+    }
+    if _, err := c.blog.ApproveComment(c.asAdmin(ctx), &pb.ModerateCommentRequest{Id: comment.GetId()}); err != nil {
+      return fmt.Errorf("ApproveComment: %w", err)
+    }
+    select {
+    case notification = <-received:
+      if notification == nil {
+        return fmt.Errorf("StreamNotifications ended before the approved comment")
+      }
+    case <-time.After(2 * time.Second):
+    }
+  }
+
+  list, err := c.blog.ListNotifications(c.asAdmin(ctx), &pb.ListNotificationsRequest{UnreadOnly: true, Limit: 100})
+  if err != nil {
+    return fmt.Errorf("ListNotifications: %w", err)
+  }
+  var unread []string
+  for _, n := range list.GetNotifications() {
+    unread = append(unread, n.GetId())
+  }
+  if err := containsAll("ListNotifications", unread, notification.GetId()); err != nil {
+    return err
+  }
+
+  marked, err := c.blog.MarkNotificationRead(c.asAdmin(ctx), &pb.MarkNotificationReadRequest{Id: notification.GetId()})
+  if err != nil {
+    return fmt.Errorf("MarkNotificationRead: %w", err)
+  }
+  for _, n := range marked.GetNotifications() {
+    if n.GetId() == notification.GetId() && !n.GetRead() {
+      return fmt.Errorf("MarkNotificationRead left %s unread", n.GetId())
+    }
+  }
+
+  return nil
+}
+
+func (c *checks) checkReports(ctx context.Context) error {
+  if c.comment == nil {
+    return fmt.Errorf("no comment to report, see the comments check")
+  }
+  postReport, err := c.blog.ReportPost(c.asReader(ctx), &pb.ReportPostRequest{PostId: c.posts[1].GetId(), Reason: pb.ReportReason_REPORT_SPAM, Details: "reported by the smoke test"})
+  if err != nil {
+    return fmt.Errorf("ReportPost: %w", err)
+  }
+  commentReport, err := c.blog.ReportComment(c.asReader(ctx), &pb.ReportCommentRequest{CommentId: c.comment.GetId(), Reason: pb.ReportReason_REPORT_OTHER, Details: "reported by the smoke test"})
+  if err != nil {
+    return fmt.Errorf("ReportComment: %w", err)
+  }
+
+  reports, err := c.blog.ListReports(c.asAdmin(ctx), &pb.ListReportsRequest{PostId: c.posts[1].GetId()})
+  if err != nil {
+    return fmt.Errorf("ListReports: %w", err)
+  }
+  var open []string
+  for _, report := range reports.GetReports() {
+    open = append(open, report.GetId())
+  }
+  if err := containsAll("ListReports", open, postReport.GetId()); err != nil {
+    return err
+  }
+
+  for _, report := range []*pb.Report{postReport, commentReport} {
+    resolved, err := c.blog.ResolveReport(c.asAdmin(ctx), &pb.ResolveReportRequest{Id: report.GetId(), Resolution: pb.ReportResolution_DISMISSED, Note: "smoke test"})
+    if err != nil {
+      return fmt.Errorf("ResolveReport: %w", err)
+    }
+    if resolved.GetResolution() != pb.ReportResolution_DISMISSED {
+      return fmt.Errorf("ResolveReport returned the resolution %v", resolved.GetResolution())
+    }
+  }
+
+  return nil
+}
+
+func (c *checks) checkPins(ctx context.Context) error {
+  id := c.posts[2].GetId()
+  pinned, err := c.blog.PinPost(c.asAdmin(ctx), &pb.PinPostRequest{Id: id})
+  if err != nil {
+    // Pinning is off, or the pins of the blog are all taken.
+    return skipDisabled(fmt.Errorf("PinPost: %w", err))
+  }
+  unpinned, err := c.blog.UnpinPost(c.asAdmin(ctx), &pb.UnpinPostRequest{Id: id})
+  if err != nil {
+    return fmt.Errorf("UnpinPost: %w", err)
+  }
+  if !pinned.GetPinned() || unpinned.GetPinned() {
+    return fmt.Errorf("PinPost and UnpinPost returned pinned %v then %v", pinned.GetPinned(), unpinned.GetPinned())
+  }
+
+  return nil
+}
+
+func (c *checks) checkAttachments(ctx context.Context) error {
+  content := bytes.Repeat([]byte("smoke test attachment\n"), 4096)
+  upload, err := c.blog.UploadAttachment(c.asAdmin(ctx))
+  if err != nil {
+    return fmt.Errorf("UploadAttachment: %w", err)
+  }
+  if err := upload.Send(&pb.UploadAttachmentRequest{Data: &pb.UploadAttachmentRequest_Metadata{Metadata: &pb.AttachmentMetadata{PostId: c.posts[0].GetId(), Filename: c.tag + ".txt", ContentType: "text/plain"}}}); err != nil {
+    return fmt.Errorf("UploadAttachment: %w", err)
+  }
+  for chunk := range slices.Chunk(content, 32*1024) {
+    if err := upload.Send(&pb.UploadAttachmentRequest{Data: &pb.UploadAttachmentRequest_Chunk{Chunk: chunk}}); err != nil {
+      break // The error comes with CloseAndRecv.
+    }
+  }
+  attachment, err := upload.CloseAndRecv()
+  if err != nil {
+    return fmt.Errorf("UploadAttachment: %w", err)
+  }
+
+  download, err := c.blog.DownloadAttachment(ctx, &pb.DownloadAttachmentRequest{PostId: c.posts[0].GetId(), AttachmentId: attachment.GetId()})
+  if err != nil {
+    return fmt.Errorf("DownloadAttachment: %w", err)
+  }
+  var downloaded []byte
+  for {
+    res, err := download.Recv()
+    if errors.Is(err, io.EOF) {
+      break
+    }
+    if err != nil {
+      return fmt.Errorf("DownloadAttachment: %w", err)
+    }
+    downloaded = append(downloaded, res.GetChunk()...)
+  }
+  if !bytes.Equal(downloaded, content) {
+    return fmt.Errorf("DownloadAttachment returned %d bytes, not the %d uploaded", len(downloaded), len(content))
+  }
+
+  // Only the attachments of a bucket have URLs.
+  url, err := c.blog.GetAttachmentURL(ctx, &pb.GetAttachmentURLRequest{PostId: c.posts[0].GetId(), AttachmentId: attachment.GetId()})
+  if err != nil {
+    return skipDisabled(fmt.Errorf("GetAttachmentURL: %w", err))
+  }
+  if url.GetUrl() == "" {
+    return fmt.Errorf("GetAttachmentURL returned no URL")
+  }
+
+  return nil
+}
+
+func (c *checks) checkTemplates(ctx context.Context) error {
+  ctx = c.asAdmin(ctx)
+  tmpl, err := c.blog.CreateTemplate(ctx, &pb.CreateTemplateRequest{Name: c.tag, Title: "Smoke test of {{date}}", Content: "{{author}} says {{greeting}}", Tags: []string{c.tag}})
+  if err != nil {
+    return fmt.Errorf("CreateTemplate: %w", err)
+  }
+  c.cleanup(func(ctx context.Context) error {
+    _, err := c.blog.DeleteTemplate(c.asAdmin(ctx), &pb.DeleteTemplateRequest{Id: tmpl.GetId()})
+    return err
+  })
+
+  if _, err := c.blog.UpdateTemplate(ctx, &pb.UpdateTemplateRequest{Id: tmpl.GetId(), Title: "Smoke test {{greeting}} of {{date}}"}); err != nil {
+    return fmt.Errorf("UpdateTemplate: %w", err)
+  }
+  got, err := c.blog.GetTemplate(ctx, &pb.GetTemplateRequest{Id: tmpl.GetId()})
+  if err != nil {
+    return fmt.Errorf("GetTemplate: %w", err)
+  }
+  if !slices.Contains(got.GetPlaceholders(), "greeting") {
+    return fmt.Errorf("GetTemplate returned the placeholders %v, without greeting", got.GetPlaceholders())
+  }
+  list, err := c.blog.ListTemplates(ctx, &pb.ListTemplatesRequest{})
+  if err != nil {
+    return fmt.Errorf("ListTemplates: %w", err)
+  }
+  var names []string
+  for _, t := range list.GetTemplates() {
+    names = append(names, t.GetName())
+  }
+  if err := containsAll("ListTemplates", names, c.tag); err != nil {
+    return err
+  }
+
+  // Tagged by the template, the post goes with the others.
+  post, err := c.blog.CreatePostFromTemplate(ctx, &pb.CreatePostFromTemplateRequest{Template: c.tag, Author: c.author, Values: map[string]string{"greeting": "hello"}, Draft: true})
+  if err != nil {
+    return fmt.Errorf("CreatePostFromTemplate: %w", err)
+  }
+  if want := c.author + " says hello"; post.GetContent() != want {
+    return fmt.Errorf("CreatePostFromTemplate made a post with %q, want %q", post.GetContent(), want)
+  }
+
+  if _, err := c.blog.DeleteTemplate(ctx, &pb.DeleteTemplateRequest{Id: tmpl.GetId()}); err != nil {
+    return fmt.Errorf("DeleteTemplate: %w", err)
+  }
+
+  return nil
+}
+
+func (c *checks) checkSeries(ctx context.Context) error {
+  ctx = c.asAdmin(ctx)
+  series, err := c.blog.CreateSeries(ctx, &pb.CreateSeriesRequest{Title: "Smoke test " + c.tag, PostIds: ids(c.posts[:2])})
+  if err != nil {
+    return fmt.Errorf("CreateSeries: %w", err)
+  }
+  c.cleanup(func(ctx context.Context) error {
+    _, err := c.blog.DeleteSeries(c.asAdmin(ctx), &pb.DeleteSeriesRequest{Id: series.GetId()})
+    return err
+  })
+
+  if series, err = c.blog.AddToSeries(ctx, &pb.AddToSeriesRequest{SeriesId: series.GetId(), PostId: c.posts[2].GetId()}); err != nil {
+    return fmt.Errorf("AddToSeries: %w", err)
+  }
+  reversed := slices.Clone(series.GetPostIds())
+  slices.Reverse(reversed)
+  if series, err = c.blog.ReorderSeries(ctx, &pb.ReorderSeriesRequest{SeriesId: series.GetId(), PostIds: reversed}); err != nil {
+    return fmt.Errorf("ReorderSeries: %w", err)
+  }
+  if series, err = c.blog.RemoveFromSeries(ctx, &pb.RemoveFromSeriesRequest{SeriesId: series.GetId(), PostId: c.posts[1].GetId()}); err != nil {
+    return fmt.Errorf("RemoveFromSeries: %w", err)
+  }
+
+  got, err := c.blog.GetSeries(ctx, &pb.GetSeriesRequest{Id: series.GetId()})
+  if err != nil {
+    return fmt.Errorf("GetSeries: %w", err)
+  }
+  var order []string
+  for _, post := range got.GetPosts() {
+    order = append(order, post.GetPost().GetId())
+  }
+  if want := []string{c.posts[2].GetId(), c.posts[0].GetId()}; !slices.Equal(order, want) {
+    return fmt.Errorf("GetSeries returned the posts %v, want %v", order, want)
+  }
+
+  list, err := c.blog.ListSeries(ctx, &pb.ListSeriesRequest{})
+  if err != nil {
+    return fmt.Errorf("ListSeries: %w", err)
+  }
+  var listed []string
+  for _, s := range list.GetSeries() {
+    listed = append(listed, s.GetId())
+  }
+  if err := containsAll("ListSeries", listed, series.GetId()); err != nil {
+    return err
+  }
+
+  if _, err := c.blog.DeleteSeries(ctx, &pb.DeleteSeriesRequest{Id: series.GetId()}); err != nil {
+    return fmt.Errorf("DeleteSeries: %w", err)
+  }
+
+  return nil
+}
+
+func (c *checks) checkWebhooks(ctx context.Context) error {
+  ctx = c.asAdmin(ctx)
+  // Nothing listens there, and the only events it wants are the archives, which the bulk archive only does as a dry run.
+  webhook, err := c.blog.RegisterWebhook(ctx, &pb.RegisterWebhookRequest{Url: "http://" + c.tag + ".invalid/hook", Types: []pb.PostEventType{pb.PostEventType_POST_ARCHIVED}})
+  if err != nil {
+    return fmt.Errorf("RegisterWebhook: %w", err)
+  }
+  c.cleanup(func(ctx context.Context) error {
+    _, err := c.blog.UnregisterWebhook(c.asAdmin(ctx), &pb.UnregisterWebhookRequest{Id: webhook.GetId()})
+    return err
+  })
+
+  list, err := c.blog.ListWebhooks(ctx, &pb.ListWebhooksRequest{})
+  if err != nil {
+    return fmt.Errorf("ListWebhooks: %w", err)
+  }
+  var registered []string
+  for _, w := range list.GetWebhooks() {
+    registered = append(registered, w.GetId())
+  }
+  if err := containsAll("ListWebhooks", registered, webhook.GetId()); err != nil {
+    return err
+  }
+
+  if _, err := c.blog.UnregisterWebhook(ctx, &pb.UnregisterWebhookRequest{Id: webhook.GetId()}); err != nil {
+    return fmt.Errorf("UnregisterWebhook: %w", err)
+  }
+
+  return nil
+}
+
+func (c *checks) checkEmails(ctx context.Context) error {
+  // Only the failures, a subscription would send an email.
+  _, subscribeErr := c.blog.SubscribeByEmail(ctx, &pb.SubscribeByEmailRequest{Email: "not an email"})
+  _, unsubscribeErr := c.blog.UnsubscribeByEmail(ctx, &pb.UnsubscribeByEmailRequest{Token: c.tag})
+  if status.Code(subscribeErr) == codes.FailedPrecondition {
+    return skipDisabled(fmt.Errorf("SubscribeByEmail: %w", subscribeErr))
+  }
+
+  if err := expectCode(subscribeErr, codes.InvalidArgument); err != nil {
+    return fmt.Errorf("SubscribeByEmail of an invalid address: %w", err)
+  }
+  if code := status.Code(unsubscribeErr); code != codes.NotFound && code != codes.InvalidArgument {
+    return fmt.Errorf("UnsubscribeByEmail of an unknown token: got %v, want NotFound or InvalidArgument", unsubscribeErr)
+  }
+
+  return nil
+}
+
+func (c *checks) checkBans(ctx context.Context) error {
+  ctx = c.asAdmin(ctx)
+  ban, err := c.admin.AddBan(ctx, &pb.AddBanRequest{Identity: c.tag, Reason: "smoke test"})
+  if err != nil {
+    return fmt.Errorf("AddBan: %w", err)
+  }
+  c.cleanup(func(ctx context.Context) error {
+    _, err := c.admin.RemoveBan(c.asAdmin(ctx), &pb.RemoveBanRequest{Id: ban.GetId()})
+    return err
+  })
+
+  list, err := c.admin.ListBans(ctx, &pb.ListBansRequest{})
+  if err != nil {
+    return fmt.Errorf("ListBans: %w", err)
+  }
+  var bans []string
+  for _, b := range list.GetBans() {
+    bans = append(bans, b.GetId())
+  }
+  if err := containsAll("ListBans", bans, ban.GetId()); err != nil {
+    return err
+  }
+
+  if _, err := c.admin.RemoveBan(ctx, &pb.RemoveBanRequest{Id: ban.GetId()}); err != nil {
+    return fmt.Errorf("RemoveBan: %w", err)
+  }
+
+  return nil
+}
+
+func (c *checks) checkAudit(ctx context.Context) error {
+  entries, err := c.blog.QueryAuditLog(c.asAdmin(ctx), &pb.QueryAuditLogRequest{PostId: c.posts[0].GetId()})
+  if err != nil {
+    return fmt.Errorf("QueryAuditLog: %w", err)
+  }
+  var methods []string
+  for _, entry := range entries.GetEntries() {
+    methods = append(methods, entry.GetMethod())
+  }
+  if !slices.ContainsFunc(methods, func(method string) bool { return strings.HasSuffix(method, "/UpdatePost") }) {
+    return fmt.Errorf("QueryAuditLog of %s returned %v, without its UpdatePost", c.posts[0].GetId(), methods)
+  }
+
+  return nil
+}
+
+func (c *checks) checkSettings(ctx context.Context) error {
+  ctx = c.asAdmin(ctx)
+  // Set to what they are, the smoke test changes nothing the server's readers would notice.
+  debug, err := c.admin.GetDebugLogging(ctx, &pb.GetDebugLoggingRequest{})
+  if err != nil {
+    return fmt.Errorf("GetDebugLogging: %w", err)
+  }
+  if _, err := c.admin.SetDebugLogging(ctx, &pb.SetDebugLoggingRequest{Enabled: debug.GetEnabled(), Redact: debug.GetRedact()}); err != nil {
+    return fmt.Errorf("SetDebugLogging: %w", err)
+  }
+
+  maintenance, err := c.admin.GetMaintenance(ctx, &pb.GetMaintenanceRequest{})
+  if err != nil {
+    return fmt.Errorf("GetMaintenance: %w", err)
+  }
+  if maintenance.GetEnabled() {
+    return fmt.Errorf("the server is in maintenance since %s: %s", maintenance.GetSince(), maintenance.GetReason())
+  }
+  if _, err := c.admin.SetMaintenance(ctx, &pb.SetMaintenanceRequest{Enabled: false}); err != nil {
+    return fmt.Errorf("SetMaintenance: %w", err)
+  }
+
+  if _, err := c.admin.ReloadConfig(ctx, &pb.ReloadConfigRequest{}); err != nil {
+    return skipDisabled(fmt.Errorf("ReloadConfig: %w", err))
+  }
+
+  return nil
+}
+
+func (c *checks) checkStorage(ctx context.Context) error {
+  ctx = c.asAdmin(ctx)
+  stats, err := c.admin.GetStorageStats(ctx, &pb.GetStorageStatsRequest{})
+  if err != nil {
+    return fmt.Errorf("GetStorageStats: %w", err)
+  }
+  if stats.GetBackend() == "" || stats.GetPosts() < int32(len(c.posts)) {
+    return fmt.Errorf("GetStorageStats returned %v, with fewer posts than the smoke test made", stats)
+  }
+  if _, err := c.admin.FlushStorage(ctx, &pb.FlushStorageRequest{}); err != nil {
+    return fmt.Errorf("FlushStorage: %w", err)
+  }
+  if _, err := c.admin.ReencryptStorage(ctx, &pb.ReencryptStorageRequest{}); err != nil {
+    return skipDisabled(fmt.Errorf("ReencryptStorage: %w", err))
+  }
+
+  return nil
+}
+
+func (c *checks) checkTasks(ctx context.Context) error {
+  ctx = c.asAdmin(ctx)
+  tasks, err := c.admin.ListScheduledTasks(ctx, &pb.ListScheduledTasksRequest{})
+  if err != nil {
+    return fmt.Errorf("ListScheduledTasks: %w", err)
+  }
+  if len(tasks.GetTasks()) == 0 {
+    return fmt.Errorf("ListScheduledTasks returned no task")
+  }
+  // The roll up of the views is the task run most often anyway.
+  name := tasks.GetTasks()[0].GetName()
+  for _, task := range tasks.GetTasks() {
+    if task.GetName() == "rollup-views" {
+      name = task.GetName()
+    }
+  }
+  if _, err := c.admin.RunScheduledTask(ctx, &pb.RunScheduledTaskRequest{Name: name}); err != nil {
+    return fmt.Errorf("RunScheduledTask %s: %w", name, err)
+  }
+
+  return nil
+}
+
+func (c *checks) checkConnections(ctx context.Context) error {
+  ctx = c.asAdmin(ctx)
+  stats, err := c.admin.GetConnectionStats(ctx, &pb.GetConnectionStatsRequest{})
+  if err != nil {
+    return fmt.Errorf("GetConnectionStats: %w", err)
+  }
+  if stats.GetOpenConnections() == 0 {
+    return fmt.Errorf("GetConnectionStats counted no open connection, not even the one of the smoke test")
+  }
+  conns, err := c.admin.ListConnections(ctx, &pb.ListConnectionsRequest{})
+  if err != nil {
+    return fmt.Errorf("ListConnections: %w", err)
+  }
+  if len(conns.GetConnections()) == 0 {
+    return fmt.Errorf("ListConnections returned no connection, not even the one of the smoke test")
+  }
+
+  return nil
+}
+
+func (c *checks) checkServerStats(ctx context.Context) error {
+  ctx, cancel := context.WithCancel(c.asAdmin(ctx))
+  defer cancel()
+
+  stream, err := c.admin.StreamServerStats(ctx, &pb.StreamServerStatsRequest{IntervalSeconds: 1})
+  if err != nil {
+    return fmt.Errorf("StreamServerStats: %w", err)
+  }
+  // The stream counts the calls from the time its handler runs, which can be after a first GetPosts: one per report until one is in.
+  for range 3 {
+    if _, err := c.blog.GetPosts(ctx, &pb.GetPostsRequest{PageSize: 1}); err != nil {
+      return fmt.Errorf("GetPosts: %w", err)
+    }
+    stats, err := stream.Recv()
+    if err != nil {
+      return fmt.Errorf("StreamServerStats: %w", err)
+    }
+    if stats.GetHeapAllocBytes() == 0 || stats.GetGoroutines() == 0 {
+      return fmt.Errorf("StreamServerStats reported no memory: %v", stats)
+    }
+    for _, method := range stats.GetMethods() {
+      if method.GetMethod() == "GetPosts" && method.GetCalls() > 0 {
+        return nil
+      }
+    }
+  }
+
+  return fmt.Errorf("StreamServerStats didn't count the GetPosts of the smoke test in 3 reports")
+}
+
+// checkExport exports the audit log of the posts made by the smoke test, in both formats. The exports to a bucket need one and aren't tried.
+func (c *checks) checkExport(ctx context.Context) error {
+  for _, format := range []pb.ExportFormat{pb.ExportFormat_EXPORT_CSV, pb.ExportFormat_EXPORT_PARQUET} {
+    stream, err := c.admin.ExportEvents(c.asAdmin(ctx), &pb.ExportEventsRequest{Dataset: pb.ExportDataset_EXPORT_AUDIT_LOG, Format: format})
+    if err != nil {
+      return fmt.Errorf("ExportEvents: %w", err)
+    }
+
+    var data []byte
+    var last *pb.ExportChunk
+    for {
+      chunk, err := stream.Recv()
+      if errors.Is(err, io.EOF) {
+        break
+      }
+      if err != nil {
+        return fmt.Errorf("ExportEvents %v: %w", format, err)
+      }
+      data = append(data, chunk.GetData()...)
+      last = chunk
+    }
+
+    if last == nil || last.GetBytes() != int64(len(data)) {
+      return fmt.Errorf("ExportEvents %v sent %d bytes and ended with %v", format, len(data), last)
+    }
+    if last.GetRows() == 0 {
+      return fmt.Errorf("ExportEvents %v found nothing in the audit log, the smoke test made posts", format)
+    }
+    switch format {
+    case pb.ExportFormat_EXPORT_CSV:
+      if !bytes.HasPrefix(data, []byte("time,identity,peer,method,")) {
+        return fmt.Errorf("the CSV export starts with %q, want its header", data[:min(len(data), 40)])
+      }
+    case pb.ExportFormat_EXPORT_PARQUET:
+      if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+        return fmt.Errorf("the Parquet export isn't a Parquet file")
+      }
+    }
+  }
+
+  return nil
+}
+
+func (c *checks) checkDelete(ctx context.Context) error {
+  id := c.posts[2].GetId()
+  if _, err := c.blog.DeletePost(c.asAdmin(ctx), &pb.DeletePostRequest{Id: id}); err != nil {
+    return fmt.Errorf("DeletePost: %w", err)
+  }
+  if _, err := c.blog.DeletePost(c.asAdmin(ctx), &pb.DeletePostRequest{Id: id}); expectCode(err, codes.NotFound) != nil {
+    return fmt.Errorf("DeletePost of a deleted post: %w", expectCode(err, codes.NotFound))
+  }
+
+  // Clients syncing since the posts were created are told.
+  changes, err := c.blog.SyncChanges(ctx, &pb.SyncChangesRequest{Cursor: c.cursor})
+  if err != nil {
+    return fmt.Errorf("SyncChanges: %w", err)
+  }
+
+  return containsAll("SyncChanges the deleted IDs", changes.GetDeletedIds(), id)
+}
+
+func (c *checks) checkBulk(ctx context.Context) error {
+  ctx = c.asAdmin(ctx)
+  filter := &pb.PostFilter{Tags: []string{c.tag}}
+  archived, err := c.blog.ArchivePosts(ctx, &pb.BulkPostsRequest{Filter: filter, DryRun: true})
+  if err != nil {
+    return fmt.Errorf("ArchivePosts: %w", err)
+  }
+  if !archived.GetDryRun() || archived.GetCount() == 0 {
+    return fmt.Errorf("ArchivePosts as a dry run returned %v", archived)
+  }
+
+  deleted, err := c.blog.DeletePosts(ctx, &pb.BulkPostsRequest{Filter: filter})
+  if err != nil {
+    return fmt.Errorf("DeletePosts: %w", err)
+  }
+  if err := containsAll("DeletePosts", deleted.GetIds(), ids(c.posts[:2])...); err != nil {
+    return err
+  }
+  left, err := c.blog.GetPosts(ctx, &pb.GetPostsRequest{Filter: filter})
+  if err != nil {
+    return fmt.Errorf("GetPosts: %w", err)
+  }
+  if len(left.GetPosts()) > 0 {
+    return fmt.Errorf("GetPosts still returned %v after DeletePosts", ids(left.GetPosts()))
+  }
+
+  return nil
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+  "bytes"
+  "context"
+  "crypto/rand"
+  "crypto/tls"
+  "crypto/x509"
+  "encoding/hex"
+  "errors"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "net"
+  "os"
+  "os/exec"
+  "path/filepath"
+  "slices"
+  "strings"
+  "sync"
+  "syscall"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/credentials"
+  "google.golang.org/grpc/credentials/insecure"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  SMOKE TEST
+
+  The tests of the repository call the handlers, the smoke test calls a server: every RPC of the Blog and Admin services at least once, the streams included, and the ways a call is turned away, without a token, with a wrong one, with a session calling an admin RPC. It prints a line per check and exits with 1 when one of them failed, so it can end a deployment script:
+
+    go run ./cmd/smoketest                       builds the server, runs it on a random port of localhost and stops it at the end
+    go run ./cmd/smoketest -server-args '-storage sqlite -file-gzip'
+    go run ./cmd/smoketest -addr blog.internal:3000 -tls -token $BLOG_ADMIN_TOKEN
+
+  Without -addr the server is built from the working directory, the root of the repository, or -server is the binary to run. It runs in a temporary directory of its own, with the features the checks need turned on: a -site-url for the sitemap, a -config to reload and encryption keys to rotate.
+
+  With -addr it checks a server that is already running, which needs the admin token. Whatever it creates is tagged smoketest-<random>, posts, templates, series, webhooks and bans, and removed at the end, even when a check failed. It still leaves traces: entries in the audit log, the comments and reports of its deleted posts, notifications in the inbox of the admin and the views of its posts in the counters. The checks of a feature the server was started without, like the emails without -smtp-addr, are skipped rather than failed.
+
+  Every RPC is called by a check. When a new RPC comes without one, the smoke test fails and names it.
+*/
+const callTimeout = 10 * time.Second
+
+func main() {
+  log.SetFlags(0)
+  log.SetPrefix("smoketest: ")
+
+  // smokeTest returns rather than exit, so the server it started is stopped whatever happens.
+  os.Exit(smokeTest())
+}
+
+func smokeTest() int {
+  addr := flag.String("addr", "", "address of a running server to check, empty starts one from -server")
+  token := flag.String("token", "", "admin token of the server of -addr")
+  useTLS := flag.Bool("tls", false, "connect to -addr over TLS")
+  caFile := flag.String("ca", "", "PEM file of the CA that signed the certificate of -addr, empty uses the system roots")
+  serverPath := flag.String("server", "", "server binary to run without -addr, empty builds it from the working directory")
+  serverArgs := flag.String("server-args", "", "more flags of the server run without -addr, separated by spaces")
+  verbose := flag.Bool("v", false, "print the checks that passed too")
+  flag.Parse()
+
+  creds := insecure.NewCredentials()
+  var server *testServer
+  switch {
+  case *addr == "" && (*useTLS || *caFile != ""):
+    log.Print("-tls and -ca are for the server of -addr")
+    return 2
+  case *addr == "":
+    var err error
+    if server, err = startServer(*serverPath, strings.Fields(*serverArgs)); err != nil {
+      log.Printf("could not start the server: %v", err)
+      return 1
+    }
+    defer server.stop()
+    *addr, *token = server.addr, server.token
+  case *token == "":
+    log.Print("-addr needs the -token of the admin, the smoke test calls the admin RPCs")
+    return 2
+  case *useTLS || *caFile != "":
+    config, err := tlsConfig(*caFile)
+    if err != nil {
+      log.Print(err)
+      return 2
+    }
+    creds = credentials.NewTLS(config)
+  }
+
+  called := &calledMethods{}
+  conn, err := grpc.NewClient(*addr,
+    grpc.WithTransportCredentials(creds),
+    grpc.WithChainUnaryInterceptor(called.unaryInterceptor),
+    grpc.WithChainStreamInterceptor(called.streamInterceptor),
+  )
+  if err != nil {
+    log.Printf("failed to connect to grpc server: %v", err)
+    return 1
+  }
+  defer conn.Close()
+  if err := waitForServer(conn, server); err != nil {
+    log.Printf("the server at %s doesn't answer: %v", *addr, err)
+    return 1
+  }
+
+  results := newChecks(conn, *token).run(*verbose)
+  // The RPCs of the checks that didn't run weren't called, that says nothing of the checks.
+  var missing []string
+  if !results.stopped {
+    missing = called.missing()
+  }
+  for _, method := range missing {
+    fmt.Printf("FAIL  %s has no check, add one to checks.go\n", method)
+  }
+
+  fmt.Printf("\n%d passed, %d skipped, %d failed against %s\n", results.passed, results.skipped, results.failed+len(missing), *addr)
+  if results.failed > 0 || len(missing) > 0 {
+    return 1
+  }
+
+  return 0
+}
+
+func tlsConfig(caFile string) (*tls.Config, error) {
+  config := &tls.Config{}
+  if caFile == "" {
+    return config, nil
+  }
+
+  pem, err := os.ReadFile(caFile)
+  if err != nil {
+    return nil, err
+  }
+  config.RootCAs = x509.NewCertPool()
+  if !config.RootCAs.AppendCertsFromPEM(pem) {
+    return nil, fmt.Errorf("no certificate in %s", caFile)
+  }
+
+  return config, nil
+}
+
+// waitForServer calls GetPosts until the server answers, a server that was just started takes a moment.
+func waitForServer(conn *grpc.ClientConn, server *testServer) error {
+  deadline := time.Now().Add(30 * time.Second)
+  for {
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    _, err := pb.NewBlogClient(conn).GetPosts(ctx, &pb.GetPostsRequest{PageSize: 1})
+    cancel()
+    if status.Code(err) != codes.Unavailable && status.Code(err) != codes.DeadlineExceeded {
+      return err
+    }
+    if server != nil && server.exited() {
+      return fmt.Errorf("the server exited, its log:\n%s", server.log.String())
+    }
+    if time.Now().After(deadline) {
+      return err
+    }
+    time.Sleep(200 * time.Millisecond)
+  }
+}
+
+// calledMethods keeps the full names of the methods the checks called.
+type calledMethods struct {
+  mu      sync.Mutex
+  methods []string
+}
+
+func (c *calledMethods) add(method string) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  if !slices.Contains(c.methods, method) {
+    c.methods = append(c.methods, method)
+  }
+}
+
+func (c *calledMethods) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+  c.add(method)
+  return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (c *calledMethods) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+  c.add(method)
+  return streamer(ctx, desc, cc, method, opts...)
+}
+
+// missing returns the RPCs of the Blog and Admin services no check called.
+func (c *calledMethods) missing() []string {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  var missing []string
+  for _, service := range []grpc.ServiceDesc{pb.Blog_ServiceDesc, pb.Admin_ServiceDesc} {
+    var names []string
+    for _, method := range service.Methods {
+      names = append(names, method.MethodName)
+    }
+    for _, stream := range service.Streams {
+      names = append(names, stream.StreamName)
+    }
+    for _, name := range names {
+      if full := "/" + service.ServiceName + "/" + name; !slices.Contains(c.methods, full) {
+        missing = append(missing, full)
+      }
+    }
+  }
+
+  return missing
+}
+
+/*
+  THE SERVER UNDER TEST
+
+  A server of its own runs in a temporary directory, with a random admin token and on a port of localhost the system picked, so the smoke test can run next to a blog already listening on :3000. The port is found by listening on :0 and closing the listener right away, another program could take it in between, in which case the server fails to start and the smoke test says so.
+*/
+type testServer struct {
+  addr, token string
+  dir         string
+  cmd         *exec.Cmd
+  log         bytes.Buffer
+  done        chan struct{}
+}
+
+func startServer(binary string, args []string) (*testServer, error) {
+  dir, err := os.MkdirTemp("", "blog-smoketest")
+  if err != nil {
+    return nil, err
+  }
+  s := &testServer{dir: dir, token: randomHex(16), done: make(chan struct{})}
+  if err := s.start(binary, args); err != nil {
+    os.RemoveAll(dir)
+    return nil, err
+  }
+
+  return s, nil
+}
+
+func (s *testServer) start(binary string, args []string) error {
+  if binary == "" {
+    binary = filepath.Join(s.dir, "server")
+    build := exec.Command("go", "build", "-o", binary, ".")
+    if out, err := build.CombinedOutput(); err != nil {
+      return fmt.Errorf("go build: %w\n%s", err, out)
+    }
+  } else if abs, err := filepath.Abs(binary); err == nil {
+    // The server runs in s.dir, a relative path would be looked up there.
+    binary = abs
+  }
+
+  lis, err := net.Listen("tcp", "127.0.0.1:0")
+  if err != nil {
+    return err
+  }
+  s.addr = lis.Addr().String()
+  lis.Close()
+
+  // What the checks of the features that are off by default need, see above.
+  files := map[string]string{
+    "posts.json":    "[]",
+    "smoketest.yml": "max-concurrent: 64\n",
+  }
+  for name, content := range files {
+    if err := os.WriteFile(filepath.Join(s.dir, name), []byte(content), 0o644); err != nil {
+      return err
+    }
+  }
+
+  args = append([]string{
+    "-addr", s.addr,
+    "-admin-token", s.token,
+    "-site-url", "http://smoketest.localhost",
+    "-config", "smoketest.yml",
+    "-encryption-keys-env", "BLOG_SMOKETEST_KEYS",
+  }, args...)
+  s.cmd = exec.Command(binary, args...)
+  s.cmd.Dir = s.dir
+  s.cmd.Env = append(os.Environ(), "BLOG_SMOKETEST_KEYS=smoketest:"+randomHex(32))
+  s.cmd.Stdout, s.cmd.Stderr = &s.log, &s.log
+  if err := s.cmd.Start(); err != nil {
+    return err
+  }
+  go func() {
+    s.cmd.Wait()
+    close(s.done)
+  }()
+
+  return nil
+}
+
+func (s *testServer) exited() bool {
+  select {
+  case <-s.done:
+    return true
+  default:
+    return false
+  }
+}
+
+// stop shuts the server down with SIGTERM, like a deployment does, and removes its directory.
+func (s *testServer) stop() {
+  if s.cmd != nil && s.cmd.Process != nil && !s.exited() {
+    s.cmd.Process.Signal(syscall.SIGTERM)
+    select {
+    case <-s.done:
+    case <-time.After(30 * time.Second):
+      s.cmd.Process.Kill()
+      <-s.done
+      log.Printf("the server was still running 30s after SIGTERM")
+    }
+  }
+  os.RemoveAll(s.dir)
+}
+
+func randomHex(n int) string {
+  b := make([]byte, n)
+  rand.Read(b)
+
+  return hex.EncodeToString(b)
+}
+
+// errSkipped marks the checks of a feature the server runs without.
+var errSkipped = errors.New("off on this server")
+
+// skipDisabled turns the FailedPrecondition of a feature that is off into errSkipped, the other errors stay as they are.
+func skipDisabled(err error) error {
+  if status.Code(err) == codes.FailedPrecondition {
+    return fmt.Errorf("%w: %s", errSkipped, status.Convert(err).Message())
+  }
+
+  return err
+}
+
+// expectCode fails unless err has the code.
+func expectCode(err error, code codes.Code) error {
+  if got := status.Code(err); got != code {
+    return fmt.Errorf("got %v, want %v", err, code)
+  }
+
+  return nil
+}
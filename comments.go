@@ -0,0 +1,278 @@
+package main
+
+import (
+  "context"
+  "encoding/json"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/store"
+  "io/fs"
+  "os"
+  "slices"
+  "sync"
+  "time"
+)
+
+/*
+  COMMENTS
+
+  Readers comment on the published posts with AddComment, and answer each other: a comment with a ParentId is a reply to that comment, which makes threads. GetComments returns the comments of a post in the order they were written, every reply after the comment it answers, and clients nest them by ParentId:
+
+    go run ./client comments add -post <post id> -author Jane -content "Great post"
+    go run ./client comments add -post <post id> -parent <comment id> -author John -content "Agreed"
+    go run ./client comments list -post <post id>
+
+  MODERATION
+
+  A comment is PENDING, APPROVED or REJECTED. New comments go through the moderators of the posts (see moderation.go) with the "comment" kind: a rejected one isn't saved at all, a flagged one waits as PENDING with the reason of the moderator. What happens to the others depends on -comment-review:
+    - all: every comment waits for a moderator, the default
+    - flagged: comments are APPROVED right away, only the flagged ones wait
+
+  The moderators are the admins, they approve or reject the waiting comments, and can take back an approved one:
+
+    go run ./client comments list -token secret -post <post id> -status pending
+    go run ./client comments approve -token secret -id <comment id>
+    go run ./client comments reject -token secret -id <comment id> -reason "spam"
+
+  Who sees what depends on the caller: readers get the approved comments and the ones they wrote themselves, so authors can tell theirs is waiting, admins get every comment. The Statuses of GetComments only narrow that down. A reply stays when the comment it answers is rejected, clients show it as the answer to a removed comment. Only approved comments can be answered.
+
+  Readers report the comments breaking the rules to the moderators, see reports.go
+
+  The creator of the post gets a notification once a comment on it is approved, see notifications.go. Comments are kept in comments.json, -fsck drops the ones of posts that are gone (see fsck.go).
+*/
+const commentsPath = "comments.json"
+
+const (
+  commentReviewAll     = "all"
+  commentReviewFlagged = "flagged"
+)
+
+type commentStore struct {
+  path string
+  // Whether new comments that moderation let through wait for a moderator, see above.
+  reviewAll bool
+
+  // mu guards the comments file.
+  mu sync.Mutex
+}
+
+func newCommentStore(path, review string) (*commentStore, error) {
+  switch review {
+  case commentReviewAll, commentReviewFlagged:
+  default:
+    return nil, fmt.Errorf("-comment-review must be all or flagged, got %q", review)
+  }
+
+  return &commentStore{path: path, reviewAll: review == commentReviewAll}, nil
+}
+
+func (s *server) AddComment(ctx context.Context, req *pb.AddCommentRequest) (*pb.Comment, error) {
+  post, err := publishedPost(req.GetPostId())
+  if err != nil {
+    return nil, err
+  }
+
+  comment := &pb.Comment{
+    Id:        store.NewID(),
+    PostId:    post.Id,
+    ParentId:  req.GetParentId(),
+    Author:    req.GetAuthor(),
+    Content:   req.GetContent(),
+    CreatedAt: serverClock.Now().UTC().Format(time.RFC3339),
+    Status:    pb.CommentStatus_APPROVED,
+  }
+  if s.comments.reviewAll {
+    comment.Status = pb.CommentStatus_PENDING
+  }
+  if identity := reqctx.Identity(ctx); identity != anonymousIdentity {
+    comment.CreatedBy = identity
+  }
+  // Moderation may call an external service, which is best done before taking the lock.
+  if err := s.moderateComment(ctx, comment); err != nil {
+    return nil, err
+  }
+
+  c := s.comments
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  all, err := c.load()
+  if err != nil {
+    return nil, err
+  }
+  if comment.ParentId != "" {
+    i := slices.IndexFunc(all, func(parent *pb.Comment) bool { return parent.Id == comment.ParentId })
+    if i == -1 || all[i].PostId != post.Id || all[i].Status != pb.CommentStatus_APPROVED {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "ParentId %q is not an approved comment of the post", comment.ParentId)
+    }
+  }
+
+  if err := c.save(append(all, comment)); err != nil {
+    return nil, err
+  }
+  if comment.Status == pb.CommentStatus_APPROVED {
+    s.notifyComment(post, comment)
+  }
+
+  return comment, nil
+}
+
+func (s *server) GetComments(ctx context.Context, req *pb.GetCommentsRequest) (*pb.Comments, error) {
+  // The comments of a post readers can't open aren't found either.
+  if _, err := publishedPost(req.GetPostId()); err != nil {
+    return nil, err
+  }
+
+  c := s.comments
+  c.mu.Lock()
+  all, err := c.load()
+  c.mu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  identity := reqctx.Identity(ctx)
+  moderator := isAdmin(identity)
+
+  comments := &pb.Comments{Comments: make([]*pb.Comment, 0)}
+  for _, comment := range all {
+    if comment.PostId != req.GetPostId() {
+      continue
+    }
+    own := identity != anonymousIdentity && comment.CreatedBy == identity
+    if !moderator && !own && comment.Status != pb.CommentStatus_APPROVED {
+      continue
+    }
+    if len(req.GetStatuses()) > 0 && !slices.Contains(req.GetStatuses(), comment.Status) {
+      continue
+    }
+    comments.Comments = append(comments.Comments, comment)
+  }
+
+  return comments, nil
+}
+
+func (s *server) ApproveComment(ctx context.Context, req *pb.ModerateCommentRequest) (*pb.Comment, error) {
+  return s.moderateCommentStatus(ctx, req, pb.CommentStatus_APPROVED)
+}
+
+func (s *server) RejectComment(ctx context.Context, req *pb.ModerateCommentRequest) (*pb.Comment, error) {
+  return s.moderateCommentStatus(ctx, req, pb.CommentStatus_REJECTED)
+}
+
+// moderateCommentStatus gives the comment the status a moderator decided on.
+func (s *server) moderateCommentStatus(ctx context.Context, req *pb.ModerateCommentRequest, status pb.CommentStatus) (*pb.Comment, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  var approved bool
+  comment, err := s.comments.change(req.GetId(), func(comment *pb.Comment) {
+    approved = status == pb.CommentStatus_APPROVED && comment.Status != pb.CommentStatus_APPROVED
+    comment.Status, comment.ModerationReason = status, req.GetReason()
+  })
+  if err != nil {
+    return nil, err
+  }
+  // The post may have been unpublished since, then nobody reads the comment anyway.
+  if post, err := publishedPost(comment.PostId); approved && err == nil {
+    s.notifyComment(post, comment)
+  }
+
+  return comment, nil
+}
+
+// notifyComment tells the creator of the post about an approved comment, unless the creator wrote it.
+func (s *server) notifyComment(post *pb.Post, comment *pb.Comment) {
+  if comment.CreatedBy != "" && comment.CreatedBy == post.CreatedBy {
+    return
+  }
+  s.notifications.notify(pb.NotificationType_NOTIFICATION_NEW_COMMENT, post)
+}
+
+// publishedPost returns the post with the ID when readers can see it.
+func publishedPost(id string) (*pb.Post, error) {
+  posts, err := readPosts(store.Query{IDs: []string{id}})
+  if err != nil {
+    return nil, err
+  }
+  post, err := findPost(posts, id)
+  if err != nil {
+    return nil, err
+  }
+  if post.Status != pb.PostStatus_PUBLISHED {
+    return nil, apperr.Errorf(apperr.ErrPostNotFound, "post %q not found", id)
+  }
+
+  return post, nil
+}
+
+// get returns the comment with the ID.
+func (c *commentStore) get(id string) (*pb.Comment, error) {
+  c.mu.Lock()
+  all, err := c.load()
+  c.mu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+  i := slices.IndexFunc(all, func(comment *pb.Comment) bool { return comment.Id == id })
+  if i == -1 {
+    return nil, apperr.Errorf(apperr.ErrCommentNotFound, "comment %q not found", id)
+  }
+
+  return all[i], nil
+}
+
+// change runs change on the comment with the ID and saves it.
+func (c *commentStore) change(id string, change func(comment *pb.Comment)) (*pb.Comment, error) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  all, err := c.load()
+  if err != nil {
+    return nil, err
+  }
+  i := slices.IndexFunc(all, func(comment *pb.Comment) bool { return comment.Id == id })
+  if i == -1 {
+    return nil, apperr.Errorf(apperr.ErrCommentNotFound, "comment %q not found", id)
+  }
+
+  change(all[i])
+  if err := c.save(all); err != nil {
+    return nil, err
+  }
+
+  return all[i], nil
+}
+
+func (c *commentStore) load() ([]*pb.Comment, error) {
+  var all []*pb.Comment
+
+  data, err := os.ReadFile(c.path)
+  // No file yet simply means nobody has commented so far.
+  if errors.Is(err, fs.ErrNotExist) {
+    return all, nil
+  }
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read comments file: %w", err)
+  }
+
+  if err := json.Unmarshal(data, &all); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to parse comments: %w", err)
+  }
+
+  return all, nil
+}
+
+func (c *commentStore) save(all []*pb.Comment) error {
+  if err := replaceFile(c.path, all); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save comments: %w", err)
+  }
+
+  return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+  "go/tutorial/grpc/internal/compression"
+  "log"
+
+  // Registers the gzip compressor, grpc-go comes with it but only turns it on when imported.
+  _ "google.golang.org/grpc/encoding/gzip"
+)
+
+/*
+  COMPRESSION
+
+  gRPC can compress every message, the client picks the compressor and the server answers with the same one. A server only understands the compressors it registered, a call naming another one fails with Unimplemented, so clients that don't ask for compression are never affected.
+
+  gzip is always registered. zstd, usually as small and much faster (see internal/compression), is registered with -zstd:
+
+    go run . -zstd
+
+  and picked by blogctl with the compressor of the profile (see config.go in the client):
+
+    profiles:
+      local:
+        address: localhost:3000
+        compressor: zstd
+
+  go test -bench GetPosts -run '^$' compares none, gzip and zstd on a large GetPosts, see compression_test.go.
+*/
+func registerCompressors(zstd bool) {
+  if zstd {
+    compression.RegisterZstd()
+    log.Printf("accepting zstd compressed calls")
+  }
+}
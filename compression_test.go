@@ -0,0 +1,170 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/compression"
+  "math/rand"
+  "net"
+  "strings"
+  "sync"
+  "sync/atomic"
+  "testing"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials/insecure"
+  "google.golang.org/grpc/test/bufconn"
+  "google.golang.org/protobuf/proto"
+)
+
+// memoryStore keeps the posts of the tests in memory, posts.json is left alone.
+type memoryStore struct {
+  mu    sync.Mutex
+  posts []*pb.Post
+}
+
+func (m *memoryStore) Load() ([]*pb.Post, error) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  return m.posts, nil
+}
+
+func (m *memoryStore) Save(posts []*pb.Post) error {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  m.posts = posts
+  return nil
+}
+
+// countingConn counts the bytes the client reads, which is what the responses take on the wire.
+type countingConn struct {
+  net.Conn
+  read *atomic.Int64
+}
+
+func (c *countingConn) Read(p []byte) (int, error) {
+  n, err := c.Conn.Read(p)
+  c.read.Add(int64(n))
+  return n, err
+}
+
+// largePosts returns posts of a few kilobytes of text each, text compresses about like real posts do.
+func largePosts(n int) []*pb.Post {
+  words := strings.Fields("the a gRPC server streams posts over HTTP/2 while every client keeps its own cache of what it read last, so a reconnect only asks for what changed since then and the protobuf messages stay small")
+  r := rand.New(rand.NewSource(1))
+
+  posts := make([]*pb.Post, n)
+  for i := range posts {
+    var content strings.Builder
+    for content.Len() < 4096 {
+      content.WriteString(words[r.Intn(len(words))])
+      content.WriteByte(' ')
+    }
+    posts[i] = &pb.Post{
+      Id:        fmt.Sprintf("post-%d", i),
+      Sequence:  int64(i + 1),
+      Slug:      fmt.Sprintf("post-%d", i),
+      Title:     fmt.Sprintf("Post number %d", i),
+      Author:    "John McWilly",
+      Content:   content.String(),
+      CreatedAt: "2025-01-02",
+      Tags:      []string{"grpc", "go"},
+    }
+  }
+
+  return posts
+}
+
+// startCompressionServer serves the posts over an in-memory listener and returns a client, along with the count of bytes it reads.
+func startCompressionServer(tb testing.TB, posts []*pb.Post) (pb.BlogClient, *atomic.Int64) {
+  tb.Helper()
+
+  previous := postStore
+  postStore = &memoryStore{posts: posts}
+  tb.Cleanup(func() { postStore = previous })
+
+  registerCompressors(true)
+
+  lis := bufconn.Listen(1 << 20)
+  srv := grpc.NewServer()
+  pb.RegisterBlogServer(srv, &server{})
+  go srv.Serve(lis)
+  tb.Cleanup(srv.Stop)
+
+  read := &atomic.Int64{}
+  conn, err := grpc.NewClient("passthrough:///bufconn",
+    grpc.WithTransportCredentials(insecure.NewCredentials()),
+    grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+      conn, err := lis.DialContext(ctx)
+      return &countingConn{Conn: conn, read: read}, err
+    }),
+    grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(64<<20)),
+  )
+  if err != nil {
+    tb.Fatal(err)
+  }
+  tb.Cleanup(func() { conn.Close() })
+
+  return pb.NewBlogClient(conn), read
+}
+
+// compressors are the ones compared, an empty name sends the calls uncompressed.
+var compressors = []struct{ name, compressor string }{{"none", ""}, {"gzip", "gzip"}, {"zstd", compression.Zstd}}
+
+func callOptions(compressor string) []grpc.CallOption {
+  if compressor == "" {
+    return nil
+  }
+
+  return []grpc.CallOption{grpc.UseCompressor(compressor)}
+}
+
+func TestGetPostsCompression(t *testing.T) {
+  posts := largePosts(50)
+  client, read := startCompressionServer(t, posts)
+
+  uncompressed := int64(0)
+  for _, c := range compressors {
+    t.Run(c.name, func(t *testing.T) {
+      before := read.Load()
+      got, err := client.GetPosts(context.Background(), &pb.GetPostsRequest{}, callOptions(c.compressor)...)
+      if err != nil {
+        t.Fatalf("GetPosts: %v", err)
+      }
+      if !proto.Equal(got, &pb.Posts{Posts: posts}) {
+        t.Fatalf("GetPosts returned other posts than the stored ones")
+      }
+
+      wire := read.Load() - before
+      if c.compressor == "" {
+        uncompressed = wire
+        return
+      }
+      if uncompressed > 0 && wire*2 > uncompressed {
+        t.Errorf("%s response took %d bytes, more than half of the %d uncompressed", c.name, wire, uncompressed)
+      }
+    })
+  }
+}
+
+// BenchmarkGetPosts compares none, gzip and zstd on a GetPosts of 500 posts of 4 KB, wire-B/op is the size of the response on the wire.
+func BenchmarkGetPosts(b *testing.B) {
+  client, read := startCompressionServer(b, largePosts(500))
+
+  for _, c := range compressors {
+    b.Run(c.name, func(b *testing.B) {
+      opts := callOptions(c.compressor)
+      before := read.Load()
+      b.ResetTimer()
+      for range b.N {
+        if _, err := client.GetPosts(context.Background(), &pb.GetPostsRequest{}, opts...); err != nil {
+          b.Fatalf("GetPosts: %v", err)
+        }
+      }
+      b.ReportMetric(float64(read.Load()-before)/float64(b.N), "wire-B/op")
+    })
+  }
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+  "context"
+  "flag"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "log"
+  "maps"
+  "os"
+  "os/signal"
+  "slices"
+  "strconv"
+  "sync"
+  "syscall"
+  "time"
+
+  "gopkg.in/yaml.v3"
+)
+
+/*
+  CONFIGURATION FILE
+
+  With enough flags the command line gets long, -config reads them from a YAML file instead. The keys are the names of the flags:
+
+    # blog.yaml
+    max-concurrent: 32
+    method-timeouts: GetPosts=2s,CreatePost=5s
+    mirror-rps: 5
+    mirror-burst: 10
+    debug-log: false
+
+    go run . -config blog.yaml
+
+  Flags given on the command line win over the file, so a setting can be tried once without editing it. A key that isn't a flag stops the server, a typo would otherwise go unnoticed.
+
+  Some settings can change while the server runs. After editing the file, send the server SIGHUP or call the ReloadConfig Admin RPC, both read the file again:
+
+    kill -HUP <pid>
+    go run ./client reload-config -token secret
+
+  The settings applied right away are the limits and the timeouts (max-concurrent, max-conns-per-ip, max-streams-per-ip, method-timeouts, slow-call-threshold, mirror-rps, mirror-burst), the injected faults (chaos), the cache TTLs (mirror-cache-ttl, redis-cache-ttl), the debug log (debug-log, debug-redact) and the schedules of the recurring tasks (cron). The answer lists them, with the settings that changed but only take effect on the next start, like addr or storage. A key removed from the file goes back to the default of its flag.
+
+  A reload applies everything or nothing: every new value is checked first, and one invalid value leaves all the settings as they were.
+*/
+type serverConfig struct {
+  path string
+  // cmdline are the flags given on the command line, the file doesn't change them.
+  cmdline map[string]bool
+
+  mu sync.Mutex
+  // loaded are the values of the file as of the last load.
+  loaded    map[string]string
+  reloaders map[string]configReloader
+}
+
+// configReloader checks a new value of a setting and returns the function applying it.
+type configReloader func(value string) (apply func(), err error)
+
+// loadServerConfig reads the file and sets the flags it lists, except the ones given on the command line. It must run after flag.Parse.
+func loadServerConfig(path string) (*serverConfig, error) {
+  c := &serverConfig{path: path, cmdline: map[string]bool{}, reloaders: map[string]configReloader{}}
+  flag.Visit(func(f *flag.Flag) { c.cmdline[f.Name] = true })
+
+  values, err := c.read()
+  if err != nil {
+    return nil, err
+  }
+  for name, value := range values {
+    if c.cmdline[name] {
+      continue
+    }
+    if err := flag.Set(name, value); err != nil {
+      return nil, fmt.Errorf("invalid %s in %s: %w", name, path, err)
+    }
+  }
+  c.loaded = values
+
+  return c, nil
+}
+
+func (c *serverConfig) read() (map[string]string, error) {
+  data, err := os.ReadFile(c.path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read %s: %w", c.path, err)
+  }
+
+  values := map[string]string{}
+  if err := yaml.Unmarshal(data, &values); err != nil {
+    return nil, fmt.Errorf("failed to parse %s: %w", c.path, err)
+  }
+  for name := range values {
+    if name == "config" || flag.Lookup(name) == nil {
+      return nil, fmt.Errorf("unknown setting %q in %s", name, c.path)
+    }
+  }
+
+  return values, nil
+}
+
+// handle makes the setting name change on reload.
+func (c *serverConfig) handle(name string, reload configReloader) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  c.reloaders[name] = reload
+}
+
+// reload reads the file again and applies the settings that changed.
+func (c *serverConfig) reload() (*pb.ConfigReload, error) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  values, err := c.read()
+  if err != nil {
+    return nil, err
+  }
+
+  result := &pb.ConfigReload{Path: c.path}
+  var applies []func()
+  // Keys removed from the file count too, their flag goes back to its default.
+  names := slices.Collect(maps.Keys(values))
+  for name := range c.loaded {
+    if _, ok := values[name]; !ok {
+      names = append(names, name)
+    }
+  }
+  slices.Sort(names)
+
+  for _, name := range names {
+    f := flag.Lookup(name)
+    value, ok := values[name]
+    if !ok {
+      value = f.DefValue
+    }
+    if c.cmdline[name] {
+      if ok && value != c.loaded[name] {
+        result.RequiresRestart = append(result.RequiresRestart, &pb.ConfigChange{Name: name, Old: f.Value.String(), New: value, Note: "given on the command line, which wins over the file"})
+      }
+      continue
+    }
+    if value == f.Value.String() {
+      continue
+    }
+
+    change := &pb.ConfigChange{Name: name, Old: f.Value.String(), New: value}
+    reload, ok := c.reloaders[name]
+    if !ok {
+      change.Note = "only read when the server starts"
+      result.RequiresRestart = append(result.RequiresRestart, change)
+      continue
+    }
+    apply, err := reload(value)
+    if err != nil {
+      return nil, fmt.Errorf("invalid %s in %s: %w", name, c.path, err)
+    }
+    applies = append(applies, func() {
+      apply()
+      // The flag keeps the applied value, it is the Old of the next reload.
+      flag.Set(name, value)
+    })
+    result.Applied = append(result.Applied, change)
+  }
+
+  for _, apply := range applies {
+    apply()
+  }
+  c.loaded = values
+
+  return result, nil
+}
+
+// handleServerConfig makes the settings of the primary server change on reload.
+func handleServerConfig(c *serverConfig, concurrency *concurrencyLimiter, limits *peerLimits, slowCalls *slowCallWatchdog, timeouts *serverTimeouts, chaos *chaosInjector, debug *debugLogger, cache *redisCache, cron *cronScheduler) {
+  c.handle("max-concurrent", func(value string) (func(), error) {
+    max, err := strconv.Atoi(value)
+    if err != nil || max < 0 {
+      return nil, fmt.Errorf("expected a number of requests, 0 for no limit")
+    }
+    return func() { concurrency.resize(max) }, nil
+  })
+  c.handle("max-conns-per-ip", func(value string) (func(), error) {
+    max, err := strconv.Atoi(value)
+    if err != nil || max < 0 {
+      return nil, fmt.Errorf("expected a number of connections, 0 for no limit")
+    }
+    return func() { limits.setMaxConns(max) }, nil
+  })
+  c.handle("max-streams-per-ip", func(value string) (func(), error) {
+    max, err := strconv.Atoi(value)
+    if err != nil || max < 0 {
+      return nil, fmt.Errorf("expected a number of calls, 0 for no limit")
+    }
+    return func() { limits.setMaxStreams(max) }, nil
+  })
+  c.handle("slow-call-threshold", func(value string) (func(), error) {
+    threshold, err := time.ParseDuration(value)
+    if err != nil || threshold < 0 {
+      return nil, fmt.Errorf("expected a duration, 0 to turn the watchdog off")
+    }
+    return func() { slowCalls.setThreshold(threshold) }, nil
+  })
+  c.handle("method-timeouts", func(value string) (func(), error) {
+    parsed, err := parseMethodTimeouts(value)
+    if err != nil {
+      return nil, err
+    }
+    return func() { timeouts.set(parsed) }, nil
+  })
+  c.handle("chaos", func(value string) (func(), error) {
+    faults, err := parseChaos(value)
+    if err != nil {
+      return nil, err
+    }
+    return func() { chaos.set(faults) }, nil
+  })
+  c.handle("debug-log", func(value string) (func(), error) {
+    enabled, err := strconv.ParseBool(value)
+    if err != nil {
+      return nil, fmt.Errorf("expected true or false")
+    }
+    return func() { debug.set(enabled, nil) }, nil
+  })
+  c.handle("debug-redact", func(value string) (func(), error) {
+    redact := splitList(value)
+    // set keeps the fields when given none.
+    if len(redact) == 0 {
+      return nil, fmt.Errorf("expected at least one field")
+    }
+    return func() { debug.set(debug.get().Enabled, redact) }, nil
+  })
+  c.handle("cron", func(value string) (func(), error) {
+    if err := cron.checkSchedules(value); err != nil {
+      return nil, err
+    }
+    return func() { cron.setSchedules(value) }, nil
+  })
+
+  // Without -redis-addr there is no cache to change, the TTL applies once the server starts with one.
+  if cache != nil {
+    c.handle("redis-cache-ttl", func(value string) (func(), error) {
+      ttl, err := parsePositiveDuration(value)
+      if err != nil {
+        return nil, err
+      }
+      return func() { cache.ttl.Store(int64(ttl)) }, nil
+    })
+  }
+}
+
+// handleMirrorConfig makes the settings of the mirror change on reload, when there is one.
+func handleMirrorConfig(c *serverConfig, mirror *mirrorServer, limiter *peerLimiter) {
+  c.handle("mirror-cache-ttl", func(value string) (func(), error) {
+    ttl, err := parsePositiveDuration(value)
+    if err != nil {
+      return nil, err
+    }
+    return func() { mirror.setTTL(ttl) }, nil
+  })
+  // The rate and the burst may change in the same reload, each one keeps the current value of the other.
+  c.handle("mirror-rps", func(value string) (func(), error) {
+    rps, err := strconv.ParseFloat(value, 64)
+    if err != nil || rps <= 0 {
+      return nil, fmt.Errorf("expected a positive number of requests per second")
+    }
+    return func() {
+      _, burst := limiter.limits()
+      limiter.setLimit(rps, burst)
+    }, nil
+  })
+  c.handle("mirror-burst", func(value string) (func(), error) {
+    burst, err := strconv.Atoi(value)
+    if err != nil || burst <= 0 {
+      return nil, fmt.Errorf("expected a positive number of requests")
+    }
+    return func() {
+      rps, _ := limiter.limits()
+      limiter.setLimit(rps, burst)
+    }, nil
+  })
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+  d, err := time.ParseDuration(value)
+  if err != nil || d <= 0 {
+    return 0, fmt.Errorf("expected a positive duration like 30s")
+  }
+
+  return d, nil
+}
+
+// reloadOnHangup reloads the file every time the server gets SIGHUP, until ctx is done.
+func (c *serverConfig) reloadOnHangup(ctx context.Context) error {
+  hangup := make(chan os.Signal, 1)
+  signal.Notify(hangup, syscall.SIGHUP)
+  defer signal.Stop(hangup)
+
+  for {
+    select {
+    case <-ctx.Done():
+      return nil
+    case <-hangup:
+      result, err := c.reload()
+      if err != nil {
+        log.Printf("config reload failed, nothing changed: %s", err)
+        continue
+      }
+      logConfigReload(result)
+    }
+  }
+}
+
+func logConfigReload(result *pb.ConfigReload) {
+  log.Printf("config reloaded from %s: %d applied, %d require a restart", result.Path, len(result.Applied), len(result.RequiresRestart))
+  for _, change := range result.Applied {
+    log.Printf("  %s: %q -> %q", change.Name, change.Old, change.New)
+  }
+  for _, change := range result.RequiresRestart {
+    log.Printf("  %s: %q -> %q not applied, %s", change.Name, change.Old, change.New, change.Note)
+  }
+}
+
+func (a *adminServer) ReloadConfig(ctx context.Context, _ *pb.ReloadConfigRequest) (*pb.ConfigReload, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+  if a.config == nil {
+    return nil, apperr.Errorf(apperr.ErrFeatureDisabled, "the server was started without -config")
+  }
+
+  result, err := a.config.reload()
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "config reload failed, nothing changed: %w", err)
+  }
+  logConfigReload(result)
+
+  return result, nil
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+  "cmp"
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "net"
+  "slices"
+  "strings"
+  "sync"
+  "sync/atomic"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/stats"
+)
+
+/*
+  CONNECTIONS
+
+  "Clients can't connect" or "one client is slow" are hard to debug from the calls alone, it takes knowing who is connected, through which listener, with how many calls running. Two Admin RPCs tell:
+
+    go run ./client connections -token secret         the totals, by listener and by peer
+    go run ./client connections -token secret -list   every open connection
+
+  The numbers come from a stats handler, which gRPC tells about every connection opening and closing and every message going through, its size on the wire included. It sees what the interceptors don't: a connection that never made a call, or a stream that is still running.
+
+  Calls and bytes are counted per connection, and go away with it. The peers are the remote addresses of the open connections, a client behind a NAT or a proxy shares its address with the others there.
+
+  The connections of -http are the HTTP server's, handed to gRPC one request at a time (see http.go), they aren't counted. Neither are the ones of the mirror, a separate server.
+
+  On shutdown the server logs how many connections and calls are left to drain, and how long they took.
+
+  CHANNELZ
+
+  For the details gRPC keeps, the state of every socket, its flow control windows and the keepalives, -channelz also serves the channelz service of gRPC, which tools like grpcdebug and grpcurl read:
+
+    grpcurl -plaintext -H 'authorization: Bearer secret' localhost:3000 grpc.channelz.v1.Channelz/GetServers
+
+  It requires admin credentials like the Admin RPCs, an interceptor checks them since the service is gRPC's own.
+*/
+const channelzService = "/grpc.channelz.v1.Channelz/"
+
+type connectionTracker struct {
+  listeners []net.Listener
+  limits    *peerLimits
+
+  accepted atomic.Int64
+  closed   atomic.Int64
+
+  mu    sync.Mutex
+  conns map[*trackedConn]struct{}
+  // byListener counts the connections accepted through every listener.
+  byListener map[string]int64
+}
+
+type trackedConn struct {
+  remote   net.Addr
+  listener string
+  openedAt time.Time
+
+  streams atomic.Int32
+  calls   atomic.Int64
+  in, out atomic.Int64
+  // Unix nanoseconds, 0 before the first call.
+  lastCall atomic.Int64
+}
+
+type trackedConnKey struct{}
+
+func newConnectionTracker(listeners []net.Listener, limits *peerLimits) *connectionTracker {
+  return &connectionTracker{listeners: listeners, limits: limits, conns: map[*trackedConn]struct{}{}, byListener: map[string]int64{}}
+}
+
+// listenerOf returns the address of the listener a connection with the local address came through.
+func (t *connectionTracker) listenerOf(local net.Addr) string {
+  for _, lis := range t.listeners {
+    addr := lis.Addr()
+    if addr.Network() != local.Network() {
+      continue
+    }
+    // A listener on [::]:3000 accepts connections on every address of the machine, only the port tells.
+    listening, ok := addr.(*net.TCPAddr)
+    accepted, _ := local.(*net.TCPAddr)
+    if ok && accepted != nil && listening.Port == accepted.Port && (listening.IP.IsUnspecified() || listening.IP.Equal(accepted.IP)) {
+      return addr.String()
+    }
+    if !ok && addr.String() == local.String() {
+      return addr.String()
+    }
+  }
+
+  return local.String()
+}
+
+func (t *connectionTracker) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+  conn := &trackedConn{remote: info.RemoteAddr, listener: t.listenerOf(info.LocalAddr), openedAt: time.Now()}
+  return context.WithValue(ctx, trackedConnKey{}, conn)
+}
+
+func (t *connectionTracker) HandleConn(ctx context.Context, s stats.ConnStats) {
+  conn, ok := ctx.Value(trackedConnKey{}).(*trackedConn)
+  if !ok {
+    return
+  }
+
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  switch s.(type) {
+  case *stats.ConnBegin:
+    t.accepted.Add(1)
+    t.byListener[conn.listener]++
+    t.conns[conn] = struct{}{}
+  case *stats.ConnEnd:
+    t.closed.Add(1)
+    delete(t.conns, conn)
+  }
+}
+
+func (t *connectionTracker) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+  return ctx
+}
+
+func (t *connectionTracker) HandleRPC(ctx context.Context, s stats.RPCStats) {
+  conn, ok := ctx.Value(trackedConnKey{}).(*trackedConn)
+  if !ok {
+    return
+  }
+
+  switch s := s.(type) {
+  case *stats.Begin:
+    conn.streams.Add(1)
+    conn.calls.Add(1)
+    conn.lastCall.Store(s.BeginTime.UnixNano())
+  case *stats.End:
+    conn.streams.Add(-1)
+  case *stats.InPayload:
+    conn.in.Add(int64(s.WireLength))
+  case *stats.OutPayload:
+    conn.out.Add(int64(s.WireLength))
+  }
+}
+
+// open returns the open connections, the oldest first.
+func (t *connectionTracker) open() []*trackedConn {
+  t.mu.Lock()
+  conns := make([]*trackedConn, 0, len(t.conns))
+  for conn := range t.conns {
+    conns = append(conns, conn)
+  }
+  t.mu.Unlock()
+
+  slices.SortFunc(conns, func(a, b *trackedConn) int { return a.openedAt.Compare(b.openedAt) })
+
+  return conns
+}
+
+// peerAddress is the IP address of the remote end, "unix" for the clients of a Unix socket, which have no address.
+func peerAddress(addr net.Addr) string {
+  if tcp, ok := addr.(*net.TCPAddr); ok {
+    return tcp.IP.String()
+  }
+
+  return remoteAddress(addr)
+}
+
+func remoteAddress(addr net.Addr) string {
+  if name := addr.String(); name != "" && name != "@" {
+    return name
+  }
+
+  return addr.Network()
+}
+
+func formatLastCall(nanos int64) string {
+  if nanos == 0 {
+    return ""
+  }
+
+  return time.Unix(0, nanos).UTC().Format(time.RFC3339)
+}
+
+func (t *connectionTracker) stats() *pb.ConnectionStats {
+  conns := t.open()
+  result := &pb.ConnectionStats{OpenConnections: int32(len(conns)), AcceptedConnections: t.accepted.Load(), ClosedConnections: t.closed.Load()}
+  result.RejectedConnections, result.RejectedCalls = t.limits.rejectedConns.Load(), t.limits.rejectedStreams.Load()
+  t.limits.mu.Lock()
+  result.MaxConnectionsPerIp, result.MaxCallsPerIp = int32(t.limits.maxConns), int32(t.limits.maxStreams)
+  t.limits.mu.Unlock()
+
+  listeners := map[string]*pb.ListenerStats{}
+  t.mu.Lock()
+  for _, lis := range t.listeners {
+    addr := lis.Addr().String()
+    listeners[addr] = &pb.ListenerStats{Address: addr, AcceptedConnections: t.byListener[addr]}
+    result.Listeners = append(result.Listeners, listeners[addr])
+  }
+  t.mu.Unlock()
+
+  peers := map[string]*pb.PeerTraffic{}
+  lastCalls := map[string]int64{}
+  for _, conn := range conns {
+    streams := conn.streams.Load()
+    result.ActiveStreams += streams
+    if lis, ok := listeners[conn.listener]; ok {
+      lis.OpenConnections++
+    }
+
+    addr := peerAddress(conn.remote)
+    peer, ok := peers[addr]
+    if !ok {
+      peer = &pb.PeerTraffic{Address: addr}
+      peers[addr] = peer
+      result.Peers = append(result.Peers, peer)
+    }
+    peer.OpenConnections++
+    peer.ActiveStreams += streams
+    peer.Calls += conn.calls.Load()
+    peer.BytesReceived += conn.in.Load()
+    peer.BytesSent += conn.out.Load()
+    lastCalls[addr] = max(lastCalls[addr], conn.lastCall.Load())
+  }
+  for _, peer := range result.Peers {
+    peer.LastCallAt = formatLastCall(lastCalls[peer.Address])
+  }
+  slices.SortStableFunc(result.Peers, func(a, b *pb.PeerTraffic) int {
+    return cmp.Or(cmp.Compare(b.OpenConnections, a.OpenConnections), strings.Compare(a.Address, b.Address))
+  })
+
+  return result
+}
+
+func (t *connectionTracker) list() *pb.Connections {
+  result := &pb.Connections{}
+  for _, conn := range t.open() {
+    result.Connections = append(result.Connections, &pb.Connection{
+      RemoteAddress: remoteAddress(conn.remote),
+      LocalAddress:  conn.listener,
+      OpenedAt:      conn.openedAt.UTC().Format(time.RFC3339),
+      ActiveStreams: conn.streams.Load(),
+      Calls:         conn.calls.Load(),
+      BytesReceived: conn.in.Load(),
+      BytesSent:     conn.out.Load(),
+      LastCallAt:    formatLastCall(conn.lastCall.Load()),
+    })
+  }
+
+  return result
+}
+
+// logDraining logs what is left to drain when the shutdown starts, and returns the function logging it is done.
+func (t *connectionTracker) logDraining() func() {
+  stats := t.stats()
+  log.Printf("draining %d connections, %d calls running", stats.GetOpenConnections(), stats.GetActiveStreams())
+  start := time.Now()
+
+  return func() {
+    log.Printf("connections drained in %s", time.Since(start).Round(time.Millisecond))
+  }
+}
+
+func (a *adminServer) GetConnectionStats(ctx context.Context, _ *pb.GetConnectionStatsRequest) (*pb.ConnectionStats, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  return a.connections.stats(), nil
+}
+
+func (a *adminServer) ListConnections(ctx context.Context, _ *pb.ListConnectionsRequest) (*pb.Connections, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  return a.connections.list(), nil
+}
+
+// channelzInterceptor requires admin credentials for the channelz service, see CHANNELZ above.
+func (a *authenticator) channelzInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  if strings.HasPrefix(info.FullMethod, channelzService) {
+    if err := a.requireAdmin(ctx); err != nil {
+      return nil, err
+    }
+  }
+
+  return handler(ctx, req)
+}
@@ -0,0 +1,445 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "log"
+  "runtime/debug"
+  "slices"
+  "strconv"
+  "strings"
+  "sync"
+  "time"
+)
+
+/*
+  RECURRING TASKS
+
+  Some work has to happen every now and then rather than on every call: cleaning up the abandoned drafts, dropping the audit entries older than -audit-retention, or rolling the views of the past days up into hours. The cron scheduler runs these tasks on the schedules given by -cron, which is a list of name=schedule separated by semicolons, the commas being taken by the schedules themselves:
+
+    go run . -cron "compact-audit=0 3 * * *;rollup-summaries=@hourly;rollup-views=@hourly"
+
+  A schedule is either the five fields of crontab (minute, hour, day of the month, month, day of the week, each one *, a number, a range like 1-5, a range or * followed by a step like /15, or a list of those), one of @hourly, @daily, @weekly and @monthly, or @every followed by a duration, like @every 30m. The times are UTC. A task missing from -cron, or given off, never runs on its own.
+
+  -cron can sit in the -config file like any flag, and a reload applies the new schedules right away (see config.go). The Admin service lists the tasks and runs one on demand, whatever its schedule:
+
+    go run ./client cron list -token secret
+    go run ./client cron run -token secret compact-audit
+
+  A task never runs twice at the same time: a task still running when it is due again skips that run, and RunScheduledTask fails with Aborted. The tasks are:
+    - clean-drafts: archives or deletes the drafts untouched for longer than -draft-retention, see drafts.go
+    - compact-audit: rewrites audit.jsonl without the entries older than -audit-retention, see audit.go
+    - rollup-summaries: sums the views of the days that ended by post and author, see summaries.go
+    - rollup-views: merges the minutes of views older than a day into their hour, see views.go
+*/
+const defaultCronSchedules = "clean-drafts=@daily;compact-audit=@daily;rollup-views=@hourly"
+
+// cronSchedule is a parsed schedule, either every or the fields of crontab.
+type cronSchedule struct {
+  every                         time.Duration
+  minute, hour, dom, month, dow []bool
+  domRestricted, dowRestricted  bool
+}
+
+var cronDescriptors = map[string]string{
+  "@hourly":  "0 * * * *",
+  "@daily":   "0 0 * * *",
+  "@weekly":  "0 0 * * 0",
+  "@monthly": "0 0 1 * *",
+}
+
+func parseCronSchedule(spec string) (*cronSchedule, error) {
+  if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+    every, err := time.ParseDuration(strings.TrimSpace(rest))
+    if err != nil || every < time.Second {
+      return nil, fmt.Errorf("invalid schedule %q, @every takes a duration of a second or more", spec)
+    }
+    return &cronSchedule{every: every}, nil
+  }
+  if fields, ok := cronDescriptors[spec]; ok {
+    spec = fields
+  }
+
+  fields := strings.Fields(spec)
+  if len(fields) != 5 {
+    return nil, fmt.Errorf("invalid schedule %q, expected the five fields of crontab, @hourly, @daily, @weekly, @monthly or @every <duration>", spec)
+  }
+
+  s := &cronSchedule{}
+  var err error
+  ranges := []struct {
+    set      *[]bool
+    min, max int
+  }{{&s.minute, 0, 59}, {&s.hour, 0, 23}, {&s.dom, 1, 31}, {&s.month, 1, 12}, {&s.dow, 0, 7}}
+  for i, r := range ranges {
+    if *r.set, err = parseCronField(fields[i], r.min, r.max); err != nil {
+      return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+    }
+  }
+  // Sunday is either 0 or 7.
+  s.dow[0] = s.dow[0] || s.dow[7]
+  s.domRestricted, s.dowRestricted = fields[2] != "*", fields[4] != "*"
+
+  return s, nil
+}
+
+// parseCronField returns which values between min and max the field matches.
+func parseCronField(field string, min, max int) ([]bool, error) {
+  set := make([]bool, max+1)
+
+  for _, part := range strings.Split(field, ",") {
+    expr, stepText, hasStep := strings.Cut(part, "/")
+    step := 1
+    if hasStep {
+      var err error
+      if step, err = strconv.Atoi(stepText); err != nil || step <= 0 {
+        return nil, fmt.Errorf("invalid step in %q", part)
+      }
+    }
+
+    low, high := min, max
+    if expr != "*" {
+      lowText, highText, isRange := strings.Cut(expr, "-")
+      var err error
+      if low, err = strconv.Atoi(lowText); err != nil {
+        return nil, fmt.Errorf("invalid value in %q", part)
+      }
+      high = low
+      if isRange {
+        if high, err = strconv.Atoi(highText); err != nil {
+          return nil, fmt.Errorf("invalid value in %q", part)
+        }
+      } else if hasStep {
+        // 5/15 starts at 5 and goes on to the end, like crontab.
+        high = max
+      }
+    }
+    if low < min || high > max || low > high {
+      return nil, fmt.Errorf("%q is out of %d-%d", part, min, max)
+    }
+
+    for v := low; v <= high; v += step {
+      set[v] = true
+    }
+  }
+
+  return set, nil
+}
+
+// next returns the first time after the given one the schedule matches, or the zero time when it never does, like on February 30.
+func (s *cronSchedule) next(after time.Time) time.Time {
+  if s.every > 0 {
+    return after.Truncate(s.every).Add(s.every)
+  }
+
+  t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+  // Every date comes back within four years, leap days included.
+  for limit := t.AddDate(4, 0, 0); t.Before(limit); {
+    switch {
+    case !s.month[t.Month()]:
+      t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+    case !s.matchesDay(t):
+      t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+    case !s.hour[t.Hour()]:
+      t = t.Truncate(time.Hour).Add(time.Hour)
+    case !s.minute[t.Minute()]:
+      t = t.Add(time.Minute)
+    default:
+      return t
+    }
+  }
+
+  return time.Time{}
+}
+
+// matchesDay follows crontab: with both the day of the month and the day of the week restricted, either one matching is enough.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+  dom, dow := s.dom[t.Day()], s.dow[t.Weekday()]
+  if s.domRestricted && s.dowRestricted {
+    return dom || dow
+  }
+
+  return dom && dow
+}
+
+// parseCronList reads a list like "compact-audit=0 3 * * *;rollup-views=@hourly".
+func parseCronList(list string) (map[string]string, error) {
+  specs := map[string]string{}
+  for _, entry := range strings.Split(list, ";") {
+    entry = strings.TrimSpace(entry)
+    if entry == "" {
+      continue
+    }
+    name, spec, ok := strings.Cut(entry, "=")
+    if !ok {
+      return nil, fmt.Errorf("invalid cron entry %q, expected name=schedule", entry)
+    }
+    specs[strings.TrimSpace(name)] = strings.TrimSpace(spec)
+  }
+
+  return specs, nil
+}
+
+type cronTask struct {
+  name, description string
+  run               func(ctx context.Context) (string, error)
+
+  // Guarded by the mutex of the scheduler.
+  spec         string
+  schedule     *cronSchedule
+  next         time.Time
+  running      bool
+  lastRun      time.Time
+  lastDuration time.Duration
+  lastResult   string
+  lastError    string
+}
+
+type cronScheduler struct {
+  mu    sync.Mutex
+  tasks map[string]*cronTask
+  // changed wakes run up when the schedules change.
+  changed chan struct{}
+  wg      sync.WaitGroup
+}
+
+func newCronScheduler() *cronScheduler {
+  return &cronScheduler{tasks: map[string]*cronTask{}, changed: make(chan struct{}, 1)}
+}
+
+// register adds a task, which only runs on its own once setSchedules gives it a schedule.
+func (c *cronScheduler) register(name, description string, run func(ctx context.Context) (string, error)) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  c.tasks[name] = &cronTask{name: name, description: description, run: run}
+}
+
+// setSchedules replaces the schedules of every task with the ones of the list, or changes nothing when one of them is invalid.
+func (c *cronScheduler) setSchedules(list string) error {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  specs, schedules, err := c.parseSchedules(list)
+  if err != nil {
+    return err
+  }
+
+  now := serverClock.Now()
+  for name, task := range c.tasks {
+    task.spec, task.schedule, task.next = "", schedules[name], time.Time{}
+    if task.schedule != nil {
+      task.spec, task.next = specs[name], task.schedule.next(now)
+    }
+  }
+
+  select {
+  case c.changed <- struct{}{}:
+  default:
+  }
+
+  return nil
+}
+
+// checkSchedules tells whether setSchedules would take the list, for the reloads of config.go
+func (c *cronScheduler) checkSchedules(list string) error {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  _, _, err := c.parseSchedules(list)
+  return err
+}
+
+// parseSchedules returns the specs of the list and the schedules of the tasks that aren't off, c.mu must be held.
+func (c *cronScheduler) parseSchedules(list string) (map[string]string, map[string]*cronSchedule, error) {
+  specs, err := parseCronList(list)
+  if err != nil {
+    return nil, nil, err
+  }
+
+  schedules := map[string]*cronSchedule{}
+  for name, spec := range specs {
+    if c.tasks[name] == nil {
+      return nil, nil, fmt.Errorf("unknown task %q in -cron, expected one of %s", name, strings.Join(c.names(), ", "))
+    }
+    if spec == "off" {
+      continue
+    }
+    if schedules[name], err = parseCronSchedule(spec); err != nil {
+      return nil, nil, fmt.Errorf("%s: %w", name, err)
+    }
+    if schedules[name].next(serverClock.Now()).IsZero() {
+      return nil, nil, fmt.Errorf("%s: the schedule %q never matches", name, spec)
+    }
+  }
+
+  return specs, schedules, nil
+}
+
+// names returns the sorted names of the tasks, c.mu must be held.
+func (c *cronScheduler) names() []string {
+  names := make([]string, 0, len(c.tasks))
+  for name := range c.tasks {
+    names = append(names, name)
+  }
+  slices.Sort(names)
+
+  return names
+}
+
+// run is the background job starting the tasks when they are due. It waits for the running ones once stopped.
+func (c *cronScheduler) run(ctx context.Context) error {
+  defer c.wg.Wait()
+
+  for {
+    c.mu.Lock()
+    var next time.Time
+    for _, task := range c.tasks {
+      if !task.next.IsZero() && (next.IsZero() || task.next.Before(next)) {
+        next = task.next
+      }
+    }
+    c.mu.Unlock()
+
+    // Nothing scheduled, only a change of the schedules can give the loop something to do.
+    wait := time.Hour
+    if !next.IsZero() {
+      wait = next.Sub(serverClock.Now())
+    }
+    timer := serverClock.NewTimer(wait)
+
+    select {
+    case <-ctx.Done():
+      timer.Stop()
+      return nil
+    case <-c.changed:
+      timer.Stop()
+    case <-timer.C():
+      c.startDue(ctx, serverClock.Now())
+    }
+  }
+}
+
+func (c *cronScheduler) startDue(ctx context.Context, now time.Time) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  for _, task := range c.tasks {
+    if task.next.IsZero() || task.next.After(now) {
+      continue
+    }
+    task.next = task.schedule.next(now)
+
+    if task.running {
+      log.Printf("cron: %s is still running, skipping this run", task.name)
+      continue
+    }
+    task.running = true
+    c.wg.Add(1)
+    go func() {
+      defer c.wg.Done()
+      c.execute(ctx, task)
+    }()
+  }
+}
+
+// execute runs the task and records how it went, task.running must have been set.
+func (c *cronScheduler) execute(ctx context.Context, task *cronTask) {
+  // When it ran is the time of the blog, how long it took the time of the process, see internal/clock.
+  started, began := serverClock.Now(), time.Now()
+  result, err := runCronTask(ctx, task)
+
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  task.running = false
+  task.lastRun, task.lastDuration, task.lastResult, task.lastError = started, time.Since(began), result, ""
+  if err != nil {
+    task.lastError = err.Error()
+    log.Printf("cron: %s failed after %s: %v", task.name, task.lastDuration, err)
+    return
+  }
+  log.Printf("cron: %s done in %s: %s", task.name, task.lastDuration, result)
+}
+
+// runCronTask turns a panic inside the task into an error, like runJob does for the jobs.
+func runCronTask(ctx context.Context, task *cronTask) (result string, err error) {
+  defer func() {
+    if r := recover(); r != nil {
+      err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+    }
+  }()
+
+  return task.run(ctx)
+}
+
+// trigger runs the task now and returns once it is done.
+func (c *cronScheduler) trigger(ctx context.Context, name string) (*pb.ScheduledTask, error) {
+  c.mu.Lock()
+  task := c.tasks[name]
+  if task == nil {
+    c.mu.Unlock()
+    return nil, apperr.Errorf(apperr.ErrTaskNotFound, "no task named %q", name)
+  }
+  if task.running {
+    c.mu.Unlock()
+    return nil, apperr.Errorf(apperr.ErrTaskRunning, "%s is already running", name)
+  }
+  task.running = true
+  c.mu.Unlock()
+
+  c.execute(ctx, task)
+
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  return task.state(), nil
+}
+
+// state describes the task for the Admin service, c.mu must be held.
+func (t *cronTask) state() *pb.ScheduledTask {
+  state := &pb.ScheduledTask{
+    Name:                t.name,
+    Description:         t.description,
+    Schedule:            t.spec,
+    Running:             t.running,
+    LastDurationSeconds: t.lastDuration.Seconds(),
+    LastResult:          t.lastResult,
+    LastError:           t.lastError,
+  }
+  if !t.next.IsZero() {
+    state.NextRun = t.next.UTC().Format(time.RFC3339)
+  }
+  if !t.lastRun.IsZero() {
+    state.LastRun = t.lastRun.UTC().Format(time.RFC3339)
+  }
+
+  return state
+}
+
+func (a *adminServer) ListScheduledTasks(ctx context.Context, _ *pb.ListScheduledTasksRequest) (*pb.ScheduledTasks, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  c := a.cron
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  tasks := &pb.ScheduledTasks{}
+  for _, name := range c.names() {
+    tasks.Tasks = append(tasks.Tasks, c.tasks[name].state())
+  }
+
+  return tasks, nil
+}
+
+func (a *adminServer) RunScheduledTask(ctx context.Context, req *pb.RunScheduledTaskRequest) (*pb.ScheduledTask, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  return a.cron.trigger(ctx, req.GetName())
+}
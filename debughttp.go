@@ -0,0 +1,98 @@
+package main
+
+import (
+  "context"
+  "errors"
+  "expvar"
+  "fmt"
+  "log"
+  "net"
+  "net/http"
+  "net/http/pprof"
+  "runtime"
+  runtimepprof "runtime/pprof"
+  "time"
+)
+
+/*
+  PROFILING
+
+  When the storage gets slow under load the question is where the time goes: the JSON encoding of posts.json, the lock every save takes, the garbage collector. Go answers it with profiles taken from the running server, -debug-addr serves them on a port of their own, off by default:
+
+    go run . -debug-addr localhost:6060
+
+    go tool pprof http://localhost:6060/debug/pprof/profile?seconds=30   where the CPU goes
+    go tool pprof http://localhost:6060/debug/pprof/heap                 what holds the memory
+    go tool pprof http://localhost:6060/debug/pprof/mutex                who waits on locks, the storage one first
+    go tool pprof http://localhost:6060/debug/pprof/block                who waits on channels and I/O
+    curl localhost:6060/debug/goroutines                                 the stack of every goroutine
+    curl localhost:6060/debug/vars                                       counters, in JSON
+
+  /debug/vars is expvar: the memory statistics of the runtime, the command line, and the blog section with the calls running, the open connections and the goroutines.
+
+  The mutex and block profiles are only collected with -debug-addr, which samples a share of the lock waits: it costs a little on every contended lock, nothing otherwise.
+
+  The port isn't authenticated, profiles and stacks tell a lot about the server and the profile endpoint keeps a CPU busy for as long as it is asked to. Keep it on localhost or an internal network, away from the -addr port and its clients.
+*/
+const (
+  // One contended lock out of mutexProfileFraction is sampled, and blocking events longer than blockProfileRate nanoseconds.
+  mutexProfileFraction = 10
+  blockProfileRate     = int(time.Millisecond)
+)
+
+func newDebugHandler() http.Handler {
+  mux := http.NewServeMux()
+  // Index serves the named profiles too, heap, goroutine, mutex, block, allocs and threadcreate.
+  mux.HandleFunc("/debug/pprof/", pprof.Index)
+  mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+  mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+  mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+  mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+  mux.Handle("/debug/vars", expvar.Handler())
+  mux.HandleFunc("/debug/goroutines", func(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+    // 2 is the format of a panic, with the state of every goroutine and how long it has been waiting.
+    runtimepprof.Lookup("goroutine").WriteTo(w, 2)
+  })
+
+  return mux
+}
+
+// publishExpvars adds the blog section of /debug/vars. It must be called once.
+func publishExpvars(metrics *serverMetrics, connections *connectionTracker) {
+  expvar.Publish("blog", expvar.Func(func() any {
+    stats := connections.stats()
+    return map[string]any{
+      "calls_in_flight":  metrics.inFlight.Load(),
+      "open_connections": stats.GetOpenConnections(),
+      "active_streams":   stats.GetActiveStreams(),
+      "goroutines":       runtime.NumGoroutine(),
+    }
+  }))
+}
+
+// serveDebug serves newDebugHandler on addr until ctx is done.
+func serveDebug(addr string) func(ctx context.Context) error {
+  return func(ctx context.Context) error {
+    runtime.SetMutexProfileFraction(mutexProfileFraction)
+    runtime.SetBlockProfileRate(blockProfileRate)
+
+    // The profile endpoint answers after as many seconds as it is asked for, no write timeout.
+    srv := &http.Server{Handler: newDebugHandler(), ReadHeaderTimeout: 10 * time.Second}
+    lis, err := net.Listen("tcp", addr)
+    if err != nil {
+      return fmt.Errorf("failed to serve the debug endpoints: %w", err)
+    }
+    go func() {
+      <-ctx.Done()
+      srv.Shutdown(context.Background())
+    }()
+
+    log.Printf("serving pprof and expvar on http://%s/debug/", lis.Addr())
+    if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+      return fmt.Errorf("failed to serve the debug endpoints: %w", err)
+    }
+
+    return nil
+  }
+}
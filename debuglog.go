@@ -0,0 +1,291 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/store"
+  "log"
+  "slices"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/protobuf/encoding/protojson"
+  "google.golang.org/protobuf/proto"
+  "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+/*
+  DEBUG LOGGING
+
+  When a client misbehaves it helps to see exactly what it sends and what it gets back. The debug interceptors log the metadata, every request and every response (or error) as JSON, for unary and streaming calls alike. It is off by default, a busy server would fill its logs fast, and can be switched on with -debug-log or at runtime through the Admin service without restarting:
+
+    go run ./client debug-log -token secret -on
+    go run ./client debug-log -token secret -off -redact Email,Secret,Token,authorization
+
+  Logs tend to be read by more people, and kept for longer, than the data they describe, so some values never make it there. Every field whose name is in the redaction list is replaced with [REDACTED] wherever it appears, in nested messages too, and so is every metadata key in the list. Names are matched without case, so "email" also covers SubscribeByEmailRequest.Email. The default list covers the subscriber emails, the webhook secrets, the unsubscribe tokens and the admin token sent in the authorization header.
+
+  To keep attachment uploads from flooding the log, a message is cut after 2KB of JSON.
+
+  Redacting works on a copy of the message (proto.Clone), the handler and the client still see the real values. Walking a message without knowing its type is done with protoreflect, the same reflection API protojson uses to encode any message.
+*/
+const (
+  redactedValue    = "[REDACTED]"
+  debugLogMaxBytes = 2048
+)
+
+var defaultRedactedFields = []string{"Email", "Secret", "Token", "authorization", sessionHeader}
+
+type debugLogger struct {
+  // mu guards both fields, they change at runtime through the Admin service.
+  mu      sync.RWMutex
+  enabled bool
+  redact  []string
+}
+
+func newDebugLogger(enabled bool, redact []string) *debugLogger {
+  return &debugLogger{enabled: enabled, redact: redact}
+}
+
+// settings returns whether logging is on and, if it is, the fields to redact.
+func (d *debugLogger) settings() (bool, map[string]bool) {
+  d.mu.RLock()
+  defer d.mu.RUnlock()
+
+  if !d.enabled {
+    return false, nil
+  }
+
+  fields := make(map[string]bool, len(d.redact))
+  for _, name := range d.redact {
+    fields[strings.ToLower(name)] = true
+  }
+
+  return true, fields
+}
+
+func (d *debugLogger) set(enabled bool, redact []string) *pb.DebugLogging {
+  d.mu.Lock()
+  defer d.mu.Unlock()
+
+  d.enabled = enabled
+  if len(redact) > 0 {
+    d.redact = redact
+  }
+
+  return &pb.DebugLogging{Enabled: d.enabled, Redact: slices.Clone(d.redact)}
+}
+
+func (d *debugLogger) get() *pb.DebugLogging {
+  d.mu.RLock()
+  defer d.mu.RUnlock()
+
+  return &pb.DebugLogging{Enabled: d.enabled, Redact: slices.Clone(d.redact)}
+}
+
+func (d *debugLogger) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  enabled, fields := d.settings()
+  if !enabled {
+    return handler(ctx, req)
+  }
+
+  id := reqctx.RequestID(ctx)
+  logMetadata(ctx, id, info.FullMethod, fields)
+  logMessage(id, info.FullMethod, "request", req, fields)
+
+  start := time.Now()
+  res, err := handler(ctx, req)
+  if err != nil {
+    log.Printf("debug %s %s: error after %s: %v", id, info.FullMethod, time.Since(start), err)
+    return res, err
+  }
+
+  logMessage(id, info.FullMethod, "response", res, fields)
+
+  return res, err
+}
+
+func (d *debugLogger) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  enabled, fields := d.settings()
+  if !enabled {
+    return handler(srv, ss)
+  }
+
+  id := reqctx.RequestID(ss.Context())
+  logMetadata(ss.Context(), id, info.FullMethod, fields)
+
+  start := time.Now()
+  err := handler(srv, &debugStream{ServerStream: ss, id: id, method: info.FullMethod, fields: fields})
+  if err != nil {
+    log.Printf("debug %s %s: error after %s: %v", id, info.FullMethod, time.Since(start), err)
+  } else {
+    log.Printf("debug %s %s: done after %s", id, info.FullMethod, time.Since(start))
+  }
+
+  return err
+}
+
+// debugStream logs every message going through a stream.
+type debugStream struct {
+  grpc.ServerStream
+  id     string
+  method string
+  fields map[string]bool
+}
+
+func (s *debugStream) RecvMsg(m any) error {
+  err := s.ServerStream.RecvMsg(m)
+  if err == nil {
+    logMessage(s.id, s.method, "received", m, s.fields)
+  }
+
+  return err
+}
+
+func (s *debugStream) SendMsg(m any) error {
+  logMessage(s.id, s.method, "sent", m, s.fields)
+
+  return s.ServerStream.SendMsg(m)
+}
+
+func logMetadata(ctx context.Context, id, method string, fields map[string]bool) {
+  md, _ := metadata.FromIncomingContext(ctx)
+
+  pairs := make([]string, 0, len(md))
+  for key, values := range md {
+    if fields[strings.ToLower(key)] {
+      values = []string{redactedValue}
+    }
+    pairs = append(pairs, key+"="+strings.Join(values, ","))
+  }
+  slices.Sort(pairs)
+
+  log.Printf("debug %s %s: %s from %s, metadata %s", id, method, reqctx.Identity(ctx), reqctx.Peer(ctx), strings.Join(pairs, " "))
+}
+
+func logMessage(id, method, what string, m any, fields map[string]bool) {
+  msg, ok := m.(proto.Message)
+  if !ok {
+    log.Printf("debug %s %s: %s %T", id, method, what, m)
+    return
+  }
+
+  msg = proto.Clone(msg)
+  redactMessage(msg.ProtoReflect(), fields)
+
+  data, err := protojson.Marshal(msg)
+  if err != nil {
+    log.Printf("debug %s %s: %s could not be encoded: %v", id, method, what, err)
+    return
+  }
+
+  if len(data) > debugLogMaxBytes {
+    data = append(data[:debugLogMaxBytes:debugLogMaxBytes], fmt.Sprintf("... (%d bytes)", len(data))...)
+  }
+
+  log.Printf("debug %s %s: %s %s", id, method, what, data)
+}
+
+// redactMessage hides the fields named in fields, in m and in every message nested in it.
+func redactMessage(m protoreflect.Message, fields map[string]bool) {
+  // A message can't be changed while ranging over it, so the fields to redact are collected first.
+  var hidden []protoreflect.FieldDescriptor
+
+  m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+    if fields[strings.ToLower(string(fd.Name()))] {
+      hidden = append(hidden, fd)
+      return true
+    }
+
+    switch {
+    case fd.IsMap():
+      if fd.MapValue().Kind() == protoreflect.MessageKind {
+        v.Map().Range(func(_ protoreflect.MapKey, item protoreflect.Value) bool {
+          redactMessage(item.Message(), fields)
+          return true
+        })
+      }
+    case fd.IsList():
+      if fd.Kind() == protoreflect.MessageKind {
+        for i := 0; i < v.List().Len(); i++ {
+          redactMessage(v.List().Get(i).Message(), fields)
+        }
+      }
+    case fd.Kind() == protoreflect.MessageKind:
+      redactMessage(v.Message(), fields)
+    }
+
+    return true
+  })
+
+  for _, fd := range hidden {
+    // Only a single string field can say [REDACTED], anything else is dropped.
+    if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+      m.Set(fd, protoreflect.ValueOfString(redactedValue))
+    } else {
+      m.Clear(fd)
+    }
+  }
+}
+
+/*
+  ADMIN SERVICE
+
+  adminServer implements the Admin service from blog.proto. It is registered on the same gRPC server as the Blog service, clients pick one or the other with pb.NewBlogClient or pb.NewAdminClient over the same connection.
+*/
+type adminServer struct {
+  pb.UnimplementedAdminServer
+  auth    *authenticator
+  debug   *debugLogger
+  storage *store.CompressingStore
+  // nil without -write-delay, see internal/store/batch.go
+  batching *store.BatchingStore
+  // See maintenance.go
+  maintenance *maintenanceMode
+  // nil without -config, see config.go
+  config *serverConfig
+  // See cron.go
+  cron *cronScheduler
+  // See bans.go
+  bans *banList
+  // Keys nil when encryption is off, see encryption.go
+  encryption *store.EncryptingStore
+  // See connections.go
+  connections *connectionTracker
+  // See serverstats.go
+  metrics *serverMetrics
+  // See export.go, bucket is nil without -attachments-backend s3
+  audit  *auditLog
+  views  *viewLog
+  bucket *bucketStore
+}
+
+func (a *adminServer) SetDebugLogging(ctx context.Context, req *pb.SetDebugLoggingRequest) (*pb.DebugLogging, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  for _, name := range req.GetRedact() {
+    if strings.TrimSpace(name) == "" {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Redact can't contain empty names")
+    }
+  }
+
+  settings := a.debug.set(req.GetEnabled(), req.GetRedact())
+  log.Printf("debug logging enabled=%t redact=%s", settings.Enabled, strings.Join(settings.Redact, ","))
+
+  return settings, nil
+}
+
+func (a *adminServer) GetDebugLogging(ctx context.Context, _ *pb.GetDebugLoggingRequest) (*pb.DebugLogging, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  return a.debug.get(), nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "time"
+)
+
+/*
+  DRAFTS
+
+  CreatePost with Draft saves a post without publishing it: like a scheduled post it is hidden from readers, and watchers hear nothing of it. Its author keeps editing it with UpdatePost, which publishes it once given Publish, at PublishAt or right away:
+
+    go run ./client create -draft -title "Half written"
+    go run ./client update -id <post id> -content "Done" -publish
+
+  Drafts get abandoned. The clean-drafts task (see cron.go) looks for the drafts nobody touched, no UpdatePost nor RestoreRevision, for longer than -draft-retention and archives them, or deletes them like DeletePost does with -draft-retention-action delete:
+
+    go run . -draft-retention 720h -draft-retention-action delete
+
+  0 keeps the drafts forever. Every draft cleaned up is logged with its title and author, and published to the watchers and webhooks as POST_ARCHIVED or POST_DELETED. They never got a POST_CREATED for it, but a tool following the drafts of its authors learns that one of them went away, and why.
+
+  Posts written before UpdatedAt existed are judged by their CreatedAt.
+*/
+const (
+  draftsArchive = "archive"
+  draftsDelete  = "delete"
+)
+
+type draftPolicy struct {
+  retention time.Duration
+  // archive or delete
+  action string
+}
+
+func newDraftPolicy(retention time.Duration, action string) (draftPolicy, error) {
+  if retention < 0 {
+    return draftPolicy{}, fmt.Errorf("-draft-retention can't be negative")
+  }
+  if action != draftsArchive && action != draftsDelete {
+    return draftPolicy{}, fmt.Errorf("unknown -draft-retention-action %q, expected archive or delete", action)
+  }
+
+  return draftPolicy{retention: retention, action: action}, nil
+}
+
+// lastTouched returns when the post was last changed.
+func lastTouched(post *pb.Post) (time.Time, bool) {
+  if t, err := time.Parse(time.RFC3339, post.UpdatedAt); err == nil {
+    return t, true
+  }
+  t, err := time.Parse("2006-01-02", post.CreatedAt)
+
+  return t, err == nil
+}
+
+// cleanDrafts is the clean-drafts task, see above.
+func (s *server) cleanDrafts(context.Context) (string, error) {
+  if s.drafts.retention == 0 {
+    return "kept every draft, -draft-retention is 0", nil
+  }
+  oldest := serverClock.Now().Add(-s.drafts.retention)
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return "", err
+  }
+
+  var stale []*pb.Post
+  for _, post := range posts.Posts {
+    if at, ok := lastTouched(post); ok && post.Status == pb.PostStatus_DRAFT && at.Before(oldest) {
+      stale = append(stale, post)
+    }
+  }
+  if len(stale) == 0 {
+    return "no stale drafts", nil
+  }
+
+  // The posts as they were, removePosts wipes the deleted ones.
+  cleaned, event, verb := stale, pb.PostEventType_POST_ARCHIVED, "archived"
+  if s.drafts.action == draftsDelete {
+    deleted, err := s.removePosts(posts, stale)
+    if err != nil {
+      return "", err
+    }
+    cleaned, event, verb = deleted, pb.PostEventType_POST_DELETED, "deleted"
+  } else {
+    sequence := nextSequence(posts)
+    for _, post := range stale {
+      post.Status = pb.PostStatus_ARCHIVED
+      post.Sequence = sequence
+      sequence++
+    }
+  }
+
+  if err := savePosts(posts); err != nil {
+    return "", fmt.Errorf("failed to save posts: %w", err)
+  }
+
+  for _, post := range cleaned {
+    at, _ := lastTouched(post)
+    log.Printf("drafts: %s draft %s %q by %s, untouched since %s", verb, post.Id, post.Title, post.Author, at.UTC().Format(time.RFC3339))
+    s.broker.publish(event, post)
+  }
+
+  return fmt.Sprintf("%s %d drafts untouched since %s", verb, len(cleaned), oldest.UTC().Format(time.RFC3339)), nil
+}
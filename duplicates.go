@@ -0,0 +1,111 @@
+package main
+
+import (
+  "context"
+  "crypto/sha256"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "strings"
+  "time"
+
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  DUPLICATE DETECTION
+
+  A client that retries a CreatePost whose response got lost, or an author pressing publish twice, ends up with the same post twice. With -duplicate-check the server compares every new post with the posts of the last -duplicate-window (a day by default):
+    - same title, ignoring case and spaces around it
+    - or same content, compared by the SHA-256 of the content with its whitespace collapsed, so reflowing a paragraph doesn't make it a different post
+
+    go run . -duplicate-check reject -duplicate-window 72h
+
+  What happens then depends on the mode:
+    - off: nothing, the default
+    - warn: the post is created and the x-duplicate-of response header carries the ID of the existing post
+    - reject: the call fails with AlreadyExists. The message names the existing post and the status carries it as a ResourceInfo error detail, so clients can link to it without parsing the message
+
+  A post is in the window when it was published, or is scheduled, after now minus the window. Deleted posts don't count. Clients that really mean to post the same thing again set AllowDuplicate.
+*/
+const duplicateOfHeader = "x-duplicate-of"
+
+type duplicateCheck struct {
+  // mode is off, warn or reject.
+  mode   string
+  window time.Duration
+}
+
+func newDuplicateCheck(mode string, window time.Duration) (*duplicateCheck, error) {
+  switch mode {
+  case "off", "warn", "reject":
+  default:
+    return nil, fmt.Errorf("unknown -duplicate-check %q, expected off, warn or reject", mode)
+  }
+  if window <= 0 {
+    return nil, fmt.Errorf("-duplicate-window must be positive")
+  }
+
+  return &duplicateCheck{mode: mode, window: window}, nil
+}
+
+func contentFingerprint(content string) [sha256.Size]byte {
+  return sha256.Sum256([]byte(strings.Join(strings.Fields(content), " ")))
+}
+
+// find returns the post of the window the new post duplicates, nil if there is none.
+func (d *duplicateCheck) find(posts []*pb.Post, post *pb.Post, now time.Time) *pb.Post {
+  since := now.Add(-d.window)
+  title := strings.TrimSpace(post.Title)
+  fingerprint := contentFingerprint(post.Content)
+
+  for _, existing := range posts {
+    if existing.Status == pb.PostStatus_DELETED {
+      continue
+    }
+    // Posts from before PublishAt existed only have their creation day.
+    at, err := time.Parse(time.RFC3339, existing.PublishAt)
+    if err != nil {
+      at, err = time.Parse("2006-01-02", existing.CreatedAt)
+      at = at.Add(24*time.Hour - time.Nanosecond)
+    }
+    if err != nil || at.Before(since) {
+      continue
+    }
+
+    if strings.EqualFold(strings.TrimSpace(existing.Title), title) {
+      return existing
+    }
+    if strings.TrimSpace(post.Content) != "" && contentFingerprint(existing.Content) == fingerprint {
+      return existing
+    }
+  }
+
+  return nil
+}
+
+// check is called by CreatePost with the posts loaded, it fails in reject mode and sets the header in warn mode.
+func (d *duplicateCheck) check(ctx context.Context, posts []*pb.Post, req *pb.CreatePostRequest, post *pb.Post) error {
+  if d == nil || d.mode == "off" || req.GetAllowDuplicate() {
+    return nil
+  }
+
+  existing := d.find(posts, post, serverClock.Now())
+  if existing == nil {
+    return nil
+  }
+
+  if d.mode == "warn" {
+    grpc.SetHeader(ctx, metadata.Pairs(duplicateOfHeader, existing.Id))
+    return nil
+  }
+
+  err := apperr.Errorf(apperr.ErrDuplicatePost, "post %q looks like a duplicate of post %s, set AllowDuplicate to create it anyway", post.Title, existing.Id)
+  return apperr.WithDetails(err, &errdetails.ResourceInfo{
+    ResourceType: "blog.Post",
+    ResourceName: existing.Id,
+    Description:  existing.Title,
+  })
+}
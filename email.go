@@ -0,0 +1,302 @@
+package main
+
+import (
+  "bytes"
+  "context"
+  "crypto/rand"
+  "embed"
+  "encoding/hex"
+  "encoding/json"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "io/fs"
+  "log"
+  "mime"
+  "net"
+  "net/mail"
+  "net/smtp"
+  "os"
+  "slices"
+  "strings"
+  "sync"
+  "text/template"
+  "time"
+)
+
+/*
+  EMAIL NOTIFICATIONS
+
+  Readers who don't run a client or a webhook receiver can still hear about new posts by email. SubscribeByEmail adds an address to subscribers.json and sends a welcome email, and from then on every POST_PUBLISHED event becomes an email to every subscriber:
+
+    go run . -smtp-addr smtp.example.com:587 -smtp-user blog -smtp-from blog@example.com
+    go run ./client subscribe -email me@example.com
+    go run ./client unsubscribe -token <token from the email>
+
+  The password is read from the BLOG_SMTP_PASSWORD environment variable rather than a flag, flags show up in the process list for everyone on the machine to see. -smtp-password-from reads it from a file or Vault instead, and picks up a new one without a restart (see secrets.go).
+
+  Unsubscribing needs the random token that comes with every email instead of the address itself, otherwise anyone could unsubscribe anyone. For the same reason subscribing an address twice doesn't send anything the second time.
+
+  The text of the emails comes from text/template templates. The defaults in templates/email.tmpl are compiled in with go:embed, -email-templates points at a copy to change them.
+
+  Like the webhooks, the notifier is a background job subscribed to the broker, see jobs.go and watch.go. Every email is an email task of the work queue (see queue.go), -queue-concurrency email=N caps how many connections the SMTP server gets at once. Sending is best effort: a failed email is logged and not retried.
+*/
+const subscribersPath = "subscribers.json"
+
+//go:embed templates/email.tmpl
+var defaultEmailTemplates embed.FS
+
+type emailSubscriber struct {
+  Email     string `json:"email"`
+  Token     string `json:"token"`
+  CreatedAt string `json:"createdAt"`
+}
+
+type emailNotifier struct {
+  addr string
+  from string
+  host string
+  // user empty talks to the SMTP server unauthenticated.
+  user      string
+  password  *secret
+  templates *template.Template
+  broker    *postBroker
+  queue     *workQueue
+  path      string
+
+  // mu guards the subscribers file.
+  mu sync.Mutex
+}
+
+// newEmailNotifier returns nil when addr is empty, email notifications are then disabled.
+func newEmailNotifier(addr, user string, password *secret, from, templatesPath string, broker *postBroker, queue *workQueue) (*emailNotifier, error) {
+  if addr == "" {
+    return nil, nil
+  }
+
+  host, _, err := net.SplitHostPort(addr)
+  if err != nil {
+    return nil, fmt.Errorf("-smtp-addr must be host:port: %w", err)
+  }
+  if _, err := mail.ParseAddress(from); err != nil {
+    return nil, fmt.Errorf("-smtp-from must be an email address: %w", err)
+  }
+
+  var templates *template.Template
+  if templatesPath == "" {
+    templates, err = template.ParseFS(defaultEmailTemplates, "templates/email.tmpl")
+  } else {
+    templates, err = template.ParseFiles(templatesPath)
+  }
+  if err != nil {
+    return nil, fmt.Errorf("failed to parse email templates: %w", err)
+  }
+
+  // Without a user we talk to the SMTP server unauthenticated, which is what local relays usually expect.
+  n := &emailNotifier{
+    addr:      addr,
+    from:      from,
+    host:      host,
+    user:      user,
+    password:  password,
+    templates: templates,
+    broker:    broker,
+    queue:     queue,
+    path:      subscribersPath,
+  }
+
+  return n, nil
+}
+
+func (s *server) SubscribeByEmail(_ context.Context, req *pb.SubscribeByEmailRequest) (*pb.SubscribeByEmailResponse, error) {
+  n := s.email
+  if n == nil {
+    return nil, apperr.Errorf(apperr.ErrFeatureDisabled, "email notifications are disabled, start the server with -smtp-addr")
+  }
+
+  address, err := mail.ParseAddress(req.GetEmail())
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "invalid email %q: %w", req.GetEmail(), err)
+  }
+
+  sub, err := n.add(address.Address)
+  if err != nil {
+    return nil, err
+  }
+  if sub == nil {
+    return &pb.SubscribeByEmailResponse{}, nil
+  }
+
+  // The subscription is saved either way, a welcome email that doesn't arrive only costs the subscriber the token to leave before the first post.
+  if err := n.send(sub, "welcome", nil); err != nil {
+    log.Printf("email: failed to welcome %s: %v", sub.Email, err)
+  }
+
+  return &pb.SubscribeByEmailResponse{}, nil
+}
+
+func (s *server) UnsubscribeByEmail(_ context.Context, req *pb.UnsubscribeByEmailRequest) (*pb.UnsubscribeByEmailResponse, error) {
+  n := s.email
+  if n == nil {
+    return nil, apperr.Errorf(apperr.ErrFeatureDisabled, "email notifications are disabled, start the server with -smtp-addr")
+  }
+
+  n.mu.Lock()
+  defer n.mu.Unlock()
+
+  subscribers, err := n.load()
+  if err != nil {
+    return nil, err
+  }
+
+  i := slices.IndexFunc(subscribers, func(sub *emailSubscriber) bool { return sub.Token == req.GetToken() })
+  if req.GetToken() == "" || i == -1 {
+    return nil, apperr.Errorf(apperr.ErrSubscriberNotFound, "unknown unsubscribe token")
+  }
+
+  if err := n.save(slices.Delete(subscribers, i, i+1)); err != nil {
+    return nil, err
+  }
+
+  return &pb.UnsubscribeByEmailResponse{}, nil
+}
+
+// add saves a new subscriber, or returns nil if the address is already subscribed.
+func (n *emailNotifier) add(email string) (*emailSubscriber, error) {
+  n.mu.Lock()
+  defer n.mu.Unlock()
+
+  subscribers, err := n.load()
+  if err != nil {
+    return nil, err
+  }
+
+  if slices.ContainsFunc(subscribers, func(sub *emailSubscriber) bool { return strings.EqualFold(sub.Email, email) }) {
+    return nil, nil
+  }
+
+  token := make([]byte, 16)
+  rand.Read(token)
+  sub := &emailSubscriber{
+    Email:     email,
+    Token:     hex.EncodeToString(token),
+    CreatedAt: serverClock.Now().UTC().Format(time.RFC3339),
+  }
+
+  if err := n.save(append(subscribers, sub)); err != nil {
+    return nil, err
+  }
+
+  return sub, nil
+}
+
+// run is the background job that emails the subscribers about every published post.
+func (n *emailNotifier) run(ctx context.Context) error {
+  events, unsubscribe := n.broker.subscribe(&pb.WatchPostsRequest{Types: []pb.PostEventType{pb.PostEventType_POST_PUBLISHED}})
+  defer unsubscribe()
+
+  for {
+    select {
+    case <-ctx.Done():
+      return nil
+    case event, ok := <-events:
+      if !ok {
+        return nil
+      }
+
+      n.mu.Lock()
+      subscribers, err := n.load()
+      n.mu.Unlock()
+      if err != nil {
+        log.Printf("email: %v", err)
+        continue
+      }
+
+      for _, sub := range subscribers {
+        n.queue.submit("email", func(ctx context.Context) error {
+          // net/smtp doesn't take a context, an email that started goes out.
+          if err := ctx.Err(); err != nil {
+            return err
+          }
+          if err := n.send(sub, "post", event.Post); err != nil {
+            return fmt.Errorf("email: failed to notify %s: %w", sub.Email, err)
+          }
+          return nil
+        })
+      }
+    }
+  }
+}
+
+// send renders the <kind>_subject and <kind>_body templates and mails them to the subscriber.
+func (n *emailNotifier) send(sub *emailSubscriber, kind string, post *pb.Post) error {
+  data := struct {
+    Token string
+    Post  *pb.Post
+  }{Token: sub.Token, Post: post}
+
+  var subject, body bytes.Buffer
+  if err := n.templates.ExecuteTemplate(&subject, kind+"_subject", data); err != nil {
+    return err
+  }
+  if err := n.templates.ExecuteTemplate(&body, kind+"_body", data); err != nil {
+    return err
+  }
+
+  // Post titles end up in the Subject header. A line break in there would let a title add headers of its own, and non ASCII characters need the =?utf-8?q?...?= encoding.
+  subjectLine := strings.Join(strings.Fields(subject.String()), " ")
+
+  var msg bytes.Buffer
+  fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+  fmt.Fprintf(&msg, "To: %s\r\n", sub.Email)
+  fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subjectLine))
+  fmt.Fprintf(&msg, "Date: %s\r\n", serverClock.Now().Format(time.RFC1123Z))
+  fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+  fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n")
+  fmt.Fprintf(&msg, "\r\n")
+  msg.WriteString(strings.ReplaceAll(body.String(), "\n", "\r\n"))
+
+  from, _ := mail.ParseAddress(n.from)
+
+  // The password is read on every email, it may have been rotated since the last one.
+  var auth smtp.Auth
+  if n.user != "" {
+    auth = smtp.PlainAuth("", n.user, n.password.text(), n.host)
+  }
+
+  return smtp.SendMail(n.addr, auth, from.Address, []string{sub.Email}, msg.Bytes())
+}
+
+// load reads the subscribers, the caller must hold n.mu.
+func (n *emailNotifier) load() ([]*emailSubscriber, error) {
+  var subscribers []*emailSubscriber
+
+  data, err := os.ReadFile(n.path)
+  if errors.Is(err, fs.ErrNotExist) {
+    return subscribers, nil
+  }
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read subscribers file: %w", err)
+  }
+
+  if err := json.Unmarshal(data, &subscribers); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to parse subscribers: %w", err)
+  }
+
+  return subscribers, nil
+}
+
+func (n *emailNotifier) save(subscribers []*emailSubscriber) error {
+  data, err := json.MarshalIndent(subscribers, "", "  ")
+  if err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save subscribers: %w", err)
+  }
+
+  // Addresses and tokens are personal data, only the owner can read the file.
+  if err := os.WriteFile(n.path, data, 0600); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save subscribers: %w", err)
+  }
+
+  return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+  "os"
+  "os/exec"
+)
+
+/*
+  ENCRYPTION AT REST
+
+  The title and the content of the posts can be encrypted in the storage, posts.json or blog.db alike, see internal/store/encrypt.go for what is encrypted and how. The keys come from the environment variable named by -encryption-keys-env, BLOG_ENCRYPTION_KEYS by default, never from a flag, which every user of the machine can see with ps:
+
+    export BLOG_ENCRYPTION_KEYS=2025-06:$(openssl rand -hex 32)
+    go run .
+
+  A key management service is reached with -encryption-keys-command instead, a command run once at start whose output is the keyring, in the same format. It is where the keys are asked to Vault or a cloud KMS, or unwrapped with a master key the server never sees:
+
+    go run . -encryption-keys-command "vault kv get -field keys secret/blog"
+
+  To rotate, put the new key first and keep the old one behind it, restart and call ReencryptStorage, which saves every post and every revision with the new key. The old key can go after that:
+
+    go run ./client reencrypt-storage -token secret
+
+  The revisions in revisions.json are encrypted with the same keys, their old titles and contents are no less private, see revisions.go. The posts cached in Redis with -redis-addr are kept in clear.
+
+  Without keys the posts are saved in clear. Posts encrypted before keep failing to load until their key is given again, the server doesn't start on them.
+*/
+func loadKeyring(envName, command string) (*store.Keyring, error) {
+  text := os.Getenv(envName)
+  source := "$" + envName
+  if command != "" {
+    out, err := exec.Command("sh", "-c", command).Output()
+    if err != nil {
+      return nil, fmt.Errorf("failed to run -encryption-keys-command: %w", err)
+    }
+    text, source = string(out), "-encryption-keys-command"
+  }
+  if text == "" {
+    return nil, nil
+  }
+
+  keys, err := store.ParseKeyring(text)
+  if err != nil {
+    return nil, fmt.Errorf("%s: %w", source, err)
+  }
+
+  return keys, nil
+}
+
+func (a *adminServer) ReencryptStorage(ctx context.Context, _ *pb.ReencryptStorageRequest) (*pb.StorageReencryption, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+  if a.encryption.Keys == nil {
+    return nil, apperr.Errorf(apperr.ErrFeatureDisabled, "encryption is off, start the server with encryption keys")
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  // The saves held back are written first, so that the stats of the load describe what is on disk.
+  if _, err := a.flush(); err != nil {
+    return nil, err
+  }
+  posts := &pb.Posts{}
+  if err := loadPost(posts); err != nil {
+    return nil, err
+  }
+  key := a.encryption.Keys.Current()
+  before := a.encryption.Stats().ByKey[key]
+
+  if err := savePosts(posts); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+  if _, err := a.flush(); err != nil {
+    return nil, err
+  }
+
+  // Loaded with every key, saved with the current one.
+  revisions, err := loadRevisions()
+  if err != nil {
+    return nil, err
+  }
+  if err := saveRevisions(revisions); err != nil {
+    return nil, err
+  }
+  var saved int32
+  for _, history := range revisions {
+    saved += int32(len(history))
+  }
+
+  return &pb.StorageReencryption{
+    Posts:       int32(len(posts.Posts)),
+    Reencrypted: int32(a.encryption.Stats().ByKey[key] - before),
+    Key:         key,
+    Revisions:   saved,
+  }, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/store"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+)
+
+func TestRevisionsAreEncrypted(t *testing.T) {
+  old, err := store.ParseKeyring("old:" + strings.Repeat("ab", 32))
+  if err != nil {
+    t.Fatal(err)
+  }
+  rotated, err := store.ParseKeyring("new:" + strings.Repeat("cd", 32) + ",old:" + strings.Repeat("ab", 32))
+  if err != nil {
+    t.Fatal(err)
+  }
+  useFileStore(t, []*pb.Post{{Id: "p1", Title: "Launch plan", Content: "We ship on Friday.", Author: "Ana"}})
+  previousPath, previousKeys := revisionsPath, revisionKeys
+  revisionsPath, revisionKeys = filepath.Join(t.TempDir(), "revisions.json"), old
+  t.Cleanup(func() { revisionsPath, revisionKeys = previousPath, previousKeys })
+
+  s := &server{}
+  if err := s.recordRevision(&pb.Post{Id: "p1", Title: "Launch plan", Content: "We ship on Friday.", Author: "Ana"}); err != nil {
+    t.Fatal(err)
+  }
+  data, err := os.ReadFile(revisionsPath)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if strings.Contains(string(data), "Launch plan") || strings.Contains(string(data), "Friday") {
+    t.Fatalf("revisions.json holds the revision in clear:\n%s", data)
+  }
+  if revisions, err := loadRevisions(); err != nil || revisions["p1"][0].Content != "We ship on Friday." {
+    t.Fatalf("got %v, %v, want the revision decrypted", revisions, err)
+  }
+
+  // ReencryptStorage saves the revisions with the new key, the old one can go after.
+  revisionKeys = rotated
+  auth := newAuthenticator(staticSecret(testAdminToken), nil, nil)
+  a := &adminServer{auth: auth, encryption: &store.EncryptingStore{PostStore: postStore, Keys: rotated}}
+  postStore = a.encryption
+  ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+testAdminToken))
+  res, err := reqctx.UnaryServerInterceptor(auth.identity)(ctx, &pb.ReencryptStorageRequest{}, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+    return a.ReencryptStorage(ctx, req.(*pb.ReencryptStorageRequest))
+  })
+  if err != nil {
+    t.Fatal(err)
+  }
+  if reencryption := res.(*pb.StorageReencryption); reencryption.Revisions != 1 {
+    t.Errorf("got %d revisions saved again, want 1", reencryption.Revisions)
+  }
+
+  revisionKeys, err = store.ParseKeyring("new:" + strings.Repeat("cd", 32))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if revisions, err := loadRevisions(); err != nil || revisions["p1"][0].Title != "Launch plan" {
+    t.Errorf("got %v, %v without the old key, want the revision decrypted", revisions, err)
+  }
+}
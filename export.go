@@ -0,0 +1,339 @@
+package main
+
+import (
+  "bufio"
+  "context"
+  "encoding/csv"
+  "encoding/json"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/parquet"
+  "io"
+  "io/fs"
+  "os"
+  "slices"
+  "strconv"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  EXPORTS
+
+  QueryAuditLog and GetPostAnalytics answer the questions the server was written for. Anything else, who changed the most posts last month, at what hour the readers of a tag come, is a job for the tools of data analysis, which want a file. ExportEvents writes the audit log or the views of a time range as CSV, for a spreadsheet, or Parquet, for DuckDB, pandas and the like:
+
+    go run ./client export -token $BLOG_ADMIN_TOKEN -dataset audit -format parquet -since 2025-06-01 -until 2025-07-01 -o june.parquet
+    go run ./client export -token $BLOG_ADMIN_TOKEN -dataset views -format csv -bucket
+
+  The file is written while the rows are read, one at a time, and sent in chunks of exportChunkSize: an export takes the memory of a chunk with CSV, and of a row group with Parquet (see internal/parquet), whatever the size of the audit log. The audit log is only locked to be opened, the entries recorded while the export runs aren't in it.
+
+  With ToBucket the file goes to the bucket of -attachments-backend s3 instead, under exports/, through the same streaming upload as the attachments (see blobs.go). The stream then only sends its last message, with the key of the file. An export that fails leaves no file behind.
+
+  The timestamps are Unix milliseconds in Parquet, which readers show as timestamps, and RFC 3339 in CSV.
+*/
+const exportChunkSize = 64 * 1024
+
+var (
+  auditColumns = []parquet.Column{
+    {Name: "time", Kind: parquet.Timestamp},
+    {Name: "identity", Kind: parquet.String},
+    {Name: "peer", Kind: parquet.String},
+    {Name: "method", Kind: parquet.String},
+    {Name: "post_id", Kind: parquet.String},
+    {Name: "code", Kind: parquet.String},
+    {Name: "request", Kind: parquet.String},
+  }
+  viewColumns = []parquet.Column{
+    {Name: "post_id", Kind: parquet.String},
+    {Name: "minute", Kind: parquet.Timestamp},
+    {Name: "views", Kind: parquet.Int64},
+  }
+)
+
+// rowWriter writes the rows of an export in its format, see parquet.Writer for the values.
+type rowWriter interface {
+  Write(row []any) error
+  Close() error
+}
+
+func newRowWriter(format pb.ExportFormat, w io.Writer, columns []parquet.Column) (rowWriter, error) {
+  switch format {
+  case pb.ExportFormat_EXPORT_CSV:
+    cw := &csvRows{w: csv.NewWriter(w), columns: columns}
+    header := make([]string, len(columns))
+    for i, column := range columns {
+      header[i] = column.Name
+    }
+    return cw, cw.w.Write(header)
+  case pb.ExportFormat_EXPORT_PARQUET:
+    return parquet.NewWriter(w, columns), nil
+  }
+
+  return nil, apperr.Errorf(apperr.ErrInvalidArgument, "unknown export format %v", format)
+}
+
+type csvRows struct {
+  w       *csv.Writer
+  columns []parquet.Column
+  record  []string
+}
+
+func (c *csvRows) Write(row []any) error {
+  c.record = c.record[:0]
+  for i, value := range row {
+    switch v := value.(type) {
+    case string:
+      c.record = append(c.record, v)
+    case int64:
+      if c.columns[i].Kind == parquet.Timestamp {
+        c.record = append(c.record, time.UnixMilli(v).UTC().Format(time.RFC3339))
+      } else {
+        c.record = append(c.record, strconv.FormatInt(v, 10))
+      }
+    }
+  }
+
+  return c.w.Write(c.record)
+}
+
+func (c *csvRows) Close() error {
+  c.w.Flush()
+  return c.w.Error()
+}
+
+// exportRange parses the Since and Until of a request, zero when empty.
+func exportRange(req *pb.ExportEventsRequest) (since, until time.Time, err error) {
+  if req.GetSince() != "" {
+    if since, err = time.Parse(time.RFC3339, req.GetSince()); err != nil {
+      return since, until, apperr.Errorf(apperr.ErrInvalidArgument, "Since must be an RFC 3339 timestamp: %w", err)
+    }
+  }
+  if req.GetUntil() != "" {
+    if until, err = time.Parse(time.RFC3339, req.GetUntil()); err != nil {
+      return since, until, apperr.Errorf(apperr.ErrInvalidArgument, "Until must be an RFC 3339 timestamp: %w", err)
+    }
+  }
+  if !since.IsZero() && !until.IsZero() && !until.After(since) {
+    return since, until, apperr.Errorf(apperr.ErrInvalidArgument, "Until must come after Since")
+  }
+
+  return since, until, nil
+}
+
+func inRange(at, since, until time.Time) bool {
+  return (since.IsZero() || !at.Before(since)) && (until.IsZero() || at.Before(until))
+}
+
+// export writes the entries of the range, in the order they were recorded, see EXPORTS.
+func (a *auditLog) export(since, until time.Time, w rowWriter) (int64, error) {
+  a.mu.Lock()
+  f, err := os.Open(a.path)
+  var size int64
+  if err == nil {
+    var info os.FileInfo
+    if info, err = f.Stat(); err == nil {
+      size = info.Size()
+    }
+  }
+  a.mu.Unlock()
+
+  if errors.Is(err, fs.ErrNotExist) {
+    return 0, nil
+  }
+  if err != nil {
+    return 0, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read audit log: %w", err)
+  }
+  defer f.Close()
+
+  // An entry being appended while the file was opened is after size, appends only ever add whole lines past it.
+  var rows int64
+  scanner := bufio.NewScanner(io.LimitReader(f, size))
+  scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+  for scanner.Scan() {
+    entry := &pb.AuditEntry{}
+    if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+      return rows, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to parse audit log: %w", err)
+    }
+    at, _ := time.Parse(time.RFC3339, entry.Time)
+    if !inRange(at, since, until) {
+      continue
+    }
+
+    if err := w.Write([]any{at.UnixMilli(), entry.Identity, entry.Peer, entry.Method, entry.PostId, entry.Code, entry.Request}); err != nil {
+      return rows, err
+    }
+    rows++
+  }
+  if err := scanner.Err(); err != nil {
+    return rows, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read audit log: %w", err)
+  }
+
+  return rows, nil
+}
+
+// export writes the minutes of the range with views, post by post, oldest first. The lock is taken for one post at a time, views keep being counted during an export.
+func (v *viewLog) export(since, until time.Time, w rowWriter) (int64, error) {
+  v.mu.Lock()
+  ids := make([]string, 0, len(v.minutes))
+  for id := range v.minutes {
+    ids = append(ids, id)
+  }
+  v.mu.Unlock()
+  slices.Sort(ids)
+
+  var rows int64
+  var points [][2]int64
+  for _, id := range ids {
+    points = points[:0]
+    v.mu.Lock()
+    for minute, views := range v.minutes[id] {
+      if inRange(time.Unix(minute*60, 0), since, until) {
+        points = append(points, [2]int64{minute, views})
+      }
+    }
+    v.mu.Unlock()
+    slices.SortFunc(points, func(a, b [2]int64) int { return int(a[0] - b[0]) })
+
+    for _, point := range points {
+      if err := w.Write([]any{id, point[0] * 60 * 1000, point[1]}); err != nil {
+        return rows, err
+      }
+      rows++
+    }
+  }
+
+  return rows, nil
+}
+
+// chunkWriter sends what is written to it in ExportChunks of exportChunkSize.
+type chunkWriter struct {
+  stream grpc.ServerStreamingServer[pb.ExportChunk]
+  buf    []byte
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+  n := len(p)
+  for len(p) > 0 {
+    take := min(len(p), exportChunkSize-len(c.buf))
+    c.buf = append(c.buf, p[:take]...)
+    p = p[take:]
+    if len(c.buf) == exportChunkSize {
+      if err := c.flush(); err != nil {
+        return 0, err
+      }
+    }
+  }
+
+  return n, nil
+}
+
+func (c *chunkWriter) flush() error {
+  if len(c.buf) == 0 {
+    return nil
+  }
+  err := c.stream.Send(&pb.ExportChunk{Data: c.buf})
+  c.buf = make([]byte, 0, exportChunkSize)
+
+  return err
+}
+
+// countingWriter counts the bytes of the file, which is the size of the object with ToBucket.
+type countingWriter struct {
+  w io.Writer
+  n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+  n, err := c.w.Write(p)
+  c.n += int64(n)
+
+  return n, err
+}
+
+func (a *adminServer) ExportEvents(req *pb.ExportEventsRequest, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+  if err := a.auth.requireAdmin(stream.Context()); err != nil {
+    return err
+  }
+  since, until, err := exportRange(req)
+  if err != nil {
+    return err
+  }
+
+  var columns []parquet.Column
+  var export func(since, until time.Time, w rowWriter) (int64, error)
+  switch req.GetDataset() {
+  case pb.ExportDataset_EXPORT_AUDIT_LOG:
+    columns, export = auditColumns, a.audit.export
+  case pb.ExportDataset_EXPORT_VIEWS:
+    columns, export = viewColumns, a.views.export
+  default:
+    return apperr.Errorf(apperr.ErrInvalidArgument, "unknown export dataset %v", req.GetDataset())
+  }
+  if req.GetToBucket() && a.bucket == nil {
+    return apperr.Errorf(apperr.ErrFeatureDisabled, "exports to a bucket need the attachments in a bucket, start the server with -attachments-backend s3")
+  }
+
+  write := func(out io.Writer) (rows, size int64, err error) {
+    counted := &countingWriter{w: out}
+    w, err := newRowWriter(req.GetFormat(), counted, columns)
+    if err != nil {
+      return 0, 0, err
+    }
+    if rows, err = export(since, until, &contextRows{rowWriter: w, ctx: stream.Context()}); err != nil {
+      return rows, counted.n, err
+    }
+    err = w.Close()
+
+    return rows, counted.n, err
+  }
+
+  if !req.GetToBucket() {
+    chunks := &chunkWriter{stream: stream}
+    rows, size, err := write(chunks)
+    if err != nil {
+      return err
+    }
+    if err := chunks.flush(); err != nil {
+      return err
+    }
+    return stream.Send(&pb.ExportChunk{Rows: rows, Bytes: size})
+  }
+
+  extension := map[pb.ExportFormat]string{pb.ExportFormat_EXPORT_CSV: "csv", pb.ExportFormat_EXPORT_PARQUET: "parquet"}[req.GetFormat()]
+  dataset := map[pb.ExportDataset]string{pb.ExportDataset_EXPORT_AUDIT_LOG: "audit", pb.ExportDataset_EXPORT_VIEWS: "views"}[req.GetDataset()]
+  key := fmt.Sprintf("exports/%s-%s.%s", dataset, serverClock.Now().UTC().Format("20060102T150405Z"), extension)
+
+  object, err := a.bucket.Create(key)
+  if err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to create %s in the bucket: %w", key, err)
+  }
+  rows, size, err := write(object)
+  // Close uploads the last part, the object of a failed export only exists afterwards and is deleted then.
+  if closeErr := object.Close(); err != nil || closeErr != nil {
+    a.bucket.Delete(key)
+    if err == nil {
+      err = apperr.Errorf(apperr.ErrStorageUnavailable, "failed to upload %s: %w", key, closeErr)
+    }
+    return err
+  }
+
+  return stream.Send(&pb.ExportChunk{Rows: rows, Bytes: size, BucketKey: key})
+}
+
+// contextRows stops an export whose caller left, an export to the bucket sends nothing that would fail before the end.
+type contextRows struct {
+  rowWriter
+  ctx context.Context
+}
+
+func (c *contextRows) Write(row []any) error {
+  if err := c.ctx.Err(); err != nil {
+    return status.FromContextError(err).Err()
+  }
+
+  return c.rowWriter.Write(row)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+  "bytes"
+  "encoding/binary"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "path/filepath"
+  "testing"
+  "time"
+)
+
+// recordedRows collects the rows of an export, as a rowWriter.
+type recordedRows struct {
+  rows [][]any
+}
+
+func (r *recordedRows) Write(row []any) error {
+  r.rows = append(r.rows, row)
+  return nil
+}
+
+func (r *recordedRows) Close() error { return nil }
+
+func TestExportAuditLogRange(t *testing.T) {
+  audit := newAuditLog(filepath.Join(t.TempDir(), "audit.log"), 0)
+  for _, at := range []string{"2025-05-31T23:59:59Z", "2025-06-01T00:00:00Z", "2025-06-15T12:00:00Z", "2025-07-01T00:00:00Z"} {
+    if err := audit.append(&pb.AuditEntry{Time: at, Identity: "admin", Method: "DeletePost", PostId: "p1", Code: "OK"}); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  since, until, err := exportRange(&pb.ExportEventsRequest{Since: "2025-06-01T00:00:00Z", Until: "2025-07-01T00:00:00Z"})
+  if err != nil {
+    t.Fatal(err)
+  }
+  var got recordedRows
+  rows, err := audit.export(since, until, &got)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  // Since is in the range, Until isn't.
+  if rows != 2 || len(got.rows) != 2 {
+    t.Fatalf("exported %d rows, want the 2 of June", rows)
+  }
+  if at := time.UnixMilli(got.rows[1][0].(int64)).UTC(); !at.Equal(time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)) {
+    t.Errorf("the second row is at %s, want 2025-06-15T12:00:00Z", at)
+  }
+
+  var csv bytes.Buffer
+  w, err := newRowWriter(pb.ExportFormat_EXPORT_CSV, &csv, auditColumns)
+  if err != nil {
+    t.Fatal(err)
+  }
+  for _, row := range got.rows {
+    w.Write(row)
+  }
+  if err := w.Close(); err != nil {
+    t.Fatal(err)
+  }
+  want := "time,identity,peer,method,post_id,code,request\n" +
+    "2025-06-01T00:00:00Z,admin,,DeletePost,p1,OK,\n" +
+    "2025-06-15T12:00:00Z,admin,,DeletePost,p1,OK,\n"
+  if csv.String() != want {
+    t.Errorf("got the CSV\n%s\nwant\n%s", csv.String(), want)
+  }
+}
+
+func TestExportViewsToParquet(t *testing.T) {
+  dir := t.TempDir()
+  views, err := newViewLog(filepath.Join(dir, "views.json"), filepath.Join(dir, "viewers.json"), 0, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+  views.record([]*pb.Post{{Id: "b"}, {Id: "a"}}, start)
+  views.record([]*pb.Post{{Id: "a"}}, start.Add(-time.Minute))
+  views.record([]*pb.Post{{Id: "a"}}, start)
+
+  var got recordedRows
+  if _, err := views.export(time.Time{}, time.Time{}, &got); err != nil {
+    t.Fatal(err)
+  }
+  // Post by post, oldest minute first.
+  want := [][]any{
+    {"a", start.Add(-time.Minute).UnixMilli(), int64(1)},
+    {"a", start.UnixMilli(), int64(2)},
+    {"b", start.UnixMilli(), int64(1)},
+  }
+  if len(got.rows) != len(want) {
+    t.Fatalf("exported %v, want %v", got.rows, want)
+  }
+  for i := range want {
+    for j := range want[i] {
+      if got.rows[i][j] != want[i][j] {
+        t.Errorf("row %d is %v, want %v", i, got.rows[i], want[i])
+        break
+      }
+    }
+  }
+
+  var file bytes.Buffer
+  w, err := newRowWriter(pb.ExportFormat_EXPORT_PARQUET, &file, viewColumns)
+  if err != nil {
+    t.Fatal(err)
+  }
+  for _, row := range got.rows {
+    if err := w.Write(row); err != nil {
+      t.Fatal(err)
+    }
+  }
+  if err := w.Close(); err != nil {
+    t.Fatal(err)
+  }
+  data := file.Bytes()
+  if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+    t.Fatalf("the export doesn't start and end with PAR1")
+  }
+  if footer := binary.LittleEndian.Uint32(data[len(data)-8:]); int(footer) >= len(data)-12 {
+    t.Errorf("the footer of %d bytes doesn't fit in the file of %d", footer, len(data))
+  }
+}
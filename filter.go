@@ -0,0 +1,129 @@
+package main
+
+import (
+  "cmp"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "slices"
+  "time"
+)
+
+/*
+  FILTERING POSTS
+
+  GetPosts, DeletePosts and ArchivePosts take the same PostFilter, so the posts a bulk RPC would touch are exactly the ones GetPosts lists with the same filter:
+
+    go run ./client list -author alice -since 2025-01-01 -tags grpc
+    go run ./client bulk archive -token secret -author alice -until 2024-12-31 -dry-run
+
+  A post has to match every field that is set: one of the authors, created between Since and Until (both days included) tagged with every tag and taking between MinReadingMinutes and MaxReadingMinutes to read (see internal/store/reading.go). Tags are normalized the same way they are on posts (see tags.go), so -tags gRPC finds the posts tagged grpc.
+*/
+type postFilter struct {
+  authors      []string
+  since, until string
+  tags         []string
+  // Minutes of reading time, 0 doesn't bound it.
+  minReading, maxReading int32
+}
+
+// parseFilter checks the filter of a request, a nil filter matches every post.
+func parseFilter(filter *pb.PostFilter) (*postFilter, error) {
+  f := &postFilter{authors: filter.GetAuthors(), since: filter.GetSince(), until: filter.GetUntil(), minReading: filter.GetMinReadingMinutes(), maxReading: filter.GetMaxReadingMinutes()}
+
+  // CreatedAt is a YYYY-MM-DD date, so once checked the dates compare as strings.
+  if _, err := time.Parse("2006-01-02", f.since); f.since != "" && err != nil {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Since must be a YYYY-MM-DD date: %w", err)
+  }
+  if _, err := time.Parse("2006-01-02", f.until); f.until != "" && err != nil {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Until must be a YYYY-MM-DD date: %w", err)
+  }
+  if f.since != "" && f.until != "" && f.until < f.since {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Until can't be before Since")
+  }
+  if f.minReading < 0 || f.maxReading < 0 {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "MinReadingMinutes and MaxReadingMinutes can't be negative")
+  }
+  if f.maxReading > 0 && f.maxReading < f.minReading {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "MaxReadingMinutes can't be less than MinReadingMinutes")
+  }
+
+  tags, err := normalizeTags(filter.GetTags())
+  if err != nil {
+    return nil, err
+  }
+  f.tags = tags
+
+  return f, nil
+}
+
+func (f *postFilter) empty() bool {
+  return len(f.authors) == 0 && f.since == "" && f.until == "" && len(f.tags) == 0 && f.minReading == 0 && f.maxReading == 0
+}
+
+func (f *postFilter) match(post *pb.Post) bool {
+  if len(f.authors) > 0 && !slices.Contains(f.authors, post.Author) {
+    return false
+  }
+  if f.since != "" && post.CreatedAt < f.since {
+    return false
+  }
+  if f.until != "" && post.CreatedAt > f.until {
+    return false
+  }
+  if post.ReadingMinutes < f.minReading || f.maxReading > 0 && post.ReadingMinutes > f.maxReading {
+    return false
+  }
+  for _, tag := range f.tags {
+    if !slices.Contains(post.Tags, tag) {
+      return false
+    }
+  }
+
+  return true
+}
+
+// apply returns the posts matching the filter, the others are left out.
+func (f *postFilter) apply(posts *pb.Posts) *pb.Posts {
+  if f.empty() {
+    return posts
+  }
+
+  filtered := &pb.Posts{Posts: make([]*pb.Post, 0)}
+  for _, post := range posts.Posts {
+    if f.match(post) {
+      filtered.Posts = append(filtered.Posts, post)
+    }
+  }
+
+  return filtered
+}
+
+/*
+  SORTING POSTS
+
+  GetPosts returns the posts in the order they were created, OrderBy sorts them by reading time instead:
+
+    go run ./client list -sort reading-time -max-reading 5
+
+  Pinned posts come first whatever the order, see pins.go
+*/
+func checkOrder(order pb.PostOrder) error {
+  if _, ok := pb.PostOrder_name[int32(order)]; !ok {
+    return apperr.Errorf(apperr.ErrInvalidArgument, "unknown OrderBy %d", order)
+  }
+
+  return nil
+}
+
+// sortPosts returns the posts in the order asked for. They are sorted in a slice of their own, the mirror shares the one it is given between calls.
+func sortPosts(posts *pb.Posts, order pb.PostOrder) *pb.Posts {
+  sorted := &pb.Posts{Posts: slices.Clone(posts.Posts)}
+  switch order {
+  case pb.PostOrder_ORDER_READING_TIME:
+    slices.SortStableFunc(sorted.Posts, func(a, b *pb.Post) int { return cmp.Compare(a.ReadingMinutes, b.ReadingMinutes) })
+  case pb.PostOrder_ORDER_READING_TIME_DESC:
+    slices.SortStableFunc(sorted.Posts, func(a, b *pb.Post) int { return cmp.Compare(b.ReadingMinutes, a.ReadingMinutes) })
+  }
+
+  return pinnedFirst(sorted)
+}
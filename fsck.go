@@ -0,0 +1,297 @@
+package main
+
+import (
+  "encoding/json"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/store"
+  "log"
+  "maps"
+  "os"
+  "slices"
+  "time"
+)
+
+/*
+  CHECKING AND REPAIRING THE DATA
+
+  The server trusts its files: a post that doesn't parse fails every GetPosts, and a revision or a view pointing to a post that's gone just sits there. Files get edited by hand, copied halfway or cut short by a full disk, so -fsck looks at everything before the server starts serving:
+
+    go run . -fsck check     # report the problems, and refuse to start if there are any
+    go run . -fsck repair    # fix them, then start
+
+  What is checked, and what repair does about it:
+    - posts that can't be read (see Salvage in internal/store/fsck.go): they are dropped, after being appended to lost+found.jsonl so nothing is lost for good
+    - posts sharing an ID: the first one keeps it, later ones get a new ID, or are dropped if they are tombstones
+    - invalid dates: CreatedAt and LastViewed must be YYYY-MM-DD and PublishAt RFC 3339. Dates in a format we recognize are rewritten, others are cleared. A scheduled post without a valid PublishAt would never be published, it gets archived instead so nobody reads it before it is looked at
+    - dangling references: attachments whose file is gone, revisions, views and comments of posts that don't exist anymore. They are dropped
+    - replies to a comment that isn't one of the same post: they become comments on the post itself
+
+  Repairing saves the posts once, through the same storage the server uses, then rewrites the files that changed.
+*/
+const lostAndFoundPath = "lost+found.jsonl"
+
+type fsck struct {
+  attachments attachmentStore
+  problems    int
+  // changed tells which files repair has to write.
+  postsChanged, revisionsChanged, viewsChanged, commentsChanged bool
+}
+
+func (f *fsck) report(where, problem, repair string) {
+  f.problems++
+  log.Printf("fsck: %s: %s, repair %s", where, problem, repair)
+}
+
+// runFsck checks the data, and with repair fixes what it found. Without repair finding problems is an error.
+func runFsck(storage store.Salvager, attachments attachmentStore, repair bool) error {
+  f := &fsck{attachments: attachments}
+
+  posts, corrupt, err := storage.Salvage()
+  if err != nil {
+    return fmt.Errorf("failed to read the posts: %w", err)
+  }
+  for _, entry := range corrupt {
+    f.report(entry.Where, "unreadable post ("+entry.Err+")", "moves it to "+lostAndFoundPath)
+    f.postsChanged = true
+  }
+
+  posts = f.checkIDs(posts)
+  live := make(map[string]bool)
+  for _, post := range posts {
+    f.checkDates(post)
+    f.checkAttachments(post)
+    if post.Status != pb.PostStatus_DELETED {
+      live[post.Id] = true
+    }
+  }
+
+  revisions, err := loadRevisions()
+  if err != nil {
+    return err
+  }
+  // Map keys are sorted so two runs report the same problems in the same order.
+  for _, id := range slices.Sorted(maps.Keys(revisions)) {
+    if !live[id] {
+      f.report("revisions of "+id, "the post doesn't exist", "drops them")
+      delete(revisions, id)
+      f.revisionsChanged = true
+    }
+  }
+
+  views, viewers := viewsFile{}, map[string]map[string]int64{}
+  if err := readJSON(viewsPath, &views); err != nil {
+    return err
+  }
+  if err := readJSON(viewersPath, &viewers); err != nil {
+    return err
+  }
+  for _, id := range slices.Sorted(maps.Keys(views)) {
+    if !live[id] {
+      f.report("views of "+id, "the post doesn't exist", "drops them")
+      delete(views, id)
+      f.viewsChanged = true
+    }
+  }
+  for _, id := range slices.Sorted(maps.Keys(viewers)) {
+    if !live[id] {
+      f.report("viewers of "+id, "the post doesn't exist", "drops them")
+      delete(viewers, id)
+      f.viewsChanged = true
+    }
+  }
+
+  comments := &commentStore{path: commentsPath}
+  allComments, err := comments.load()
+  if err != nil {
+    return err
+  }
+  allComments = f.checkComments(allComments, live)
+
+  if f.problems == 0 {
+    log.Printf("fsck: %d posts checked, no problems found", len(posts))
+    return nil
+  }
+  if !repair {
+    return fmt.Errorf("fsck found %d problems, start with -fsck repair to fix them", f.problems)
+  }
+
+  if err := appendLostAndFound(corrupt); err != nil {
+    return err
+  }
+  if f.postsChanged {
+    if err := savePosts(&pb.Posts{Posts: posts}); err != nil {
+      return fmt.Errorf("failed to save the repaired posts: %w", err)
+    }
+  }
+  if f.revisionsChanged {
+    if err := saveRevisions(revisions); err != nil {
+      return err
+    }
+  }
+  if f.viewsChanged {
+    if err := replaceFile(viewsPath, views); err != nil {
+      return fmt.Errorf("failed to save the repaired views: %w", err)
+    }
+    if err := replaceFile(viewersPath, viewers); err != nil {
+      return fmt.Errorf("failed to save the repaired viewers: %w", err)
+    }
+  }
+  if f.commentsChanged {
+    if err := comments.save(allComments); err != nil {
+      return err
+    }
+  }
+  log.Printf("fsck: repaired %d problems", f.problems)
+
+  return nil
+}
+
+// checkComments returns the comments of the posts that exist, with the replies to a missing comment moved to the post.
+func (f *fsck) checkComments(comments []*pb.Comment, live map[string]bool) []*pb.Comment {
+  postOf := make(map[string]string)
+  for _, comment := range comments {
+    postOf[comment.Id] = comment.PostId
+  }
+
+  kept := make([]*pb.Comment, 0, len(comments))
+  for _, comment := range comments {
+    if !live[comment.PostId] {
+      f.report("comment "+comment.Id, "the post "+comment.PostId+" doesn't exist", "drops it")
+      f.commentsChanged = true
+      continue
+    }
+    if comment.ParentId != "" && postOf[comment.ParentId] != comment.PostId {
+      f.report("comment "+comment.Id, "answers "+comment.ParentId+" which isn't a comment of the post", "makes it a comment on the post")
+      comment.ParentId = ""
+      f.commentsChanged = true
+    }
+    kept = append(kept, comment)
+  }
+
+  return kept
+}
+
+// checkIDs returns the posts without the duplicated tombstones, and gives the other duplicates an ID of their own.
+func (f *fsck) checkIDs(posts []*pb.Post) []*pb.Post {
+  seen := make(map[string]bool)
+  kept := make([]*pb.Post, 0, len(posts))
+  for _, post := range posts {
+    // Posts without an ID get one from Backfill when they are loaded.
+    if post.Id == "" || !seen[post.Id] {
+      seen[post.Id] = true
+      kept = append(kept, post)
+      continue
+    }
+
+    f.postsChanged = true
+    if post.Status == pb.PostStatus_DELETED {
+      f.report("post "+post.Id, "a tombstone repeats the ID of another post", "drops it")
+      continue
+    }
+    id := store.NewID()
+    f.report("post "+post.Id, fmt.Sprintf("%q repeats the ID of another post", post.Title), "gives it the ID "+id)
+    post.Id = id
+    kept = append(kept, post)
+  }
+
+  return kept
+}
+
+// dateLayouts are the formats a date in the wrong format is recognized in.
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006/01/02", "2006-01-02"}
+
+func parseAnyDate(value string) (time.Time, bool) {
+  for _, layout := range dateLayouts {
+    if t, err := time.Parse(layout, value); err == nil {
+      return t, true
+    }
+  }
+
+  return time.Time{}, false
+}
+
+func (f *fsck) checkDates(post *pb.Post) {
+  where := "post " + post.Id
+
+  for _, field := range []struct {
+    name  string
+    value *string
+  }{{"CreatedAt", &post.CreatedAt}, {"LastViewed", &post.LastViewed}} {
+    if _, err := time.Parse("2006-01-02", *field.value); *field.value == "" || err == nil {
+      continue
+    }
+
+    f.postsChanged = true
+    if t, ok := parseAnyDate(*field.value); ok {
+      f.report(where, fmt.Sprintf("%s %q is not a YYYY-MM-DD date", field.name, *field.value), "rewrites it as "+t.Format("2006-01-02"))
+      *field.value = t.Format("2006-01-02")
+    } else {
+      f.report(where, fmt.Sprintf("%s %q is not a date", field.name, *field.value), "clears it")
+      *field.value = ""
+    }
+  }
+
+  if _, err := time.Parse(time.RFC3339, post.PublishAt); post.PublishAt == "" || err == nil {
+    if post.PublishAt == "" && post.Status == pb.PostStatus_SCHEDULED {
+      f.report(where, "a scheduled post has no PublishAt", "archives it")
+      post.Status = pb.PostStatus_ARCHIVED
+      f.postsChanged = true
+    }
+    return
+  }
+
+  f.postsChanged = true
+  switch t, ok := parseAnyDate(post.PublishAt); {
+  case ok:
+    f.report(where, fmt.Sprintf("PublishAt %q is not an RFC 3339 time", post.PublishAt), "rewrites it as "+t.UTC().Format(time.RFC3339))
+    post.PublishAt = t.UTC().Format(time.RFC3339)
+  case post.Status == pb.PostStatus_SCHEDULED:
+    f.report(where, fmt.Sprintf("PublishAt %q is not a time, the post would never be published", post.PublishAt), "archives it")
+    post.Status, post.PublishAt = pb.PostStatus_ARCHIVED, ""
+  default:
+    f.report(where, fmt.Sprintf("PublishAt %q is not a time", post.PublishAt), "clears it")
+    post.PublishAt = ""
+  }
+}
+
+func (f *fsck) checkAttachments(post *pb.Post) {
+  if post.Status == pb.PostStatus_DELETED {
+    return
+  }
+
+  kept := post.Attachments[:0]
+  for _, attachment := range post.Attachments {
+    r, err := f.attachments.Open(post.Id, attachment.Id)
+    if err != nil {
+      f.report("post "+post.Id, fmt.Sprintf("attachment %s (%s) can't be opened: %v", attachment.Id, attachment.Filename, err), "drops it")
+      f.postsChanged = true
+      continue
+    }
+    r.Close()
+    kept = append(kept, attachment)
+  }
+  post.Attachments = kept
+}
+
+// appendLostAndFound keeps the posts that couldn't be read, one JSON object per line.
+func appendLostAndFound(corrupt []store.CorruptEntry) error {
+  if len(corrupt) == 0 {
+    return nil
+  }
+
+  file, err := os.OpenFile(lostAndFoundPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+  if err != nil {
+    return fmt.Errorf("failed to open %s: %w", lostAndFoundPath, err)
+  }
+  defer file.Close()
+
+  enc := json.NewEncoder(file)
+  for _, entry := range corrupt {
+    if err := enc.Encode(entry); err != nil {
+      return fmt.Errorf("failed to write %s: %w", lostAndFoundPath, err)
+    }
+  }
+
+  return file.Close()
+}
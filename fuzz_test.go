@@ -0,0 +1,147 @@
+package main
+
+import (
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/store"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+)
+
+/*
+  FUZZING
+
+  posts.json is written by the server but read back whatever happened to it since: edited by hand, cut short by a full disk, written by an older version. The fuzz targets feed the code reading it, and the code turning what authors write into slugs and HTML, inputs nobody thought of:
+
+    go test -run '^$' -fuzz FuzzLoadPost -fuzztime 1m
+    go test -run '^$' -fuzz FuzzSlugify -fuzztime 1m
+    go test -run '^$' -fuzz FuzzRender -fuzztime 1m
+
+  An input that fails is written to testdata/fuzz/<target>, commit it with the fix: go test runs every file there, so the bug stays fixed.
+*/
+
+func FuzzLoadPost(f *testing.F) {
+  f.Add([]byte(`[{"Title":"Small Post","Content":"Hello","Author":"John"}]`))
+  f.Add([]byte(`{"Id":"a","Title":"One"}` + "\n" + `{"Id":"b","Title":"Two","Status":"DELETED"}`))
+  f.Add([]byte(`null`))
+  f.Add([]byte(``))
+  f.Add([]byte(`[{"Title":"Cut short`))
+  f.Add([]byte(`[{"Id":"a","Sequence":"12","Tags":["go","go"],"Slug":"","PublishAt":"not a date"}]`))
+  if data, err := os.ReadFile("posts.json"); err == nil {
+    f.Add(data)
+  }
+
+  f.Fuzz(func(t *testing.T, data []byte) {
+    previous := postStore
+    path := filepath.Join(t.TempDir(), "posts.json")
+    postStore = &store.FileStore{Path: path}
+    defer func() { postStore = previous }()
+    if err := os.WriteFile(path, data, 0o644); err != nil {
+      t.Fatal(err)
+    }
+
+    posts := &pb.Posts{}
+    if err := loadPost(posts); err != nil {
+      // Refusing what it can't read is fine, crashing isn't.
+      return
+    }
+
+    // What could be read is backfilled, and written back in a form that reads the same.
+    for _, post := range posts.Posts {
+      if post.Id == "" || post.Sequence == 0 {
+        t.Fatalf("post %q left without an ID or a sequence", post.Title)
+      }
+    }
+    again := &pb.Posts{}
+    if err := loadPost(again); err != nil {
+      t.Fatalf("the posts don't load anymore once saved: %v", err)
+    }
+    if len(again.Posts) != len(posts.Posts) {
+      t.Fatalf("loaded %d posts, then %d", len(posts.Posts), len(again.Posts))
+    }
+  })
+}
+
+func FuzzSlugify(f *testing.F) {
+  for _, title := range []string{"Hello, World!", "Café crème", "Привет мир", "--a--b--", strings.Repeat("long title ", 20), "ǅemal ﬁnds Ⅻ"} {
+    f.Add(title)
+  }
+
+  f.Fuzz(func(t *testing.T, title string) {
+    slug := store.Slugify(title)
+    if len(slug) > store.MaxSlugLength {
+      t.Fatalf("Slugify(%q) = %q, longer than %d", title, slug, store.MaxSlugLength)
+    }
+    if strings.HasPrefix(slug, "-") || strings.HasSuffix(slug, "-") || strings.Contains(slug, "--") {
+      t.Fatalf("Slugify(%q) = %q has stray dashes", title, slug)
+    }
+    for _, r := range slug {
+      if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+        t.Fatalf("Slugify(%q) = %q has %q, which isn't URL friendly", title, slug, r)
+      }
+    }
+    if again := store.Slugify(slug); again != slug {
+      t.Fatalf("Slugify(%q) = %q, which slugifies again to %q", title, slug, again)
+    }
+    if unique := store.UniqueSlug([]*pb.Post{{Slug: slug}}, title); unique == "" || unique == slug || len(unique) > store.MaxSlugLength {
+      t.Fatalf("UniqueSlug(%q) = %q next to a post with the same slug", title, unique)
+    }
+  })
+}
+
+func TestUniqueSlugOfLongTitles(t *testing.T) {
+  // A title of 80 letters, and one whose cut for the number falls on a dash.
+  for _, title := range []string{strings.Repeat("abcdefghij", 8), strings.Repeat("a", 77) + " bc"} {
+    var posts []*pb.Post
+    for range 11 {
+      slug := store.UniqueSlug(posts, title)
+      if err := validateRequest(&pb.GetPostBySlugRequest{Slug: slug}); err != nil {
+        t.Fatalf("the slug %q of post %d doesn't pass GetPostBySlug: %v", slug, len(posts)+1, err)
+      }
+      posts = append(posts, &pb.Post{Slug: slug})
+    }
+    if last := posts[10].Slug; !strings.HasSuffix(last, "-11") {
+      t.Errorf("the 11th post got %q, want it numbered 11", last)
+    }
+  }
+}
+
+func FuzzRender(f *testing.F) {
+  for _, content := range []string{
+    "# Title\n\nSome *emphasis* and `code`.",
+    "<script>alert(1)</script>",
+    "[click](javascript:alert(1))",
+    "| a | b |\n|---|---|\n| 1 | 2 |",
+    "- [ ] task\n- [x] done",
+    "> quote\n>> nested\n\n```go\nfunc main() {}\n```",
+    "<img src=x onerror=alert(1)>",
+  } {
+    f.Add(content)
+  }
+
+  renderers := map[string]renderer{}
+  for _, name := range []string{"commonmark", "gfm", "plain"} {
+    r, err := newRenderer(name)
+    if err != nil {
+      f.Fatal(err)
+    }
+    renderers[name] = r
+  }
+
+  f.Fuzz(func(t *testing.T, content string) {
+    for name, r := range renderers {
+      html, err := r.Render(content)
+      if err != nil {
+        t.Fatalf("%s: %v", name, err)
+      }
+      // The sanitizing of render.go: no markup of the author gets through.
+      lower := strings.ToLower(html)
+      for _, unsafe := range []string{"<script", "<iframe", "href=\"javascript:", "src=\"javascript:"} {
+        if strings.Contains(lower, unsafe) {
+          t.Fatalf("%s rendered %q into %q", name, content, html)
+        }
+      }
+    }
+  })
+}
@@ -10,8 +10,10 @@
 package grpc_tutorial
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,6 +26,528 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Enums:
+// PUBLISHED is the zero value on purpose: fields that are not set take the zero value, so every post stored before statuses existed reads as published.
+type PostStatus int32
+
+const (
+	PostStatus_PUBLISHED PostStatus = 0
+	PostStatus_SCHEDULED PostStatus = 1
+	// Deleted posts are kept as an empty tombstone so SyncChanges can tell clients to drop them.
+	PostStatus_DELETED PostStatus = 2
+	// Archived posts are hidden from readers like scheduled ones, but keep everything, see ArchivePosts.
+	PostStatus_ARCHIVED PostStatus = 3
+	// Drafts are hidden from readers until UpdatePost publishes them. Drafts left untouched for too long are cleaned up, see drafts.go
+	PostStatus_DRAFT PostStatus = 4
+)
+
+// Enum value maps for PostStatus.
+var (
+	PostStatus_name = map[int32]string{
+		0: "PUBLISHED",
+		1: "SCHEDULED",
+		2: "DELETED",
+		3: "ARCHIVED",
+		4: "DRAFT",
+	}
+	PostStatus_value = map[string]int32{
+		"PUBLISHED": 0,
+		"SCHEDULED": 1,
+		"DELETED":   2,
+		"ARCHIVED":  3,
+		"DRAFT":     4,
+	}
+)
+
+func (x PostStatus) Enum() *PostStatus {
+	p := new(PostStatus)
+	*p = x
+	return p
+}
+
+func (x PostStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[0].Descriptor()
+}
+
+func (PostStatus) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[0]
+}
+
+func (x PostStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostStatus.Descriptor instead.
+func (PostStatus) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{0}
+}
+
+type PostOrder int32
+
+const (
+	// The order the posts were created in.
+	PostOrder_ORDER_CREATED PostOrder = 0
+	// The quickest reads first, posts of the same reading time keep the order they were created in.
+	PostOrder_ORDER_READING_TIME PostOrder = 1
+	// The longest reads first.
+	PostOrder_ORDER_READING_TIME_DESC PostOrder = 2
+)
+
+// Enum value maps for PostOrder.
+var (
+	PostOrder_name = map[int32]string{
+		0: "ORDER_CREATED",
+		1: "ORDER_READING_TIME",
+		2: "ORDER_READING_TIME_DESC",
+	}
+	PostOrder_value = map[string]int32{
+		"ORDER_CREATED":           0,
+		"ORDER_READING_TIME":      1,
+		"ORDER_READING_TIME_DESC": 2,
+	}
+)
+
+func (x PostOrder) Enum() *PostOrder {
+	p := new(PostOrder)
+	*p = x
+	return p
+}
+
+func (x PostOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[1].Descriptor()
+}
+
+func (PostOrder) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[1]
+}
+
+func (x PostOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostOrder.Descriptor instead.
+func (PostOrder) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{1}
+}
+
+// Enum values share the scope of the enum itself, so these are prefixed to stay clear of PostStatus.
+type PostEventType int32
+
+const (
+	PostEventType_POST_CREATED PostEventType = 0
+	PostEventType_POST_UPDATED PostEventType = 1
+	PostEventType_POST_DELETED PostEventType = 2
+	// The post became visible to readers, which happens right after POST_CREATED for posts that aren't scheduled.
+	PostEventType_POST_PUBLISHED PostEventType = 3
+	// The post was archived by ArchivePosts, or as a stale draft, and is hidden from readers from now on.
+	PostEventType_POST_ARCHIVED PostEventType = 4
+	// The post was published and isn't anymore, UpdatePost gave it a PublishAt to come. Clients drop it like a deleted one, the event only carries its ID, author and sequence until it is published again.
+	PostEventType_POST_UNPUBLISHED PostEventType = 5
+)
+
+// Enum value maps for PostEventType.
+var (
+	PostEventType_name = map[int32]string{
+		0: "POST_CREATED",
+		1: "POST_UPDATED",
+		2: "POST_DELETED",
+		3: "POST_PUBLISHED",
+		4: "POST_ARCHIVED",
+		5: "POST_UNPUBLISHED",
+	}
+	PostEventType_value = map[string]int32{
+		"POST_CREATED":     0,
+		"POST_UPDATED":     1,
+		"POST_DELETED":     2,
+		"POST_PUBLISHED":   3,
+		"POST_ARCHIVED":    4,
+		"POST_UNPUBLISHED": 5,
+	}
+)
+
+func (x PostEventType) Enum() *PostEventType {
+	p := new(PostEventType)
+	*p = x
+	return p
+}
+
+func (x PostEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[2].Descriptor()
+}
+
+func (PostEventType) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[2]
+}
+
+func (x PostEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostEventType.Descriptor instead.
+func (PostEventType) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{2}
+}
+
+type SummaryPeriod int32
+
+const (
+	SummaryPeriod_SUMMARY_DAY SummaryPeriod = 0
+	// From Monday to Sunday, as ISO 8601 weeks.
+	SummaryPeriod_SUMMARY_WEEK SummaryPeriod = 1
+)
+
+// Enum value maps for SummaryPeriod.
+var (
+	SummaryPeriod_name = map[int32]string{
+		0: "SUMMARY_DAY",
+		1: "SUMMARY_WEEK",
+	}
+	SummaryPeriod_value = map[string]int32{
+		"SUMMARY_DAY":  0,
+		"SUMMARY_WEEK": 1,
+	}
+)
+
+func (x SummaryPeriod) Enum() *SummaryPeriod {
+	p := new(SummaryPeriod)
+	*p = x
+	return p
+}
+
+func (x SummaryPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SummaryPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[3].Descriptor()
+}
+
+func (SummaryPeriod) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[3]
+}
+
+func (x SummaryPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SummaryPeriod.Descriptor instead.
+func (SummaryPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{3}
+}
+
+type NotificationType int32
+
+const (
+	// A post of the caller became visible to readers.
+	NotificationType_NOTIFICATION_POST_PUBLISHED NotificationType = 0
+	// Somebody commented on a post of the caller.
+	NotificationType_NOTIFICATION_NEW_COMMENT NotificationType = 1
+)
+
+// Enum value maps for NotificationType.
+var (
+	NotificationType_name = map[int32]string{
+		0: "NOTIFICATION_POST_PUBLISHED",
+		1: "NOTIFICATION_NEW_COMMENT",
+	}
+	NotificationType_value = map[string]int32{
+		"NOTIFICATION_POST_PUBLISHED": 0,
+		"NOTIFICATION_NEW_COMMENT":    1,
+	}
+)
+
+func (x NotificationType) Enum() *NotificationType {
+	p := new(NotificationType)
+	*p = x
+	return p
+}
+
+func (x NotificationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[4].Descriptor()
+}
+
+func (NotificationType) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[4]
+}
+
+func (x NotificationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationType.Descriptor instead.
+func (NotificationType) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{4}
+}
+
+type CommentStatus int32
+
+const (
+	// Waiting for a moderator, only its author and the moderators see it.
+	CommentStatus_PENDING  CommentStatus = 0
+	CommentStatus_APPROVED CommentStatus = 1
+	CommentStatus_REJECTED CommentStatus = 2
+)
+
+// Enum value maps for CommentStatus.
+var (
+	CommentStatus_name = map[int32]string{
+		0: "PENDING",
+		1: "APPROVED",
+		2: "REJECTED",
+	}
+	CommentStatus_value = map[string]int32{
+		"PENDING":  0,
+		"APPROVED": 1,
+		"REJECTED": 2,
+	}
+)
+
+func (x CommentStatus) Enum() *CommentStatus {
+	p := new(CommentStatus)
+	*p = x
+	return p
+}
+
+func (x CommentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[5].Descriptor()
+}
+
+func (CommentStatus) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[5]
+}
+
+func (x CommentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommentStatus.Descriptor instead.
+func (CommentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{5}
+}
+
+type ReportReason int32
+
+const (
+	ReportReason_REPORT_OTHER      ReportReason = 0
+	ReportReason_REPORT_SPAM       ReportReason = 1
+	ReportReason_REPORT_HARASSMENT ReportReason = 2
+	ReportReason_REPORT_OFFENSIVE  ReportReason = 3
+)
+
+// Enum value maps for ReportReason.
+var (
+	ReportReason_name = map[int32]string{
+		0: "REPORT_OTHER",
+		1: "REPORT_SPAM",
+		2: "REPORT_HARASSMENT",
+		3: "REPORT_OFFENSIVE",
+	}
+	ReportReason_value = map[string]int32{
+		"REPORT_OTHER":      0,
+		"REPORT_SPAM":       1,
+		"REPORT_HARASSMENT": 2,
+		"REPORT_OFFENSIVE":  3,
+	}
+)
+
+func (x ReportReason) Enum() *ReportReason {
+	p := new(ReportReason)
+	*p = x
+	return p
+}
+
+func (x ReportReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[6].Descriptor()
+}
+
+func (ReportReason) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[6]
+}
+
+func (x ReportReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportReason.Descriptor instead.
+func (ReportReason) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{6}
+}
+
+// What the moderator did about a report.
+type ReportResolution int32
+
+const (
+	// Still waiting for a moderator.
+	ReportResolution_UNRESOLVED ReportResolution = 0
+	// Nothing wrong, the content stays.
+	ReportResolution_DISMISSED ReportResolution = 1
+	// The post was archived, or the comment rejected.
+	ReportResolution_CONTENT_HIDDEN ReportResolution = 2
+	// The content was hidden and its author can't create posts or comments anymore.
+	ReportResolution_AUTHOR_BANNED ReportResolution = 3
+)
+
+// Enum value maps for ReportResolution.
+var (
+	ReportResolution_name = map[int32]string{
+		0: "UNRESOLVED",
+		1: "DISMISSED",
+		2: "CONTENT_HIDDEN",
+		3: "AUTHOR_BANNED",
+	}
+	ReportResolution_value = map[string]int32{
+		"UNRESOLVED":     0,
+		"DISMISSED":      1,
+		"CONTENT_HIDDEN": 2,
+		"AUTHOR_BANNED":  3,
+	}
+)
+
+func (x ReportResolution) Enum() *ReportResolution {
+	p := new(ReportResolution)
+	*p = x
+	return p
+}
+
+func (x ReportResolution) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportResolution) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[7].Descriptor()
+}
+
+func (ReportResolution) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[7]
+}
+
+func (x ReportResolution) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportResolution.Descriptor instead.
+func (ReportResolution) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{7}
+}
+
+type ExportDataset int32
+
+const (
+	// The entries of audit.jsonl: Time, Identity, Peer, Method, PostId, Code and Request.
+	ExportDataset_EXPORT_AUDIT_LOG ExportDataset = 0
+	// The views of every post by minute, as far back as -views-retention: PostId, Minute and Views.
+	ExportDataset_EXPORT_VIEWS ExportDataset = 1
+)
+
+// Enum value maps for ExportDataset.
+var (
+	ExportDataset_name = map[int32]string{
+		0: "EXPORT_AUDIT_LOG",
+		1: "EXPORT_VIEWS",
+	}
+	ExportDataset_value = map[string]int32{
+		"EXPORT_AUDIT_LOG": 0,
+		"EXPORT_VIEWS":     1,
+	}
+)
+
+func (x ExportDataset) Enum() *ExportDataset {
+	p := new(ExportDataset)
+	*p = x
+	return p
+}
+
+func (x ExportDataset) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportDataset) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[8].Descriptor()
+}
+
+func (ExportDataset) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[8]
+}
+
+func (x ExportDataset) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportDataset.Descriptor instead.
+func (ExportDataset) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{8}
+}
+
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_CSV     ExportFormat = 0
+	ExportFormat_EXPORT_PARQUET ExportFormat = 1
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_CSV",
+		1: "EXPORT_PARQUET",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_CSV":     0,
+		"EXPORT_PARQUET": 1,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[9].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[9]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{9}
+}
+
 // Message:
 // Defines the structure of the data being sent. Messages are like structs or classes in programming languages. They specify:
 // - Field names
@@ -31,32 +555,8985 @@ const (
 // - Field numbers (unique identifiers used in the binary encoding)
 // Messages are used as input and output types for RPCs.
 type Post struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=Title,proto3" json:"Title,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=Content,proto3" json:"Content,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
-	Author        string                 `protobuf:"bytes,4,opt,name=Author,proto3" json:"Author,omitempty"`
-	ViewCount     int64                  `protobuf:"varint,5,opt,name=ViewCount,proto3" json:"ViewCount,omitempty"`
-	LastViewed    string                 `protobuf:"bytes,6,opt,name=LastViewed,proto3" json:"LastViewed,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=Title,proto3" json:"Title,omitempty"`
+	Content     string                 `protobuf:"bytes,2,opt,name=Content,proto3" json:"Content,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Author      string                 `protobuf:"bytes,4,opt,name=Author,proto3" json:"Author,omitempty"`
+	ViewCount   int64                  `protobuf:"varint,5,opt,name=ViewCount,proto3" json:"ViewCount,omitempty"`
+	LastViewed  string                 `protobuf:"bytes,6,opt,name=LastViewed,proto3" json:"LastViewed,omitempty"`
+	Id          string                 `protobuf:"bytes,7,opt,name=Id,proto3" json:"Id,omitempty"`
+	Attachments []*Attachment          `protobuf:"bytes,8,rep,name=Attachments,proto3" json:"Attachments,omitempty"`
+	// RFC 3339 timestamp, e.g. 2025-06-04T09:00:00Z
+	PublishAt string     `protobuf:"bytes,9,opt,name=PublishAt,proto3" json:"PublishAt,omitempty"`
+	Status    PostStatus `protobuf:"varint,10,opt,name=Status,proto3,enum=grpc_tutorial.PostStatus" json:"Status,omitempty"`
+	// Position of the latest change to this post in the server's change log, used by SyncChanges.
+	Sequence int64 `protobuf:"varint,11,opt,name=Sequence,proto3" json:"Sequence,omitempty"`
+	// Number of different viewers among the ViewCount views.
+	UniqueViewers int64 `protobuf:"varint,12,opt,name=UniqueViewers,proto3" json:"UniqueViewers,omitempty"`
+	// Lowercase words made of letters, digits and dashes, e.g. "grpc" or "getting-started".
+	Tags []string `protobuf:"bytes,13,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	// Set when content moderation let the post through but wants a human to look at it, see moderation.go
+	Flagged    bool   `protobuf:"varint,14,opt,name=Flagged,proto3" json:"Flagged,omitempty"`
+	FlagReason string `protobuf:"bytes,15,opt,name=FlagReason,proto3" json:"FlagReason,omitempty"`
+	// URL-friendly name made from the title on creation, unique among the posts, e.g. "getting-started-with-grpc".
+	Slug string `protobuf:"bytes,16,opt,name=Slug,proto3" json:"Slug,omitempty"`
+	// RFC 3339 time of the last change made by CreatePost, UpdatePost or RestoreRevision, empty for the posts written before it existed.
+	UpdatedAt string `protobuf:"bytes,17,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	// Set by PinPost, see pins.go
+	Pinned bool `protobuf:"varint,18,opt,name=Pinned,proto3" json:"Pinned,omitempty"`
+	// Where a pinned post comes among the pinned ones, 1 is the first. 0 when the post isn't pinned.
+	PinPosition int32 `protobuf:"varint,19,opt,name=PinPosition,proto3" json:"PinPosition,omitempty"`
+	// Words of the content and the minutes it takes to read them, computed by the server whenever the content changes. See internal/store/reading.go
+	WordCount      int32 `protobuf:"varint,20,opt,name=WordCount,proto3" json:"WordCount,omitempty"`
+	ReadingMinutes int32 `protobuf:"varint,21,opt,name=ReadingMinutes,proto3" json:"ReadingMinutes,omitempty"`
+	// IDs of the other posts the content mentions, found by the server whenever the content changes. See internal/store/links.go
+	LinkedPostIds []string `protobuf:"bytes,22,rep,name=LinkedPostIds,proto3" json:"LinkedPostIds,omitempty"`
+	// The identity of the caller that created the post (see auth.go), who gets its notifications. Empty for anonymous callers and the posts written before it existed.
+	CreatedBy     string `protobuf:"bytes,23,opt,name=CreatedBy,proto3" json:"CreatedBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Post) Reset() {
+	*x = Post{}
+	mi := &file_blog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Post) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Post) ProtoMessage() {}
+
+func (x *Post) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Post.ProtoReflect.Descriptor instead.
+func (*Post) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Post) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Post) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Post) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Post) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Post) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *Post) GetLastViewed() string {
+	if x != nil {
+		return x.LastViewed
+	}
+	return ""
+}
+
+func (x *Post) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Post) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *Post) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+func (x *Post) GetStatus() PostStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PostStatus_PUBLISHED
+}
+
+func (x *Post) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Post) GetUniqueViewers() int64 {
+	if x != nil {
+		return x.UniqueViewers
+	}
+	return 0
+}
+
+func (x *Post) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Post) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
+
+func (x *Post) GetFlagReason() string {
+	if x != nil {
+		return x.FlagReason
+	}
+	return ""
+}
+
+func (x *Post) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Post) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Post) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *Post) GetPinPosition() int32 {
+	if x != nil {
+		return x.PinPosition
+	}
+	return 0
+}
+
+func (x *Post) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *Post) GetReadingMinutes() int32 {
+	if x != nil {
+		return x.ReadingMinutes
+	}
+	return 0
+}
+
+func (x *Post) GetLinkedPostIds() []string {
+	if x != nil {
+		return x.LinkedPostIds
+	}
+	return nil
+}
+
+func (x *Post) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+// Attachment only holds the metadata of a file, the bytes themselves are kept by the attachment store.
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=Filename,proto3" json:"Filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_blog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type Posts struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// This means an array of posts.
+	// It's common to nest message definitions within each other. Basically, every time we have nested object within a payload this one should be extracted into it's own message definition.
+	Posts []*Post `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	// Only set by GetPosts when there are posts after this page, see pages.go
+	NextPageToken string `protobuf:"bytes,2,opt,name=NextPageToken,proto3" json:"NextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Posts) Reset() {
+	*x = Posts{}
+	mi := &file_blog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Posts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Posts) ProtoMessage() {}
+
+func (x *Posts) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Posts.ProtoReflect.Descriptor instead.
+func (*Posts) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Posts) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *Posts) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Selects posts by what they have in common, see filter.go. Empty fields don't filter anything.
+type PostFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Posts by any of these authors.
+	Authors []string `protobuf:"bytes,1,rep,name=Authors,proto3" json:"Authors,omitempty"`
+	// YYYY-MM-DD, the posts created on that day or later.
+	Since string `protobuf:"bytes,2,opt,name=Since,proto3" json:"Since,omitempty"`
+	// YYYY-MM-DD, the posts created on that day or earlier.
+	Until string `protobuf:"bytes,3,opt,name=Until,proto3" json:"Until,omitempty"`
+	// Posts tagged with every one of these tags.
+	Tags []string `protobuf:"bytes,4,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	// The posts taking at least, or at most, this many minutes to read.
+	MinReadingMinutes int32 `protobuf:"varint,5,opt,name=MinReadingMinutes,proto3" json:"MinReadingMinutes,omitempty"`
+	MaxReadingMinutes int32 `protobuf:"varint,6,opt,name=MaxReadingMinutes,proto3" json:"MaxReadingMinutes,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PostFilter) Reset() {
+	*x = PostFilter{}
+	mi := &file_blog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostFilter) ProtoMessage() {}
+
+func (x *PostFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostFilter.ProtoReflect.Descriptor instead.
+func (*PostFilter) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PostFilter) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *PostFilter) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *PostFilter) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *PostFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PostFilter) GetMinReadingMinutes() int32 {
+	if x != nil {
+		return x.MinReadingMinutes
+	}
+	return 0
+}
+
+func (x *PostFilter) GetMaxReadingMinutes() int32 {
+	if x != nil {
+		return x.MaxReadingMinutes
+	}
+	return 0
+}
+
+type GetPostsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *PostFilter            `protobuf:"bytes,1,opt,name=Filter,proto3" json:"Filter,omitempty"`
+	// The Post fields to return, by name, e.g. paths: ["Id", "Title"]. Empty returns every field.
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=ReadMask,proto3" json:"ReadMask,omitempty"`
+	// Pinned posts come first whatever the order, see pins.go
+	OrderBy PostOrder `protobuf:"varint,3,opt,name=OrderBy,proto3,enum=grpc_tutorial.PostOrder" json:"OrderBy,omitempty"`
+	// How many posts to return at most, 0 returns all of them. See pages.go
+	PageSize int32 `protobuf:"varint,4,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	// The NextPageToken of the previous page, empty for the first one. The other fields must stay the same from page to page.
+	PageToken string `protobuf:"bytes,5,opt,name=PageToken,proto3" json:"PageToken,omitempty"`
+	// Leaves out the posts the caller marked as read, see MarkAsRead. Needs a session or another identity.
+	UnreadOnly    bool `protobuf:"varint,6,opt,name=UnreadOnly,proto3" json:"UnreadOnly,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostsRequest) Reset() {
+	*x = GetPostsRequest{}
+	mi := &file_blog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostsRequest) ProtoMessage() {}
+
+func (x *GetPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostsRequest.ProtoReflect.Descriptor instead.
+func (*GetPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPostsRequest) GetFilter() *PostFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetPostsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *GetPostsRequest) GetOrderBy() PostOrder {
+	if x != nil {
+		return x.OrderBy
+	}
+	return PostOrder_ORDER_CREATED
+}
+
+func (x *GetPostsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetPostsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetPostsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+type CreatePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// required only refuses "", not_blank refuses a title of spaces too.
+	Title     string `protobuf:"bytes,1,opt,name=Title,proto3" json:"Title,omitempty"`
+	Content   string `protobuf:"bytes,2,opt,name=Content,proto3" json:"Content,omitempty"`
+	CreatedAt string `protobuf:"bytes,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Author    string `protobuf:"bytes,4,opt,name=Author,proto3" json:"Author,omitempty"`
+	// Leave empty to publish right away.
+	PublishAt string `protobuf:"bytes,5,opt,name=PublishAt,proto3" json:"PublishAt,omitempty"`
+	// At most 10, the server lowercases them and drops duplicates.
+	Tags []string `protobuf:"bytes,6,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	// Skips the duplicate check of the server (-duplicate-check), for posts that are meant to look like an existing one.
+	AllowDuplicate bool `protobuf:"varint,7,opt,name=AllowDuplicate,proto3" json:"AllowDuplicate,omitempty"`
+	// Saves the post as a draft rather than publishing it, PublishAt must then be empty.
+	Draft         bool `protobuf:"varint,8,opt,name=Draft,proto3" json:"Draft,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePostRequest) Reset() {
+	*x = CreatePostRequest{}
+	mi := &file_blog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePostRequest) ProtoMessage() {}
+
+func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePostRequest.ProtoReflect.Descriptor instead.
+func (*CreatePostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreatePostRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreatePostRequest) GetAllowDuplicate() bool {
+	if x != nil {
+		return x.AllowDuplicate
+	}
+	return false
+}
+
+func (x *CreatePostRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+// Empty fields keep their current value.
+type UpdatePostRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Title     string                 `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Content   string                 `protobuf:"bytes,3,opt,name=Content,proto3" json:"Content,omitempty"`
+	Author    string                 `protobuf:"bytes,4,opt,name=Author,proto3" json:"Author,omitempty"`
+	PublishAt string                 `protobuf:"bytes,5,opt,name=PublishAt,proto3" json:"PublishAt,omitempty"`
+	// Replaces every tag of the post, no tags keeps the current ones.
+	Tags []string `protobuf:"bytes,6,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	// Publishes a draft, at PublishAt when it is set, right away otherwise.
+	Publish       bool `protobuf:"varint,7,opt,name=Publish,proto3" json:"Publish,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePostRequest) Reset() {
+	*x = UpdatePostRequest{}
+	mi := &file_blog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePostRequest) ProtoMessage() {}
+
+func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePostRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdatePostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdatePostRequest) GetPublish() bool {
+	if x != nil {
+		return x.Publish
+	}
+	return false
+}
+
+type SyncChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cursor returned by the previous call, 0 to start from the beginning.
+	Cursor        int64 `protobuf:"varint,1,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncChangesRequest) Reset() {
+	*x = SyncChangesRequest{}
+	mi := &file_blog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncChangesRequest) ProtoMessage() {}
+
+func (x *SyncChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncChangesRequest.ProtoReflect.Descriptor instead.
+func (*SyncChangesRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SyncChangesRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+type SyncChangesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Posts created or changed since the cursor. A post that already exists in the local copy should replace it.
+	Posts []*Post `protobuf:"bytes,1,rep,name=Posts,proto3" json:"Posts,omitempty"`
+	// Cursor to send on the next call.
+	Cursor int64 `protobuf:"varint,2,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	// Posts deleted, archived or scheduled again since the cursor, to be removed from the local copy.
+	DeletedIds    []string `protobuf:"bytes,3,rep,name=DeletedIds,proto3" json:"DeletedIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncChangesResponse) Reset() {
+	*x = SyncChangesResponse{}
+	mi := &file_blog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncChangesResponse) ProtoMessage() {}
+
+func (x *SyncChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncChangesResponse.ProtoReflect.Descriptor instead.
+func (*SyncChangesResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SyncChangesResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *SyncChangesResponse) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *SyncChangesResponse) GetDeletedIds() []string {
+	if x != nil {
+		return x.DeletedIds
+	}
+	return nil
+}
+
+type AttachmentMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=Filename,proto3" json:"Filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentMetadata) Reset() {
+	*x = AttachmentMetadata{}
+	mi := &file_blog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentMetadata) ProtoMessage() {}
+
+func (x *AttachmentMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentMetadata.ProtoReflect.Descriptor instead.
+func (*AttachmentMetadata) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AttachmentMetadata) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A oneof means only one of the fields can be set at a time. The first message of the stream carries the metadata and every following one a chunk of the file.
+	//
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadAttachmentRequest_Metadata
+	//	*UploadAttachmentRequest_Chunk
+	Data          isUploadAttachmentRequest_Data `protobuf_oneof:"Data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_blog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UploadAttachmentRequest) GetData() isUploadAttachmentRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetMetadata() *AttachmentMetadata {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Data interface {
+	isUploadAttachmentRequest_Data()
+}
+
+type UploadAttachmentRequest_Metadata struct {
+	Metadata *AttachmentMetadata `protobuf:"bytes,1,opt,name=Metadata,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=Chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Metadata) isUploadAttachmentRequest_Data() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Data() {}
+
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	AttachmentId  string                 `protobuf:"bytes,2,opt,name=AttachmentId,proto3" json:"AttachmentId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_blog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DownloadAttachmentRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *DownloadAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same idea as the upload: the first message describes the attachment and the rest carry its bytes.
+	//
+	// Types that are valid to be assigned to Data:
+	//
+	//	*DownloadAttachmentResponse_Metadata
+	//	*DownloadAttachmentResponse_Chunk
+	Data          isDownloadAttachmentResponse_Data `protobuf_oneof:"Data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_blog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DownloadAttachmentResponse) GetData() isDownloadAttachmentResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetMetadata() *Attachment {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Data interface {
+	isDownloadAttachmentResponse_Data()
+}
+
+type DownloadAttachmentResponse_Metadata struct {
+	Metadata *Attachment `protobuf:"bytes,1,opt,name=Metadata,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=Chunk,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Metadata) isDownloadAttachmentResponse_Data() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Data() {}
+
+type RenderPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderPostRequest) Reset() {
+	*x = RenderPostRequest{}
+	mi := &file_blog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPostRequest) ProtoMessage() {}
+
+func (x *RenderPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPostRequest.ProtoReflect.Descriptor instead.
+func (*RenderPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RenderPostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RenderedPost struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Html  string                 `protobuf:"bytes,3,opt,name=Html,proto3" json:"Html,omitempty"`
+	// Publishing date written out in the language of the accept-language metadata, e.g. "June 4, 2025".
+	PublishedOn   string `protobuf:"bytes,4,opt,name=PublishedOn,proto3" json:"PublishedOn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderedPost) Reset() {
+	*x = RenderedPost{}
+	mi := &file_blog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderedPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderedPost) ProtoMessage() {}
+
+func (x *RenderedPost) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderedPost.ProtoReflect.Descriptor instead.
+func (*RenderedPost) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RenderedPost) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RenderedPost) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RenderedPost) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *RenderedPost) GetPublishedOn() string {
+	if x != nil {
+		return x.PublishedOn
+	}
+	return ""
+}
+
+// Every field narrows down the events sent, an empty list lets everything through.
+type WatchPostsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Types   []PostEventType        `protobuf:"varint,1,rep,packed,name=Types,proto3,enum=grpc_tutorial.PostEventType" json:"Types,omitempty"`
+	Authors []string               `protobuf:"bytes,2,rep,name=Authors,proto3" json:"Authors,omitempty"`
+	// The Cursor of the last event received, to resume a watch without missing the changes made in between, see watch.go. 0 only watches the changes from now on.
+	Cursor        int64 `protobuf:"varint,3,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPostsRequest) Reset() {
+	*x = WatchPostsRequest{}
+	mi := &file_blog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPostsRequest) ProtoMessage() {}
+
+func (x *WatchPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPostsRequest.ProtoReflect.Descriptor instead.
+func (*WatchPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchPostsRequest) GetTypes() []PostEventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *WatchPostsRequest) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *WatchPostsRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+type PostEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  PostEventType          `protobuf:"varint,1,opt,name=Type,proto3,enum=grpc_tutorial.PostEventType" json:"Type,omitempty"`
+	// The post after the change, or the last version of it for POST_DELETED.
+	Post *Post `protobuf:"bytes,2,opt,name=Post,proto3" json:"Post,omitempty"`
+	// Where the change is in the change log of SyncChanges, the Cursor to resume a watch from.
+	Cursor        int64 `protobuf:"varint,3,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostEvent) Reset() {
+	*x = PostEvent{}
+	mi := &file_blog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostEvent) ProtoMessage() {}
+
+func (x *PostEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostEvent.ProtoReflect.Descriptor instead.
+func (*PostEvent) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PostEvent) GetType() PostEventType {
+	if x != nil {
+		return x.Type
+	}
+	return PostEventType_POST_CREATED
+}
+
+func (x *PostEvent) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *PostEvent) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+type Revision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Revisions of a post are numbered starting at 1.
+	Number  int64  `protobuf:"varint,1,opt,name=Number,proto3" json:"Number,omitempty"`
+	Title   string `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Content string `protobuf:"bytes,3,opt,name=Content,proto3" json:"Content,omitempty"`
+	Author  string `protobuf:"bytes,4,opt,name=Author,proto3" json:"Author,omitempty"`
+	// When this version was replaced by a newer one.
+	CreatedAt     string   `protobuf:"bytes,5,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Tags          []string `protobuf:"bytes,6,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	mi := &file_blog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Revision) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Revision) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Revision) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Revision) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Revision) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Revision) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type Revisions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*Revision            `protobuf:"bytes,1,rep,name=Revisions,proto3" json:"Revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Revisions) Reset() {
+	*x = Revisions{}
+	mi := &file_blog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revisions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revisions) ProtoMessage() {}
+
+func (x *Revisions) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revisions.ProtoReflect.Descriptor instead.
+func (*Revisions) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Revisions) GetRevisions() []*Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type ListRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsRequest) Reset() {
+	*x = ListRevisionsRequest{}
+	mi := &file_blog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsRequest) ProtoMessage() {}
+
+func (x *ListRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListRevisionsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+type RestoreRevisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	Number        int64                  `protobuf:"varint,2,opt,name=Number,proto3" json:"Number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreRevisionRequest) Reset() {
+	*x = RestoreRevisionRequest{}
+	mi := &file_blog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRevisionRequest) ProtoMessage() {}
+
+func (x *RestoreRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreRevisionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestoreRevisionRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *RestoreRevisionRequest) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type DeletePostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePostRequest) Reset() {
+	*x = DeletePostRequest{}
+	mi := &file_blog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePostRequest) ProtoMessage() {}
+
+func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
+func (*DeletePostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeletePostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeletePostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePostResponse) Reset() {
+	*x = DeletePostResponse{}
+	mi := &file_blog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePostResponse) ProtoMessage() {}
+
+func (x *DeletePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePostResponse.ProtoReflect.Descriptor instead.
+func (*DeletePostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{22}
+}
+
+type AuditEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC 3339 timestamp of the call.
+	Time string `protobuf:"bytes,1,opt,name=Time,proto3" json:"Time,omitempty"`
+	// The authenticated identity of the caller, "anonymous" when there is none.
+	Identity string `protobuf:"bytes,2,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	// Network address of the caller.
+	Peer string `protobuf:"bytes,3,opt,name=Peer,proto3" json:"Peer,omitempty"`
+	// Full gRPC method name, e.g. /grpc_tutorial.Blog/CreatePost
+	Method string `protobuf:"bytes,4,opt,name=Method,proto3" json:"Method,omitempty"`
+	PostId string `protobuf:"bytes,5,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// The gRPC status code the call finished with.
+	Code string `protobuf:"bytes,6,opt,name=Code,proto3" json:"Code,omitempty"`
+	// The request message as JSON.
+	Request       string `protobuf:"bytes,7,opt,name=Request,proto3" json:"Request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_blog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AuditEntry) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetRequest() string {
+	if x != nil {
+		return x.Request
+	}
+	return ""
+}
+
+type AuditEntries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntries) Reset() {
+	*x = AuditEntries{}
+	mi := &file_blog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntries) ProtoMessage() {}
+
+func (x *AuditEntries) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntries.ProtoReflect.Descriptor instead.
+func (*AuditEntries) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AuditEntries) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Every field is optional and narrows down the results.
+type QueryAuditLogRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PostId   string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	Identity string                 `protobuf:"bytes,2,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	// RFC 3339 timestamps.
+	Since string `protobuf:"bytes,3,opt,name=Since,proto3" json:"Since,omitempty"`
+	Until string `protobuf:"bytes,4,opt,name=Until,proto3" json:"Until,omitempty"`
+	// Only return that many of the most recent entries, 0 means 100.
+	Limit         int32 `protobuf:"varint,5,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditLogRequest) Reset() {
+	*x = QueryAuditLogRequest{}
+	mi := &file_blog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogRequest) ProtoMessage() {}
+
+func (x *QueryAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QueryAuditLogRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type StreamPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cursor of the last post received, empty starts from the beginning.
+	Cursor        string `protobuf:"bytes,1,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPostsRequest) Reset() {
+	*x = StreamPostsRequest{}
+	mi := &file_blog_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPostsRequest) ProtoMessage() {}
+
+func (x *StreamPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPostsRequest.ProtoReflect.Descriptor instead.
+func (*StreamPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StreamPostsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type StreamPostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Post  *Post                  `protobuf:"bytes,1,opt,name=Post,proto3" json:"Post,omitempty"`
+	// Opaque token, pass it as StreamPostsRequest.Cursor to resume after this post.
+	Cursor        string `protobuf:"bytes,2,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPostsResponse) Reset() {
+	*x = StreamPostsResponse{}
+	mi := &file_blog_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPostsResponse) ProtoMessage() {}
+
+func (x *StreamPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPostsResponse.ProtoReflect.Descriptor instead.
+func (*StreamPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StreamPostsResponse) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *StreamPostsResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type Webhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Url   string                 `protobuf:"bytes,2,opt,name=Url,proto3" json:"Url,omitempty"`
+	// Key of the HMAC-SHA256 signature sent with every delivery. Only returned by RegisterWebhook.
+	Secret string `protobuf:"bytes,3,opt,name=Secret,proto3" json:"Secret,omitempty"`
+	// Events delivered to the webhook, empty means all of them.
+	Types         []PostEventType `protobuf:"varint,4,rep,packed,name=Types,proto3,enum=grpc_tutorial.PostEventType" json:"Types,omitempty"`
+	CreatedAt     string          `protobuf:"bytes,5,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_blog_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetTypes() []PostEventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Webhook) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type Webhooks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=Webhooks,proto3" json:"Webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhooks) Reset() {
+	*x = Webhooks{}
+	mi := &file_blog_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhooks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhooks) ProtoMessage() {}
+
+func (x *Webhooks) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhooks.ProtoReflect.Descriptor instead.
+func (*Webhooks) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Webhooks) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type RegisterWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An http or https URL.
+	Url string `protobuf:"bytes,1,opt,name=Url,proto3" json:"Url,omitempty"`
+	// Leave empty to let the server generate one.
+	Secret        string          `protobuf:"bytes,2,opt,name=Secret,proto3" json:"Secret,omitempty"`
+	Types         []PostEventType `protobuf:"varint,3,rep,packed,name=Types,proto3,enum=grpc_tutorial.PostEventType" json:"Types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterWebhookRequest) Reset() {
+	*x = RegisterWebhookRequest{}
+	mi := &file_blog_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWebhookRequest) ProtoMessage() {}
+
+func (x *RegisterWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RegisterWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RegisterWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *RegisterWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *RegisterWebhookRequest) GetTypes() []PostEventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type UnregisterWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterWebhookRequest) Reset() {
+	*x = UnregisterWebhookRequest{}
+	mi := &file_blog_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterWebhookRequest) ProtoMessage() {}
+
+func (x *UnregisterWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterWebhookRequest.ProtoReflect.Descriptor instead.
+func (*UnregisterWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UnregisterWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UnregisterWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterWebhookResponse) Reset() {
+	*x = UnregisterWebhookResponse{}
+	mi := &file_blog_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterWebhookResponse) ProtoMessage() {}
+
+func (x *UnregisterWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterWebhookResponse.ProtoReflect.Descriptor instead.
+func (*UnregisterWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{32}
+}
+
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_blog_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{33}
+}
+
+type PostTemplate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// Unique among the templates, the RPCs taking a template accept its name in place of its ID, e.g. "weekly-digest".
+	Name string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Title and Content of the posts, with placeholders like {{author}}.
+	Title     string   `protobuf:"bytes,3,opt,name=Title,proto3" json:"Title,omitempty"`
+	Content   string   `protobuf:"bytes,4,opt,name=Content,proto3" json:"Content,omitempty"`
+	Tags      []string `protobuf:"bytes,5,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	CreatedAt string   `protobuf:"bytes,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt string   `protobuf:"bytes,7,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	// The placeholders of Title and Content, computed by the server. Every one but author and date needs a value in CreatePostFromTemplateRequest.Values.
+	Placeholders  []string `protobuf:"bytes,8,rep,name=Placeholders,proto3" json:"Placeholders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostTemplate) Reset() {
+	*x = PostTemplate{}
+	mi := &file_blog_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostTemplate) ProtoMessage() {}
+
+func (x *PostTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostTemplate.ProtoReflect.Descriptor instead.
+func (*PostTemplate) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PostTemplate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PostTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PostTemplate) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PostTemplate) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PostTemplate) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PostTemplate) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *PostTemplate) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *PostTemplate) GetPlaceholders() []string {
+	if x != nil {
+		return x.Placeholders
+	}
+	return nil
+}
+
+type PostTemplates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*PostTemplate        `protobuf:"bytes,1,rep,name=Templates,proto3" json:"Templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostTemplates) Reset() {
+	*x = PostTemplates{}
+	mi := &file_blog_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostTemplates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostTemplates) ProtoMessage() {}
+
+func (x *PostTemplates) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostTemplates.ProtoReflect.Descriptor instead.
+func (*PostTemplates) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PostTemplates) GetTemplates() []*PostTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type CreateTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=Content,proto3" json:"Content,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTemplateRequest) Reset() {
+	*x = CreateTemplateRequest{}
+	mi := &file_blog_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateRequest) ProtoMessage() {}
+
+func (x *CreateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTemplateRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTemplateRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreateTemplateRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type GetTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID or name of the template.
+	Id            string `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateRequest) Reset() {
+	*x = GetTemplateRequest{}
+	mi := &file_blog_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateRequest) ProtoMessage() {}
+
+func (x *GetTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_blog_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{38}
+}
+
+// Empty fields keep their current value.
+type UpdateTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID or name of the template.
+	Id      string `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Title   string `protobuf:"bytes,3,opt,name=Title,proto3" json:"Title,omitempty"`
+	Content string `protobuf:"bytes,4,opt,name=Content,proto3" json:"Content,omitempty"`
+	// Replaces every tag of the template, no tags keeps the current ones.
+	Tags          []string `protobuf:"bytes,5,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTemplateRequest) Reset() {
+	*x = UpdateTemplateRequest{}
+	mi := &file_blog_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTemplateRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTemplateRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdateTemplateRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type DeleteTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID or name of the template.
+	Id            string `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateRequest) Reset() {
+	*x = DeleteTemplateRequest{}
+	mi := &file_blog_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateResponse) Reset() {
+	*x = DeleteTemplateResponse{}
+	mi := &file_blog_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{41}
+}
+
+type CreatePostFromTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID or name of the template.
+	Template string `protobuf:"bytes,1,opt,name=Template,proto3" json:"Template,omitempty"`
+	// Author of the post, and the value of {{author}}.
+	Author string `protobuf:"bytes,2,opt,name=Author,proto3" json:"Author,omitempty"`
+	// Values of the placeholders other than author and date, by name.
+	Values map[string]string `protobuf:"bytes,3,rep,name=Values,proto3" json:"Values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Like in CreatePostRequest. {{date}} is the day of PublishAt when it is set, today otherwise.
+	PublishAt     string `protobuf:"bytes,4,opt,name=PublishAt,proto3" json:"PublishAt,omitempty"`
+	Draft         bool   `protobuf:"varint,5,opt,name=Draft,proto3" json:"Draft,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePostFromTemplateRequest) Reset() {
+	*x = CreatePostFromTemplateRequest{}
+	mi := &file_blog_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePostFromTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePostFromTemplateRequest) ProtoMessage() {}
+
+func (x *CreatePostFromTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePostFromTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreatePostFromTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreatePostFromTemplateRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *CreatePostFromTemplateRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *CreatePostFromTemplateRequest) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *CreatePostFromTemplateRequest) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+func (x *CreatePostFromTemplateRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+type Series struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
+	// The posts of the series in their order, whatever their status.
+	PostIds       []string `protobuf:"bytes,4,rep,name=PostIds,proto3" json:"PostIds,omitempty"`
+	CreatedAt     string   `protobuf:"bytes,5,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt     string   `protobuf:"bytes,6,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Series) Reset() {
+	*x = Series{}
+	mi := &file_blog_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Series) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Series) ProtoMessage() {}
+
+func (x *Series) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Series.ProtoReflect.Descriptor instead.
+func (*Series) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Series) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Series) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Series) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Series) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+func (x *Series) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Series) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type SeriesList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        []*Series              `protobuf:"bytes,1,rep,name=Series,proto3" json:"Series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesList) Reset() {
+	*x = SeriesList{}
+	mi := &file_blog_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesList) ProtoMessage() {}
+
+func (x *SeriesList) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesList.ProtoReflect.Descriptor instead.
+func (*SeriesList) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SeriesList) GetSeries() []*Series {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+type CreateSeriesRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=Title,proto3" json:"Title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=Description,proto3" json:"Description,omitempty"`
+	// The first posts of the series, in their order. More can be added with AddToSeries.
+	PostIds       []string `protobuf:"bytes,3,rep,name=PostIds,proto3" json:"PostIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSeriesRequest) Reset() {
+	*x = CreateSeriesRequest{}
+	mi := &file_blog_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSeriesRequest) ProtoMessage() {}
+
+func (x *CreateSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSeriesRequest.ProtoReflect.Descriptor instead.
+func (*CreateSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CreateSeriesRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateSeriesRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateSeriesRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+type ListSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeriesRequest) Reset() {
+	*x = ListSeriesRequest{}
+	mi := &file_blog_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeriesRequest) ProtoMessage() {}
+
+func (x *ListSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeriesRequest.ProtoReflect.Descriptor instead.
+func (*ListSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{46}
+}
+
+type GetSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeriesRequest) Reset() {
+	*x = GetSeriesRequest{}
+	mi := &file_blog_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeriesRequest) ProtoMessage() {}
+
+func (x *GetSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeriesRequest.ProtoReflect.Descriptor instead.
+func (*GetSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetSeriesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SeriesPosts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        *Series                `protobuf:"bytes,1,opt,name=Series,proto3" json:"Series,omitempty"`
+	Posts         []*SeriesPost          `protobuf:"bytes,2,rep,name=Posts,proto3" json:"Posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesPosts) Reset() {
+	*x = SeriesPosts{}
+	mi := &file_blog_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesPosts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesPosts) ProtoMessage() {}
+
+func (x *SeriesPosts) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesPosts.ProtoReflect.Descriptor instead.
+func (*SeriesPosts) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SeriesPosts) GetSeries() *Series {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *SeriesPosts) GetPosts() []*SeriesPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+type SeriesPost struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Post  *Post                  `protobuf:"bytes,1,opt,name=Post,proto3" json:"Post,omitempty"`
+	// Where the post comes among the published posts of the series, 1 is the first.
+	Position int32 `protobuf:"varint,2,opt,name=Position,proto3" json:"Position,omitempty"`
+	// The published posts before and after this one in the series, empty for the first and the last.
+	PreviousId    string `protobuf:"bytes,3,opt,name=PreviousId,proto3" json:"PreviousId,omitempty"`
+	NextId        string `protobuf:"bytes,4,opt,name=NextId,proto3" json:"NextId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesPost) Reset() {
+	*x = SeriesPost{}
+	mi := &file_blog_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesPost) ProtoMessage() {}
+
+func (x *SeriesPost) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesPost.ProtoReflect.Descriptor instead.
+func (*SeriesPost) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SeriesPost) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *SeriesPost) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *SeriesPost) GetPreviousId() string {
+	if x != nil {
+		return x.PreviousId
+	}
+	return ""
+}
+
+func (x *SeriesPost) GetNextId() string {
+	if x != nil {
+		return x.NextId
+	}
+	return ""
+}
+
+type AddToSeriesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SeriesId string                 `protobuf:"bytes,1,opt,name=SeriesId,proto3" json:"SeriesId,omitempty"`
+	PostId   string                 `protobuf:"bytes,2,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// Where the post goes in the series, 1 is the first. 0, or past the last one, puts it last.
+	Position      int32 `protobuf:"varint,3,opt,name=Position,proto3" json:"Position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddToSeriesRequest) Reset() {
+	*x = AddToSeriesRequest{}
+	mi := &file_blog_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToSeriesRequest) ProtoMessage() {}
+
+func (x *AddToSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToSeriesRequest.ProtoReflect.Descriptor instead.
+func (*AddToSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AddToSeriesRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *AddToSeriesRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *AddToSeriesRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type RemoveFromSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeriesId      string                 `protobuf:"bytes,1,opt,name=SeriesId,proto3" json:"SeriesId,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFromSeriesRequest) Reset() {
+	*x = RemoveFromSeriesRequest{}
+	mi := &file_blog_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFromSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFromSeriesRequest) ProtoMessage() {}
+
+func (x *RemoveFromSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFromSeriesRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFromSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RemoveFromSeriesRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *RemoveFromSeriesRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+type ReorderSeriesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SeriesId string                 `protobuf:"bytes,1,opt,name=SeriesId,proto3" json:"SeriesId,omitempty"`
+	// Every post of the series once, in the new order.
+	PostIds       []string `protobuf:"bytes,2,rep,name=PostIds,proto3" json:"PostIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderSeriesRequest) Reset() {
+	*x = ReorderSeriesRequest{}
+	mi := &file_blog_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderSeriesRequest) ProtoMessage() {}
+
+func (x *ReorderSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderSeriesRequest.ProtoReflect.Descriptor instead.
+func (*ReorderSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ReorderSeriesRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *ReorderSeriesRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+type DeleteSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSeriesRequest) Reset() {
+	*x = DeleteSeriesRequest{}
+	mi := &file_blog_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSeriesRequest) ProtoMessage() {}
+
+func (x *DeleteSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSeriesRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DeleteSeriesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteSeriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSeriesResponse) Reset() {
+	*x = DeleteSeriesResponse{}
+	mi := &file_blog_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSeriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSeriesResponse) ProtoMessage() {}
+
+func (x *DeleteSeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSeriesResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSeriesResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{54}
+}
+
+type SubscribeByEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=Email,proto3" json:"Email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeByEmailRequest) Reset() {
+	*x = SubscribeByEmailRequest{}
+	mi := &file_blog_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeByEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeByEmailRequest) ProtoMessage() {}
+
+func (x *SubscribeByEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeByEmailRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeByEmailRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SubscribeByEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type SubscribeByEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeByEmailResponse) Reset() {
+	*x = SubscribeByEmailResponse{}
+	mi := &file_blog_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeByEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeByEmailResponse) ProtoMessage() {}
+
+func (x *SubscribeByEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeByEmailResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeByEmailResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{56}
+}
+
+type UnsubscribeByEmailRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Token found at the bottom of every email.
+	Token         string `protobuf:"bytes,1,opt,name=Token,proto3" json:"Token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeByEmailRequest) Reset() {
+	*x = UnsubscribeByEmailRequest{}
+	mi := &file_blog_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeByEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeByEmailRequest) ProtoMessage() {}
+
+func (x *UnsubscribeByEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeByEmailRequest.ProtoReflect.Descriptor instead.
+func (*UnsubscribeByEmailRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *UnsubscribeByEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type UnsubscribeByEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeByEmailResponse) Reset() {
+	*x = UnsubscribeByEmailResponse{}
+	mi := &file_blog_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeByEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeByEmailResponse) ProtoMessage() {}
+
+func (x *UnsubscribeByEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeByEmailResponse.ProtoReflect.Descriptor instead.
+func (*UnsubscribeByEmailResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{58}
+}
+
+type GetAttachmentURLRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	PostId       string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	AttachmentId string                 `protobuf:"bytes,2,opt,name=AttachmentId,proto3" json:"AttachmentId,omitempty"`
+	// How long the URL stays valid, 0 means 15 minutes. At most 7 days.
+	ExpiresInSeconds int64 `protobuf:"varint,3,opt,name=ExpiresInSeconds,proto3" json:"ExpiresInSeconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetAttachmentURLRequest) Reset() {
+	*x = GetAttachmentURLRequest{}
+	mi := &file_blog_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentURLRequest) ProtoMessage() {}
+
+func (x *GetAttachmentURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentURLRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentURLRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetAttachmentURLRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetAttachmentURLRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *GetAttachmentURLRequest) GetExpiresInSeconds() int64 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
+type AttachmentURL struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=Url,proto3" json:"Url,omitempty"`
+	// RFC 3339 timestamp after which the URL stops working.
+	ExpiresAt     string `protobuf:"bytes,2,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentURL) Reset() {
+	*x = AttachmentURL{}
+	mi := &file_blog_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentURL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentURL) ProtoMessage() {}
+
+func (x *AttachmentURL) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentURL.ProtoReflect.Descriptor instead.
+func (*AttachmentURL) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *AttachmentURL) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AttachmentURL) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type SetDebugLoggingRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=Enabled,proto3" json:"Enabled,omitempty"`
+	// Names of the fields (or metadata keys) whose values are hidden from the logs, e.g. Email or authorization. Empty keeps the current list.
+	Redact        []string `protobuf:"bytes,2,rep,name=Redact,proto3" json:"Redact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDebugLoggingRequest) Reset() {
+	*x = SetDebugLoggingRequest{}
+	mi := &file_blog_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDebugLoggingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDebugLoggingRequest) ProtoMessage() {}
+
+func (x *SetDebugLoggingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDebugLoggingRequest.ProtoReflect.Descriptor instead.
+func (*SetDebugLoggingRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SetDebugLoggingRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetDebugLoggingRequest) GetRedact() []string {
+	if x != nil {
+		return x.Redact
+	}
+	return nil
+}
+
+type GetDebugLoggingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDebugLoggingRequest) Reset() {
+	*x = GetDebugLoggingRequest{}
+	mi := &file_blog_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDebugLoggingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDebugLoggingRequest) ProtoMessage() {}
+
+func (x *GetDebugLoggingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDebugLoggingRequest.ProtoReflect.Descriptor instead.
+func (*GetDebugLoggingRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{62}
+}
+
+type DebugLogging struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=Enabled,proto3" json:"Enabled,omitempty"`
+	Redact        []string               `protobuf:"bytes,2,rep,name=Redact,proto3" json:"Redact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugLogging) Reset() {
+	*x = DebugLogging{}
+	mi := &file_blog_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugLogging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugLogging) ProtoMessage() {}
+
+func (x *DebugLogging) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugLogging.ProtoReflect.Descriptor instead.
+func (*DebugLogging) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DebugLogging) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *DebugLogging) GetRedact() []string {
+	if x != nil {
+		return x.Redact
+	}
+	return nil
+}
+
+type SetMaintenanceRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=Enabled,proto3" json:"Enabled,omitempty"`
+	// Shown to the clients, e.g. "upgrading the database".
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	// When clients are told to try again, 30 seconds when 0.
+	RetryAfterSeconds int32 `protobuf:"varint,3,opt,name=RetryAfterSeconds,proto3" json:"RetryAfterSeconds,omitempty"`
+	// Only answer once the calls that were running have finished, or the deadline of this call is reached.
+	Wait          bool `protobuf:"varint,4,opt,name=Wait,proto3" json:"Wait,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceRequest) Reset() {
+	*x = SetMaintenanceRequest{}
+	mi := &file_blog_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceRequest) ProtoMessage() {}
+
+func (x *SetMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *SetMaintenanceRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetMaintenanceRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SetMaintenanceRequest) GetRetryAfterSeconds() int32 {
+	if x != nil {
+		return x.RetryAfterSeconds
+	}
+	return 0
+}
+
+func (x *SetMaintenanceRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
+type GetMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMaintenanceRequest) Reset() {
+	*x = GetMaintenanceRequest{}
+	mi := &file_blog_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaintenanceRequest) ProtoMessage() {}
+
+func (x *GetMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*GetMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{65}
+}
+
+type Maintenance struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Enabled           bool                   `protobuf:"varint,1,opt,name=Enabled,proto3" json:"Enabled,omitempty"`
+	Reason            string                 `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	RetryAfterSeconds int32                  `protobuf:"varint,3,opt,name=RetryAfterSeconds,proto3" json:"RetryAfterSeconds,omitempty"`
+	// RFC 3339 time maintenance started at, empty when it is off.
+	Since string `protobuf:"bytes,4,opt,name=Since,proto3" json:"Since,omitempty"`
+	// Calls still running, besides the Admin ones. 0 once the server is drained.
+	InFlight      int32 `protobuf:"varint,5,opt,name=InFlight,proto3" json:"InFlight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Maintenance) Reset() {
+	*x = Maintenance{}
+	mi := &file_blog_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Maintenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Maintenance) ProtoMessage() {}
+
+func (x *Maintenance) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Maintenance.ProtoReflect.Descriptor instead.
+func (*Maintenance) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *Maintenance) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Maintenance) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Maintenance) GetRetryAfterSeconds() int32 {
+	if x != nil {
+		return x.RetryAfterSeconds
+	}
+	return 0
+}
+
+func (x *Maintenance) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *Maintenance) GetInFlight() int32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+type ReloadConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigRequest) Reset() {
+	*x = ReloadConfigRequest{}
+	mi := &file_blog_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigRequest) ProtoMessage() {}
+
+func (x *ReloadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigRequest.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{67}
+}
+
+type ConfigChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the setting, the same as its flag, e.g. mirror-rps.
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Old  string `protobuf:"bytes,2,opt,name=Old,proto3" json:"Old,omitempty"`
+	New  string `protobuf:"bytes,3,opt,name=New,proto3" json:"New,omitempty"`
+	// Why the change wasn't applied, for the changes in RequiresRestart.
+	Note          string `protobuf:"bytes,4,opt,name=Note,proto3" json:"Note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigChange) Reset() {
+	*x = ConfigChange{}
+	mi := &file_blog_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigChange) ProtoMessage() {}
+
+func (x *ConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigChange.ProtoReflect.Descriptor instead.
+func (*ConfigChange) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ConfigChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ConfigReload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The file that was read.
+	Path string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	// The settings that changed and were applied.
+	Applied []*ConfigChange `protobuf:"bytes,2,rep,name=Applied,proto3" json:"Applied,omitempty"`
+	// The settings that changed but only take effect on the next start.
+	RequiresRestart []*ConfigChange `protobuf:"bytes,3,rep,name=RequiresRestart,proto3" json:"RequiresRestart,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConfigReload) Reset() {
+	*x = ConfigReload{}
+	mi := &file_blog_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigReload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigReload) ProtoMessage() {}
+
+func (x *ConfigReload) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigReload.ProtoReflect.Descriptor instead.
+func (*ConfigReload) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ConfigReload) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ConfigReload) GetApplied() []*ConfigChange {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+func (x *ConfigReload) GetRequiresRestart() []*ConfigChange {
+	if x != nil {
+		return x.RequiresRestart
+	}
+	return nil
+}
+
+type GetStorageStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStorageStatsRequest) Reset() {
+	*x = GetStorageStatsRequest{}
+	mi := &file_blog_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStorageStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStorageStatsRequest) ProtoMessage() {}
+
+func (x *GetStorageStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStorageStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStorageStatsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{70}
+}
+
+type StorageStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// file, sqlite or postgres
+	Backend string `protobuf:"bytes,1,opt,name=Backend,proto3" json:"Backend,omitempty"`
+	// What the storage takes on disk.
+	StorageBytes int64 `protobuf:"varint,2,opt,name=StorageBytes,proto3" json:"StorageBytes,omitempty"`
+	// Posts, tombstones included, and how many of them have compressed content.
+	Posts           int32 `protobuf:"varint,3,opt,name=Posts,proto3" json:"Posts,omitempty"`
+	CompressedPosts int32 `protobuf:"varint,4,opt,name=CompressedPosts,proto3" json:"CompressedPosts,omitempty"`
+	// Size of the content of every post, and what it takes once compressed.
+	ContentBytes       int64 `protobuf:"varint,5,opt,name=ContentBytes,proto3" json:"ContentBytes,omitempty"`
+	StoredContentBytes int64 `protobuf:"varint,6,opt,name=StoredContentBytes,proto3" json:"StoredContentBytes,omitempty"`
+	// Content of this many bytes or more is compressed, 0 when compression is off.
+	CompressThreshold int64 `protobuf:"varint,7,opt,name=CompressThreshold,proto3" json:"CompressThreshold,omitempty"`
+	// Saves held back by -write-delay, the stats above don't count them until they are written.
+	PendingSaves int32 `protobuf:"varint,8,opt,name=PendingSaves,proto3" json:"PendingSaves,omitempty"`
+	// Posts with encrypted content, and the key new content is encrypted with, empty when encryption is off.
+	EncryptedPosts int32  `protobuf:"varint,9,opt,name=EncryptedPosts,proto3" json:"EncryptedPosts,omitempty"`
+	EncryptionKey  string `protobuf:"bytes,10,opt,name=EncryptionKey,proto3" json:"EncryptionKey,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StorageStats) Reset() {
+	*x = StorageStats{}
+	mi := &file_blog_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageStats) ProtoMessage() {}
+
+func (x *StorageStats) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageStats.ProtoReflect.Descriptor instead.
+func (*StorageStats) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *StorageStats) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *StorageStats) GetStorageBytes() int64 {
+	if x != nil {
+		return x.StorageBytes
+	}
+	return 0
+}
+
+func (x *StorageStats) GetPosts() int32 {
+	if x != nil {
+		return x.Posts
+	}
+	return 0
+}
+
+func (x *StorageStats) GetCompressedPosts() int32 {
+	if x != nil {
+		return x.CompressedPosts
+	}
+	return 0
+}
+
+func (x *StorageStats) GetContentBytes() int64 {
+	if x != nil {
+		return x.ContentBytes
+	}
+	return 0
+}
+
+func (x *StorageStats) GetStoredContentBytes() int64 {
+	if x != nil {
+		return x.StoredContentBytes
+	}
+	return 0
+}
+
+func (x *StorageStats) GetCompressThreshold() int64 {
+	if x != nil {
+		return x.CompressThreshold
+	}
+	return 0
+}
+
+func (x *StorageStats) GetPendingSaves() int32 {
+	if x != nil {
+		return x.PendingSaves
+	}
+	return 0
+}
+
+func (x *StorageStats) GetEncryptedPosts() int32 {
+	if x != nil {
+		return x.EncryptedPosts
+	}
+	return 0
+}
+
+func (x *StorageStats) GetEncryptionKey() string {
+	if x != nil {
+		return x.EncryptionKey
+	}
+	return ""
+}
+
+type FlushStorageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlushStorageRequest) Reset() {
+	*x = FlushStorageRequest{}
+	mi := &file_blog_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlushStorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlushStorageRequest) ProtoMessage() {}
+
+func (x *FlushStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlushStorageRequest.ProtoReflect.Descriptor instead.
+func (*FlushStorageRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{72}
+}
+
+type StorageFlush struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How many saves the write was made of, 0 when nothing was pending.
+	Saves         int32 `protobuf:"varint,1,opt,name=Saves,proto3" json:"Saves,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageFlush) Reset() {
+	*x = StorageFlush{}
+	mi := &file_blog_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageFlush) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageFlush) ProtoMessage() {}
+
+func (x *StorageFlush) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageFlush.ProtoReflect.Descriptor instead.
+func (*StorageFlush) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *StorageFlush) GetSaves() int32 {
+	if x != nil {
+		return x.Saves
+	}
+	return 0
+}
+
+type ReencryptStorageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReencryptStorageRequest) Reset() {
+	*x = ReencryptStorageRequest{}
+	mi := &file_blog_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReencryptStorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReencryptStorageRequest) ProtoMessage() {}
+
+func (x *ReencryptStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReencryptStorageRequest.ProtoReflect.Descriptor instead.
+func (*ReencryptStorageRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{74}
+}
+
+type StorageReencryption struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Posts int32                  `protobuf:"varint,1,opt,name=Posts,proto3" json:"Posts,omitempty"`
+	// The posts that were in clear or encrypted with another key before.
+	Reencrypted int32 `protobuf:"varint,2,opt,name=Reencrypted,proto3" json:"Reencrypted,omitempty"`
+	// The key every post is encrypted with now.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// The revisions of the posts, all saved again with the key.
+	Revisions     int32 `protobuf:"varint,4,opt,name=Revisions,proto3" json:"Revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageReencryption) Reset() {
+	*x = StorageReencryption{}
+	mi := &file_blog_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageReencryption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageReencryption) ProtoMessage() {}
+
+func (x *StorageReencryption) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageReencryption.ProtoReflect.Descriptor instead.
+func (*StorageReencryption) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *StorageReencryption) GetPosts() int32 {
+	if x != nil {
+		return x.Posts
+	}
+	return 0
+}
+
+func (x *StorageReencryption) GetReencrypted() int32 {
+	if x != nil {
+		return x.Reencrypted
+	}
+	return 0
+}
+
+func (x *StorageReencryption) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StorageReencryption) GetRevisions() int32 {
+	if x != nil {
+		return x.Revisions
+	}
+	return 0
+}
+
+type ListScheduledTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledTasksRequest) Reset() {
+	*x = ListScheduledTasksRequest{}
+	mi := &file_blog_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledTasksRequest) ProtoMessage() {}
+
+func (x *ListScheduledTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledTasksRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{76}
+}
+
+type RunScheduledTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// e.g. compact-audit
+	Name          string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunScheduledTaskRequest) Reset() {
+	*x = RunScheduledTaskRequest{}
+	mi := &file_blog_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunScheduledTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunScheduledTaskRequest) ProtoMessage() {}
+
+func (x *RunScheduledTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunScheduledTaskRequest.ProtoReflect.Descriptor instead.
+func (*RunScheduledTaskRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RunScheduledTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ScheduledTask struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=Description,proto3" json:"Description,omitempty"`
+	// The schedule of -cron, empty when the task only runs through RunScheduledTask.
+	Schedule string `protobuf:"bytes,3,opt,name=Schedule,proto3" json:"Schedule,omitempty"`
+	// RFC 3339 times of the next and the last run, empty when there is none.
+	NextRun             string  `protobuf:"bytes,4,opt,name=NextRun,proto3" json:"NextRun,omitempty"`
+	LastRun             string  `protobuf:"bytes,5,opt,name=LastRun,proto3" json:"LastRun,omitempty"`
+	LastDurationSeconds float64 `protobuf:"fixed64,6,opt,name=LastDurationSeconds,proto3" json:"LastDurationSeconds,omitempty"`
+	// What the last run did, e.g. "dropped 120 entries", and why it failed when it did.
+	LastResult    string `protobuf:"bytes,7,opt,name=LastResult,proto3" json:"LastResult,omitempty"`
+	LastError     string `protobuf:"bytes,8,opt,name=LastError,proto3" json:"LastError,omitempty"`
+	Running       bool   `protobuf:"varint,9,opt,name=Running,proto3" json:"Running,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledTask) Reset() {
+	*x = ScheduledTask{}
+	mi := &file_blog_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTask) ProtoMessage() {}
+
+func (x *ScheduledTask) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTask.ProtoReflect.Descriptor instead.
+func (*ScheduledTask) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ScheduledTask) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetNextRun() string {
+	if x != nil {
+		return x.NextRun
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetLastRun() string {
+	if x != nil {
+		return x.LastRun
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetLastDurationSeconds() float64 {
+	if x != nil {
+		return x.LastDurationSeconds
+	}
+	return 0
+}
+
+func (x *ScheduledTask) GetLastResult() string {
+	if x != nil {
+		return x.LastResult
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+type ScheduledTasks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*ScheduledTask       `protobuf:"bytes,1,rep,name=Tasks,proto3" json:"Tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledTasks) Reset() {
+	*x = ScheduledTasks{}
+	mi := &file_blog_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledTasks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTasks) ProtoMessage() {}
+
+func (x *ScheduledTasks) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTasks.ProtoReflect.Descriptor instead.
+func (*ScheduledTasks) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ScheduledTasks) GetTasks() []*ScheduledTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type GetPublishingScheduleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// First and last day of the range as YYYY-MM-DD dates, both included. Empty From is today, empty To is 30 days after From. The range can't be longer than a year.
+	From string `protobuf:"bytes,1,opt,name=From,proto3" json:"From,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=To,proto3" json:"To,omitempty"`
+	// IANA time zone name, e.g. Europe/Paris. Days start at midnight in that zone and the times of the response are written in it. Empty is UTC.
+	TimeZone      string `protobuf:"bytes,3,opt,name=TimeZone,proto3" json:"TimeZone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublishingScheduleRequest) Reset() {
+	*x = GetPublishingScheduleRequest{}
+	mi := &file_blog_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublishingScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublishingScheduleRequest) ProtoMessage() {}
+
+func (x *GetPublishingScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublishingScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetPublishingScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *GetPublishingScheduleRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetPublishingScheduleRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetPublishingScheduleRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type PublishingSchedule struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TimeZone string                 `protobuf:"bytes,1,opt,name=TimeZone,proto3" json:"TimeZone,omitempty"`
+	// Only the days with at least one post, in order.
+	Days          []*ScheduledDay `protobuf:"bytes,2,rep,name=Days,proto3" json:"Days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishingSchedule) Reset() {
+	*x = PublishingSchedule{}
+	mi := &file_blog_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishingSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishingSchedule) ProtoMessage() {}
+
+func (x *PublishingSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishingSchedule.ProtoReflect.Descriptor instead.
+func (*PublishingSchedule) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *PublishingSchedule) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *PublishingSchedule) GetDays() []*ScheduledDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type ScheduledDay struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// YYYY-MM-DD in the time zone of the request.
+	Date string `protobuf:"bytes,1,opt,name=Date,proto3" json:"Date,omitempty"`
+	// In the order they get published.
+	Posts         []*ScheduledPost `protobuf:"bytes,2,rep,name=Posts,proto3" json:"Posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledDay) Reset() {
+	*x = ScheduledDay{}
+	mi := &file_blog_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledDay) ProtoMessage() {}
+
+func (x *ScheduledDay) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledDay.ProtoReflect.Descriptor instead.
+func (*ScheduledDay) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ScheduledDay) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ScheduledDay) GetPosts() []*ScheduledPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+type ScheduledPost struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Title  string                 `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Author string                 `protobuf:"bytes,3,opt,name=Author,proto3" json:"Author,omitempty"`
+	// RFC 3339 timestamp with the offset of the time zone of the request, e.g. 2025-06-04T11:00:00+02:00
+	PublishAt     string `protobuf:"bytes,4,opt,name=PublishAt,proto3" json:"PublishAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledPost) Reset() {
+	*x = ScheduledPost{}
+	mi := &file_blog_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledPost) ProtoMessage() {}
+
+func (x *ScheduledPost) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledPost.ProtoReflect.Descriptor instead.
+func (*ScheduledPost) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ScheduledPost) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledPost) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ScheduledPost) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ScheduledPost) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+type GetTrendingPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How far back views are counted, in seconds. 0 is 24 hours. The window can't reach further back than the server keeps views (-views-retention).
+	WindowSeconds int64 `protobuf:"varint,1,opt,name=WindowSeconds,proto3" json:"WindowSeconds,omitempty"`
+	// Number of posts to return, 0 is 10. At most 100.
+	Limit         int32 `protobuf:"varint,2,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingPostsRequest) Reset() {
+	*x = GetTrendingPostsRequest{}
+	mi := &file_blog_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingPostsRequest) ProtoMessage() {}
+
+func (x *GetTrendingPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingPostsRequest.ProtoReflect.Descriptor instead.
+func (*GetTrendingPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *GetTrendingPostsRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *GetTrendingPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TrendingPost struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Post  *Post                  `protobuf:"bytes,1,opt,name=Post,proto3" json:"Post,omitempty"`
+	// Views in the window, unlike Post.ViewCount which counts them all.
+	Views         int64 `protobuf:"varint,2,opt,name=Views,proto3" json:"Views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingPost) Reset() {
+	*x = TrendingPost{}
+	mi := &file_blog_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingPost) ProtoMessage() {}
+
+func (x *TrendingPost) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingPost.ProtoReflect.Descriptor instead.
+func (*TrendingPost) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *TrendingPost) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *TrendingPost) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+type TrendingPosts struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The most viewed first, posts without views in the window are left out.
+	Posts         []*TrendingPost `protobuf:"bytes,1,rep,name=Posts,proto3" json:"Posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingPosts) Reset() {
+	*x = TrendingPosts{}
+	mi := &file_blog_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingPosts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingPosts) ProtoMessage() {}
+
+func (x *TrendingPosts) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingPosts.ProtoReflect.Descriptor instead.
+func (*TrendingPosts) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *TrendingPosts) GetPosts() []*TrendingPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+type GetPostAnalyticsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// RFC 3339 timestamps. Empty Until is now, empty Since is 24 hours before Until.
+	Since string `protobuf:"bytes,2,opt,name=Since,proto3" json:"Since,omitempty"`
+	Until string `protobuf:"bytes,3,opt,name=Until,proto3" json:"Until,omitempty"`
+	// Width of every bucket in seconds, 0 is an hour. Views are recorded by the minute, so buckets are a whole number of minutes.
+	BucketSeconds int64 `protobuf:"varint,4,opt,name=BucketSeconds,proto3" json:"BucketSeconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostAnalyticsRequest) Reset() {
+	*x = GetPostAnalyticsRequest{}
+	mi := &file_blog_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostAnalyticsRequest) ProtoMessage() {}
+
+func (x *GetPostAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*GetPostAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetPostAnalyticsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetPostAnalyticsRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *GetPostAnalyticsRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *GetPostAnalyticsRequest) GetBucketSeconds() int64 {
+	if x != nil {
+		return x.BucketSeconds
+	}
+	return 0
+}
+
+type ViewBucket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC 3339 timestamp the bucket starts at, it ends where the next one starts.
+	Start         string `protobuf:"bytes,1,opt,name=Start,proto3" json:"Start,omitempty"`
+	Views         int64  `protobuf:"varint,2,opt,name=Views,proto3" json:"Views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewBucket) Reset() {
+	*x = ViewBucket{}
+	mi := &file_blog_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewBucket) ProtoMessage() {}
+
+func (x *ViewBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewBucket.ProtoReflect.Descriptor instead.
+func (*ViewBucket) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ViewBucket) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *ViewBucket) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+type PostAnalytics struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// Views between Since and Until.
+	Views int64 `protobuf:"varint,2,opt,name=Views,proto3" json:"Views,omitempty"`
+	// Every bucket between Since and Until, including the empty ones so they can be charted as they come.
+	Buckets       []*ViewBucket `protobuf:"bytes,3,rep,name=Buckets,proto3" json:"Buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostAnalytics) Reset() {
+	*x = PostAnalytics{}
+	mi := &file_blog_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostAnalytics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostAnalytics) ProtoMessage() {}
+
+func (x *PostAnalytics) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostAnalytics.ProtoReflect.Descriptor instead.
+func (*PostAnalytics) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *PostAnalytics) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostAnalytics) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *PostAnalytics) GetBuckets() []*ViewBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type GetDailySummaryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A day of the period, YYYY-MM-DD in UTC. Empty is yesterday, the last day that ended.
+	Date   string        `protobuf:"bytes,1,opt,name=Date,proto3" json:"Date,omitempty"`
+	Period SummaryPeriod `protobuf:"varint,2,opt,name=Period,proto3,enum=grpc_tutorial.SummaryPeriod" json:"Period,omitempty"`
+	// Number of posts and authors to return, 0 is 10. At most 100.
+	Limit         int32 `protobuf:"varint,3,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailySummaryRequest) Reset() {
+	*x = GetDailySummaryRequest{}
+	mi := &file_blog_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailySummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailySummaryRequest) ProtoMessage() {}
+
+func (x *GetDailySummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailySummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetDailySummaryRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *GetDailySummaryRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GetDailySummaryRequest) GetPeriod() SummaryPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return SummaryPeriod_SUMMARY_DAY
+}
+
+func (x *GetDailySummaryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type PostSummary struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// Empty for the posts deleted since.
+	Title  string `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Author string `protobuf:"bytes,3,opt,name=Author,proto3" json:"Author,omitempty"`
+	Views  int64  `protobuf:"varint,4,opt,name=Views,proto3" json:"Views,omitempty"`
+	// Views of the period before, and how many more there are now, negative for fewer.
+	PreviousViews int64 `protobuf:"varint,5,opt,name=PreviousViews,proto3" json:"PreviousViews,omitempty"`
+	Delta         int64 `protobuf:"varint,6,opt,name=Delta,proto3" json:"Delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostSummary) Reset() {
+	*x = PostSummary{}
+	mi := &file_blog_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostSummary) ProtoMessage() {}
+
+func (x *PostSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostSummary.ProtoReflect.Descriptor instead.
+func (*PostSummary) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *PostSummary) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostSummary) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PostSummary) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *PostSummary) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *PostSummary) GetPreviousViews() int64 {
+	if x != nil {
+		return x.PreviousViews
+	}
+	return 0
+}
+
+func (x *PostSummary) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type AuthorSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Author        string                 `protobuf:"bytes,1,opt,name=Author,proto3" json:"Author,omitempty"`
+	Views         int64                  `protobuf:"varint,2,opt,name=Views,proto3" json:"Views,omitempty"`
+	PreviousViews int64                  `protobuf:"varint,3,opt,name=PreviousViews,proto3" json:"PreviousViews,omitempty"`
+	Delta         int64                  `protobuf:"varint,4,opt,name=Delta,proto3" json:"Delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorSummary) Reset() {
+	*x = AuthorSummary{}
+	mi := &file_blog_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorSummary) ProtoMessage() {}
+
+func (x *AuthorSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorSummary.ProtoReflect.Descriptor instead.
+func (*AuthorSummary) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *AuthorSummary) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AuthorSummary) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *AuthorSummary) GetPreviousViews() int64 {
+	if x != nil {
+		return x.PreviousViews
+	}
+	return 0
+}
+
+func (x *AuthorSummary) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type DailySummary struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Period SummaryPeriod          `protobuf:"varint,1,opt,name=Period,proto3,enum=grpc_tutorial.SummaryPeriod" json:"Period,omitempty"`
+	// First and last day of the period, YYYY-MM-DD.
+	Start string `protobuf:"bytes,2,opt,name=Start,proto3" json:"Start,omitempty"`
+	End   string `protobuf:"bytes,3,opt,name=End,proto3" json:"End,omitempty"`
+	// Views of every post in the period, and of the period before.
+	Views         int64 `protobuf:"varint,4,opt,name=Views,proto3" json:"Views,omitempty"`
+	PreviousViews int64 `protobuf:"varint,5,opt,name=PreviousViews,proto3" json:"PreviousViews,omitempty"`
+	Delta         int64 `protobuf:"varint,6,opt,name=Delta,proto3" json:"Delta,omitempty"`
+	// Delta in percent of PreviousViews, 0 when the period before had no views.
+	DeltaPercent float64 `protobuf:"fixed64,7,opt,name=DeltaPercent,proto3" json:"DeltaPercent,omitempty"`
+	// False while the period runs, its views keep coming.
+	Complete bool `protobuf:"varint,8,opt,name=Complete,proto3" json:"Complete,omitempty"`
+	// The most viewed in the period first, with as many as Limit.
+	TopPosts      []*PostSummary   `protobuf:"bytes,9,rep,name=TopPosts,proto3" json:"TopPosts,omitempty"`
+	TopAuthors    []*AuthorSummary `protobuf:"bytes,10,rep,name=TopAuthors,proto3" json:"TopAuthors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailySummary) Reset() {
+	*x = DailySummary{}
+	mi := &file_blog_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailySummary) ProtoMessage() {}
+
+func (x *DailySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailySummary.ProtoReflect.Descriptor instead.
+func (*DailySummary) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *DailySummary) GetPeriod() SummaryPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return SummaryPeriod_SUMMARY_DAY
+}
+
+func (x *DailySummary) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *DailySummary) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *DailySummary) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *DailySummary) GetPreviousViews() int64 {
+	if x != nil {
+		return x.PreviousViews
+	}
+	return 0
+}
+
+func (x *DailySummary) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *DailySummary) GetDeltaPercent() float64 {
+	if x != nil {
+		return x.DeltaPercent
+	}
+	return 0
+}
+
+func (x *DailySummary) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+func (x *DailySummary) GetTopPosts() []*PostSummary {
+	if x != nil {
+		return x.TopPosts
+	}
+	return nil
+}
+
+func (x *DailySummary) GetTopAuthors() []*AuthorSummary {
+	if x != nil {
+		return x.TopAuthors
+	}
+	return nil
+}
+
+type GetAuthorProfileRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Author string                 `protobuf:"bytes,1,opt,name=Author,proto3" json:"Author,omitempty"`
+	// Number of recent posts to return, 0 is 5. At most 50.
+	RecentPosts   int32 `protobuf:"varint,2,opt,name=RecentPosts,proto3" json:"RecentPosts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuthorProfileRequest) Reset() {
+	*x = GetAuthorProfileRequest{}
+	mi := &file_blog_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthorProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthorProfileRequest) ProtoMessage() {}
+
+func (x *GetAuthorProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthorProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetAuthorProfileRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *GetAuthorProfileRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *GetAuthorProfileRequest) GetRecentPosts() int32 {
+	if x != nil {
+		return x.RecentPosts
+	}
+	return 0
+}
+
+type SetAuthorBioRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Author string                 `protobuf:"bytes,1,opt,name=Author,proto3" json:"Author,omitempty"`
+	// Empty removes the bio.
+	Bio           string `protobuf:"bytes,2,opt,name=Bio,proto3" json:"Bio,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAuthorBioRequest) Reset() {
+	*x = SetAuthorBioRequest{}
+	mi := &file_blog_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAuthorBioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAuthorBioRequest) ProtoMessage() {}
+
+func (x *SetAuthorBioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAuthorBioRequest.ProtoReflect.Descriptor instead.
+func (*SetAuthorBioRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *SetAuthorBioRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *SetAuthorBioRequest) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+type TagUse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tag   string                 `protobuf:"bytes,1,opt,name=Tag,proto3" json:"Tag,omitempty"`
+	// Published posts of the author with the tag.
+	Posts         int32 `protobuf:"varint,2,opt,name=Posts,proto3" json:"Posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagUse) Reset() {
+	*x = TagUse{}
+	mi := &file_blog_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagUse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagUse) ProtoMessage() {}
+
+func (x *TagUse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagUse.ProtoReflect.Descriptor instead.
+func (*TagUse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *TagUse) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagUse) GetPosts() int32 {
+	if x != nil {
+		return x.Posts
+	}
+	return 0
+}
+
+type AuthorProfile struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Author string                 `protobuf:"bytes,1,opt,name=Author,proto3" json:"Author,omitempty"`
+	Bio    string                 `protobuf:"bytes,2,opt,name=Bio,proto3" json:"Bio,omitempty"`
+	// Published posts, the others aren't on the page of the author.
+	PostCount int32 `protobuf:"varint,3,opt,name=PostCount,proto3" json:"PostCount,omitempty"`
+	// Views of all the published posts.
+	TotalViews int64 `protobuf:"varint,4,opt,name=TotalViews,proto3" json:"TotalViews,omitempty"`
+	// The latest first, without their Content, like a GetPosts whose ReadMask leaves it out.
+	RecentPosts []*Post `protobuf:"bytes,5,rep,name=RecentPosts,proto3" json:"RecentPosts,omitempty"`
+	// The most used first, at most 10.
+	TopTags       []*TagUse `protobuf:"bytes,6,rep,name=TopTags,proto3" json:"TopTags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorProfile) Reset() {
+	*x = AuthorProfile{}
+	mi := &file_blog_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorProfile) ProtoMessage() {}
+
+func (x *AuthorProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorProfile.ProtoReflect.Descriptor instead.
+func (*AuthorProfile) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *AuthorProfile) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AuthorProfile) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *AuthorProfile) GetPostCount() int32 {
+	if x != nil {
+		return x.PostCount
+	}
+	return 0
+}
+
+func (x *AuthorProfile) GetTotalViews() int64 {
+	if x != nil {
+		return x.TotalViews
+	}
+	return 0
+}
+
+func (x *AuthorProfile) GetRecentPosts() []*Post {
+	if x != nil {
+		return x.RecentPosts
+	}
+	return nil
+}
+
+func (x *AuthorProfile) GetTopTags() []*TagUse {
+	if x != nil {
+		return x.TopTags
+	}
+	return nil
+}
+
+type RecordViewRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// Anything that tells viewers apart, like a user or device ID. The server only keeps a hash of it. Empty is the caller's identity, its session when it has one (see StartSession), or its IP address for anonymous callers.
+	ViewerId      string `protobuf:"bytes,2,opt,name=ViewerId,proto3" json:"ViewerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordViewRequest) Reset() {
+	*x = RecordViewRequest{}
+	mi := &file_blog_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordViewRequest) ProtoMessage() {}
+
+func (x *RecordViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordViewRequest.ProtoReflect.Descriptor instead.
+func (*RecordViewRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *RecordViewRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+type RecordViewResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False when the viewer already viewed the post within the deduplication window (-view-dedup-window).
+	Counted       bool  `protobuf:"varint,1,opt,name=Counted,proto3" json:"Counted,omitempty"`
+	ViewCount     int64 `protobuf:"varint,2,opt,name=ViewCount,proto3" json:"ViewCount,omitempty"`
+	UniqueViewers int64 `protobuf:"varint,3,opt,name=UniqueViewers,proto3" json:"UniqueViewers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordViewResponse) Reset() {
+	*x = RecordViewResponse{}
+	mi := &file_blog_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordViewResponse) ProtoMessage() {}
+
+func (x *RecordViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordViewResponse.ProtoReflect.Descriptor instead.
+func (*RecordViewResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *RecordViewResponse) GetCounted() bool {
+	if x != nil {
+		return x.Counted
+	}
+	return false
+}
+
+func (x *RecordViewResponse) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *RecordViewResponse) GetUniqueViewers() int64 {
+	if x != nil {
+		return x.UniqueViewers
+	}
+	return 0
+}
+
+type GetRelatedPostsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// Number of posts to return, 0 is 5. At most 50.
+	Limit         int32 `protobuf:"varint,2,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedPostsRequest) Reset() {
+	*x = GetRelatedPostsRequest{}
+	mi := &file_blog_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedPostsRequest) ProtoMessage() {}
+
+func (x *GetRelatedPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedPostsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *GetRelatedPostsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetRelatedPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RelatedPost struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Post  *Post                  `protobuf:"bytes,1,opt,name=Post,proto3" json:"Post,omitempty"`
+	// Between 0 (nothing in common) and 1 (same tags and same words).
+	Score         float64  `protobuf:"fixed64,2,opt,name=Score,proto3" json:"Score,omitempty"`
+	SharedTags    []string `protobuf:"bytes,3,rep,name=SharedTags,proto3" json:"SharedTags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedPost) Reset() {
+	*x = RelatedPost{}
+	mi := &file_blog_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedPost) ProtoMessage() {}
+
+func (x *RelatedPost) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedPost.ProtoReflect.Descriptor instead.
+func (*RelatedPost) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *RelatedPost) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *RelatedPost) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RelatedPost) GetSharedTags() []string {
+	if x != nil {
+		return x.SharedTags
+	}
+	return nil
+}
+
+type RelatedPosts struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The most similar first, posts with nothing in common are left out.
+	Posts         []*RelatedPost `protobuf:"bytes,1,rep,name=Posts,proto3" json:"Posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedPosts) Reset() {
+	*x = RelatedPosts{}
+	mi := &file_blog_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedPosts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedPosts) ProtoMessage() {}
+
+func (x *RelatedPosts) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedPosts.ProtoReflect.Descriptor instead.
+func (*RelatedPosts) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *RelatedPosts) GetPosts() []*RelatedPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+type GetPostBySlugRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Slugs are made of lowercase letters and digits separated by single dashes, see internal/store/slug.go
+	Slug          string `protobuf:"bytes,1,opt,name=Slug,proto3" json:"Slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostBySlugRequest) Reset() {
+	*x = GetPostBySlugRequest{}
+	mi := &file_blog_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostBySlugRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostBySlugRequest) ProtoMessage() {}
+
+func (x *GetPostBySlugRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostBySlugRequest.ProtoReflect.Descriptor instead.
+func (*GetPostBySlugRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *GetPostBySlugRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type GetBacklinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBacklinksRequest) Reset() {
+	*x = GetBacklinksRequest{}
+	mi := &file_blog_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBacklinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBacklinksRequest) ProtoMessage() {}
+
+func (x *GetBacklinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBacklinksRequest.ProtoReflect.Descriptor instead.
+func (*GetBacklinksRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *GetBacklinksRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+type GetSitemapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSitemapRequest) Reset() {
+	*x = GetSitemapRequest{}
+	mi := &file_blog_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSitemapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSitemapRequest) ProtoMessage() {}
+
+func (x *GetSitemapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSitemapRequest.ProtoReflect.Descriptor instead.
+func (*GetSitemapRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{105}
+}
+
+type Sitemap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The sitemap.xml document, one URL per published post.
+	Xml           string `protobuf:"bytes,1,opt,name=Xml,proto3" json:"Xml,omitempty"`
+	UrlCount      int32  `protobuf:"varint,2,opt,name=UrlCount,proto3" json:"UrlCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sitemap) Reset() {
+	*x = Sitemap{}
+	mi := &file_blog_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sitemap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sitemap) ProtoMessage() {}
+
+func (x *Sitemap) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sitemap.ProtoReflect.Descriptor instead.
+func (*Sitemap) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *Sitemap) GetXml() string {
+	if x != nil {
+		return x.Xml
+	}
+	return ""
+}
+
+func (x *Sitemap) GetUrlCount() int32 {
+	if x != nil {
+		return x.UrlCount
+	}
+	return 0
+}
+
+type StartSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSessionRequest) Reset() {
+	*x = StartSessionRequest{}
+	mi := &file_blog_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSessionRequest) ProtoMessage() {}
+
+func (x *StartSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSessionRequest.ProtoReflect.Descriptor instead.
+func (*StartSessionRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{107}
+}
+
+type Session struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sent back in the x-session metadata of every call.
+	Token string `protobuf:"bytes,1,opt,name=Token,proto3" json:"Token,omitempty"`
+	// What views are counted for, stays the same when the session is renewed.
+	ViewerId string `protobuf:"bytes,2,opt,name=ViewerId,proto3" json:"ViewerId,omitempty"`
+	// RFC 3339, StartSession with the token renews it before then.
+	ExpiresAt     string `protobuf:"bytes,3,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_blog_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *Session) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Session) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *Session) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type GetChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChallengeRequest) Reset() {
+	*x = GetChallengeRequest{}
+	mi := &file_blog_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChallengeRequest) ProtoMessage() {}
+
+func (x *GetChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChallengeRequest.ProtoReflect.Descriptor instead.
+func (*GetChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{109}
+}
+
+type Challenge struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty when CreatePost needs no challenge.
+	Token string `protobuf:"bytes,1,opt,name=Token,proto3" json:"Token,omitempty"`
+	// The zero bits the SHA-256 of "<Token>:<nonce>" must start with, see internal/pow
+	Bits int32 `protobuf:"varint,2,opt,name=Bits,proto3" json:"Bits,omitempty"`
+	// RFC 3339, the challenge must be used before then, and only once.
+	ExpiresAt     string `protobuf:"bytes,3,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Challenge) Reset() {
+	*x = Challenge{}
+	mi := &file_blog_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Challenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Challenge) ProtoMessage() {}
+
+func (x *Challenge) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Challenge.ProtoReflect.Descriptor instead.
+func (*Challenge) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *Challenge) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Challenge) GetBits() int32 {
+	if x != nil {
+		return x.Bits
+	}
+	return 0
+}
+
+func (x *Challenge) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type GetReadingHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How many posts at most, 0 returns all of them.
+	Limit int32 `protobuf:"varint,1,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	// Only the posts viewed but not marked as read, the ones to pick up where the reader left off.
+	InProgress    bool `protobuf:"varint,2,opt,name=InProgress,proto3" json:"InProgress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReadingHistoryRequest) Reset() {
+	*x = GetReadingHistoryRequest{}
+	mi := &file_blog_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReadingHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReadingHistoryRequest) ProtoMessage() {}
+
+func (x *GetReadingHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReadingHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetReadingHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *GetReadingHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetReadingHistoryRequest) GetInProgress() bool {
+	if x != nil {
+		return x.InProgress
+	}
+	return false
+}
+
+type ReadingHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*HistoryEntry        `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadingHistory) Reset() {
+	*x = ReadingHistory{}
+	mi := &file_blog_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadingHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadingHistory) ProtoMessage() {}
+
+func (x *ReadingHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadingHistory.ProtoReflect.Descriptor instead.
+func (*ReadingHistory) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ReadingHistory) GetEntries() []*HistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type HistoryEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Post  *Post                  `protobuf:"bytes,1,opt,name=Post,proto3" json:"Post,omitempty"`
+	// When the caller last viewed the post or marked it, RFC 3339.
+	ViewedAt string `protobuf:"bytes,2,opt,name=ViewedAt,proto3" json:"ViewedAt,omitempty"`
+	// Marked as read with MarkAsRead.
+	Read          bool `protobuf:"varint,3,opt,name=Read,proto3" json:"Read,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	mi := &file_blog_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEntry) ProtoMessage() {}
+
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *HistoryEntry) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetViewedAt() string {
+	if x != nil {
+		return x.ViewedAt
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetRead() bool {
+	if x != nil {
+		return x.Read
+	}
+	return false
+}
+
+type MarkAsReadRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// Marks the post as not read, it stays in the history.
+	Unread        bool `protobuf:"varint,2,opt,name=Unread,proto3" json:"Unread,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkAsReadRequest) Reset() {
+	*x = MarkAsReadRequest{}
+	mi := &file_blog_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkAsReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkAsReadRequest) ProtoMessage() {}
+
+func (x *MarkAsReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkAsReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkAsReadRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *MarkAsReadRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *MarkAsReadRequest) GetUnread() bool {
+	if x != nil {
+		return x.Unread
+	}
+	return false
+}
+
+type Notification struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Type   NotificationType       `protobuf:"varint,2,opt,name=Type,proto3,enum=grpc_tutorial.NotificationType" json:"Type,omitempty"`
+	PostId string                 `protobuf:"bytes,3,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// The title of the post when the notification was made.
+	PostTitle string `protobuf:"bytes,4,opt,name=PostTitle,proto3" json:"PostTitle,omitempty"`
+	// RFC 3339
+	CreatedAt     string `protobuf:"bytes,5,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Read          bool   `protobuf:"varint,6,opt,name=Read,proto3" json:"Read,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_blog_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetType() NotificationType {
+	if x != nil {
+		return x.Type
+	}
+	return NotificationType_NOTIFICATION_POST_PUBLISHED
+}
+
+func (x *Notification) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *Notification) GetPostTitle() string {
+	if x != nil {
+		return x.PostTitle
+	}
+	return ""
+}
+
+func (x *Notification) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Notification) GetRead() bool {
+	if x != nil {
+		return x.Read
+	}
+	return false
+}
+
+type ListNotificationsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UnreadOnly bool                   `protobuf:"varint,1,opt,name=UnreadOnly,proto3" json:"UnreadOnly,omitempty"`
+	// How many notifications at most, the most recent first. 0 returns all of them.
+	Limit         int32 `protobuf:"varint,2,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_blog_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ListNotificationsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+func (x *ListNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Notifications struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=Notifications,proto3" json:"Notifications,omitempty"`
+	// Of the whole inbox, not only the notifications returned.
+	UnreadCount   int32 `protobuf:"varint,2,opt,name=UnreadCount,proto3" json:"UnreadCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notifications) Reset() {
+	*x = Notifications{}
+	mi := &file_blog_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notifications) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notifications) ProtoMessage() {}
+
+func (x *Notifications) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notifications.ProtoReflect.Descriptor instead.
+func (*Notifications) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *Notifications) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *Notifications) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+type MarkNotificationReadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The notification to mark, ignored with All.
+	Id string `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// Marks every notification of the inbox.
+	All           bool `protobuf:"varint,2,opt,name=All,proto3" json:"All,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationReadRequest) Reset() {
+	*x = MarkNotificationReadRequest{}
+	mi := &file_blog_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationReadRequest) ProtoMessage() {}
+
+func (x *MarkNotificationReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationReadRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *MarkNotificationReadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MarkNotificationReadRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type StreamNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamNotificationsRequest) Reset() {
+	*x = StreamNotificationsRequest{}
+	mi := &file_blog_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamNotificationsRequest) ProtoMessage() {}
+
+func (x *StreamNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*StreamNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{119}
+}
+
+type Comment struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	PostId string                 `protobuf:"bytes,2,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// The comment this one answers, empty for the comments on the post itself.
+	ParentId string `protobuf:"bytes,3,opt,name=ParentId,proto3" json:"ParentId,omitempty"`
+	Author   string `protobuf:"bytes,4,opt,name=Author,proto3" json:"Author,omitempty"`
+	Content  string `protobuf:"bytes,5,opt,name=Content,proto3" json:"Content,omitempty"`
+	// RFC 3339
+	CreatedAt string        `protobuf:"bytes,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Status    CommentStatus `protobuf:"varint,7,opt,name=Status,proto3,enum=grpc_tutorial.CommentStatus" json:"Status,omitempty"`
+	// Why moderation held the comment back or the moderator rejected it.
+	ModerationReason string `protobuf:"bytes,8,opt,name=ModerationReason,proto3" json:"ModerationReason,omitempty"`
+	// The identity of the caller that wrote it, empty for anonymous callers. See auth.go
+	CreatedBy     string `protobuf:"bytes,9,opt,name=CreatedBy,proto3" json:"CreatedBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_blog_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *Comment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Comment) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *Comment) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Comment) GetStatus() CommentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CommentStatus_PENDING
+}
+
+func (x *Comment) GetModerationReason() string {
+	if x != nil {
+		return x.ModerationReason
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type AddCommentRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// An approved comment of the same post.
+	ParentId      string `protobuf:"bytes,2,opt,name=ParentId,proto3" json:"ParentId,omitempty"`
+	Author        string `protobuf:"bytes,3,opt,name=Author,proto3" json:"Author,omitempty"`
+	Content       string `protobuf:"bytes,4,opt,name=Content,proto3" json:"Content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_blog_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *AddCommentRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type GetCommentsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// Only the comments with these statuses, empty returns every comment the caller may see: the approved ones and its own for readers, all of them for admins.
+	Statuses      []CommentStatus `protobuf:"varint,2,rep,packed,name=Statuses,proto3,enum=grpc_tutorial.CommentStatus" json:"Statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentsRequest) Reset() {
+	*x = GetCommentsRequest{}
+	mi := &file_blog_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentsRequest) ProtoMessage() {}
+
+func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentsRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *GetCommentsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetCommentsRequest) GetStatuses() []CommentStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type Comments struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order they were written, replies after the comment they answer.
+	Comments      []*Comment `protobuf:"bytes,1,rep,name=Comments,proto3" json:"Comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comments) Reset() {
+	*x = Comments{}
+	mi := &file_blog_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comments) ProtoMessage() {}
+
+func (x *Comments) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comments.ProtoReflect.Descriptor instead.
+func (*Comments) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *Comments) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type ModerateCommentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// Shown to the author of the comment.
+	Reason        string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateCommentRequest) Reset() {
+	*x = ModerateCommentRequest{}
+	mi := &file_blog_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateCommentRequest) ProtoMessage() {}
+
+func (x *ModerateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateCommentRequest.ProtoReflect.Descriptor instead.
+func (*ModerateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *ModerateCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModerateCommentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PinPostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// Where the post goes among the pinned posts, 1 is the first. 0, or past the last one, puts it last.
+	Position      int32 `protobuf:"varint,2,opt,name=Position,proto3" json:"Position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinPostRequest) Reset() {
+	*x = PinPostRequest{}
+	mi := &file_blog_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinPostRequest) ProtoMessage() {}
+
+func (x *PinPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinPostRequest.ProtoReflect.Descriptor instead.
+func (*PinPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *PinPostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PinPostRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type UnpinPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinPostRequest) Reset() {
+	*x = UnpinPostRequest{}
+	mi := &file_blog_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinPostRequest) ProtoMessage() {}
+
+func (x *UnpinPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinPostRequest.ProtoReflect.Descriptor instead.
+func (*UnpinPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *UnpinPostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BulkPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Can't be empty, a filter that matches everything is more often a mistake than not.
+	Filter        *PostFilter `protobuf:"bytes,1,opt,name=Filter,proto3" json:"Filter,omitempty"`
+	DryRun        bool        `protobuf:"varint,2,opt,name=DryRun,proto3" json:"DryRun,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkPostsRequest) Reset() {
+	*x = BulkPostsRequest{}
+	mi := &file_blog_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkPostsRequest) ProtoMessage() {}
+
+func (x *BulkPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkPostsRequest.ProtoReflect.Descriptor instead.
+func (*BulkPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *BulkPostsRequest) GetFilter() *PostFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *BulkPostsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type BulkPostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of posts deleted or archived, or that would be with DryRun.
+	Count         int32    `protobuf:"varint,1,opt,name=Count,proto3" json:"Count,omitempty"`
+	Ids           []string `protobuf:"bytes,2,rep,name=Ids,proto3" json:"Ids,omitempty"`
+	DryRun        bool     `protobuf:"varint,3,opt,name=DryRun,proto3" json:"DryRun,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkPostsResponse) Reset() {
+	*x = BulkPostsResponse{}
+	mi := &file_blog_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkPostsResponse) ProtoMessage() {}
+
+func (x *BulkPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkPostsResponse.ProtoReflect.Descriptor instead.
+func (*BulkPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *BulkPostsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BulkPostsResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BulkPostsResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type Report struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// The post reported, or the post of the comment reported.
+	PostId string `protobuf:"bytes,2,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	// Empty when the post itself was reported.
+	CommentId string       `protobuf:"bytes,3,opt,name=CommentId,proto3" json:"CommentId,omitempty"`
+	Reason    ReportReason `protobuf:"varint,4,opt,name=Reason,proto3,enum=grpc_tutorial.ReportReason" json:"Reason,omitempty"`
+	Details   string       `protobuf:"bytes,5,opt,name=Details,proto3" json:"Details,omitempty"`
+	// The identity of the caller that reported it, empty for anonymous callers. See auth.go
+	ReportedBy string `protobuf:"bytes,6,opt,name=ReportedBy,proto3" json:"ReportedBy,omitempty"`
+	// RFC 3339
+	CreatedAt  string           `protobuf:"bytes,7,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Resolution ReportResolution `protobuf:"varint,8,opt,name=Resolution,proto3,enum=grpc_tutorial.ReportResolution" json:"Resolution,omitempty"`
+	ResolvedBy string           `protobuf:"bytes,9,opt,name=ResolvedBy,proto3" json:"ResolvedBy,omitempty"`
+	ResolvedAt string           `protobuf:"bytes,10,opt,name=ResolvedAt,proto3" json:"ResolvedAt,omitempty"`
+	// Why the moderator resolved it that way.
+	Note          string `protobuf:"bytes,11,opt,name=Note,proto3" json:"Note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	mi := &file_blog_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *Report) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Report) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *Report) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *Report) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_OTHER
+}
+
+func (x *Report) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *Report) GetReportedBy() string {
+	if x != nil {
+		return x.ReportedBy
+	}
+	return ""
+}
+
+func (x *Report) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Report) GetResolution() ReportResolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return ReportResolution_UNRESOLVED
+}
+
+func (x *Report) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Report) GetResolvedAt() string {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return ""
+}
+
+func (x *Report) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ReportPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	Reason        ReportReason           `protobuf:"varint,2,opt,name=Reason,proto3,enum=grpc_tutorial.ReportReason" json:"Reason,omitempty"`
+	Details       string                 `protobuf:"bytes,3,opt,name=Details,proto3" json:"Details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportPostRequest) Reset() {
+	*x = ReportPostRequest{}
+	mi := &file_blog_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPostRequest) ProtoMessage() {}
+
+func (x *ReportPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPostRequest.ProtoReflect.Descriptor instead.
+func (*ReportPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *ReportPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *ReportPostRequest) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_OTHER
+}
+
+func (x *ReportPostRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+type ReportCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=CommentId,proto3" json:"CommentId,omitempty"`
+	Reason        ReportReason           `protobuf:"varint,2,opt,name=Reason,proto3,enum=grpc_tutorial.ReportReason" json:"Reason,omitempty"`
+	Details       string                 `protobuf:"bytes,3,opt,name=Details,proto3" json:"Details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportCommentRequest) Reset() {
+	*x = ReportCommentRequest{}
+	mi := &file_blog_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportCommentRequest) ProtoMessage() {}
+
+func (x *ReportCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportCommentRequest.ProtoReflect.Descriptor instead.
+func (*ReportCommentRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *ReportCommentRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *ReportCommentRequest) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_OTHER
+}
+
+func (x *ReportCommentRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+type ListReportsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resolved reports instead of the open ones.
+	Resolved bool `protobuf:"varint,1,opt,name=Resolved,proto3" json:"Resolved,omitempty"`
+	// Only the reports of this post and its comments, empty lists them all.
+	PostId        string `protobuf:"bytes,2,opt,name=PostId,proto3" json:"PostId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReportsRequest) Reset() {
+	*x = ListReportsRequest{}
+	mi := &file_blog_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsRequest) ProtoMessage() {}
+
+func (x *ListReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListReportsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *ListReportsRequest) GetResolved() bool {
+	if x != nil {
+		return x.Resolved
+	}
+	return false
+}
+
+func (x *ListReportsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+type Reports struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The oldest first, the order moderators go through them in.
+	Reports       []*Report `protobuf:"bytes,1,rep,name=Reports,proto3" json:"Reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reports) Reset() {
+	*x = Reports{}
+	mi := &file_blog_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reports) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reports) ProtoMessage() {}
+
+func (x *Reports) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reports.ProtoReflect.Descriptor instead.
+func (*Reports) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *Reports) GetReports() []*Report {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type ResolveReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// Anything but UNRESOLVED. The other open reports of the same post or comment are resolved the same way.
+	Resolution    ReportResolution `protobuf:"varint,2,opt,name=Resolution,proto3,enum=grpc_tutorial.ReportResolution" json:"Resolution,omitempty"`
+	Note          string           `protobuf:"bytes,3,opt,name=Note,proto3" json:"Note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveReportRequest) Reset() {
+	*x = ResolveReportRequest{}
+	mi := &file_blog_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveReportRequest) ProtoMessage() {}
+
+func (x *ResolveReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveReportRequest.ProtoReflect.Descriptor instead.
+func (*ResolveReportRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *ResolveReportRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResolveReportRequest) GetResolution() ReportResolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return ReportResolution_UNRESOLVED
+}
+
+func (x *ResolveReportRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type Ban struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// Who is banned: an identity like oidc:jane or session:<viewer>, or the IP address the calls come from. One of the two is set.
+	Identity string `protobuf:"bytes,2,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	Ip       string `protobuf:"bytes,3,opt,name=Ip,proto3" json:"Ip,omitempty"`
+	Reason   string `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	BannedBy string `protobuf:"bytes,5,opt,name=BannedBy,proto3" json:"BannedBy,omitempty"`
+	// RFC 3339
+	CreatedAt string `protobuf:"bytes,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	// The report the ban comes from when a moderator resolved it with AUTHOR_BANNED.
+	ReportId      string `protobuf:"bytes,7,opt,name=ReportId,proto3" json:"ReportId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ban) Reset() {
+	*x = Ban{}
+	mi := &file_blog_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ban) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ban) ProtoMessage() {}
+
+func (x *Ban) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ban.ProtoReflect.Descriptor instead.
+func (*Ban) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *Ban) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Ban) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *Ban) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *Ban) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Ban) GetBannedBy() string {
+	if x != nil {
+		return x.BannedBy
+	}
+	return ""
+}
+
+func (x *Ban) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Ban) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type AddBanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Either an Identity or an Ip, admins can't be banned.
+	Identity      string `protobuf:"bytes,1,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	Ip            string `protobuf:"bytes,2,opt,name=Ip,proto3" json:"Ip,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBanRequest) Reset() {
+	*x = AddBanRequest{}
+	mi := &file_blog_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBanRequest) ProtoMessage() {}
+
+func (x *AddBanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBanRequest.ProtoReflect.Descriptor instead.
+func (*AddBanRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *AddBanRequest) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *AddBanRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AddBanRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RemoveBanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveBanRequest) Reset() {
+	*x = RemoveBanRequest{}
+	mi := &file_blog_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveBanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBanRequest) ProtoMessage() {}
+
+func (x *RemoveBanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBanRequest.ProtoReflect.Descriptor instead.
+func (*RemoveBanRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *RemoveBanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemoveBanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveBanResponse) Reset() {
+	*x = RemoveBanResponse{}
+	mi := &file_blog_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveBanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBanResponse) ProtoMessage() {}
+
+func (x *RemoveBanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBanResponse.ProtoReflect.Descriptor instead.
+func (*RemoveBanResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{138}
+}
+
+type ListBansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBansRequest) Reset() {
+	*x = ListBansRequest{}
+	mi := &file_blog_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBansRequest) ProtoMessage() {}
+
+func (x *ListBansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBansRequest.ProtoReflect.Descriptor instead.
+func (*ListBansRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{139}
+}
+
+type Bans struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The oldest first.
+	Bans          []*Ban `protobuf:"bytes,1,rep,name=Bans,proto3" json:"Bans,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bans) Reset() {
+	*x = Bans{}
+	mi := &file_blog_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bans) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bans) ProtoMessage() {}
+
+func (x *Bans) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bans.ProtoReflect.Descriptor instead.
+func (*Bans) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *Bans) GetBans() []*Ban {
+	if x != nil {
+		return x.Bans
+	}
+	return nil
+}
+
+type GetConnectionStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionStatsRequest) Reset() {
+	*x = GetConnectionStatsRequest{}
+	mi := &file_blog_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionStatsRequest) ProtoMessage() {}
+
+func (x *GetConnectionStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetConnectionStatsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{141}
+}
+
+type ConnectionStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OpenConnections int32                  `protobuf:"varint,1,opt,name=OpenConnections,proto3" json:"OpenConnections,omitempty"`
+	// The calls running on them, streams included.
+	ActiveStreams int32 `protobuf:"varint,2,opt,name=ActiveStreams,proto3" json:"ActiveStreams,omitempty"`
+	// Since the server started.
+	AcceptedConnections int64 `protobuf:"varint,3,opt,name=AcceptedConnections,proto3" json:"AcceptedConnections,omitempty"`
+	ClosedConnections   int64 `protobuf:"varint,4,opt,name=ClosedConnections,proto3" json:"ClosedConnections,omitempty"`
+	// One per address of -addr, see listeners.go
+	Listeners []*ListenerStats `protobuf:"bytes,5,rep,name=Listeners,proto3" json:"Listeners,omitempty"`
+	// The remote addresses of the open connections, the most connected first.
+	Peers []*PeerTraffic `protobuf:"bytes,6,rep,name=Peers,proto3" json:"Peers,omitempty"`
+	// Turned away by the limits of a client IP since the start, see peerlimits.go
+	RejectedConnections int64 `protobuf:"varint,7,opt,name=RejectedConnections,proto3" json:"RejectedConnections,omitempty"`
+	RejectedCalls       int64 `protobuf:"varint,8,opt,name=RejectedCalls,proto3" json:"RejectedCalls,omitempty"`
+	// The limits, 0 when off.
+	MaxConnectionsPerIp int32 `protobuf:"varint,9,opt,name=MaxConnectionsPerIp,proto3" json:"MaxConnectionsPerIp,omitempty"`
+	MaxCallsPerIp       int32 `protobuf:"varint,10,opt,name=MaxCallsPerIp,proto3" json:"MaxCallsPerIp,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ConnectionStats) Reset() {
+	*x = ConnectionStats{}
+	mi := &file_blog_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionStats) ProtoMessage() {}
+
+func (x *ConnectionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionStats.ProtoReflect.Descriptor instead.
+func (*ConnectionStats) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *ConnectionStats) GetOpenConnections() int32 {
+	if x != nil {
+		return x.OpenConnections
+	}
+	return 0
+}
+
+func (x *ConnectionStats) GetActiveStreams() int32 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+func (x *ConnectionStats) GetAcceptedConnections() int64 {
+	if x != nil {
+		return x.AcceptedConnections
+	}
+	return 0
+}
+
+func (x *ConnectionStats) GetClosedConnections() int64 {
+	if x != nil {
+		return x.ClosedConnections
+	}
+	return 0
+}
+
+func (x *ConnectionStats) GetListeners() []*ListenerStats {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *ConnectionStats) GetPeers() []*PeerTraffic {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *ConnectionStats) GetRejectedConnections() int64 {
+	if x != nil {
+		return x.RejectedConnections
+	}
+	return 0
+}
+
+func (x *ConnectionStats) GetRejectedCalls() int64 {
+	if x != nil {
+		return x.RejectedCalls
+	}
+	return 0
+}
+
+func (x *ConnectionStats) GetMaxConnectionsPerIp() int32 {
+	if x != nil {
+		return x.MaxConnectionsPerIp
+	}
+	return 0
+}
+
+func (x *ConnectionStats) GetMaxCallsPerIp() int32 {
+	if x != nil {
+		return x.MaxCallsPerIp
+	}
+	return 0
+}
+
+type ListenerStats struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Address             string                 `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	OpenConnections     int32                  `protobuf:"varint,2,opt,name=OpenConnections,proto3" json:"OpenConnections,omitempty"`
+	AcceptedConnections int64                  `protobuf:"varint,3,opt,name=AcceptedConnections,proto3" json:"AcceptedConnections,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListenerStats) Reset() {
+	*x = ListenerStats{}
+	mi := &file_blog_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerStats) ProtoMessage() {}
+
+func (x *ListenerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerStats.ProtoReflect.Descriptor instead.
+func (*ListenerStats) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *ListenerStats) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListenerStats) GetOpenConnections() int32 {
+	if x != nil {
+		return x.OpenConnections
+	}
+	return 0
+}
+
+func (x *ListenerStats) GetAcceptedConnections() int64 {
+	if x != nil {
+		return x.AcceptedConnections
+	}
+	return 0
+}
+
+type PeerTraffic struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The IP address, or the path of the socket for Unix sockets.
+	Address         string `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	OpenConnections int32  `protobuf:"varint,2,opt,name=OpenConnections,proto3" json:"OpenConnections,omitempty"`
+	ActiveStreams   int32  `protobuf:"varint,3,opt,name=ActiveStreams,proto3" json:"ActiveStreams,omitempty"`
+	// Over the open connections only, a connection closing takes its traffic with it.
+	Calls         int64 `protobuf:"varint,4,opt,name=Calls,proto3" json:"Calls,omitempty"`
+	BytesReceived int64 `protobuf:"varint,5,opt,name=BytesReceived,proto3" json:"BytesReceived,omitempty"`
+	BytesSent     int64 `protobuf:"varint,6,opt,name=BytesSent,proto3" json:"BytesSent,omitempty"`
+	// RFC 3339, empty when no call came yet.
+	LastCallAt    string `protobuf:"bytes,7,opt,name=LastCallAt,proto3" json:"LastCallAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerTraffic) Reset() {
+	*x = PeerTraffic{}
+	mi := &file_blog_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerTraffic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerTraffic) ProtoMessage() {}
+
+func (x *PeerTraffic) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerTraffic.ProtoReflect.Descriptor instead.
+func (*PeerTraffic) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *PeerTraffic) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PeerTraffic) GetOpenConnections() int32 {
+	if x != nil {
+		return x.OpenConnections
+	}
+	return 0
+}
+
+func (x *PeerTraffic) GetActiveStreams() int32 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+func (x *PeerTraffic) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *PeerTraffic) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *PeerTraffic) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *PeerTraffic) GetLastCallAt() string {
+	if x != nil {
+		return x.LastCallAt
+	}
+	return ""
+}
+
+type ListConnectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConnectionsRequest) Reset() {
+	*x = ListConnectionsRequest{}
+	mi := &file_blog_proto_msgTypes[145]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConnectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConnectionsRequest) ProtoMessage() {}
+
+func (x *ListConnectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[145]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConnectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListConnectionsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{145}
+}
+
+type Connections struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The oldest first.
+	Connections   []*Connection `protobuf:"bytes,1,rep,name=Connections,proto3" json:"Connections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connections) Reset() {
+	*x = Connections{}
+	mi := &file_blog_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connections) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connections) ProtoMessage() {}
+
+func (x *Connections) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[146]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connections.ProtoReflect.Descriptor instead.
+func (*Connections) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *Connections) GetConnections() []*Connection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+type Connection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RemoteAddress string                 `protobuf:"bytes,1,opt,name=RemoteAddress,proto3" json:"RemoteAddress,omitempty"`
+	// The address of the listener it came through.
+	LocalAddress string `protobuf:"bytes,2,opt,name=LocalAddress,proto3" json:"LocalAddress,omitempty"`
+	// RFC 3339
+	OpenedAt      string `protobuf:"bytes,3,opt,name=OpenedAt,proto3" json:"OpenedAt,omitempty"`
+	ActiveStreams int32  `protobuf:"varint,4,opt,name=ActiveStreams,proto3" json:"ActiveStreams,omitempty"`
+	Calls         int64  `protobuf:"varint,5,opt,name=Calls,proto3" json:"Calls,omitempty"`
+	BytesReceived int64  `protobuf:"varint,6,opt,name=BytesReceived,proto3" json:"BytesReceived,omitempty"`
+	BytesSent     int64  `protobuf:"varint,7,opt,name=BytesSent,proto3" json:"BytesSent,omitempty"`
+	// RFC 3339, empty when no call came yet.
+	LastCallAt    string `protobuf:"bytes,8,opt,name=LastCallAt,proto3" json:"LastCallAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	mi := &file_blog_proto_msgTypes[147]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[147]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *Connection) GetRemoteAddress() string {
+	if x != nil {
+		return x.RemoteAddress
+	}
+	return ""
+}
+
+func (x *Connection) GetLocalAddress() string {
+	if x != nil {
+		return x.LocalAddress
+	}
+	return ""
+}
+
+func (x *Connection) GetOpenedAt() string {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return ""
+}
+
+func (x *Connection) GetActiveStreams() int32 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+func (x *Connection) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *Connection) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *Connection) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *Connection) GetLastCallAt() string {
+	if x != nil {
+		return x.LastCallAt
+	}
+	return ""
+}
+
+type StreamServerStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Seconds between two reports, 5 when 0.
+	IntervalSeconds int32 `protobuf:"varint,1,opt,name=IntervalSeconds,proto3" json:"IntervalSeconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StreamServerStatsRequest) Reset() {
+	*x = StreamServerStatsRequest{}
+	mi := &file_blog_proto_msgTypes[148]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamServerStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamServerStatsRequest) ProtoMessage() {}
+
+func (x *StreamServerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[148]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamServerStatsRequest.ProtoReflect.Descriptor instead.
+func (*StreamServerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{148}
+}
+
+func (x *StreamServerStatsRequest) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+// What happened on the server since the previous report, or since the stream started for the first one.
+type ServerStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC 3339 time of the report.
+	Time string `protobuf:"bytes,1,opt,name=Time,proto3" json:"Time,omitempty"`
+	// How long the interval really was, a slow reader makes it longer than asked.
+	IntervalSeconds float64 `protobuf:"fixed64,2,opt,name=IntervalSeconds,proto3" json:"IntervalSeconds,omitempty"`
+	// The calls that ended during the interval, streams included, and how many of them failed.
+	Calls          int64   `protobuf:"varint,3,opt,name=Calls,proto3" json:"Calls,omitempty"`
+	Errors         int64   `protobuf:"varint,4,opt,name=Errors,proto3" json:"Errors,omitempty"`
+	CallsPerSecond float64 `protobuf:"fixed64,5,opt,name=CallsPerSecond,proto3" json:"CallsPerSecond,omitempty"`
+	// Running at the time of the report, this stream included.
+	InFlight int64 `protobuf:"varint,6,opt,name=InFlight,proto3" json:"InFlight,omitempty"`
+	// How long the unary calls of the interval took, in milliseconds, 0 without calls. Streams last as long as their clients want and are left out.
+	LatencyP50Ms float64 `protobuf:"fixed64,7,opt,name=LatencyP50Ms,proto3" json:"LatencyP50Ms,omitempty"`
+	LatencyP90Ms float64 `protobuf:"fixed64,8,opt,name=LatencyP90Ms,proto3" json:"LatencyP90Ms,omitempty"`
+	LatencyP99Ms float64 `protobuf:"fixed64,9,opt,name=LatencyP99Ms,proto3" json:"LatencyP99Ms,omitempty"`
+	LatencyMaxMs float64 `protobuf:"fixed64,10,opt,name=LatencyMaxMs,proto3" json:"LatencyMaxMs,omitempty"`
+	// The calls of the interval by method, the most called first.
+	Methods []*MethodStats `protobuf:"bytes,11,rep,name=Methods,proto3" json:"Methods,omitempty"`
+	// The memory of the process at the time of the report, see runtime.MemStats
+	HeapAllocBytes uint64 `protobuf:"varint,12,opt,name=HeapAllocBytes,proto3" json:"HeapAllocBytes,omitempty"`
+	SysBytes       uint64 `protobuf:"varint,13,opt,name=SysBytes,proto3" json:"SysBytes,omitempty"`
+	Goroutines     int32  `protobuf:"varint,14,opt,name=Goroutines,proto3" json:"Goroutines,omitempty"`
+	// The garbage collections of the interval and how long they stopped the program in total.
+	GcRuns        uint32  `protobuf:"varint,15,opt,name=GcRuns,proto3" json:"GcRuns,omitempty"`
+	GcPauseMs     float64 `protobuf:"fixed64,16,opt,name=GcPauseMs,proto3" json:"GcPauseMs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Post) Reset() {
-	*x = Post{}
-	mi := &file_blog_proto_msgTypes[0]
+func (x *ServerStats) Reset() {
+	*x = ServerStats{}
+	mi := &file_blog_proto_msgTypes[149]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Post) String() string {
+func (x *ServerStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Post) ProtoMessage() {}
+func (*ServerStats) ProtoMessage() {}
 
-func (x *Post) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[0]
+func (x *ServerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[149]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -67,77 +9544,150 @@ func (x *Post) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Post.ProtoReflect.Descriptor instead.
-func (*Post) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ServerStats.ProtoReflect.Descriptor instead.
+func (*ServerStats) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{149}
 }
 
-func (x *Post) GetTitle() string {
+func (x *ServerStats) GetTime() string {
 	if x != nil {
-		return x.Title
+		return x.Time
 	}
 	return ""
 }
 
-func (x *Post) GetContent() string {
+func (x *ServerStats) GetIntervalSeconds() float64 {
 	if x != nil {
-		return x.Content
+		return x.IntervalSeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *Post) GetCreatedAt() string {
+func (x *ServerStats) GetCalls() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Calls
 	}
-	return ""
+	return 0
 }
 
-func (x *Post) GetAuthor() string {
+func (x *ServerStats) GetErrors() int64 {
 	if x != nil {
-		return x.Author
+		return x.Errors
 	}
-	return ""
+	return 0
 }
 
-func (x *Post) GetViewCount() int64 {
+func (x *ServerStats) GetCallsPerSecond() float64 {
 	if x != nil {
-		return x.ViewCount
+		return x.CallsPerSecond
 	}
 	return 0
 }
 
-func (x *Post) GetLastViewed() string {
+func (x *ServerStats) GetInFlight() int64 {
 	if x != nil {
-		return x.LastViewed
+		return x.InFlight
 	}
-	return ""
+	return 0
 }
 
-type Posts struct {
+func (x *ServerStats) GetLatencyP50Ms() float64 {
+	if x != nil {
+		return x.LatencyP50Ms
+	}
+	return 0
+}
+
+func (x *ServerStats) GetLatencyP90Ms() float64 {
+	if x != nil {
+		return x.LatencyP90Ms
+	}
+	return 0
+}
+
+func (x *ServerStats) GetLatencyP99Ms() float64 {
+	if x != nil {
+		return x.LatencyP99Ms
+	}
+	return 0
+}
+
+func (x *ServerStats) GetLatencyMaxMs() float64 {
+	if x != nil {
+		return x.LatencyMaxMs
+	}
+	return 0
+}
+
+func (x *ServerStats) GetMethods() []*MethodStats {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *ServerStats) GetHeapAllocBytes() uint64 {
+	if x != nil {
+		return x.HeapAllocBytes
+	}
+	return 0
+}
+
+func (x *ServerStats) GetSysBytes() uint64 {
+	if x != nil {
+		return x.SysBytes
+	}
+	return 0
+}
+
+func (x *ServerStats) GetGoroutines() int32 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+func (x *ServerStats) GetGcRuns() uint32 {
+	if x != nil {
+		return x.GcRuns
+	}
+	return 0
+}
+
+func (x *ServerStats) GetGcPauseMs() float64 {
+	if x != nil {
+		return x.GcPauseMs
+	}
+	return 0
+}
+
+type MethodStats struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// This means an array of posts.
-	// It's common to nest message definitions within each other. Basically, every time we have nested object within a payload this one should be extracted into it's own message definition.
-	Posts         []*Post `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	// The name of the RPC, like GetPosts.
+	Method string `protobuf:"bytes,1,opt,name=Method,proto3" json:"Method,omitempty"`
+	Calls  int64  `protobuf:"varint,2,opt,name=Calls,proto3" json:"Calls,omitempty"`
+	Errors int64  `protobuf:"varint,3,opt,name=Errors,proto3" json:"Errors,omitempty"`
+	// 0 for the streams, see ServerStats.
+	MeanLatencyMs float64 `protobuf:"fixed64,4,opt,name=MeanLatencyMs,proto3" json:"MeanLatencyMs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Posts) Reset() {
-	*x = Posts{}
-	mi := &file_blog_proto_msgTypes[1]
+func (x *MethodStats) Reset() {
+	*x = MethodStats{}
+	mi := &file_blog_proto_msgTypes[150]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Posts) String() string {
+func (x *MethodStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Posts) ProtoMessage() {}
+func (*MethodStats) ProtoMessage() {}
 
-func (x *Posts) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[1]
+func (x *MethodStats) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[150]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -148,39 +9698,67 @@ func (x *Posts) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Posts.ProtoReflect.Descriptor instead.
-func (*Posts) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use MethodStats.ProtoReflect.Descriptor instead.
+func (*MethodStats) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{150}
 }
 
-func (x *Posts) GetPosts() []*Post {
+func (x *MethodStats) GetMethod() string {
 	if x != nil {
-		return x.Posts
+		return x.Method
 	}
-	return nil
+	return ""
 }
 
-type GetPostsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *MethodStats) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *MethodStats) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *MethodStats) GetMeanLatencyMs() float64 {
+	if x != nil {
+		return x.MeanLatencyMs
+	}
+	return 0
+}
+
+type ExportEventsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Dataset ExportDataset          `protobuf:"varint,1,opt,name=Dataset,proto3,enum=grpc_tutorial.ExportDataset" json:"Dataset,omitempty"`
+	Format  ExportFormat           `protobuf:"varint,2,opt,name=Format,proto3,enum=grpc_tutorial.ExportFormat" json:"Format,omitempty"`
+	// RFC 3339 timestamps, Since included and Until left out, empty for no limit.
+	Since string `protobuf:"bytes,3,opt,name=Since,proto3" json:"Since,omitempty"`
+	Until string `protobuf:"bytes,4,opt,name=Until,proto3" json:"Until,omitempty"`
+	// Put the file in the bucket of -attachments-backend s3, under exports/, rather than send it back.
+	ToBucket      bool `protobuf:"varint,5,opt,name=ToBucket,proto3" json:"ToBucket,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPostsRequest) Reset() {
-	*x = GetPostsRequest{}
-	mi := &file_blog_proto_msgTypes[2]
+func (x *ExportEventsRequest) Reset() {
+	*x = ExportEventsRequest{}
+	mi := &file_blog_proto_msgTypes[151]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPostsRequest) String() string {
+func (x *ExportEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPostsRequest) ProtoMessage() {}
+func (*ExportEventsRequest) ProtoMessage() {}
 
-func (x *GetPostsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[2]
+func (x *ExportEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[151]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -191,36 +9769,74 @@ func (x *GetPostsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPostsRequest.ProtoReflect.Descriptor instead.
-func (*GetPostsRequest) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ExportEventsRequest.ProtoReflect.Descriptor instead.
+func (*ExportEventsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{151}
 }
 
-type CreatePostRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=Title,proto3" json:"Title,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=Content,proto3" json:"Content,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
-	Author        string                 `protobuf:"bytes,4,opt,name=Author,proto3" json:"Author,omitempty"`
+func (x *ExportEventsRequest) GetDataset() ExportDataset {
+	if x != nil {
+		return x.Dataset
+	}
+	return ExportDataset_EXPORT_AUDIT_LOG
+}
+
+func (x *ExportEventsRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_CSV
+}
+
+func (x *ExportEventsRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *ExportEventsRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *ExportEventsRequest) GetToBucket() bool {
+	if x != nil {
+		return x.ToBucket
+	}
+	return false
+}
+
+// The file comes in chunks of Data, the last message has the totals.
+type ExportChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  []byte                 `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+	// Set on the last message only.
+	Rows  int64 `protobuf:"varint,2,opt,name=Rows,proto3" json:"Rows,omitempty"`
+	Bytes int64 `protobuf:"varint,3,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+	// The key of the file in the bucket, with ToBucket.
+	BucketKey     string `protobuf:"bytes,4,opt,name=BucketKey,proto3" json:"BucketKey,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePostRequest) Reset() {
-	*x = CreatePostRequest{}
-	mi := &file_blog_proto_msgTypes[3]
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_blog_proto_msgTypes[152]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePostRequest) String() string {
+func (x *ExportChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePostRequest) ProtoMessage() {}
+func (*ExportChunk) ProtoMessage() {}
 
-func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[3]
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[152]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,35 +9847,35 @@ func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePostRequest.ProtoReflect.Descriptor instead.
-func (*CreatePostRequest) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{152}
 }
 
-func (x *CreatePostRequest) GetTitle() string {
+func (x *ExportChunk) GetData() []byte {
 	if x != nil {
-		return x.Title
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-func (x *CreatePostRequest) GetContent() string {
+func (x *ExportChunk) GetRows() int64 {
 	if x != nil {
-		return x.Content
+		return x.Rows
 	}
-	return ""
+	return 0
 }
 
-func (x *CreatePostRequest) GetCreatedAt() string {
+func (x *ExportChunk) GetBytes() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Bytes
 	}
-	return ""
+	return 0
 }
 
-func (x *CreatePostRequest) GetAuthor() string {
+func (x *ExportChunk) GetBucketKey() string {
 	if x != nil {
-		return x.Author
+		return x.BucketKey
 	}
 	return ""
 }
@@ -269,7 +9885,7 @@ var File_blog_proto protoreflect.FileDescriptor
 const file_blog_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"blog.proto\x12\rgrpc_tutorial\"\xaa\x01\n" +
+	"blog.proto\x12\rgrpc_tutorial\x1a google/protobuf/field_mask.proto\x1a\x1bbuf/validate/validate.proto\"\xce\x05\n" +
 	"\x04Post\x12\x14\n" +
 	"\x05Title\x18\x01 \x01(\tR\x05Title\x12\x18\n" +
 	"\aContent\x18\x02 \x01(\tR\aContent\x12\x1c\n" +
@@ -278,19 +9894,791 @@ const file_blog_proto_rawDesc = "" +
 	"\tViewCount\x18\x05 \x01(\x03R\tViewCount\x12\x1e\n" +
 	"\n" +
 	"LastViewed\x18\x06 \x01(\tR\n" +
-	"LastViewed\"2\n" +
+	"LastViewed\x12\x0e\n" +
+	"\x02Id\x18\a \x01(\tR\x02Id\x12;\n" +
+	"\vAttachments\x18\b \x03(\v2\x19.grpc_tutorial.AttachmentR\vAttachments\x12\x1c\n" +
+	"\tPublishAt\x18\t \x01(\tR\tPublishAt\x121\n" +
+	"\x06Status\x18\n" +
+	" \x01(\x0e2\x19.grpc_tutorial.PostStatusR\x06Status\x12\x1a\n" +
+	"\bSequence\x18\v \x01(\x03R\bSequence\x12$\n" +
+	"\rUniqueViewers\x18\f \x01(\x03R\rUniqueViewers\x12\x12\n" +
+	"\x04Tags\x18\r \x03(\tR\x04Tags\x12\x18\n" +
+	"\aFlagged\x18\x0e \x01(\bR\aFlagged\x12\x1e\n" +
+	"\n" +
+	"FlagReason\x18\x0f \x01(\tR\n" +
+	"FlagReason\x12\x12\n" +
+	"\x04Slug\x18\x10 \x01(\tR\x04Slug\x12\x1c\n" +
+	"\tUpdatedAt\x18\x11 \x01(\tR\tUpdatedAt\x12\x16\n" +
+	"\x06Pinned\x18\x12 \x01(\bR\x06Pinned\x12 \n" +
+	"\vPinPosition\x18\x13 \x01(\x05R\vPinPosition\x12\x1c\n" +
+	"\tWordCount\x18\x14 \x01(\x05R\tWordCount\x12&\n" +
+	"\x0eReadingMinutes\x18\x15 \x01(\x05R\x0eReadingMinutes\x12$\n" +
+	"\rLinkedPostIds\x18\x16 \x03(\tR\rLinkedPostIds\x12\x1c\n" +
+	"\tCreatedBy\x18\x17 \x01(\tR\tCreatedBy\"\x8c\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x1a\n" +
+	"\bFilename\x18\x02 \x01(\tR\bFilename\x12 \n" +
+	"\vContentType\x18\x03 \x01(\tR\vContentType\x12\x12\n" +
+	"\x04Size\x18\x04 \x01(\x03R\x04Size\x12\x1c\n" +
+	"\tCreatedAt\x18\x05 \x01(\tR\tCreatedAt\"X\n" +
 	"\x05Posts\x12)\n" +
-	"\x05posts\x18\x01 \x03(\v2\x13.grpc_tutorial.PostR\x05posts\"\x11\n" +
-	"\x0fGetPostsRequest\"y\n" +
-	"\x11CreatePostRequest\x12\x14\n" +
-	"\x05Title\x18\x01 \x01(\tR\x05Title\x12\x18\n" +
+	"\x05posts\x18\x01 \x03(\v2\x13.grpc_tutorial.PostR\x05posts\x12$\n" +
+	"\rNextPageToken\x18\x02 \x01(\tR\rNextPageToken\"\xc2\x01\n" +
+	"\n" +
+	"PostFilter\x12\x18\n" +
+	"\aAuthors\x18\x01 \x03(\tR\aAuthors\x12\x14\n" +
+	"\x05Since\x18\x02 \x01(\tR\x05Since\x12\x14\n" +
+	"\x05Until\x18\x03 \x01(\tR\x05Until\x12\x12\n" +
+	"\x04Tags\x18\x04 \x03(\tR\x04Tags\x12,\n" +
+	"\x11MinReadingMinutes\x18\x05 \x01(\x05R\x11MinReadingMinutes\x12,\n" +
+	"\x11MaxReadingMinutes\x18\x06 \x01(\x05R\x11MaxReadingMinutes\"\x95\x02\n" +
+	"\x0fGetPostsRequest\x121\n" +
+	"\x06Filter\x18\x01 \x01(\v2\x19.grpc_tutorial.PostFilterR\x06Filter\x126\n" +
+	"\bReadMask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\bReadMask\x122\n" +
+	"\aOrderBy\x18\x03 \x01(\x0e2\x18.grpc_tutorial.PostOrderR\aOrderBy\x12%\n" +
+	"\bPageSize\x18\x04 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\bPageSize\x12\x1c\n" +
+	"\tPageToken\x18\x05 \x01(\tR\tPageToken\x12\x1e\n" +
+	"\n" +
+	"UnreadOnly\x18\x06 \x01(\bR\n" +
+	"UnreadOnly\"\xaf\x02\n" +
+	"\x11CreatePostRequest\x12Z\n" +
+	"\x05Title\x18\x01 \x01(\tBD\xbaHA\xba\x016\n" +
+	"\tnot_blank\x12\x14value can't be blank\x1a\x13this.matches('\\\\S')\xc8\x01\x01r\x03\x18\xc8\x01R\x05Title\x12\x18\n" +
 	"\aContent\x18\x02 \x01(\tR\aContent\x12\x1c\n" +
 	"\tCreatedAt\x18\x03 \x01(\tR\tCreatedAt\x12\x16\n" +
-	"\x06Author\x18\x04 \x01(\tR\x06Author2\x8d\x01\n" +
+	"\x06Author\x18\x04 \x01(\tR\x06Author\x12\x1c\n" +
+	"\tPublishAt\x18\x05 \x01(\tR\tPublishAt\x12\x12\n" +
+	"\x04Tags\x18\x06 \x03(\tR\x04Tags\x12&\n" +
+	"\x0eAllowDuplicate\x18\a \x01(\bR\x0eAllowDuplicate\x12\x14\n" +
+	"\x05Draft\x18\b \x01(\bR\x05Draft\"\xc9\x01\n" +
+	"\x11UpdatePostRequest\x12\x16\n" +
+	"\x02Id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02Id\x12\x1e\n" +
+	"\x05Title\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xc8\x01R\x05Title\x12\x18\n" +
+	"\aContent\x18\x03 \x01(\tR\aContent\x12\x16\n" +
+	"\x06Author\x18\x04 \x01(\tR\x06Author\x12\x1c\n" +
+	"\tPublishAt\x18\x05 \x01(\tR\tPublishAt\x12\x12\n" +
+	"\x04Tags\x18\x06 \x03(\tR\x04Tags\x12\x18\n" +
+	"\aPublish\x18\a \x01(\bR\aPublish\",\n" +
+	"\x12SyncChangesRequest\x12\x16\n" +
+	"\x06Cursor\x18\x01 \x01(\x03R\x06Cursor\"x\n" +
+	"\x13SyncChangesResponse\x12)\n" +
+	"\x05Posts\x18\x01 \x03(\v2\x13.grpc_tutorial.PostR\x05Posts\x12\x16\n" +
+	"\x06Cursor\x18\x02 \x01(\x03R\x06Cursor\x12\x1e\n" +
+	"\n" +
+	"DeletedIds\x18\x03 \x03(\tR\n" +
+	"DeletedIds\"j\n" +
+	"\x12AttachmentMetadata\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\x12\x1a\n" +
+	"\bFilename\x18\x02 \x01(\tR\bFilename\x12 \n" +
+	"\vContentType\x18\x03 \x01(\tR\vContentType\"z\n" +
+	"\x17UploadAttachmentRequest\x12?\n" +
+	"\bMetadata\x18\x01 \x01(\v2!.grpc_tutorial.AttachmentMetadataH\x00R\bMetadata\x12\x16\n" +
+	"\x05Chunk\x18\x02 \x01(\fH\x00R\x05ChunkB\x06\n" +
+	"\x04Data\"W\n" +
+	"\x19DownloadAttachmentRequest\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\x12\"\n" +
+	"\fAttachmentId\x18\x02 \x01(\tR\fAttachmentId\"u\n" +
+	"\x1aDownloadAttachmentResponse\x127\n" +
+	"\bMetadata\x18\x01 \x01(\v2\x19.grpc_tutorial.AttachmentH\x00R\bMetadata\x12\x16\n" +
+	"\x05Chunk\x18\x02 \x01(\fH\x00R\x05ChunkB\x06\n" +
+	"\x04Data\"#\n" +
+	"\x11RenderPostRequest\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\"j\n" +
+	"\fRenderedPost\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x14\n" +
+	"\x05Title\x18\x02 \x01(\tR\x05Title\x12\x12\n" +
+	"\x04Html\x18\x03 \x01(\tR\x04Html\x12 \n" +
+	"\vPublishedOn\x18\x04 \x01(\tR\vPublishedOn\"\x82\x01\n" +
+	"\x11WatchPostsRequest\x122\n" +
+	"\x05Types\x18\x01 \x03(\x0e2\x1c.grpc_tutorial.PostEventTypeR\x05Types\x12\x18\n" +
+	"\aAuthors\x18\x02 \x03(\tR\aAuthors\x12\x1f\n" +
+	"\x06Cursor\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x06Cursor\"~\n" +
+	"\tPostEvent\x120\n" +
+	"\x04Type\x18\x01 \x01(\x0e2\x1c.grpc_tutorial.PostEventTypeR\x04Type\x12'\n" +
+	"\x04Post\x18\x02 \x01(\v2\x13.grpc_tutorial.PostR\x04Post\x12\x16\n" +
+	"\x06Cursor\x18\x03 \x01(\x03R\x06Cursor\"\x9c\x01\n" +
+	"\bRevision\x12\x16\n" +
+	"\x06Number\x18\x01 \x01(\x03R\x06Number\x12\x14\n" +
+	"\x05Title\x18\x02 \x01(\tR\x05Title\x12\x18\n" +
+	"\aContent\x18\x03 \x01(\tR\aContent\x12\x16\n" +
+	"\x06Author\x18\x04 \x01(\tR\x06Author\x12\x1c\n" +
+	"\tCreatedAt\x18\x05 \x01(\tR\tCreatedAt\x12\x12\n" +
+	"\x04Tags\x18\x06 \x03(\tR\x04Tags\"B\n" +
+	"\tRevisions\x125\n" +
+	"\tRevisions\x18\x01 \x03(\v2\x17.grpc_tutorial.RevisionR\tRevisions\".\n" +
+	"\x14ListRevisionsRequest\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\"H\n" +
+	"\x16RestoreRevisionRequest\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\x12\x16\n" +
+	"\x06Number\x18\x02 \x01(\x03R\x06Number\"+\n" +
+	"\x11DeletePostRequest\x12\x16\n" +
+	"\x02Id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02Id\"\x14\n" +
+	"\x12DeletePostResponse\"\xae\x01\n" +
+	"\n" +
+	"AuditEntry\x12\x12\n" +
+	"\x04Time\x18\x01 \x01(\tR\x04Time\x12\x1a\n" +
+	"\bIdentity\x18\x02 \x01(\tR\bIdentity\x12\x12\n" +
+	"\x04Peer\x18\x03 \x01(\tR\x04Peer\x12\x16\n" +
+	"\x06Method\x18\x04 \x01(\tR\x06Method\x12\x16\n" +
+	"\x06PostId\x18\x05 \x01(\tR\x06PostId\x12\x12\n" +
+	"\x04Code\x18\x06 \x01(\tR\x04Code\x12\x18\n" +
+	"\aRequest\x18\a \x01(\tR\aRequest\"C\n" +
+	"\fAuditEntries\x123\n" +
+	"\aEntries\x18\x01 \x03(\v2\x19.grpc_tutorial.AuditEntryR\aEntries\"\x8c\x01\n" +
+	"\x14QueryAuditLogRequest\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\x12\x1a\n" +
+	"\bIdentity\x18\x02 \x01(\tR\bIdentity\x12\x14\n" +
+	"\x05Since\x18\x03 \x01(\tR\x05Since\x12\x14\n" +
+	"\x05Until\x18\x04 \x01(\tR\x05Until\x12\x14\n" +
+	"\x05Limit\x18\x05 \x01(\x05R\x05Limit\",\n" +
+	"\x12StreamPostsRequest\x12\x16\n" +
+	"\x06Cursor\x18\x01 \x01(\tR\x06Cursor\"V\n" +
+	"\x13StreamPostsResponse\x12'\n" +
+	"\x04Post\x18\x01 \x01(\v2\x13.grpc_tutorial.PostR\x04Post\x12\x16\n" +
+	"\x06Cursor\x18\x02 \x01(\tR\x06Cursor\"\x95\x01\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x10\n" +
+	"\x03Url\x18\x02 \x01(\tR\x03Url\x12\x16\n" +
+	"\x06Secret\x18\x03 \x01(\tR\x06Secret\x122\n" +
+	"\x05Types\x18\x04 \x03(\x0e2\x1c.grpc_tutorial.PostEventTypeR\x05Types\x12\x1c\n" +
+	"\tCreatedAt\x18\x05 \x01(\tR\tCreatedAt\">\n" +
+	"\bWebhooks\x122\n" +
+	"\bWebhooks\x18\x01 \x03(\v2\x16.grpc_tutorial.WebhookR\bWebhooks\"v\n" +
+	"\x16RegisterWebhookRequest\x12\x10\n" +
+	"\x03Url\x18\x01 \x01(\tR\x03Url\x12\x16\n" +
+	"\x06Secret\x18\x02 \x01(\tR\x06Secret\x122\n" +
+	"\x05Types\x18\x03 \x03(\x0e2\x1c.grpc_tutorial.PostEventTypeR\x05Types\"*\n" +
+	"\x18UnregisterWebhookRequest\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\"\x1b\n" +
+	"\x19UnregisterWebhookResponse\"\x15\n" +
+	"\x13ListWebhooksRequest\"\xd6\x01\n" +
+	"\fPostTemplate\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x14\n" +
+	"\x05Title\x18\x03 \x01(\tR\x05Title\x12\x18\n" +
+	"\aContent\x18\x04 \x01(\tR\aContent\x12\x12\n" +
+	"\x04Tags\x18\x05 \x03(\tR\x04Tags\x12\x1c\n" +
+	"\tCreatedAt\x18\x06 \x01(\tR\tCreatedAt\x12\x1c\n" +
+	"\tUpdatedAt\x18\a \x01(\tR\tUpdatedAt\x12\"\n" +
+	"\fPlaceholders\x18\b \x03(\tR\fPlaceholders\"J\n" +
+	"\rPostTemplates\x129\n" +
+	"\tTemplates\x18\x01 \x03(\v2\x1b.grpc_tutorial.PostTemplateR\tTemplates\"o\n" +
+	"\x15CreateTemplateRequest\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\x12\x14\n" +
+	"\x05Title\x18\x02 \x01(\tR\x05Title\x12\x18\n" +
+	"\aContent\x18\x03 \x01(\tR\aContent\x12\x12\n" +
+	"\x04Tags\x18\x04 \x03(\tR\x04Tags\"$\n" +
+	"\x12GetTemplateRequest\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\"\x16\n" +
+	"\x14ListTemplatesRequest\"\x7f\n" +
+	"\x15UpdateTemplateRequest\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x14\n" +
+	"\x05Title\x18\x03 \x01(\tR\x05Title\x12\x18\n" +
+	"\aContent\x18\x04 \x01(\tR\aContent\x12\x12\n" +
+	"\x04Tags\x18\x05 \x03(\tR\x04Tags\"'\n" +
+	"\x15DeleteTemplateRequest\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\"\x18\n" +
+	"\x16DeleteTemplateResponse\"\x94\x02\n" +
+	"\x1dCreatePostFromTemplateRequest\x12\x1a\n" +
+	"\bTemplate\x18\x01 \x01(\tR\bTemplate\x12\x16\n" +
+	"\x06Author\x18\x02 \x01(\tR\x06Author\x12P\n" +
+	"\x06Values\x18\x03 \x03(\v28.grpc_tutorial.CreatePostFromTemplateRequest.ValuesEntryR\x06Values\x12\x1c\n" +
+	"\tPublishAt\x18\x04 \x01(\tR\tPublishAt\x12\x14\n" +
+	"\x05Draft\x18\x05 \x01(\bR\x05Draft\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x01\n" +
+	"\x06Series\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x14\n" +
+	"\x05Title\x18\x02 \x01(\tR\x05Title\x12 \n" +
+	"\vDescription\x18\x03 \x01(\tR\vDescription\x12\x18\n" +
+	"\aPostIds\x18\x04 \x03(\tR\aPostIds\x12\x1c\n" +
+	"\tCreatedAt\x18\x05 \x01(\tR\tCreatedAt\x12\x1c\n" +
+	"\tUpdatedAt\x18\x06 \x01(\tR\tUpdatedAt\";\n" +
+	"\n" +
+	"SeriesList\x12-\n" +
+	"\x06Series\x18\x01 \x03(\v2\x15.grpc_tutorial.SeriesR\x06Series\"\xa8\x01\n" +
+	"\x13CreateSeriesRequest\x12U\n" +
+	"\x05Title\x18\x01 \x01(\tB?\xbaH<\xba\x016\n" +
+	"\tnot_blank\x12\x14value can't be blank\x1a\x13this.matches('\\\\S')\xc8\x01\x01R\x05Title\x12 \n" +
+	"\vDescription\x18\x02 \x01(\tR\vDescription\x12\x18\n" +
+	"\aPostIds\x18\x03 \x03(\tR\aPostIds\"\x13\n" +
+	"\x11ListSeriesRequest\"\"\n" +
+	"\x10GetSeriesRequest\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\"m\n" +
+	"\vSeriesPosts\x12-\n" +
+	"\x06Series\x18\x01 \x01(\v2\x15.grpc_tutorial.SeriesR\x06Series\x12/\n" +
+	"\x05Posts\x18\x02 \x03(\v2\x19.grpc_tutorial.SeriesPostR\x05Posts\"\x89\x01\n" +
+	"\n" +
+	"SeriesPost\x12'\n" +
+	"\x04Post\x18\x01 \x01(\v2\x13.grpc_tutorial.PostR\x04Post\x12\x1a\n" +
+	"\bPosition\x18\x02 \x01(\x05R\bPosition\x12\x1e\n" +
+	"\n" +
+	"PreviousId\x18\x03 \x01(\tR\n" +
+	"PreviousId\x12\x16\n" +
+	"\x06NextId\x18\x04 \x01(\tR\x06NextId\"m\n" +
+	"\x12AddToSeriesRequest\x12\x1a\n" +
+	"\bSeriesId\x18\x01 \x01(\tR\bSeriesId\x12\x16\n" +
+	"\x06PostId\x18\x02 \x01(\tR\x06PostId\x12#\n" +
+	"\bPosition\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bPosition\"M\n" +
+	"\x17RemoveFromSeriesRequest\x12\x1a\n" +
+	"\bSeriesId\x18\x01 \x01(\tR\bSeriesId\x12\x16\n" +
+	"\x06PostId\x18\x02 \x01(\tR\x06PostId\"L\n" +
+	"\x14ReorderSeriesRequest\x12\x1a\n" +
+	"\bSeriesId\x18\x01 \x01(\tR\bSeriesId\x12\x18\n" +
+	"\aPostIds\x18\x02 \x03(\tR\aPostIds\"%\n" +
+	"\x13DeleteSeriesRequest\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\"\x16\n" +
+	"\x14DeleteSeriesResponse\"/\n" +
+	"\x17SubscribeByEmailRequest\x12\x14\n" +
+	"\x05Email\x18\x01 \x01(\tR\x05Email\"\x1a\n" +
+	"\x18SubscribeByEmailResponse\"1\n" +
+	"\x19UnsubscribeByEmailRequest\x12\x14\n" +
+	"\x05Token\x18\x01 \x01(\tR\x05Token\"\x1c\n" +
+	"\x1aUnsubscribeByEmailResponse\"\x81\x01\n" +
+	"\x17GetAttachmentURLRequest\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\x12\"\n" +
+	"\fAttachmentId\x18\x02 \x01(\tR\fAttachmentId\x12*\n" +
+	"\x10ExpiresInSeconds\x18\x03 \x01(\x03R\x10ExpiresInSeconds\"?\n" +
+	"\rAttachmentURL\x12\x10\n" +
+	"\x03Url\x18\x01 \x01(\tR\x03Url\x12\x1c\n" +
+	"\tExpiresAt\x18\x02 \x01(\tR\tExpiresAt\"J\n" +
+	"\x16SetDebugLoggingRequest\x12\x18\n" +
+	"\aEnabled\x18\x01 \x01(\bR\aEnabled\x12\x16\n" +
+	"\x06Redact\x18\x02 \x03(\tR\x06Redact\"\x18\n" +
+	"\x16GetDebugLoggingRequest\"@\n" +
+	"\fDebugLogging\x12\x18\n" +
+	"\aEnabled\x18\x01 \x01(\bR\aEnabled\x12\x16\n" +
+	"\x06Redact\x18\x02 \x03(\tR\x06Redact\"\x94\x01\n" +
+	"\x15SetMaintenanceRequest\x12\x18\n" +
+	"\aEnabled\x18\x01 \x01(\bR\aEnabled\x12\x16\n" +
+	"\x06Reason\x18\x02 \x01(\tR\x06Reason\x125\n" +
+	"\x11RetryAfterSeconds\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x11RetryAfterSeconds\x12\x12\n" +
+	"\x04Wait\x18\x04 \x01(\bR\x04Wait\"\x17\n" +
+	"\x15GetMaintenanceRequest\"\x9f\x01\n" +
+	"\vMaintenance\x12\x18\n" +
+	"\aEnabled\x18\x01 \x01(\bR\aEnabled\x12\x16\n" +
+	"\x06Reason\x18\x02 \x01(\tR\x06Reason\x12,\n" +
+	"\x11RetryAfterSeconds\x18\x03 \x01(\x05R\x11RetryAfterSeconds\x12\x14\n" +
+	"\x05Since\x18\x04 \x01(\tR\x05Since\x12\x1a\n" +
+	"\bInFlight\x18\x05 \x01(\x05R\bInFlight\"\x15\n" +
+	"\x13ReloadConfigRequest\"Z\n" +
+	"\fConfigChange\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\x12\x10\n" +
+	"\x03Old\x18\x02 \x01(\tR\x03Old\x12\x10\n" +
+	"\x03New\x18\x03 \x01(\tR\x03New\x12\x12\n" +
+	"\x04Note\x18\x04 \x01(\tR\x04Note\"\xa0\x01\n" +
+	"\fConfigReload\x12\x12\n" +
+	"\x04Path\x18\x01 \x01(\tR\x04Path\x125\n" +
+	"\aApplied\x18\x02 \x03(\v2\x1b.grpc_tutorial.ConfigChangeR\aApplied\x12E\n" +
+	"\x0fRequiresRestart\x18\x03 \x03(\v2\x1b.grpc_tutorial.ConfigChangeR\x0fRequiresRestart\"\x18\n" +
+	"\x16GetStorageStatsRequest\"\x80\x03\n" +
+	"\fStorageStats\x12\x18\n" +
+	"\aBackend\x18\x01 \x01(\tR\aBackend\x12\"\n" +
+	"\fStorageBytes\x18\x02 \x01(\x03R\fStorageBytes\x12\x14\n" +
+	"\x05Posts\x18\x03 \x01(\x05R\x05Posts\x12(\n" +
+	"\x0fCompressedPosts\x18\x04 \x01(\x05R\x0fCompressedPosts\x12\"\n" +
+	"\fContentBytes\x18\x05 \x01(\x03R\fContentBytes\x12.\n" +
+	"\x12StoredContentBytes\x18\x06 \x01(\x03R\x12StoredContentBytes\x12,\n" +
+	"\x11CompressThreshold\x18\a \x01(\x03R\x11CompressThreshold\x12\"\n" +
+	"\fPendingSaves\x18\b \x01(\x05R\fPendingSaves\x12&\n" +
+	"\x0eEncryptedPosts\x18\t \x01(\x05R\x0eEncryptedPosts\x12$\n" +
+	"\rEncryptionKey\x18\n" +
+	" \x01(\tR\rEncryptionKey\"\x15\n" +
+	"\x13FlushStorageRequest\"$\n" +
+	"\fStorageFlush\x12\x14\n" +
+	"\x05Saves\x18\x01 \x01(\x05R\x05Saves\"\x19\n" +
+	"\x17ReencryptStorageRequest\"}\n" +
+	"\x13StorageReencryption\x12\x14\n" +
+	"\x05Posts\x18\x01 \x01(\x05R\x05Posts\x12 \n" +
+	"\vReencrypted\x18\x02 \x01(\x05R\vReencrypted\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x1c\n" +
+	"\tRevisions\x18\x04 \x01(\x05R\tRevisions\"\x1b\n" +
+	"\x19ListScheduledTasksRequest\"-\n" +
+	"\x17RunScheduledTaskRequest\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\"\x9f\x02\n" +
+	"\rScheduledTask\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\x12 \n" +
+	"\vDescription\x18\x02 \x01(\tR\vDescription\x12\x1a\n" +
+	"\bSchedule\x18\x03 \x01(\tR\bSchedule\x12\x18\n" +
+	"\aNextRun\x18\x04 \x01(\tR\aNextRun\x12\x18\n" +
+	"\aLastRun\x18\x05 \x01(\tR\aLastRun\x120\n" +
+	"\x13LastDurationSeconds\x18\x06 \x01(\x01R\x13LastDurationSeconds\x12\x1e\n" +
+	"\n" +
+	"LastResult\x18\a \x01(\tR\n" +
+	"LastResult\x12\x1c\n" +
+	"\tLastError\x18\b \x01(\tR\tLastError\x12\x18\n" +
+	"\aRunning\x18\t \x01(\bR\aRunning\"D\n" +
+	"\x0eScheduledTasks\x122\n" +
+	"\x05Tasks\x18\x01 \x03(\v2\x1c.grpc_tutorial.ScheduledTaskR\x05Tasks\"^\n" +
+	"\x1cGetPublishingScheduleRequest\x12\x12\n" +
+	"\x04From\x18\x01 \x01(\tR\x04From\x12\x0e\n" +
+	"\x02To\x18\x02 \x01(\tR\x02To\x12\x1a\n" +
+	"\bTimeZone\x18\x03 \x01(\tR\bTimeZone\"a\n" +
+	"\x12PublishingSchedule\x12\x1a\n" +
+	"\bTimeZone\x18\x01 \x01(\tR\bTimeZone\x12/\n" +
+	"\x04Days\x18\x02 \x03(\v2\x1b.grpc_tutorial.ScheduledDayR\x04Days\"V\n" +
+	"\fScheduledDay\x12\x12\n" +
+	"\x04Date\x18\x01 \x01(\tR\x04Date\x122\n" +
+	"\x05Posts\x18\x02 \x03(\v2\x1c.grpc_tutorial.ScheduledPostR\x05Posts\"k\n" +
+	"\rScheduledPost\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x14\n" +
+	"\x05Title\x18\x02 \x01(\tR\x05Title\x12\x16\n" +
+	"\x06Author\x18\x03 \x01(\tR\x06Author\x12\x1c\n" +
+	"\tPublishAt\x18\x04 \x01(\tR\tPublishAt\"`\n" +
+	"\x17GetTrendingPostsRequest\x12$\n" +
+	"\rWindowSeconds\x18\x01 \x01(\x03R\rWindowSeconds\x12\x1f\n" +
+	"\x05Limit\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\x05Limit\"M\n" +
+	"\fTrendingPost\x12'\n" +
+	"\x04Post\x18\x01 \x01(\v2\x13.grpc_tutorial.PostR\x04Post\x12\x14\n" +
+	"\x05Views\x18\x02 \x01(\x03R\x05Views\"B\n" +
+	"\rTrendingPosts\x121\n" +
+	"\x05Posts\x18\x01 \x03(\v2\x1b.grpc_tutorial.TrendingPostR\x05Posts\"\x83\x01\n" +
+	"\x17GetPostAnalyticsRequest\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\x12\x14\n" +
+	"\x05Since\x18\x02 \x01(\tR\x05Since\x12\x14\n" +
+	"\x05Until\x18\x03 \x01(\tR\x05Until\x12$\n" +
+	"\rBucketSeconds\x18\x04 \x01(\x03R\rBucketSeconds\"8\n" +
+	"\n" +
+	"ViewBucket\x12\x14\n" +
+	"\x05Start\x18\x01 \x01(\tR\x05Start\x12\x14\n" +
+	"\x05Views\x18\x02 \x01(\x03R\x05Views\"r\n" +
+	"\rPostAnalytics\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\x12\x14\n" +
+	"\x05Views\x18\x02 \x01(\x03R\x05Views\x123\n" +
+	"\aBuckets\x18\x03 \x03(\v2\x19.grpc_tutorial.ViewBucketR\aBuckets\"\x83\x01\n" +
+	"\x16GetDailySummaryRequest\x12\x12\n" +
+	"\x04Date\x18\x01 \x01(\tR\x04Date\x124\n" +
+	"\x06Period\x18\x02 \x01(\x0e2\x1c.grpc_tutorial.SummaryPeriodR\x06Period\x12\x1f\n" +
+	"\x05Limit\x18\x03 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\x05Limit\"\xa5\x01\n" +
+	"\vPostSummary\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\x12\x14\n" +
+	"\x05Title\x18\x02 \x01(\tR\x05Title\x12\x16\n" +
+	"\x06Author\x18\x03 \x01(\tR\x06Author\x12\x14\n" +
+	"\x05Views\x18\x04 \x01(\x03R\x05Views\x12$\n" +
+	"\rPreviousViews\x18\x05 \x01(\x03R\rPreviousViews\x12\x14\n" +
+	"\x05Delta\x18\x06 \x01(\x03R\x05Delta\"y\n" +
+	"\rAuthorSummary\x12\x16\n" +
+	"\x06Author\x18\x01 \x01(\tR\x06Author\x12\x14\n" +
+	"\x05Views\x18\x02 \x01(\x03R\x05Views\x12$\n" +
+	"\rPreviousViews\x18\x03 \x01(\x03R\rPreviousViews\x12\x14\n" +
+	"\x05Delta\x18\x04 \x01(\x03R\x05Delta\"\xf4\x02\n" +
+	"\fDailySummary\x124\n" +
+	"\x06Period\x18\x01 \x01(\x0e2\x1c.grpc_tutorial.SummaryPeriodR\x06Period\x12\x14\n" +
+	"\x05Start\x18\x02 \x01(\tR\x05Start\x12\x10\n" +
+	"\x03End\x18\x03 \x01(\tR\x03End\x12\x14\n" +
+	"\x05Views\x18\x04 \x01(\x03R\x05Views\x12$\n" +
+	"\rPreviousViews\x18\x05 \x01(\x03R\rPreviousViews\x12\x14\n" +
+	"\x05Delta\x18\x06 \x01(\x03R\x05Delta\x12\"\n" +
+	"\fDeltaPercent\x18\a \x01(\x01R\fDeltaPercent\x12\x1a\n" +
+	"\bComplete\x18\b \x01(\bR\bComplete\x126\n" +
+	"\bTopPosts\x18\t \x03(\v2\x1a.grpc_tutorial.PostSummaryR\bTopPosts\x12<\n" +
+	"\n" +
+	"TopAuthors\x18\n" +
+	" \x03(\v2\x1c.grpc_tutorial.AuthorSummaryR\n" +
+	"TopAuthors\"f\n" +
+	"\x17GetAuthorProfileRequest\x12\x1e\n" +
+	"\x06Author\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06Author\x12+\n" +
+	"\vRecentPosts\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x182(\x00R\vRecentPosts\"Q\n" +
+	"\x13SetAuthorBioRequest\x12\x1e\n" +
+	"\x06Author\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06Author\x12\x1a\n" +
+	"\x03Bio\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\x03Bio\"0\n" +
+	"\x06TagUse\x12\x10\n" +
+	"\x03Tag\x18\x01 \x01(\tR\x03Tag\x12\x14\n" +
+	"\x05Posts\x18\x02 \x01(\x05R\x05Posts\"\xdf\x01\n" +
+	"\rAuthorProfile\x12\x16\n" +
+	"\x06Author\x18\x01 \x01(\tR\x06Author\x12\x10\n" +
+	"\x03Bio\x18\x02 \x01(\tR\x03Bio\x12\x1c\n" +
+	"\tPostCount\x18\x03 \x01(\x05R\tPostCount\x12\x1e\n" +
+	"\n" +
+	"TotalViews\x18\x04 \x01(\x03R\n" +
+	"TotalViews\x125\n" +
+	"\vRecentPosts\x18\x05 \x03(\v2\x13.grpc_tutorial.PostR\vRecentPosts\x12/\n" +
+	"\aTopTags\x18\x06 \x03(\v2\x15.grpc_tutorial.TagUseR\aTopTags\"G\n" +
+	"\x11RecordViewRequest\x12\x16\n" +
+	"\x06PostId\x18\x01 \x01(\tR\x06PostId\x12\x1a\n" +
+	"\bViewerId\x18\x02 \x01(\tR\bViewerId\"r\n" +
+	"\x12RecordViewResponse\x12\x18\n" +
+	"\aCounted\x18\x01 \x01(\bR\aCounted\x12\x1c\n" +
+	"\tViewCount\x18\x02 \x01(\x03R\tViewCount\x12$\n" +
+	"\rUniqueViewers\x18\x03 \x01(\x03R\rUniqueViewers\"Y\n" +
+	"\x16GetRelatedPostsRequest\x12\x1e\n" +
+	"\x06PostId\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06PostId\x12\x1f\n" +
+	"\x05Limit\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x182(\x00R\x05Limit\"l\n" +
+	"\vRelatedPost\x12'\n" +
+	"\x04Post\x18\x01 \x01(\v2\x13.grpc_tutorial.PostR\x04Post\x12\x14\n" +
+	"\x05Score\x18\x02 \x01(\x01R\x05Score\x12\x1e\n" +
+	"\n" +
+	"SharedTags\x18\x03 \x03(\tR\n" +
+	"SharedTags\"@\n" +
+	"\fRelatedPosts\x120\n" +
+	"\x05Posts\x18\x01 \x03(\v2\x1a.grpc_tutorial.RelatedPostR\x05Posts\"P\n" +
+	"\x14GetPostBySlugRequest\x128\n" +
+	"\x04Slug\x18\x01 \x01(\tB$\xbaH!\xc8\x01\x01r\x1c\x18P2\x18^[a-z0-9]+(-[a-z0-9]+)*$R\x04Slug\"5\n" +
+	"\x13GetBacklinksRequest\x12\x1e\n" +
+	"\x06PostId\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06PostId\"\x13\n" +
+	"\x11GetSitemapRequest\"7\n" +
+	"\aSitemap\x12\x10\n" +
+	"\x03Xml\x18\x01 \x01(\tR\x03Xml\x12\x1a\n" +
+	"\bUrlCount\x18\x02 \x01(\x05R\bUrlCount\"\x15\n" +
+	"\x13StartSessionRequest\"Y\n" +
+	"\aSession\x12\x14\n" +
+	"\x05Token\x18\x01 \x01(\tR\x05Token\x12\x1a\n" +
+	"\bViewerId\x18\x02 \x01(\tR\bViewerId\x12\x1c\n" +
+	"\tExpiresAt\x18\x03 \x01(\tR\tExpiresAt\"\x15\n" +
+	"\x13GetChallengeRequest\"S\n" +
+	"\tChallenge\x12\x14\n" +
+	"\x05Token\x18\x01 \x01(\tR\x05Token\x12\x12\n" +
+	"\x04Bits\x18\x02 \x01(\x05R\x04Bits\x12\x1c\n" +
+	"\tExpiresAt\x18\x03 \x01(\tR\tExpiresAt\"[\n" +
+	"\x18GetReadingHistoryRequest\x12\x1f\n" +
+	"\x05Limit\x18\x01 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\x05Limit\x12\x1e\n" +
+	"\n" +
+	"InProgress\x18\x02 \x01(\bR\n" +
+	"InProgress\"G\n" +
+	"\x0eReadingHistory\x125\n" +
+	"\aEntries\x18\x01 \x03(\v2\x1b.grpc_tutorial.HistoryEntryR\aEntries\"g\n" +
+	"\fHistoryEntry\x12'\n" +
+	"\x04Post\x18\x01 \x01(\v2\x13.grpc_tutorial.PostR\x04Post\x12\x1a\n" +
+	"\bViewedAt\x18\x02 \x01(\tR\bViewedAt\x12\x12\n" +
+	"\x04Read\x18\x03 \x01(\bR\x04Read\"K\n" +
+	"\x11MarkAsReadRequest\x12\x1e\n" +
+	"\x06PostId\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06PostId\x12\x16\n" +
+	"\x06Unread\x18\x02 \x01(\bR\x06Unread\"\xbb\x01\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x123\n" +
+	"\x04Type\x18\x02 \x01(\x0e2\x1f.grpc_tutorial.NotificationTypeR\x04Type\x12\x16\n" +
+	"\x06PostId\x18\x03 \x01(\tR\x06PostId\x12\x1c\n" +
+	"\tPostTitle\x18\x04 \x01(\tR\tPostTitle\x12\x1c\n" +
+	"\tCreatedAt\x18\x05 \x01(\tR\tCreatedAt\x12\x12\n" +
+	"\x04Read\x18\x06 \x01(\bR\x04Read\"[\n" +
+	"\x18ListNotificationsRequest\x12\x1e\n" +
+	"\n" +
+	"UnreadOnly\x18\x01 \x01(\bR\n" +
+	"UnreadOnly\x12\x1f\n" +
+	"\x05Limit\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\x05Limit\"t\n" +
+	"\rNotifications\x12A\n" +
+	"\rNotifications\x18\x01 \x03(\v2\x1b.grpc_tutorial.NotificationR\rNotifications\x12 \n" +
+	"\vUnreadCount\x18\x02 \x01(\x05R\vUnreadCount\"?\n" +
+	"\x1bMarkNotificationReadRequest\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x10\n" +
+	"\x03All\x18\x02 \x01(\bR\x03All\"\x1c\n" +
+	"\x1aStreamNotificationsRequest\"\x9d\x02\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x16\n" +
+	"\x06PostId\x18\x02 \x01(\tR\x06PostId\x12\x1a\n" +
+	"\bParentId\x18\x03 \x01(\tR\bParentId\x12\x16\n" +
+	"\x06Author\x18\x04 \x01(\tR\x06Author\x12\x18\n" +
+	"\aContent\x18\x05 \x01(\tR\aContent\x12\x1c\n" +
+	"\tCreatedAt\x18\x06 \x01(\tR\tCreatedAt\x124\n" +
+	"\x06Status\x18\a \x01(\x0e2\x1c.grpc_tutorial.CommentStatusR\x06Status\x12*\n" +
+	"\x10ModerationReason\x18\b \x01(\tR\x10ModerationReason\x12\x1c\n" +
+	"\tCreatedBy\x18\t \x01(\tR\tCreatedBy\"\x9a\x01\n" +
+	"\x11AddCommentRequest\x12\x1e\n" +
+	"\x06PostId\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06PostId\x12\x1a\n" +
+	"\bParentId\x18\x02 \x01(\tR\bParentId\x12\"\n" +
+	"\x06Author\x18\x03 \x01(\tB\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x18dR\x06Author\x12%\n" +
+	"\aContent\x18\x04 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x18\x88'R\aContent\"n\n" +
+	"\x12GetCommentsRequest\x12\x1e\n" +
+	"\x06PostId\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06PostId\x128\n" +
+	"\bStatuses\x18\x02 \x03(\x0e2\x1c.grpc_tutorial.CommentStatusR\bStatuses\">\n" +
+	"\bComments\x122\n" +
+	"\bComments\x18\x01 \x03(\v2\x16.grpc_tutorial.CommentR\bComments\"R\n" +
+	"\x16ModerateCommentRequest\x12\x16\n" +
+	"\x02Id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02Id\x12 \n" +
+	"\x06Reason\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\x06Reason\"M\n" +
+	"\x0ePinPostRequest\x12\x16\n" +
+	"\x02Id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02Id\x12#\n" +
+	"\bPosition\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bPosition\"*\n" +
+	"\x10UnpinPostRequest\x12\x16\n" +
+	"\x02Id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02Id\"]\n" +
+	"\x10BulkPostsRequest\x121\n" +
+	"\x06Filter\x18\x01 \x01(\v2\x19.grpc_tutorial.PostFilterR\x06Filter\x12\x16\n" +
+	"\x06DryRun\x18\x02 \x01(\bR\x06DryRun\"S\n" +
+	"\x11BulkPostsResponse\x12\x14\n" +
+	"\x05Count\x18\x01 \x01(\x05R\x05Count\x12\x10\n" +
+	"\x03Ids\x18\x02 \x03(\tR\x03Ids\x12\x16\n" +
+	"\x06DryRun\x18\x03 \x01(\bR\x06DryRun\"\xf0\x02\n" +
+	"\x06Report\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x16\n" +
+	"\x06PostId\x18\x02 \x01(\tR\x06PostId\x12\x1c\n" +
+	"\tCommentId\x18\x03 \x01(\tR\tCommentId\x123\n" +
+	"\x06Reason\x18\x04 \x01(\x0e2\x1b.grpc_tutorial.ReportReasonR\x06Reason\x12\x18\n" +
+	"\aDetails\x18\x05 \x01(\tR\aDetails\x12\x1e\n" +
+	"\n" +
+	"ReportedBy\x18\x06 \x01(\tR\n" +
+	"ReportedBy\x12\x1c\n" +
+	"\tCreatedAt\x18\a \x01(\tR\tCreatedAt\x12?\n" +
+	"\n" +
+	"Resolution\x18\b \x01(\x0e2\x1f.grpc_tutorial.ReportResolutionR\n" +
+	"Resolution\x12\x1e\n" +
+	"\n" +
+	"ResolvedBy\x18\t \x01(\tR\n" +
+	"ResolvedBy\x12\x1e\n" +
+	"\n" +
+	"ResolvedAt\x18\n" +
+	" \x01(\tR\n" +
+	"ResolvedAt\x12\x12\n" +
+	"\x04Note\x18\v \x01(\tR\x04Note\"\x8c\x01\n" +
+	"\x11ReportPostRequest\x12\x1e\n" +
+	"\x06PostId\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06PostId\x123\n" +
+	"\x06Reason\x18\x02 \x01(\x0e2\x1b.grpc_tutorial.ReportReasonR\x06Reason\x12\"\n" +
+	"\aDetails\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xe8\aR\aDetails\"\x95\x01\n" +
+	"\x14ReportCommentRequest\x12$\n" +
+	"\tCommentId\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\tCommentId\x123\n" +
+	"\x06Reason\x18\x02 \x01(\x0e2\x1b.grpc_tutorial.ReportReasonR\x06Reason\x12\"\n" +
+	"\aDetails\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xe8\aR\aDetails\"H\n" +
+	"\x12ListReportsRequest\x12\x1a\n" +
+	"\bResolved\x18\x01 \x01(\bR\bResolved\x12\x16\n" +
+	"\x06PostId\x18\x02 \x01(\tR\x06PostId\":\n" +
+	"\aReports\x12/\n" +
+	"\aReports\x18\x01 \x03(\v2\x15.grpc_tutorial.ReportR\aReports\"\x8d\x01\n" +
+	"\x14ResolveReportRequest\x12\x16\n" +
+	"\x02Id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02Id\x12?\n" +
+	"\n" +
+	"Resolution\x18\x02 \x01(\x0e2\x1f.grpc_tutorial.ReportResolutionR\n" +
+	"Resolution\x12\x1c\n" +
+	"\x04Note\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\x04Note\"\xaf\x01\n" +
+	"\x03Ban\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x1a\n" +
+	"\bIdentity\x18\x02 \x01(\tR\bIdentity\x12\x0e\n" +
+	"\x02Ip\x18\x03 \x01(\tR\x02Ip\x12\x16\n" +
+	"\x06Reason\x18\x04 \x01(\tR\x06Reason\x12\x1a\n" +
+	"\bBannedBy\x18\x05 \x01(\tR\bBannedBy\x12\x1c\n" +
+	"\tCreatedAt\x18\x06 \x01(\tR\tCreatedAt\x12\x1a\n" +
+	"\bReportId\x18\a \x01(\tR\bReportId\"]\n" +
+	"\rAddBanRequest\x12\x1a\n" +
+	"\bIdentity\x18\x01 \x01(\tR\bIdentity\x12\x0e\n" +
+	"\x02Ip\x18\x02 \x01(\tR\x02Ip\x12 \n" +
+	"\x06Reason\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\x06Reason\"*\n" +
+	"\x10RemoveBanRequest\x12\x16\n" +
+	"\x02Id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02Id\"\x13\n" +
+	"\x11RemoveBanResponse\"\x11\n" +
+	"\x0fListBansRequest\".\n" +
+	"\x04Bans\x12&\n" +
+	"\x04Bans\x18\x01 \x03(\v2\x12.grpc_tutorial.BanR\x04Bans\"\x1b\n" +
+	"\x19GetConnectionStatsRequest\"\xdf\x03\n" +
+	"\x0fConnectionStats\x12(\n" +
+	"\x0fOpenConnections\x18\x01 \x01(\x05R\x0fOpenConnections\x12$\n" +
+	"\rActiveStreams\x18\x02 \x01(\x05R\rActiveStreams\x120\n" +
+	"\x13AcceptedConnections\x18\x03 \x01(\x03R\x13AcceptedConnections\x12,\n" +
+	"\x11ClosedConnections\x18\x04 \x01(\x03R\x11ClosedConnections\x12:\n" +
+	"\tListeners\x18\x05 \x03(\v2\x1c.grpc_tutorial.ListenerStatsR\tListeners\x120\n" +
+	"\x05Peers\x18\x06 \x03(\v2\x1a.grpc_tutorial.PeerTrafficR\x05Peers\x120\n" +
+	"\x13RejectedConnections\x18\a \x01(\x03R\x13RejectedConnections\x12$\n" +
+	"\rRejectedCalls\x18\b \x01(\x03R\rRejectedCalls\x120\n" +
+	"\x13MaxConnectionsPerIp\x18\t \x01(\x05R\x13MaxConnectionsPerIp\x12$\n" +
+	"\rMaxCallsPerIp\x18\n" +
+	" \x01(\x05R\rMaxCallsPerIp\"\x85\x01\n" +
+	"\rListenerStats\x12\x18\n" +
+	"\aAddress\x18\x01 \x01(\tR\aAddress\x12(\n" +
+	"\x0fOpenConnections\x18\x02 \x01(\x05R\x0fOpenConnections\x120\n" +
+	"\x13AcceptedConnections\x18\x03 \x01(\x03R\x13AcceptedConnections\"\xf1\x01\n" +
+	"\vPeerTraffic\x12\x18\n" +
+	"\aAddress\x18\x01 \x01(\tR\aAddress\x12(\n" +
+	"\x0fOpenConnections\x18\x02 \x01(\x05R\x0fOpenConnections\x12$\n" +
+	"\rActiveStreams\x18\x03 \x01(\x05R\rActiveStreams\x12\x14\n" +
+	"\x05Calls\x18\x04 \x01(\x03R\x05Calls\x12$\n" +
+	"\rBytesReceived\x18\x05 \x01(\x03R\rBytesReceived\x12\x1c\n" +
+	"\tBytesSent\x18\x06 \x01(\x03R\tBytesSent\x12\x1e\n" +
+	"\n" +
+	"LastCallAt\x18\a \x01(\tR\n" +
+	"LastCallAt\"\x18\n" +
+	"\x16ListConnectionsRequest\"J\n" +
+	"\vConnections\x12;\n" +
+	"\vConnections\x18\x01 \x03(\v2\x19.grpc_tutorial.ConnectionR\vConnections\"\x92\x02\n" +
+	"\n" +
+	"Connection\x12$\n" +
+	"\rRemoteAddress\x18\x01 \x01(\tR\rRemoteAddress\x12\"\n" +
+	"\fLocalAddress\x18\x02 \x01(\tR\fLocalAddress\x12\x1a\n" +
+	"\bOpenedAt\x18\x03 \x01(\tR\bOpenedAt\x12$\n" +
+	"\rActiveStreams\x18\x04 \x01(\x05R\rActiveStreams\x12\x14\n" +
+	"\x05Calls\x18\x05 \x01(\x03R\x05Calls\x12$\n" +
+	"\rBytesReceived\x18\x06 \x01(\x03R\rBytesReceived\x12\x1c\n" +
+	"\tBytesSent\x18\a \x01(\x03R\tBytesSent\x12\x1e\n" +
+	"\n" +
+	"LastCallAt\x18\b \x01(\tR\n" +
+	"LastCallAt\"P\n" +
+	"\x18StreamServerStatsRequest\x124\n" +
+	"\x0fIntervalSeconds\x18\x01 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\x90\x1c(\x00R\x0fIntervalSeconds\"\x9d\x04\n" +
+	"\vServerStats\x12\x12\n" +
+	"\x04Time\x18\x01 \x01(\tR\x04Time\x12(\n" +
+	"\x0fIntervalSeconds\x18\x02 \x01(\x01R\x0fIntervalSeconds\x12\x14\n" +
+	"\x05Calls\x18\x03 \x01(\x03R\x05Calls\x12\x16\n" +
+	"\x06Errors\x18\x04 \x01(\x03R\x06Errors\x12&\n" +
+	"\x0eCallsPerSecond\x18\x05 \x01(\x01R\x0eCallsPerSecond\x12\x1a\n" +
+	"\bInFlight\x18\x06 \x01(\x03R\bInFlight\x12\"\n" +
+	"\fLatencyP50Ms\x18\a \x01(\x01R\fLatencyP50Ms\x12\"\n" +
+	"\fLatencyP90Ms\x18\b \x01(\x01R\fLatencyP90Ms\x12\"\n" +
+	"\fLatencyP99Ms\x18\t \x01(\x01R\fLatencyP99Ms\x12\"\n" +
+	"\fLatencyMaxMs\x18\n" +
+	" \x01(\x01R\fLatencyMaxMs\x124\n" +
+	"\aMethods\x18\v \x03(\v2\x1a.grpc_tutorial.MethodStatsR\aMethods\x12&\n" +
+	"\x0eHeapAllocBytes\x18\f \x01(\x04R\x0eHeapAllocBytes\x12\x1a\n" +
+	"\bSysBytes\x18\r \x01(\x04R\bSysBytes\x12\x1e\n" +
+	"\n" +
+	"Goroutines\x18\x0e \x01(\x05R\n" +
+	"Goroutines\x12\x16\n" +
+	"\x06GcRuns\x18\x0f \x01(\rR\x06GcRuns\x12\x1c\n" +
+	"\tGcPauseMs\x18\x10 \x01(\x01R\tGcPauseMs\"y\n" +
+	"\vMethodStats\x12\x16\n" +
+	"\x06Method\x18\x01 \x01(\tR\x06Method\x12\x14\n" +
+	"\x05Calls\x18\x02 \x01(\x03R\x05Calls\x12\x16\n" +
+	"\x06Errors\x18\x03 \x01(\x03R\x06Errors\x12$\n" +
+	"\rMeanLatencyMs\x18\x04 \x01(\x01R\rMeanLatencyMs\"\xca\x01\n" +
+	"\x13ExportEventsRequest\x126\n" +
+	"\aDataset\x18\x01 \x01(\x0e2\x1c.grpc_tutorial.ExportDatasetR\aDataset\x123\n" +
+	"\x06Format\x18\x02 \x01(\x0e2\x1b.grpc_tutorial.ExportFormatR\x06Format\x12\x14\n" +
+	"\x05Since\x18\x03 \x01(\tR\x05Since\x12\x14\n" +
+	"\x05Until\x18\x04 \x01(\tR\x05Until\x12\x1a\n" +
+	"\bToBucket\x18\x05 \x01(\bR\bToBucket\"i\n" +
+	"\vExportChunk\x12\x12\n" +
+	"\x04Data\x18\x01 \x01(\fR\x04Data\x12\x12\n" +
+	"\x04Rows\x18\x02 \x01(\x03R\x04Rows\x12\x14\n" +
+	"\x05Bytes\x18\x03 \x01(\x03R\x05Bytes\x12\x1c\n" +
+	"\tBucketKey\x18\x04 \x01(\tR\tBucketKey*P\n" +
+	"\n" +
+	"PostStatus\x12\r\n" +
+	"\tPUBLISHED\x10\x00\x12\r\n" +
+	"\tSCHEDULED\x10\x01\x12\v\n" +
+	"\aDELETED\x10\x02\x12\f\n" +
+	"\bARCHIVED\x10\x03\x12\t\n" +
+	"\x05DRAFT\x10\x04*S\n" +
+	"\tPostOrder\x12\x11\n" +
+	"\rORDER_CREATED\x10\x00\x12\x16\n" +
+	"\x12ORDER_READING_TIME\x10\x01\x12\x1b\n" +
+	"\x17ORDER_READING_TIME_DESC\x10\x02*\x82\x01\n" +
+	"\rPostEventType\x12\x10\n" +
+	"\fPOST_CREATED\x10\x00\x12\x10\n" +
+	"\fPOST_UPDATED\x10\x01\x12\x10\n" +
+	"\fPOST_DELETED\x10\x02\x12\x12\n" +
+	"\x0ePOST_PUBLISHED\x10\x03\x12\x11\n" +
+	"\rPOST_ARCHIVED\x10\x04\x12\x14\n" +
+	"\x10POST_UNPUBLISHED\x10\x05*2\n" +
+	"\rSummaryPeriod\x12\x0f\n" +
+	"\vSUMMARY_DAY\x10\x00\x12\x10\n" +
+	"\fSUMMARY_WEEK\x10\x01*Q\n" +
+	"\x10NotificationType\x12\x1f\n" +
+	"\x1bNOTIFICATION_POST_PUBLISHED\x10\x00\x12\x1c\n" +
+	"\x18NOTIFICATION_NEW_COMMENT\x10\x01*8\n" +
+	"\rCommentStatus\x12\v\n" +
+	"\aPENDING\x10\x00\x12\f\n" +
+	"\bAPPROVED\x10\x01\x12\f\n" +
+	"\bREJECTED\x10\x02*^\n" +
+	"\fReportReason\x12\x10\n" +
+	"\fREPORT_OTHER\x10\x00\x12\x0f\n" +
+	"\vREPORT_SPAM\x10\x01\x12\x15\n" +
+	"\x11REPORT_HARASSMENT\x10\x02\x12\x14\n" +
+	"\x10REPORT_OFFENSIVE\x10\x03*X\n" +
+	"\x10ReportResolution\x12\x0e\n" +
+	"\n" +
+	"UNRESOLVED\x10\x00\x12\r\n" +
+	"\tDISMISSED\x10\x01\x12\x12\n" +
+	"\x0eCONTENT_HIDDEN\x10\x02\x12\x11\n" +
+	"\rAUTHOR_BANNED\x10\x03*7\n" +
+	"\rExportDataset\x12\x14\n" +
+	"\x10EXPORT_AUDIT_LOG\x10\x00\x12\x10\n" +
+	"\fEXPORT_VIEWS\x10\x01*2\n" +
+	"\fExportFormat\x12\x0e\n" +
+	"\n" +
+	"EXPORT_CSV\x10\x00\x12\x12\n" +
+	"\x0eEXPORT_PARQUET\x10\x012\x90(\n" +
 	"\x04Blog\x12@\n" +
 	"\bGetPosts\x12\x1e.grpc_tutorial.GetPostsRequest\x1a\x14.grpc_tutorial.Posts\x12C\n" +
 	"\n" +
-	"CreatePost\x12 .grpc_tutorial.CreatePostRequest\x1a\x13.grpc_tutorial.PostB\x11Z\x0f./grpc_tutorialb\x06proto3"
+	"CreatePost\x12 .grpc_tutorial.CreatePostRequest\x1a\x13.grpc_tutorial.Post\x12C\n" +
+	"\n" +
+	"UpdatePost\x12 .grpc_tutorial.UpdatePostRequest\x1a\x13.grpc_tutorial.Post\x12Q\n" +
+	"\n" +
+	"DeletePost\x12 .grpc_tutorial.DeletePostRequest\x1a!.grpc_tutorial.DeletePostResponse\x12T\n" +
+	"\vSyncChanges\x12!.grpc_tutorial.SyncChangesRequest\x1a\".grpc_tutorial.SyncChangesResponse\x12W\n" +
+	"\x10UploadAttachment\x12&.grpc_tutorial.UploadAttachmentRequest\x1a\x19.grpc_tutorial.Attachment(\x01\x12k\n" +
+	"\x12DownloadAttachment\x12(.grpc_tutorial.DownloadAttachmentRequest\x1a).grpc_tutorial.DownloadAttachmentResponse0\x01\x12X\n" +
+	"\x10GetAttachmentURL\x12&.grpc_tutorial.GetAttachmentURLRequest\x1a\x1c.grpc_tutorial.AttachmentURL\x12K\n" +
+	"\n" +
+	"RenderPost\x12 .grpc_tutorial.RenderPostRequest\x1a\x1b.grpc_tutorial.RenderedPost\x12J\n" +
+	"\n" +
+	"WatchPosts\x12 .grpc_tutorial.WatchPostsRequest\x1a\x18.grpc_tutorial.PostEvent0\x01\x12N\n" +
+	"\rListRevisions\x12#.grpc_tutorial.ListRevisionsRequest\x1a\x18.grpc_tutorial.Revisions\x12M\n" +
+	"\x0fRestoreRevision\x12%.grpc_tutorial.RestoreRevisionRequest\x1a\x13.grpc_tutorial.Post\x12Q\n" +
+	"\rQueryAuditLog\x12#.grpc_tutorial.QueryAuditLogRequest\x1a\x1b.grpc_tutorial.AuditEntries\x12P\n" +
+	"\x0fRegisterWebhook\x12%.grpc_tutorial.RegisterWebhookRequest\x1a\x16.grpc_tutorial.Webhook\x12f\n" +
+	"\x11UnregisterWebhook\x12'.grpc_tutorial.UnregisterWebhookRequest\x1a(.grpc_tutorial.UnregisterWebhookResponse\x12K\n" +
+	"\fListWebhooks\x12\".grpc_tutorial.ListWebhooksRequest\x1a\x17.grpc_tutorial.Webhooks\x12c\n" +
+	"\x10SubscribeByEmail\x12&.grpc_tutorial.SubscribeByEmailRequest\x1a'.grpc_tutorial.SubscribeByEmailResponse\x12i\n" +
+	"\x12UnsubscribeByEmail\x12(.grpc_tutorial.UnsubscribeByEmailRequest\x1a).grpc_tutorial.UnsubscribeByEmailResponse\x12V\n" +
+	"\vStreamPosts\x12!.grpc_tutorial.StreamPostsRequest\x1a\".grpc_tutorial.StreamPostsResponse0\x01\x12g\n" +
+	"\x15GetPublishingSchedule\x12+.grpc_tutorial.GetPublishingScheduleRequest\x1a!.grpc_tutorial.PublishingSchedule\x12X\n" +
+	"\x10GetTrendingPosts\x12&.grpc_tutorial.GetTrendingPostsRequest\x1a\x1c.grpc_tutorial.TrendingPosts\x12X\n" +
+	"\x10GetPostAnalytics\x12&.grpc_tutorial.GetPostAnalyticsRequest\x1a\x1c.grpc_tutorial.PostAnalytics\x12U\n" +
+	"\x0fGetDailySummary\x12%.grpc_tutorial.GetDailySummaryRequest\x1a\x1b.grpc_tutorial.DailySummary\x12X\n" +
+	"\x10GetAuthorProfile\x12&.grpc_tutorial.GetAuthorProfileRequest\x1a\x1c.grpc_tutorial.AuthorProfile\x12P\n" +
+	"\fSetAuthorBio\x12\".grpc_tutorial.SetAuthorBioRequest\x1a\x1c.grpc_tutorial.AuthorProfile\x12Q\n" +
+	"\n" +
+	"RecordView\x12 .grpc_tutorial.RecordViewRequest\x1a!.grpc_tutorial.RecordViewResponse\x12U\n" +
+	"\x0fGetRelatedPosts\x12%.grpc_tutorial.GetRelatedPostsRequest\x1a\x1b.grpc_tutorial.RelatedPosts\x12I\n" +
+	"\rGetPostBySlug\x12#.grpc_tutorial.GetPostBySlugRequest\x1a\x13.grpc_tutorial.Post\x12H\n" +
+	"\fGetBacklinks\x12\".grpc_tutorial.GetBacklinksRequest\x1a\x14.grpc_tutorial.Posts\x12F\n" +
+	"\n" +
+	"GetSitemap\x12 .grpc_tutorial.GetSitemapRequest\x1a\x16.grpc_tutorial.Sitemap\x12J\n" +
+	"\fStartSession\x12\".grpc_tutorial.StartSessionRequest\x1a\x16.grpc_tutorial.Session\x12L\n" +
+	"\fGetChallenge\x12\".grpc_tutorial.GetChallengeRequest\x1a\x18.grpc_tutorial.Challenge\x12[\n" +
+	"\x11GetReadingHistory\x12'.grpc_tutorial.GetReadingHistoryRequest\x1a\x1d.grpc_tutorial.ReadingHistory\x12K\n" +
+	"\n" +
+	"MarkAsRead\x12 .grpc_tutorial.MarkAsReadRequest\x1a\x1b.grpc_tutorial.HistoryEntry\x12Z\n" +
+	"\x11ListNotifications\x12'.grpc_tutorial.ListNotificationsRequest\x1a\x1c.grpc_tutorial.Notifications\x12`\n" +
+	"\x14MarkNotificationRead\x12*.grpc_tutorial.MarkNotificationReadRequest\x1a\x1c.grpc_tutorial.Notifications\x12_\n" +
+	"\x13StreamNotifications\x12).grpc_tutorial.StreamNotificationsRequest\x1a\x1b.grpc_tutorial.Notification0\x01\x12F\n" +
+	"\n" +
+	"AddComment\x12 .grpc_tutorial.AddCommentRequest\x1a\x16.grpc_tutorial.Comment\x12I\n" +
+	"\vGetComments\x12!.grpc_tutorial.GetCommentsRequest\x1a\x17.grpc_tutorial.Comments\x12O\n" +
+	"\x0eApproveComment\x12%.grpc_tutorial.ModerateCommentRequest\x1a\x16.grpc_tutorial.Comment\x12N\n" +
+	"\rRejectComment\x12%.grpc_tutorial.ModerateCommentRequest\x1a\x16.grpc_tutorial.Comment\x12E\n" +
+	"\n" +
+	"ReportPost\x12 .grpc_tutorial.ReportPostRequest\x1a\x15.grpc_tutorial.Report\x12K\n" +
+	"\rReportComment\x12#.grpc_tutorial.ReportCommentRequest\x1a\x15.grpc_tutorial.Report\x12H\n" +
+	"\vListReports\x12!.grpc_tutorial.ListReportsRequest\x1a\x16.grpc_tutorial.Reports\x12K\n" +
+	"\rResolveReport\x12#.grpc_tutorial.ResolveReportRequest\x1a\x15.grpc_tutorial.Report\x12P\n" +
+	"\vDeletePosts\x12\x1f.grpc_tutorial.BulkPostsRequest\x1a .grpc_tutorial.BulkPostsResponse\x12Q\n" +
+	"\fArchivePosts\x12\x1f.grpc_tutorial.BulkPostsRequest\x1a .grpc_tutorial.BulkPostsResponse\x12=\n" +
+	"\aPinPost\x12\x1d.grpc_tutorial.PinPostRequest\x1a\x13.grpc_tutorial.Post\x12A\n" +
+	"\tUnpinPost\x12\x1f.grpc_tutorial.UnpinPostRequest\x1a\x13.grpc_tutorial.Post\x12S\n" +
+	"\x0eCreateTemplate\x12$.grpc_tutorial.CreateTemplateRequest\x1a\x1b.grpc_tutorial.PostTemplate\x12M\n" +
+	"\vGetTemplate\x12!.grpc_tutorial.GetTemplateRequest\x1a\x1b.grpc_tutorial.PostTemplate\x12R\n" +
+	"\rListTemplates\x12#.grpc_tutorial.ListTemplatesRequest\x1a\x1c.grpc_tutorial.PostTemplates\x12S\n" +
+	"\x0eUpdateTemplate\x12$.grpc_tutorial.UpdateTemplateRequest\x1a\x1b.grpc_tutorial.PostTemplate\x12]\n" +
+	"\x0eDeleteTemplate\x12$.grpc_tutorial.DeleteTemplateRequest\x1a%.grpc_tutorial.DeleteTemplateResponse\x12[\n" +
+	"\x16CreatePostFromTemplate\x12,.grpc_tutorial.CreatePostFromTemplateRequest\x1a\x13.grpc_tutorial.Post\x12I\n" +
+	"\fCreateSeries\x12\".grpc_tutorial.CreateSeriesRequest\x1a\x15.grpc_tutorial.Series\x12I\n" +
+	"\n" +
+	"ListSeries\x12 .grpc_tutorial.ListSeriesRequest\x1a\x19.grpc_tutorial.SeriesList\x12H\n" +
+	"\tGetSeries\x12\x1f.grpc_tutorial.GetSeriesRequest\x1a\x1a.grpc_tutorial.SeriesPosts\x12G\n" +
+	"\vAddToSeries\x12!.grpc_tutorial.AddToSeriesRequest\x1a\x15.grpc_tutorial.Series\x12Q\n" +
+	"\x10RemoveFromSeries\x12&.grpc_tutorial.RemoveFromSeriesRequest\x1a\x15.grpc_tutorial.Series\x12K\n" +
+	"\rReorderSeries\x12#.grpc_tutorial.ReorderSeriesRequest\x1a\x15.grpc_tutorial.Series\x12W\n" +
+	"\fDeleteSeries\x12\".grpc_tutorial.DeleteSeriesRequest\x1a#.grpc_tutorial.DeleteSeriesResponse2\xa0\v\n" +
+	"\x05Admin\x12U\n" +
+	"\x0fSetDebugLogging\x12%.grpc_tutorial.SetDebugLoggingRequest\x1a\x1b.grpc_tutorial.DebugLogging\x12U\n" +
+	"\x0fGetDebugLogging\x12%.grpc_tutorial.GetDebugLoggingRequest\x1a\x1b.grpc_tutorial.DebugLogging\x12U\n" +
+	"\x0fGetStorageStats\x12%.grpc_tutorial.GetStorageStatsRequest\x1a\x1b.grpc_tutorial.StorageStats\x12R\n" +
+	"\x0eSetMaintenance\x12$.grpc_tutorial.SetMaintenanceRequest\x1a\x1a.grpc_tutorial.Maintenance\x12R\n" +
+	"\x0eGetMaintenance\x12$.grpc_tutorial.GetMaintenanceRequest\x1a\x1a.grpc_tutorial.Maintenance\x12O\n" +
+	"\fReloadConfig\x12\".grpc_tutorial.ReloadConfigRequest\x1a\x1b.grpc_tutorial.ConfigReload\x12O\n" +
+	"\fFlushStorage\x12\".grpc_tutorial.FlushStorageRequest\x1a\x1b.grpc_tutorial.StorageFlush\x12^\n" +
+	"\x10ReencryptStorage\x12&.grpc_tutorial.ReencryptStorageRequest\x1a\".grpc_tutorial.StorageReencryption\x12]\n" +
+	"\x12ListScheduledTasks\x12(.grpc_tutorial.ListScheduledTasksRequest\x1a\x1d.grpc_tutorial.ScheduledTasks\x12X\n" +
+	"\x10RunScheduledTask\x12&.grpc_tutorial.RunScheduledTaskRequest\x1a\x1c.grpc_tutorial.ScheduledTask\x12:\n" +
+	"\x06AddBan\x12\x1c.grpc_tutorial.AddBanRequest\x1a\x12.grpc_tutorial.Ban\x12N\n" +
+	"\tRemoveBan\x12\x1f.grpc_tutorial.RemoveBanRequest\x1a .grpc_tutorial.RemoveBanResponse\x12?\n" +
+	"\bListBans\x12\x1e.grpc_tutorial.ListBansRequest\x1a\x13.grpc_tutorial.Bans\x12^\n" +
+	"\x12GetConnectionStats\x12(.grpc_tutorial.GetConnectionStatsRequest\x1a\x1e.grpc_tutorial.ConnectionStats\x12T\n" +
+	"\x0fListConnections\x12%.grpc_tutorial.ListConnectionsRequest\x1a\x1a.grpc_tutorial.Connections\x12Z\n" +
+	"\x11StreamServerStats\x12'.grpc_tutorial.StreamServerStatsRequest\x1a\x1a.grpc_tutorial.ServerStats0\x01\x12P\n" +
+	"\fExportEvents\x12\".grpc_tutorial.ExportEventsRequest\x1a\x1a.grpc_tutorial.ExportChunk0\x01BGZEgithub.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen;grpc_tutorialb\x06proto3"
 
 var (
 	file_blog_proto_rawDescOnce sync.Once
@@ -304,24 +10692,400 @@ func file_blog_proto_rawDescGZIP() []byte {
 	return file_blog_proto_rawDescData
 }
 
-var file_blog_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_blog_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_blog_proto_msgTypes = make([]protoimpl.MessageInfo, 154)
 var file_blog_proto_goTypes = []any{
-	(*Post)(nil),              // 0: grpc_tutorial.Post
-	(*Posts)(nil),             // 1: grpc_tutorial.Posts
-	(*GetPostsRequest)(nil),   // 2: grpc_tutorial.GetPostsRequest
-	(*CreatePostRequest)(nil), // 3: grpc_tutorial.CreatePostRequest
+	(PostStatus)(0),                       // 0: grpc_tutorial.PostStatus
+	(PostOrder)(0),                        // 1: grpc_tutorial.PostOrder
+	(PostEventType)(0),                    // 2: grpc_tutorial.PostEventType
+	(SummaryPeriod)(0),                    // 3: grpc_tutorial.SummaryPeriod
+	(NotificationType)(0),                 // 4: grpc_tutorial.NotificationType
+	(CommentStatus)(0),                    // 5: grpc_tutorial.CommentStatus
+	(ReportReason)(0),                     // 6: grpc_tutorial.ReportReason
+	(ReportResolution)(0),                 // 7: grpc_tutorial.ReportResolution
+	(ExportDataset)(0),                    // 8: grpc_tutorial.ExportDataset
+	(ExportFormat)(0),                     // 9: grpc_tutorial.ExportFormat
+	(*Post)(nil),                          // 10: grpc_tutorial.Post
+	(*Attachment)(nil),                    // 11: grpc_tutorial.Attachment
+	(*Posts)(nil),                         // 12: grpc_tutorial.Posts
+	(*PostFilter)(nil),                    // 13: grpc_tutorial.PostFilter
+	(*GetPostsRequest)(nil),               // 14: grpc_tutorial.GetPostsRequest
+	(*CreatePostRequest)(nil),             // 15: grpc_tutorial.CreatePostRequest
+	(*UpdatePostRequest)(nil),             // 16: grpc_tutorial.UpdatePostRequest
+	(*SyncChangesRequest)(nil),            // 17: grpc_tutorial.SyncChangesRequest
+	(*SyncChangesResponse)(nil),           // 18: grpc_tutorial.SyncChangesResponse
+	(*AttachmentMetadata)(nil),            // 19: grpc_tutorial.AttachmentMetadata
+	(*UploadAttachmentRequest)(nil),       // 20: grpc_tutorial.UploadAttachmentRequest
+	(*DownloadAttachmentRequest)(nil),     // 21: grpc_tutorial.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),    // 22: grpc_tutorial.DownloadAttachmentResponse
+	(*RenderPostRequest)(nil),             // 23: grpc_tutorial.RenderPostRequest
+	(*RenderedPost)(nil),                  // 24: grpc_tutorial.RenderedPost
+	(*WatchPostsRequest)(nil),             // 25: grpc_tutorial.WatchPostsRequest
+	(*PostEvent)(nil),                     // 26: grpc_tutorial.PostEvent
+	(*Revision)(nil),                      // 27: grpc_tutorial.Revision
+	(*Revisions)(nil),                     // 28: grpc_tutorial.Revisions
+	(*ListRevisionsRequest)(nil),          // 29: grpc_tutorial.ListRevisionsRequest
+	(*RestoreRevisionRequest)(nil),        // 30: grpc_tutorial.RestoreRevisionRequest
+	(*DeletePostRequest)(nil),             // 31: grpc_tutorial.DeletePostRequest
+	(*DeletePostResponse)(nil),            // 32: grpc_tutorial.DeletePostResponse
+	(*AuditEntry)(nil),                    // 33: grpc_tutorial.AuditEntry
+	(*AuditEntries)(nil),                  // 34: grpc_tutorial.AuditEntries
+	(*QueryAuditLogRequest)(nil),          // 35: grpc_tutorial.QueryAuditLogRequest
+	(*StreamPostsRequest)(nil),            // 36: grpc_tutorial.StreamPostsRequest
+	(*StreamPostsResponse)(nil),           // 37: grpc_tutorial.StreamPostsResponse
+	(*Webhook)(nil),                       // 38: grpc_tutorial.Webhook
+	(*Webhooks)(nil),                      // 39: grpc_tutorial.Webhooks
+	(*RegisterWebhookRequest)(nil),        // 40: grpc_tutorial.RegisterWebhookRequest
+	(*UnregisterWebhookRequest)(nil),      // 41: grpc_tutorial.UnregisterWebhookRequest
+	(*UnregisterWebhookResponse)(nil),     // 42: grpc_tutorial.UnregisterWebhookResponse
+	(*ListWebhooksRequest)(nil),           // 43: grpc_tutorial.ListWebhooksRequest
+	(*PostTemplate)(nil),                  // 44: grpc_tutorial.PostTemplate
+	(*PostTemplates)(nil),                 // 45: grpc_tutorial.PostTemplates
+	(*CreateTemplateRequest)(nil),         // 46: grpc_tutorial.CreateTemplateRequest
+	(*GetTemplateRequest)(nil),            // 47: grpc_tutorial.GetTemplateRequest
+	(*ListTemplatesRequest)(nil),          // 48: grpc_tutorial.ListTemplatesRequest
+	(*UpdateTemplateRequest)(nil),         // 49: grpc_tutorial.UpdateTemplateRequest
+	(*DeleteTemplateRequest)(nil),         // 50: grpc_tutorial.DeleteTemplateRequest
+	(*DeleteTemplateResponse)(nil),        // 51: grpc_tutorial.DeleteTemplateResponse
+	(*CreatePostFromTemplateRequest)(nil), // 52: grpc_tutorial.CreatePostFromTemplateRequest
+	(*Series)(nil),                        // 53: grpc_tutorial.Series
+	(*SeriesList)(nil),                    // 54: grpc_tutorial.SeriesList
+	(*CreateSeriesRequest)(nil),           // 55: grpc_tutorial.CreateSeriesRequest
+	(*ListSeriesRequest)(nil),             // 56: grpc_tutorial.ListSeriesRequest
+	(*GetSeriesRequest)(nil),              // 57: grpc_tutorial.GetSeriesRequest
+	(*SeriesPosts)(nil),                   // 58: grpc_tutorial.SeriesPosts
+	(*SeriesPost)(nil),                    // 59: grpc_tutorial.SeriesPost
+	(*AddToSeriesRequest)(nil),            // 60: grpc_tutorial.AddToSeriesRequest
+	(*RemoveFromSeriesRequest)(nil),       // 61: grpc_tutorial.RemoveFromSeriesRequest
+	(*ReorderSeriesRequest)(nil),          // 62: grpc_tutorial.ReorderSeriesRequest
+	(*DeleteSeriesRequest)(nil),           // 63: grpc_tutorial.DeleteSeriesRequest
+	(*DeleteSeriesResponse)(nil),          // 64: grpc_tutorial.DeleteSeriesResponse
+	(*SubscribeByEmailRequest)(nil),       // 65: grpc_tutorial.SubscribeByEmailRequest
+	(*SubscribeByEmailResponse)(nil),      // 66: grpc_tutorial.SubscribeByEmailResponse
+	(*UnsubscribeByEmailRequest)(nil),     // 67: grpc_tutorial.UnsubscribeByEmailRequest
+	(*UnsubscribeByEmailResponse)(nil),    // 68: grpc_tutorial.UnsubscribeByEmailResponse
+	(*GetAttachmentURLRequest)(nil),       // 69: grpc_tutorial.GetAttachmentURLRequest
+	(*AttachmentURL)(nil),                 // 70: grpc_tutorial.AttachmentURL
+	(*SetDebugLoggingRequest)(nil),        // 71: grpc_tutorial.SetDebugLoggingRequest
+	(*GetDebugLoggingRequest)(nil),        // 72: grpc_tutorial.GetDebugLoggingRequest
+	(*DebugLogging)(nil),                  // 73: grpc_tutorial.DebugLogging
+	(*SetMaintenanceRequest)(nil),         // 74: grpc_tutorial.SetMaintenanceRequest
+	(*GetMaintenanceRequest)(nil),         // 75: grpc_tutorial.GetMaintenanceRequest
+	(*Maintenance)(nil),                   // 76: grpc_tutorial.Maintenance
+	(*ReloadConfigRequest)(nil),           // 77: grpc_tutorial.ReloadConfigRequest
+	(*ConfigChange)(nil),                  // 78: grpc_tutorial.ConfigChange
+	(*ConfigReload)(nil),                  // 79: grpc_tutorial.ConfigReload
+	(*GetStorageStatsRequest)(nil),        // 80: grpc_tutorial.GetStorageStatsRequest
+	(*StorageStats)(nil),                  // 81: grpc_tutorial.StorageStats
+	(*FlushStorageRequest)(nil),           // 82: grpc_tutorial.FlushStorageRequest
+	(*StorageFlush)(nil),                  // 83: grpc_tutorial.StorageFlush
+	(*ReencryptStorageRequest)(nil),       // 84: grpc_tutorial.ReencryptStorageRequest
+	(*StorageReencryption)(nil),           // 85: grpc_tutorial.StorageReencryption
+	(*ListScheduledTasksRequest)(nil),     // 86: grpc_tutorial.ListScheduledTasksRequest
+	(*RunScheduledTaskRequest)(nil),       // 87: grpc_tutorial.RunScheduledTaskRequest
+	(*ScheduledTask)(nil),                 // 88: grpc_tutorial.ScheduledTask
+	(*ScheduledTasks)(nil),                // 89: grpc_tutorial.ScheduledTasks
+	(*GetPublishingScheduleRequest)(nil),  // 90: grpc_tutorial.GetPublishingScheduleRequest
+	(*PublishingSchedule)(nil),            // 91: grpc_tutorial.PublishingSchedule
+	(*ScheduledDay)(nil),                  // 92: grpc_tutorial.ScheduledDay
+	(*ScheduledPost)(nil),                 // 93: grpc_tutorial.ScheduledPost
+	(*GetTrendingPostsRequest)(nil),       // 94: grpc_tutorial.GetTrendingPostsRequest
+	(*TrendingPost)(nil),                  // 95: grpc_tutorial.TrendingPost
+	(*TrendingPosts)(nil),                 // 96: grpc_tutorial.TrendingPosts
+	(*GetPostAnalyticsRequest)(nil),       // 97: grpc_tutorial.GetPostAnalyticsRequest
+	(*ViewBucket)(nil),                    // 98: grpc_tutorial.ViewBucket
+	(*PostAnalytics)(nil),                 // 99: grpc_tutorial.PostAnalytics
+	(*GetDailySummaryRequest)(nil),        // 100: grpc_tutorial.GetDailySummaryRequest
+	(*PostSummary)(nil),                   // 101: grpc_tutorial.PostSummary
+	(*AuthorSummary)(nil),                 // 102: grpc_tutorial.AuthorSummary
+	(*DailySummary)(nil),                  // 103: grpc_tutorial.DailySummary
+	(*GetAuthorProfileRequest)(nil),       // 104: grpc_tutorial.GetAuthorProfileRequest
+	(*SetAuthorBioRequest)(nil),           // 105: grpc_tutorial.SetAuthorBioRequest
+	(*TagUse)(nil),                        // 106: grpc_tutorial.TagUse
+	(*AuthorProfile)(nil),                 // 107: grpc_tutorial.AuthorProfile
+	(*RecordViewRequest)(nil),             // 108: grpc_tutorial.RecordViewRequest
+	(*RecordViewResponse)(nil),            // 109: grpc_tutorial.RecordViewResponse
+	(*GetRelatedPostsRequest)(nil),        // 110: grpc_tutorial.GetRelatedPostsRequest
+	(*RelatedPost)(nil),                   // 111: grpc_tutorial.RelatedPost
+	(*RelatedPosts)(nil),                  // 112: grpc_tutorial.RelatedPosts
+	(*GetPostBySlugRequest)(nil),          // 113: grpc_tutorial.GetPostBySlugRequest
+	(*GetBacklinksRequest)(nil),           // 114: grpc_tutorial.GetBacklinksRequest
+	(*GetSitemapRequest)(nil),             // 115: grpc_tutorial.GetSitemapRequest
+	(*Sitemap)(nil),                       // 116: grpc_tutorial.Sitemap
+	(*StartSessionRequest)(nil),           // 117: grpc_tutorial.StartSessionRequest
+	(*Session)(nil),                       // 118: grpc_tutorial.Session
+	(*GetChallengeRequest)(nil),           // 119: grpc_tutorial.GetChallengeRequest
+	(*Challenge)(nil),                     // 120: grpc_tutorial.Challenge
+	(*GetReadingHistoryRequest)(nil),      // 121: grpc_tutorial.GetReadingHistoryRequest
+	(*ReadingHistory)(nil),                // 122: grpc_tutorial.ReadingHistory
+	(*HistoryEntry)(nil),                  // 123: grpc_tutorial.HistoryEntry
+	(*MarkAsReadRequest)(nil),             // 124: grpc_tutorial.MarkAsReadRequest
+	(*Notification)(nil),                  // 125: grpc_tutorial.Notification
+	(*ListNotificationsRequest)(nil),      // 126: grpc_tutorial.ListNotificationsRequest
+	(*Notifications)(nil),                 // 127: grpc_tutorial.Notifications
+	(*MarkNotificationReadRequest)(nil),   // 128: grpc_tutorial.MarkNotificationReadRequest
+	(*StreamNotificationsRequest)(nil),    // 129: grpc_tutorial.StreamNotificationsRequest
+	(*Comment)(nil),                       // 130: grpc_tutorial.Comment
+	(*AddCommentRequest)(nil),             // 131: grpc_tutorial.AddCommentRequest
+	(*GetCommentsRequest)(nil),            // 132: grpc_tutorial.GetCommentsRequest
+	(*Comments)(nil),                      // 133: grpc_tutorial.Comments
+	(*ModerateCommentRequest)(nil),        // 134: grpc_tutorial.ModerateCommentRequest
+	(*PinPostRequest)(nil),                // 135: grpc_tutorial.PinPostRequest
+	(*UnpinPostRequest)(nil),              // 136: grpc_tutorial.UnpinPostRequest
+	(*BulkPostsRequest)(nil),              // 137: grpc_tutorial.BulkPostsRequest
+	(*BulkPostsResponse)(nil),             // 138: grpc_tutorial.BulkPostsResponse
+	(*Report)(nil),                        // 139: grpc_tutorial.Report
+	(*ReportPostRequest)(nil),             // 140: grpc_tutorial.ReportPostRequest
+	(*ReportCommentRequest)(nil),          // 141: grpc_tutorial.ReportCommentRequest
+	(*ListReportsRequest)(nil),            // 142: grpc_tutorial.ListReportsRequest
+	(*Reports)(nil),                       // 143: grpc_tutorial.Reports
+	(*ResolveReportRequest)(nil),          // 144: grpc_tutorial.ResolveReportRequest
+	(*Ban)(nil),                           // 145: grpc_tutorial.Ban
+	(*AddBanRequest)(nil),                 // 146: grpc_tutorial.AddBanRequest
+	(*RemoveBanRequest)(nil),              // 147: grpc_tutorial.RemoveBanRequest
+	(*RemoveBanResponse)(nil),             // 148: grpc_tutorial.RemoveBanResponse
+	(*ListBansRequest)(nil),               // 149: grpc_tutorial.ListBansRequest
+	(*Bans)(nil),                          // 150: grpc_tutorial.Bans
+	(*GetConnectionStatsRequest)(nil),     // 151: grpc_tutorial.GetConnectionStatsRequest
+	(*ConnectionStats)(nil),               // 152: grpc_tutorial.ConnectionStats
+	(*ListenerStats)(nil),                 // 153: grpc_tutorial.ListenerStats
+	(*PeerTraffic)(nil),                   // 154: grpc_tutorial.PeerTraffic
+	(*ListConnectionsRequest)(nil),        // 155: grpc_tutorial.ListConnectionsRequest
+	(*Connections)(nil),                   // 156: grpc_tutorial.Connections
+	(*Connection)(nil),                    // 157: grpc_tutorial.Connection
+	(*StreamServerStatsRequest)(nil),      // 158: grpc_tutorial.StreamServerStatsRequest
+	(*ServerStats)(nil),                   // 159: grpc_tutorial.ServerStats
+	(*MethodStats)(nil),                   // 160: grpc_tutorial.MethodStats
+	(*ExportEventsRequest)(nil),           // 161: grpc_tutorial.ExportEventsRequest
+	(*ExportChunk)(nil),                   // 162: grpc_tutorial.ExportChunk
+	nil,                                   // 163: grpc_tutorial.CreatePostFromTemplateRequest.ValuesEntry
+	(*fieldmaskpb.FieldMask)(nil),         // 164: google.protobuf.FieldMask
 }
 var file_blog_proto_depIdxs = []int32{
-	0, // 0: grpc_tutorial.Posts.posts:type_name -> grpc_tutorial.Post
-	2, // 1: grpc_tutorial.Blog.GetPosts:input_type -> grpc_tutorial.GetPostsRequest
-	3, // 2: grpc_tutorial.Blog.CreatePost:input_type -> grpc_tutorial.CreatePostRequest
-	1, // 3: grpc_tutorial.Blog.GetPosts:output_type -> grpc_tutorial.Posts
-	0, // 4: grpc_tutorial.Blog.CreatePost:output_type -> grpc_tutorial.Post
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	11,  // 0: grpc_tutorial.Post.Attachments:type_name -> grpc_tutorial.Attachment
+	0,   // 1: grpc_tutorial.Post.Status:type_name -> grpc_tutorial.PostStatus
+	10,  // 2: grpc_tutorial.Posts.posts:type_name -> grpc_tutorial.Post
+	13,  // 3: grpc_tutorial.GetPostsRequest.Filter:type_name -> grpc_tutorial.PostFilter
+	164, // 4: grpc_tutorial.GetPostsRequest.ReadMask:type_name -> google.protobuf.FieldMask
+	1,   // 5: grpc_tutorial.GetPostsRequest.OrderBy:type_name -> grpc_tutorial.PostOrder
+	10,  // 6: grpc_tutorial.SyncChangesResponse.Posts:type_name -> grpc_tutorial.Post
+	19,  // 7: grpc_tutorial.UploadAttachmentRequest.Metadata:type_name -> grpc_tutorial.AttachmentMetadata
+	11,  // 8: grpc_tutorial.DownloadAttachmentResponse.Metadata:type_name -> grpc_tutorial.Attachment
+	2,   // 9: grpc_tutorial.WatchPostsRequest.Types:type_name -> grpc_tutorial.PostEventType
+	2,   // 10: grpc_tutorial.PostEvent.Type:type_name -> grpc_tutorial.PostEventType
+	10,  // 11: grpc_tutorial.PostEvent.Post:type_name -> grpc_tutorial.Post
+	27,  // 12: grpc_tutorial.Revisions.Revisions:type_name -> grpc_tutorial.Revision
+	33,  // 13: grpc_tutorial.AuditEntries.Entries:type_name -> grpc_tutorial.AuditEntry
+	10,  // 14: grpc_tutorial.StreamPostsResponse.Post:type_name -> grpc_tutorial.Post
+	2,   // 15: grpc_tutorial.Webhook.Types:type_name -> grpc_tutorial.PostEventType
+	38,  // 16: grpc_tutorial.Webhooks.Webhooks:type_name -> grpc_tutorial.Webhook
+	2,   // 17: grpc_tutorial.RegisterWebhookRequest.Types:type_name -> grpc_tutorial.PostEventType
+	44,  // 18: grpc_tutorial.PostTemplates.Templates:type_name -> grpc_tutorial.PostTemplate
+	163, // 19: grpc_tutorial.CreatePostFromTemplateRequest.Values:type_name -> grpc_tutorial.CreatePostFromTemplateRequest.ValuesEntry
+	53,  // 20: grpc_tutorial.SeriesList.Series:type_name -> grpc_tutorial.Series
+	53,  // 21: grpc_tutorial.SeriesPosts.Series:type_name -> grpc_tutorial.Series
+	59,  // 22: grpc_tutorial.SeriesPosts.Posts:type_name -> grpc_tutorial.SeriesPost
+	10,  // 23: grpc_tutorial.SeriesPost.Post:type_name -> grpc_tutorial.Post
+	78,  // 24: grpc_tutorial.ConfigReload.Applied:type_name -> grpc_tutorial.ConfigChange
+	78,  // 25: grpc_tutorial.ConfigReload.RequiresRestart:type_name -> grpc_tutorial.ConfigChange
+	88,  // 26: grpc_tutorial.ScheduledTasks.Tasks:type_name -> grpc_tutorial.ScheduledTask
+	92,  // 27: grpc_tutorial.PublishingSchedule.Days:type_name -> grpc_tutorial.ScheduledDay
+	93,  // 28: grpc_tutorial.ScheduledDay.Posts:type_name -> grpc_tutorial.ScheduledPost
+	10,  // 29: grpc_tutorial.TrendingPost.Post:type_name -> grpc_tutorial.Post
+	95,  // 30: grpc_tutorial.TrendingPosts.Posts:type_name -> grpc_tutorial.TrendingPost
+	98,  // 31: grpc_tutorial.PostAnalytics.Buckets:type_name -> grpc_tutorial.ViewBucket
+	3,   // 32: grpc_tutorial.GetDailySummaryRequest.Period:type_name -> grpc_tutorial.SummaryPeriod
+	3,   // 33: grpc_tutorial.DailySummary.Period:type_name -> grpc_tutorial.SummaryPeriod
+	101, // 34: grpc_tutorial.DailySummary.TopPosts:type_name -> grpc_tutorial.PostSummary
+	102, // 35: grpc_tutorial.DailySummary.TopAuthors:type_name -> grpc_tutorial.AuthorSummary
+	10,  // 36: grpc_tutorial.AuthorProfile.RecentPosts:type_name -> grpc_tutorial.Post
+	106, // 37: grpc_tutorial.AuthorProfile.TopTags:type_name -> grpc_tutorial.TagUse
+	10,  // 38: grpc_tutorial.RelatedPost.Post:type_name -> grpc_tutorial.Post
+	111, // 39: grpc_tutorial.RelatedPosts.Posts:type_name -> grpc_tutorial.RelatedPost
+	123, // 40: grpc_tutorial.ReadingHistory.Entries:type_name -> grpc_tutorial.HistoryEntry
+	10,  // 41: grpc_tutorial.HistoryEntry.Post:type_name -> grpc_tutorial.Post
+	4,   // 42: grpc_tutorial.Notification.Type:type_name -> grpc_tutorial.NotificationType
+	125, // 43: grpc_tutorial.Notifications.Notifications:type_name -> grpc_tutorial.Notification
+	5,   // 44: grpc_tutorial.Comment.Status:type_name -> grpc_tutorial.CommentStatus
+	5,   // 45: grpc_tutorial.GetCommentsRequest.Statuses:type_name -> grpc_tutorial.CommentStatus
+	130, // 46: grpc_tutorial.Comments.Comments:type_name -> grpc_tutorial.Comment
+	13,  // 47: grpc_tutorial.BulkPostsRequest.Filter:type_name -> grpc_tutorial.PostFilter
+	6,   // 48: grpc_tutorial.Report.Reason:type_name -> grpc_tutorial.ReportReason
+	7,   // 49: grpc_tutorial.Report.Resolution:type_name -> grpc_tutorial.ReportResolution
+	6,   // 50: grpc_tutorial.ReportPostRequest.Reason:type_name -> grpc_tutorial.ReportReason
+	6,   // 51: grpc_tutorial.ReportCommentRequest.Reason:type_name -> grpc_tutorial.ReportReason
+	139, // 52: grpc_tutorial.Reports.Reports:type_name -> grpc_tutorial.Report
+	7,   // 53: grpc_tutorial.ResolveReportRequest.Resolution:type_name -> grpc_tutorial.ReportResolution
+	145, // 54: grpc_tutorial.Bans.Bans:type_name -> grpc_tutorial.Ban
+	153, // 55: grpc_tutorial.ConnectionStats.Listeners:type_name -> grpc_tutorial.ListenerStats
+	154, // 56: grpc_tutorial.ConnectionStats.Peers:type_name -> grpc_tutorial.PeerTraffic
+	157, // 57: grpc_tutorial.Connections.Connections:type_name -> grpc_tutorial.Connection
+	160, // 58: grpc_tutorial.ServerStats.Methods:type_name -> grpc_tutorial.MethodStats
+	8,   // 59: grpc_tutorial.ExportEventsRequest.Dataset:type_name -> grpc_tutorial.ExportDataset
+	9,   // 60: grpc_tutorial.ExportEventsRequest.Format:type_name -> grpc_tutorial.ExportFormat
+	14,  // 61: grpc_tutorial.Blog.GetPosts:input_type -> grpc_tutorial.GetPostsRequest
+	15,  // 62: grpc_tutorial.Blog.CreatePost:input_type -> grpc_tutorial.CreatePostRequest
+	16,  // 63: grpc_tutorial.Blog.UpdatePost:input_type -> grpc_tutorial.UpdatePostRequest
+	31,  // 64: grpc_tutorial.Blog.DeletePost:input_type -> grpc_tutorial.DeletePostRequest
+	17,  // 65: grpc_tutorial.Blog.SyncChanges:input_type -> grpc_tutorial.SyncChangesRequest
+	20,  // 66: grpc_tutorial.Blog.UploadAttachment:input_type -> grpc_tutorial.UploadAttachmentRequest
+	21,  // 67: grpc_tutorial.Blog.DownloadAttachment:input_type -> grpc_tutorial.DownloadAttachmentRequest
+	69,  // 68: grpc_tutorial.Blog.GetAttachmentURL:input_type -> grpc_tutorial.GetAttachmentURLRequest
+	23,  // 69: grpc_tutorial.Blog.RenderPost:input_type -> grpc_tutorial.RenderPostRequest
+	25,  // 70: grpc_tutorial.Blog.WatchPosts:input_type -> grpc_tutorial.WatchPostsRequest
+	29,  // 71: grpc_tutorial.Blog.ListRevisions:input_type -> grpc_tutorial.ListRevisionsRequest
+	30,  // 72: grpc_tutorial.Blog.RestoreRevision:input_type -> grpc_tutorial.RestoreRevisionRequest
+	35,  // 73: grpc_tutorial.Blog.QueryAuditLog:input_type -> grpc_tutorial.QueryAuditLogRequest
+	40,  // 74: grpc_tutorial.Blog.RegisterWebhook:input_type -> grpc_tutorial.RegisterWebhookRequest
+	41,  // 75: grpc_tutorial.Blog.UnregisterWebhook:input_type -> grpc_tutorial.UnregisterWebhookRequest
+	43,  // 76: grpc_tutorial.Blog.ListWebhooks:input_type -> grpc_tutorial.ListWebhooksRequest
+	65,  // 77: grpc_tutorial.Blog.SubscribeByEmail:input_type -> grpc_tutorial.SubscribeByEmailRequest
+	67,  // 78: grpc_tutorial.Blog.UnsubscribeByEmail:input_type -> grpc_tutorial.UnsubscribeByEmailRequest
+	36,  // 79: grpc_tutorial.Blog.StreamPosts:input_type -> grpc_tutorial.StreamPostsRequest
+	90,  // 80: grpc_tutorial.Blog.GetPublishingSchedule:input_type -> grpc_tutorial.GetPublishingScheduleRequest
+	94,  // 81: grpc_tutorial.Blog.GetTrendingPosts:input_type -> grpc_tutorial.GetTrendingPostsRequest
+	97,  // 82: grpc_tutorial.Blog.GetPostAnalytics:input_type -> grpc_tutorial.GetPostAnalyticsRequest
+	100, // 83: grpc_tutorial.Blog.GetDailySummary:input_type -> grpc_tutorial.GetDailySummaryRequest
+	104, // 84: grpc_tutorial.Blog.GetAuthorProfile:input_type -> grpc_tutorial.GetAuthorProfileRequest
+	105, // 85: grpc_tutorial.Blog.SetAuthorBio:input_type -> grpc_tutorial.SetAuthorBioRequest
+	108, // 86: grpc_tutorial.Blog.RecordView:input_type -> grpc_tutorial.RecordViewRequest
+	110, // 87: grpc_tutorial.Blog.GetRelatedPosts:input_type -> grpc_tutorial.GetRelatedPostsRequest
+	113, // 88: grpc_tutorial.Blog.GetPostBySlug:input_type -> grpc_tutorial.GetPostBySlugRequest
+	114, // 89: grpc_tutorial.Blog.GetBacklinks:input_type -> grpc_tutorial.GetBacklinksRequest
+	115, // 90: grpc_tutorial.Blog.GetSitemap:input_type -> grpc_tutorial.GetSitemapRequest
+	117, // 91: grpc_tutorial.Blog.StartSession:input_type -> grpc_tutorial.StartSessionRequest
+	119, // 92: grpc_tutorial.Blog.GetChallenge:input_type -> grpc_tutorial.GetChallengeRequest
+	121, // 93: grpc_tutorial.Blog.GetReadingHistory:input_type -> grpc_tutorial.GetReadingHistoryRequest
+	124, // 94: grpc_tutorial.Blog.MarkAsRead:input_type -> grpc_tutorial.MarkAsReadRequest
+	126, // 95: grpc_tutorial.Blog.ListNotifications:input_type -> grpc_tutorial.ListNotificationsRequest
+	128, // 96: grpc_tutorial.Blog.MarkNotificationRead:input_type -> grpc_tutorial.MarkNotificationReadRequest
+	129, // 97: grpc_tutorial.Blog.StreamNotifications:input_type -> grpc_tutorial.StreamNotificationsRequest
+	131, // 98: grpc_tutorial.Blog.AddComment:input_type -> grpc_tutorial.AddCommentRequest
+	132, // 99: grpc_tutorial.Blog.GetComments:input_type -> grpc_tutorial.GetCommentsRequest
+	134, // 100: grpc_tutorial.Blog.ApproveComment:input_type -> grpc_tutorial.ModerateCommentRequest
+	134, // 101: grpc_tutorial.Blog.RejectComment:input_type -> grpc_tutorial.ModerateCommentRequest
+	140, // 102: grpc_tutorial.Blog.ReportPost:input_type -> grpc_tutorial.ReportPostRequest
+	141, // 103: grpc_tutorial.Blog.ReportComment:input_type -> grpc_tutorial.ReportCommentRequest
+	142, // 104: grpc_tutorial.Blog.ListReports:input_type -> grpc_tutorial.ListReportsRequest
+	144, // 105: grpc_tutorial.Blog.ResolveReport:input_type -> grpc_tutorial.ResolveReportRequest
+	137, // 106: grpc_tutorial.Blog.DeletePosts:input_type -> grpc_tutorial.BulkPostsRequest
+	137, // 107: grpc_tutorial.Blog.ArchivePosts:input_type -> grpc_tutorial.BulkPostsRequest
+	135, // 108: grpc_tutorial.Blog.PinPost:input_type -> grpc_tutorial.PinPostRequest
+	136, // 109: grpc_tutorial.Blog.UnpinPost:input_type -> grpc_tutorial.UnpinPostRequest
+	46,  // 110: grpc_tutorial.Blog.CreateTemplate:input_type -> grpc_tutorial.CreateTemplateRequest
+	47,  // 111: grpc_tutorial.Blog.GetTemplate:input_type -> grpc_tutorial.GetTemplateRequest
+	48,  // 112: grpc_tutorial.Blog.ListTemplates:input_type -> grpc_tutorial.ListTemplatesRequest
+	49,  // 113: grpc_tutorial.Blog.UpdateTemplate:input_type -> grpc_tutorial.UpdateTemplateRequest
+	50,  // 114: grpc_tutorial.Blog.DeleteTemplate:input_type -> grpc_tutorial.DeleteTemplateRequest
+	52,  // 115: grpc_tutorial.Blog.CreatePostFromTemplate:input_type -> grpc_tutorial.CreatePostFromTemplateRequest
+	55,  // 116: grpc_tutorial.Blog.CreateSeries:input_type -> grpc_tutorial.CreateSeriesRequest
+	56,  // 117: grpc_tutorial.Blog.ListSeries:input_type -> grpc_tutorial.ListSeriesRequest
+	57,  // 118: grpc_tutorial.Blog.GetSeries:input_type -> grpc_tutorial.GetSeriesRequest
+	60,  // 119: grpc_tutorial.Blog.AddToSeries:input_type -> grpc_tutorial.AddToSeriesRequest
+	61,  // 120: grpc_tutorial.Blog.RemoveFromSeries:input_type -> grpc_tutorial.RemoveFromSeriesRequest
+	62,  // 121: grpc_tutorial.Blog.ReorderSeries:input_type -> grpc_tutorial.ReorderSeriesRequest
+	63,  // 122: grpc_tutorial.Blog.DeleteSeries:input_type -> grpc_tutorial.DeleteSeriesRequest
+	71,  // 123: grpc_tutorial.Admin.SetDebugLogging:input_type -> grpc_tutorial.SetDebugLoggingRequest
+	72,  // 124: grpc_tutorial.Admin.GetDebugLogging:input_type -> grpc_tutorial.GetDebugLoggingRequest
+	80,  // 125: grpc_tutorial.Admin.GetStorageStats:input_type -> grpc_tutorial.GetStorageStatsRequest
+	74,  // 126: grpc_tutorial.Admin.SetMaintenance:input_type -> grpc_tutorial.SetMaintenanceRequest
+	75,  // 127: grpc_tutorial.Admin.GetMaintenance:input_type -> grpc_tutorial.GetMaintenanceRequest
+	77,  // 128: grpc_tutorial.Admin.ReloadConfig:input_type -> grpc_tutorial.ReloadConfigRequest
+	82,  // 129: grpc_tutorial.Admin.FlushStorage:input_type -> grpc_tutorial.FlushStorageRequest
+	84,  // 130: grpc_tutorial.Admin.ReencryptStorage:input_type -> grpc_tutorial.ReencryptStorageRequest
+	86,  // 131: grpc_tutorial.Admin.ListScheduledTasks:input_type -> grpc_tutorial.ListScheduledTasksRequest
+	87,  // 132: grpc_tutorial.Admin.RunScheduledTask:input_type -> grpc_tutorial.RunScheduledTaskRequest
+	146, // 133: grpc_tutorial.Admin.AddBan:input_type -> grpc_tutorial.AddBanRequest
+	147, // 134: grpc_tutorial.Admin.RemoveBan:input_type -> grpc_tutorial.RemoveBanRequest
+	149, // 135: grpc_tutorial.Admin.ListBans:input_type -> grpc_tutorial.ListBansRequest
+	151, // 136: grpc_tutorial.Admin.GetConnectionStats:input_type -> grpc_tutorial.GetConnectionStatsRequest
+	155, // 137: grpc_tutorial.Admin.ListConnections:input_type -> grpc_tutorial.ListConnectionsRequest
+	158, // 138: grpc_tutorial.Admin.StreamServerStats:input_type -> grpc_tutorial.StreamServerStatsRequest
+	161, // 139: grpc_tutorial.Admin.ExportEvents:input_type -> grpc_tutorial.ExportEventsRequest
+	12,  // 140: grpc_tutorial.Blog.GetPosts:output_type -> grpc_tutorial.Posts
+	10,  // 141: grpc_tutorial.Blog.CreatePost:output_type -> grpc_tutorial.Post
+	10,  // 142: grpc_tutorial.Blog.UpdatePost:output_type -> grpc_tutorial.Post
+	32,  // 143: grpc_tutorial.Blog.DeletePost:output_type -> grpc_tutorial.DeletePostResponse
+	18,  // 144: grpc_tutorial.Blog.SyncChanges:output_type -> grpc_tutorial.SyncChangesResponse
+	11,  // 145: grpc_tutorial.Blog.UploadAttachment:output_type -> grpc_tutorial.Attachment
+	22,  // 146: grpc_tutorial.Blog.DownloadAttachment:output_type -> grpc_tutorial.DownloadAttachmentResponse
+	70,  // 147: grpc_tutorial.Blog.GetAttachmentURL:output_type -> grpc_tutorial.AttachmentURL
+	24,  // 148: grpc_tutorial.Blog.RenderPost:output_type -> grpc_tutorial.RenderedPost
+	26,  // 149: grpc_tutorial.Blog.WatchPosts:output_type -> grpc_tutorial.PostEvent
+	28,  // 150: grpc_tutorial.Blog.ListRevisions:output_type -> grpc_tutorial.Revisions
+	10,  // 151: grpc_tutorial.Blog.RestoreRevision:output_type -> grpc_tutorial.Post
+	34,  // 152: grpc_tutorial.Blog.QueryAuditLog:output_type -> grpc_tutorial.AuditEntries
+	38,  // 153: grpc_tutorial.Blog.RegisterWebhook:output_type -> grpc_tutorial.Webhook
+	42,  // 154: grpc_tutorial.Blog.UnregisterWebhook:output_type -> grpc_tutorial.UnregisterWebhookResponse
+	39,  // 155: grpc_tutorial.Blog.ListWebhooks:output_type -> grpc_tutorial.Webhooks
+	66,  // 156: grpc_tutorial.Blog.SubscribeByEmail:output_type -> grpc_tutorial.SubscribeByEmailResponse
+	68,  // 157: grpc_tutorial.Blog.UnsubscribeByEmail:output_type -> grpc_tutorial.UnsubscribeByEmailResponse
+	37,  // 158: grpc_tutorial.Blog.StreamPosts:output_type -> grpc_tutorial.StreamPostsResponse
+	91,  // 159: grpc_tutorial.Blog.GetPublishingSchedule:output_type -> grpc_tutorial.PublishingSchedule
+	96,  // 160: grpc_tutorial.Blog.GetTrendingPosts:output_type -> grpc_tutorial.TrendingPosts
+	99,  // 161: grpc_tutorial.Blog.GetPostAnalytics:output_type -> grpc_tutorial.PostAnalytics
+	103, // 162: grpc_tutorial.Blog.GetDailySummary:output_type -> grpc_tutorial.DailySummary
+	107, // 163: grpc_tutorial.Blog.GetAuthorProfile:output_type -> grpc_tutorial.AuthorProfile
+	107, // 164: grpc_tutorial.Blog.SetAuthorBio:output_type -> grpc_tutorial.AuthorProfile
+	109, // 165: grpc_tutorial.Blog.RecordView:output_type -> grpc_tutorial.RecordViewResponse
+	112, // 166: grpc_tutorial.Blog.GetRelatedPosts:output_type -> grpc_tutorial.RelatedPosts
+	10,  // 167: grpc_tutorial.Blog.GetPostBySlug:output_type -> grpc_tutorial.Post
+	12,  // 168: grpc_tutorial.Blog.GetBacklinks:output_type -> grpc_tutorial.Posts
+	116, // 169: grpc_tutorial.Blog.GetSitemap:output_type -> grpc_tutorial.Sitemap
+	118, // 170: grpc_tutorial.Blog.StartSession:output_type -> grpc_tutorial.Session
+	120, // 171: grpc_tutorial.Blog.GetChallenge:output_type -> grpc_tutorial.Challenge
+	122, // 172: grpc_tutorial.Blog.GetReadingHistory:output_type -> grpc_tutorial.ReadingHistory
+	123, // 173: grpc_tutorial.Blog.MarkAsRead:output_type -> grpc_tutorial.HistoryEntry
+	127, // 174: grpc_tutorial.Blog.ListNotifications:output_type -> grpc_tutorial.Notifications
+	127, // 175: grpc_tutorial.Blog.MarkNotificationRead:output_type -> grpc_tutorial.Notifications
+	125, // 176: grpc_tutorial.Blog.StreamNotifications:output_type -> grpc_tutorial.Notification
+	130, // 177: grpc_tutorial.Blog.AddComment:output_type -> grpc_tutorial.Comment
+	133, // 178: grpc_tutorial.Blog.GetComments:output_type -> grpc_tutorial.Comments
+	130, // 179: grpc_tutorial.Blog.ApproveComment:output_type -> grpc_tutorial.Comment
+	130, // 180: grpc_tutorial.Blog.RejectComment:output_type -> grpc_tutorial.Comment
+	139, // 181: grpc_tutorial.Blog.ReportPost:output_type -> grpc_tutorial.Report
+	139, // 182: grpc_tutorial.Blog.ReportComment:output_type -> grpc_tutorial.Report
+	143, // 183: grpc_tutorial.Blog.ListReports:output_type -> grpc_tutorial.Reports
+	139, // 184: grpc_tutorial.Blog.ResolveReport:output_type -> grpc_tutorial.Report
+	138, // 185: grpc_tutorial.Blog.DeletePosts:output_type -> grpc_tutorial.BulkPostsResponse
+	138, // 186: grpc_tutorial.Blog.ArchivePosts:output_type -> grpc_tutorial.BulkPostsResponse
+	10,  // 187: grpc_tutorial.Blog.PinPost:output_type -> grpc_tutorial.Post
+	10,  // 188: grpc_tutorial.Blog.UnpinPost:output_type -> grpc_tutorial.Post
+	44,  // 189: grpc_tutorial.Blog.CreateTemplate:output_type -> grpc_tutorial.PostTemplate
+	44,  // 190: grpc_tutorial.Blog.GetTemplate:output_type -> grpc_tutorial.PostTemplate
+	45,  // 191: grpc_tutorial.Blog.ListTemplates:output_type -> grpc_tutorial.PostTemplates
+	44,  // 192: grpc_tutorial.Blog.UpdateTemplate:output_type -> grpc_tutorial.PostTemplate
+	51,  // 193: grpc_tutorial.Blog.DeleteTemplate:output_type -> grpc_tutorial.DeleteTemplateResponse
+	10,  // 194: grpc_tutorial.Blog.CreatePostFromTemplate:output_type -> grpc_tutorial.Post
+	53,  // 195: grpc_tutorial.Blog.CreateSeries:output_type -> grpc_tutorial.Series
+	54,  // 196: grpc_tutorial.Blog.ListSeries:output_type -> grpc_tutorial.SeriesList
+	58,  // 197: grpc_tutorial.Blog.GetSeries:output_type -> grpc_tutorial.SeriesPosts
+	53,  // 198: grpc_tutorial.Blog.AddToSeries:output_type -> grpc_tutorial.Series
+	53,  // 199: grpc_tutorial.Blog.RemoveFromSeries:output_type -> grpc_tutorial.Series
+	53,  // 200: grpc_tutorial.Blog.ReorderSeries:output_type -> grpc_tutorial.Series
+	64,  // 201: grpc_tutorial.Blog.DeleteSeries:output_type -> grpc_tutorial.DeleteSeriesResponse
+	73,  // 202: grpc_tutorial.Admin.SetDebugLogging:output_type -> grpc_tutorial.DebugLogging
+	73,  // 203: grpc_tutorial.Admin.GetDebugLogging:output_type -> grpc_tutorial.DebugLogging
+	81,  // 204: grpc_tutorial.Admin.GetStorageStats:output_type -> grpc_tutorial.StorageStats
+	76,  // 205: grpc_tutorial.Admin.SetMaintenance:output_type -> grpc_tutorial.Maintenance
+	76,  // 206: grpc_tutorial.Admin.GetMaintenance:output_type -> grpc_tutorial.Maintenance
+	79,  // 207: grpc_tutorial.Admin.ReloadConfig:output_type -> grpc_tutorial.ConfigReload
+	83,  // 208: grpc_tutorial.Admin.FlushStorage:output_type -> grpc_tutorial.StorageFlush
+	85,  // 209: grpc_tutorial.Admin.ReencryptStorage:output_type -> grpc_tutorial.StorageReencryption
+	89,  // 210: grpc_tutorial.Admin.ListScheduledTasks:output_type -> grpc_tutorial.ScheduledTasks
+	88,  // 211: grpc_tutorial.Admin.RunScheduledTask:output_type -> grpc_tutorial.ScheduledTask
+	145, // 212: grpc_tutorial.Admin.AddBan:output_type -> grpc_tutorial.Ban
+	148, // 213: grpc_tutorial.Admin.RemoveBan:output_type -> grpc_tutorial.RemoveBanResponse
+	150, // 214: grpc_tutorial.Admin.ListBans:output_type -> grpc_tutorial.Bans
+	152, // 215: grpc_tutorial.Admin.GetConnectionStats:output_type -> grpc_tutorial.ConnectionStats
+	156, // 216: grpc_tutorial.Admin.ListConnections:output_type -> grpc_tutorial.Connections
+	159, // 217: grpc_tutorial.Admin.StreamServerStats:output_type -> grpc_tutorial.ServerStats
+	162, // 218: grpc_tutorial.Admin.ExportEvents:output_type -> grpc_tutorial.ExportChunk
+	140, // [140:219] is the sub-list for method output_type
+	61,  // [61:140] is the sub-list for method input_type
+	61,  // [61:61] is the sub-list for extension type_name
+	61,  // [61:61] is the sub-list for extension extendee
+	0,   // [0:61] is the sub-list for field type_name
 }
 
 func init() { file_blog_proto_init() }
@@ -329,18 +11093,27 @@ func file_blog_proto_init() {
 	if File_blog_proto != nil {
 		return
 	}
+	file_blog_proto_msgTypes[10].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Metadata)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	file_blog_proto_msgTypes[12].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Metadata)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_blog_proto_rawDesc), len(file_blog_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      10,
+			NumMessages:   154,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_blog_proto_goTypes,
 		DependencyIndexes: file_blog_proto_depIdxs,
+		EnumInfos:         file_blog_proto_enumTypes,
 		MessageInfos:      file_blog_proto_msgTypes,
 	}.Build()
 	File_blog_proto = out.File
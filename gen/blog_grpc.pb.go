@@ -22,8 +22,68 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Blog_GetPosts_FullMethodName   = "/grpc_tutorial.Blog/GetPosts"
-	Blog_CreatePost_FullMethodName = "/grpc_tutorial.Blog/CreatePost"
+	Blog_GetPosts_FullMethodName               = "/grpc_tutorial.Blog/GetPosts"
+	Blog_CreatePost_FullMethodName             = "/grpc_tutorial.Blog/CreatePost"
+	Blog_UpdatePost_FullMethodName             = "/grpc_tutorial.Blog/UpdatePost"
+	Blog_DeletePost_FullMethodName             = "/grpc_tutorial.Blog/DeletePost"
+	Blog_SyncChanges_FullMethodName            = "/grpc_tutorial.Blog/SyncChanges"
+	Blog_UploadAttachment_FullMethodName       = "/grpc_tutorial.Blog/UploadAttachment"
+	Blog_DownloadAttachment_FullMethodName     = "/grpc_tutorial.Blog/DownloadAttachment"
+	Blog_GetAttachmentURL_FullMethodName       = "/grpc_tutorial.Blog/GetAttachmentURL"
+	Blog_RenderPost_FullMethodName             = "/grpc_tutorial.Blog/RenderPost"
+	Blog_WatchPosts_FullMethodName             = "/grpc_tutorial.Blog/WatchPosts"
+	Blog_ListRevisions_FullMethodName          = "/grpc_tutorial.Blog/ListRevisions"
+	Blog_RestoreRevision_FullMethodName        = "/grpc_tutorial.Blog/RestoreRevision"
+	Blog_QueryAuditLog_FullMethodName          = "/grpc_tutorial.Blog/QueryAuditLog"
+	Blog_RegisterWebhook_FullMethodName        = "/grpc_tutorial.Blog/RegisterWebhook"
+	Blog_UnregisterWebhook_FullMethodName      = "/grpc_tutorial.Blog/UnregisterWebhook"
+	Blog_ListWebhooks_FullMethodName           = "/grpc_tutorial.Blog/ListWebhooks"
+	Blog_SubscribeByEmail_FullMethodName       = "/grpc_tutorial.Blog/SubscribeByEmail"
+	Blog_UnsubscribeByEmail_FullMethodName     = "/grpc_tutorial.Blog/UnsubscribeByEmail"
+	Blog_StreamPosts_FullMethodName            = "/grpc_tutorial.Blog/StreamPosts"
+	Blog_GetPublishingSchedule_FullMethodName  = "/grpc_tutorial.Blog/GetPublishingSchedule"
+	Blog_GetTrendingPosts_FullMethodName       = "/grpc_tutorial.Blog/GetTrendingPosts"
+	Blog_GetPostAnalytics_FullMethodName       = "/grpc_tutorial.Blog/GetPostAnalytics"
+	Blog_GetDailySummary_FullMethodName        = "/grpc_tutorial.Blog/GetDailySummary"
+	Blog_GetAuthorProfile_FullMethodName       = "/grpc_tutorial.Blog/GetAuthorProfile"
+	Blog_SetAuthorBio_FullMethodName           = "/grpc_tutorial.Blog/SetAuthorBio"
+	Blog_RecordView_FullMethodName             = "/grpc_tutorial.Blog/RecordView"
+	Blog_GetRelatedPosts_FullMethodName        = "/grpc_tutorial.Blog/GetRelatedPosts"
+	Blog_GetPostBySlug_FullMethodName          = "/grpc_tutorial.Blog/GetPostBySlug"
+	Blog_GetBacklinks_FullMethodName           = "/grpc_tutorial.Blog/GetBacklinks"
+	Blog_GetSitemap_FullMethodName             = "/grpc_tutorial.Blog/GetSitemap"
+	Blog_StartSession_FullMethodName           = "/grpc_tutorial.Blog/StartSession"
+	Blog_GetChallenge_FullMethodName           = "/grpc_tutorial.Blog/GetChallenge"
+	Blog_GetReadingHistory_FullMethodName      = "/grpc_tutorial.Blog/GetReadingHistory"
+	Blog_MarkAsRead_FullMethodName             = "/grpc_tutorial.Blog/MarkAsRead"
+	Blog_ListNotifications_FullMethodName      = "/grpc_tutorial.Blog/ListNotifications"
+	Blog_MarkNotificationRead_FullMethodName   = "/grpc_tutorial.Blog/MarkNotificationRead"
+	Blog_StreamNotifications_FullMethodName    = "/grpc_tutorial.Blog/StreamNotifications"
+	Blog_AddComment_FullMethodName             = "/grpc_tutorial.Blog/AddComment"
+	Blog_GetComments_FullMethodName            = "/grpc_tutorial.Blog/GetComments"
+	Blog_ApproveComment_FullMethodName         = "/grpc_tutorial.Blog/ApproveComment"
+	Blog_RejectComment_FullMethodName          = "/grpc_tutorial.Blog/RejectComment"
+	Blog_ReportPost_FullMethodName             = "/grpc_tutorial.Blog/ReportPost"
+	Blog_ReportComment_FullMethodName          = "/grpc_tutorial.Blog/ReportComment"
+	Blog_ListReports_FullMethodName            = "/grpc_tutorial.Blog/ListReports"
+	Blog_ResolveReport_FullMethodName          = "/grpc_tutorial.Blog/ResolveReport"
+	Blog_DeletePosts_FullMethodName            = "/grpc_tutorial.Blog/DeletePosts"
+	Blog_ArchivePosts_FullMethodName           = "/grpc_tutorial.Blog/ArchivePosts"
+	Blog_PinPost_FullMethodName                = "/grpc_tutorial.Blog/PinPost"
+	Blog_UnpinPost_FullMethodName              = "/grpc_tutorial.Blog/UnpinPost"
+	Blog_CreateTemplate_FullMethodName         = "/grpc_tutorial.Blog/CreateTemplate"
+	Blog_GetTemplate_FullMethodName            = "/grpc_tutorial.Blog/GetTemplate"
+	Blog_ListTemplates_FullMethodName          = "/grpc_tutorial.Blog/ListTemplates"
+	Blog_UpdateTemplate_FullMethodName         = "/grpc_tutorial.Blog/UpdateTemplate"
+	Blog_DeleteTemplate_FullMethodName         = "/grpc_tutorial.Blog/DeleteTemplate"
+	Blog_CreatePostFromTemplate_FullMethodName = "/grpc_tutorial.Blog/CreatePostFromTemplate"
+	Blog_CreateSeries_FullMethodName           = "/grpc_tutorial.Blog/CreateSeries"
+	Blog_ListSeries_FullMethodName             = "/grpc_tutorial.Blog/ListSeries"
+	Blog_GetSeries_FullMethodName              = "/grpc_tutorial.Blog/GetSeries"
+	Blog_AddToSeries_FullMethodName            = "/grpc_tutorial.Blog/AddToSeries"
+	Blog_RemoveFromSeries_FullMethodName       = "/grpc_tutorial.Blog/RemoveFromSeries"
+	Blog_ReorderSeries_FullMethodName          = "/grpc_tutorial.Blog/ReorderSeries"
+	Blog_DeleteSeries_FullMethodName           = "/grpc_tutorial.Blog/DeleteSeries"
 )
 
 // BlogClient is the client API for Blog service.
@@ -41,6 +101,101 @@ type BlogClient interface {
 	// RPCs allow clients to call server methods as if they were local functions.
 	GetPosts(ctx context.Context, in *GetPostsRequest, opts ...grpc.CallOption) (*Posts, error)
 	CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*Post, error)
+	UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*Post, error)
+	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error)
+	// Returns the posts created after the given cursor so clients can keep a local copy up to date without downloading everything again.
+	SyncChanges(ctx context.Context, in *SyncChangesRequest, opts ...grpc.CallOption) (*SyncChangesResponse, error)
+	// STREAMING RPCs
+	// The stream keyword turns a request or a response into a sequence of messages:
+	// - Client streaming (UploadAttachment): the client sends many messages and the server answers once when the client is done.
+	// - Server streaming (DownloadAttachment): the client sends one request and the server answers with many messages.
+	// Streaming is the natural fit for files since neither side needs to hold the whole file in memory.
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, Attachment], error)
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error)
+	// Returns a temporary URL the attachment can be downloaded from without going through the server. Only available when attachments are kept in a bucket.
+	GetAttachmentURL(ctx context.Context, in *GetAttachmentURLRequest, opts ...grpc.CallOption) (*AttachmentURL, error)
+	// Converts the Markdown content of a post into sanitized HTML so thin clients can display it without a Markdown library.
+	RenderPost(ctx context.Context, in *RenderPostRequest, opts ...grpc.CallOption) (*RenderedPost, error)
+	// Streams an event every time a post is created, updated, deleted or published, either right away on creation or when its PublishAt time comes.
+	WatchPosts(ctx context.Context, in *WatchPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PostEvent], error)
+	// Every UpdatePost keeps the previous version of the post around as a revision.
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*Revisions, error)
+	RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*Post, error)
+	// Who created, updated or deleted what and when. Only available to admins.
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*AuditEntries, error)
+	// Webhooks get a signed HTTP POST for every post event they subscribed to. Only available to admins.
+	RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	UnregisterWebhook(ctx context.Context, in *UnregisterWebhookRequest, opts ...grpc.CallOption) (*UnregisterWebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*Webhooks, error)
+	// Email subscribers get a message for every published post. Every email carries the token to unsubscribe. Only available when the server has an SMTP server configured.
+	SubscribeByEmail(ctx context.Context, in *SubscribeByEmailRequest, opts ...grpc.CallOption) (*SubscribeByEmailResponse, error)
+	UnsubscribeByEmail(ctx context.Context, in *UnsubscribeByEmailRequest, opts ...grpc.CallOption) (*UnsubscribeByEmailResponse, error)
+	// Same posts as GetPosts, sent one message per post. Every message carries a cursor the client can pass back to continue a dropped stream.
+	StreamPosts(ctx context.Context, in *StreamPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamPostsResponse], error)
+	// The scheduled posts of a date range grouped by day, for editorial calendars. Scheduled posts are hidden from readers, so only admins can see them.
+	GetPublishingSchedule(ctx context.Context, in *GetPublishingScheduleRequest, opts ...grpc.CallOption) (*PublishingSchedule, error)
+	// Every view is recorded with its time, so besides the ViewCount of a post the server knows when the views happened. GetTrendingPosts ranks the posts by their views over a recent window and GetPostAnalytics returns the views of a post over time.
+	GetTrendingPosts(ctx context.Context, in *GetTrendingPostsRequest, opts ...grpc.CallOption) (*TrendingPosts, error)
+	GetPostAnalytics(ctx context.Context, in *GetPostAnalyticsRequest, opts ...grpc.CallOption) (*PostAnalytics, error)
+	// The views of a day or a week for every post and author, next to those of the period before. The rollup-summaries task sums the views of every day that ended, the sums are kept long after the views themselves.
+	GetDailySummary(ctx context.Context, in *GetDailySummaryRequest, opts ...grpc.CallOption) (*DailySummary, error)
+	// Everything the page of an author shows in one call: the bio, the numbers, the latest posts and the tags, from a cache the server keeps up to date as posts are written. SetAuthorBio requires the admin token.
+	GetAuthorProfile(ctx context.Context, in *GetAuthorProfileRequest, opts ...grpc.CallOption) (*AuthorProfile, error)
+	SetAuthorBio(ctx context.Context, in *SetAuthorBioRequest, opts ...grpc.CallOption) (*AuthorProfile, error)
+	// Counts a view of a post. GetPosts doesn't count views, clients call RecordView when a post is actually read. Views of the same viewer within a few minutes of each other count once.
+	RecordView(ctx context.Context, in *RecordViewRequest, opts ...grpc.CallOption) (*RecordViewResponse, error)
+	// Published posts similar to the given one, ranked by the tags they share and how alike their words are.
+	GetRelatedPosts(ctx context.Context, in *GetRelatedPostsRequest, opts ...grpc.CallOption) (*RelatedPosts, error)
+	// Resolves a pretty URL to its post. Only published posts are found.
+	GetPostBySlug(ctx context.Context, in *GetPostBySlugRequest, opts ...grpc.CallOption) (*Post, error)
+	// The published posts whose content links to a post, see internal/store/links.go
+	GetBacklinks(ctx context.Context, in *GetBacklinksRequest, opts ...grpc.CallOption) (*Posts, error)
+	// The sitemap.xml of the published posts, for search engines. Needs -site-url, see sitemap.go
+	GetSitemap(ctx context.Context, in *GetSitemapRequest, opts ...grpc.CallOption) (*Sitemap, error)
+	// Gives an anonymous reader a viewer token to send in the x-session metadata, which tells it apart from the other readers sharing its address. Called with a valid token it renews it. See sessions.go
+	StartSession(ctx context.Context, in *StartSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	// A proof of work anonymous callers solve and send in the x-challenge metadata of CreatePost when the server runs with -challenge-bits, see challenges.go
+	GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
+	// The posts the caller viewed, the most recent first. Needs a session or another identity, see sessions.go
+	GetReadingHistory(ctx context.Context, in *GetReadingHistoryRequest, opts ...grpc.CallOption) (*ReadingHistory, error)
+	// Viewing a post only opens it, MarkAsRead tells it was read to the end, or with Unread that it wasn't. GetPosts with UnreadOnly leaves out the posts marked as read.
+	MarkAsRead(ctx context.Context, in *MarkAsReadRequest, opts ...grpc.CallOption) (*HistoryEntry, error)
+	// The inbox of the caller: its posts getting published, new comments on them. StreamNotifications sends the new ones as they come, until the client goes away. Needs a session or another identity, see notifications.go
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*Notifications, error)
+	MarkNotificationRead(ctx context.Context, in *MarkNotificationReadRequest, opts ...grpc.CallOption) (*Notifications, error)
+	StreamNotifications(ctx context.Context, in *StreamNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error)
+	// Comments on the published posts, in threads: a comment can answer another one with ParentId. New comments wait for a moderator unless the server lets them through, see comments.go. Approving and rejecting them is only available to admins.
+	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	GetComments(ctx context.Context, in *GetCommentsRequest, opts ...grpc.CallOption) (*Comments, error)
+	ApproveComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	RejectComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	// Anybody can report a post or a comment breaking the rules. The admins go through the open reports with ListReports and resolve them: dismiss the report, hide what was reported, or hide it and ban its author. See reports.go
+	ReportPost(ctx context.Context, in *ReportPostRequest, opts ...grpc.CallOption) (*Report, error)
+	ReportComment(ctx context.Context, in *ReportCommentRequest, opts ...grpc.CallOption) (*Report, error)
+	ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*Reports, error)
+	ResolveReport(ctx context.Context, in *ResolveReportRequest, opts ...grpc.CallOption) (*Report, error)
+	// Delete or archive every post matching a filter in one save. With DryRun nothing changes, the response tells what would. Only available to admins.
+	DeletePosts(ctx context.Context, in *BulkPostsRequest, opts ...grpc.CallOption) (*BulkPostsResponse, error)
+	ArchivePosts(ctx context.Context, in *BulkPostsRequest, opts ...grpc.CallOption) (*BulkPostsResponse, error)
+	// Pinned posts come first in GetPosts, in the order they were given. PinPost also moves a post already pinned. Only available to admins.
+	PinPost(ctx context.Context, in *PinPostRequest, opts ...grpc.CallOption) (*Post, error)
+	UnpinPost(ctx context.Context, in *UnpinPostRequest, opts ...grpc.CallOption) (*Post, error)
+	// Templates give recurring posts the same shape, CreatePostFromTemplate fills their placeholders like {{author}} and {{date}}. See posttemplates.go. Anybody can read them and create posts from them, changing them is only available to admins.
+	CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*PostTemplate, error)
+	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*PostTemplate, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*PostTemplates, error)
+	UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*PostTemplate, error)
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
+	CreatePostFromTemplate(ctx context.Context, in *CreatePostFromTemplateRequest, opts ...grpc.CallOption) (*Post, error)
+	// A series is an ordered list of posts read one after the other, like the parts of a tutorial. See series.go. Anybody can read them, changing them is only available to admins.
+	CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*Series, error)
+	ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*SeriesList, error)
+	// The published posts of the series in their order, each with the posts before and after it.
+	GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*SeriesPosts, error)
+	AddToSeries(ctx context.Context, in *AddToSeriesRequest, opts ...grpc.CallOption) (*Series, error)
+	RemoveFromSeries(ctx context.Context, in *RemoveFromSeriesRequest, opts ...grpc.CallOption) (*Series, error)
+	ReorderSeries(ctx context.Context, in *ReorderSeriesRequest, opts ...grpc.CallOption) (*Series, error)
+	DeleteSeries(ctx context.Context, in *DeleteSeriesRequest, opts ...grpc.CallOption) (*DeleteSeriesResponse, error)
 }
 
 type blogClient struct {
@@ -71,110 +226,3056 @@ func (c *blogClient) CreatePost(ctx context.Context, in *CreatePostRequest, opts
 	return out, nil
 }
 
+func (c *blogClient) UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, Blog_UpdatePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePostResponse)
+	err := c.cc.Invoke(ctx, Blog_DeletePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) SyncChanges(ctx context.Context, in *SyncChangesRequest, opts ...grpc.CallOption) (*SyncChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncChangesResponse)
+	err := c.cc.Invoke(ctx, Blog_SyncChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, Attachment], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Blog_ServiceDesc.Streams[0], Blog_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadAttachmentRequest, Attachment]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_UploadAttachmentClient = grpc.ClientStreamingClient[UploadAttachmentRequest, Attachment]
+
+func (c *blogClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Blog_ServiceDesc.Streams[1], Blog_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_DownloadAttachmentClient = grpc.ServerStreamingClient[DownloadAttachmentResponse]
+
+func (c *blogClient) GetAttachmentURL(ctx context.Context, in *GetAttachmentURLRequest, opts ...grpc.CallOption) (*AttachmentURL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachmentURL)
+	err := c.cc.Invoke(ctx, Blog_GetAttachmentURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) RenderPost(ctx context.Context, in *RenderPostRequest, opts ...grpc.CallOption) (*RenderedPost, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderedPost)
+	err := c.cc.Invoke(ctx, Blog_RenderPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) WatchPosts(ctx context.Context, in *WatchPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PostEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Blog_ServiceDesc.Streams[2], Blog_WatchPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPostsRequest, PostEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_WatchPostsClient = grpc.ServerStreamingClient[PostEvent]
+
+func (c *blogClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*Revisions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Revisions)
+	err := c.cc.Invoke(ctx, Blog_ListRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, Blog_RestoreRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*AuditEntries, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditEntries)
+	err := c.cc.Invoke(ctx, Blog_QueryAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, Blog_RegisterWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) UnregisterWebhook(ctx context.Context, in *UnregisterWebhookRequest, opts ...grpc.CallOption) (*UnregisterWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnregisterWebhookResponse)
+	err := c.cc.Invoke(ctx, Blog_UnregisterWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*Webhooks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhooks)
+	err := c.cc.Invoke(ctx, Blog_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) SubscribeByEmail(ctx context.Context, in *SubscribeByEmailRequest, opts ...grpc.CallOption) (*SubscribeByEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubscribeByEmailResponse)
+	err := c.cc.Invoke(ctx, Blog_SubscribeByEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) UnsubscribeByEmail(ctx context.Context, in *UnsubscribeByEmailRequest, opts ...grpc.CallOption) (*UnsubscribeByEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnsubscribeByEmailResponse)
+	err := c.cc.Invoke(ctx, Blog_UnsubscribeByEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) StreamPosts(ctx context.Context, in *StreamPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamPostsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Blog_ServiceDesc.Streams[3], Blog_StreamPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPostsRequest, StreamPostsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_StreamPostsClient = grpc.ServerStreamingClient[StreamPostsResponse]
+
+func (c *blogClient) GetPublishingSchedule(ctx context.Context, in *GetPublishingScheduleRequest, opts ...grpc.CallOption) (*PublishingSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishingSchedule)
+	err := c.cc.Invoke(ctx, Blog_GetPublishingSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetTrendingPosts(ctx context.Context, in *GetTrendingPostsRequest, opts ...grpc.CallOption) (*TrendingPosts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrendingPosts)
+	err := c.cc.Invoke(ctx, Blog_GetTrendingPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetPostAnalytics(ctx context.Context, in *GetPostAnalyticsRequest, opts ...grpc.CallOption) (*PostAnalytics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostAnalytics)
+	err := c.cc.Invoke(ctx, Blog_GetPostAnalytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetDailySummary(ctx context.Context, in *GetDailySummaryRequest, opts ...grpc.CallOption) (*DailySummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DailySummary)
+	err := c.cc.Invoke(ctx, Blog_GetDailySummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetAuthorProfile(ctx context.Context, in *GetAuthorProfileRequest, opts ...grpc.CallOption) (*AuthorProfile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthorProfile)
+	err := c.cc.Invoke(ctx, Blog_GetAuthorProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) SetAuthorBio(ctx context.Context, in *SetAuthorBioRequest, opts ...grpc.CallOption) (*AuthorProfile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthorProfile)
+	err := c.cc.Invoke(ctx, Blog_SetAuthorBio_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) RecordView(ctx context.Context, in *RecordViewRequest, opts ...grpc.CallOption) (*RecordViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordViewResponse)
+	err := c.cc.Invoke(ctx, Blog_RecordView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetRelatedPosts(ctx context.Context, in *GetRelatedPostsRequest, opts ...grpc.CallOption) (*RelatedPosts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelatedPosts)
+	err := c.cc.Invoke(ctx, Blog_GetRelatedPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetPostBySlug(ctx context.Context, in *GetPostBySlugRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, Blog_GetPostBySlug_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetBacklinks(ctx context.Context, in *GetBacklinksRequest, opts ...grpc.CallOption) (*Posts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Posts)
+	err := c.cc.Invoke(ctx, Blog_GetBacklinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetSitemap(ctx context.Context, in *GetSitemapRequest, opts ...grpc.CallOption) (*Sitemap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sitemap)
+	err := c.cc.Invoke(ctx, Blog_GetSitemap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) StartSession(ctx context.Context, in *StartSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Session)
+	err := c.cc.Invoke(ctx, Blog_StartSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, Blog_GetChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetReadingHistory(ctx context.Context, in *GetReadingHistoryRequest, opts ...grpc.CallOption) (*ReadingHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadingHistory)
+	err := c.cc.Invoke(ctx, Blog_GetReadingHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) MarkAsRead(ctx context.Context, in *MarkAsReadRequest, opts ...grpc.CallOption) (*HistoryEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryEntry)
+	err := c.cc.Invoke(ctx, Blog_MarkAsRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*Notifications, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notifications)
+	err := c.cc.Invoke(ctx, Blog_ListNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) MarkNotificationRead(ctx context.Context, in *MarkNotificationReadRequest, opts ...grpc.CallOption) (*Notifications, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notifications)
+	err := c.cc.Invoke(ctx, Blog_MarkNotificationRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) StreamNotifications(ctx context.Context, in *StreamNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Blog_ServiceDesc.Streams[4], Blog_StreamNotifications_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamNotificationsRequest, Notification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_StreamNotificationsClient = grpc.ServerStreamingClient[Notification]
+
+func (c *blogClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, Blog_AddComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetComments(ctx context.Context, in *GetCommentsRequest, opts ...grpc.CallOption) (*Comments, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comments)
+	err := c.cc.Invoke(ctx, Blog_GetComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ApproveComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, Blog_ApproveComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) RejectComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, Blog_RejectComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ReportPost(ctx context.Context, in *ReportPostRequest, opts ...grpc.CallOption) (*Report, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Report)
+	err := c.cc.Invoke(ctx, Blog_ReportPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ReportComment(ctx context.Context, in *ReportCommentRequest, opts ...grpc.CallOption) (*Report, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Report)
+	err := c.cc.Invoke(ctx, Blog_ReportComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*Reports, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reports)
+	err := c.cc.Invoke(ctx, Blog_ListReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ResolveReport(ctx context.Context, in *ResolveReportRequest, opts ...grpc.CallOption) (*Report, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Report)
+	err := c.cc.Invoke(ctx, Blog_ResolveReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) DeletePosts(ctx context.Context, in *BulkPostsRequest, opts ...grpc.CallOption) (*BulkPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkPostsResponse)
+	err := c.cc.Invoke(ctx, Blog_DeletePosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ArchivePosts(ctx context.Context, in *BulkPostsRequest, opts ...grpc.CallOption) (*BulkPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkPostsResponse)
+	err := c.cc.Invoke(ctx, Blog_ArchivePosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) PinPost(ctx context.Context, in *PinPostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, Blog_PinPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) UnpinPost(ctx context.Context, in *UnpinPostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, Blog_UnpinPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*PostTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostTemplate)
+	err := c.cc.Invoke(ctx, Blog_CreateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*PostTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostTemplate)
+	err := c.cc.Invoke(ctx, Blog_GetTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*PostTemplates, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostTemplates)
+	err := c.cc.Invoke(ctx, Blog_ListTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*PostTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostTemplate)
+	err := c.cc.Invoke(ctx, Blog_UpdateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTemplateResponse)
+	err := c.cc.Invoke(ctx, Blog_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) CreatePostFromTemplate(ctx context.Context, in *CreatePostFromTemplateRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, Blog_CreatePostFromTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*Series, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Series)
+	err := c.cc.Invoke(ctx, Blog_CreateSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*SeriesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeriesList)
+	err := c.cc.Invoke(ctx, Blog_ListSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*SeriesPosts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeriesPosts)
+	err := c.cc.Invoke(ctx, Blog_GetSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) AddToSeries(ctx context.Context, in *AddToSeriesRequest, opts ...grpc.CallOption) (*Series, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Series)
+	err := c.cc.Invoke(ctx, Blog_AddToSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) RemoveFromSeries(ctx context.Context, in *RemoveFromSeriesRequest, opts ...grpc.CallOption) (*Series, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Series)
+	err := c.cc.Invoke(ctx, Blog_RemoveFromSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) ReorderSeries(ctx context.Context, in *ReorderSeriesRequest, opts ...grpc.CallOption) (*Series, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Series)
+	err := c.cc.Invoke(ctx, Blog_ReorderSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogClient) DeleteSeries(ctx context.Context, in *DeleteSeriesRequest, opts ...grpc.CallOption) (*DeleteSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSeriesResponse)
+	err := c.cc.Invoke(ctx, Blog_DeleteSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlogServer is the server API for Blog service.
 // All implementations must embed UnimplementedBlogServer
 // for forward compatibility.
 //
-// Service:
-// Defines a set of methods that can be called remotely. Think of it as an API contract between the client and server. In gRPC, a service specifies the methods that can be called remotely with their parameters and return types.
-type BlogServer interface {
-	// RPC (Remote Procedure Call):
-	// Defines a single method within a service. Each RPC specifies:
-	// - Method name
-	// - Input message (parameters)
-	// - Output message (return type)
-	// RPCs allow clients to call server methods as if they were local functions.
-	GetPosts(context.Context, *GetPostsRequest) (*Posts, error)
-	CreatePost(context.Context, *CreatePostRequest) (*Post, error)
-	mustEmbedUnimplementedBlogServer()
+// Service:
+// Defines a set of methods that can be called remotely. Think of it as an API contract between the client and server. In gRPC, a service specifies the methods that can be called remotely with their parameters and return types.
+type BlogServer interface {
+	// RPC (Remote Procedure Call):
+	// Defines a single method within a service. Each RPC specifies:
+	// - Method name
+	// - Input message (parameters)
+	// - Output message (return type)
+	// RPCs allow clients to call server methods as if they were local functions.
+	GetPosts(context.Context, *GetPostsRequest) (*Posts, error)
+	CreatePost(context.Context, *CreatePostRequest) (*Post, error)
+	UpdatePost(context.Context, *UpdatePostRequest) (*Post, error)
+	DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error)
+	// Returns the posts created after the given cursor so clients can keep a local copy up to date without downloading everything again.
+	SyncChanges(context.Context, *SyncChangesRequest) (*SyncChangesResponse, error)
+	// STREAMING RPCs
+	// The stream keyword turns a request or a response into a sequence of messages:
+	// - Client streaming (UploadAttachment): the client sends many messages and the server answers once when the client is done.
+	// - Server streaming (DownloadAttachment): the client sends one request and the server answers with many messages.
+	// Streaming is the natural fit for files since neither side needs to hold the whole file in memory.
+	UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, Attachment]) error
+	DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error
+	// Returns a temporary URL the attachment can be downloaded from without going through the server. Only available when attachments are kept in a bucket.
+	GetAttachmentURL(context.Context, *GetAttachmentURLRequest) (*AttachmentURL, error)
+	// Converts the Markdown content of a post into sanitized HTML so thin clients can display it without a Markdown library.
+	RenderPost(context.Context, *RenderPostRequest) (*RenderedPost, error)
+	// Streams an event every time a post is created, updated, deleted or published, either right away on creation or when its PublishAt time comes.
+	WatchPosts(*WatchPostsRequest, grpc.ServerStreamingServer[PostEvent]) error
+	// Every UpdatePost keeps the previous version of the post around as a revision.
+	ListRevisions(context.Context, *ListRevisionsRequest) (*Revisions, error)
+	RestoreRevision(context.Context, *RestoreRevisionRequest) (*Post, error)
+	// Who created, updated or deleted what and when. Only available to admins.
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*AuditEntries, error)
+	// Webhooks get a signed HTTP POST for every post event they subscribed to. Only available to admins.
+	RegisterWebhook(context.Context, *RegisterWebhookRequest) (*Webhook, error)
+	UnregisterWebhook(context.Context, *UnregisterWebhookRequest) (*UnregisterWebhookResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*Webhooks, error)
+	// Email subscribers get a message for every published post. Every email carries the token to unsubscribe. Only available when the server has an SMTP server configured.
+	SubscribeByEmail(context.Context, *SubscribeByEmailRequest) (*SubscribeByEmailResponse, error)
+	UnsubscribeByEmail(context.Context, *UnsubscribeByEmailRequest) (*UnsubscribeByEmailResponse, error)
+	// Same posts as GetPosts, sent one message per post. Every message carries a cursor the client can pass back to continue a dropped stream.
+	StreamPosts(*StreamPostsRequest, grpc.ServerStreamingServer[StreamPostsResponse]) error
+	// The scheduled posts of a date range grouped by day, for editorial calendars. Scheduled posts are hidden from readers, so only admins can see them.
+	GetPublishingSchedule(context.Context, *GetPublishingScheduleRequest) (*PublishingSchedule, error)
+	// Every view is recorded with its time, so besides the ViewCount of a post the server knows when the views happened. GetTrendingPosts ranks the posts by their views over a recent window and GetPostAnalytics returns the views of a post over time.
+	GetTrendingPosts(context.Context, *GetTrendingPostsRequest) (*TrendingPosts, error)
+	GetPostAnalytics(context.Context, *GetPostAnalyticsRequest) (*PostAnalytics, error)
+	// The views of a day or a week for every post and author, next to those of the period before. The rollup-summaries task sums the views of every day that ended, the sums are kept long after the views themselves.
+	GetDailySummary(context.Context, *GetDailySummaryRequest) (*DailySummary, error)
+	// Everything the page of an author shows in one call: the bio, the numbers, the latest posts and the tags, from a cache the server keeps up to date as posts are written. SetAuthorBio requires the admin token.
+	GetAuthorProfile(context.Context, *GetAuthorProfileRequest) (*AuthorProfile, error)
+	SetAuthorBio(context.Context, *SetAuthorBioRequest) (*AuthorProfile, error)
+	// Counts a view of a post. GetPosts doesn't count views, clients call RecordView when a post is actually read. Views of the same viewer within a few minutes of each other count once.
+	RecordView(context.Context, *RecordViewRequest) (*RecordViewResponse, error)
+	// Published posts similar to the given one, ranked by the tags they share and how alike their words are.
+	GetRelatedPosts(context.Context, *GetRelatedPostsRequest) (*RelatedPosts, error)
+	// Resolves a pretty URL to its post. Only published posts are found.
+	GetPostBySlug(context.Context, *GetPostBySlugRequest) (*Post, error)
+	// The published posts whose content links to a post, see internal/store/links.go
+	GetBacklinks(context.Context, *GetBacklinksRequest) (*Posts, error)
+	// The sitemap.xml of the published posts, for search engines. Needs -site-url, see sitemap.go
+	GetSitemap(context.Context, *GetSitemapRequest) (*Sitemap, error)
+	// Gives an anonymous reader a viewer token to send in the x-session metadata, which tells it apart from the other readers sharing its address. Called with a valid token it renews it. See sessions.go
+	StartSession(context.Context, *StartSessionRequest) (*Session, error)
+	// A proof of work anonymous callers solve and send in the x-challenge metadata of CreatePost when the server runs with -challenge-bits, see challenges.go
+	GetChallenge(context.Context, *GetChallengeRequest) (*Challenge, error)
+	// The posts the caller viewed, the most recent first. Needs a session or another identity, see sessions.go
+	GetReadingHistory(context.Context, *GetReadingHistoryRequest) (*ReadingHistory, error)
+	// Viewing a post only opens it, MarkAsRead tells it was read to the end, or with Unread that it wasn't. GetPosts with UnreadOnly leaves out the posts marked as read.
+	MarkAsRead(context.Context, *MarkAsReadRequest) (*HistoryEntry, error)
+	// The inbox of the caller: its posts getting published, new comments on them. StreamNotifications sends the new ones as they come, until the client goes away. Needs a session or another identity, see notifications.go
+	ListNotifications(context.Context, *ListNotificationsRequest) (*Notifications, error)
+	MarkNotificationRead(context.Context, *MarkNotificationReadRequest) (*Notifications, error)
+	StreamNotifications(*StreamNotificationsRequest, grpc.ServerStreamingServer[Notification]) error
+	// Comments on the published posts, in threads: a comment can answer another one with ParentId. New comments wait for a moderator unless the server lets them through, see comments.go. Approving and rejecting them is only available to admins.
+	AddComment(context.Context, *AddCommentRequest) (*Comment, error)
+	GetComments(context.Context, *GetCommentsRequest) (*Comments, error)
+	ApproveComment(context.Context, *ModerateCommentRequest) (*Comment, error)
+	RejectComment(context.Context, *ModerateCommentRequest) (*Comment, error)
+	// Anybody can report a post or a comment breaking the rules. The admins go through the open reports with ListReports and resolve them: dismiss the report, hide what was reported, or hide it and ban its author. See reports.go
+	ReportPost(context.Context, *ReportPostRequest) (*Report, error)
+	ReportComment(context.Context, *ReportCommentRequest) (*Report, error)
+	ListReports(context.Context, *ListReportsRequest) (*Reports, error)
+	ResolveReport(context.Context, *ResolveReportRequest) (*Report, error)
+	// Delete or archive every post matching a filter in one save. With DryRun nothing changes, the response tells what would. Only available to admins.
+	DeletePosts(context.Context, *BulkPostsRequest) (*BulkPostsResponse, error)
+	ArchivePosts(context.Context, *BulkPostsRequest) (*BulkPostsResponse, error)
+	// Pinned posts come first in GetPosts, in the order they were given. PinPost also moves a post already pinned. Only available to admins.
+	PinPost(context.Context, *PinPostRequest) (*Post, error)
+	UnpinPost(context.Context, *UnpinPostRequest) (*Post, error)
+	// Templates give recurring posts the same shape, CreatePostFromTemplate fills their placeholders like {{author}} and {{date}}. See posttemplates.go. Anybody can read them and create posts from them, changing them is only available to admins.
+	CreateTemplate(context.Context, *CreateTemplateRequest) (*PostTemplate, error)
+	GetTemplate(context.Context, *GetTemplateRequest) (*PostTemplate, error)
+	ListTemplates(context.Context, *ListTemplatesRequest) (*PostTemplates, error)
+	UpdateTemplate(context.Context, *UpdateTemplateRequest) (*PostTemplate, error)
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+	CreatePostFromTemplate(context.Context, *CreatePostFromTemplateRequest) (*Post, error)
+	// A series is an ordered list of posts read one after the other, like the parts of a tutorial. See series.go. Anybody can read them, changing them is only available to admins.
+	CreateSeries(context.Context, *CreateSeriesRequest) (*Series, error)
+	ListSeries(context.Context, *ListSeriesRequest) (*SeriesList, error)
+	// The published posts of the series in their order, each with the posts before and after it.
+	GetSeries(context.Context, *GetSeriesRequest) (*SeriesPosts, error)
+	AddToSeries(context.Context, *AddToSeriesRequest) (*Series, error)
+	RemoveFromSeries(context.Context, *RemoveFromSeriesRequest) (*Series, error)
+	ReorderSeries(context.Context, *ReorderSeriesRequest) (*Series, error)
+	DeleteSeries(context.Context, *DeleteSeriesRequest) (*DeleteSeriesResponse, error)
+	mustEmbedUnimplementedBlogServer()
+}
+
+// UnimplementedBlogServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBlogServer struct{}
+
+func (UnimplementedBlogServer) GetPosts(context.Context, *GetPostsRequest) (*Posts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPosts not implemented")
+}
+func (UnimplementedBlogServer) CreatePost(context.Context, *CreatePostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePost not implemented")
+}
+func (UnimplementedBlogServer) UpdatePost(context.Context, *UpdatePostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePost not implemented")
+}
+func (UnimplementedBlogServer) DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePost not implemented")
+}
+func (UnimplementedBlogServer) SyncChanges(context.Context, *SyncChangesRequest) (*SyncChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncChanges not implemented")
+}
+func (UnimplementedBlogServer) UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, Attachment]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedBlogServer) DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedBlogServer) GetAttachmentURL(context.Context, *GetAttachmentURLRequest) (*AttachmentURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachmentURL not implemented")
+}
+func (UnimplementedBlogServer) RenderPost(context.Context, *RenderPostRequest) (*RenderedPost, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderPost not implemented")
+}
+func (UnimplementedBlogServer) WatchPosts(*WatchPostsRequest, grpc.ServerStreamingServer[PostEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPosts not implemented")
+}
+func (UnimplementedBlogServer) ListRevisions(context.Context, *ListRevisionsRequest) (*Revisions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedBlogServer) RestoreRevision(context.Context, *RestoreRevisionRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRevision not implemented")
+}
+func (UnimplementedBlogServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*AuditEntries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
+func (UnimplementedBlogServer) RegisterWebhook(context.Context, *RegisterWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWebhook not implemented")
+}
+func (UnimplementedBlogServer) UnregisterWebhook(context.Context, *UnregisterWebhookRequest) (*UnregisterWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnregisterWebhook not implemented")
+}
+func (UnimplementedBlogServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*Webhooks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedBlogServer) SubscribeByEmail(context.Context, *SubscribeByEmailRequest) (*SubscribeByEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubscribeByEmail not implemented")
+}
+func (UnimplementedBlogServer) UnsubscribeByEmail(context.Context, *UnsubscribeByEmailRequest) (*UnsubscribeByEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsubscribeByEmail not implemented")
+}
+func (UnimplementedBlogServer) StreamPosts(*StreamPostsRequest, grpc.ServerStreamingServer[StreamPostsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPosts not implemented")
+}
+func (UnimplementedBlogServer) GetPublishingSchedule(context.Context, *GetPublishingScheduleRequest) (*PublishingSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublishingSchedule not implemented")
+}
+func (UnimplementedBlogServer) GetTrendingPosts(context.Context, *GetTrendingPostsRequest) (*TrendingPosts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrendingPosts not implemented")
+}
+func (UnimplementedBlogServer) GetPostAnalytics(context.Context, *GetPostAnalyticsRequest) (*PostAnalytics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostAnalytics not implemented")
+}
+func (UnimplementedBlogServer) GetDailySummary(context.Context, *GetDailySummaryRequest) (*DailySummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailySummary not implemented")
+}
+func (UnimplementedBlogServer) GetAuthorProfile(context.Context, *GetAuthorProfileRequest) (*AuthorProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthorProfile not implemented")
+}
+func (UnimplementedBlogServer) SetAuthorBio(context.Context, *SetAuthorBioRequest) (*AuthorProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAuthorBio not implemented")
+}
+func (UnimplementedBlogServer) RecordView(context.Context, *RecordViewRequest) (*RecordViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordView not implemented")
+}
+func (UnimplementedBlogServer) GetRelatedPosts(context.Context, *GetRelatedPostsRequest) (*RelatedPosts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedPosts not implemented")
+}
+func (UnimplementedBlogServer) GetPostBySlug(context.Context, *GetPostBySlugRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostBySlug not implemented")
+}
+func (UnimplementedBlogServer) GetBacklinks(context.Context, *GetBacklinksRequest) (*Posts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBacklinks not implemented")
+}
+func (UnimplementedBlogServer) GetSitemap(context.Context, *GetSitemapRequest) (*Sitemap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSitemap not implemented")
+}
+func (UnimplementedBlogServer) StartSession(context.Context, *StartSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSession not implemented")
+}
+func (UnimplementedBlogServer) GetChallenge(context.Context, *GetChallengeRequest) (*Challenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChallenge not implemented")
+}
+func (UnimplementedBlogServer) GetReadingHistory(context.Context, *GetReadingHistoryRequest) (*ReadingHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReadingHistory not implemented")
+}
+func (UnimplementedBlogServer) MarkAsRead(context.Context, *MarkAsReadRequest) (*HistoryEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkAsRead not implemented")
+}
+func (UnimplementedBlogServer) ListNotifications(context.Context, *ListNotificationsRequest) (*Notifications, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
+}
+func (UnimplementedBlogServer) MarkNotificationRead(context.Context, *MarkNotificationReadRequest) (*Notifications, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkNotificationRead not implemented")
+}
+func (UnimplementedBlogServer) StreamNotifications(*StreamNotificationsRequest, grpc.ServerStreamingServer[Notification]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamNotifications not implemented")
+}
+func (UnimplementedBlogServer) AddComment(context.Context, *AddCommentRequest) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddComment not implemented")
+}
+func (UnimplementedBlogServer) GetComments(context.Context, *GetCommentsRequest) (*Comments, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComments not implemented")
+}
+func (UnimplementedBlogServer) ApproveComment(context.Context, *ModerateCommentRequest) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveComment not implemented")
+}
+func (UnimplementedBlogServer) RejectComment(context.Context, *ModerateCommentRequest) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectComment not implemented")
+}
+func (UnimplementedBlogServer) ReportPost(context.Context, *ReportPostRequest) (*Report, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportPost not implemented")
+}
+func (UnimplementedBlogServer) ReportComment(context.Context, *ReportCommentRequest) (*Report, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportComment not implemented")
+}
+func (UnimplementedBlogServer) ListReports(context.Context, *ListReportsRequest) (*Reports, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReports not implemented")
+}
+func (UnimplementedBlogServer) ResolveReport(context.Context, *ResolveReportRequest) (*Report, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveReport not implemented")
+}
+func (UnimplementedBlogServer) DeletePosts(context.Context, *BulkPostsRequest) (*BulkPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePosts not implemented")
+}
+func (UnimplementedBlogServer) ArchivePosts(context.Context, *BulkPostsRequest) (*BulkPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchivePosts not implemented")
+}
+func (UnimplementedBlogServer) PinPost(context.Context, *PinPostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinPost not implemented")
+}
+func (UnimplementedBlogServer) UnpinPost(context.Context, *UnpinPostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpinPost not implemented")
+}
+func (UnimplementedBlogServer) CreateTemplate(context.Context, *CreateTemplateRequest) (*PostTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTemplate not implemented")
+}
+func (UnimplementedBlogServer) GetTemplate(context.Context, *GetTemplateRequest) (*PostTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
+}
+func (UnimplementedBlogServer) ListTemplates(context.Context, *ListTemplatesRequest) (*PostTemplates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedBlogServer) UpdateTemplate(context.Context, *UpdateTemplateRequest) (*PostTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTemplate not implemented")
+}
+func (UnimplementedBlogServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedBlogServer) CreatePostFromTemplate(context.Context, *CreatePostFromTemplateRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePostFromTemplate not implemented")
+}
+func (UnimplementedBlogServer) CreateSeries(context.Context, *CreateSeriesRequest) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSeries not implemented")
+}
+func (UnimplementedBlogServer) ListSeries(context.Context, *ListSeriesRequest) (*SeriesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSeries not implemented")
+}
+func (UnimplementedBlogServer) GetSeries(context.Context, *GetSeriesRequest) (*SeriesPosts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeries not implemented")
+}
+func (UnimplementedBlogServer) AddToSeries(context.Context, *AddToSeriesRequest) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToSeries not implemented")
+}
+func (UnimplementedBlogServer) RemoveFromSeries(context.Context, *RemoveFromSeriesRequest) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromSeries not implemented")
+}
+func (UnimplementedBlogServer) ReorderSeries(context.Context, *ReorderSeriesRequest) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderSeries not implemented")
+}
+func (UnimplementedBlogServer) DeleteSeries(context.Context, *DeleteSeriesRequest) (*DeleteSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSeries not implemented")
+}
+func (UnimplementedBlogServer) mustEmbedUnimplementedBlogServer() {}
+func (UnimplementedBlogServer) testEmbeddedByValue()              {}
+
+// UnsafeBlogServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlogServer will
+// result in compilation errors.
+type UnsafeBlogServer interface {
+	mustEmbedUnimplementedBlogServer()
+}
+
+func RegisterBlogServer(s grpc.ServiceRegistrar, srv BlogServer) {
+	// If the following call pancis, it indicates UnimplementedBlogServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Blog_ServiceDesc, srv)
+}
+
+func _Blog_GetPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetPosts(ctx, req.(*GetPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_CreatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).CreatePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_CreatePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).CreatePost(ctx, req.(*CreatePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_UpdatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).UpdatePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_UpdatePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).UpdatePost(ctx, req.(*UpdatePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_DeletePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).DeletePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_DeletePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).DeletePost(ctx, req.(*DeletePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_SyncChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).SyncChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_SyncChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).SyncChanges(ctx, req.(*SyncChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlogServer).UploadAttachment(&grpc.GenericServerStream[UploadAttachmentRequest, Attachment]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_UploadAttachmentServer = grpc.ClientStreamingServer[UploadAttachmentRequest, Attachment]
+
+func _Blog_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServer).DownloadAttachment(m, &grpc.GenericServerStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_DownloadAttachmentServer = grpc.ServerStreamingServer[DownloadAttachmentResponse]
+
+func _Blog_GetAttachmentURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetAttachmentURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetAttachmentURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetAttachmentURL(ctx, req.(*GetAttachmentURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_RenderPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).RenderPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_RenderPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).RenderPost(ctx, req.(*RenderPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_WatchPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServer).WatchPosts(m, &grpc.GenericServerStream[WatchPostsRequest, PostEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_WatchPostsServer = grpc.ServerStreamingServer[PostEvent]
+
+func _Blog_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ListRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).RestoreRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_RestoreRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).RestoreRevision(ctx, req.(*RestoreRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_QueryAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_RegisterWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).RegisterWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_RegisterWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).RegisterWebhook(ctx, req.(*RegisterWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_UnregisterWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).UnregisterWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_UnregisterWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).UnregisterWebhook(ctx, req.(*UnregisterWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_SubscribeByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).SubscribeByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_SubscribeByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).SubscribeByEmail(ctx, req.(*SubscribeByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_UnsubscribeByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).UnsubscribeByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_UnsubscribeByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).UnsubscribeByEmail(ctx, req.(*UnsubscribeByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_StreamPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServer).StreamPosts(m, &grpc.GenericServerStream[StreamPostsRequest, StreamPostsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_StreamPostsServer = grpc.ServerStreamingServer[StreamPostsResponse]
+
+func _Blog_GetPublishingSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublishingScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetPublishingSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetPublishingSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetPublishingSchedule(ctx, req.(*GetPublishingScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetTrendingPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendingPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetTrendingPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetTrendingPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetTrendingPosts(ctx, req.(*GetTrendingPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetPostAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetPostAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetPostAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetPostAnalytics(ctx, req.(*GetPostAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetDailySummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailySummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetDailySummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetDailySummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetDailySummary(ctx, req.(*GetDailySummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetAuthorProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthorProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetAuthorProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetAuthorProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetAuthorProfile(ctx, req.(*GetAuthorProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_SetAuthorBio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAuthorBioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).SetAuthorBio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_SetAuthorBio_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).SetAuthorBio(ctx, req.(*SetAuthorBioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_RecordView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).RecordView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_RecordView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).RecordView(ctx, req.(*RecordViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetRelatedPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetRelatedPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetRelatedPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetRelatedPosts(ctx, req.(*GetRelatedPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetPostBySlug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostBySlugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetPostBySlug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetPostBySlug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetPostBySlug(ctx, req.(*GetPostBySlugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetBacklinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBacklinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetBacklinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetBacklinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetBacklinks(ctx, req.(*GetBacklinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetSitemap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSitemapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetSitemap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetSitemap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetSitemap(ctx, req.(*GetSitemapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_StartSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).StartSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_StartSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).StartSession(ctx, req.(*StartSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetChallenge(ctx, req.(*GetChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetReadingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadingHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetReadingHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetReadingHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetReadingHistory(ctx, req.(*GetReadingHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_MarkAsRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkAsReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).MarkAsRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_MarkAsRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).MarkAsRead(ctx, req.(*MarkAsReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ListNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ListNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_MarkNotificationRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkNotificationReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).MarkNotificationRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_MarkNotificationRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).MarkNotificationRead(ctx, req.(*MarkNotificationReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_StreamNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServer).StreamNotifications(m, &grpc.GenericServerStream[StreamNotificationsRequest, Notification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blog_StreamNotificationsServer = grpc.ServerStreamingServer[Notification]
+
+func _Blog_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_AddComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetComments(ctx, req.(*GetCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ApproveComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ApproveComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ApproveComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ApproveComment(ctx, req.(*ModerateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_RejectComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).RejectComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_RejectComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).RejectComment(ctx, req.(*ModerateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ReportPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ReportPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ReportPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ReportPost(ctx, req.(*ReportPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ReportComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ReportComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ReportComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ReportComment(ctx, req.(*ReportCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ListReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ListReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ListReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ListReports(ctx, req.(*ListReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ResolveReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ResolveReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ResolveReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ResolveReport(ctx, req.(*ResolveReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_DeletePosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).DeletePosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_DeletePosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).DeletePosts(ctx, req.(*BulkPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ArchivePosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ArchivePosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ArchivePosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ArchivePosts(ctx, req.(*BulkPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_PinPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).PinPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_PinPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).PinPost(ctx, req.(*PinPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_UnpinPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).UnpinPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_UnpinPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).UnpinPost(ctx, req.(*UnpinPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).CreateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_CreateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).CreateTemplate(ctx, req.(*CreateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetTemplate(ctx, req.(*GetTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ListTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_UpdateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).UpdateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_UpdateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).UpdateTemplate(ctx, req.(*UpdateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_CreatePostFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePostFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).CreatePostFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_CreatePostFromTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).CreatePostFromTemplate(ctx, req.(*CreatePostFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_CreateSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).CreateSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_CreateSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).CreateSeries(ctx, req.(*CreateSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ListSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ListSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ListSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ListSeries(ctx, req.(*ListSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_GetSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).GetSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_GetSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).GetSeries(ctx, req.(*GetSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_AddToSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).AddToSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_AddToSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).AddToSeries(ctx, req.(*AddToSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_RemoveFromSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).RemoveFromSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_RemoveFromSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).RemoveFromSeries(ctx, req.(*RemoveFromSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_ReorderSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).ReorderSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_ReorderSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).ReorderSeries(ctx, req.(*ReorderSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blog_DeleteSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServer).DeleteSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blog_DeleteSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServer).DeleteSeries(ctx, req.(*DeleteSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Blog_ServiceDesc is the grpc.ServiceDesc for Blog service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Blog_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc_tutorial.Blog",
+	HandlerType: (*BlogServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPosts",
+			Handler:    _Blog_GetPosts_Handler,
+		},
+		{
+			MethodName: "CreatePost",
+			Handler:    _Blog_CreatePost_Handler,
+		},
+		{
+			MethodName: "UpdatePost",
+			Handler:    _Blog_UpdatePost_Handler,
+		},
+		{
+			MethodName: "DeletePost",
+			Handler:    _Blog_DeletePost_Handler,
+		},
+		{
+			MethodName: "SyncChanges",
+			Handler:    _Blog_SyncChanges_Handler,
+		},
+		{
+			MethodName: "GetAttachmentURL",
+			Handler:    _Blog_GetAttachmentURL_Handler,
+		},
+		{
+			MethodName: "RenderPost",
+			Handler:    _Blog_RenderPost_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _Blog_ListRevisions_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _Blog_RestoreRevision_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _Blog_QueryAuditLog_Handler,
+		},
+		{
+			MethodName: "RegisterWebhook",
+			Handler:    _Blog_RegisterWebhook_Handler,
+		},
+		{
+			MethodName: "UnregisterWebhook",
+			Handler:    _Blog_UnregisterWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _Blog_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "SubscribeByEmail",
+			Handler:    _Blog_SubscribeByEmail_Handler,
+		},
+		{
+			MethodName: "UnsubscribeByEmail",
+			Handler:    _Blog_UnsubscribeByEmail_Handler,
+		},
+		{
+			MethodName: "GetPublishingSchedule",
+			Handler:    _Blog_GetPublishingSchedule_Handler,
+		},
+		{
+			MethodName: "GetTrendingPosts",
+			Handler:    _Blog_GetTrendingPosts_Handler,
+		},
+		{
+			MethodName: "GetPostAnalytics",
+			Handler:    _Blog_GetPostAnalytics_Handler,
+		},
+		{
+			MethodName: "GetDailySummary",
+			Handler:    _Blog_GetDailySummary_Handler,
+		},
+		{
+			MethodName: "GetAuthorProfile",
+			Handler:    _Blog_GetAuthorProfile_Handler,
+		},
+		{
+			MethodName: "SetAuthorBio",
+			Handler:    _Blog_SetAuthorBio_Handler,
+		},
+		{
+			MethodName: "RecordView",
+			Handler:    _Blog_RecordView_Handler,
+		},
+		{
+			MethodName: "GetRelatedPosts",
+			Handler:    _Blog_GetRelatedPosts_Handler,
+		},
+		{
+			MethodName: "GetPostBySlug",
+			Handler:    _Blog_GetPostBySlug_Handler,
+		},
+		{
+			MethodName: "GetBacklinks",
+			Handler:    _Blog_GetBacklinks_Handler,
+		},
+		{
+			MethodName: "GetSitemap",
+			Handler:    _Blog_GetSitemap_Handler,
+		},
+		{
+			MethodName: "StartSession",
+			Handler:    _Blog_StartSession_Handler,
+		},
+		{
+			MethodName: "GetChallenge",
+			Handler:    _Blog_GetChallenge_Handler,
+		},
+		{
+			MethodName: "GetReadingHistory",
+			Handler:    _Blog_GetReadingHistory_Handler,
+		},
+		{
+			MethodName: "MarkAsRead",
+			Handler:    _Blog_MarkAsRead_Handler,
+		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _Blog_ListNotifications_Handler,
+		},
+		{
+			MethodName: "MarkNotificationRead",
+			Handler:    _Blog_MarkNotificationRead_Handler,
+		},
+		{
+			MethodName: "AddComment",
+			Handler:    _Blog_AddComment_Handler,
+		},
+		{
+			MethodName: "GetComments",
+			Handler:    _Blog_GetComments_Handler,
+		},
+		{
+			MethodName: "ApproveComment",
+			Handler:    _Blog_ApproveComment_Handler,
+		},
+		{
+			MethodName: "RejectComment",
+			Handler:    _Blog_RejectComment_Handler,
+		},
+		{
+			MethodName: "ReportPost",
+			Handler:    _Blog_ReportPost_Handler,
+		},
+		{
+			MethodName: "ReportComment",
+			Handler:    _Blog_ReportComment_Handler,
+		},
+		{
+			MethodName: "ListReports",
+			Handler:    _Blog_ListReports_Handler,
+		},
+		{
+			MethodName: "ResolveReport",
+			Handler:    _Blog_ResolveReport_Handler,
+		},
+		{
+			MethodName: "DeletePosts",
+			Handler:    _Blog_DeletePosts_Handler,
+		},
+		{
+			MethodName: "ArchivePosts",
+			Handler:    _Blog_ArchivePosts_Handler,
+		},
+		{
+			MethodName: "PinPost",
+			Handler:    _Blog_PinPost_Handler,
+		},
+		{
+			MethodName: "UnpinPost",
+			Handler:    _Blog_UnpinPost_Handler,
+		},
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _Blog_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplate",
+			Handler:    _Blog_GetTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _Blog_ListTemplates_Handler,
+		},
+		{
+			MethodName: "UpdateTemplate",
+			Handler:    _Blog_UpdateTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _Blog_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "CreatePostFromTemplate",
+			Handler:    _Blog_CreatePostFromTemplate_Handler,
+		},
+		{
+			MethodName: "CreateSeries",
+			Handler:    _Blog_CreateSeries_Handler,
+		},
+		{
+			MethodName: "ListSeries",
+			Handler:    _Blog_ListSeries_Handler,
+		},
+		{
+			MethodName: "GetSeries",
+			Handler:    _Blog_GetSeries_Handler,
+		},
+		{
+			MethodName: "AddToSeries",
+			Handler:    _Blog_AddToSeries_Handler,
+		},
+		{
+			MethodName: "RemoveFromSeries",
+			Handler:    _Blog_RemoveFromSeries_Handler,
+		},
+		{
+			MethodName: "ReorderSeries",
+			Handler:    _Blog_ReorderSeries_Handler,
+		},
+		{
+			MethodName: "DeleteSeries",
+			Handler:    _Blog_DeleteSeries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _Blog_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _Blog_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchPosts",
+			Handler:       _Blog_WatchPosts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamPosts",
+			Handler:       _Blog_StreamPosts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamNotifications",
+			Handler:       _Blog_StreamNotifications_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "blog.proto",
+}
+
+const (
+	Admin_SetDebugLogging_FullMethodName    = "/grpc_tutorial.Admin/SetDebugLogging"
+	Admin_GetDebugLogging_FullMethodName    = "/grpc_tutorial.Admin/GetDebugLogging"
+	Admin_GetStorageStats_FullMethodName    = "/grpc_tutorial.Admin/GetStorageStats"
+	Admin_SetMaintenance_FullMethodName     = "/grpc_tutorial.Admin/SetMaintenance"
+	Admin_GetMaintenance_FullMethodName     = "/grpc_tutorial.Admin/GetMaintenance"
+	Admin_ReloadConfig_FullMethodName       = "/grpc_tutorial.Admin/ReloadConfig"
+	Admin_FlushStorage_FullMethodName       = "/grpc_tutorial.Admin/FlushStorage"
+	Admin_ReencryptStorage_FullMethodName   = "/grpc_tutorial.Admin/ReencryptStorage"
+	Admin_ListScheduledTasks_FullMethodName = "/grpc_tutorial.Admin/ListScheduledTasks"
+	Admin_RunScheduledTask_FullMethodName   = "/grpc_tutorial.Admin/RunScheduledTask"
+	Admin_AddBan_FullMethodName             = "/grpc_tutorial.Admin/AddBan"
+	Admin_RemoveBan_FullMethodName          = "/grpc_tutorial.Admin/RemoveBan"
+	Admin_ListBans_FullMethodName           = "/grpc_tutorial.Admin/ListBans"
+	Admin_GetConnectionStats_FullMethodName = "/grpc_tutorial.Admin/GetConnectionStats"
+	Admin_ListConnections_FullMethodName    = "/grpc_tutorial.Admin/ListConnections"
+	Admin_StreamServerStats_FullMethodName  = "/grpc_tutorial.Admin/StreamServerStats"
+	Admin_ExportEvents_FullMethodName       = "/grpc_tutorial.Admin/ExportEvents"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// A proto file can define several services, and a gRPC server can serve several of them on the same port. Admin groups the RPCs that operate the server itself rather than the blog, all of them require the admin token.
+type AdminClient interface {
+	// Turns the logging of every request and response on or off, and changes which fields are redacted from the logs.
+	SetDebugLogging(ctx context.Context, in *SetDebugLoggingRequest, opts ...grpc.CallOption) (*DebugLogging, error)
+	GetDebugLogging(ctx context.Context, in *GetDebugLoggingRequest, opts ...grpc.CallOption) (*DebugLogging, error)
+	// How big the posts are, and how much content compression saves, see internal/store/compress.go
+	GetStorageStats(ctx context.Context, in *GetStorageStatsRequest, opts ...grpc.CallOption) (*StorageStats, error)
+	// Turns maintenance mode on or off: while it is on every other call fails with Unavailable and a hint of when to retry, see maintenance.go
+	SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*Maintenance, error)
+	GetMaintenance(ctx context.Context, in *GetMaintenanceRequest, opts ...grpc.CallOption) (*Maintenance, error)
+	// Reads the -config file again and applies what changed, without a restart. SIGHUP does the same, see config.go
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ConfigReload, error)
+	// Writes the saves -write-delay holds back right away, see internal/store/batch.go
+	FlushStorage(ctx context.Context, in *FlushStorageRequest, opts ...grpc.CallOption) (*StorageFlush, error)
+	// Saves every post again, encrypted with the current key, after a key was added or before an old one is dropped. See internal/store/encrypt.go
+	ReencryptStorage(ctx context.Context, in *ReencryptStorageRequest, opts ...grpc.CallOption) (*StorageReencryption, error)
+	// The recurring tasks of the server, like the compaction of the audit log, with their schedules and their last run. See cron.go
+	ListScheduledTasks(ctx context.Context, in *ListScheduledTasksRequest, opts ...grpc.CallOption) (*ScheduledTasks, error)
+	// Runs a task right away, whatever its schedule, and answers once it is done.
+	RunScheduledTask(ctx context.Context, in *RunScheduledTaskRequest, opts ...grpc.CallOption) (*ScheduledTask, error)
+	// Banned authors and addresses can't change anything anymore, see bans.go
+	AddBan(ctx context.Context, in *AddBanRequest, opts ...grpc.CallOption) (*Ban, error)
+	RemoveBan(ctx context.Context, in *RemoveBanRequest, opts ...grpc.CallOption) (*RemoveBanResponse, error)
+	ListBans(ctx context.Context, in *ListBansRequest, opts ...grpc.CallOption) (*Bans, error)
+	// The connections open on the server, by listener and by peer, and their traffic. ListConnections details every one of them, see connections.go
+	GetConnectionStats(ctx context.Context, in *GetConnectionStatsRequest, opts ...grpc.CallOption) (*ConnectionStats, error)
+	ListConnections(ctx context.Context, in *ListConnectionsRequest, opts ...grpc.CallOption) (*Connections, error)
+	// Reports the calls per second, their latency and the memory of the server every IntervalSeconds until the caller stops, for a dashboard without Prometheus. See serverstats.go
+	StreamServerStats(ctx context.Context, in *StreamServerStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ServerStats], error)
+	// Writes the audit log or the views of a time range as a CSV or Parquet file, sent back in chunks or put in the bucket of the attachments. See export.go
+	ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) SetDebugLogging(ctx context.Context, in *SetDebugLoggingRequest, opts ...grpc.CallOption) (*DebugLogging, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DebugLogging)
+	err := c.cc.Invoke(ctx, Admin_SetDebugLogging_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetDebugLogging(ctx context.Context, in *GetDebugLoggingRequest, opts ...grpc.CallOption) (*DebugLogging, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DebugLogging)
+	err := c.cc.Invoke(ctx, Admin_GetDebugLogging_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetStorageStats(ctx context.Context, in *GetStorageStatsRequest, opts ...grpc.CallOption) (*StorageStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageStats)
+	err := c.cc.Invoke(ctx, Admin_GetStorageStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*Maintenance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Maintenance)
+	err := c.cc.Invoke(ctx, Admin_SetMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetMaintenance(ctx context.Context, in *GetMaintenanceRequest, opts ...grpc.CallOption) (*Maintenance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Maintenance)
+	err := c.cc.Invoke(ctx, Admin_GetMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ConfigReload, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigReload)
+	err := c.cc.Invoke(ctx, Admin_ReloadConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) FlushStorage(ctx context.Context, in *FlushStorageRequest, opts ...grpc.CallOption) (*StorageFlush, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageFlush)
+	err := c.cc.Invoke(ctx, Admin_FlushStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ReencryptStorage(ctx context.Context, in *ReencryptStorageRequest, opts ...grpc.CallOption) (*StorageReencryption, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageReencryption)
+	err := c.cc.Invoke(ctx, Admin_ReencryptStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListScheduledTasks(ctx context.Context, in *ListScheduledTasksRequest, opts ...grpc.CallOption) (*ScheduledTasks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTasks)
+	err := c.cc.Invoke(ctx, Admin_ListScheduledTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RunScheduledTask(ctx context.Context, in *RunScheduledTaskRequest, opts ...grpc.CallOption) (*ScheduledTask, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTask)
+	err := c.cc.Invoke(ctx, Admin_RunScheduledTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) AddBan(ctx context.Context, in *AddBanRequest, opts ...grpc.CallOption) (*Ban, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ban)
+	err := c.cc.Invoke(ctx, Admin_AddBan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RemoveBan(ctx context.Context, in *RemoveBanRequest, opts ...grpc.CallOption) (*RemoveBanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveBanResponse)
+	err := c.cc.Invoke(ctx, Admin_RemoveBan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListBans(ctx context.Context, in *ListBansRequest, opts ...grpc.CallOption) (*Bans, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bans)
+	err := c.cc.Invoke(ctx, Admin_ListBans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetConnectionStats(ctx context.Context, in *GetConnectionStatsRequest, opts ...grpc.CallOption) (*ConnectionStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConnectionStats)
+	err := c.cc.Invoke(ctx, Admin_GetConnectionStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListConnections(ctx context.Context, in *ListConnectionsRequest, opts ...grpc.CallOption) (*Connections, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Connections)
+	err := c.cc.Invoke(ctx, Admin_ListConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) StreamServerStats(ctx context.Context, in *StreamServerStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ServerStats], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[0], Admin_StreamServerStats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamServerStatsRequest, ServerStats]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_StreamServerStatsClient = grpc.ServerStreamingClient[ServerStats]
+
+func (c *adminClient) ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[1], Admin_ExportEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportEventsRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ExportEventsClient = grpc.ServerStreamingClient[ExportChunk]
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility.
+//
+// A proto file can define several services, and a gRPC server can serve several of them on the same port. Admin groups the RPCs that operate the server itself rather than the blog, all of them require the admin token.
+type AdminServer interface {
+	// Turns the logging of every request and response on or off, and changes which fields are redacted from the logs.
+	SetDebugLogging(context.Context, *SetDebugLoggingRequest) (*DebugLogging, error)
+	GetDebugLogging(context.Context, *GetDebugLoggingRequest) (*DebugLogging, error)
+	// How big the posts are, and how much content compression saves, see internal/store/compress.go
+	GetStorageStats(context.Context, *GetStorageStatsRequest) (*StorageStats, error)
+	// Turns maintenance mode on or off: while it is on every other call fails with Unavailable and a hint of when to retry, see maintenance.go
+	SetMaintenance(context.Context, *SetMaintenanceRequest) (*Maintenance, error)
+	GetMaintenance(context.Context, *GetMaintenanceRequest) (*Maintenance, error)
+	// Reads the -config file again and applies what changed, without a restart. SIGHUP does the same, see config.go
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ConfigReload, error)
+	// Writes the saves -write-delay holds back right away, see internal/store/batch.go
+	FlushStorage(context.Context, *FlushStorageRequest) (*StorageFlush, error)
+	// Saves every post again, encrypted with the current key, after a key was added or before an old one is dropped. See internal/store/encrypt.go
+	ReencryptStorage(context.Context, *ReencryptStorageRequest) (*StorageReencryption, error)
+	// The recurring tasks of the server, like the compaction of the audit log, with their schedules and their last run. See cron.go
+	ListScheduledTasks(context.Context, *ListScheduledTasksRequest) (*ScheduledTasks, error)
+	// Runs a task right away, whatever its schedule, and answers once it is done.
+	RunScheduledTask(context.Context, *RunScheduledTaskRequest) (*ScheduledTask, error)
+	// Banned authors and addresses can't change anything anymore, see bans.go
+	AddBan(context.Context, *AddBanRequest) (*Ban, error)
+	RemoveBan(context.Context, *RemoveBanRequest) (*RemoveBanResponse, error)
+	ListBans(context.Context, *ListBansRequest) (*Bans, error)
+	// The connections open on the server, by listener and by peer, and their traffic. ListConnections details every one of them, see connections.go
+	GetConnectionStats(context.Context, *GetConnectionStatsRequest) (*ConnectionStats, error)
+	ListConnections(context.Context, *ListConnectionsRequest) (*Connections, error)
+	// Reports the calls per second, their latency and the memory of the server every IntervalSeconds until the caller stops, for a dashboard without Prometheus. See serverstats.go
+	StreamServerStats(*StreamServerStatsRequest, grpc.ServerStreamingServer[ServerStats]) error
+	// Writes the audit log or the views of a time range as a CSV or Parquet file, sent back in chunks or put in the bucket of the attachments. See export.go
+	ExportEvents(*ExportEventsRequest, grpc.ServerStreamingServer[ExportChunk]) error
+	mustEmbedUnimplementedAdminServer()
 }
 
-// UnimplementedBlogServer must be embedded to have
+// UnimplementedAdminServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedBlogServer struct{}
+type UnimplementedAdminServer struct{}
 
-func (UnimplementedBlogServer) GetPosts(context.Context, *GetPostsRequest) (*Posts, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPosts not implemented")
+func (UnimplementedAdminServer) SetDebugLogging(context.Context, *SetDebugLoggingRequest) (*DebugLogging, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDebugLogging not implemented")
 }
-func (UnimplementedBlogServer) CreatePost(context.Context, *CreatePostRequest) (*Post, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreatePost not implemented")
+func (UnimplementedAdminServer) GetDebugLogging(context.Context, *GetDebugLoggingRequest) (*DebugLogging, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDebugLogging not implemented")
 }
-func (UnimplementedBlogServer) mustEmbedUnimplementedBlogServer() {}
-func (UnimplementedBlogServer) testEmbeddedByValue()              {}
+func (UnimplementedAdminServer) GetStorageStats(context.Context, *GetStorageStatsRequest) (*StorageStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageStats not implemented")
+}
+func (UnimplementedAdminServer) SetMaintenance(context.Context, *SetMaintenanceRequest) (*Maintenance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMaintenance not implemented")
+}
+func (UnimplementedAdminServer) GetMaintenance(context.Context, *GetMaintenanceRequest) (*Maintenance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaintenance not implemented")
+}
+func (UnimplementedAdminServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ConfigReload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedAdminServer) FlushStorage(context.Context, *FlushStorageRequest) (*StorageFlush, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushStorage not implemented")
+}
+func (UnimplementedAdminServer) ReencryptStorage(context.Context, *ReencryptStorageRequest) (*StorageReencryption, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReencryptStorage not implemented")
+}
+func (UnimplementedAdminServer) ListScheduledTasks(context.Context, *ListScheduledTasksRequest) (*ScheduledTasks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTasks not implemented")
+}
+func (UnimplementedAdminServer) RunScheduledTask(context.Context, *RunScheduledTaskRequest) (*ScheduledTask, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunScheduledTask not implemented")
+}
+func (UnimplementedAdminServer) AddBan(context.Context, *AddBanRequest) (*Ban, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBan not implemented")
+}
+func (UnimplementedAdminServer) RemoveBan(context.Context, *RemoveBanRequest) (*RemoveBanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBan not implemented")
+}
+func (UnimplementedAdminServer) ListBans(context.Context, *ListBansRequest) (*Bans, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBans not implemented")
+}
+func (UnimplementedAdminServer) GetConnectionStats(context.Context, *GetConnectionStatsRequest) (*ConnectionStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnectionStats not implemented")
+}
+func (UnimplementedAdminServer) ListConnections(context.Context, *ListConnectionsRequest) (*Connections, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConnections not implemented")
+}
+func (UnimplementedAdminServer) StreamServerStats(*StreamServerStatsRequest, grpc.ServerStreamingServer[ServerStats]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamServerStats not implemented")
+}
+func (UnimplementedAdminServer) ExportEvents(*ExportEventsRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportEvents not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+func (UnimplementedAdminServer) testEmbeddedByValue()               {}
 
-// UnsafeBlogServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to BlogServer will
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
 // result in compilation errors.
-type UnsafeBlogServer interface {
-	mustEmbedUnimplementedBlogServer()
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
 }
 
-func RegisterBlogServer(s grpc.ServiceRegistrar, srv BlogServer) {
-	// If the following call pancis, it indicates UnimplementedBlogServer was
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&Blog_ServiceDesc, srv)
+	s.RegisterService(&Admin_ServiceDesc, srv)
 }
 
-func _Blog_GetPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPostsRequest)
+func _Admin_SetDebugLogging_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDebugLoggingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServer).GetPosts(ctx, in)
+		return srv.(AdminServer).SetDebugLogging(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Blog_GetPosts_FullMethodName,
+		FullMethod: Admin_SetDebugLogging_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServer).GetPosts(ctx, req.(*GetPostsRequest))
+		return srv.(AdminServer).SetDebugLogging(ctx, req.(*SetDebugLoggingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Blog_CreatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreatePostRequest)
+func _Admin_GetDebugLogging_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDebugLoggingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServer).CreatePost(ctx, in)
+		return srv.(AdminServer).GetDebugLogging(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Blog_CreatePost_FullMethodName,
+		FullMethod: Admin_GetDebugLogging_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServer).CreatePost(ctx, req.(*CreatePostRequest))
+		return srv.(AdminServer).GetDebugLogging(ctx, req.(*GetDebugLoggingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// Blog_ServiceDesc is the grpc.ServiceDesc for Blog service.
+func _Admin_GetStorageStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetStorageStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetStorageStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetStorageStats(ctx, req.(*GetStorageStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetMaintenance(ctx, req.(*SetMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetMaintenance(ctx, req.(*GetMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_FlushStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushStorageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).FlushStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_FlushStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).FlushStorage(ctx, req.(*FlushStorageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ReencryptStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReencryptStorageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReencryptStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ReencryptStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReencryptStorage(ctx, req.(*ReencryptStorageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListScheduledTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListScheduledTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListScheduledTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListScheduledTasks(ctx, req.(*ListScheduledTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RunScheduledTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunScheduledTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RunScheduledTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_RunScheduledTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RunScheduledTask(ctx, req.(*RunScheduledTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_AddBan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AddBan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_AddBan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AddBan(ctx, req.(*AddBanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RemoveBan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RemoveBan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_RemoveBan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RemoveBan(ctx, req.(*RemoveBanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListBans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListBans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListBans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListBans(ctx, req.(*ListBansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetConnectionStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConnectionStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetConnectionStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetConnectionStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetConnectionStats(ctx, req.(*GetConnectionStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListConnections(ctx, req.(*ListConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_StreamServerStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamServerStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).StreamServerStats(m, &grpc.GenericServerStream[StreamServerStatsRequest, ServerStats]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_StreamServerStatsServer = grpc.ServerStreamingServer[ServerStats]
+
+func _Admin_ExportEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).ExportEvents(m, &grpc.GenericServerStream[ExportEventsRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ExportEventsServer = grpc.ServerStreamingServer[ExportChunk]
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var Blog_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "grpc_tutorial.Blog",
-	HandlerType: (*BlogServer)(nil),
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc_tutorial.Admin",
+	HandlerType: (*AdminServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetPosts",
-			Handler:    _Blog_GetPosts_Handler,
+			MethodName: "SetDebugLogging",
+			Handler:    _Admin_SetDebugLogging_Handler,
 		},
 		{
-			MethodName: "CreatePost",
-			Handler:    _Blog_CreatePost_Handler,
+			MethodName: "GetDebugLogging",
+			Handler:    _Admin_GetDebugLogging_Handler,
+		},
+		{
+			MethodName: "GetStorageStats",
+			Handler:    _Admin_GetStorageStats_Handler,
+		},
+		{
+			MethodName: "SetMaintenance",
+			Handler:    _Admin_SetMaintenance_Handler,
+		},
+		{
+			MethodName: "GetMaintenance",
+			Handler:    _Admin_GetMaintenance_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _Admin_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "FlushStorage",
+			Handler:    _Admin_FlushStorage_Handler,
+		},
+		{
+			MethodName: "ReencryptStorage",
+			Handler:    _Admin_ReencryptStorage_Handler,
+		},
+		{
+			MethodName: "ListScheduledTasks",
+			Handler:    _Admin_ListScheduledTasks_Handler,
+		},
+		{
+			MethodName: "RunScheduledTask",
+			Handler:    _Admin_RunScheduledTask_Handler,
+		},
+		{
+			MethodName: "AddBan",
+			Handler:    _Admin_AddBan_Handler,
+		},
+		{
+			MethodName: "RemoveBan",
+			Handler:    _Admin_RemoveBan_Handler,
+		},
+		{
+			MethodName: "ListBans",
+			Handler:    _Admin_ListBans_Handler,
+		},
+		{
+			MethodName: "GetConnectionStats",
+			Handler:    _Admin_GetConnectionStats_Handler,
+		},
+		{
+			MethodName: "ListConnections",
+			Handler:    _Admin_ListConnections_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamServerStats",
+			Handler:       _Admin_StreamServerStats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportEvents",
+			Handler:       _Admin_ExportEvents_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "blog.proto",
 }
@@ -0,0 +1,293 @@
+/*
+  Package blogsdk is a client for the blog server that hides the generated stubs behind plain Go methods.
+
+  The stubs of the parent package take a request message and return a response message for every RPC, which is all the server needs but a lot of ceremony for a program that just wants to post something:
+
+    res, err := pb.NewBlogClient(conn).CreatePost(ctx, &pb.CreatePostRequest{Title: title, Content: content, Author: author})
+
+  The SDK takes the arguments themselves, retries the calls that can safely be tried again, turns the pages of GetPosts and the posts of StreamPosts into Go iterators, and the events of WatchPosts into a channel (see watch.go):
+
+    client, err := blogsdk.Dial("localhost:3000")
+    if err != nil { ... }
+    defer client.Close()
+
+    post, err := client.CreatePost(ctx, "Hello", "My first post", "alice", blogsdk.Tags("intro"))
+    for post, err := range client.Posts(ctx, &pb.GetPostsRequest{Filter: &pb.PostFilter{Tags: []string{"grpc"}}}) { ... }
+    for post, err := range client.ListAll(ctx) { ... }
+    for event := range client.Watch(ctx, &pb.WatchPostsRequest{}).Events() { ... }
+
+  Everything the SDK doesn't cover is still one call away with Stub, which returns the generated client sharing the same connection.
+*/
+package blogsdk
+
+import (
+  "context"
+  "errors"
+  "io"
+  "iter"
+  "time"
+
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials"
+  "google.golang.org/grpc/credentials/insecure"
+  "google.golang.org/protobuf/proto"
+)
+
+// Client talks to one blog server. It is safe to use from several goroutines.
+type Client struct {
+  blog pb.BlogClient
+  // conn is only set when Dial opened the connection, Close leaves connections handed to New alone.
+  conn    *grpc.ClientConn
+  retries int
+}
+
+type options struct {
+  tokens   *tokenCredentials
+  signing  *signingKey
+  retries  int
+  creds    credentials.TransportCredentials
+  balancer string
+  dial     []grpc.DialOption
+}
+
+// Option changes how a Client is set up, see Dial and New.
+type Option func(*options)
+
+// WithToken sends the admin token with every call, for the RPCs only admins may call. WithAdminToken does the same for a single call, WithTokenSource takes tokens that expire.
+func WithToken(token string) Option {
+  return func(o *options) { o.tokens = fixedToken(token) }
+}
+
+// WithRetries sets how many times a call is tried again, see retry.go. 0 never retries, the default is 3.
+func WithRetries(n int) Option {
+  return func(o *options) { o.retries = max(n, 0) }
+}
+
+// WithTransportCredentials connects over TLS, or anything else gRPC offers. Without it Dial connects in plain text, like the blog server listens by default.
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+  return func(o *options) { o.creds = creds }
+}
+
+// WithDialOptions passes more options to grpc.NewClient, like interceptors or a user agent.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+  return func(o *options) { o.dial = append(o.dial, opts...) }
+}
+
+func newOptions(opts []Option) *options {
+  o := &options{retries: 3, creds: insecure.NewCredentials(), balancer: defaultBalancer}
+  for _, opt := range opts {
+    opt(o)
+  }
+
+  return o
+}
+
+// Dial connects to the servers of target, host:port or any gRPC target, see resolver.go. The connections are only opened on the first call.
+func Dial(target string, opts ...Option) (*Client, error) {
+  o := newOptions(opts)
+  dial := []grpc.DialOption{
+    grpc.WithTransportCredentials(o.creds),
+    grpc.WithResolvers(StaticResolver()),
+    grpc.WithDefaultServiceConfig(serviceConfig(o.balancer)),
+  }
+  conn, err := grpc.NewClient(target, append(dial, o.dial...)...)
+  if err != nil {
+    return nil, err
+  }
+
+  c := New(conn, opts...)
+  c.conn = conn
+
+  return c, nil
+}
+
+// New returns a client using a connection of the caller, which stays the caller's to close. The dialing options are ignored.
+func New(conn grpc.ClientConnInterface, opts ...Option) *Client {
+  o := newOptions(opts)
+  switch {
+  case o.signing != nil:
+    conn = signingConn{ClientConnInterface: conn, key: o.signing}
+  case o.tokens != nil:
+    conn = credentialsConn{ClientConnInterface: conn, tokens: o.tokens}
+  }
+
+  return &Client{blog: pb.NewBlogClient(conn), retries: o.retries}
+}
+
+// Close closes the connection opened by Dial.
+func (c *Client) Close() error {
+  if c.conn == nil {
+    return nil
+  }
+
+  return c.conn.Close()
+}
+
+// Stub returns the generated client, for the RPCs the SDK has no method for. Its calls carry the token or the signature of the client too.
+func (c *Client) Stub() pb.BlogClient {
+  return c.blog
+}
+
+// PostOption sets the optional fields of CreatePost.
+type PostOption func(*pb.CreatePostRequest)
+
+// Tags tags the post, the server lowercases the tags and drops duplicates.
+func Tags(tags ...string) PostOption {
+  return func(req *pb.CreatePostRequest) { req.Tags = append(req.Tags, tags...) }
+}
+
+// PublishAt schedules the post instead of publishing it right away.
+func PublishAt(at time.Time) PostOption {
+  return func(req *pb.CreatePostRequest) { req.PublishAt = at.UTC().Format(time.RFC3339) }
+}
+
+// AsDraft saves the post as a draft, UpdatePost with Publish publishes it.
+func AsDraft() PostOption {
+  return func(req *pb.CreatePostRequest) { req.Draft = true }
+}
+
+// AllowDuplicate skips the duplicate check of the server for this post.
+func AllowDuplicate() PostOption {
+  return func(req *pb.CreatePostRequest) { req.AllowDuplicate = true }
+}
+
+// CreatePost creates a post. It is only retried when the server turned the call away before running it, see retry.go
+func (c *Client) CreatePost(ctx context.Context, title, content, author string, opts ...PostOption) (*pb.Post, error) {
+  req := &pb.CreatePostRequest{Title: title, Content: content, Author: author}
+  for _, opt := range opts {
+    opt(req)
+  }
+
+  return call(ctx, c, false, func(ctx context.Context) (*pb.Post, error) { return c.blog.CreatePost(ctx, req) })
+}
+
+// Update lists the changes of UpdatePost, empty fields keep their current value.
+type Update struct {
+  Title   string
+  Content string
+  Author  string
+  // Replaces every tag of the post.
+  Tags []string
+  // Publishes a draft, at PublishAt when it is set.
+  Publish   bool
+  PublishAt time.Time
+}
+
+// UpdatePost changes the post, see Update. Like CreatePost it is only retried during a maintenance.
+func (c *Client) UpdatePost(ctx context.Context, id string, update Update) (*pb.Post, error) {
+  req := &pb.UpdatePostRequest{Id: id, Title: update.Title, Content: update.Content, Author: update.Author, Tags: update.Tags, Publish: update.Publish}
+  if !update.PublishAt.IsZero() {
+    req.PublishAt = update.PublishAt.UTC().Format(time.RFC3339)
+  }
+
+  return call(ctx, c, false, func(ctx context.Context) (*pb.Post, error) { return c.blog.UpdatePost(ctx, req) })
+}
+
+// DeletePost deletes the post. It is not retried either: a deletion that went through before the connection dropped would fail the second time with NotFound.
+func (c *Client) DeletePost(ctx context.Context, id string) error {
+  _, err := call(ctx, c, false, func(ctx context.Context) (*pb.DeletePostResponse, error) {
+    return c.blog.DeletePost(ctx, &pb.DeletePostRequest{Id: id})
+  })
+
+  return err
+}
+
+// GetPostBySlug finds a published post by the slug of its pretty URL.
+func (c *Client) GetPostBySlug(ctx context.Context, slug string) (*pb.Post, error) {
+  return call(ctx, c, true, func(ctx context.Context) (*pb.Post, error) {
+    return c.blog.GetPostBySlug(ctx, &pb.GetPostBySlugRequest{Slug: slug})
+  })
+}
+
+// DefaultPageSize is the PageSize Posts asks for when the request has none.
+const DefaultPageSize = 50
+
+// ListPosts returns the published posts matching the filter in one response, nil returns all of them. Posts reads them a page at a time.
+func (c *Client) ListPosts(ctx context.Context, filter *pb.PostFilter) ([]*pb.Post, error) {
+  posts, err := call(ctx, c, true, func(ctx context.Context) (*pb.Posts, error) {
+    return c.blog.GetPosts(ctx, &pb.GetPostsRequest{Filter: filter})
+  })
+
+  return posts.GetPosts(), err
+}
+
+/*
+  Posts returns the posts of GetPosts for the request, a page at a time, following the NextPageToken of every page so the loop never sees one:
+
+    req := &pb.GetPostsRequest{OrderBy: pb.PostOrder_ORDER_READING_TIME, PageSize: 20}
+    for post, err := range client.Posts(ctx, req) {
+      if err != nil { ... }
+      fmt.Println(post.GetTitle())
+    }
+
+  Unlike ListPosts only one page is in memory at a time, of DefaultPageSize posts when the request doesn't give a PageSize. Every page is retried on its own, a failure halfway goes on from the page that failed. The request is left as it was, and a PageToken in it starts from that page. The iterator stops after an error, which it yields once, or when the loop breaks.
+*/
+func (c *Client) Posts(ctx context.Context, req *pb.GetPostsRequest) iter.Seq2[*pb.Post, error] {
+  return func(yield func(*pb.Post, error) bool) {
+    req := proto.Clone(req).(*pb.GetPostsRequest)
+    if req.PageSize == 0 {
+      req.PageSize = DefaultPageSize
+    }
+
+    for {
+      page, err := call(ctx, c, true, func(ctx context.Context) (*pb.Posts, error) { return c.blog.GetPosts(ctx, req) })
+      if err != nil {
+        yield(nil, err)
+        return
+      }
+      for _, post := range page.GetPosts() {
+        if !yield(post, nil) {
+          return
+        }
+      }
+      if page.GetNextPageToken() == "" {
+        return
+      }
+      req.PageToken = page.GetNextPageToken()
+    }
+  }
+}
+
+/*
+  ListAll returns every published post, one at a time, from StreamPosts:
+
+    for post, err := range client.ListAll(ctx) {
+      if err != nil { ... }
+      fmt.Println(post.GetTitle())
+    }
+
+  The posts are never all in memory at once. When the stream drops, the iterator opens a new one from the cursor of the last post it returned, so no post is skipped or repeated. It stops after an error, which it yields once, or when the loop breaks.
+*/
+func (c *Client) ListAll(ctx context.Context) iter.Seq2[*pb.Post, error] {
+  return func(yield func(*pb.Post, error) bool) {
+    var cursor string
+    // Every post received starts the count again, only a stream failing over and over gives up.
+    failures := 0
+
+    for {
+      stream, err := c.blog.StreamPosts(ctx, &pb.StreamPostsRequest{Cursor: cursor})
+      for err == nil {
+        var res *pb.StreamPostsResponse
+        if res, err = stream.Recv(); err != nil {
+          break
+        }
+        failures = 0
+        cursor = res.GetCursor()
+        if !yield(res.GetPost(), nil) {
+          return
+        }
+      }
+      if errors.Is(err, io.EOF) {
+        return
+      }
+
+      delay, ok := retryDelay(err, true, failures)
+      if !ok || failures >= c.retries || !wait(ctx, delay) {
+        yield(nil, err)
+        return
+      }
+      failures++
+    }
+  }
+}
@@ -0,0 +1,29 @@
+package blogsdk
+
+import (
+  "context"
+
+  "google.golang.org/grpc/metadata"
+)
+
+// The metadata keys the server reads, see auth.go and internal/reqctx in the server.
+const (
+  authorizationKey = "authorization"
+  languageKey      = "accept-language"
+  requestIDKey     = "x-request-id"
+)
+
+// WithAdminToken returns a context whose calls carry the admin token, instead of the token of the client if it has one.
+func WithAdminToken(ctx context.Context, token string) context.Context {
+  return metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+token)
+}
+
+// WithLanguage asks the server to answer in the language, like "fr" or "es-MX,es;q=0.9". The messages of the errors are translated, and RenderPost writes dates the way the language does.
+func WithLanguage(ctx context.Context, language string) context.Context {
+  return metadata.AppendToOutgoingContext(ctx, languageKey, language)
+}
+
+// WithRequestID sends the ID the server logs the calls of the context with, instead of one it makes up. Giving every call of a bigger operation the same ID ties them together in the logs.
+func WithRequestID(ctx context.Context, id string) context.Context {
+  return metadata.AppendToOutgoingContext(ctx, requestIDKey, id)
+}
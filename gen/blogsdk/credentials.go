@@ -0,0 +1,149 @@
+package blogsdk
+
+import (
+  "context"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/credentials"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  CREDENTIALS
+
+  The admin RPCs want a token in the authorization metadata of every call. WithToken gives the client a token that never changes, the admin token the server was started with. Tokens handed out by something else, a login service or a secret manager rotating them, expire and have to be asked for again, so WithTokenSource takes a function the client calls whenever it needs one:
+
+    client, err := blogsdk.Dial(addr, blogsdk.WithTokenSource(func(ctx context.Context) (blogsdk.Token, error) {
+      token, expiry, err := vault.BlogToken(ctx)
+      return blogsdk.Token{Value: token, Expiry: expiry}, err
+    }))
+
+  ClientCredentials is such a source for the OpenID Connect providers, see oidc.go
+
+  The token is kept until it is about to expire, refreshBefore ahead of its Expiry so calls on their way don't arrive with a stale one. Calls waiting for a refresh share it, the source isn't called once per call. A call turned away with Unauthenticated means the token was revoked before it expired: the client drops it, asks the source for a new one and tries the call once more. Streams can't be tried again that way, they fail, and the next one gets the new token.
+
+  Both are gRPC PerRPCCredentials, which gRPC asks for the metadata of every call, streams included. They are added to every call of the connection, those of Stub too. TokenCredentials returns the same credentials for a connection dialed without the SDK:
+
+    conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithPerRPCCredentials(blogsdk.TokenCredentials(source)))
+
+  A token given for a single call with WithAdminToken wins over the one of the client.
+*/
+const refreshBefore = 30 * time.Second
+
+// Token is a credential together with when it stops being valid.
+type Token struct {
+  // Sent as "Bearer <Value>".
+  Value string
+  // Zero never expires.
+  Expiry time.Time
+}
+
+// TokenSource returns a new token, it is called when the client has none or the one it has is about to expire.
+type TokenSource func(ctx context.Context) (Token, error)
+
+// WithTokenSource authenticates every call with the tokens of the source, see above.
+func WithTokenSource(source TokenSource) Option {
+  return func(o *options) { o.tokens = newTokenCredentials(source, true) }
+}
+
+// TokenCredentials returns the credentials of WithTokenSource, for connections that aren't made by the SDK.
+func TokenCredentials(source TokenSource) credentials.PerRPCCredentials {
+  return newTokenCredentials(source, true)
+}
+
+type tokenCredentials struct {
+  source TokenSource
+  // False for the fixed token of WithToken, asking it again gives the same one.
+  refreshes bool
+
+  mu    sync.Mutex
+  token Token
+  ok    bool
+}
+
+func newTokenCredentials(source TokenSource, refreshes bool) *tokenCredentials {
+  return &tokenCredentials{source: source, refreshes: refreshes}
+}
+
+func fixedToken(token string) *tokenCredentials {
+  return newTokenCredentials(func(context.Context) (Token, error) { return Token{Value: token}, nil }, false)
+}
+
+func (t *tokenCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+  if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(authorizationKey)) > 0 {
+    return nil, nil
+  }
+
+  token, err := t.current(ctx)
+  if err != nil {
+    return nil, status.Errorf(codes.Unauthenticated, "blogsdk: could not get a token: %v", err)
+  }
+
+  return map[string]string{authorizationKey: "Bearer " + token.Value}, nil
+}
+
+// RequireTransportSecurity is false since the blog server listens in plain text by default. Over the network, give the client TLS with WithTransportCredentials so the tokens aren't sent in the clear.
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+  return false
+}
+
+// current returns the token, asking the source for a new one when it is about to expire.
+func (t *tokenCredentials) current(ctx context.Context) (Token, error) {
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  if t.ok && (t.token.Expiry.IsZero() || time.Until(t.token.Expiry) > refreshBefore) {
+    return t.token, nil
+  }
+
+  token, err := t.source(ctx)
+  if err != nil {
+    // A token that hasn't expired yet is still better than none, the next call asks again.
+    if t.ok && time.Now().Before(t.token.Expiry) {
+      return t.token, nil
+    }
+    return Token{}, err
+  }
+  t.token, t.ok = token, true
+
+  return token, nil
+}
+
+// invalidate drops the token after the server refused it, it reports whether a new one can be asked for.
+func (t *tokenCredentials) invalidate() bool {
+  if !t.refreshes {
+    return false
+  }
+
+  t.mu.Lock()
+  defer t.mu.Unlock()
+  t.ok = false
+
+  return true
+}
+
+// credentialsConn adds the credentials of the client to every call made on the connection.
+type credentialsConn struct {
+  grpc.ClientConnInterface
+  tokens *tokenCredentials
+}
+
+func (c credentialsConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+  opts = append(opts, grpc.PerRPCCredentials(c.tokens))
+  err := c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+  // The server refuses a token before doing anything, so any call can be tried again with a new one.
+  if status.Code(err) == codes.Unauthenticated && c.tokens.invalidate() {
+    err = c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+  }
+
+  return err
+}
+
+// NewStream can't try a stream again, a refused token only shows up once the stream is running. The next stream gets a new token.
+func (c credentialsConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+  return c.ClientConnInterface.NewStream(ctx, desc, method, append(opts, grpc.PerRPCCredentials(c.tokens))...)
+}
@@ -0,0 +1,149 @@
+package blogsdk
+
+import (
+  "context"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "net/http"
+  "net/url"
+  "strings"
+  "sync"
+  "time"
+)
+
+/*
+  CLIENT CREDENTIALS
+
+  A server started with -oidc-issuer accepts the access tokens of an OpenID Connect provider (see oidc.go in the server). Programs get them with the OAuth2 client credentials flow: they POST their client ID and secret to the token endpoint of the provider and get back a token and how long it lasts. ClientCredentials does that whenever the client needs a new token:
+
+    client, err := blogsdk.Dial(addr, blogsdk.WithTokenSource(blogsdk.ClientCredentials(blogsdk.ClientCredentialsConfig{
+      Issuer:       "https://auth.example.com/realms/blog",
+      ClientID:     "publisher",
+      ClientSecret: os.Getenv("BLOG_CLIENT_SECRET"),
+      Scopes:       []string{"blog.admin"},
+    })))
+
+  The token endpoint is read from the discovery document of the issuer, <issuer>/.well-known/openid-configuration, the first time a token is needed. TokenURL skips the discovery, for providers that don't publish one.
+*/
+
+// ClientCredentialsConfig tells ClientCredentials where and how to ask for tokens.
+type ClientCredentialsConfig struct {
+  // Issuer is the address of the provider, the -oidc-issuer of the server.
+  Issuer string
+  // TokenURL is the token endpoint, found from Issuer when empty.
+  TokenURL     string
+  ClientID     string
+  ClientSecret string
+  Scopes       []string
+  // Audience is sent to the providers that want to know who the token is for, like Auth0, the -oidc-audience of the server.
+  Audience string
+  // HTTPClient makes the requests to the provider, a client with a 10 second timeout when nil.
+  HTTPClient *http.Client
+}
+
+// ClientCredentials returns a TokenSource asking the provider for tokens with the client credentials flow, see above.
+func ClientCredentials(config ClientCredentialsConfig) TokenSource {
+  if config.HTTPClient == nil {
+    config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+  }
+
+  var mu sync.Mutex
+  tokenURL := config.TokenURL
+
+  return func(ctx context.Context) (Token, error) {
+    mu.Lock()
+    defer mu.Unlock()
+
+    if tokenURL == "" {
+      var err error
+      if tokenURL, err = discoverTokenURL(ctx, config); err != nil {
+        return Token{}, err
+      }
+    }
+
+    return requestToken(ctx, config, tokenURL)
+  }
+}
+
+func discoverTokenURL(ctx context.Context, config ClientCredentialsConfig) (string, error) {
+  if config.Issuer == "" {
+    return "", errors.New("oidc: the config needs an Issuer or a TokenURL")
+  }
+
+  req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(config.Issuer, "/")+"/.well-known/openid-configuration", nil)
+  if err != nil {
+    return "", err
+  }
+  res, err := config.HTTPClient.Do(req)
+  if err != nil {
+    return "", fmt.Errorf("oidc: failed to read the discovery document: %w", err)
+  }
+  defer res.Body.Close()
+
+  if res.StatusCode != http.StatusOK {
+    return "", fmt.Errorf("oidc: the discovery document answered %s", res.Status)
+  }
+  var discovery struct {
+    TokenEndpoint string `json:"token_endpoint"`
+  }
+  if err := json.NewDecoder(res.Body).Decode(&discovery); err != nil {
+    return "", fmt.Errorf("oidc: invalid discovery document: %w", err)
+  }
+  if discovery.TokenEndpoint == "" {
+    return "", errors.New("oidc: the discovery document has no token_endpoint")
+  }
+
+  return discovery.TokenEndpoint, nil
+}
+
+func requestToken(ctx context.Context, config ClientCredentialsConfig, tokenURL string) (Token, error) {
+  form := url.Values{"grant_type": {"client_credentials"}}
+  if len(config.Scopes) > 0 {
+    form.Set("scope", strings.Join(config.Scopes, " "))
+  }
+  if config.Audience != "" {
+    form.Set("audience", config.Audience)
+  }
+
+  req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+  if err != nil {
+    return Token{}, err
+  }
+  req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+  req.Header.Set("Accept", "application/json")
+  // RFC 6749 wants the ID and secret form encoded before they go into the basic authentication.
+  req.SetBasicAuth(url.QueryEscape(config.ClientID), url.QueryEscape(config.ClientSecret))
+
+  requested := time.Now()
+  res, err := config.HTTPClient.Do(req)
+  if err != nil {
+    return Token{}, fmt.Errorf("oidc: failed to get a token: %w", err)
+  }
+  defer res.Body.Close()
+
+  var body struct {
+    AccessToken string `json:"access_token"`
+    ExpiresIn   int64  `json:"expires_in"`
+    // Set instead when the provider turns the request down.
+    Error            string `json:"error"`
+    ErrorDescription string `json:"error_description"`
+  }
+  if err := json.NewDecoder(res.Body).Decode(&body); err != nil && res.StatusCode == http.StatusOK {
+    return Token{}, fmt.Errorf("oidc: invalid token response: %w", err)
+  }
+  if res.StatusCode != http.StatusOK || body.Error != "" {
+    return Token{}, fmt.Errorf("oidc: the provider refused the client credentials (%s): %s %s", res.Status, body.Error, body.ErrorDescription)
+  }
+  if body.AccessToken == "" {
+    return Token{}, errors.New("oidc: the token response has no access_token")
+  }
+
+  token := Token{Value: body.AccessToken}
+  // Counted from when the request left, the token may have spent a while on its way back.
+  if body.ExpiresIn > 0 {
+    token.Expiry = requested.Add(time.Duration(body.ExpiresIn) * time.Second)
+  }
+
+  return token, nil
+}
@@ -0,0 +1,79 @@
+package blogsdk
+
+import (
+  "fmt"
+  "strings"
+
+  "google.golang.org/grpc/resolver"
+)
+
+/*
+  SEVERAL SERVERS
+
+  Dial takes a gRPC target, not just host:port, and spreads the calls over every address the target resolves to, round robin:
+
+    blogsdk.Dial("dns:///blog.internal:3000")
+    blogsdk.Dial(blogsdk.StaticTarget("10.0.0.1:3000", "10.0.0.2:3000"))
+
+  dns:/// looks the name up and connects to every address it gets, which is what a headless Kubernetes service answers with, one address per pod. A plain host:port is looked up the same way. Behind a ClusterIP service the name is a single address and Kubernetes does the balancing, per connection, so a client with one long lived connection would send everything to the same pod.
+
+  gRPC looks the name up again when a connection to one of the servers goes away, not on a timer, so pods added later only get calls once something reconnects. -max-connection-age on the server closes the connections after a while, gracefully, which keeps the clients finding the new pods.
+
+  static:/// lists the servers themselves, separated by commas, for a fixed set of servers in a config file. StaticTarget builds it from the list.
+
+  WithBalancer picks another policy, pick_first sends every call to the first server that answers and only moves on when it goes away.
+*/
+const (
+  StaticScheme    = "static"
+  defaultBalancer = "round_robin"
+)
+
+// WithBalancer sets the load balancing policy of Dial, round_robin by default, see above.
+func WithBalancer(policy string) Option {
+  return func(o *options) { o.balancer = policy }
+}
+
+// StaticTarget returns the target of Dial connecting to addrs, see above.
+func StaticTarget(addrs ...string) string {
+  return StaticScheme + ":///" + strings.Join(addrs, ",")
+}
+
+// StaticResolver resolves the static:/// targets. Dial knows them already, grpc.WithResolvers(StaticResolver()) teaches them to a connection of your own.
+func StaticResolver() resolver.Builder {
+  return staticBuilder{}
+}
+
+// serviceConfig is the default service config of Dial, setting the balancer.
+func serviceConfig(policy string) string {
+  return `{"loadBalancingConfig": [{"` + policy + `": {}}]}`
+}
+
+type staticBuilder struct{}
+
+func (staticBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+  var state resolver.State
+  for _, addr := range strings.Split(target.Endpoint(), ",") {
+    if addr = strings.TrimSpace(addr); addr != "" {
+      state.Addresses = append(state.Addresses, resolver.Address{Addr: addr})
+    }
+  }
+  if len(state.Addresses) == 0 {
+    return nil, fmt.Errorf("%s:/// lists no address", StaticScheme)
+  }
+  if err := cc.UpdateState(state); err != nil {
+    return nil, err
+  }
+
+  return staticResolver{}, nil
+}
+
+func (staticBuilder) Scheme() string {
+  return StaticScheme
+}
+
+// staticResolver has nothing to look up again, the addresses never change.
+type staticResolver struct{}
+
+func (staticResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (staticResolver) Close() {}
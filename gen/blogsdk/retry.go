@@ -0,0 +1,87 @@
+package blogsdk
+
+import (
+  "context"
+  "time"
+
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  RETRIES
+
+  A call failing with Unavailable may work if it is tried again a little later: the server was restarting, the connection dropped, the storage was busy. Whether trying again is safe depends on the call though. A read can be repeated at will, but a CreatePost that timed out on the way back may have created the post already, and sending it again would create a second one.
+
+  So the SDK tells two cases apart:
+    - the server is in maintenance: it says so with an ErrorInfo whose reason is MAINTENANCE, and how long to wait with a RetryInfo. The call was turned away before reaching its handler, so every call is retried, after the delay the server asked for.
+    - any other Unavailable: only the reads are retried, along with the streams of ListAll, which resume from their cursor. The delay doubles with every attempt, from retryBaseDelay up to retryMaxDelay.
+
+  A call is tried at most WithRetries times more, and never past the deadline of its context: a call that has one second left doesn't wait two.
+*/
+const (
+  retryBaseDelay = 100 * time.Millisecond
+  retryMaxDelay  = 2 * time.Second
+)
+
+// call runs do, trying again as described above.
+func call[T any](ctx context.Context, c *Client, idempotent bool, do func(context.Context) (T, error)) (T, error) {
+  for attempt := 0; ; attempt++ {
+    res, err := do(ctx)
+    if err == nil {
+      return res, nil
+    }
+
+    delay, ok := retryDelay(err, idempotent, attempt)
+    if !ok || attempt >= c.retries {
+      return res, err
+    }
+    if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+      return res, err
+    }
+    if !wait(ctx, delay) {
+      return res, err
+    }
+  }
+}
+
+// retryDelay tells whether the call failing with err can be tried again, and after how long.
+func retryDelay(err error, idempotent bool, attempt int) (time.Duration, bool) {
+  st := status.Convert(err)
+  if st.Code() != codes.Unavailable {
+    return 0, false
+  }
+
+  var maintenance bool
+  var delay time.Duration
+  for _, detail := range st.Details() {
+    switch d := detail.(type) {
+    case *errdetails.ErrorInfo:
+      maintenance = d.GetReason() == "MAINTENANCE"
+    case *errdetails.RetryInfo:
+      delay = d.GetRetryDelay().AsDuration()
+    }
+  }
+  if maintenance {
+    return delay, true
+  }
+  if !idempotent {
+    return 0, false
+  }
+
+  return min(retryBaseDelay<<attempt, retryMaxDelay), true
+}
+
+// wait waits for the delay, false when the context is done first.
+func wait(ctx context.Context, delay time.Duration) bool {
+  timer := time.NewTimer(delay)
+  defer timer.Stop()
+
+  select {
+  case <-ctx.Done():
+    return false
+  case <-timer.C:
+    return true
+  }
+}
@@ -0,0 +1,109 @@
+package blogsdk
+
+import (
+  "context"
+  "errors"
+  "io"
+  "strconv"
+
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  WATCHING POSTS
+
+  WatchPosts is a stream that stays open for as long as the client wants to hear about changes, which means it breaks sooner or later: the server restarts, goes into maintenance, the connection drops. Watch hides the stream behind a channel that outlives all of them:
+
+    watch := client.Watch(ctx, &pb.WatchPostsRequest{Types: []pb.PostEventType{pb.PostEventType_POST_PUBLISHED}})
+    for event := range watch.Events() {
+      fmt.Println(event.GetPost().GetTitle())
+    }
+    if err := watch.Err(); err != nil { ... }
+
+  When the stream breaks the watch opens a new one with the Cursor of the last event, or the one the server started the stream at, so the server replays what changed in between (see watch.go on the server) and no change is missed. The streams are retried like ListAll's, after the delay of a maintenance or with a backoff, and every stream the server accepts starts the count again: a watch can live through any number of breaks, as long as it reconnects.
+
+  The channel is closed when the context is done or the server ends the watch, after which Err returns nil, or when the watch gives up, after which Err returns why.
+*/
+
+// watchCursorHeader is the header the server starts every watch with, holding the cursor of the last change.
+const watchCursorHeader = "x-watch-cursor"
+
+// Watch is a watch started by Client.Watch.
+type Watch struct {
+  events chan *pb.PostEvent
+  // Only written before events is closed.
+  err error
+}
+
+// Events returns the channel the events come in, in the order of the changes.
+func (w *Watch) Events() <-chan *pb.PostEvent {
+  return w.events
+}
+
+// Err returns why the channel of Events was closed, nil when the context was done or the server ended the watch. It is only meaningful once the channel is closed.
+func (w *Watch) Err() error {
+  return w.err
+}
+
+// Watch watches the posts matching the request until the context is done, see above. A Cursor in the request replays the changes made after it first.
+func (c *Client) Watch(ctx context.Context, req *pb.WatchPostsRequest) *Watch {
+  w := &Watch{events: make(chan *pb.PostEvent)}
+  req = proto.Clone(req).(*pb.WatchPostsRequest)
+
+  go func() {
+    defer close(w.events)
+    failures := 0
+
+    for {
+      err := c.watchStream(ctx, req, w.events, &failures)
+      if ctx.Err() != nil || errors.Is(err, io.EOF) {
+        return
+      }
+
+      delay, ok := retryDelay(err, true, failures)
+      if !ok || failures >= c.retries || !wait(ctx, delay) {
+        if ctx.Err() == nil {
+          w.err = err
+        }
+        return
+      }
+      failures++
+    }
+  }()
+
+  return w
+}
+
+// watchStream sends the events of one stream until it breaks, moving the cursor of the request along for the next one.
+func (c *Client) watchStream(ctx context.Context, req *pb.WatchPostsRequest, events chan<- *pb.PostEvent, failures *int) error {
+  ctx, cancel := context.WithCancel(ctx)
+  // Leaving before the stream is over has to cancel it, or it would stay open.
+  defer cancel()
+
+  stream, err := c.blog.WatchPosts(ctx, req)
+  if err != nil {
+    return err
+  }
+  // The header is sent once the server accepted the watch, before the first event. An error here is the stream failing and Recv returns it too.
+  if header, err := stream.Header(); err == nil && len(header.Get(watchCursorHeader)) > 0 {
+    *failures = 0
+    if cursor, err := strconv.ParseInt(header.Get(watchCursorHeader)[0], 10, 64); err == nil {
+      req.Cursor = max(req.Cursor, cursor)
+    }
+  }
+
+  for {
+    event, err := stream.Recv()
+    if err != nil {
+      return err
+    }
+
+    req.Cursor = max(req.Cursor, event.GetCursor())
+    select {
+    case events <- event:
+    case <-ctx.Done():
+      return ctx.Err()
+    }
+  }
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+  "net/http"
+  "strings"
+
+  "golang.org/x/net/http2"
+  "golang.org/x/net/http2/h2c"
+  "google.golang.org/grpc"
+  healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+/*
+  ONE PORT, TWO PROTOCOLS
+
+  With -http the server speaks both gRPC and plain HTTP on the -addr port, so tools that only know HTTP (curl, Kubernetes HTTP probes, Prometheus) can reach the health and metrics endpoints without a second port:
+
+    curl localhost:3000/healthz
+    curl localhost:3000/metrics
+    curl localhost:3000/sitemap.xml
+
+  /sitemap.xml is only there with -site-url, see sitemap.go
+
+  gRPC is just HTTP/2 with a content type of application/grpc, so telling the two apart is a matter of looking at every request. Our clients don't use TLS, which means they speak HTTP/2 "in the clear" (h2c); the h2c handler from golang.org/x/net upgrades those connections, while curl's HTTP/1.1 requests go through untouched.
+
+  Keep in mind grpc.Server.ServeHTTP is marked experimental and is noticeably slower than Serve, since it runs on the standard library HTTP/2 implementation instead of gRPC's own. It also can't drain connections gracefully, so on shutdown the in-flight RPCs are cancelled instead of waited for. That's why -http is off by default.
+*/
+func newHTTPHandler(grpcServer *grpc.Server, health healthpb.HealthServer, metrics *serverMetrics, sitemap *sitemap) http.Handler {
+  mux := http.NewServeMux()
+  mux.Handle("/metrics", metrics)
+  if sitemap != nil {
+    mux.Handle("/sitemap.xml", sitemap)
+  }
+  mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+    // The empty service name asks about the server as a whole, the same question grpc-health-probe asks.
+    res, err := health.Check(r.Context(), &healthpb.HealthCheckRequest{})
+    if err != nil || res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+      http.Error(w, "NOT_SERVING", http.StatusServiceUnavailable)
+      return
+    }
+
+    w.Write([]byte("SERVING\n"))
+  })
+
+  return h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+      grpcServer.ServeHTTP(w, r)
+      return
+    }
+
+    mux.ServeHTTP(w, r)
+  }), &http2.Server{})
+}
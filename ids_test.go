@@ -0,0 +1,97 @@
+package main
+
+import (
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/clock"
+  "go/tutorial/grpc/internal/store"
+  "strconv"
+  "testing"
+  "time"
+)
+
+func TestULIDsSortInTheOrderTheyWereMade(t *testing.T) {
+  fake := clock.NewFake(time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC))
+  ids := &store.ULIDs{Now: fake.Now}
+
+  previous := ""
+  for i := range 1000 {
+    // Many IDs in the same millisecond, then the next one.
+    if i%100 == 0 {
+      fake.Advance(time.Millisecond)
+    }
+    id := ids.NewID()
+    if len(id) != 26 {
+      t.Fatalf("%q has %d characters, want 26", id, len(id))
+    }
+    if id <= previous {
+      t.Fatalf("%q came after %q", id, previous)
+    }
+    previous = id
+  }
+
+  // The clock ended at 2025-06-04T09:00:00.010Z, 1749027600010 milliseconds or 01JWX2PDMA in base32.
+  if got := previous[:10]; got != "01JWX2PDMA" {
+    t.Errorf("the time of %q is %s, want 01JWX2PDMA", previous, got)
+  }
+}
+
+func TestLookupBySortedIDs(t *testing.T) {
+  ids := &store.ULIDs{}
+  posts := make([]*pb.Post, 20)
+  for i := range posts {
+    posts[i] = &pb.Post{Id: ids.NewID(), Title: "Post " + strconv.Itoa(i), Author: "Ana"}
+  }
+
+  for _, test := range []struct {
+    name  string
+    posts []*pb.Post
+  }{
+    {"ulids", posts},
+    // A post saved before the switch to ULIDs, the index isn't sorted anymore.
+    {"mixed", append([]*pb.Post{{Id: store.UUIDs{}.NewID(), Title: "Older", Author: "Ana"}}, posts...)},
+  } {
+    t.Run(test.name, func(t *testing.T) {
+      s := &store.FileStore{Path: tempPath(t, "posts.json")}
+      if err := s.Save(clonedPosts(test.posts)); err != nil {
+        t.Fatal(err)
+      }
+
+      // In the order of the file whatever the order of the query, once, and only the ones that exist.
+      r, err := store.Lookup(s, store.Query{IDs: []string{posts[15].Id, "missing", posts[2].Id, posts[15].Id, posts[9].Id}})
+      if err != nil {
+        t.Fatal(err)
+      }
+      found, err := store.ReadAll(r)
+      r.Close()
+      if err != nil {
+        t.Fatal(err)
+      }
+      if diff := samePosts(found, []*pb.Post{posts[2], posts[9], posts[15]}); diff != "" {
+        t.Errorf("Lookup returned %s", diff)
+      }
+    })
+  }
+}
+
+func TestSequenceGoesOnAfterARestart(t *testing.T) {
+  path := tempPath(t, "ids.seq")
+
+  first, err := store.OpenSequence(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+  for i := 1; i <= 3; i++ {
+    if id := first.NewID(); id != strconv.Itoa(i) {
+      t.Fatalf("ID %d is %q", i, id)
+    }
+  }
+
+  // The IDs left in the block of the first server are skipped rather than given twice.
+  second, err := store.OpenSequence(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if id, _ := strconv.Atoi(second.NewID()); id <= 3 {
+    t.Errorf("the restarted sequence gave %d again", id)
+  }
+}
@@ -0,0 +1,461 @@
+//go:build integration
+
+package main
+
+import (
+  "bytes"
+  "context"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen/blogsdk"
+  "go/tutorial/grpc/internal/store"
+  "io"
+  "os"
+  "os/exec"
+  "path/filepath"
+  "slices"
+  "syscall"
+  "testing"
+  "time"
+
+  "github.com/ory/dockertest/v3"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/credentials/insecure"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  INTEGRATION TESTS
+
+  The other tests call the handlers in the test binary, with the posts in memory. These run the server the way it is deployed, a binary built from the tree, against real backends, and make the calls a client makes: creating, updating, viewing, pinning, syncing, streaming and deleting posts, then restarting the server to check nothing was lost. They take a minute and need Docker, so they only build with the integration tag:
+
+    go test -tags integration -run Integration -v
+
+  Every test starts the containers it needs with ory/dockertest, which talks to the Docker daemon of DOCKER_HOST or the local one, and purges them when it is done: a Redis for the cache and the view counters of redis.go, a Postgres for -storage postgres. SQLite and posts.json need no container, they are files of the working directory of the server. BLOG_TEST_REDIS_ADDR and BLOG_TEST_POSTGRES_URL run the tests against servers that are already running, service containers of the CI for example, instead of starting them. The Postgres one is emptied by the tests.
+*/
+const integrationAdminToken = "integration-secret"
+
+// serverBinary is the server built by TestMain for the integration tests.
+var serverBinary string
+
+func TestMain(m *testing.M) {
+  dir, err := os.MkdirTemp("", "blog-integration")
+  if err != nil {
+    fmt.Fprintln(os.Stderr, err)
+    os.Exit(1)
+  }
+  serverBinary = filepath.Join(dir, "server")
+  build := exec.Command("go", "build", "-o", serverBinary, ".")
+  build.Stdout, build.Stderr = os.Stderr, os.Stderr
+  if err := build.Run(); err != nil {
+    fmt.Fprintln(os.Stderr, "failed to build the server:", err)
+    os.RemoveAll(dir)
+    os.Exit(1)
+  }
+
+  code := m.Run()
+  os.RemoveAll(dir)
+  os.Exit(code)
+}
+
+// startContainer runs a container of repository:tag with env, purged when the test is done, and returns the address its port is published on once ready succeeds with it.
+func startContainer(t *testing.T, repository, tag string, env []string, port string, ready func(addr string) error) string {
+  t.Helper()
+
+  pool, err := dockertest.NewPool("")
+  if err == nil {
+    err = pool.Client.Ping()
+  }
+  if err != nil {
+    t.Fatalf("the integration tests need docker: %v", err)
+  }
+  // Retry tries ready again until MaxWait, the containers take a moment to answer.
+  pool.MaxWait = 30 * time.Second
+
+  // The port goes to a random one of the host, tests running side by side don't fight over it.
+  resource, err := pool.Run(repository, tag, env)
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Cleanup(func() {
+    if err := pool.Purge(resource); err != nil {
+      t.Errorf("failed to remove the %s container: %v", repository, err)
+    }
+  })
+
+  addr := resource.GetHostPort(port)
+  if err := pool.Retry(func() error { return ready(addr) }); err != nil {
+    t.Fatalf("%s still not ready after %s: %v", repository, pool.MaxWait, err)
+  }
+
+  return addr
+}
+
+// retry calls try until it succeeds, and fails the test when it still doesn't after timeout. The servers take a moment to answer.
+func retry(t *testing.T, timeout time.Duration, try func() error) {
+  t.Helper()
+
+  deadline := time.Now().Add(timeout)
+  for {
+    err := try()
+    if err == nil {
+      return
+    }
+    if time.Now().After(deadline) {
+      t.Fatalf("still failing after %s: %v", timeout, err)
+    }
+    time.Sleep(200 * time.Millisecond)
+  }
+}
+
+// startRedis returns the address of a Redis server, the one of BLOG_TEST_REDIS_ADDR or a container of its own.
+func startRedis(t *testing.T) string {
+  t.Helper()
+
+  if addr := os.Getenv("BLOG_TEST_REDIS_ADDR"); addr != "" {
+    return addr
+  }
+
+  return startContainer(t, "redis", "7-alpine", nil, "6379/tcp", func(addr string) error {
+    cache := newRedisCache(addr, nil, time.Minute)
+    defer cache.client.Close()
+    return cache.client.Ping(context.Background()).Err()
+  })
+}
+
+// startPostgres returns the URL of a Postgres database, the one of BLOG_TEST_POSTGRES_URL or the one of a container of its own.
+func startPostgres(t *testing.T) string {
+  t.Helper()
+
+  if url := os.Getenv("BLOG_TEST_POSTGRES_URL"); url != "" {
+    // The posts left by other tests would be found by the server, everything goes and the server migrates it up again.
+    db, err := store.OpenPostgres(url)
+    if err != nil {
+      t.Fatal(err)
+    }
+    defer db.Close()
+    if err := store.MigratePostgres(db, "0"); err != nil {
+      t.Fatal(err)
+    }
+    return url
+  }
+  var url string
+  startContainer(t, "postgres", "16-alpine", []string{"POSTGRES_PASSWORD=" + integrationAdminToken, "POSTGRES_DB=blog"}, "5432/tcp", func(addr string) error {
+    url = "postgres://postgres:" + integrationAdminToken + "@" + addr + "/blog?sslmode=disable"
+    db, err := store.OpenPostgres(url)
+    if err != nil {
+      return err
+    }
+    return db.Close()
+  })
+
+  return url
+}
+
+// integrationServer is a server binary running in a directory of its own, on a Unix socket of it.
+type integrationServer struct {
+  dir  string
+  args []string
+  cmd  *exec.Cmd
+  log  bytes.Buffer
+  // exited is closed once the server exited, with err the error of its exit.
+  exited chan struct{}
+  err    error
+}
+
+func (s *integrationServer) socket() string {
+  return filepath.Join(s.dir, "blog.sock")
+}
+
+func (s *integrationServer) start(t *testing.T) {
+  t.Helper()
+
+  args := append([]string{"-addr", "unix://" + s.socket(), "-admin-token", integrationAdminToken}, s.args...)
+  s.cmd = exec.Command(serverBinary, args...)
+  s.cmd.Dir = s.dir
+  s.cmd.Stdout, s.cmd.Stderr = &s.log, &s.log
+  if err := s.cmd.Start(); err != nil {
+    t.Fatal(err)
+  }
+  s.exited = make(chan struct{})
+  go func() {
+    s.err = s.cmd.Wait()
+    close(s.exited)
+  }()
+}
+
+// stop shuts the server down the way a deployment does, with SIGTERM, which saves what is pending before exiting.
+func (s *integrationServer) stop(t *testing.T) {
+  t.Helper()
+
+  if s.cmd == nil {
+    return
+  }
+  s.cmd.Process.Signal(syscall.SIGTERM)
+  select {
+  case <-s.exited:
+    if s.err != nil {
+      t.Errorf("the server exited with %v", s.err)
+    }
+  case <-time.After(30 * time.Second):
+    s.cmd.Process.Kill()
+    <-s.exited
+    t.Errorf("the server was still running 30s after SIGTERM")
+  }
+  s.cmd = nil
+}
+
+// startIntegrationServer runs the server with args in dir and returns a connection to it, the server and the connection go when the test is done.
+func startIntegrationServer(t *testing.T, dir string, args ...string) (*integrationServer, *grpc.ClientConn) {
+  t.Helper()
+
+  s := &integrationServer{dir: dir, args: args}
+  s.start(t)
+  t.Cleanup(func() {
+    s.stop(t)
+    if t.Failed() {
+      t.Logf("server log:\n%s", s.log.String())
+    }
+  })
+
+  conn, err := grpc.NewClient("unix://"+s.socket(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Cleanup(func() { conn.Close() })
+  s.wait(t, conn)
+
+  return s, conn
+}
+
+// wait waits until the server answers on conn.
+func (s *integrationServer) wait(t *testing.T, conn *grpc.ClientConn) {
+  t.Helper()
+
+  blog := pb.NewBlogClient(conn)
+  retry(t, 30*time.Second, func() error {
+    select {
+    case <-s.exited:
+      t.Fatalf("the server exited with %v", s.err)
+    default:
+    }
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+    _, err := blog.GetPosts(ctx, &pb.GetPostsRequest{})
+    return err
+  })
+}
+
+// integrationSetups are the backends the RPCs run against, each with what it needs started.
+var integrationSetups = []struct {
+  name string
+  // args returns the flags of the server, after preparing its working directory.
+  args func(t *testing.T, dir string) []string
+}{
+  {"file", func(t *testing.T, dir string) []string {
+    writeFile(t, filepath.Join(dir, "posts.json"), "[]")
+    return nil
+  }},
+  {"ndjson", func(t *testing.T, dir string) []string {
+    writeFile(t, filepath.Join(dir, "posts.json"), "")
+    return []string{"-file-format", "ndjson"}
+  }},
+  {"sqlite", func(*testing.T, string) []string { return []string{"-storage", "sqlite"} }},
+  {"redis", func(t *testing.T, _ string) []string {
+    return []string{"-storage", "sqlite", "-redis-addr", startRedis(t)}
+  }},
+  {"postgres", func(t *testing.T, _ string) []string {
+    // The server gets the environment of the test, -postgres-url-env names the variable.
+    t.Setenv("BLOG_TEST_SERVER_POSTGRES_URL", startPostgres(t))
+    return []string{"-storage", "postgres", "-postgres-url-env", "BLOG_TEST_SERVER_POSTGRES_URL"}
+  }},
+}
+
+func writeFile(t *testing.T, path, content string) {
+  if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func TestIntegration(t *testing.T) {
+  for _, setup := range integrationSetups {
+    t.Run(setup.name, func(t *testing.T) {
+      dir := t.TempDir()
+      args := setup.args(t, dir)
+      server, conn := startIntegrationServer(t, dir, args...)
+      ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+      defer cancel()
+
+      want := exerciseBlog(t, ctx, conn)
+
+      // Whatever the backend, the posts are still there after a restart, with their views.
+      server.stop(t)
+      server.start(t)
+      server.wait(t, conn)
+      checkPosts(t, ctx, blogsdk.New(conn), want)
+    })
+  }
+}
+
+// exerciseBlog goes through the life of a few posts and returns the posts left at the end.
+func exerciseBlog(t *testing.T, ctx context.Context, conn *grpc.ClientConn) []*pb.Post {
+  t.Helper()
+
+  client := blogsdk.New(conn, blogsdk.WithToken(integrationAdminToken))
+  blog := client.Stub()
+  admin := pb.NewAdminClient(conn)
+  adminCtx := blogsdk.WithAdminToken(ctx, integrationAdminToken)
+
+  // Creating
+  var posts []*pb.Post
+  for i, title := range []string{"Running gRPC in production", "Caching posts in Redis", "Streaming with HTTP/2"} {
+    post, err := client.CreatePost(ctx, title, fmt.Sprintf("Post number %d, with enough words to be read.", i+1), "alice", blogsdk.Tags("grpc", fmt.Sprintf("part-%d", i+1)))
+    if err != nil {
+      t.Fatalf("CreatePost: %v", err)
+    }
+    if post.Id == "" || post.Slug == "" || post.Sequence == 0 {
+      t.Fatalf("CreatePost returned %v, without an ID, a slug or a sequence", post)
+    }
+    posts = append(posts, post)
+  }
+  if _, err := client.CreatePost(ctx, "", "no title", "alice"); status.Code(err) != codes.InvalidArgument {
+    t.Errorf("CreatePost without a title: got %v, want InvalidArgument", err)
+  }
+
+  listed, err := client.ListPosts(ctx, &pb.PostFilter{Tags: []string{"part-2"}})
+  if err != nil {
+    t.Fatalf("ListPosts: %v", err)
+  }
+  if len(listed) != 1 || listed[0].Id != posts[1].Id {
+    t.Errorf("ListPosts of the tag part-2 returned %d posts, want %s", len(listed), posts[1].Id)
+  }
+
+  // Updating
+  updated, err := client.UpdatePost(ctx, posts[0].Id, blogsdk.Update{Content: "Rewritten content, longer than the first version of it."})
+  if err != nil {
+    t.Fatalf("UpdatePost: %v", err)
+  }
+  if updated.Content != "Rewritten content, longer than the first version of it." || updated.Title != posts[0].Title {
+    t.Errorf("UpdatePost returned %v", updated)
+  }
+  posts[0] = updated
+  bySlug, err := client.GetPostBySlug(ctx, posts[0].Slug)
+  if err != nil {
+    t.Fatalf("GetPostBySlug: %v", err)
+  }
+  if bySlug.Id != posts[0].Id || bySlug.Content != posts[0].Content {
+    t.Errorf("GetPostBySlug(%q) returned %v", posts[0].Slug, bySlug)
+  }
+  if _, err := client.UpdatePost(ctx, "no-such-post", blogsdk.Update{Title: "Nope"}); status.Code(err) != codes.NotFound {
+    t.Errorf("UpdatePost of a missing post: got %v, want NotFound", err)
+  }
+
+  // Viewing, twice by the same viewer who only counts once
+  for _, viewer := range []string{"viewer-1", "viewer-2", "viewer-1"} {
+    if _, err := blog.RecordView(ctx, &pb.RecordViewRequest{PostId: posts[1].Id, ViewerId: viewer}); err != nil {
+      t.Fatalf("RecordView: %v", err)
+    }
+  }
+  viewed, err := blog.GetPosts(ctx, &pb.GetPostsRequest{Filter: &pb.PostFilter{Tags: []string{"part-2"}}})
+  if err != nil {
+    t.Fatalf("GetPosts: %v", err)
+  }
+  if len(viewed.Posts) != 1 || viewed.Posts[0].ViewCount != 2 || viewed.Posts[0].UniqueViewers != 2 {
+    t.Errorf("after 3 views by 2 viewers GetPosts returned %v, want 2 views by 2 viewers", viewed.Posts)
+  }
+  posts[1].ViewCount, posts[1].UniqueViewers = 2, 2
+
+  // Pinning
+  pinned, err := blog.PinPost(ctx, &pb.PinPostRequest{Id: posts[2].Id})
+  if err != nil {
+    t.Fatalf("PinPost: %v", err)
+  }
+  if !pinned.Pinned {
+    t.Errorf("PinPost returned %v, not pinned", pinned)
+  }
+  posts[2].Pinned = true
+
+  // Deleting, which syncing clients are told about
+  synced, err := blog.SyncChanges(ctx, &pb.SyncChangesRequest{})
+  if err != nil {
+    t.Fatalf("SyncChanges: %v", err)
+  }
+  if err := client.DeletePost(ctx, posts[0].Id); err != nil {
+    t.Fatalf("DeletePost: %v", err)
+  }
+  changes, err := blog.SyncChanges(ctx, &pb.SyncChangesRequest{Cursor: synced.Cursor})
+  if err != nil {
+    t.Fatalf("SyncChanges: %v", err)
+  }
+  if !slices.Contains(changes.DeletedIds, posts[0].Id) {
+    t.Errorf("SyncChanges after DeletePost returned the deleted IDs %v, without %s", changes.DeletedIds, posts[0].Id)
+  }
+  if err := client.DeletePost(ctx, posts[0].Id); status.Code(err) != codes.NotFound {
+    t.Errorf("DeletePost of a deleted post: got %v, want NotFound", err)
+  }
+  posts = posts[1:]
+
+  // Streaming reads the posts the storage has, one at a time
+  stream, err := blog.StreamPosts(ctx, &pb.StreamPostsRequest{})
+  if err != nil {
+    t.Fatalf("StreamPosts: %v", err)
+  }
+  var streamed []string
+  for {
+    resp, err := stream.Recv()
+    if errors.Is(err, io.EOF) {
+      break
+    }
+    if err != nil {
+      t.Fatalf("StreamPosts: %v", err)
+    }
+    streamed = append(streamed, resp.Post.Id)
+  }
+  for _, post := range posts {
+    if !slices.Contains(streamed, post.Id) {
+      t.Errorf("StreamPosts returned %v, without %s", streamed, post.Id)
+    }
+  }
+
+  // The storage as the admins see it
+  stats, err := admin.GetStorageStats(adminCtx, &pb.GetStorageStatsRequest{})
+  if err != nil {
+    t.Fatalf("GetStorageStats: %v", err)
+  }
+  if stats.Backend == "" || stats.StorageBytes == 0 {
+    t.Errorf("GetStorageStats returned %v", stats)
+  }
+  if _, err := admin.GetStorageStats(ctx, &pb.GetStorageStatsRequest{}); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("GetStorageStats without the admin token: got %v, want Unauthenticated", err)
+  }
+
+  checkPosts(t, ctx, client, posts)
+
+  return posts
+}
+
+// checkPosts checks the server has the posts, and only them, as they were last returned.
+func checkPosts(t *testing.T, ctx context.Context, client *blogsdk.Client, want []*pb.Post) {
+  t.Helper()
+
+  got, err := client.ListPosts(ctx, nil)
+  if err != nil {
+    t.Fatalf("ListPosts: %v", err)
+  }
+  if len(got) != len(want) {
+    t.Fatalf("ListPosts returned %d posts, want %d", len(got), len(want))
+  }
+  for _, w := range want {
+    i := slices.IndexFunc(got, func(p *pb.Post) bool { return p.Id == w.Id })
+    if i < 0 {
+      t.Errorf("post %s is missing", w.Id)
+      continue
+    }
+    g := got[i]
+    if g.Title != w.Title || g.Content != w.Content || g.Slug != w.Slug || !slices.Equal(g.Tags, w.Tags) || g.ViewCount != w.ViewCount || g.UniqueViewers != w.UniqueViewers || g.Pinned != w.Pinned {
+      t.Errorf("post %s is\n  %v\nwant\n  %v", w.Id, g, w)
+    }
+  }
+}
@@ -0,0 +1,157 @@
+/*
+  Package apperr defines the errors the blog's handlers return and the gRPC status code each of them maps to.
+*/
+package apperr
+
+import (
+  "fmt"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/protoadapt"
+)
+
+/*
+  APPLICATION ERRORS
+
+  A handler used to pick a status code and write a message every time it failed, so the same failure could come out as NotFound in one RPC and Internal in another, and the only way to tell errors apart in Go code was comparing strings. Instead, every kind of failure is declared once below along with its code, and handlers return one of them with the details of the call:
+
+    return nil, apperr.Errorf(apperr.ErrPostNotFound, "post %q not found", id)
+
+  The client still gets a regular gRPC status: gRPC asks every error a handler returns for its status through a GRPCStatus method, which Error implements. Code running in the server (and tests) can check the kind with errors.Is, without parsing messages:
+
+    if errors.Is(err, apperr.ErrPostNotFound) { ... }
+
+  Errorf keeps any error passed to %w as the cause, so errors.Is and errors.As also see through to it.
+
+  Some errors carry more than a message: WithDetails attaches messages of the standard google.rpc error details (errdetails) to the status, which clients read back with status.Details, instead of parsing the message:
+
+    return nil, apperr.WithDetails(apperr.Errorf(apperr.ErrDuplicatePost, "..."), &errdetails.ResourceInfo{ResourceName: id})
+
+  Only the failures of the blog itself live here. The errors of the plumbing around the handlers, like authentication, rate limits and timeouts, are produced by a single interceptor or helper each and keep building their status directly.
+*/
+var (
+  ErrPostNotFound         = New(codes.NotFound, "post not found")
+  ErrRevisionNotFound     = New(codes.NotFound, "revision not found")
+  ErrAttachmentNotFound   = New(codes.NotFound, "attachment not found")
+  ErrWebhookNotFound      = New(codes.NotFound, "webhook not found")
+  ErrSubscriberNotFound   = New(codes.NotFound, "subscriber not found")
+  ErrTaskNotFound         = New(codes.NotFound, "task not found")
+  ErrTemplateNotFound     = New(codes.NotFound, "template not found")
+  ErrSeriesNotFound       = New(codes.NotFound, "series not found")
+  ErrNotificationNotFound = New(codes.NotFound, "notification not found")
+  ErrCommentNotFound      = New(codes.NotFound, "comment not found")
+  ErrReportNotFound       = New(codes.NotFound, "report not found")
+  ErrBanNotFound          = New(codes.NotFound, "ban not found")
+  ErrAuthorNotFound       = New(codes.NotFound, "author not found")
+  ErrInvalidTitle         = New(codes.InvalidArgument, "invalid title")
+  ErrInvalidArgument      = New(codes.InvalidArgument, "invalid argument")
+  // Content moderation turned the post down, see moderation.go in the server.
+  ErrContentRejected = New(codes.InvalidArgument, "content rejected")
+  // A post like the one being created already exists, see duplicates.go in the server.
+  ErrDuplicatePost = New(codes.AlreadyExists, "duplicate post")
+  // Template names are unique, see posttemplates.go in the server.
+  ErrTemplateExists = New(codes.AlreadyExists, "template exists")
+  // A recurring task was asked to run while it was running already, see cron.go in the server.
+  ErrTaskRunning = New(codes.Aborted, "task already running")
+  // PinPost was asked to pin one post more than -max-pinned allows, see pins.go in the server.
+  ErrTooManyPinned = New(codes.FailedPrecondition, "too many pinned posts")
+  // The RPC needs a feature the server wasn't started with, like email notifications without -smtp-addr.
+  ErrFeatureDisabled = New(codes.FailedPrecondition, "feature disabled")
+  // The storage couldn't be read or written. Unavailable tells clients the call may work if they try again later.
+  ErrStorageUnavailable = New(codes.Unavailable, "storage unavailable")
+  ErrInternal           = New(codes.Internal, "internal error")
+)
+
+type Error struct {
+  code    codes.Code
+  message string
+
+  // kind is the error declared above this one was made from, cause the error wrapped with %w.
+  kind  *Error
+  cause error
+
+  // The message is kept unformatted too, so it can be translated, see Localized.
+  format string
+  args   []any
+
+  details []protoadapt.MessageV1
+}
+
+// New declares a new kind of error.
+func New(code codes.Code, message string) *Error {
+  return &Error{code: code, message: message}
+}
+
+// Errorf returns an error of the given kind with a message of its own. Like fmt.Errorf it wraps the argument of %w.
+func Errorf(kind *Error, format string, args ...any) error {
+  err := fmt.Errorf(format, args...)
+
+  e := &Error{code: kind.code, message: err.Error(), kind: kind, format: format, args: args}
+  if wrapped, ok := err.(interface{ Unwrap() error }); ok {
+    e.cause = wrapped.Unwrap()
+  }
+
+  return e
+}
+
+func (e *Error) Error() string {
+  return e.message
+}
+
+// Unwrap lets errors.Is find the kind and the cause.
+func (e *Error) Unwrap() []error {
+  var errs []error
+  if e.kind != nil {
+    errs = append(errs, e.kind)
+  }
+  if e.cause != nil {
+    errs = append(errs, e.cause)
+  }
+
+  return errs
+}
+
+func (e *Error) Code() codes.Code {
+  return e.code
+}
+
+// WithDetails returns a copy of err carrying the details, errors that don't come from Errorf are returned as they are.
+func WithDetails(err error, details ...protoadapt.MessageV1) error {
+  e, ok := err.(*Error)
+  if !ok {
+    return err
+  }
+
+  withDetails := *e
+  withDetails.details = append(e.details[:len(e.details):len(e.details)], details...)
+
+  return &withDetails
+}
+
+// Localized returns the status with the message formatted from translate(format) rather than format, the arguments stay the same.
+func (e *Error) Localized(translate func(format string) string) *status.Status {
+  if e.format == "" {
+    return e.withDetails(status.New(e.code, translate(e.message)))
+  }
+
+  return e.withDetails(status.New(e.code, fmt.Errorf(translate(e.format), e.args...).Error()))
+}
+
+func (e *Error) GRPCStatus() *status.Status {
+  return e.withDetails(status.New(e.code, e.message))
+}
+
+func (e *Error) withDetails(st *status.Status) *status.Status {
+  if len(e.details) == 0 {
+    return st
+  }
+
+  // WithDetails only fails for an OK status, which errors never have.
+  withDetails, err := st.WithDetails(e.details...)
+  if err != nil {
+    return st
+  }
+
+  return withDetails
+}
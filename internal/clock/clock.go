@@ -0,0 +1,140 @@
+/*
+  Package clock is the time of the blog, which tests can stop and move forward.
+*/
+package clock
+
+import (
+  "slices"
+  "sync"
+  "time"
+)
+
+/*
+  CLOCK
+
+  Posts get published at their PublishAt, sessions and challenges expire, the mirror keeps its answers for a while: a test of any of these would have to sleep for as long with time.Now, a minute for a cache, days for a schedule. The server asks a Clock instead, System in production, and a test gives it a Fake that only moves when told to:
+
+    fake := clock.NewFake(time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC))
+    serverClock = fake
+    ... schedule a post at 10:00 ...
+    fake.Advance(time.Hour)   // the scheduler wakes up and publishes it
+
+  The timers of a Fake fire when Advance or Set reaches their time, which is how the loops sleeping until the next post or task is due follow the clock of the test.
+
+  Only the time of the blog goes through a Clock: the timestamps of what is saved, the schedules, the expiries and the retention windows. How long something takes, the latency of a call or the backoff of a retry, is the real time of the process and keeps using time.Now, and so do the timestamps other parties check against their own clocks, like the signatures and the OIDC tokens.
+*/
+type Clock interface {
+  Now() time.Time
+  // NewTimer is time.NewTimer on the clock: C receives once the clock got d further.
+  NewTimer(d time.Duration) Timer
+}
+
+// Timer is the part of time.Timer the server uses.
+type Timer interface {
+  C() <-chan time.Time
+  Stop() bool
+}
+
+// System is the clock of the operating system.
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+  return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+  return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+  *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+  return t.Timer.C
+}
+
+// Fake is a clock standing still between the calls to Advance and Set, for tests.
+type Fake struct {
+  mu     sync.Mutex
+  now    time.Time
+  timers []*fakeTimer
+}
+
+func NewFake(now time.Time) *Fake {
+  return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  return f.now
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  t := &fakeTimer{clock: f, at: f.now.Add(d), c: make(chan time.Time, 1)}
+  if d <= 0 {
+    t.c <- f.now
+    return t
+  }
+  f.timers = append(f.timers, t)
+
+  return t
+}
+
+// Advance moves the clock d forward and fires the timers due by then.
+func (f *Fake) Advance(d time.Duration) {
+  f.Set(f.Now().Add(d))
+}
+
+// Set moves the clock to now, backwards too, and fires the timers due by then.
+func (f *Fake) Set(now time.Time) {
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  f.now = now
+  f.timers = slices.DeleteFunc(f.timers, func(t *fakeTimer) bool {
+    if t.at.After(now) {
+      return false
+    }
+    t.c <- now
+    return true
+  })
+}
+
+// Timers returns how many timers wait for the clock, so a test can tell a loop went to sleep before advancing it.
+func (f *Fake) Timers() int {
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  return len(f.timers)
+}
+
+type fakeTimer struct {
+  clock *Fake
+  at    time.Time
+  c     chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+  return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+  t.clock.mu.Lock()
+  defer t.clock.mu.Unlock()
+
+  i := slices.Index(t.clock.timers, t)
+  if i < 0 {
+    return false
+  }
+  t.clock.timers = slices.Delete(t.clock.timers, i, i+1)
+
+  return true
+}
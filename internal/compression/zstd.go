@@ -0,0 +1,96 @@
+/*
+  Package compression holds the gRPC compressors the server and blogctl share. gzip comes with gRPC (google.golang.org/grpc/encoding/gzip), zstd is registered here.
+*/
+package compression
+
+import (
+  "io"
+  "sync"
+
+  "github.com/klauspost/compress/zstd"
+  "google.golang.org/grpc/encoding"
+)
+
+/*
+  ZSTANDARD
+
+  gRPC compresses messages with whatever compressor the caller names in the grpc-encoding header, as long as both ends registered one under that name. zstd compresses text about as well as gzip in about half the time, on both ends, which matters for big GetPosts responses where the bytes saved are paid for in CPU time. See compression_test.go at the root for the numbers.
+
+  Encoders and decoders keep large buffers around, creating one per message would cost more than the compression saves, so they are pooled like gRPC does for gzip. Concurrency 1 keeps each one on the goroutine of its call instead of starting goroutines of its own.
+*/
+const Zstd = "zstd"
+
+// RegisterZstd makes zstd available to gRPC. Like encoding.RegisterCompressor it must be called before any call is made or served.
+func RegisterZstd() {
+  encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+type zstdCompressor struct {
+  encoders sync.Pool
+  decoders sync.Pool
+}
+
+func (c *zstdCompressor) Name() string {
+  return Zstd
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+  enc, ok := c.encoders.Get().(*zstd.Encoder)
+  if !ok {
+    var err error
+    if enc, err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1)); err != nil {
+      return nil, err
+    }
+  }
+  enc.Reset(w)
+
+  return &zstdWriter{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+  dec, ok := c.decoders.Get().(*zstd.Decoder)
+  if !ok {
+    var err error
+    if dec, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1)); err != nil {
+      return nil, err
+    }
+  }
+  if err := dec.Reset(r); err != nil {
+    c.decoders.Put(dec)
+    return nil, err
+  }
+
+  return &zstdReader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+// zstdWriter gives the encoder back to the pool once the message is written. Close flushes the encoder, Reset makes it usable again.
+type zstdWriter struct {
+  *zstd.Encoder
+  pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+  defer w.pool.Put(w.Encoder)
+
+  return w.Encoder.Close()
+}
+
+// zstdReader gives the decoder back to the pool once the message is read. gRPC reads until io.EOF, it never closes the reader.
+type zstdReader struct {
+  *zstd.Decoder
+  pool *sync.Pool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+  if r.Decoder == nil {
+    return 0, io.EOF
+  }
+
+  n, err := r.Decoder.Read(p)
+  if err == io.EOF {
+    r.pool.Put(r.Decoder)
+    r.Decoder = nil
+  }
+
+  return n, err
+}
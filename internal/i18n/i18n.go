@@ -0,0 +1,102 @@
+/*
+  Package i18n translates the server's messages and formats dates in the language a caller asked for.
+*/
+package i18n
+
+import (
+  "embed"
+  "encoding/json"
+  "path"
+  "strconv"
+  "strings"
+  "time"
+)
+
+/*
+  MESSAGE CATALOGS
+
+  Every supported language has a catalog in locales/<language>.json, compiled into the binary with go:embed so the server doesn't depend on files next to it:
+
+    {
+      "date": {"layout": "{day} de {month} de {year}", "months": ["enero", ...]},
+      "messages": {"post %q not found": "no se encontró la publicación %q", ...}
+    }
+
+  Messages are looked up by their English format string, the way gettext does it. The English text stays readable in the code, and a message without a translation simply stays in English, so a new error never breaks a language that hasn't caught up yet. Translations have to keep the verbs (%q, %d, %w) in the same order as the original since they get the same arguments.
+
+  Language tags are matched loosely: "fr-CA" uses fr.json, and anything we don't have falls back to English.
+
+  Adding a language is adding a file to locales/, nothing else changes.
+*/
+const Fallback = "en"
+
+//go:embed locales/*.json
+var files embed.FS
+
+type catalog struct {
+  Date struct {
+    Layout string   `json:"layout"`
+    Months []string `json:"months"`
+  } `json:"date"`
+  Messages map[string]string `json:"messages"`
+}
+
+// The catalogs are part of the binary, one that doesn't parse is a bug we want to see on startup.
+var catalogs = mustLoad()
+
+func mustLoad() map[string]*catalog {
+  entries, err := files.ReadDir("locales")
+  if err != nil {
+    panic(err)
+  }
+
+  catalogs := make(map[string]*catalog, len(entries))
+  for _, entry := range entries {
+    data, err := files.ReadFile("locales/" + entry.Name())
+    if err != nil {
+      panic(err)
+    }
+
+    c := &catalog{}
+    if err := json.Unmarshal(data, c); err != nil {
+      panic("i18n: " + entry.Name() + ": " + err.Error())
+    }
+    if len(c.Date.Months) != 12 {
+      panic("i18n: " + entry.Name() + " must name the 12 months")
+    }
+
+    catalogs[strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))] = c
+  }
+
+  return catalogs
+}
+
+// Match returns the supported language closest to tag, e.g. "fr" for "fr-CA" and Fallback for "ja".
+func Match(tag string) string {
+  language, _, _ := strings.Cut(strings.ToLower(tag), "-")
+  if _, ok := catalogs[language]; ok {
+    return language
+  }
+
+  return Fallback
+}
+
+// Translate returns the message in the language of tag, or the message itself without a translation.
+func Translate(tag, message string) string {
+  if translated, ok := catalogs[Match(tag)].Messages[message]; ok {
+    return translated
+  }
+
+  return message
+}
+
+// FormatDate writes t as a date in the language of tag, e.g. "June 4, 2025" or "4 juin 2025".
+func FormatDate(tag string, t time.Time) string {
+  c := catalogs[Match(tag)]
+
+  return strings.NewReplacer(
+    "{day}", strconv.Itoa(t.Day()),
+    "{month}", c.Date.Months[t.Month()-1],
+    "{year}", strconv.Itoa(t.Year()),
+  ).Replace(c.Date.Layout)
+}
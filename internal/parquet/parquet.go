@@ -0,0 +1,254 @@
+/*
+  Package parquet writes Parquet files, the columnar format the tools of data analysis read (DuckDB, pandas, Spark, BigQuery...). Only what the exports of the server need is there: flat rows of required strings, integers and timestamps.
+*/
+package parquet
+
+import (
+  "encoding/binary"
+  "errors"
+  "fmt"
+  "io"
+
+  "github.com/klauspost/compress/snappy"
+)
+
+/*
+  PARQUET FILES
+
+  A Parquet file keeps the values of a column together rather than the fields of a row, which is what makes it small and fast to read for analysis: a column of the same method name repeated a million times compresses to almost nothing, and a query summing the views doesn't read the request bodies. The layout is:
+
+    PAR1
+    row group 1:  column chunk 1 (a page header and the values of the column), column chunk 2...
+    row group 2:  ...
+    footer:       the schema, and where every column chunk of every row group starts
+    length of the footer, PAR1
+
+  Writer keeps the rows of one row group in memory, column by column, and writes the group once it holds RowGroupBytes, so a file of any size is written in the memory of one group. The footer is only known at the end and goes last, which is why readers start from the end of the file.
+
+  The values are written with the PLAIN encoding (integers as 8 bytes little endian, strings as their length and their bytes) and every page is compressed with Snappy, the codec every reader knows. The headers and the footer are Thrift structures in the compact protocol, written by thrift.go. The field numbers come from parquet.thrift of the format: https://github.com/apache/parquet-format
+*/
+type Kind int
+
+const (
+  String Kind = iota
+  Int64
+  // Timestamp columns hold Unix milliseconds, in UTC.
+  Timestamp
+)
+
+type Column struct {
+  Name string
+  Kind Kind
+}
+
+// The types and enums of parquet.thrift the writer uses.
+const (
+  typeInt64     = 2
+  typeByteArray = 6
+
+  repetitionRequired = 0
+
+  convertedUTF8            = 0
+  convertedTimestampMillis = 9
+
+  encodingPlain = 0
+  encodingRLE   = 3
+
+  codecSnappy = 1
+
+  pageData = 0
+)
+
+const magic = "PAR1"
+
+// DefaultRowGroupBytes is the size of the values of a row group before it is written, when Writer.RowGroupBytes is 0.
+const DefaultRowGroupBytes = 8 << 20
+
+var errClosed = errors.New("parquet: write to a closed writer")
+
+type Writer struct {
+  // RowGroupBytes is the size the values of a row group reach before it is written.
+  RowGroupBytes int
+
+  w       io.Writer
+  columns []Column
+  offset  int64
+  err     error
+  closed  bool
+
+  // The values of the row group being filled, encoded, one buffer per column.
+  values [][]byte
+  rows   int64
+
+  rowGroups [][]field
+  totalRows int64
+}
+
+// NewWriter starts a file of the columns on w. Close writes the footer, w is left open.
+func NewWriter(w io.Writer, columns []Column) *Writer {
+  pw := &Writer{w: w, columns: columns, values: make([][]byte, len(columns))}
+  pw.write([]byte(magic))
+
+  return pw
+}
+
+func (pw *Writer) write(p []byte) {
+  if pw.err != nil {
+    return
+  }
+  n, err := pw.w.Write(p)
+  pw.offset += int64(n)
+  pw.err = err
+}
+
+// Write adds a row, with a string for the String columns and an int64 for the others, in the order of the columns.
+func (pw *Writer) Write(row []any) error {
+  if pw.closed {
+    return errClosed
+  }
+  if len(row) != len(pw.columns) {
+    return errors.New("parquet: the row doesn't have a value for every column")
+  }
+
+  // Checked before anything is added, a row is written whole or not at all.
+  for i, column := range pw.columns {
+    switch row[i].(type) {
+    case string:
+      if column.Kind != String {
+        return errors.New("parquet: a string for the integer column " + column.Name)
+      }
+    case int64:
+      if column.Kind == String {
+        return errors.New("parquet: an integer for the string column " + column.Name)
+      }
+    default:
+      return fmt.Errorf("parquet: a %T for the column %s, the values are strings and int64s", row[i], column.Name)
+    }
+  }
+
+  size := 0
+  for i := range pw.columns {
+    switch v := row[i].(type) {
+    case string:
+      pw.values[i] = binary.LittleEndian.AppendUint32(pw.values[i], uint32(len(v)))
+      pw.values[i] = append(pw.values[i], v...)
+    case int64:
+      pw.values[i] = binary.LittleEndian.AppendUint64(pw.values[i], uint64(v))
+    }
+    size += len(pw.values[i])
+  }
+  pw.rows++
+
+  limit := pw.RowGroupBytes
+  if limit == 0 {
+    limit = DefaultRowGroupBytes
+  }
+  if size >= limit {
+    pw.flush()
+  }
+
+  return pw.err
+}
+
+// flush writes the row group being filled, a page per column chunk.
+func (pw *Writer) flush() {
+  if pw.rows == 0 || pw.err != nil {
+    return
+  }
+
+  var chunks [][]field
+  var groupBytes int64
+  for i, column := range pw.columns {
+    compressed := snappy.Encode(nil, pw.values[i])
+    header := encodeStruct(nil, []field{
+      {1, int32(pageData)},
+      {2, int32(len(pw.values[i]))},
+      {3, int32(len(compressed))},
+      {5, []field{
+        {1, int32(pw.rows)},
+        {2, int32(encodingPlain)},
+        // Required columns have no levels, the encodings still have to be named.
+        {3, int32(encodingRLE)},
+        {4, int32(encodingRLE)},
+      }},
+    })
+
+    start := pw.offset
+    pw.write(header)
+    pw.write(compressed)
+
+    uncompressed := int64(len(header) + len(pw.values[i]))
+    groupBytes += uncompressed
+    chunks = append(chunks, []field{
+      {2, start},
+      {3, []field{
+        {1, int32(physicalType(column.Kind))},
+        {2, []int32{encodingPlain, encodingRLE}},
+        {3, []string{column.Name}},
+        {4, int32(codecSnappy)},
+        {5, pw.rows},
+        {6, uncompressed},
+        {7, pw.offset - start},
+        {9, start},
+      }},
+    })
+    pw.values[i] = pw.values[i][:0]
+  }
+
+  pw.rowGroups = append(pw.rowGroups, []field{
+    {1, chunks},
+    {2, groupBytes},
+    {3, pw.rows},
+  })
+  pw.totalRows += pw.rows
+  pw.rows = 0
+}
+
+func physicalType(kind Kind) int {
+  if kind == String {
+    return typeByteArray
+  }
+
+  return typeInt64
+}
+
+// Close writes the last row group and the footer. A file without rows is valid, with a schema and no row group.
+func (pw *Writer) Close() error {
+  if pw.closed {
+    return pw.err
+  }
+  pw.flush()
+  pw.closed = true
+
+  schema := [][]field{{
+    {4, "schema"},
+    {5, int32(len(pw.columns))},
+  }}
+  for _, column := range pw.columns {
+    element := []field{
+      {1, int32(physicalType(column.Kind))},
+      {3, int32(repetitionRequired)},
+      {4, column.Name},
+    }
+    switch column.Kind {
+    case String:
+      element = append(element, field{6, int32(convertedUTF8)})
+    case Timestamp:
+      element = append(element, field{6, int32(convertedTimestampMillis)})
+    }
+    schema = append(schema, element)
+  }
+
+  footer := encodeStruct(nil, []field{
+    {1, int32(1)},
+    {2, schema},
+    {3, pw.totalRows},
+    {4, pw.rowGroups},
+    {6, "go/tutorial/grpc"},
+  })
+  pw.write(footer)
+  pw.write(binary.LittleEndian.AppendUint32(nil, uint32(len(footer))))
+  pw.write([]byte(magic))
+
+  return pw.err
+}
@@ -0,0 +1,106 @@
+package parquet
+
+import (
+  "encoding/binary"
+  "fmt"
+)
+
+/*
+  THRIFT COMPACT PROTOCOL
+
+  The page headers and the footer of a Parquet file are Thrift structures, written with the compact protocol: every field is a header byte, the difference with the number of the previous field in its top 4 bits and its type in the bottom 4, followed by the value. Integers are zigzag varints, so small negative numbers stay small, strings are their length and their bytes, a list is the number of elements and their type followed by the elements, a structure ends with a 0 byte.
+
+  The writer only needs to write structures it knows, so rather than generating code from parquet.thrift they are written as lists of fields in the order of their numbers, with the Go type of the value giving the Thrift type. Optional fields are left out.
+*/
+type field struct {
+  id    int16
+  value any
+}
+
+// The types of the compact protocol.
+const (
+  compactI32    = 5
+  compactI64    = 6
+  compactBinary = 8
+  compactList   = 9
+  compactStruct = 12
+)
+
+// encodeStruct appends the structure of fields to b: int32 and int64 are integers, string a string, []field a structure and []int32, []string and [][]field lists of them.
+func encodeStruct(b []byte, fields []field) []byte {
+  var last int16
+  for _, f := range fields {
+    typ := compactType(f.value)
+    if delta := f.id - last; delta > 0 && delta <= 15 {
+      b = append(b, byte(delta)<<4|typ)
+    } else {
+      b = append(b, typ)
+      b = binary.AppendUvarint(b, zigzag(int64(f.id)))
+    }
+    last = f.id
+    b = encodeValue(b, f.value)
+  }
+
+  return append(b, 0)
+}
+
+func compactType(value any) byte {
+  switch value.(type) {
+  case int32:
+    return compactI32
+  case int64:
+    return compactI64
+  case string:
+    return compactBinary
+  case []field:
+    return compactStruct
+  case []int32, []string, [][]field:
+    return compactList
+  }
+  panic(fmt.Sprintf("parquet: no Thrift type for %T", value))
+}
+
+func encodeValue(b []byte, value any) []byte {
+  switch v := value.(type) {
+  case int32:
+    return binary.AppendUvarint(b, zigzag(int64(v)))
+  case int64:
+    return binary.AppendUvarint(b, zigzag(v))
+  case string:
+    b = binary.AppendUvarint(b, uint64(len(v)))
+    return append(b, v...)
+  case []field:
+    return encodeStruct(b, v)
+  case []int32:
+    b = listHeader(b, len(v), compactI32)
+    for _, e := range v {
+      b = encodeValue(b, e)
+    }
+  case []string:
+    b = listHeader(b, len(v), compactBinary)
+    for _, e := range v {
+      b = encodeValue(b, e)
+    }
+  case [][]field:
+    b = listHeader(b, len(v), compactStruct)
+    for _, e := range v {
+      b = encodeStruct(b, e)
+    }
+  }
+
+  return b
+}
+
+// listHeader holds the size in its top 4 bits up to 14, bigger sizes follow as a varint.
+func listHeader(b []byte, size int, typ byte) []byte {
+  if size < 15 {
+    return append(b, byte(size)<<4|typ)
+  }
+  b = append(b, 0xf0|typ)
+
+  return binary.AppendUvarint(b, uint64(size))
+}
+
+func zigzag(n int64) uint64 {
+  return uint64(n<<1) ^ uint64(n>>63)
+}
@@ -0,0 +1,46 @@
+/*
+  Package pow holds the proof of work the server asks anonymous callers for before CreatePost (see challenges.go at the root) and blogctl solves.
+*/
+package pow
+
+import (
+  "crypto/sha256"
+  "math/bits"
+  "strconv"
+)
+
+/*
+  PROOF OF WORK
+
+  A proof of work is a puzzle that takes many tries to solve and one to check. The server hands out a challenge, a random string, and a difficulty in bits. Solving it means finding a nonce such that the SHA-256 of "<challenge>:<nonce>" starts with that many zero bits, there is no better way than trying nonces one after the other: 2^bits tries on average. Checking a solution is a single hash.
+
+  20 bits is about a million hashes: under a second for somebody posting once, a real cost for a bot posting thousands of times.
+*/
+
+// Solve returns the first nonce solving the challenge.
+func Solve(challenge string, difficulty int) uint64 {
+  for nonce := uint64(0); ; nonce++ {
+    if Check(challenge, nonce, difficulty) {
+      return nonce
+    }
+  }
+}
+
+// Check tells whether the nonce solves the challenge.
+func Check(challenge string, nonce uint64, difficulty int) bool {
+  sum := sha256.Sum256([]byte(challenge + ":" + strconv.FormatUint(nonce, 10)))
+
+  return leadingZeros(sum[:]) >= difficulty
+}
+
+func leadingZeros(sum []byte) int {
+  var zeros int
+  for _, b := range sum {
+    if b != 0 {
+      return zeros + bits.LeadingZeros8(b)
+    }
+    zeros += 8
+  }
+
+  return zeros
+}
@@ -0,0 +1,148 @@
+/*
+  Package reqctx reads who is calling, from where, in which language and under which request ID out of an incoming gRPC call, and keeps it in the call's context for the handlers and the logs.
+*/
+package reqctx
+
+import (
+  "context"
+  "go/tutorial/grpc/internal/store"
+  "strings"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/peer"
+)
+
+/*
+  REQUEST CONTEXT
+
+  A handler that wants to know who is calling could dig through the metadata, the peer and the headers on its own, but then every handler (and every interceptor that logs something) would do it slightly differently. The interceptors in this package do it once, at the start of every call, and store the result in the context. From then on handlers only use the accessors:
+
+    reqctx.RequestID(ctx)  // the ID sent back in the x-request-id header
+    reqctx.Identity(ctx)   // who is calling, e.g. admin or anonymous
+    reqctx.Peer(ctx)       // the network address of the caller
+    reqctx.Locale(ctx)     // the language the caller asked for, "en" unless told otherwise
+
+  Context values are keyed by an unexported type, so no other package can read or overwrite them by accident, and they are all kept in a single struct so a call only adds one value to its context.
+
+  REQUEST IDS
+
+  Every call gets a request ID that is sent back to the client in the "x-request-id" response header. If the client already sent one (for example because the call is part of a bigger operation it is tracing) we keep it, otherwise we assign a new one. Logging the ID on both sides makes it easy to match a client error with what happened on the server.
+
+  Headers are sent before the first response message, so they have to be set while the handler runs. grpc.SetHeader (unary) and ServerStream.SetHeader (streaming) only queue them, gRPC sends them along with the response.
+
+  LOCALE
+
+  Clients say which language they want in the "accept-language" key, the same value browsers send in the HTTP header of the same name (e.g. "fr-CA,fr;q=0.9"). We keep the first language of the list.
+*/
+const (
+  RequestIDHeader = "x-request-id"
+  LocaleHeader    = "accept-language"
+  DefaultLocale   = "en"
+)
+
+// Identifier tells who is making a call, the server passes its authenticator's.
+type Identifier func(ctx context.Context) string
+
+type key struct{}
+
+type values struct {
+  requestID string
+  identity  string
+  peer      string
+  locale    string
+}
+
+func from(ctx context.Context) values {
+  v, _ := ctx.Value(key{}).(values)
+  return v
+}
+
+// RequestID returns the ID of the call the context belongs to.
+func RequestID(ctx context.Context) string {
+  return from(ctx).requestID
+}
+
+// Identity returns who is making the call, or an empty string outside of a call.
+func Identity(ctx context.Context) string {
+  return from(ctx).identity
+}
+
+// Peer returns the address the call comes from, like 127.0.0.1:53210.
+func Peer(ctx context.Context) string {
+  return from(ctx).peer
+}
+
+// Locale returns the language tag the caller asked for, DefaultLocale when it didn't ask.
+func Locale(ctx context.Context) string {
+  if locale := from(ctx).locale; locale != "" {
+    return locale
+  }
+
+  return DefaultLocale
+}
+
+// extract reads the incoming call and returns the context with its values.
+func extract(ctx context.Context, identify Identifier) (context.Context, values) {
+  md, _ := metadata.FromIncomingContext(ctx)
+
+  v := values{requestID: first(md, RequestIDHeader)}
+  if v.requestID == "" {
+    v.requestID = store.NewID()
+  }
+
+  if identify != nil {
+    v.identity = identify(ctx)
+  }
+
+  if p, ok := peer.FromContext(ctx); ok {
+    v.peer = p.Addr.String()
+  }
+
+  // "fr-CA,fr;q=0.9" -> "fr-CA"
+  language, _, _ := strings.Cut(first(md, LocaleHeader), ",")
+  language, _, _ = strings.Cut(language, ";")
+  v.locale = strings.TrimSpace(language)
+
+  return context.WithValue(ctx, key{}, v), v
+}
+
+func first(md metadata.MD, key string) string {
+  if values := md.Get(key); len(values) > 0 {
+    return values[0]
+  }
+
+  return ""
+}
+
+func UnaryServerInterceptor(identify Identifier) grpc.UnaryServerInterceptor {
+  return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+    ctx, v := extract(ctx, identify)
+    grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, v.requestID))
+
+    return handler(ctx, req)
+  }
+}
+
+func StreamServerInterceptor(identify Identifier) grpc.StreamServerInterceptor {
+  return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+    ctx, v := extract(ss.Context(), identify)
+    ss.SetHeader(metadata.Pairs(RequestIDHeader, v.requestID))
+
+    return handler(srv, WrapStream(ss, ctx))
+  }
+}
+
+// WrapStream returns ss with its context swapped for ctx, the only way for a stream interceptor to pass values down to the handler.
+func WrapStream(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+  return &contextStream{ServerStream: ss, ctx: ctx}
+}
+
+type contextStream struct {
+  grpc.ServerStream
+  ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+  return s.ctx
+}
@@ -0,0 +1,370 @@
+/*
+  Package spiffe fetches the X.509 SVIDs of a workload from the SPIFFE Workload API, for the server (see spiffe.go at the root) and blogctl to authenticate each other with mutual TLS.
+*/
+package spiffe
+
+import (
+  "context"
+  "crypto"
+  "crypto/tls"
+  "crypto/x509"
+  "fmt"
+  "log"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials/insecure"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/protobuf/encoding/protowire"
+)
+
+/*
+  SPIFFE
+
+  SPIFFE gives every workload an identity, a URI like spiffe://example.org/blog, and an X.509 certificate carrying it, the SVID. An agent running next to the workload, SPIRE for instance, hands them out over the Workload API, a gRPC service on a Unix socket, and rotates them long before they expire: nothing to provision, no secret to copy around.
+
+  Source keeps the SVID of the workload and the trust bundle, the certificate authorities of the trust domain, up to date. FetchX509SVID is a server streaming RPC answering with a new message at every rotation, Source keeps the stream open and opens it again when the agent goes away.
+
+  The Workload API is defined by workload.proto of the SPIFFE project. The few fields used here are read with protowire rather than generated code, so the blog doesn't depend on the SPIFFE libraries:
+
+    message X509SVIDResponse { repeated X509SVID svids = 1; ... }
+    message X509SVID { string spiffe_id = 1; bytes x509_svid = 2; bytes x509_svid_key = 3; bytes bundle = 4; ... }
+
+  where the certificates are DER encoded one after the other and the key is PKCS#8. The agent finds out who calls it from the socket itself, the call only says it means the Workload API with the workload.spiffe.io metadata.
+*/
+const (
+  // EndpointEnv is the environment variable the agent's socket is usually given in.
+  EndpointEnv = "SPIFFE_ENDPOINT_SOCKET"
+
+  fetchX509SVID = "/SpiffeWorkloadAPI/FetchX509SVID"
+  firstSVIDWait = 30 * time.Second
+)
+
+// Source keeps the SVID and the trust bundle of the workload up to date, see above.
+type Source struct {
+  conn   *grpc.ClientConn
+  cancel context.CancelFunc
+
+  mu     sync.RWMutex
+  id     string
+  cert   *tls.Certificate
+  bundle *x509.CertPool
+}
+
+// Open connects to the agent at socket, a path or unix:///<path>, and returns once it got the first SVID.
+func Open(socket string) (*Source, error) {
+  if !strings.HasPrefix(socket, "unix:") {
+    socket = "unix://" + socket
+  }
+  conn, err := grpc.NewClient(socket, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})))
+  if err != nil {
+    return nil, fmt.Errorf("failed to connect to the workload API: %w", err)
+  }
+
+  ctx, cancel := context.WithCancel(context.Background())
+  s := &Source{conn: conn, cancel: cancel}
+  first := make(chan error, 1)
+  go s.watch(ctx, first)
+
+  select {
+  case err := <-first:
+    if err != nil {
+      s.Close()
+      return nil, err
+    }
+  case <-time.After(firstSVIDWait):
+    s.Close()
+    return nil, fmt.Errorf("the workload API sent no SVID within %s", firstSVIDWait)
+  }
+
+  return s, nil
+}
+
+func (s *Source) Close() error {
+  s.cancel()
+  return s.conn.Close()
+}
+
+// watch follows the rotations until ctx is done, first gets the outcome of the first fetch.
+func (s *Source) watch(ctx context.Context, first chan<- error) {
+  ctx = metadata.AppendToOutgoingContext(ctx, "workload.spiffe.io", "true")
+  backoff := time.Second
+  var started bool
+  for {
+    err := s.fetch(ctx, func() {
+      if !started {
+        started = true
+        first <- nil
+      }
+    })
+    if ctx.Err() != nil {
+      return
+    }
+    if !started {
+      // A refused first fetch, the workload isn't registered for instance, is worth telling right away.
+      first <- err
+      return
+    }
+    log.Printf("spiffe: workload API stream ended, retrying in %s: %v", backoff, err)
+    select {
+    case <-ctx.Done():
+      return
+    case <-time.After(backoff):
+    }
+    backoff = min(2*backoff, time.Minute)
+  }
+}
+
+// fetch applies every SVID the stream sends, calling updated after each one.
+func (s *Source) fetch(ctx context.Context, updated func()) error {
+  stream, err := s.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, fetchX509SVID)
+  if err != nil {
+    return err
+  }
+  if err := stream.SendMsg(&rawMessage{}); err != nil {
+    return err
+  }
+  if err := stream.CloseSend(); err != nil {
+    return err
+  }
+
+  for {
+    var msg rawMessage
+    if err := stream.RecvMsg(&msg); err != nil {
+      return err
+    }
+    if err := s.update(msg.data); err != nil {
+      return err
+    }
+    updated()
+  }
+}
+
+func (s *Source) update(response []byte) error {
+  svid, ok, err := firstField(response, 1)
+  if err != nil || !ok {
+    return fmt.Errorf("the workload API sent no X509SVID: %v", err)
+  }
+
+  var id string
+  var certs, key, bundle []byte
+  for len(svid) > 0 {
+    num, typ, n := protowire.ConsumeTag(svid)
+    if n < 0 {
+      return protowire.ParseError(n)
+    }
+    svid = svid[n:]
+    if typ != protowire.BytesType {
+      n = protowire.ConsumeFieldValue(num, typ, svid)
+      if n < 0 {
+        return protowire.ParseError(n)
+      }
+      svid = svid[n:]
+      continue
+    }
+    value, n := protowire.ConsumeBytes(svid)
+    if n < 0 {
+      return protowire.ParseError(n)
+    }
+    svid = svid[n:]
+    switch num {
+    case 1:
+      id = string(value)
+    case 2:
+      certs = value
+    case 3:
+      key = value
+    case 4:
+      bundle = value
+    }
+  }
+
+  chain, err := x509.ParseCertificates(certs)
+  if err != nil || len(chain) == 0 {
+    return fmt.Errorf("invalid SVID certificates: %v", err)
+  }
+  privateKey, err := x509.ParsePKCS8PrivateKey(key)
+  if err != nil {
+    return fmt.Errorf("invalid SVID key: %w", err)
+  }
+  signer, ok := privateKey.(crypto.Signer)
+  if !ok {
+    return fmt.Errorf("the SVID key can't sign")
+  }
+  roots, err := x509.ParseCertificates(bundle)
+  if err != nil || len(roots) == 0 {
+    return fmt.Errorf("invalid trust bundle: %v", err)
+  }
+
+  cert := &tls.Certificate{PrivateKey: signer, Leaf: chain[0]}
+  for _, c := range chain {
+    cert.Certificate = append(cert.Certificate, c.Raw)
+  }
+  pool := x509.NewCertPool()
+  for _, root := range roots {
+    pool.AddCert(root)
+  }
+
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.id, s.cert, s.bundle = id, cert, pool
+
+  return nil
+}
+
+// ID returns the SPIFFE ID of the workload.
+func (s *Source) ID() string {
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+
+  return s.id
+}
+
+func (s *Source) certificate() *tls.Certificate {
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+
+  return s.cert
+}
+
+func (s *Source) roots() *x509.CertPool {
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+
+  return s.bundle
+}
+
+// ServerTLSConfig serves the SVID and verifies the SVIDs clients present against the bundle. Clients without one are let through, without an identity from SPIFFE.
+func (s *Source) ServerTLSConfig() *tls.Config {
+  return &tls.Config{
+    MinVersion: tls.VersionTLS12,
+    // Built again for every connection, the bundle may have changed since the last one.
+    GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+      return &tls.Config{
+        MinVersion:   tls.VersionTLS12,
+        Certificates: []tls.Certificate{*s.certificate()},
+        ClientAuth:   tls.VerifyClientCertIfGiven,
+        ClientCAs:    s.roots(),
+        NextProtos:   []string{"h2"},
+      }, nil
+    },
+  }
+}
+
+// ClientTLSConfig presents the SVID and accepts a server whose SVID has the ID serverID, any ID of the trust domain when it is empty. The host name isn't checked, SVIDs don't name hosts.
+func (s *Source) ClientTLSConfig(serverID string) *tls.Config {
+  return &tls.Config{
+    MinVersion: tls.VersionTLS12,
+    GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+      return s.certificate(), nil
+    },
+    // The chain is verified below, against the bundle instead of the system's authorities.
+    InsecureSkipVerify: true,
+    VerifyPeerCertificate: func(raw [][]byte, _ [][]*x509.Certificate) error {
+      id, err := s.Verify(raw)
+      if err != nil {
+        return err
+      }
+      if serverID != "" && id != serverID {
+        return fmt.Errorf("the server is %s, expected %s", id, serverID)
+      }
+      return nil
+    },
+  }
+}
+
+// Verify checks a chain of DER certificates against the bundle and returns the SPIFFE ID of the first one.
+func (s *Source) Verify(raw [][]byte) (string, error) {
+  if len(raw) == 0 {
+    return "", fmt.Errorf("no certificate presented")
+  }
+  var chain []*x509.Certificate
+  for _, der := range raw {
+    cert, err := x509.ParseCertificate(der)
+    if err != nil {
+      return "", err
+    }
+    chain = append(chain, cert)
+  }
+  intermediates := x509.NewCertPool()
+  for _, cert := range chain[1:] {
+    intermediates.AddCert(cert)
+  }
+  if _, err := chain[0].Verify(x509.VerifyOptions{Roots: s.roots(), Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}); err != nil {
+    return "", err
+  }
+
+  id, ok := IDFromCertificate(chain[0])
+  if !ok {
+    return "", fmt.Errorf("the certificate isn't an SVID")
+  }
+
+  return id, nil
+}
+
+// IDFromCertificate returns the SPIFFE ID of an SVID, its only URI.
+func IDFromCertificate(cert *x509.Certificate) (string, bool) {
+  if len(cert.URIs) != 1 || cert.URIs[0].Scheme != "spiffe" {
+    return "", false
+  }
+
+  return cert.URIs[0].String(), true
+}
+
+// Match tells whether the ID matches pattern, an ID or a prefix ending with /*.
+func Match(pattern, id string) bool {
+  if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+    return strings.HasPrefix(id, prefix+"/")
+  }
+
+  return pattern == id
+}
+
+// firstField returns the first bytes field num of a message.
+func firstField(msg []byte, want protowire.Number) ([]byte, bool, error) {
+  for len(msg) > 0 {
+    num, typ, n := protowire.ConsumeTag(msg)
+    if n < 0 {
+      return nil, false, protowire.ParseError(n)
+    }
+    msg = msg[n:]
+    if num == want && typ == protowire.BytesType {
+      value, n := protowire.ConsumeBytes(msg)
+      if n < 0 {
+        return nil, false, protowire.ParseError(n)
+      }
+      return value, true, nil
+    }
+    n = protowire.ConsumeFieldValue(num, typ, msg)
+    if n < 0 {
+      return nil, false, protowire.ParseError(n)
+    }
+    msg = msg[n:]
+  }
+
+  return nil, false, nil
+}
+
+// rawMessage is a message as encoded, rawCodec leaves the encoding to the code above.
+type rawMessage struct {
+  data []byte
+}
+
+// The name is the one of the content type the agent expects, application/grpc+proto.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+  return v.(*rawMessage).data, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+  v.(*rawMessage).data = append([]byte(nil), data...)
+  return nil
+}
+
+func (rawCodec) Name() string {
+  return "proto"
+}
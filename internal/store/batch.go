@@ -0,0 +1,156 @@
+package store
+
+import (
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "sync"
+  "time"
+
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  BATCHED WRITES
+
+  Every mutation saves every post: ten CreatePost calls in a second rewrite posts.json ten times, when only the last write matters. BatchingStore keeps what was saved in memory and writes it after Window, so all the saves in between become a single write:
+
+    go run . -write-delay 2s
+
+  Loads and reads see the pending posts, so nothing changes for the handlers, only the disk lags behind by up to Window. That is the trade-off: a crash, or a kill -9, loses the saves of the last Window. A graceful shutdown flushes before exiting, and so do the FlushStorage Admin RPC and SetMaintenance with Wait, after which the files on disk are current.
+
+  A delayed write that fails is reported to OnError and tried again after Window, the posts stay pending until it succeeds.
+
+  The pending posts are copies: handlers keep changing the posts they loaded, and a change that ends in an error must not reach the disk.
+*/
+type BatchingStore struct {
+  PostStore
+  Window time.Duration
+  // OnError is called with the errors of the delayed writes.
+  OnError func(error)
+  // Background runs the delayed writes, when it returns true. Nil writes them on the goroutine of their timer.
+  Background func(task func()) bool
+
+  mu      sync.Mutex
+  pending []*pb.Post
+  dirty   bool
+  // saves counts the saves pending holds.
+  saves int
+  timer *time.Timer
+}
+
+func (b *BatchingStore) Load() ([]*pb.Post, error) {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  if b.dirty {
+    return clonePosts(b.pending), nil
+  }
+
+  return b.PostStore.Load()
+}
+
+// Save keeps the posts for the next write, it only fails when the write it schedules does.
+func (b *BatchingStore) Save(posts []*pb.Post) error {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  b.pending, b.dirty = clonePosts(posts), true
+  b.saves++
+  if b.timer == nil {
+    b.timer = time.AfterFunc(b.Window, b.scheduleFlush)
+  }
+
+  return nil
+}
+
+// Reader reads the pending posts when there are some, the backend otherwise.
+func (b *BatchingStore) Reader() (PostReader, error) {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  if b.dirty {
+    return &sliceReader{posts: clonePosts(b.pending)}, nil
+  }
+
+  return OpenReader(b.PostStore)
+}
+
+// Lookup goes through the index of the backend, which only describes the file once the pending posts are written.
+func (b *BatchingStore) Lookup(q Query) (PostReader, error) {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  if b.dirty {
+    return nil, ErrNotIndexed
+  }
+
+  return Lookup(b.PostStore, q)
+}
+
+// Pending returns how many saves the next write is made of.
+func (b *BatchingStore) Pending() int {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  return b.saves
+}
+
+// Flush writes the pending posts now, and returns how many saves the write was made of.
+func (b *BatchingStore) Flush() (int, error) {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  if b.timer != nil {
+    b.timer.Stop()
+    b.timer = nil
+  }
+
+  saves, err := b.flush()
+  if err != nil {
+    b.timer = time.AfterFunc(b.Window, b.scheduleFlush)
+  }
+
+  return saves, err
+}
+
+func (b *BatchingStore) scheduleFlush() {
+  if b.Background == nil || !b.Background(b.flushLater) {
+    b.flushLater()
+  }
+}
+
+func (b *BatchingStore) flushLater() {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  b.timer = nil
+  if _, err := b.flush(); err != nil {
+    if b.OnError != nil {
+      b.OnError(err)
+    }
+    b.timer = time.AfterFunc(b.Window, b.scheduleFlush)
+  }
+}
+
+func (b *BatchingStore) flush() (int, error) {
+  if !b.dirty {
+    return 0, nil
+  }
+
+  if err := b.PostStore.Save(b.pending); err != nil {
+    return 0, err
+  }
+
+  saves := b.saves
+  b.pending, b.dirty, b.saves = nil, false, 0
+
+  return saves, nil
+}
+
+func clonePosts(posts []*pb.Post) []*pb.Post {
+  clones := make([]*pb.Post, len(posts))
+  for i, post := range posts {
+    clones[i] = proto.Clone(post).(*pb.Post)
+  }
+
+  return clones
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+  "context"
+  "database/sql"
+  "errors"
+  "io/fs"
+  "os"
+  "path/filepath"
+  "strings"
+
+  "golang.org/x/crypto/acme/autocert"
+)
+
+/*
+  CERTIFICATE CACHE
+
+  The ACME client of the server (see acme.go at the root) has to remember its account key and the certificates it obtained, otherwise every start would ask Let's Encrypt for a new certificate and soon hit its rate limits. CertCache keeps them next to the posts: in the acme_certs table with -storage sqlite, so servers sharing the database share the certificates too, in files under Dir otherwise.
+
+  They hold private keys. With a Keyring (see encrypt.go) they are encrypted like the posts, under the name of the entry.
+*/
+type CertCache struct {
+  // DB nil keeps the entries in files under Dir.
+  DB   *sql.DB
+  Dir  string
+  Keys *Keyring
+}
+
+// Get returns autocert.ErrCacheMiss for an entry it doesn't have, which is what makes autocert ask for a certificate.
+func (c *CertCache) Get(ctx context.Context, key string) ([]byte, error) {
+  var data []byte
+  var err error
+  if c.DB != nil {
+    err = c.DB.QueryRowContext(ctx, "SELECT data FROM acme_certs WHERE key = ?", key).Scan(&data)
+  } else {
+    data, err = os.ReadFile(filepath.Join(c.Dir, key))
+  }
+  if errors.Is(err, sql.ErrNoRows) || errors.Is(err, fs.ErrNotExist) {
+    return nil, autocert.ErrCacheMiss
+  }
+  if err != nil {
+    return nil, err
+  }
+
+  if !strings.HasPrefix(string(data), encryptedMarker) {
+    return data, nil
+  }
+  value, _, err := c.Keys.decrypt("acme", key, string(data))
+  if err != nil {
+    return nil, err
+  }
+
+  return []byte(value), nil
+}
+
+func (c *CertCache) Put(ctx context.Context, key string, data []byte) error {
+  if c.Keys != nil {
+    encrypted, err := c.Keys.encrypt("acme", key, string(data))
+    if err != nil {
+      return err
+    }
+    data = []byte(encrypted)
+  }
+
+  if c.DB != nil {
+    _, err := c.DB.ExecContext(ctx, "INSERT INTO acme_certs (key, data) VALUES (?, ?) ON CONFLICT (key) DO UPDATE SET data = excluded.data", key, data)
+    return err
+  }
+  if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+    return err
+  }
+
+  // Written aside and renamed, a crash halfway leaves the previous certificate.
+  path := filepath.Join(c.Dir, key)
+  if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+    return err
+  }
+
+  return os.Rename(path+".tmp", path)
+}
+
+func (c *CertCache) Delete(ctx context.Context, key string) error {
+  if c.DB != nil {
+    _, err := c.DB.ExecContext(ctx, "DELETE FROM acme_certs WHERE key = ?", key)
+    return err
+  }
+
+  if err := os.Remove(filepath.Join(c.Dir, key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+    return err
+  }
+
+  return nil
+}
@@ -0,0 +1,245 @@
+package store
+
+import (
+  "bytes"
+  "compress/gzip"
+  "encoding/base64"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "os"
+  "strings"
+  "sync"
+
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  COMPRESSING CONTENT
+
+  Most of the bytes of a blog are in the content of a few long posts, and text compresses well. CompressingStore wraps another PostStore and gzips the content of the posts longer than Threshold on the way in, and gunzips it on the way out, so the handlers never see the difference:
+
+    go run . -compress-content-over 4096
+
+  Content is a string in blog.proto, and both backends store it as text, so the compressed bytes are base64 encoded behind a marker no post starts with, a NUL byte followed by "gzip". base64 makes the bytes a third bigger again, so content that doesn't shrink enough to make up for it is kept as it is.
+
+  Load decompresses whatever carries the marker whatever the threshold, so changing it, or setting it back to 0 to stop compressing, never makes posts unreadable: the next save writes every post as the new threshold says.
+
+  Stats tells how much is saved, the server exposes it through the Admin service.
+*/
+const compressedMarker = "\x00gzip:"
+
+type CompressingStore struct {
+  PostStore
+  // Threshold is the content length in bytes from which content is compressed, 0 never compresses.
+  Threshold int
+
+  mu    sync.Mutex
+  stats ContentStats
+}
+
+// ContentStats describes the content of the posts as of the last load or save.
+type ContentStats struct {
+  Posts           int
+  CompressedPosts int
+  // ContentBytes is the size of the content, StoredBytes what it takes once compressed.
+  ContentBytes int64
+  StoredBytes  int64
+}
+
+func (s *CompressingStore) Load() ([]*pb.Post, error) {
+  posts, err := s.PostStore.Load()
+  if err != nil {
+    return nil, err
+  }
+
+  stats := ContentStats{Posts: len(posts)}
+  for _, post := range posts {
+    stats.StoredBytes += int64(len(post.Content))
+
+    if strings.HasPrefix(post.Content, compressedMarker) {
+      content, err := decompressContent(post.Content)
+      if err != nil {
+        return nil, fmt.Errorf("failed to decompress the content of post %s: %w", post.Id, err)
+      }
+      post.Content = content
+      stats.CompressedPosts++
+    }
+    stats.ContentBytes += int64(len(post.Content))
+  }
+  s.setStats(stats)
+
+  return posts, nil
+}
+
+// Reader decompresses the posts one at a time. The stats are left alone, they describe the posts as of the last Load or Save.
+func (s *CompressingStore) Reader() (PostReader, error) {
+  r, err := OpenReader(s.PostStore)
+  if err != nil {
+    return nil, err
+  }
+
+  return &decompressingReader{PostReader: r}, nil
+}
+
+type decompressingReader struct {
+  PostReader
+}
+
+func (r *decompressingReader) Next() (*pb.Post, error) {
+  post, err := r.PostReader.Next()
+  if err != nil || !strings.HasPrefix(post.Content, compressedMarker) {
+    return post, err
+  }
+
+  content, err := decompressContent(post.Content)
+  if err != nil {
+    return nil, fmt.Errorf("failed to decompress the content of post %s: %w", post.Id, err)
+  }
+  post.Content = content
+
+  return post, nil
+}
+
+// Lookup finds the posts through the index of the backend, when it has one, see index.go
+func (s *CompressingStore) Lookup(q Query) (PostReader, error) {
+  r, err := Lookup(s.PostStore, q)
+  if err != nil {
+    return nil, err
+  }
+
+  return &decompressingReader{PostReader: r}, nil
+}
+
+// Salvage decompresses what the backend could salvage, content that doesn't decompress makes its post corrupt.
+func (s *CompressingStore) Salvage() ([]*pb.Post, []CorruptEntry, error) {
+  salvager, ok := s.PostStore.(Salvager)
+  if !ok {
+    return nil, nil, fmt.Errorf("the storage can't salvage posts")
+  }
+
+  posts, corrupt, err := salvager.Salvage()
+  if err != nil {
+    return nil, nil, err
+  }
+
+  readable := make([]*pb.Post, 0, len(posts))
+  for _, post := range posts {
+    if strings.HasPrefix(post.Content, compressedMarker) {
+      content, err := decompressContent(post.Content)
+      if err != nil {
+        corrupt = append(corrupt, CorruptEntry{Where: "post " + post.Id, Err: "failed to decompress the content: " + err.Error(), Raw: post.Content})
+        continue
+      }
+      post.Content = content
+    }
+    readable = append(readable, post)
+  }
+
+  return readable, corrupt, nil
+}
+
+// Save leaves the posts of the caller alone, the ones with compressed content are copies.
+func (s *CompressingStore) Save(posts []*pb.Post) error {
+  stored := make([]*pb.Post, len(posts))
+  stats := ContentStats{Posts: len(posts)}
+  for i, post := range posts {
+    stored[i] = post
+    stats.ContentBytes += int64(len(post.Content))
+
+    // Content that happens to start with the marker is always compressed, otherwise Load would take it for compressed content.
+    if (s.Threshold > 0 && len(post.Content) >= s.Threshold) || strings.HasPrefix(post.Content, compressedMarker) {
+      content, err := compressContent(post.Content)
+      if err != nil {
+        return fmt.Errorf("failed to compress the content of post %s: %w", post.Id, err)
+      }
+      if len(content) < len(post.Content) || strings.HasPrefix(post.Content, compressedMarker) {
+        stored[i] = proto.Clone(post).(*pb.Post)
+        stored[i].Content = content
+        stats.CompressedPosts++
+      }
+    }
+    stats.StoredBytes += int64(len(stored[i].Content))
+  }
+
+  if err := s.PostStore.Save(stored); err != nil {
+    return err
+  }
+  s.setStats(stats)
+
+  return nil
+}
+
+func (s *CompressingStore) setStats(stats ContentStats) {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.stats = stats
+}
+
+func (s *CompressingStore) Stats() ContentStats {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  return s.stats
+}
+
+func compressContent(content string) (string, error) {
+  var buf bytes.Buffer
+  zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+  if err != nil {
+    return "", err
+  }
+  if _, err := io.WriteString(zw, content); err != nil {
+    return "", err
+  }
+  if err := zw.Close(); err != nil {
+    return "", err
+  }
+
+  return compressedMarker + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func decompressContent(stored string) (string, error) {
+  data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, compressedMarker))
+  if err != nil {
+    return "", err
+  }
+
+  zr, err := gzip.NewReader(bytes.NewReader(data))
+  if err != nil {
+    return "", err
+  }
+  content, err := io.ReadAll(zr)
+  if err != nil {
+    return "", err
+  }
+
+  return string(content), nil
+}
+
+// Size returns how many bytes the backend takes on disk, for the backends that know.
+func Size(s PostStore) (int64, error) {
+  switch s := s.(type) {
+  case *CompressingStore:
+    return Size(s.PostStore)
+  case *EncryptingStore:
+    return Size(s.PostStore)
+  case *FileStore:
+    info, err := os.Stat(s.Path)
+    if err != nil {
+      return 0, err
+    }
+    return info.Size(), nil
+  case *SQLiteStore:
+    var size int64
+    err := s.DB.QueryRow("SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()").Scan(&size)
+    return size, err
+  case *PostgresStore:
+    var size int64
+    err := s.DB.QueryRow("SELECT pg_total_relation_size('posts')").Scan(&size)
+    return size, err
+  }
+
+  return 0, fmt.Errorf("unknown storage size")
+}
@@ -0,0 +1,364 @@
+package store
+
+import (
+  "crypto/aes"
+  "crypto/cipher"
+  "crypto/rand"
+  "encoding/base64"
+  "encoding/hex"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "strings"
+  "sync"
+
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  ENCRYPTION AT REST
+
+  posts.json and blog.db are only as private as the disk they are on, and the backups made of it. EncryptingStore wraps a backend like CompressingStore does and encrypts the title and the content of the posts with AES-256-GCM on the way in, decrypting them on the way out. It sits under CompressingStore: encrypted bytes look random and don't compress, so content is compressed first.
+
+  The rest of a post stays in clear, the indexes of the backends need the authors, the tags and the slugs to find posts (see index.go), and the scheduler the status and the publishing times. Slugs are made from titles, so a title can be guessed from its slug.
+
+  Like compressed content an encrypted field is text behind a marker, a NUL byte followed by "aesgcm:", then the ID of the key and the base64 of the nonce and the sealed bytes:
+
+    \x00aesgcm:2025-06:q83v...
+
+  The ID of the post and the name of the field are authenticated along, so an encrypted title can't be moved into the content, or into another post, without failing to decrypt.
+
+  KEYS AND ROTATION
+
+  A Keyring holds the keys, 32 bytes each, written as <ID>:<hex> and separated by commas or new lines. The first key encrypts, every key decrypts:
+
+    BLOG_ENCRYPTION_KEYS=2025-06:4f1a...,2025-01:9c3b...
+
+  Rotating a key is putting the new one first and keeping the old one behind it: the posts encrypted with the old key still load, and every save writes them with the new one. ReencryptStorage (see the server) saves them all right away, after which the old key can go. A post encrypted with a key that isn't in the keyring fails to load, like a corrupt one.
+
+  Without a keyring the posts are saved in clear, and the encrypted ones fail to load. Stats tells how many posts are encrypted with which key.
+
+  The revisions of the posts (see revisions.go at the root) hold old titles and contents, EncryptRevision and DecryptRevision encrypt them with the same keys.
+*/
+const encryptedMarker = "\x00aesgcm:"
+
+// Keyring holds the keys content is encrypted with, see above.
+type Keyring struct {
+  ids   []string
+  aeads map[string]cipher.AEAD
+}
+
+// ParseKeyring reads keys written as <ID>:<hex>, separated by commas or new lines. The first one is the current key.
+func ParseKeyring(text string) (*Keyring, error) {
+  k := &Keyring{aeads: make(map[string]cipher.AEAD)}
+  for _, entry := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == '\n' }) {
+    entry = strings.TrimSpace(entry)
+    if entry == "" {
+      continue
+    }
+    id, hexKey, ok := strings.Cut(entry, ":")
+    if !ok || id == "" {
+      return nil, fmt.Errorf("encryption keys must be written <ID>:<hex>")
+    }
+    if _, ok := k.aeads[id]; ok {
+      return nil, fmt.Errorf("encryption key %q is given twice", id)
+    }
+    key, err := hex.DecodeString(hexKey)
+    if err != nil || len(key) != 32 {
+      return nil, fmt.Errorf("encryption key %q must be 32 hex encoded bytes", id)
+    }
+    block, err := aes.NewCipher(key)
+    if err != nil {
+      return nil, err
+    }
+    aead, err := cipher.NewGCM(block)
+    if err != nil {
+      return nil, err
+    }
+    k.ids = append(k.ids, id)
+    k.aeads[id] = aead
+  }
+  if len(k.ids) == 0 {
+    return nil, fmt.Errorf("no encryption key given")
+  }
+
+  return k, nil
+}
+
+// Current returns the ID of the key new content is encrypted with.
+func (k *Keyring) Current() string {
+  return k.ids[0]
+}
+
+func (k *Keyring) encrypt(postID, field, value string) (string, error) {
+  aead := k.aeads[k.Current()]
+  nonce := make([]byte, aead.NonceSize())
+  if _, err := rand.Read(nonce); err != nil {
+    return "", err
+  }
+  sealed := aead.Seal(nonce, nonce, []byte(value), []byte(postID+"/"+field))
+
+  return encryptedMarker + k.Current() + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt returns the value of an encrypted field and the ID of its key.
+func (k *Keyring) decrypt(postID, field, stored string) (string, string, error) {
+  id, encoded, ok := strings.Cut(strings.TrimPrefix(stored, encryptedMarker), ":")
+  if !ok {
+    return "", "", fmt.Errorf("malformed encrypted %s", field)
+  }
+  if k == nil {
+    return "", id, fmt.Errorf("the %s is encrypted and no encryption key is given", field)
+  }
+  aead, ok := k.aeads[id]
+  if !ok {
+    return "", id, fmt.Errorf("the %s is encrypted with key %q, which isn't in the keyring", field, id)
+  }
+  sealed, err := base64.StdEncoding.DecodeString(encoded)
+  if err != nil || len(sealed) < aead.NonceSize() {
+    return "", id, fmt.Errorf("malformed encrypted %s", field)
+  }
+  value, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(postID+"/"+field))
+  if err != nil {
+    return "", id, fmt.Errorf("failed to decrypt the %s: %w", field, err)
+  }
+
+  return string(value), id, nil
+}
+
+// EncryptRevision encrypts the title and the content of a revision of the post in place, a nil Keyring leaves them in clear. The number of the revision is authenticated along with the ID of the post, so revisions can't be swapped either.
+func (k *Keyring) EncryptRevision(postID string, revision *pb.Revision) error {
+  if k == nil {
+    return nil
+  }
+  for _, field := range revisionFields(revision) {
+    if *field.value == "" {
+      continue
+    }
+    encrypted, err := k.encrypt(postID, field.name, *field.value)
+    if err != nil {
+      return fmt.Errorf("failed to encrypt the %s of post %s: %w", field.name, postID, err)
+    }
+    *field.value = encrypted
+  }
+
+  return nil
+}
+
+// DecryptRevision decrypts a revision encrypted by EncryptRevision in place, the fields in clear are left alone.
+func (k *Keyring) DecryptRevision(postID string, revision *pb.Revision) error {
+  for _, field := range revisionFields(revision) {
+    if !strings.HasPrefix(*field.value, encryptedMarker) {
+      continue
+    }
+    value, _, err := k.decrypt(postID, field.name, *field.value)
+    if err != nil {
+      return fmt.Errorf("post %s: %w", postID, err)
+    }
+    *field.value = value
+  }
+
+  return nil
+}
+
+type revisionField struct {
+  name  string
+  value *string
+}
+
+func revisionFields(revision *pb.Revision) []revisionField {
+  n := fmt.Sprint(revision.Number)
+
+  return []revisionField{{"title of revision " + n, &revision.Title}, {"content of revision " + n, &revision.Content}}
+}
+
+type EncryptingStore struct {
+  PostStore
+  // Keys nil saves the posts in clear, see above.
+  Keys *Keyring
+
+  mu    sync.Mutex
+  stats EncryptionStats
+}
+
+// EncryptionStats describes the posts as of the last load or save.
+type EncryptionStats struct {
+  // ByKey[ID] is how many posts are encrypted with the key.
+  ByKey map[string]int
+}
+
+// Encrypted returns how many posts are encrypted, whatever the key.
+func (s EncryptionStats) Encrypted() int {
+  var n int
+  for _, posts := range s.ByKey {
+    n += posts
+  }
+
+  return n
+}
+
+// decryptPost decrypts the fields of the post in place and returns the ID of their key, empty when the post is in clear.
+func (s *EncryptingStore) decryptPost(post *pb.Post) (string, error) {
+  var key string
+  for _, field := range []struct {
+    name  string
+    value *string
+  }{{"title", &post.Title}, {"content", &post.Content}} {
+    if !strings.HasPrefix(*field.value, encryptedMarker) {
+      continue
+    }
+    value, id, err := s.Keys.decrypt(post.Id, field.name, *field.value)
+    if err != nil {
+      return "", fmt.Errorf("post %s: %w", post.Id, err)
+    }
+    *field.value, key = value, id
+  }
+
+  return key, nil
+}
+
+func (s *EncryptingStore) Load() ([]*pb.Post, error) {
+  posts, err := s.PostStore.Load()
+  if err != nil {
+    return nil, err
+  }
+
+  stats := EncryptionStats{ByKey: make(map[string]int)}
+  for _, post := range posts {
+    key, err := s.decryptPost(post)
+    if err != nil {
+      return nil, err
+    }
+    if key != "" {
+      stats.ByKey[key]++
+    }
+  }
+  s.setStats(stats)
+
+  return posts, nil
+}
+
+// Reader decrypts the posts one at a time, leaving the stats alone like CompressingStore.
+func (s *EncryptingStore) Reader() (PostReader, error) {
+  r, err := OpenReader(s.PostStore)
+  if err != nil {
+    return nil, err
+  }
+
+  return &decryptingReader{PostReader: r, store: s}, nil
+}
+
+func (s *EncryptingStore) Lookup(q Query) (PostReader, error) {
+  r, err := Lookup(s.PostStore, q)
+  if err != nil {
+    return nil, err
+  }
+
+  return &decryptingReader{PostReader: r, store: s}, nil
+}
+
+type decryptingReader struct {
+  PostReader
+  store *EncryptingStore
+}
+
+func (r *decryptingReader) Next() (*pb.Post, error) {
+  post, err := r.PostReader.Next()
+  if err != nil {
+    return nil, err
+  }
+  if _, err := r.store.decryptPost(post); err != nil {
+    return nil, err
+  }
+
+  return post, nil
+}
+
+// Salvage decrypts what the backend could salvage, a post that doesn't decrypt is corrupt.
+func (s *EncryptingStore) Salvage() ([]*pb.Post, []CorruptEntry, error) {
+  salvager, ok := s.PostStore.(Salvager)
+  if !ok {
+    return nil, nil, fmt.Errorf("the storage can't salvage posts")
+  }
+
+  posts, corrupt, err := salvager.Salvage()
+  if err != nil {
+    return nil, nil, err
+  }
+
+  readable := make([]*pb.Post, 0, len(posts))
+  for _, post := range posts {
+    raw := post.Content
+    if _, err := s.decryptPost(post); err != nil {
+      corrupt = append(corrupt, CorruptEntry{Where: "post " + post.Id, Err: err.Error(), Raw: raw})
+      continue
+    }
+    readable = append(readable, post)
+  }
+
+  return readable, corrupt, nil
+}
+
+// Save encrypts copies of the posts, the posts of the caller stay in clear. Posts without an ID yet are saved in clear, the ID is part of what is authenticated.
+func (s *EncryptingStore) Save(posts []*pb.Post) error {
+  stats := EncryptionStats{ByKey: make(map[string]int)}
+  if s.Keys == nil {
+    if err := s.PostStore.Save(posts); err != nil {
+      return err
+    }
+    s.setStats(stats)
+    return nil
+  }
+
+  stored := make([]*pb.Post, len(posts))
+  for i, post := range posts {
+    stored[i] = post
+    if post.Id == "" || (post.Title == "" && post.Content == "") {
+      continue
+    }
+
+    encrypted := proto.Clone(post).(*pb.Post)
+    var err error
+    if encrypted.Title, err = s.encryptField(post.Id, "title", post.Title); err != nil {
+      return err
+    }
+    if encrypted.Content, err = s.encryptField(post.Id, "content", post.Content); err != nil {
+      return err
+    }
+    stored[i] = encrypted
+    stats.ByKey[s.Keys.Current()]++
+  }
+
+  if err := s.PostStore.Save(stored); err != nil {
+    return err
+  }
+  s.setStats(stats)
+
+  return nil
+}
+
+// encryptField leaves empty fields empty, there is nothing to hide in them.
+func (s *EncryptingStore) encryptField(postID, field, value string) (string, error) {
+  if value == "" {
+    return "", nil
+  }
+  encrypted, err := s.Keys.encrypt(postID, field, value)
+  if err != nil {
+    return "", fmt.Errorf("failed to encrypt the %s of post %s: %w", field, postID, err)
+  }
+
+  return encrypted, nil
+}
+
+func (s *EncryptingStore) setStats(stats EncryptionStats) {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.stats = stats
+}
+
+func (s *EncryptingStore) Stats() EncryptionStats {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  return s.stats
+}
@@ -0,0 +1,357 @@
+package store
+
+import (
+  "bufio"
+  "bytes"
+  "compress/gzip"
+  "encoding/json"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "os"
+  "slices"
+  "sync"
+)
+
+/*
+  FILE FORMATS
+
+  FileStore keeps every post in a single file. By default that's the indented JSON array this tutorial started with, which is easy to read and edit by hand, but -file-format picks something else:
+    - json: an indented JSON array
+    - compact: the same array without the whitespace, noticeably smaller once posts pile up
+    - ndjson: newline delimited JSON, one post per line. Tools like jq, grep or tail work on it line by line and new posts can be added by appending a line
+  and -file-gzip compresses any of them.
+
+  Format only controls how the file is written. Load looks at the file itself: gzip files start with the bytes 0x1f 0x8b, and a JSON array starts with [ while NDJSON starts with the { of its first post. So switching formats is a matter of restarting the server with the new flags, the next save rewrites the file in the new format.
+*/
+const (
+  FormatJSON    = "json"
+  FormatCompact = "compact"
+  FormatNDJSON  = "ndjson"
+)
+
+// FileFormats lists the formats FileStore can write.
+var FileFormats = []string{FormatJSON, FormatCompact, FormatNDJSON}
+
+type FileStore struct {
+  Path string
+  // Format is one of FileFormats, empty means FormatJSON.
+  Format string
+  Gzip   bool
+  // Background runs the rebuilds of the index off the lookups, when it returns true. Nil rebuilds during the lookup. See index.go
+  Background func(task func()) bool
+
+  // saving makes the saves take turns: they write the same temporary file, and the index of one must not end up next to the file of another.
+  saving sync.Mutex
+  // mu guards index, the index of the file as of the last Save or Lookup, and rebuilding. See index.go
+  mu         sync.Mutex
+  index      *fileIndex
+  rebuilding bool
+}
+
+func (s *FileStore) Load() ([]*pb.Post, error) {
+  r, err := s.Reader()
+  if err != nil {
+    return nil, err
+  }
+  defer r.Close()
+
+  return ReadAll(r)
+}
+
+/*
+  READING ONE POST AT A TIME
+
+  Reader decodes the posts straight from the file with a json.Decoder, one post at a time, so going through the posts only ever holds one of them in memory, plus the buffer of the decoder. It is how StreamPosts and filtered GetPosts read big stores, see OpenReader in reader.go. A json.Decoder reads each post twice, once to find where it ends and once to decode it, so it takes more time than a json.Unmarshal of the whole file: memory that stays flat whatever the size of the file is worth it.
+
+  Save writes a new file and renames it over the old one, so a Reader opened before a save keeps reading the posts as they were when it was opened, the file it has open is not the one being written.
+*/
+func (s *FileStore) Reader() (PostReader, error) {
+  r, err := s.open()
+  if err != nil {
+    return nil, err
+  }
+
+  // A JSON array starts with [, NDJSON with the { of its first post. An empty file is an NDJSON file without posts.
+  tok, err := r.dec.Token()
+  switch {
+  case err == io.EOF:
+    r.done = true
+  case err != nil:
+    r.Close()
+    return nil, fmt.Errorf("failed to parse post data: %w", err)
+  case tok == nil:
+    // null, which is how encoding/json writes a nil slice of posts.
+    r.done = true
+  case tok == json.Delim('['):
+    r.array = true
+  case tok == json.Delim('{'):
+    // Token consumed the { of the first post, which can't be decoded without it. Starting over only costs the first few bytes.
+    r.Close()
+    return s.open()
+  default:
+    r.Close()
+    return nil, fmt.Errorf("failed to parse post data: unexpected %v", tok)
+  }
+
+  return r, nil
+}
+
+// open returns a reader decoding the file from its first byte.
+func (s *FileStore) open() (*fileReader, error) {
+  file, err := os.Open(s.Path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read posts file: %w", err)
+  }
+
+  r := &fileReader{file: file, buffered: getReader(file)}
+  if magic, _ := r.buffered.Peek(2); !bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+    r.dec = json.NewDecoder(r.buffered)
+    return r, nil
+  }
+
+  zr, err := gzip.NewReader(r.buffered)
+  if err != nil {
+    r.Close()
+    return nil, fmt.Errorf("failed to decompress posts file: %w", err)
+  }
+  r.dec = json.NewDecoder(zr)
+
+  return r, nil
+}
+
+type fileReader struct {
+  file     *os.File
+  buffered *bufio.Reader
+  dec      *json.Decoder
+  // array is set for a JSON array, which must end with its ].
+  array bool
+  done  bool
+}
+
+// Next returns the next post, decoded into a released post when there is one (see pool.go), and io.EOF after the last one.
+func (r *fileReader) Next() (*pb.Post, error) {
+  if r.done {
+    return nil, io.EOF
+  }
+  if !r.dec.More() {
+    r.done = true
+    if tok, err := r.dec.Token(); r.array && (err != nil || tok != json.Delim(']')) {
+      return nil, fmt.Errorf("failed to parse post data: the array of posts doesn't end with ]")
+    }
+    return nil, io.EOF
+  }
+
+  post := newPost()
+  if err := r.dec.Decode(post); err != nil {
+    Release(post)
+    return nil, fmt.Errorf("failed to parse post data: %w", err)
+  }
+
+  return post, nil
+}
+
+func (r *fileReader) Close() error {
+  if r.buffered != nil {
+    putReader(r.buffered)
+    r.buffered = nil
+  }
+
+  return r.file.Close()
+}
+
+// read returns the contents of the file, decompressed.
+func (s *FileStore) read() ([]byte, error) {
+  data, err := os.ReadFile(s.Path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read posts file: %w", err)
+  }
+
+  if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+    zr, err := gzip.NewReader(bytes.NewReader(data))
+    if err != nil {
+      return nil, fmt.Errorf("failed to decompress posts file: %w", err)
+    }
+    if data, err = io.ReadAll(zr); err != nil {
+      return nil, fmt.Errorf("failed to decompress posts file: %w", err)
+    }
+  }
+
+  return data, nil
+}
+
+/*
+  Salvage reads the posts one at a time, so a post that doesn't parse only loses that post, where Load gives up on the whole file. A post is corrupt when it is valid JSON that isn't a post, like a ViewCount of "lots", and is simply skipped. Broken JSON, like a file cut short by a full disk, can't be read past: the posts before it are kept and the rest of the file becomes a single corrupt entry. NDJSON fares better, every line is read on its own.
+*/
+func (s *FileStore) Salvage() ([]*pb.Post, []CorruptEntry, error) {
+  data, err := s.read()
+  if err != nil {
+    return nil, nil, err
+  }
+
+  var posts []*pb.Post
+  var corrupt []CorruptEntry
+  decode := func(where string, raw []byte) {
+    post := &pb.Post{}
+    if err := json.Unmarshal(raw, post); err != nil {
+      corrupt = append(corrupt, CorruptEntry{Where: where, Raw: string(raw), Err: err.Error()})
+      return
+    }
+    posts = append(posts, post)
+  }
+
+  trimmed := bytes.TrimSpace(data)
+  if len(trimmed) == 0 || trimmed[0] == '{' {
+    for i, line := range bytes.Split(trimmed, []byte("\n")) {
+      if line = bytes.TrimSpace(line); len(line) > 0 {
+        decode(fmt.Sprintf("line %d", i+1), line)
+      }
+    }
+    return posts, corrupt, nil
+  }
+
+  dec := json.NewDecoder(bytes.NewReader(trimmed))
+  if _, err := dec.Token(); err != nil {
+    return nil, append(corrupt, CorruptEntry{Where: "byte 0", Raw: string(trimmed), Err: err.Error()}), nil
+  }
+  for i := 0; dec.More(); i++ {
+    offset := dec.InputOffset()
+    var raw json.RawMessage
+    if err := dec.Decode(&raw); err != nil {
+      corrupt = append(corrupt, CorruptEntry{Where: fmt.Sprintf("post %d, byte %d", i+1, offset), Raw: string(trimmed[offset:]), Err: err.Error()})
+      break
+    }
+    decode(fmt.Sprintf("post %d, byte %d", i+1, offset), raw)
+  }
+
+  return posts, corrupt, nil
+}
+
+// Save writes the index of the file along with it, see index.go
+func (s *FileStore) Save(posts []*pb.Post) error {
+  var buf bytes.Buffer
+  var w io.Writer = &buf
+
+  var zw *gzip.Writer
+  if s.Gzip {
+    zw = gzip.NewWriter(&buf)
+    w = zw
+  }
+
+  entries, err := s.encode(w, posts)
+  if err != nil {
+    return err
+  }
+
+  if zw != nil {
+    if err := zw.Close(); err != nil {
+      return err
+    }
+  }
+
+  s.saving.Lock()
+  defer s.saving.Unlock()
+
+  // Written next to the file and renamed over it: a crash halfway through leaves the previous version in place, and the readers that have it open keep reading it, see Reader.
+  if err := os.WriteFile(s.Path+".tmp", buf.Bytes(), 0644); err != nil {
+    return err
+  }
+  if err := os.Rename(s.Path+".tmp", s.Path); err != nil {
+    return err
+  }
+
+  return s.saveIndex(entries)
+}
+
+// encode writes the posts one by one, and returns where each one is for the index.
+func (s *FileStore) encode(w io.Writer, posts []*pb.Post) ([]indexEntry, error) {
+  cw := &countingWriter{w: w}
+  entries := make([]indexEntry, 0, len(posts))
+  var err error
+  // write keeps the first error, the ones after it are the same one.
+  write := func(data []byte) {
+    if err == nil {
+      _, err = cw.Write(data)
+    }
+  }
+  writePost := func(data []byte, post *pb.Post) {
+    entries = append(entries, indexEntry{Offset: cw.n, Length: int64(len(data)), ID: post.Id, Author: post.Author, Tags: slices.Clone(post.Tags)})
+    write(data)
+  }
+
+  switch s.Format {
+  case "", FormatJSON:
+    // The same bytes as json.MarshalIndent(posts, "", "  "), a post at a time.
+    if len(posts) == 0 {
+      write(emptyArray(posts))
+      return entries, err
+    }
+    write([]byte("[\n"))
+    for i, post := range posts {
+      data, merr := json.MarshalIndent(post, "  ", "  ")
+      if merr != nil {
+        return nil, merr
+      }
+      write([]byte("  "))
+      writePost(data, post)
+      if i < len(posts)-1 {
+        write([]byte(",\n"))
+      } else {
+        write([]byte("\n"))
+      }
+    }
+    write([]byte("]"))
+  case FormatCompact:
+    // The same bytes as json.Marshal(posts).
+    if len(posts) == 0 {
+      write(emptyArray(posts))
+      return entries, err
+    }
+    write([]byte("["))
+    for i, post := range posts {
+      data, merr := json.Marshal(post)
+      if merr != nil {
+        return nil, merr
+      }
+      if i > 0 {
+        write([]byte(","))
+      }
+      writePost(data, post)
+    }
+    write([]byte("]"))
+  case FormatNDJSON:
+    for _, post := range posts {
+      data, merr := json.Marshal(post)
+      if merr != nil {
+        return nil, merr
+      }
+      writePost(data, post)
+      write([]byte("\n"))
+    }
+  default:
+    return nil, fmt.Errorf("unknown file format %q", s.Format)
+  }
+
+  return entries, err
+}
+
+// emptyArray is how encoding/json writes a slice without posts.
+func emptyArray(posts []*pb.Post) []byte {
+  if posts == nil {
+    return []byte("null")
+  }
+
+  return []byte("[]")
+}
+
+type countingWriter struct {
+  w io.Writer
+  n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+  n, err := c.w.Write(p)
+  c.n += int64(n)
+
+  return n, err
+}
@@ -0,0 +1,21 @@
+package store
+
+import (
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+)
+
+/*
+  SALVAGING POSTS
+
+  Load is all or nothing: a single post that can't be read fails the whole load, which is right while serving, a handler shouldn't save the posts it could read and lose the others. The server's -fsck mode (see fsck.go at the root) needs the opposite, every post that can be read and a list of the ones that can't, and that is what Salvager returns.
+*/
+type Salvager interface {
+  Salvage() ([]*pb.Post, []CorruptEntry, error)
+}
+
+// CorruptEntry is something the backend couldn't read: Where tells where it is in the storage, Raw is what could be read of it.
+type CorruptEntry struct {
+  Where string `json:"where"`
+  Err   string `json:"error"`
+  Raw   string `json:"raw,omitempty"`
+}
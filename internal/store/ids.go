@@ -0,0 +1,187 @@
+package store
+
+import (
+  "crypto/rand"
+  "encoding/binary"
+  "fmt"
+  "log"
+  "os"
+  "strconv"
+  "strings"
+  "sync"
+  "time"
+)
+
+/*
+  IDS
+
+  Posts, comments, attachments, bans and the rest get their ID from NewID, which asks the IDGenerator the server was started with:
+
+    go run . -ids uuid          3b241101-e2bb-4255-8caf-4136c566a962, random, the default
+    go run . -ids ulid          01JWX2PDMA7N2K4M6P8R0T2V4W, the time they were made first, then random
+    go run . -ids sequential    1, 2, 3... counted in ids.seq, for demos and tests
+
+  A ULID sorts in the order the IDs were made. posts.json keeps the posts in the order they were created, so with ULIDs its index (see index.go) is sorted by ID too, and a lookup by ID finds its entry by a binary search instead of going through all of them. The posts looked up the most are the recent ones, they sit together at the end of the index and of the file. The posts made before switching to ULIDs keep their UUIDs, the index of a file mixing both isn't sorted and lookups go through the entries like before.
+
+  Sequential IDs are short enough to type, which is all they are good for: they tell how many posts the blog has and are easy to guess. The count is kept in a file so a restarted server doesn't give an ID twice.
+
+  The IDs already given never change, a server can switch between the generators at any time.
+*/
+type IDGenerator interface {
+  NewID() string
+}
+
+// IDs is the generator of NewID. It is set once, before the server starts.
+var IDs IDGenerator = UUIDs{}
+
+// NewID returns a new ID from IDs.
+func NewID() string {
+  return IDs.NewID()
+}
+
+// UUIDs generates random (version 4) UUIDs such as 3b241101-e2bb-4255-8caf-4136c566a962
+type UUIDs struct{}
+
+func (UUIDs) NewID() string {
+  b := make([]byte, 16)
+  rand.Read(b)
+  b[6] = (b[6] & 0x0f) | 0x40
+  b[8] = (b[8] & 0x3f) | 0x80
+
+  return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// crockford is the alphabet of ULIDs, Crockford's base32: no I, L, O or U to be misread.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+/*
+  ULIDs generates ULIDs (https://github.com/ulid/spec): 48 bits of milliseconds since 1970 followed by 80 random bits, written as 26 characters of base32 which sort like the time.
+
+  The IDs made in the same millisecond add one to the random bits of the previous one instead of drawing new ones, so they still sort in the order they were made.
+*/
+type ULIDs struct {
+  // Now is the time of the IDs, time.Now when nil.
+  Now func() time.Time
+
+  mu     sync.Mutex
+  ms     uint64
+  random [10]byte
+}
+
+func (u *ULIDs) NewID() string {
+  now := time.Now
+  if u.Now != nil {
+    now = u.Now
+  }
+  ms := uint64(now().UnixMilli())
+
+  u.mu.Lock()
+  defer u.mu.Unlock()
+
+  if ms > u.ms {
+    u.ms = ms
+    rand.Read(u.random[:])
+  } else if !increment(u.random[:]) {
+    // 2^80 IDs in a millisecond, or a clock set back: the next millisecond keeps them in order.
+    u.ms++
+    rand.Read(u.random[:])
+  }
+
+  var b [16]byte
+  binary.BigEndian.PutUint64(b[:8], u.ms<<16)
+  copy(b[6:], u.random[:])
+
+  return encodeULID(b)
+}
+
+// increment adds one to the big endian number of b and reports false when it overflowed.
+func increment(b []byte) bool {
+  for i := len(b) - 1; i >= 0; i-- {
+    b[i]++
+    if b[i] != 0 {
+      return true
+    }
+  }
+
+  return false
+}
+
+// encodeULID writes the 128 bits of b as 26 characters of 5 bits, the first one only has 3.
+func encodeULID(b [16]byte) string {
+  hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+
+  var out [26]byte
+  for i := len(out) - 1; i >= 0; i-- {
+    out[i] = crockford[lo&31]
+    lo = lo>>5 | hi<<59
+    hi >>= 5
+  }
+
+  return string(out[:])
+}
+
+// sequenceBlock is how many IDs Sequence counts between two writes of its file.
+const sequenceBlock = 100
+
+/*
+  Sequence generates the IDs 1, 2, 3... Writing the count to the file for every ID would slow down every call that makes one, request IDs included, so Sequence writes the end of a block of IDs instead and counts up to it in memory. A restarted server goes on from the end of the last block, the IDs of the block that weren't given are skipped.
+*/
+type Sequence struct {
+  path string
+
+  mu         sync.Mutex
+  next, last int64
+}
+
+// OpenSequence goes on with the count of the file at path, or starts one when there is no file.
+func OpenSequence(path string) (*Sequence, error) {
+  s := &Sequence{path: path}
+
+  data, err := os.ReadFile(path)
+  switch {
+  case err == nil:
+    if s.last, err = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err != nil {
+      return nil, fmt.Errorf("failed to read the ID count of %s: %w", path, err)
+    }
+  case !os.IsNotExist(err):
+    return nil, err
+  }
+  s.next = s.last + 1
+
+  // Reserving the first block now tells right away when the file can't be written.
+  if err := s.reserve(); err != nil {
+    return nil, err
+  }
+
+  return s, nil
+}
+
+func (s *Sequence) NewID() string {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  if s.next > s.last {
+    // The count goes on in memory, but the IDs given from now on could be given again after a restart.
+    if err := s.reserve(); err != nil {
+      log.Printf("failed to reserve IDs in %s: %s", s.path, err)
+    }
+  }
+  id := s.next
+  s.next++
+
+  return strconv.FormatInt(id, 10)
+}
+
+// reserve writes the end of the next block to the file, with s.mu held.
+func (s *Sequence) reserve() error {
+  last := s.next + sequenceBlock - 1
+  if err := os.WriteFile(s.path+".tmp", []byte(strconv.FormatInt(last, 10)+"\n"), 0644); err != nil {
+    return err
+  }
+  if err := os.Rename(s.path+".tmp", s.path); err != nil {
+    return err
+  }
+  s.last = last
+
+  return nil
+}
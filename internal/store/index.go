@@ -0,0 +1,375 @@
+package store
+
+import (
+  "bytes"
+  "cmp"
+  "encoding/json"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "os"
+  "slices"
+  "strings"
+)
+
+/*
+  INDEX FILES
+
+  posts.json has no order a lookup could use, so finding one post means reading the file up to it, and filtering by author or tag means reading all of it. FileStore keeps an index next to the file, posts.json.idx, with the ID, author and tags of every post and where its JSON starts and ends in the file:
+
+    {"Size": 48213, "ModTime": 1760420000000000000, "Posts": [{"Offset": 4, "Length": 512, "ID": "3b24...", "Author": "Jane McFarland", "Tags": ["go"]}, ...]}
+
+  Lookup reads the index and then only the bytes of the posts it names. When the IDs of the index are sorted, as they are with ULIDs, the posts looked up by ID are found by a binary search rather than by going through the index, see ids.go. The index is written by every Save, and records the size and modification time of the file it was written for: a file changed by anything else, like someone editing it by hand or copying an older version over it, doesn't match anymore and the index gets rebuilt from the file the next time it is needed. A missing index is rebuilt the same way. With Background set, the lookups don't wait for the rebuild: they read the whole file, like a store without an index, until the index is back.
+
+  A gzipped file can't be read from the middle, it has no index and lookups read the whole file like before. Neither does SQLite need one, it has indexes of its own.
+*/
+const indexVersion = 1
+
+// ErrNotIndexed is returned by Lookup when the store can't answer from an index, see the Lookup function.
+var ErrNotIndexed = errors.New("the posts have no index")
+
+// Query selects posts: the ones with one of the IDs, by one of the authors and with all of the tags. Empty fields select every post.
+type Query struct {
+  IDs     []string
+  Authors []string
+  Tags    []string
+}
+
+func (q Query) match(id, author string, tags []string) bool {
+  if len(q.IDs) > 0 && !slices.Contains(q.IDs, id) {
+    return false
+  }
+  if len(q.Authors) > 0 && !slices.Contains(q.Authors, author) {
+    return false
+  }
+  for _, tag := range q.Tags {
+    if !slices.Contains(tags, tag) {
+      return false
+    }
+  }
+
+  return true
+}
+
+// PostIndex is implemented by the stores that can find posts without reading all of them.
+type PostIndex interface {
+  Lookup(q Query) (PostReader, error)
+}
+
+// Lookup returns a reader of the posts matching q, in the order of the store. Stores with an index only read those posts, the others are read whole.
+func Lookup(s PostStore, q Query) (PostReader, error) {
+  if index, ok := s.(PostIndex); ok {
+    r, err := index.Lookup(q)
+    if !errors.Is(err, ErrNotIndexed) {
+      return r, err
+    }
+  }
+
+  r, err := OpenReader(s)
+  if err != nil {
+    return nil, err
+  }
+
+  return &matchingReader{PostReader: r, q: q}, nil
+}
+
+// matchingReader skips the posts that don't match its query.
+type matchingReader struct {
+  PostReader
+  q Query
+}
+
+func (r *matchingReader) Next() (*pb.Post, error) {
+  for {
+    post, err := r.PostReader.Next()
+    if err != nil || r.q.match(post.Id, post.Author, post.Tags) {
+      return post, err
+    }
+    Release(post)
+  }
+}
+
+type fileIndex struct {
+  Version int
+  // Size and ModTime (in nanoseconds) of the file the index describes.
+  Size    int64
+  ModTime int64
+  Posts   []indexEntry
+  // SortedIDs tells the IDs of Posts go up, like ULIDs do. See ids.go
+  SortedIDs bool `json:",omitempty"`
+}
+
+func newFileIndex(info os.FileInfo, entries []indexEntry) *fileIndex {
+  // Strictly, a binary search would find only one of two posts with the same ID.
+  sorted := true
+  for i := 1; sorted && i < len(entries); i++ {
+    sorted = entries[i-1].ID < entries[i].ID
+  }
+
+  return &fileIndex{Version: indexVersion, Size: info.Size(), ModTime: info.ModTime().UnixNano(), Posts: entries, SortedIDs: sorted}
+}
+
+type indexEntry struct {
+  Offset int64
+  Length int64
+  ID     string
+  Author string
+  Tags   []string `json:",omitempty"`
+}
+
+func (s *FileStore) indexPath() string {
+  return s.Path + ".idx"
+}
+
+// Lookup reads the posts matching q through the index, see above.
+func (s *FileStore) Lookup(q Query) (PostReader, error) {
+  file, err := os.Open(s.Path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read posts file: %w", err)
+  }
+
+  index, err := s.loadIndex(file)
+  if err != nil {
+    file.Close()
+    return nil, err
+  }
+
+  r := &indexReader{file: file}
+  entries := index.Posts
+  if len(q.IDs) > 0 && index.SortedIDs {
+    entries = index.find(q.IDs)
+  }
+  for _, entry := range entries {
+    if q.match(entry.ID, entry.Author, entry.Tags) {
+      r.entries = append(r.entries, entry)
+    }
+  }
+
+  return r, nil
+}
+
+// find returns the entries of the IDs in the order of the file, by binary searches of an index with SortedIDs.
+func (index *fileIndex) find(ids []string) []indexEntry {
+  var found []indexEntry
+  for _, id := range ids {
+    i, ok := slices.BinarySearchFunc(index.Posts, id, func(entry indexEntry, id string) int { return strings.Compare(entry.ID, id) })
+    if ok && !slices.ContainsFunc(found, func(entry indexEntry) bool { return entry.ID == id }) {
+      found = append(found, index.Posts[i])
+    }
+  }
+  slices.SortFunc(found, func(a, b indexEntry) int { return cmp.Compare(a.Offset, b.Offset) })
+
+  return found
+}
+
+// loadIndex returns the index of the open file, rebuilt when it is missing or doesn't match the file.
+func (s *FileStore) loadIndex(file *os.File) (*fileIndex, error) {
+  info, err := file.Stat()
+  if err != nil {
+    return nil, fmt.Errorf("failed to read posts file: %w", err)
+  }
+
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  current := func(index *fileIndex) bool {
+    return index != nil && index.Version == indexVersion && index.Size == info.Size() && index.ModTime == info.ModTime().UnixNano()
+  }
+  if current(s.index) {
+    return s.index, nil
+  }
+
+  var index *fileIndex
+  if data, err := os.ReadFile(s.indexPath()); err == nil {
+    index = &fileIndex{}
+    if json.Unmarshal(data, index) != nil {
+      index = nil
+    }
+  }
+  if !current(index) {
+    // In the background the lookups read the whole file until the index is rebuilt, rather than wait for it.
+    if s.rebuilding || s.Gzip {
+      return nil, ErrNotIndexed
+    }
+    if s.Background != nil && s.Background(s.rebuildIndex) {
+      s.rebuilding = true
+      return nil, ErrNotIndexed
+    }
+
+    index, err = s.buildIndex(file, info)
+    if err != nil {
+      return nil, err
+    }
+  }
+  s.index = index
+
+  return index, nil
+}
+
+// rebuildIndex is the background version of the rebuild of loadIndex.
+func (s *FileStore) rebuildIndex() {
+  defer func() {
+    s.mu.Lock()
+    s.rebuilding = false
+    s.mu.Unlock()
+  }()
+
+  file, err := os.Open(s.Path)
+  if err != nil {
+    return
+  }
+  defer file.Close()
+
+  info, err := file.Stat()
+  if err != nil {
+    return
+  }
+  index, err := s.buildIndex(file, info)
+  if err != nil {
+    return
+  }
+
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  // A Save while rebuilding wrote an index of its own, for a newer file.
+  if latest, err := os.Stat(s.Path); err == nil && latest.Size() == index.Size && latest.ModTime().UnixNano() == index.ModTime {
+    s.index = index
+  }
+}
+
+func (s *FileStore) buildIndex(file *os.File, info os.FileInfo) (*fileIndex, error) {
+  entries, err := scanIndex(file)
+  if err != nil {
+    return nil, err
+  }
+  index := newFileIndex(info, entries)
+  // The rebuilt index is good for this process even when it can't be written, the next one rebuilds it again.
+  writeIndex(s.indexPath(), index)
+
+  return index, nil
+}
+
+// scanIndex reads the file once to find where every post is. A gzipped file can't be indexed.
+func scanIndex(file *os.File) ([]indexEntry, error) {
+  if _, err := file.Seek(0, io.SeekStart); err != nil {
+    return nil, fmt.Errorf("failed to read posts file: %w", err)
+  }
+  br := getReader(file)
+  defer putReader(br)
+
+  if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+    return nil, ErrNotIndexed
+  }
+
+  // A JSON array of posts starts with [, NDJSON with the { of its first post, see Reader. Anything else, like an empty file or null, has no posts.
+  entries := make([]indexEntry, 0)
+  dec := json.NewDecoder(br)
+  switch first, _ := peekFirstByte(br); first {
+  case '[':
+    if _, err := dec.Token(); err != nil {
+      return nil, fmt.Errorf("failed to parse post data: %w", err)
+    }
+  case '{':
+  default:
+    return entries, nil
+  }
+
+  for dec.More() {
+    start := dec.InputOffset()
+    var raw json.RawMessage
+    if err := dec.Decode(&raw); err != nil {
+      return nil, fmt.Errorf("failed to parse post data: %w", err)
+    }
+    post := newPost()
+    if err := json.Unmarshal(raw, post); err != nil {
+      Release(post)
+      return nil, fmt.Errorf("failed to parse post data: %w", err)
+    }
+    entries = append(entries, indexEntry{Offset: start, Length: dec.InputOffset() - start, ID: post.Id, Author: post.Author, Tags: slices.Clone(post.Tags)})
+    Release(post)
+  }
+
+  return entries, nil
+}
+
+// peekFirstByte returns the first byte that isn't whitespace without consuming anything.
+func peekFirstByte(br interface{ Peek(int) ([]byte, error) }) (byte, error) {
+  for n := 1; ; n++ {
+    data, err := br.Peek(n)
+    if err != nil {
+      return 0, err
+    }
+    if c := data[n-1]; c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+      return c, nil
+    }
+  }
+}
+
+// saveIndex writes the index of the file Save just wrote.
+func (s *FileStore) saveIndex(entries []indexEntry) error {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.index = nil
+  if s.Gzip {
+    // An index left over from before the file was gzipped would be rebuilt for nothing.
+    os.Remove(s.indexPath())
+    return nil
+  }
+
+  info, err := os.Stat(s.Path)
+  if err != nil {
+    return err
+  }
+
+  s.index = newFileIndex(info, entries)
+
+  return writeIndex(s.indexPath(), s.index)
+}
+
+func writeIndex(path string, index *fileIndex) error {
+  data, err := json.Marshal(index)
+  if err != nil {
+    return err
+  }
+
+  if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+    return err
+  }
+
+  return os.Rename(path+".tmp", path)
+}
+
+// indexReader reads the posts an index lookup found, from the file opened for the lookup.
+type indexReader struct {
+  file    *os.File
+  entries []indexEntry
+  buf     []byte
+}
+
+func (r *indexReader) Next() (*pb.Post, error) {
+  if len(r.entries) == 0 {
+    return nil, io.EOF
+  }
+  entry := r.entries[0]
+  r.entries = r.entries[1:]
+
+  r.buf = slices.Grow(r.buf[:0], int(entry.Length))[:entry.Length]
+  if _, err := r.file.ReadAt(r.buf, entry.Offset); err != nil {
+    return nil, fmt.Errorf("failed to read posts file: %w", err)
+  }
+
+  // A post found by scanning starts with the comma and the spaces before it.
+  post := newPost()
+  if err := json.Unmarshal(bytes.TrimLeft(r.buf, ", \t\r\n"), post); err != nil {
+    Release(post)
+    return nil, fmt.Errorf("failed to parse post data: %w", err)
+  }
+
+  return post, nil
+}
+
+func (r *indexReader) Close() error {
+  return r.file.Close()
+}
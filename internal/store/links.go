@@ -0,0 +1,64 @@
+package store
+
+import (
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "regexp"
+  "slices"
+)
+
+/*
+  LINKS BETWEEN POSTS
+
+  Posts often point at each other: "as we saw in the first part", "see getting started". Whenever the content of a post changes the server looks for the other posts it mentions and keeps their IDs in LinkedPostIds, so GetBacklinks can answer "which posts link here" without reading every post.
+
+  A post is mentioned in one of two ways:
+    - by its ID, anywhere in the content, like a link to /posts/<id>
+    - by its slug in the path of a pretty URL, like https://blog.example.com/posts/getting-started-with-grpc or a relative /posts/getting-started-with-grpc
+
+  Only posts that exist when the content is saved are linked: a slug of a post written later, or of a deleted one, is taken as plain text. A post never links to itself. Posts saved before links were tracked get their LinkedPostIds the next time their content changes.
+*/
+var (
+  idPattern       = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+  slugLinkPattern = regexp.MustCompile(`/posts/([a-z0-9]+(?:-[a-z0-9]+)*)`)
+)
+
+// SetLinks finds the posts among posts that the content of post mentions, see above, and keeps their IDs in the order they first appear.
+func SetLinks(posts []*pb.Post, post *pb.Post) {
+  ids := make(map[string]bool, len(posts))
+  slugs := make(map[string]string, len(posts))
+  for _, other := range posts {
+    if other.Id == post.Id || other.Status == pb.PostStatus_DELETED {
+      continue
+    }
+    ids[other.Id] = true
+    if other.Slug != "" {
+      slugs[other.Slug] = other.Id
+    }
+  }
+
+  // Both kinds of mentions are collected with their offset, so the IDs come out in the order of the content.
+  type mention struct {
+    at int
+    id string
+  }
+  var mentions []mention
+  for _, at := range idPattern.FindAllStringIndex(post.Content, -1) {
+    if id := post.Content[at[0]:at[1]]; ids[id] {
+      mentions = append(mentions, mention{at[0], id})
+    }
+  }
+  for _, at := range slugLinkPattern.FindAllStringSubmatchIndex(post.Content, -1) {
+    if id, ok := slugs[post.Content[at[2]:at[3]]]; ok {
+      mentions = append(mentions, mention{at[2], id})
+    }
+  }
+  slices.SortStableFunc(mentions, func(a, b mention) int { return a.at - b.at })
+  var linked []string
+  for _, m := range mentions {
+    if !slices.Contains(linked, m.id) {
+      linked = append(linked, m.id)
+    }
+  }
+
+  post.LinkedPostIds = linked
+}
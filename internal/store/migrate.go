@@ -0,0 +1,214 @@
+package store
+
+import (
+  "database/sql"
+  "embed"
+  "fmt"
+  "log"
+  "path"
+  "sort"
+  "strconv"
+  "strings"
+)
+
+/*
+  SCHEMA MIGRATIONS
+
+  A SQL database needs its tables to exist before the first query, and their shape changes as the blog grows. Instead of asking whoever runs the server to apply the changes by hand, every change to the schema is a pair of numbered files in ./migrations:
+
+    0001_create_posts.up.sql      applies the change
+    0001_create_posts.down.sql    undoes it
+
+  The go:embed directive below compiles those files into the binary, so a deployed server doesn't need the migrations directory next to it. The schema_version table remembers the last migration applied, every migration runs in its own transaction together with the update of that version, so a failing migration leaves the database as it was.
+
+  The server brings the database up to date every time it starts. With -migrate it only migrates and exits, which is also the way back:
+
+    go run . -storage sqlite -migrate up      # apply every pending migration
+    go run . -storage sqlite -migrate down    # undo the last migration
+    go run . -storage sqlite -migrate 1       # go to exactly version 1, up or down
+
+  Migrations that have been released are never edited, a new change always gets a new number.
+
+  Postgres has migrations of its own in ./migrations/postgres, the SQL of the two databases differs for anything but the simplest tables: Postgres has real booleans and 64 bit integers have to be asked for. Its first migration creates the posts table as the SQLite migrations left it, a change to the schema gets a migration in both directories. -migrate works the same with -storage postgres.
+*/
+
+//go:embed migrations/*.sql migrations/postgres/*.sql
+var migrationFiles embed.FS
+
+// A dialect is what the migrations need to know of a kind of database.
+type dialect struct {
+  dir string
+  // setVersion records the version in schema_version, with the placeholder of the driver.
+  setVersion string
+}
+
+var (
+  sqliteDialect   = dialect{dir: "migrations", setVersion: "INSERT INTO schema_version (version) VALUES (?)"}
+  postgresDialect = dialect{dir: "migrations/postgres", setVersion: "INSERT INTO schema_version (version) VALUES ($1)"}
+)
+
+type migration struct {
+  version int
+  name    string
+  up      string
+  down    string
+}
+
+// loadMigrations reads the embedded files of dir and returns them sorted by version.
+func loadMigrations(dir string) ([]migration, error) {
+  entries, err := migrationFiles.ReadDir(dir)
+  if err != nil {
+    return nil, err
+  }
+
+  byVersion := make(map[int]*migration)
+  for _, entry := range entries {
+    if entry.IsDir() {
+      continue
+    }
+
+    // 0001_create_posts.up.sql -> "0001", "create_posts", "up"
+    base := strings.TrimSuffix(entry.Name(), ".sql")
+    ext := path.Ext(base)
+    number, name, ok := strings.Cut(strings.TrimSuffix(base, ext), "_")
+    version, err := strconv.Atoi(number)
+    if !ok || err != nil || version <= 0 {
+      return nil, fmt.Errorf("migration %s: name must look like 0001_description.up.sql", entry.Name())
+    }
+
+    data, err := migrationFiles.ReadFile(path.Join(dir, entry.Name()))
+    if err != nil {
+      return nil, err
+    }
+
+    m, ok := byVersion[version]
+    if !ok {
+      m = &migration{version: version, name: name}
+      byVersion[version] = m
+    }
+
+    switch ext {
+    case ".up":
+      m.up = string(data)
+    case ".down":
+      m.down = string(data)
+    default:
+      return nil, fmt.Errorf("migration %s: must end in .up.sql or .down.sql", entry.Name())
+    }
+  }
+
+  migrations := make([]migration, 0, len(byVersion))
+  for _, m := range byVersion {
+    if m.up == "" || m.down == "" {
+      return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", m.version, m.name)
+    }
+    migrations = append(migrations, *m)
+  }
+  sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+
+  // Versions have to follow each other, otherwise going down one step could skip a migration.
+  for i, m := range migrations {
+    if m.version != i+1 {
+      return nil, fmt.Errorf("migration %04d_%s: expected version %d", m.version, m.name, i+1)
+    }
+  }
+
+  return migrations, nil
+}
+
+func schemaVersion(db *sql.DB) (int, error) {
+  if _, err := db.Exec("CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)"); err != nil {
+    return 0, err
+  }
+
+  var version int
+  err := db.QueryRow("SELECT version FROM schema_version").Scan(&version)
+  if err == sql.ErrNoRows {
+    return 0, nil
+  }
+
+  return version, err
+}
+
+// Migrate brings the SQLite database to the version named by target: "up" goes to the latest version, "down" undoes one migration and a number goes to that exact version.
+func Migrate(db *sql.DB, target string) error {
+  return migrateTo(db, sqliteDialect, target)
+}
+
+// MigratePostgres is Migrate for a Postgres database.
+func MigratePostgres(db *sql.DB, target string) error {
+  return migrateTo(db, postgresDialect, target)
+}
+
+func migrateTo(db *sql.DB, d dialect, target string) error {
+  migrations, err := loadMigrations(d.dir)
+  if err != nil {
+    return err
+  }
+
+  current, err := schemaVersion(db)
+  if err != nil {
+    return err
+  }
+
+  version := len(migrations)
+  switch target {
+  case "up":
+  case "down":
+    version = max(current-1, 0)
+  default:
+    version, err = strconv.Atoi(target)
+    if err != nil || version < 0 || version > len(migrations) {
+      return fmt.Errorf("migration target must be up, down or a version between 0 and %d, got %q", len(migrations), target)
+    }
+  }
+
+  return migrate(db, d, migrations, current, version)
+}
+
+func migrate(db *sql.DB, d dialect, migrations []migration, current, target int) error {
+  if current > len(migrations) {
+    return fmt.Errorf("database is at version %d but this server only knows %d migrations, is it older than the database?", current, len(migrations))
+  }
+
+  for current < target {
+    m := migrations[current]
+    if err := applyMigration(db, d, m.up, m.version); err != nil {
+      return fmt.Errorf("migration %04d_%s up: %w", m.version, m.name, err)
+    }
+    log.Printf("migrated up to version %d (%s)", m.version, m.name)
+    current++
+  }
+
+  for current > target {
+    m := migrations[current-1]
+    if err := applyMigration(db, d, m.down, m.version-1); err != nil {
+      return fmt.Errorf("migration %04d_%s down: %w", m.version, m.name, err)
+    }
+    log.Printf("migrated down to version %d (undid %s)", m.version-1, m.name)
+    current--
+  }
+
+  return nil
+}
+
+func applyMigration(db *sql.DB, d dialect, script string, version int) error {
+  tx, err := db.Begin()
+  if err != nil {
+    return err
+  }
+  // Rollback is a no-op once the transaction has been committed.
+  defer tx.Rollback()
+
+  if _, err := tx.Exec(script); err != nil {
+    return err
+  }
+  if _, err := tx.Exec("DELETE FROM schema_version"); err != nil {
+    return err
+  }
+  if _, err := tx.Exec(d.setVersion, version); err != nil {
+    return err
+  }
+
+  return tx.Commit()
+}
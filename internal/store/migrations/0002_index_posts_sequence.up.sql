@@ -0,0 +1,2 @@
+-- SyncChanges looks up the posts changed after a cursor.
+CREATE INDEX posts_sequence ON posts (sequence);
@@ -0,0 +1,2 @@
+-- RecordView counts the different viewers of every post next to its views.
+ALTER TABLE posts ADD COLUMN unique_viewers INTEGER NOT NULL DEFAULT 0;
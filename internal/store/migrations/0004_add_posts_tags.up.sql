@@ -0,0 +1,2 @@
+-- Tags are stored as a JSON array, like attachments.
+ALTER TABLE posts ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+ALTER TABLE posts DROP COLUMN flag_reason;
+ALTER TABLE posts DROP COLUMN flagged;
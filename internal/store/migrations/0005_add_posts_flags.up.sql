@@ -0,0 +1,3 @@
+-- Posts content moderation let through but wants a human to look at.
+ALTER TABLE posts ADD COLUMN flagged INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN flag_reason TEXT NOT NULL DEFAULT '';
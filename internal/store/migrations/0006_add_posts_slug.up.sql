@@ -0,0 +1,3 @@
+-- Tombstones of deleted posts have no slug, so only non-empty slugs have to be unique.
+ALTER TABLE posts ADD COLUMN slug TEXT NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX posts_slug ON posts (slug) WHERE slug != '';
@@ -0,0 +1,2 @@
+-- RFC 3339 time of the last change made to the post, empty for the posts written before it existed.
+ALTER TABLE posts ADD COLUMN updated_at TEXT NOT NULL DEFAULT '';
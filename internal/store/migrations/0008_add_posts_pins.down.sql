@@ -0,0 +1,2 @@
+ALTER TABLE posts DROP COLUMN pin_position;
+ALTER TABLE posts DROP COLUMN pinned;
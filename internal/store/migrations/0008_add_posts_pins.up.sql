@@ -0,0 +1,3 @@
+-- Pinned posts come first in GetPosts, pin_position orders them among themselves.
+ALTER TABLE posts ADD COLUMN pinned INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN pin_position INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE posts DROP COLUMN reading_minutes;
+ALTER TABLE posts DROP COLUMN word_count;
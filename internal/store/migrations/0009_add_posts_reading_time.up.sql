@@ -0,0 +1,3 @@
+-- Computed from the content by the server, see reading.go
+ALTER TABLE posts ADD COLUMN word_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN reading_minutes INTEGER NOT NULL DEFAULT 0;
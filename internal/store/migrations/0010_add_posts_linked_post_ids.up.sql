@@ -0,0 +1,2 @@
+-- JSON array of post IDs, like tags. Found in the content by the server, see links.go
+ALTER TABLE posts ADD COLUMN linked_post_ids TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+-- Identity of the caller that created the post, empty for anonymous callers and the posts written before it existed.
+ALTER TABLE posts ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
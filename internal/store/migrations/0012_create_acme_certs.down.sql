@@ -0,0 +1 @@
+DROP TABLE acme_certs;
@@ -0,0 +1,5 @@
+-- What the ACME client keeps between starts, its account key and the certificates it obtained, see certs.go.
+CREATE TABLE acme_certs (
+  key TEXT PRIMARY KEY,
+  data BLOB NOT NULL
+);
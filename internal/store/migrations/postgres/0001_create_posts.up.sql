@@ -0,0 +1,29 @@
+-- The posts table as the SQLite migrations 0001 to 0011 left it, see migrate.go
+CREATE TABLE posts (
+  id TEXT PRIMARY KEY,
+  position INTEGER NOT NULL,
+  title TEXT NOT NULL,
+  content TEXT NOT NULL,
+  author TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  view_count BIGINT NOT NULL DEFAULT 0,
+  last_viewed TEXT NOT NULL DEFAULT '',
+  attachments TEXT NOT NULL DEFAULT '[]',
+  publish_at TEXT NOT NULL DEFAULT '',
+  status INTEGER NOT NULL DEFAULT 0,
+  sequence BIGINT NOT NULL DEFAULT 0,
+  unique_viewers BIGINT NOT NULL DEFAULT 0,
+  tags TEXT NOT NULL DEFAULT '[]',
+  flagged BOOLEAN NOT NULL DEFAULT FALSE,
+  flag_reason TEXT NOT NULL DEFAULT '',
+  slug TEXT NOT NULL DEFAULT '',
+  updated_at TEXT NOT NULL DEFAULT '',
+  pinned BOOLEAN NOT NULL DEFAULT FALSE,
+  pin_position INTEGER NOT NULL DEFAULT 0,
+  word_count INTEGER NOT NULL DEFAULT 0,
+  reading_minutes INTEGER NOT NULL DEFAULT 0,
+  linked_post_ids TEXT NOT NULL DEFAULT '[]',
+  created_by TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX posts_sequence ON posts (sequence);
+CREATE UNIQUE INDEX posts_slug ON posts (slug) WHERE slug != '';
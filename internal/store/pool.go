@@ -0,0 +1,51 @@
+package store
+
+import (
+  "bufio"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+  "sync"
+
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  POOLED POSTS
+
+  Every read of the posts decodes every post into a new message, all of it garbage as soon as the handler is done. For GetPosts the garbage is the price of handing the posts to caches, but StreamPosts sends each post and forgets about it, and a busy server spends a good part of its time collecting what a few streams leave behind.
+
+  Handlers that know nobody kept a post call Release when they are done with it, and the next read decodes into it instead of allocating a new one. Handlers that don't call Release behave exactly as before, the GC gets their posts.
+
+  A released post must not be used anymore, it will be reset and filled with some other post. Only release posts that went nowhere else: not into a cache, not to the broker, not into a message still being sent.
+
+  The buffered readers the files are read through are pooled too.
+*/
+var (
+  postPool   = sync.Pool{New: func() any { return &pb.Post{} }}
+  readerPool = sync.Pool{New: func() any { return bufio.NewReaderSize(nil, 64<<10) }}
+)
+
+// newPost returns an empty post to decode into, a released one when there is one.
+func newPost() *pb.Post {
+  return postPool.Get().(*pb.Post)
+}
+
+// Release gives back posts read from a store, see above.
+func Release(posts ...*pb.Post) {
+  for _, post := range posts {
+    proto.Reset(post)
+    postPool.Put(post)
+  }
+}
+
+func getReader(r io.Reader) *bufio.Reader {
+  br := readerPool.Get().(*bufio.Reader)
+  br.Reset(r)
+
+  return br
+}
+
+func putReader(br *bufio.Reader) {
+  br.Reset(nil)
+  readerPool.Put(br)
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+  "errors"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "io"
+)
+
+/*
+  STREAMING READS
+
+  Load returns every post at once, which is what handlers changing posts need: they change some and save them all. Handlers that only read can go through the posts one at a time instead, with a PostReader, and never hold more than the posts they keep. A store that can read its posts one at a time implements PostStreamer, FileStore does (see file.go). For the others OpenReader falls back on Load, which is no worse than before.
+
+  A reader sees the posts as they were when it was opened, even if they are saved while it is being read, so it can be opened under the lock handlers take around the storage and read after releasing it.
+*/
+type PostReader interface {
+  // Next returns the next post, and io.EOF after the last one.
+  Next() (*pb.Post, error)
+  Close() error
+}
+
+type PostStreamer interface {
+  Reader() (PostReader, error)
+}
+
+// OpenReader returns a reader of the posts of s, one at a time when s is a PostStreamer.
+func OpenReader(s PostStore) (PostReader, error) {
+  if streamer, ok := s.(PostStreamer); ok {
+    return streamer.Reader()
+  }
+
+  posts, err := s.Load()
+  if err != nil {
+    return nil, err
+  }
+
+  return &sliceReader{posts: posts}, nil
+}
+
+// ReadAll reads the posts left in r.
+func ReadAll(r PostReader) ([]*pb.Post, error) {
+  posts := make([]*pb.Post, 0)
+  for {
+    post, err := r.Next()
+    if errors.Is(err, io.EOF) {
+      return posts, nil
+    }
+    if err != nil {
+      return nil, err
+    }
+    posts = append(posts, post)
+  }
+}
+
+// sliceReader reads posts that are already loaded.
+type sliceReader struct {
+  posts []*pb.Post
+}
+
+func (r *sliceReader) Next() (*pb.Post, error) {
+  if len(r.posts) == 0 {
+    return nil, io.EOF
+  }
+
+  post := r.posts[0]
+  r.posts = r.posts[1:]
+
+  return post, nil
+}
+
+func (r *sliceReader) Close() error {
+  return nil
+}
@@ -0,0 +1,37 @@
+package store
+
+import (
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "strings"
+  "unicode"
+)
+
+/*
+  READING TIME
+
+  Every post carries how many words its content has and how long it takes to read, so clients can print "4 min read" without downloading and counting the content themselves, and GetPosts can filter and sort on it.
+
+  A word is a run of characters between spaces that has at least one letter or digit in it: the Markdown around the words, like the # of a heading or the - of a list, doesn't count. The reading time assumes WordsPerMinute and is rounded up, so even a post of a few words takes a minute.
+
+  The counts are computed from the content whenever it changes. Posts written before they existed get them from Backfill.
+*/
+const WordsPerMinute = 200
+
+// CountWords returns the number of words of content, see above.
+func CountWords(content string) int {
+  words := 0
+  for _, field := range strings.Fields(content) {
+    if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+      words++
+    }
+  }
+
+  return words
+}
+
+// SetReadingTime computes the WordCount and ReadingMinutes of the post from its content.
+func SetReadingTime(post *pb.Post) {
+  words := CountWords(post.Content)
+  post.WordCount = int32(words)
+  post.ReadingMinutes = int32(max(1, (words+WordsPerMinute-1)/WordsPerMinute))
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "strings"
+  "unicode"
+
+  "golang.org/x/text/unicode/norm"
+)
+
+/*
+  SLUGS
+
+  A slug is the part of a pretty URL that names a post, like getting-started-with-grpc in https://blog.example.com/posts/getting-started-with-grpc. It is made from the title when the post is created:
+    - accents are dropped ("Café" becomes "cafe"): NFD normalization splits an accented letter into the letter and a combining mark, and the marks are left out
+    - letters and digits are lowercased, everything else becomes a dash, and runs of dashes collapse into one
+    - letters without an ASCII version, like Cyrillic or Japanese ones, are left out, a title made only of them gets the slug "post"
+    - long titles are cut at a word boundary so the slug stays under MaxSlugLength
+
+  Two posts can't share a slug, the second "Hello world" becomes hello-world-2, a slug already as long as it can be is cut shorter for the number. Slugs don't follow later changes of the title, a link that worked yesterday keeps working.
+*/
+const MaxSlugLength = 80
+
+// Slugify turns a title into a slug, empty when the title has no letters or digits.
+func Slugify(title string) string {
+  var b strings.Builder
+  dash := false
+  for _, r := range norm.NFD.String(title) {
+    switch {
+    case unicode.Is(unicode.Mn, r):
+    case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+      if dash && b.Len() > 0 {
+        b.WriteByte('-')
+      }
+      b.WriteRune(unicode.ToLower(r))
+      dash = false
+    default:
+      dash = true
+    }
+  }
+
+  slug := b.String()
+  if len(slug) > MaxSlugLength {
+    slug = slug[:MaxSlugLength]
+    if i := strings.LastIndexByte(slug, '-'); i > 0 {
+      slug = slug[:i]
+    }
+  }
+
+  return slug
+}
+
+// UniqueSlug returns the slug of the title, with a number appended when another post already has it.
+func UniqueSlug(posts []*pb.Post, title string) string {
+  base := Slugify(title)
+  if base == "" {
+    base = "post"
+  }
+
+  taken := make(map[string]bool, len(posts))
+  for _, post := range posts {
+    taken[post.Slug] = true
+  }
+
+  slug := base
+  for n := 2; taken[slug]; n++ {
+    // The number must fit under MaxSlugLength too, the slug is cut to make room without ending with a dash.
+    suffix := fmt.Sprintf("-%d", n)
+    slug = strings.TrimRight(base[:min(len(base), MaxSlugLength-len(suffix))], "-") + suffix
+  }
+
+  return slug
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+  "database/sql"
+  "encoding/json"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "strconv"
+  "strings"
+
+  // The driver registers itself with database/sql under the name "sqlite". modernc.org/sqlite is SQLite translated to Go, so unlike the usual C bindings it builds without cgo.
+  _ "modernc.org/sqlite"
+)
+
+/*
+  SQLITE BACKEND
+
+  SQLiteStore keeps the posts in the posts table created by the migrations (see migrate.go), one row per post. Saving replaces every row inside a transaction, which mirrors what rewriting posts.json does and keeps the handlers unaware of the backend. The position column remembers the order of the posts, since a SQL table has none of its own.
+
+  Attachments are a list inside each post, so they are stored as a JSON column instead of a table of their own.
+*/
+type SQLiteStore struct {
+  DB *sql.DB
+}
+
+// OpenSQLite opens (or creates) the database file, call Migrate before using it.
+func OpenSQLite(path string) (*sql.DB, error) {
+  db, err := sql.Open("sqlite", path)
+  if err != nil {
+    return nil, err
+  }
+
+  // The server's storeMu already makes the handlers take turns, a single connection saves SQLite from juggling locks between connections.
+  db.SetMaxOpenConns(1)
+
+  return db, db.Ping()
+}
+
+func (s *SQLiteStore) Load() ([]*pb.Post, error) {
+  posts, _, err := s.load(false)
+  return posts, err
+}
+
+// Salvage is Load skipping the rows it can't read, after asking SQLite to check the database file itself. See fsck.go
+func (s *SQLiteStore) Salvage() ([]*pb.Post, []CorruptEntry, error) {
+  var corrupt []CorruptEntry
+
+  rows, err := s.DB.Query("PRAGMA integrity_check")
+  if err != nil {
+    return nil, nil, err
+  }
+  for rows.Next() {
+    var result string
+    if err := rows.Scan(&result); err != nil {
+      rows.Close()
+      return nil, nil, err
+    }
+    if result != "ok" {
+      corrupt = append(corrupt, CorruptEntry{Where: "integrity_check", Err: result})
+    }
+  }
+  rows.Close()
+
+  posts, unreadable, err := s.load(true)
+  return posts, append(corrupt, unreadable...), err
+}
+
+func (s *SQLiteStore) load(salvage bool) ([]*pb.Post, []CorruptEntry, error) {
+  return loadPostRows(s.DB, salvage)
+}
+
+func (s *SQLiteStore) Save(posts []*pb.Post) error {
+  tx, err := s.DB.Begin()
+  if err != nil {
+    return err
+  }
+  defer tx.Rollback()
+
+  if err := savePostRows(tx, posts, "?"); err != nil {
+    return err
+  }
+
+  return tx.Commit()
+}
+
+// loadPostRows reads every row of the posts table, with salvage the rows that can't be read are returned apart instead of failing. The Postgres backend shares it, see postgres.go
+func loadPostRows(db *sql.DB, salvage bool) ([]*pb.Post, []CorruptEntry, error) {
+  rows, err := db.Query(`SELECT id, title, content, author, created_at, view_count, unique_viewers, last_viewed, attachments, publish_at, status, sequence, tags, flagged, flag_reason, slug, updated_at, pinned, pin_position, word_count, reading_minutes, linked_post_ids, created_by
+    FROM posts ORDER BY position`)
+  if err != nil {
+    return nil, nil, err
+  }
+  defer rows.Close()
+
+  posts := make([]*pb.Post, 0)
+  var corrupt []CorruptEntry
+  for rows.Next() {
+    // Rows are scanned into released posts when there are some, see pool.go
+    post := newPost()
+    var attachments, tags, links string
+    var postStatus int32
+
+    if err := rows.Scan(&post.Id, &post.Title, &post.Content, &post.Author, &post.CreatedAt, &post.ViewCount, &post.UniqueViewers, &post.LastViewed, &attachments, &post.PublishAt, &postStatus, &post.Sequence, &tags, &post.Flagged, &post.FlagReason, &post.Slug, &post.UpdatedAt, &post.Pinned, &post.PinPosition, &post.WordCount, &post.ReadingMinutes, &links, &post.CreatedBy); err != nil {
+      return nil, nil, err
+    }
+    err := json.Unmarshal([]byte(attachments), &post.Attachments)
+    if err == nil {
+      err = json.Unmarshal([]byte(tags), &post.Tags)
+    }
+    if err == nil {
+      err = json.Unmarshal([]byte(links), &post.LinkedPostIds)
+    }
+    if err != nil {
+      if !salvage {
+        return nil, nil, err
+      }
+      corrupt = append(corrupt, CorruptEntry{Where: "post " + post.Id, Err: err.Error(), Raw: fmt.Sprintf(`{"attachments": %q, "tags": %q, "linked_post_ids": %q}`, attachments, tags, links)})
+      Release(post)
+      continue
+    }
+    post.Status = pb.PostStatus(postStatus)
+
+    posts = append(posts, post)
+  }
+
+  return posts, corrupt, rows.Err()
+}
+
+// savePostRows replaces the rows of the posts table inside tx. placeholder is the one of the driver, ? for SQLite and $ for Postgres, which numbers them.
+func savePostRows(tx *sql.Tx, posts []*pb.Post, placeholder string) error {
+  if _, err := tx.Exec("DELETE FROM posts"); err != nil {
+    return err
+  }
+
+  insert, err := tx.Prepare(`INSERT INTO posts (id, position, title, content, author, created_at, view_count, unique_viewers, last_viewed, attachments, publish_at, status, sequence, tags, flagged, flag_reason, slug, updated_at, pinned, pin_position, word_count, reading_minutes, linked_post_ids, created_by)
+    VALUES ` + placeholders(placeholder, 24))
+  if err != nil {
+    return err
+  }
+  defer insert.Close()
+
+  for i, post := range posts {
+    attachments, err := json.Marshal(post.GetAttachments())
+    if err != nil {
+      return err
+    }
+    if post.Attachments == nil {
+      attachments = []byte("[]")
+    }
+    tags, err := json.Marshal(post.GetTags())
+    if err != nil {
+      return err
+    }
+    if post.Tags == nil {
+      tags = []byte("[]")
+    }
+    links, err := json.Marshal(post.GetLinkedPostIds())
+    if err != nil {
+      return err
+    }
+    if post.LinkedPostIds == nil {
+      links = []byte("[]")
+    }
+
+    if _, err := insert.Exec(post.Id, i, post.Title, post.Content, post.Author, post.CreatedAt, post.ViewCount, post.UniqueViewers, post.LastViewed, string(attachments), post.PublishAt, int32(post.Status), post.Sequence, string(tags), post.Flagged, post.FlagReason, post.Slug, post.UpdatedAt, post.Pinned, post.PinPosition, post.WordCount, post.ReadingMinutes, string(links), post.CreatedBy); err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
+
+// placeholders returns the list of n placeholders of a statement, like (?, ?) or ($1, $2).
+func placeholders(placeholder string, n int) string {
+  list := make([]string, n)
+  for i := range list {
+    list[i] = placeholder
+    if placeholder == "$" {
+      list[i] += strconv.Itoa(i + 1)
+    }
+  }
+
+  return "(" + strings.Join(list, ", ") + ")"
+}
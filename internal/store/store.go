@@ -0,0 +1,51 @@
+/*
+  Package store holds the storage backends for the blog posts. It lives under internal/ so both the server and blogctl (the client) can use it, while Go refuses to let code outside this module import it.
+*/
+package store
+
+import (
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+)
+
+/*
+  STORAGE BACKENDS
+
+  The server's handlers never talk to posts.json directly, they go through loadPost and savePosts, which in turn use whatever PostStore the server was started with:
+
+    go run . -storage file                     # posts.json, the default, see file.go
+    go run . -storage sqlite -db blog.db       # a SQLite database, see sqlite.go
+    go run . -storage postgres                 # a Postgres database, see postgres.go
+
+  The backends keep the same load everything, change it, save everything flow the handlers are written around, the server's storeMu still serializes them. Only the posts move, revisions and the audit log stay in their own files.
+*/
+type PostStore interface {
+  Load() ([]*pb.Post, error)
+  Save(posts []*pb.Post) error
+}
+
+// Backfill gives an ID, a sequence number, a slug and a reading time to the posts written before they existed and reports whether it changed anything. Using their position as sequence keeps the cursors handed out by older versions of SyncChanges valid.
+func Backfill(posts []*pb.Post) bool {
+  assigned := false
+  for i, post := range posts {
+    if post.Id == "" {
+      post.Id = NewID()
+      assigned = true
+    }
+    if post.Sequence == 0 {
+      post.Sequence = int64(i + 1)
+      assigned = true
+    }
+    // Deleted posts are tombstones without a title, they don't need a slug.
+    if post.Slug == "" && post.Status != pb.PostStatus_DELETED {
+      post.Slug = UniqueSlug(posts, post.Title)
+      assigned = true
+    }
+    // Every post takes at least a minute to read, see reading.go
+    if post.ReadingMinutes == 0 && post.Status != pb.PostStatus_DELETED {
+      SetReadingTime(post)
+      assigned = true
+    }
+  }
+
+  return assigned
+}
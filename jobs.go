@@ -0,0 +1,138 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  "log"
+  "runtime/debug"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc/health"
+  healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+/*
+  BACKGROUND JOBS
+
+  Some parts of the server run on their own goroutine instead of inside a request: the mirror's janitor today, and things like schedulers or flushers as the server grows. Starting them with a bare "go f()" works until one of them panics (taking the whole process down) or main returns while they're halfway through a write.
+
+  The jobManager owns all of them:
+    - Every job gets its own context, cancelled when the job is stopped.
+    - A job that returns an error or panics is restarted with an exponential backoff.
+    - The state of every job is reported through the standard gRPC health service under the name "job.<name>", so `grpc-health-probe -service job.mirror-janitor` tells you whether it's running.
+    - Shutdown stops the jobs in the reverse order they were started, so a job can rely on the ones started before it (e.g. a producer feeding a flusher) still being around while it winds down.
+*/
+type jobManager struct {
+  health *health.Server
+
+  mu   sync.Mutex
+  jobs []*managedJob
+}
+
+type managedJob struct {
+  name   string
+  run    func(ctx context.Context) error
+  cancel context.CancelFunc
+  done   chan struct{}
+}
+
+const (
+  jobMinBackoff = time.Second
+  jobMaxBackoff = time.Minute
+)
+
+func newJobManager(hs *health.Server) *jobManager {
+  return &jobManager{health: hs}
+}
+
+// Start runs the job on its own goroutine until it returns nil or the manager shuts down.
+func (m *jobManager) Start(name string, run func(ctx context.Context) error) {
+  ctx, cancel := context.WithCancel(context.Background())
+  j := &managedJob{
+    name:   name,
+    run:    run,
+    cancel: cancel,
+    done:   make(chan struct{}),
+  }
+
+  m.mu.Lock()
+  m.jobs = append(m.jobs, j)
+  m.mu.Unlock()
+
+  go m.supervise(ctx, j)
+}
+
+func (m *jobManager) supervise(ctx context.Context, j *managedJob) {
+  defer close(j.done)
+  defer m.setHealth(j, healthpb.HealthCheckResponse_NOT_SERVING)
+
+  backoff := jobMinBackoff
+  for {
+    m.setHealth(j, healthpb.HealthCheckResponse_SERVING)
+    started := time.Now()
+    err := runJob(ctx, j)
+
+    if ctx.Err() != nil {
+      return
+    }
+    if err == nil {
+      log.Printf("job %s finished", j.name)
+      return
+    }
+
+    // A job that ran fine for a while before failing starts over with the smallest backoff.
+    if time.Since(started) > jobMaxBackoff {
+      backoff = jobMinBackoff
+    }
+
+    log.Printf("job %s failed, restarting in %s: %v", j.name, backoff, err)
+    m.setHealth(j, healthpb.HealthCheckResponse_NOT_SERVING)
+
+    select {
+    case <-ctx.Done():
+      return
+    case <-time.After(backoff):
+    }
+
+    backoff = min(backoff*2, jobMaxBackoff)
+  }
+}
+
+// runJob turns a panic inside the job into an error, so one misbehaving job can't crash the server.
+func runJob(ctx context.Context, j *managedJob) (err error) {
+  defer func() {
+    if r := recover(); r != nil {
+      err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+    }
+  }()
+
+  return j.run(ctx)
+}
+
+func (m *jobManager) setHealth(j *managedJob, s healthpb.HealthCheckResponse_ServingStatus) {
+  if m.health != nil {
+    m.health.SetServingStatus("job."+j.name, s)
+  }
+}
+
+// Shutdown stops the jobs from the last started to the first, waiting for each one before moving on to the next.
+func (m *jobManager) Shutdown(ctx context.Context) error {
+  m.mu.Lock()
+  jobs := m.jobs
+  m.jobs = nil
+  m.mu.Unlock()
+
+  for i := len(jobs) - 1; i >= 0; i-- {
+    j := jobs[i]
+    j.cancel()
+
+    select {
+    case <-j.done:
+    case <-ctx.Done():
+      return fmt.Errorf("job %s didn't stop in time: %w", j.name, ctx.Err())
+    }
+  }
+
+  return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "sync"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  LOAD SHEDDING
+
+  Every handler reads and writes the whole posts.json file while holding storeMu, so requests are served one at a time no matter how many arrive. When a burst of clients shows up, the goroutines pile up behind the mutex, each one holding on to memory and a connection while its deadline runs out.
+
+  concurrencyLimiter puts a cap on that queue. It is a semaphore built on a buffered channel: a request takes a slot by sending into the channel and gives it back by receiving from it. Once the channel is full the request is rejected straight away with codes.Unavailable, which tells well behaved clients (and gRPC's retry policies) to back off and try again later.
+
+  The limit applies to all the clients together, unlike the per client rate limit of the mirror (see mirror.go).
+
+  The limit can be changed while the server runs (see config.go). A new limit is a new channel, the requests holding a slot of the old one give it back there, so for a moment both limits count.
+*/
+type concurrencyLimiter struct {
+  mu sync.Mutex
+  // slots is nil when there is no limit.
+  slots chan struct{}
+}
+
+// newConcurrencyLimiter returns a limiter letting max requests in at a time, 0 lets every request through.
+func newConcurrencyLimiter(max int) *concurrencyLimiter {
+  l := &concurrencyLimiter{}
+  l.resize(max)
+
+  return l
+}
+
+func (l *concurrencyLimiter) resize(max int) {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  l.slots = nil
+  if max > 0 {
+    l.slots = make(chan struct{}, max)
+  }
+}
+
+// acquire takes a slot, and returns the function giving it back. It returns false when every slot is taken.
+func (l *concurrencyLimiter) acquire() (func(), bool) {
+  l.mu.Lock()
+  slots := l.slots
+  l.mu.Unlock()
+
+  if slots == nil {
+    return func() {}, true
+  }
+
+  select {
+  case slots <- struct{}{}:
+    return func() { <-slots }, true
+  default:
+    return nil, false
+  }
+}
+
+func (l *concurrencyLimiter) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  release, ok := l.acquire()
+  if !ok {
+    return nil, status.Errorf(codes.Unavailable, "server is busy, try again later")
+  }
+  defer release()
+
+  return handler(ctx, req)
+}
+
+func (l *concurrencyLimiter) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  // A WatchPosts stream stays open for as long as the client wants and only waits on the broker, so counting it would let a handful of watchers starve everybody else. A StreamServerStats too, and the admin watching a busy server would be turned away when the stats matter most.
+  if info.FullMethod == pb.Blog_WatchPosts_FullMethodName || info.FullMethod == pb.Admin_StreamServerStats_FullMethodName {
+    return handler(srv, ss)
+  }
+
+  release, ok := l.acquire()
+  if !ok {
+    return status.Errorf(codes.Unavailable, "server is busy, try again later")
+  }
+  defer release()
+
+  return handler(srv, ss)
+}
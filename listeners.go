@@ -0,0 +1,113 @@
+package main
+
+import (
+  "errors"
+  "fmt"
+  "io/fs"
+  "log"
+  "net"
+  "os"
+  "strings"
+)
+
+/*
+  LISTENERS
+
+  -addr takes several addresses separated by commas, and the server serves the same services on all of them at once:
+
+    go run . -addr '0.0.0.0:3000,[::1]:3000,unix:///run/blog/blog.sock'
+
+  An address is host:port, which Go listens on over IPv4 and IPv6 alike when the host is empty or a name, or network://address to pick the network:
+
+    tcp4://0.0.0.0:3000      IPv4 only
+    tcp6://[::]:3000         IPv6 only
+    unix:///run/blog.sock    a Unix socket, for a proxy or sidecar on the same machine, the path after unix://
+
+  The default :3000 is every interface over both. The server logs every address once it is bound, which tells the port the system picked for :0. Every listener gets every call, with the same interceptors, TLS included: the calls coming through the Unix socket are no more trusted than the others.
+
+  A Unix socket left behind by a server that crashed is removed before listening, one still answering is another server running and stops this one.
+*/
+type listenAddr struct {
+  network string
+  address string
+}
+
+func (a listenAddr) String() string {
+  return a.network + "://" + a.address
+}
+
+func parseListenAddrs(spec string) ([]listenAddr, error) {
+  var addrs []listenAddr
+  for _, item := range splitList(spec) {
+    network, address, ok := strings.Cut(item, "://")
+    if !ok {
+      network, address = "tcp", item
+    }
+    switch network {
+    case "tcp", "tcp4", "tcp6":
+      if _, _, err := net.SplitHostPort(address); err != nil {
+        return nil, fmt.Errorf("invalid address %q in -addr: %w", item, err)
+      }
+    case "unix":
+      if address == "" {
+        return nil, fmt.Errorf("invalid address %q in -addr: expected unix://<path>", item)
+      }
+    default:
+      return nil, fmt.Errorf("invalid address %q in -addr: expected host:port or tcp4://, tcp6://, unix:// addresses", item)
+    }
+    addrs = append(addrs, listenAddr{network: network, address: address})
+  }
+  if len(addrs) == 0 {
+    return nil, fmt.Errorf("-addr lists no address")
+  }
+
+  return addrs, nil
+}
+
+// listen binds every address, closing the ones already bound when one of them fails.
+func listen(addrs []listenAddr) ([]net.Listener, error) {
+  var listeners []net.Listener
+  for _, addr := range addrs {
+    if addr.network == "unix" {
+      if err := removeStaleSocket(addr.address); err != nil {
+        closeListeners(listeners)
+        return nil, err
+      }
+    }
+    lis, err := net.Listen(addr.network, addr.address)
+    if err != nil {
+      closeListeners(listeners)
+      return nil, err
+    }
+    log.Printf("listening on %s://%s", lis.Addr().Network(), lis.Addr())
+    listeners = append(listeners, lis)
+  }
+
+  return listeners, nil
+}
+
+func closeListeners(listeners []net.Listener) {
+  for _, lis := range listeners {
+    lis.Close()
+  }
+}
+
+// removeStaleSocket removes the socket at path unless a server still answers on it.
+func removeStaleSocket(path string) error {
+  info, err := os.Lstat(path)
+  if errors.Is(err, fs.ErrNotExist) {
+    return nil
+  }
+  if err != nil {
+    return err
+  }
+  if info.Mode()&fs.ModeSocket == 0 {
+    return fmt.Errorf("%s exists and isn't a socket", path)
+  }
+  if conn, err := net.Dial("unix", path); err == nil {
+    conn.Close()
+    return fmt.Errorf("another server is listening on %s", path)
+  }
+
+  return os.Remove(path)
+}
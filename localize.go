@@ -0,0 +1,40 @@
+package main
+
+import (
+  "context"
+  "errors"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/i18n"
+  "go/tutorial/grpc/internal/reqctx"
+
+  "google.golang.org/grpc"
+)
+
+/*
+  LOCALIZATION
+
+  Clients send the language they prefer in the accept-language metadata (see reqctx), and the server answers in it when it can: the localize interceptors translate the message of every application error (see internal/apperr) with the catalogs of internal/i18n, and RenderPost writes the publishing date the way that language does. English, Spanish and French are supported, everything else gets English.
+
+    go run ./client render -id <post id> -lang fr
+
+  Only the message changes, the status code is the same in every language, so code that checks errors keeps working whatever the client asked for. The logs stay in English, the interceptors run outside of the debug log (see main.go) and only the response is translated.
+*/
+func localizeError(ctx context.Context, err error) error {
+  var e *apperr.Error
+  locale := reqctx.Locale(ctx)
+  if err == nil || !errors.As(err, &e) || i18n.Match(locale) == i18n.Fallback {
+    return err
+  }
+
+  return e.Localized(func(message string) string { return i18n.Translate(locale, message) }).Err()
+}
+
+func localizeUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  resp, err := handler(ctx, req)
+
+  return resp, localizeError(ctx, err)
+}
+
+func localizeStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  return localizeError(ss.Context(), handler(srv, ss))
+}
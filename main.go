@@ -2,21 +2,39 @@ package main
 
 import (
   "context"
-  "encoding/json"
+  "errors"
+  "flag"
 
   /*
     ALIASES AND GENERATED CODE
     The generated code is located within the /gen file. We're going to need some of the functions exported in there to implement our gRPC server. gRPC developers commonly alias these methods as 'pb' (Protocol Buffers) to indicate that this code is generated.
+    /gen is a Go module of its own, with a path that can be fetched, so other programs can talk to the blog by importing it without the rest of this repo. buf generates it, see buf.gen.yaml
   */
-  pb "go/tutorial/grpc/gen"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/clock"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/spiffe"
+  "go/tutorial/grpc/internal/store"
+  "io"
   "log"
   "net"
+  "net/http"
   "os"
+  "os/signal"
+  "slices"
+  "strings"
+  "sync"
+  "syscall"
   "time"
 
+  "golang.org/x/crypto/acme/autocert"
   "google.golang.org/grpc"
-  "google.golang.org/grpc/codes"
-  "google.golang.org/grpc/status"
+  channelz "google.golang.org/grpc/channelz/service"
+  "google.golang.org/grpc/health"
+  healthpb "google.golang.org/grpc/health/grpc_health_v1"
+  "google.golang.org/grpc/keepalive"
+  "google.golang.org/protobuf/proto"
 )
 
 /*
@@ -54,6 +72,64 @@ import (
 
 type server struct {
   pb.UnimplementedBlogServer
+
+  // Where the bytes of the post attachments are kept, see attachments.go
+  attachments attachmentStore
+  // Turns the Markdown content of posts into HTML, see render.go
+  renderer renderer
+  // Fans post events out to the WatchPosts streams and the webhooks, see watch.go
+  broker *postBroker
+  // Wakes the scheduler up when a post gets scheduled, see schedule.go
+  scheduleChanged chan struct{}
+  // How many revisions to keep per post, 0 keeps all of them. See revisions.go
+  maxRevisions int
+  // Tells who is calling, see auth.go
+  auth *authenticator
+  // Records the mutating RPCs, see audit.go
+  audit *auditLog
+  // Calls the registered webhooks on post events, see webhooks.go
+  webhooks *webhookDispatcher
+  // Emails the subscribers about new posts, nil when no SMTP server is configured. See email.go
+  email *emailNotifier
+  // Counts views and caches posts, nil without -redis-addr. See redis.go
+  redis *redisCache
+  // When the views happened, for the analytics RPCs. See views.go
+  views *viewLog
+  // The views summed by day and week, see summaries.go
+  summaries *summaryLog
+  // The published posts by author, for GetAuthorProfile. See authors.go
+  authors *authorIndex
+  // The word counts and tags of the published posts, for GetRelatedPosts. See related.go
+  related *relatedIndex
+  // The URLs of the published posts for GetSitemap, nil without -site-url. See sitemap.go
+  sitemap *sitemap
+  // Checks posts before they are saved, nil when no moderation is configured. See moderation.go
+  moderator moderator
+  // Spots new posts repeating a recent one, see duplicates.go
+  duplicates *duplicateCheck
+  // Turns calls away while the server is being worked on, see maintenance.go
+  maintenance *maintenanceMode
+  // What happens to the drafts left untouched, see drafts.go
+  drafts draftPolicy
+  // How many posts can be pinned at once, 0 turns pinning off. See pins.go
+  maxPinned int
+  // The templates of CreatePostFromTemplate, see posttemplates.go
+  templates *templateStore
+  // The ordered lists of posts of GetSeries, see series.go
+  series *seriesStore
+  // Signs the session tokens of anonymous readers and keeps their reading history, see sessions.go
+  sessions *sessions
+  // The inboxes of the callers, see notifications.go
+  notifications *notificationStore
+  // The comments on the posts and whether they wait for a moderator, see comments.go
+  comments *commentStore
+  // The reports of abuse and the banned authors, see reports.go
+  reports *reportStore
+  // The banned authors and addresses, and whether GetPosts hides the posts of the authors, see bans.go
+  bans       *banList
+  hideBanned bool
+  // The proofs of work of the anonymous posts, see challenges.go
+  challenges *challenges
 }
 
 /*
@@ -96,12 +172,63 @@ type server struct {
 
 var (
   filePath string = "posts.json"
+
+  // postStore is where loadPost and savePosts keep the posts, see internal/store.
+  postStore store.PostStore = &store.FileStore{Path: filePath}
+
+  // serverClock is the time of the blog, which the tests of scheduled posts and expiries stop and move forward, see internal/clock.
+  serverClock clock.Clock = clock.System
+
+  /*
+    MUTEXES
+
+    gRPC serves every request on its own goroutine, so two calls can try to read and rewrite posts.json at the same time. Without coordination one of the writes gets lost or a reader sees a half written file. A sync.Mutex makes sure only one handler touches the file at any given moment.
+  */
+  storeMu sync.Mutex
 )
 
 /*
   As explained above now our server needs to override the GetPosts method to comply with the BlogServer interface. Notice how the function signature exactly matches the UnimplementedBlogServer including the arguments and return types.
 */
-func (s *server) GetPosts(context.Context, *pb.GetPostsRequest) (*pb.Posts, error) {
+func (s *server) GetPosts(ctx context.Context, req *pb.GetPostsRequest) (*pb.Posts, error) {
+  // The filter narrows down the posts below and the read mask their fields, see filter.go and projection.go
+  filter, err := parseFilter(req.GetFilter())
+  if err != nil {
+    return nil, err
+  }
+  fields, err := parseReadMask(req.GetReadMask())
+  if err != nil {
+    return nil, err
+  }
+  if err := checkOrder(req.GetOrderBy()); err != nil {
+    return nil, err
+  }
+  // With a PageSize only some of the posts are returned, see pages.go
+  token, err := parsePageToken(req.GetPageToken())
+  if err != nil {
+    return nil, err
+  }
+  // With UnreadOnly the posts marked as read are left out, see sessions.go
+  read, err := s.sessions.readPosts(ctx, req.GetUnreadOnly())
+  if err != nil {
+    return nil, err
+  }
+  // With -hide-banned the posts of the banned authors are left out too, see bans.go
+  var hidden map[string]bool
+  if s.hideBanned {
+    hidden = s.bans.bannedAuthors()
+  }
+
+  // With Redis posts are cached over there, see redis.go
+  if s.redis != nil {
+    published, err := s.getPostsFromRedis(ctx)
+    if err != nil {
+      return nil, err
+    }
+    page, _ := paginate(sortPosts(dropAuthors(dropRead(filter.apply(published), read), hidden), req.GetOrderBy()), req.GetPageSize(), token)
+    return fields.apply(page), nil
+  }
+
   /*
     We need to leverage the types that protobuf generated for us. In this case we want to use Posts defined in the blog.pb.go
 
@@ -123,90 +250,1004 @@ func (s *server) GetPosts(context.Context, *pb.GetPostsRequest) (*pb.Posts, erro
   /*
    Notice that error handling is different than in the web version. Here we just return an error as opposed to having to write the error using the http writer.
   */
-  if err := loadPost(posts); err != nil {
+  r, err := openPosts(store.Query{Authors: filter.authors, Tags: filter.tags})
+  if err != nil {
     return nil, err
   }
+  // The defer closes the reader however we leave the function.
+  defer r.Close()
 
-  for i := 0; i < len(posts.Posts); i++ {
-    post := posts.Posts[i]
-    post.ViewCount += 1
-    post.LastViewed = time.Now().Format("2006-01-02")
+  // The posts are read one at a time (see openPosts), only the ones the filter keeps stay in memory. Filtering by author or tag only reads the posts that have them.
+  for {
+    post, err := r.Next()
+    if errors.Is(err, io.EOF) {
+      break
+    }
+    if err != nil {
+      return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to load posts: %w", err)
+    }
+
+    // Scheduled posts stay hidden from readers until the scheduler publishes them, like publishedPosts does. Listing posts doesn't count as viewing them, clients call RecordView for that (see views.go).
+    if post.Status != pb.PostStatus_PUBLISHED || !filter.match(post) || read[post.Id] || hidden[post.CreatedBy] {
+      store.Release(post)
+      continue
+    }
+    posts.Posts = append(posts.Posts, post)
   }
+  // In the order asked for with the pinned posts first, see filter.go and pins.go
+  posts = sortPosts(posts, req.GetOrderBy())
+  posts, dropped := paginate(posts, req.GetPageSize(), token)
+  store.Release(dropped...)
 
-  if err := savePosts(posts); err != nil {
-    return nil, status.Errorf(codes.Internal, "failed to save posts %v", err)
+  if len(fields) == 0 {
+    return posts, nil
   }
+  // The projection is made of new messages, the posts it was made from go nowhere.
+  projected := fields.apply(posts)
+  store.Release(posts.Posts...)
 
-  return posts, nil
+  return projected, nil
 }
 
 // Similar to the above we need to comply exactly with the signature of the UnimplementedBlogServer
-func (s *server) CreatePost(_ context.Context, req *pb.CreatePostRequest) (*pb.Post, error) {
+func (s *server) CreatePost(ctx context.Context, req *pb.CreatePostRequest) (*pb.Post, error) {
+  // With -challenge-bits anonymous callers solve a proof of work first, see challenges.go
+  if err := s.challenges.verify(ctx); err != nil {
+    return nil, err
+  }
+  tags, err := normalizeTags(req.GetTags())
+  if err != nil {
+    return nil, err
+  }
+
   // We build a new post object by leveraging the stub definition.
   newPost := &pb.Post{
+    Id:         store.NewID(),
     Title:      req.GetTitle(),
     Content:    req.GetContent(),
     Author:     req.GetAuthor(),
-    CreatedAt:  time.Now().Format("2006-01-02"),
-    LastViewed: time.Now().Format("2006-01-02"),
+    CreatedAt:  serverClock.Now().Format("2006-01-02"),
+    LastViewed: serverClock.Now().Format("2006-01-02"),
     ViewCount:  0,
+    Tags:       tags,
+  }
+  // Who gets the notifications of the post, see notifications.go
+  if identity := reqctx.Identity(ctx); identity != anonymousIdentity {
+    newPost.CreatedBy = identity
+  }
+  // How long the post takes to read, see internal/store/reading.go
+  store.SetReadingTime(newPost)
+
+  // A draft is published by UpdatePost, which is when it gets its PublishAt. See drafts.go
+  if req.GetDraft() {
+    if req.GetPublishAt() != "" {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "a draft can't have a PublishAt, give it to the UpdatePost publishing the draft")
+    }
+    newPost.Status = pb.PostStatus_DRAFT
+  } else if err := schedulePost(newPost, req.GetPublishAt()); err != nil {
+    return nil, err
+  }
+  newPost.UpdatedAt = serverClock.Now().UTC().Format(time.RFC3339)
+
+  // Moderation may call an external service, which is best done before taking the lock.
+  if err := s.moderatePost(ctx, newPost); err != nil {
+    return nil, err
   }
 
   posts := &pb.Posts{
     Posts: make([]*pb.Post, 0),
   }
 
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  // The lock may have taken a while, see timeouts.go
+  if err := checkCallDone(ctx); err != nil {
+    return nil, err
+  }
   if err := loadPost(posts); err != nil {
-    return nil, status.Errorf(codes.Internal, "failed to load posts: %v\n", err)
+    return nil, err
   }
 
+  if err := s.duplicates.check(ctx, posts.Posts, req, newPost); err != nil {
+    return nil, err
+  }
+
+  newPost.Sequence = nextSequence(posts)
+  newPost.Slug = store.UniqueSlug(posts.Posts, newPost.Title)
+  // The other posts it mentions, see internal/store/links.go
+  store.SetLinks(posts.Posts, newPost)
   posts.Posts = append(posts.Posts, newPost)
 
+  if err := checkCallDone(ctx); err != nil {
+    return nil, err
+  }
   if err := savePosts(posts); err != nil {
-    return nil, status.Errorf(codes.Internal, "failed to save posts: %v", err)
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+
+  if newPost.Status == pb.PostStatus_PUBLISHED {
+    s.broker.publish(pb.PostEventType_POST_CREATED, newPost)
+  }
+  if newPost.Status != pb.PostStatus_DRAFT {
+    s.afterSchedule(newPost)
   }
 
   return newPost, nil
 }
 
-func savePosts(posts *pb.Posts) error {
-  data, err := json.MarshalIndent(posts.Posts, "", "  ")
+// UpdatePost only changes the fields that are set in the request, an empty string keeps the current value.
+func (s *server) UpdatePost(ctx context.Context, req *pb.UpdatePostRequest) (*pb.Post, error) {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  // The lock may have taken a while, see timeouts.go
+  if err := checkCallDone(ctx); err != nil {
+    return nil, err
+  }
+  if err := loadPost(posts); err != nil {
+    return nil, err
+  }
+
+  post, err := findPost(posts, req.GetId())
   if err != nil {
-    return err
+    return nil, err
+  }
+
+  // A draft stays one until asked to be published, a PublishAt alone would publish it by surprise.
+  if post.Status == pb.PostStatus_DRAFT && req.GetPublishAt() != "" && !req.GetPublish() {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "post %q is a draft, set Publish to publish it at PublishAt", post.Id)
   }
+  if req.GetPublish() && post.Status != pb.PostStatus_DRAFT {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "post %q isn't a draft, only drafts are published with Publish", post.Id)
+  }
+
+  // Keep a copy of the current version, it becomes a revision once we know the update is valid.
+  previous := proto.Clone(post).(*pb.Post)
 
-  return os.WriteFile(filePath, data, 0644)
+  if req.GetTitle() != "" {
+    post.Title = req.GetTitle()
+  }
+  if req.GetContent() != "" {
+    post.Content = req.GetContent()
+    store.SetReadingTime(post)
+    store.SetLinks(posts.Posts, post)
+  }
+  if req.GetAuthor() != "" {
+    post.Author = req.GetAuthor()
+  }
+  if len(req.GetTags()) > 0 {
+    tags, err := normalizeTags(req.GetTags())
+    if err != nil {
+      return nil, err
+    }
+    post.Tags = tags
+  }
+
+  wasPublished := post.Status == pb.PostStatus_PUBLISHED
+  if req.GetPublishAt() != "" || req.GetPublish() {
+    if err := schedulePost(post, req.GetPublishAt()); err != nil {
+      return nil, err
+    }
+  }
+  post.UpdatedAt = serverClock.Now().UTC().Format(time.RFC3339)
+
+  // The post as edited is only known here, so unlike CreatePost the moderators are asked while holding the lock. The external service has a short timeout for that reason.
+  if err := s.moderatePost(ctx, post); err != nil {
+    return nil, err
+  }
+
+  // The last moment the update can still fail, the revision is the first thing written.
+  if err := checkCallDone(ctx); err != nil {
+    return nil, err
+  }
+  if err := s.recordRevision(previous); err != nil {
+    return nil, err
+  }
+
+  post.Sequence = nextSequence(posts)
+
+  if err := savePosts(posts); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+
+  // A post that was already published is simply updated, otherwise afterSchedule publishes it or hands it to the scheduler. Drafts wait.
+  switch {
+  case wasPublished && post.Status == pb.PostStatus_PUBLISHED:
+    s.broker.publish(pb.PostEventType_POST_UPDATED, post)
+  case wasPublished:
+    // Scheduled again: the readers must drop their copy, without being shown what comes out later.
+    s.broker.publish(pb.PostEventType_POST_UNPUBLISHED, &pb.Post{Id: post.Id, Author: post.Author, Status: post.Status, Sequence: post.Sequence})
+    s.afterSchedule(post)
+  case post.Status != pb.PostStatus_DRAFT:
+    s.afterSchedule(post)
+  }
+
+  return post, nil
 }
 
-func loadPost(posts *pb.Posts) error {
-  data, err := os.ReadFile(filePath)
+/*
+  DeletePost doesn't remove the post from posts.json but turns it into a tombstone: everything but the ID is wiped and the status becomes DELETED. Keeping the ID around with a new sequence number is what lets SyncChanges tell clients to drop their copy.
+*/
+func (s *server) DeletePost(_ context.Context, req *pb.DeletePostRequest) (*pb.DeletePostResponse, error) {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return nil, err
+  }
+
+  post, err := findPost(posts, req.GetId())
+  if err != nil {
+    return nil, err
+  }
+
+  deleted, err := s.removePosts(posts, []*pb.Post{post})
+  if err != nil {
+    return nil, err
+  }
+
+  if err := savePosts(posts); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+
+  s.publishDeleted(deleted)
+
+  return &pb.DeletePostResponse{}, nil
+}
+
+// removePosts turns the posts into tombstones along with their attachments and revisions, saving them is up to the caller. It returns the posts as they were.
+func (s *server) removePosts(posts *pb.Posts, targets []*pb.Post) ([]*pb.Post, error) {
+  revisions, err := loadRevisions()
+  if err != nil {
+    return nil, err
+  }
+
+  for _, post := range targets {
+    for _, attachment := range post.Attachments {
+      s.attachments.Delete(post.Id, attachment.Id)
+    }
+    delete(revisions, post.Id)
+  }
+
+  if err := saveRevisions(revisions); err != nil {
+    return nil, err
+  }
+
+  // Watchers get the posts as they were, a tombstone wouldn't tell them much. They carry the sequence of the tombstone though, which is the cursor of their event (see watch.go).
+  deleted := make([]*pb.Post, 0, len(targets))
+  sequence := nextSequence(posts)
+  for _, post := range targets {
+    previous := proto.Clone(post).(*pb.Post)
+    previous.Sequence = sequence
+    deleted = append(deleted, previous)
+
+    *post = pb.Post{
+      Id:       post.Id,
+      Status:   pb.PostStatus_DELETED,
+      Sequence: sequence,
+    }
+    sequence++
+  }
+
+  return deleted, nil
+}
+
+// publishDeleted tells the watchers about the deleted posts they could see.
+func (s *server) publishDeleted(deleted []*pb.Post) {
+  for _, post := range deleted {
+    if post.Status == pb.PostStatus_PUBLISHED {
+      s.broker.publish(pb.PostEventType_POST_DELETED, post)
+    }
+  }
+}
+
+/*
+  SyncChanges lets clients keep a local copy of the blog (see the client's search subcommand) by only asking for what they haven't seen yet.
+
+  Every time a post is created, updated, published or deleted it gets the next number in a sequence shared by all posts. That turns the posts file into a change log: a client remembers the highest sequence it has seen and sends it as the cursor to get back only what changed after it.
+*/
+func (s *server) SyncChanges(_ context.Context, req *pb.SyncChangesRequest) (*pb.SyncChangesResponse, error) {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  return syncFrom(posts, req.GetCursor())
+}
+
+// GetPostBySlug finds a post by the slug it got on creation, see internal/store/slug.go
+func (s *server) GetPostBySlug(_ context.Context, req *pb.GetPostBySlugRequest) (*pb.Post, error) {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
 
   if err != nil {
-    return status.Errorf(codes.Internal, "failed to read posts file: %v\n", err)
+    return nil, err
+  }
+
+  for _, post := range publishedPosts(posts).Posts {
+    if post.Slug == req.GetSlug() {
+      return post, nil
+    }
   }
 
-  var postsSlice []*pb.Post
+  return nil, apperr.Errorf(apperr.ErrPostNotFound, "no post with the slug %q", req.GetSlug())
+}
 
-  if err := json.Unmarshal(data, &postsSlice); err != nil {
-    return status.Errorf(codes.Internal, "failed to parse post data %v\n", err)
+// GetBacklinks returns the published posts linking to a published post, in the order they were created. See internal/store/links.go
+func (s *server) GetBacklinks(_ context.Context, req *pb.GetBacklinksRequest) (*pb.Posts, error) {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  published := publishedPosts(posts)
+  if _, err := findPost(published, req.GetPostId()); err != nil {
+    return nil, err
+  }
+
+  backlinks := &pb.Posts{Posts: make([]*pb.Post, 0)}
+  for _, post := range published.Posts {
+    if slices.Contains(post.LinkedPostIds, req.GetPostId()) {
+      backlinks.Posts = append(backlinks.Posts, post)
+    }
+  }
+
+  return backlinks, nil
+}
+
+// syncFrom only returns published posts, a scheduled post shows up in the change log once it gets published. Deleted and archived posts are only reported by ID, and so are the scheduled ones, which may have been published before UpdatePost scheduled them again.
+func syncFrom(posts *pb.Posts, cursor int64) (*pb.SyncChangesResponse, error) {
+  if cursor < 0 {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "invalid cursor %d", cursor)
+  }
+
+  res := &pb.SyncChangesResponse{
+    Posts:  make([]*pb.Post, 0),
+    Cursor: cursor,
+  }
+
+  for _, post := range posts.Posts {
+    if post.Sequence <= cursor {
+      continue
+    }
+
+    switch post.Status {
+    case pb.PostStatus_PUBLISHED:
+      res.Posts = append(res.Posts, post)
+    // Clients drop archived and rescheduled posts like deleted ones, readers can't see them anymore. A scheduled post they never had is dropped from nothing.
+    case pb.PostStatus_DELETED, pb.PostStatus_ARCHIVED, pb.PostStatus_SCHEDULED:
+      res.DeletedIds = append(res.DeletedIds, post.Id)
+    default:
+      continue
+    }
+    res.Cursor = max(res.Cursor, post.Sequence)
+  }
+
+  return res, nil
+}
+
+func savePosts(posts *pb.Posts) error {
+  return postStore.Save(posts.Posts)
+}
+
+func loadPost(posts *pb.Posts) error {
+  postsSlice, err := postStore.Load()
+
+  if err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to load posts: %w", err)
   }
 
   posts.Posts = postsSlice
 
+  // Posts created before IDs and sequence numbers were introduced get them the first time they are loaded. We save right away so they stay the same on the next load.
+  if store.Backfill(posts.Posts) {
+    if err := savePosts(posts); err != nil {
+      return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+    }
+  }
+
   return nil
 }
 
+// openPosts returns a reader of the posts matching q, for the handlers that only read them and can do it one post at a time. It takes storeMu only to open the reader, see internal/store/reader.go. Posts looked up by ID, author or tag are found through the index of the storage when it has one, see internal/store/index.go
+func openPosts(q store.Query) (store.PostReader, error) {
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  var r store.PostReader
+  var err error
+  if len(q.IDs) == 0 && len(q.Authors) == 0 && len(q.Tags) == 0 {
+    r, err = store.OpenReader(postStore)
+  } else {
+    r, err = store.Lookup(postStore, q)
+  }
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to load posts: %w", err)
+  }
+
+  return r, nil
+}
+
+// readPosts returns the posts matching q, see openPosts.
+func readPosts(q store.Query) (*pb.Posts, error) {
+  r, err := openPosts(q)
+  if err != nil {
+    return nil, err
+  }
+  defer r.Close()
+
+  posts, err := store.ReadAll(r)
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to load posts: %w", err)
+  }
+
+  return &pb.Posts{Posts: posts}, nil
+}
+
+// nextSequence returns the sequence number for the next change, see SyncChanges.
+func nextSequence(posts *pb.Posts) int64 {
+  var last int64
+  for _, post := range posts.Posts {
+    last = max(last, post.Sequence)
+  }
+
+  return last + 1
+}
+
+// publishedPosts leaves out the posts that are still waiting for their publishing time.
+func publishedPosts(posts *pb.Posts) *pb.Posts {
+  published := &pb.Posts{
+    Posts: make([]*pb.Post, 0, len(posts.Posts)),
+  }
+
+  for _, post := range posts.Posts {
+    if post.Status == pb.PostStatus_PUBLISHED {
+      published.Posts = append(published.Posts, post)
+    }
+  }
+
+  return published
+}
+
+// findPost never returns deleted posts, as far as the RPCs are concerned they don't exist anymore.
+func findPost(posts *pb.Posts, id string) (*pb.Post, error) {
+  for _, post := range posts.Posts {
+    if post.Id == id && post.Status != pb.PostStatus_DELETED {
+      return post, nil
+    }
+  }
+
+  return nil, apperr.Errorf(apperr.ErrPostNotFound, "post %q not found", id)
+}
+
 func main() {
+  addr := flag.String("addr", ":3000", "addresses the gRPC server listens on, separated by commas, host:port or tcp4://, tcp6://, unix:// ones, see listeners.go")
+  mirrorAddr := flag.String("mirror-addr", "", "if set, also serve a read-only public mirror on this address")
+  mirrorTTL := flag.Duration("mirror-cache-ttl", 30*time.Second, "how long the mirror caches responses")
+  mirrorRPS := flag.Float64("mirror-rps", 5, "requests per second allowed per client on the mirror")
+  mirrorBurst := flag.Int("mirror-burst", 10, "burst of requests allowed per client on the mirror")
+  attachmentsDir := flag.String("attachments-dir", "attachments", "directory where post attachments are stored")
+  attachmentsBackend := flag.String("attachments-backend", "disk", "where attachments are stored: disk (-attachments-dir) or s3, see blobs.go")
+  s3Endpoint := flag.String("s3-endpoint", "s3.amazonaws.com", "host of the S3 compatible API, storage.googleapis.com for GCS")
+  s3Bucket := flag.String("s3-bucket", "", "bucket the attachments are stored in with -attachments-backend s3")
+  s3Region := flag.String("s3-region", "", "region of the bucket, empty lets the client look it up")
+  s3Insecure := flag.Bool("s3-insecure", false, "talk to -s3-endpoint over plain HTTP, for local MinIO servers")
+  maxRevisions := flag.Int("max-revisions", 20, "number of previous versions kept for every post, 0 keeps all of them")
+  adminToken := flag.String("admin-token", "", "token that authenticates admins, admin RPCs are disabled without it or -oidc-issuer")
+  adminTokenFrom := flag.String("admin-token-from", "", "secret reference to read the admin token from instead of -admin-token, like file:<path> or vault:<path>#<field>, see secrets.go")
+  sessionKeyFrom := flag.String("session-key-from", "", "secret reference to read the key of the session tokens from instead of session.key, see secrets.go")
+  tlsCertFrom := flag.String("tls-cert-from", "", "secret reference to read the TLS certificate of the server from, in PEM, see secrets.go")
+  tlsKeyFrom := flag.String("tls-key-from", "", "secret reference to read the private key of -tls-cert-from from, in PEM")
+  acmeDomains := flag.String("acme-domains", "", "comma separated domains to get the TLS certificate of the server for from Let's Encrypt, see acme.go")
+  acmeEmail := flag.String("acme-email", "", "email Let's Encrypt warns about certificates that failed to renew")
+  acmeDirectory := flag.String("acme-directory", "", "directory URL of the ACME server, empty for Let's Encrypt")
+  acmeHTTPAddr := flag.String("acme-http-addr", "", "address to answer the HTTP-01 challenges of Let's Encrypt on, usually :80")
+  spiffeSocket := flag.String("spiffe-socket", "", "Unix socket of the SPIFFE Workload API, usually $"+spiffe.EndpointEnv+", to serve mutual TLS with the SVID of the server, see spiffe.go")
+  spiffePolicyPath := flag.String("spiffe-policy", "", "file of the rules saying which SPIFFE IDs may call which RPCs, see spiffe.go")
+  maxConnectionAge := flag.Duration("max-connection-age", 0, "close client connections this old, gracefully, so clients balancing over DNS find new servers, 0 keeps them open, see resolver.go in the SDK")
+  secretsRefresh := flag.Duration("secrets-refresh", defaultSecretsRefresh, "how often the secret references are read again to pick up rotated secrets")
+  oidcIssuer := flag.String("oidc-issuer", "", "URL of an OpenID Connect provider whose access tokens authenticate callers, see oidc.go")
+  oidcAudience := flag.String("oidc-audience", "", "aud the access tokens of -oidc-issuer must have")
+  oidcAdminScope := flag.String("oidc-admin-scope", "blog.admin", "scope that makes the holder of an access token an admin")
+  signingKeys := flag.String("signing-keys", "", "file of the keys machine clients sign their calls with, see signing.go")
+  auditPath := flag.String("audit-log", "audit.jsonl", "file that records every mutating RPC")
+  auditRetention := flag.Duration("audit-retention", 0, "entries of the audit log older than this are dropped by the compact-audit task, 0 keeps them all, see audit.go")
+  rendererName := flag.String("renderer", "commonmark", "Markdown renderer used by RenderPost: commonmark, gfm or plain")
+  maxConnsPerIP := flag.Int("max-conns-per-ip", defaultMaxConnsPerIP, "connections a client IP may have open at once, 0 disables the limit, see peerlimits.go")
+  maxStreamsPerIP := flag.Int("max-streams-per-ip", defaultMaxStreamsPerIP, "calls a client IP may have running at once, streams included, 0 disables the limit")
+  slowCallThreshold := flag.Duration("slow-call-threshold", defaultSlowCallThreshold, "log the calls still running after this long, 0 turns it off, see slowcalls.go")
+  slowCallStacks := flag.String("slow-call-stacks", "", "directory to write the goroutine stacks to when a call turns slow, at most once a minute")
+  chaosSpec := flag.String("chaos", "", "faults to inject into the calls of the Blog service to test clients, like latency=50ms-500ms,unavailable=0.2,truncate=0.3, see chaos.go")
+  maxConcurrent := flag.Int("max-concurrent", 64, "requests handled at the same time before the server answers Unavailable, 0 disables the limit")
+  storageName := flag.String("storage", "file", "where posts are stored: file (posts.json), sqlite or postgres")
+  fileFormat := flag.String("file-format", store.FormatJSON, "format of posts.json with -storage file: json, compact or ndjson, see internal/store/file.go")
+  fileGzip := flag.Bool("file-gzip", false, "gzip posts.json with -storage file")
+  idFormat := flag.String("ids", "uuid", "IDs of new posts, comments and everything else: uuid, ulid or sequential, see internal/store/ids.go")
+  sequencePath := flag.String("ids-file", "ids.seq", "file keeping the count of -ids sequential")
+  encryptionEnv := flag.String("encryption-keys-env", "BLOG_ENCRYPTION_KEYS", "environment variable holding the keys the posts are encrypted with, see encryption.go")
+  encryptionCommand := flag.String("encryption-keys-command", "", "command printing the encryption keys, to get them from a key management service, see encryption.go")
+  compressOver := flag.Int("compress-content-over", 0, "gzip the content of posts of this many bytes or more in the storage, 0 doesn't compress, see internal/store/compress.go")
+  fsckMode := flag.String("fsck", "", "check the posts, revisions and views before serving: check refuses to start on problems, repair fixes them, see fsck.go")
+  dbPath := flag.String("db", "blog.db", "SQLite database used by -storage sqlite")
+  postgresURLEnv := flag.String("postgres-url-env", "BLOG_POSTGRES_URL", "environment variable holding the URL of the database of -storage postgres, see internal/store/postgres.go")
+  migrateTo := flag.String("migrate", "", "migrate the SQLite or Postgres database (up, down or a version number) and exit, see migrate.go")
+  smtpAddr := flag.String("smtp-addr", "", "host:port of the SMTP server used to email subscribers, email notifications are disabled without it")
+  smtpUser := flag.String("smtp-user", "", "SMTP user, the password comes from -smtp-password-from")
+  smtpPasswordFrom := flag.String("smtp-password-from", "env:BLOG_SMTP_PASSWORD", "secret reference to read the password of -smtp-user from, see secrets.go")
+  smtpFrom := flag.String("smtp-from", "blog@localhost", "sender of the notification emails")
+  emailTemplates := flag.String("email-templates", "", "file with the email templates, empty uses templates/email.tmpl compiled into the server")
+  eventBusKind := flag.String("event-bus", "", "publish post events to a message bus: kafka or nats, see bus.go")
+  eventBusURL := flag.String("event-bus-url", "", "comma separated Kafka brokers or the NATS server URL")
+  eventBusTopic := flag.String("event-bus-topic", "blog.posts", "Kafka topic, or NATS subject prefix, the events are published to")
+  redisAddr := flag.String("redis-addr", "", "host:port of a Redis server to count views and cache posts in, see redis.go")
+  redisTTL := flag.Duration("redis-cache-ttl", time.Minute, "how long posts stay cached in Redis")
+  redisPasswordFrom := flag.String("redis-password-from", "", "secret reference to read the password of the Redis server from, see secrets.go")
+  timeoutList := flag.String("method-timeouts", "GetPosts=2s,CreatePost=5s", "comma separated Method=duration limits on how long a call may run, see timeouts.go")
+  debugLog := flag.Bool("debug-log", false, "log every request and response, can be switched at runtime through the Admin service, see debuglog.go")
+  debugRedact := flag.String("debug-redact", strings.Join(defaultRedactedFields, ","), "comma separated fields and metadata keys hidden from the debug log")
+  viewsRetention := flag.Duration("views-retention", 7*24*time.Hour, "how long the time of every view is kept for GetTrendingPosts and GetPostAnalytics, see views.go")
+  summariesRetention := flag.Duration("summaries-retention", 90*24*time.Hour, "how long the daily summaries of the views are kept for GetDailySummary, the weekly ones are kept forever, see summaries.go")
+  viewDedupWindow := flag.Duration("view-dedup-window", 30*time.Minute, "repeat views of the same viewer within this long count once, see views.go")
+  moderationWordlist := flag.String("moderation-wordlist", "", "file of words and phrases posts are moderated against, one per line, see moderation.go")
+  moderationAction := flag.String("moderation-wordlist-action", "reject", "what happens to a post containing a word of -moderation-wordlist: flag or reject")
+  moderationURL := flag.String("moderation-url", "", "URL of an external moderation service posts are sent to before being saved, see moderation.go")
+  duplicateMode := flag.String("duplicate-check", "off", "what to do with a new post repeating the title or content of a recent one: off, warn or reject, see duplicates.go")
+  duplicateWindow := flag.Duration("duplicate-window", 24*time.Hour, "how far back -duplicate-check looks")
+  debugAddr := flag.String("debug-addr", "", "address to serve pprof, expvar and the goroutine stacks on over HTTP, like localhost:6060, see debughttp.go")
+  serveChannelz := flag.Bool("channelz", false, "serve the channelz service of gRPC to admins, see connections.go")
+  serveHTTP := flag.Bool("http", false, "also serve /healthz, /metrics and /sitemap.xml over plain HTTP on the -addr port, see http.go")
+  zstdCompression := flag.Bool("zstd", false, "accept zstd compressed calls, gzip is always accepted, see compression.go")
+  writeDelay := flag.Duration("write-delay", 0, "hold saves back this long and write them at once, 0 writes every save right away, see internal/store/batch.go")
+  queueWorkers := flag.Int("queue-workers", 8, "workers running the tasks of the work queue: webhook deliveries, emails, index rebuilds and delayed writes, see queue.go")
+  queueSize := flag.Int("queue-size", 1000, "tasks waiting for a worker before new ones are dropped")
+  queueConcurrency := flag.String("queue-concurrency", "webhook=4,email=2,index=1,snapshot=1", "comma separated kind=workers caps on the workers a kind of task takes at once")
+  draftRetention := flag.Duration("draft-retention", 30*24*time.Hour, "drafts left untouched this long are cleaned up by the clean-drafts task, 0 keeps them, see drafts.go")
+  maxPinned := flag.Int("max-pinned", 3, "posts pinned at the top of GetPosts at once, 0 turns pinning off, see pins.go")
+  draftAction := flag.String("draft-retention-action", draftsArchive, "what happens to the stale drafts: archive or delete")
+  cronList := flag.String("cron", defaultCronSchedules, "semicolon separated task=schedule list of the recurring tasks, see cron.go")
+  siteURL := flag.String("site-url", "", "address readers reach the blog at, like https://blog.example.com, the sitemap is turned off without it, see sitemap.go")
+  commentReview := flag.String("comment-review", commentReviewAll, "which new comments wait for a moderator: all, or only the ones flagged by moderation, see comments.go")
+  sessionTTL := flag.Duration("session-ttl", 30*24*time.Hour, "how long the session tokens of StartSession last, see sessions.go")
+  challengeBits := flag.Int("challenge-bits", 0, "difficulty of the proof of work anonymous callers solve before CreatePost, 0 asks for none, see challenges.go")
+  hideBanned := flag.Bool("hide-banned", false, "leave the posts of banned authors out of GetPosts, see bans.go")
+  recordPath := flag.String("record", "", "file to append every call of the Blog and Admin services to, with its requests and responses, see replay.go")
+  replayPath := flag.String("replay", "", "serve the calls of a file of -record on -addr instead of the blog, without storage")
+  configPath := flag.String("config", "", "YAML file of flag values, read again on SIGHUP and by the ReloadConfig Admin RPC, see config.go")
+  flag.Parse()
+
+  // The file sets the flags that weren't given on the command line, so it must be read before any flag is used.
+  var config *serverConfig
+  if *configPath != "" {
+    var err error
+    if config, err = loadServerConfig(*configPath); err != nil {
+      log.Fatalf("%s", err)
+    }
+  }
+
+  // A replay answers from its recording and nothing else runs, see replay.go
+  if *replayPath != "" {
+    if err := serveReplay(*addr, *replayPath); err != nil {
+      log.Fatalf("%s", err)
+    }
+    return
+  }
+
+  // Every secret is read once now, one that can't be read stops the server. See secrets.go
+  if *secretsRefresh < time.Second {
+    log.Fatalf("-secrets-refresh must be at least a second")
+  }
+  secrets := newSecretStore()
+  openSecret := func(name, ref string) *secret {
+    sec, err := secrets.open(name, ref)
+    if err != nil {
+      log.Fatalf("%s", err)
+    }
+    return sec
+  }
+  adminSecret := openSecret("admin-token-from", *adminTokenFrom)
+  if adminSecret != nil && *adminToken != "" {
+    log.Fatalf("-admin-token and -admin-token-from can't be given together")
+  }
+  if adminSecret == nil {
+    adminSecret = staticSecret(*adminToken)
+  }
+  sessionSecret := openSecret("session-key-from", *sessionKeyFrom)
+  var smtpPassword *secret
+  if *smtpUser != "" {
+    smtpPassword = openSecret("smtp-password-from", *smtpPasswordFrom)
+  }
+  var redisPassword *secret
+  if *redisAddr != "" {
+    redisPassword = openSecret("redis-password-from", *redisPasswordFrom)
+  }
+  tlsCert, err := newTLSCertificate(openSecret("tls-cert-from", *tlsCertFrom), openSecret("tls-key-from", *tlsKeyFrom))
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+
+  // The stores hand their background work to the queue, so it starts before them.
+  if *queueWorkers <= 0 || *queueSize <= 0 {
+    log.Fatalf("-queue-workers and -queue-size must be positive")
+  }
+  queueLimits, err := parseQueueConcurrency(*queueConcurrency)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  queue := newWorkQueue(*queueWorkers, *queueSize, queueLimits)
+
+  r, err := newRenderer(*rendererName)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+
+  var attachments attachmentStore
+  // Also where the exports go, see export.go
+  var bucket *bucketStore
+  switch *attachmentsBackend {
+  case "disk":
+    attachments = newDiskAttachmentStore(*attachmentsDir)
+  case "s3":
+    bucket, err = newBucketStore(*s3Endpoint, *s3Bucket, *s3Region, !*s3Insecure)
+    if err != nil {
+      log.Fatalf("%s", err)
+    }
+    attachments = &bucketAttachmentStore{bucket: bucket}
+  default:
+    log.Fatalf("unknown attachments backend %q, expected disk or s3", *attachmentsBackend)
+  }
+
+  switch *storageName {
+  case "file":
+    if *migrateTo != "" {
+      log.Fatalf("-migrate only applies to -storage sqlite and postgres")
+    }
+    if !slices.Contains(store.FileFormats, *fileFormat) {
+      log.Fatalf("unknown file format %q, expected json, compact or ndjson", *fileFormat)
+    }
+    postStore = &store.FileStore{Path: filePath, Format: *fileFormat, Gzip: *fileGzip, Background: queue.background("index")}
+  case "sqlite":
+    db, err := store.OpenSQLite(*dbPath)
+    if err != nil {
+      log.Fatalf("failed to open database %s", err)
+    }
+    defer db.Close()
+
+    if *migrateTo != "" {
+      if err := store.Migrate(db, *migrateTo); err != nil {
+        log.Fatalf("failed to migrate database %s", err)
+      }
+      return
+    }
+
+    // Without -migrate the database is brought up to date before we start serving.
+    if err := store.Migrate(db, "up"); err != nil {
+      log.Fatalf("failed to migrate database %s", err)
+    }
+    postStore = &store.SQLiteStore{DB: db}
+  case "postgres":
+    url := os.Getenv(*postgresURLEnv)
+    if url == "" {
+      log.Fatalf("-storage postgres needs the URL of the database in $%s", *postgresURLEnv)
+    }
+    db, err := store.OpenPostgres(url)
+    if err != nil {
+      log.Fatalf("failed to open database %s", err)
+    }
+    defer db.Close()
+
+    if *migrateTo != "" {
+      if err := store.MigratePostgres(db, *migrateTo); err != nil {
+        log.Fatalf("failed to migrate database %s", err)
+      }
+      return
+    }
+
+    if err := store.MigratePostgres(db, "up"); err != nil {
+      log.Fatalf("failed to migrate database %s", err)
+    }
+    postStore = &store.PostgresStore{DB: db}
+  default:
+    log.Fatalf("unknown storage %q, expected file, sqlite or postgres", *storageName)
+  }
+  storageBackend = *storageName
+
+  switch *idFormat {
+  case "uuid":
+  case "ulid":
+    store.IDs = &store.ULIDs{Now: serverClock.Now}
+  case "sequential":
+    sequence, err := store.OpenSequence(*sequencePath)
+    if err != nil {
+      log.Fatalf("failed to open the ID count: %s", err)
+    }
+    store.IDs = sequence
+  default:
+    log.Fatalf("unknown IDs %q, expected uuid, ulid or sequential", *idFormat)
+  }
+
+  // Always in place too, so posts encrypted before fail to load rather than being served encrypted. Under the compression, content is compressed before it is encrypted. See internal/store/encrypt.go
+  keys, err := loadKeyring(*encryptionEnv, *encryptionCommand)
+  if err != nil {
+    log.Fatalf("failed to load the encryption keys: %s", err)
+  }
+  encryption := &store.EncryptingStore{PostStore: postStore, Keys: keys}
+  postStore = encryption
+  revisionKeys = keys
+
+  // The certificates are kept with the posts, encrypted like them. See acme.go
+  serverTLS := tlsCert.config()
+  var acmeManager *autocert.Manager
+  switch {
+  case *acmeDomains != "" && tlsCert != nil:
+    log.Fatalf("-acme-domains and -tls-cert-from can't be given together")
+  case *acmeDomains != "":
+    certCache := &store.CertCache{Dir: acmeCacheDir, Keys: keys}
+    if sqlite, ok := encryption.PostStore.(*store.SQLiteStore); ok {
+      certCache.DB = sqlite.DB
+    }
+    acmeManager = newACMEManager(splitList(*acmeDomains), *acmeEmail, *acmeDirectory, certCache)
+    serverTLS = acmeManager.TLSConfig()
+  case *acmeHTTPAddr != "":
+    log.Fatalf("-acme-http-addr needs -acme-domains")
+  }
+  if *spiffeSocket != "" {
+    if serverTLS != nil {
+      log.Fatalf("-spiffe-socket can't be given with -tls-cert-from or -acme-domains, the SVID is the certificate of the server")
+    }
+    workload, err := spiffe.Open(*spiffeSocket)
+    if err != nil {
+      log.Fatalf("%s", err)
+    }
+    defer workload.Close()
+    log.Printf("serving mutual TLS as %s", workload.ID())
+    serverTLS = workload.ServerTLSConfig()
+  }
+
+  // Always in place, so content compressed before keeps loading when compression is turned off. See internal/store/compress.go
+  if *compressOver < 0 {
+    log.Fatalf("-compress-content-over can't be negative")
+  }
+  contentStore := &store.CompressingStore{PostStore: postStore, Threshold: *compressOver}
+  postStore = contentStore
+
+  // Under the Redis wrapper, which must invalidate on every save rather than on the delayed writes.
+  var batching *store.BatchingStore
+  if *writeDelay < 0 {
+    log.Fatalf("-write-delay can't be negative")
+  }
+  if *writeDelay > 0 {
+    batching = &store.BatchingStore{PostStore: postStore, Window: *writeDelay, Background: queue.background("snapshot"), OnError: func(err error) {
+      log.Printf("failed to write the posts, trying again in %s: %v", *writeDelay, err)
+    }}
+    postStore = batching
+  }
+
+  var cache *redisCache
+  if *redisAddr != "" {
+    cache = newRedisCache(*redisAddr, redisPassword, *redisTTL)
+    if err := cache.client.Ping(context.Background()).Err(); err != nil {
+      log.Fatalf("failed to connect to redis %s", err)
+    }
+    postStore = &redisInvalidatingStore{PostStore: postStore, cache: cache}
+  }
+
+  switch *fsckMode {
+  case "":
+  case "check", "repair":
+    if err := runFsck(contentStore, attachments, *fsckMode == "repair"); err != nil {
+      log.Fatalf("%s", err)
+    }
+  default:
+    log.Fatalf("unknown -fsck mode %q, expected check or repair", *fsckMode)
+  }
+
+  // loadPost gives the posts written before IDs existed their ID, doing it once now means openPosts never returns a post without one.
+  if err := loadPost(&pb.Posts{}); err != nil {
+    log.Fatalf("%s", err)
+  }
+
   // Contrary to the web example, in here we need to do a bit more setup
-  // Set up TCP connection and start listening on port 3000
-  lis, err := net.Listen("tcp", ":3000")
+  // Set up the TCP connections and start listening on the -addr flag (port 3000 by default), see listeners.go
+  listenAddrs, err := parseListenAddrs(*addr)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  listeners, err := listen(listenAddrs)
 
   if err != nil {
     log.Fatalf("failed to listen %s", err)
   }
+  // Connections over the limit of their IP are closed right away, see peerlimits.go
+  peerLimits := newPeerLimits(*maxConnsPerIP, *maxStreamsPerIP)
+  for i, lis := range listeners {
+    listeners[i] = peerLimits.listen(lis)
+  }
 
-  // Create the instance of the gRPC server
-  grpcServer := grpc.NewServer()
+  registerCompressors(*zstdCompression)
+  oidc, err := newOIDCVerifier(*oidcIssuer, *oidcAudience, *oidcAdminScope)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  var sessionKey []byte
+  if sessionSecret != nil {
+    sessionKey, err = parseSessionKey(sessionSecret.get(), "-session-key-from")
+  } else {
+    sessionKey, err = loadSessionKey(sessionKeyPath)
+  }
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  sessions, err := newSessions(sessionKey, historyPath, *sessionTTL)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  if sessionSecret != nil {
+    sessionSecret.changed(func(value []byte) error {
+      key, err := parseSessionKey(value, "-session-key-from")
+      if err != nil {
+        return err
+      }
+      sessions.rotateKey(key)
+      return nil
+    })
+  }
+  auth := newAuthenticator(adminSecret, oidc, sessions)
+  signatures, err := loadSigningKeys(*signingKeys)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  spiffePolicy, err := loadSPIFFEPolicy(*spiffePolicyPath)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  recorder, err := newCallRecorder(*recordPath)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  defer recorder.Close()
+  comments, err := newCommentStore(commentsPath, *commentReview)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  bans, err := newBanList(bansPath)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  challenges, err := newChallenges(*challengeBits, sessions)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  drafts, err := newDraftPolicy(*draftRetention, *draftAction)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  if *maxPinned < 0 {
+    log.Fatalf("-max-pinned can't be negative")
+  }
+  if *auditRetention < 0 {
+    log.Fatalf("-audit-retention can't be negative")
+  }
+  audit := newAuditLog(*auditPath, *auditRetention)
+  metrics := newServerMetrics()
+  metrics.collect(queue.writeMetrics)
+  slowCalls := newSlowCallWatchdog(*slowCallThreshold, *slowCallStacks)
+  metrics.collect(slowCalls.writeMetrics)
+  concurrency := newConcurrencyLimiter(*maxConcurrent)
+  debug := newDebugLogger(*debugLog, splitList(*debugRedact))
+  parsedTimeouts, err := parseMethodTimeouts(*timeoutList)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  timeouts := newServerTimeouts(parsedTimeouts)
+  faults, err := parseChaos(*chaosSpec)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  chaos := newChaosInjector(faults)
+  if faults.enabled() {
+    log.Printf("-chaos is set, the calls of the Blog service fail on purpose: %s", *chaosSpec)
+  }
+  if *viewsRetention < time.Minute {
+    log.Fatalf("-views-retention must be at least a minute")
+  }
+  if *viewDedupWindow < 0 {
+    log.Fatalf("-view-dedup-window can't be negative")
+  }
+  views, err := newViewLog(viewsPath, viewersPath, *viewsRetention, *viewDedupWindow)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  summaries, err := newSummaryLog(summariesPath, *summariesRetention)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+
+  // Maintenance mode reports through the health service, which is registered below.
+  healthServer := health.NewServer()
+  maintenance := newMaintenanceMode(healthServer)
+
+  // Create the instance of the gRPC server. Interceptors run in the order they are given, each one wrapping the next, see mirror.go for an introduction to them.
+  connections := newConnectionTracker(listeners, peerLimits)
+  grpcServer := grpc.NewServer(
+    serverCredentials(serverTLS),
+    grpc.StatsHandler(connections),
+    // 0 is no limit for gRPC too. The calls running get a minute to finish before the connection closes.
+    grpc.KeepaliveParams(keepalive.ServerParameters{MaxConnectionAge: *maxConnectionAge, MaxConnectionAgeGrace: time.Minute}),
+    grpc.ChainUnaryInterceptor(
+      recorder.unaryInterceptor,
+      peerLimits.unaryInterceptor,
+      signatures.unaryInterceptor,
+      spiffePolicy.unaryInterceptor,
+      reqctx.UnaryServerInterceptor(auth.identity),
+      auth.channelzInterceptor,
+      localizeUnaryInterceptor,
+      debug.unaryInterceptor,
+      slowCalls.unaryInterceptor,
+      metrics.unaryInterceptor,
+      chaos.unaryInterceptor,
+      maintenance.unaryInterceptor,
+      concurrency.unaryInterceptor,
+      timeouts.unaryInterceptor,
+      statsUnaryInterceptor,
+      validateUnaryInterceptor,
+      audit.unaryInterceptor,
+      bans.unaryInterceptor,
+    ),
+    grpc.ChainStreamInterceptor(
+      recorder.streamInterceptor,
+      peerLimits.streamInterceptor,
+      signatures.streamInterceptor,
+      spiffePolicy.streamInterceptor,
+      reqctx.StreamServerInterceptor(auth.identity),
+      localizeStreamInterceptor,
+      debug.streamInterceptor,
+      metrics.streamInterceptor,
+      chaos.streamInterceptor,
+      maintenance.streamInterceptor,
+      concurrency.streamInterceptor,
+      timeouts.streamInterceptor,
+      statsStreamInterceptor,
+      validateStreamInterceptor,
+      audit.streamInterceptor,
+      bans.streamInterceptor,
+    ),
+  )
+
+  /*
+    HEALTH CHECKS
+
+    gRPC ships with a standard health service (grpc.health.v1.Health) that load balancers, Kubernetes probes and tools like grpc-health-probe know how to call. The empty service name reports on the server as a whole, and the job manager reports every background job under its own name.
+  */
+  healthpb.RegisterHealthServer(grpcServer, healthServer)
+  jobs := newJobManager(healthServer)
 
   /*
     Register our server implementation with the gRPC server. As mentioned previously the RegisterBlogServer requires our server to implement the BlogServer interface
@@ -226,10 +1267,232 @@ func main() {
 
     Showcasing why we embedded the pb.UnimplementedBlogServer into our server struct.
   */
-  pb.RegisterBlogServer(grpcServer, &server{})
+  moderator, err := newModerator(*moderationWordlist, *moderationAction, *moderationURL)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+
+  duplicates, err := newDuplicateCheck(*duplicateMode, *duplicateWindow)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+
+  broker := newPostBroker()
+  authors, err := newAuthorIndex(broker, authorBiosPath)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  sitemap, err := newSitemap(*siteURL, broker)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  email, err := newEmailNotifier(*smtpAddr, *smtpUser, smtpPassword, *smtpFrom, *emailTemplates, broker, queue)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  notifications, err := newNotificationStore(notificationsPath, broker)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+
+  srv := &server{
+    attachments:     attachments,
+    renderer:        r,
+    broker:          broker,
+    scheduleChanged: make(chan struct{}, 1),
+    maxRevisions:    *maxRevisions,
+    auth:            auth,
+    audit:           audit,
+    webhooks:        newWebhookDispatcher(webhooksPath, broker, queue),
+    email:           email,
+    redis:           cache,
+    views:           views,
+    summaries:       summaries,
+    authors:         authors,
+    related:         newRelatedIndex(broker),
+    sitemap:         sitemap,
+    moderator:       moderator,
+    duplicates:      duplicates,
+    maintenance:     maintenance,
+    drafts:          drafts,
+    maxPinned:       *maxPinned,
+    templates:       newTemplateStore(postTemplatesPath),
+    series:          newSeriesStore(seriesPath),
+    sessions:        sessions,
+    notifications:   notifications,
+    comments:        comments,
+    reports:         newReportStore(reportsPath),
+    bans:            bans,
+    hideBanned:      *hideBanned,
+    challenges:      challenges,
+  }
+  pb.RegisterBlogServer(grpcServer, srv)
+
+  cron := newCronScheduler()
+  cron.register("compact-audit", "drops the entries of the audit log older than -audit-retention", audit.compact)
+  cron.register("clean-drafts", "archives or deletes the drafts left untouched for longer than -draft-retention", srv.cleanDrafts)
+  cron.register("rollup-summaries", "sums the views of the days that ended by post and author", srv.rollupSummaries)
+  cron.register("rollup-views", "merges the minutes of views older than a day into their hour", views.rollup)
+  if err := cron.setSchedules(*cronList); err != nil {
+    log.Fatalf("%s", err)
+  }
+  pb.RegisterAdminServer(grpcServer, &adminServer{auth: auth, debug: debug, storage: contentStore, batching: batching, maintenance: maintenance, config: config, cron: cron, bans: bans, encryption: encryption, connections: connections, metrics: metrics, audit: audit, views: views, bucket: bucket})
+  if *serveChannelz {
+    channelz.RegisterChannelzServiceToServer(grpcServer)
+  }
+  jobs.Start("scheduler", srv.runScheduler)
+  jobs.Start("cron", cron.run)
+  jobs.Start("webhooks", srv.webhooks.run)
+  jobs.Start("views", views.run)
+  jobs.Start("sessions", sessions.run)
+  jobs.Start("notifications", notifications.run)
+  jobs.Start("related", srv.related.run)
+  jobs.Start("authors", srv.authors.run)
+  if sitemap != nil {
+    jobs.Start("sitemap", sitemap.run)
+  }
+  if email != nil {
+    jobs.Start("email", email.run)
+  }
+
+  bus, err := newEventBus(*eventBusKind, *eventBusURL, *eventBusTopic)
+  if err != nil {
+    log.Fatalf("%s", err)
+  }
+  if bus != nil {
+    defer bus.Close()
+    jobs.Start("event-bus", publishEvents(broker, bus))
+  }
+
+  if *debugAddr != "" {
+    publishExpvars(metrics, connections)
+    jobs.Start("debug-http", serveDebug(*debugAddr))
+  }
+  if acmeManager != nil && *acmeHTTPAddr != "" {
+    jobs.Start("acme-http", serveACMEChallenges(acmeManager, *acmeHTTPAddr))
+  }
+  if !secrets.empty() {
+    jobs.Start("secrets", secrets.run(*secretsRefresh))
+  }
+
+  if config != nil {
+    handleServerConfig(config, concurrency, peerLimits, slowCalls, timeouts, chaos, debug, cache, cron)
+    jobs.Start("config", config.reloadOnHangup)
+  }
+
+  // Every gRPC server we start gets added here so they can all be stopped on shutdown.
+  servers := []*grpc.Server{grpcServer}
+
+  // The mirror is a completely separate gRPC server with its own listener, so it runs on its own goroutine while the primary server blocks below.
+  if *mirrorAddr != "" {
+    mirrorLis, err := net.Listen("tcp", *mirrorAddr)
+
+    if err != nil {
+      log.Fatalf("failed to listen %s", err)
+    }
+
+    limiter := newPeerLimiter(*mirrorRPS, *mirrorBurst)
+    jobs.Start("mirror-janitor", func(ctx context.Context) error {
+      limiter.janitor(ctx, 10*time.Minute)
+      return nil
+    })
+
+    mirrorServer := grpc.NewServer(
+      serverCredentials(serverTLS),
+      grpc.ChainUnaryInterceptor(
+        // Only the sessions are known on the mirror, admin tokens have nothing to do there.
+        reqctx.UnaryServerInterceptor(newAuthenticator(nil, nil, sessions).identity),
+        localizeUnaryInterceptor,
+        limiter.unaryInterceptor,
+        statsUnaryInterceptor,
+        validateUnaryInterceptor,
+      ),
+    )
+    mirror := newMirrorServer(*mirrorTTL, r, sessions)
+    pb.RegisterBlogServer(mirrorServer, mirror)
+    if config != nil {
+      handleMirrorConfig(config, mirror, limiter)
+    }
+
+    go func() {
+      if err := mirrorServer.Serve(mirrorLis); err != nil {
+        log.Fatalf("Fail to serve mirror %s", err)
+      }
+    }()
+    servers = append(servers, mirrorServer)
+  }
+
+  /*
+    GRACEFUL SHUTDOWN
+
+    On Ctrl+C (SIGINT) or SIGTERM, GracefulStop stops accepting new connections and waits for the in-flight RPCs to finish, which makes Serve below return. Only then do we stop the background jobs, so no request is left talking to a job that is already gone.
+  */
+  ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+  defer stop()
 
-  // Finally we hook our server definitions to the tcp listener to start receiving requests.
-  if err := grpcServer.Serve(lis); err != nil {
-    log.Fatalf("Fail to server %s", err)
+  // With -http the listener belongs to an HTTP server that hands gRPC requests over to grpcServer, see http.go.
+  var httpServer *http.Server
+  if *serveHTTP {
+    httpServer = &http.Server{Handler: newHTTPHandler(grpcServer, healthServer, metrics, sitemap)}
+    httpServer.TLSConfig = serverTLS
+  }
+
+  go func() {
+    <-ctx.Done()
+    log.Printf("shutting down")
+    healthServer.Shutdown()
+    srv.broker.close()
+    srv.notifications.close()
+    metrics.closeStreams()
+    drained := connections.logDraining()
+    defer drained()
+    for _, srv := range servers {
+      // GracefulStop doesn't support connections handed over by ServeHTTP, so those are closed instead.
+      if srv == grpcServer && httpServer != nil {
+        httpServer.Shutdown(context.Background())
+        srv.Stop()
+        continue
+      }
+      srv.GracefulStop()
+    }
+  }()
+
+  // Finally we hook our server definitions to the listeners to start receiving requests, one goroutine each, until the shutdown makes them all return.
+  var serving sync.WaitGroup
+  for _, lis := range listeners {
+    serving.Add(1)
+    go func() {
+      defer serving.Done()
+      if httpServer != nil && serverTLS != nil {
+        // The certificate comes from TLSConfig, ServeTLS only reads the files it is given.
+        if err := httpServer.ServeTLS(lis, "", ""); err != http.ErrServerClosed {
+          log.Fatalf("Fail to server %s", err)
+        }
+      } else if httpServer != nil {
+        if err := httpServer.Serve(lis); err != http.ErrServerClosed {
+          log.Fatalf("Fail to server %s", err)
+        }
+      } else if err := grpcServer.Serve(lis); err != nil {
+        log.Fatalf("Fail to server %s", err)
+      }
+    }()
+  }
+  serving.Wait()
+
+  shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+  defer cancel()
+
+  if err := jobs.Shutdown(shutdownCtx); err != nil {
+    log.Printf("failed to stop background jobs: %v", err)
+  }
+  // After the jobs, which queue the tasks.
+  if err := queue.shutdown(shutdownCtx); err != nil {
+    log.Printf("failed to run the queued tasks: %v", err)
+  }
+  // Last, no call nor job can save anymore.
+  if batching != nil {
+    if _, err := batching.Flush(); err != nil {
+      log.Printf("failed to write the pending posts: %v", err)
+    }
   }
 }
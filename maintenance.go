@@ -0,0 +1,238 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "log"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/health"
+  healthpb "google.golang.org/grpc/health/grpc_health_v1"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/types/known/durationpb"
+)
+
+/*
+  MAINTENANCE MODE
+
+  Some work is best done while nobody is using the server, like moving posts.json around or running -fsck. SetMaintenance turns maintenance mode on without stopping the server:
+
+    go run ./client maintenance -token secret -reason "moving to SQLite" -retry-after 2m -wait on
+    go run ./client maintenance -token secret off
+
+  While it is on:
+    - calls that were already running finish normally. With Wait, SetMaintenance only answers once they have and the saves held back by -write-delay are written, the server is drained and its files can be touched
+    - every new call fails with Unavailable. The status carries two of the standard error details: an ErrorInfo with the reason MAINTENANCE, so clients can tell it apart from other Unavailable errors, and a RetryInfo saying when to try again. blogctl waits and retries on its own (see conn.go in the client)
+    - open WatchPosts streams are closed with the same status, plus an x-close-reason trailer. It is the gRPC version of the GOAWAY frame HTTP/2 servers send before closing a connection: clients learn why the stream ended and when to come back, rather than seeing it drop
+    - the health service reports NOT_SERVING, so load balancers send the traffic to other replicas in the meantime
+
+  The Admin service and the health checks are left alone, otherwise there would be no way to turn maintenance off, or to know it is on.
+*/
+const (
+  defaultMaintenanceRetry = 30 * time.Second
+  closeReasonTrailer      = "x-close-reason"
+  maintenanceReason       = "MAINTENANCE"
+)
+
+type maintenanceMode struct {
+  health *health.Server
+
+  mu         sync.Mutex
+  enabled    bool
+  reason     string
+  retryAfter time.Duration
+  since      time.Time
+  // closing is closed when maintenance starts, which ends the WatchPosts streams waiting on it.
+  closing  chan struct{}
+  inFlight int
+  // drained is signalled every time a call finishes, Wait waits on it.
+  drained *sync.Cond
+}
+
+func newMaintenanceMode(health *health.Server) *maintenanceMode {
+  m := &maintenanceMode{health: health, closing: make(chan struct{})}
+  m.drained = sync.NewCond(&m.mu)
+
+  return m
+}
+
+// exemptFromMaintenance tells the calls maintenance leaves alone.
+func exemptFromMaintenance(method string) bool {
+  return strings.HasPrefix(method, "/"+pb.Admin_ServiceDesc.ServiceName+"/") || strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
+func (m *maintenanceMode) set(enabled bool, reason string, retryAfter time.Duration) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  if enabled && !m.enabled {
+    m.since = serverClock.Now()
+    close(m.closing)
+    m.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+  }
+  if !enabled && m.enabled {
+    m.closing = make(chan struct{})
+    m.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+  }
+
+  m.enabled, m.reason, m.retryAfter = enabled, reason, retryAfter
+}
+
+// wait blocks until no call is running anymore or the context is done.
+func (m *maintenanceMode) wait(ctx context.Context) {
+  // Cond can't wait on a context, so the context wakes the waiter up when it is done.
+  stop := context.AfterFunc(ctx, func() {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.drained.Broadcast()
+  })
+  defer stop()
+
+  m.mu.Lock()
+  defer m.mu.Unlock()
+  for m.inFlight > 0 && ctx.Err() == nil {
+    m.drained.Wait()
+  }
+}
+
+func (m *maintenanceMode) get() *pb.Maintenance {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  state := &pb.Maintenance{Enabled: m.enabled, InFlight: int32(m.inFlight)}
+  if m.enabled {
+    state.Reason, state.RetryAfterSeconds, state.Since = m.reason, int32(m.retryAfter/time.Second), m.since.UTC().Format(time.RFC3339)
+  }
+
+  return state
+}
+
+// closingStreams returns the channel closed when maintenance starts.
+func (m *maintenanceMode) closingStreams() <-chan struct{} {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  return m.closing
+}
+
+// unavailable is the status of the calls made during maintenance.
+func (m *maintenanceMode) unavailable() error {
+  m.mu.Lock()
+  reason, retryAfter := m.reason, m.retryAfter
+  m.mu.Unlock()
+
+  message := "the server is down for maintenance"
+  if reason != "" {
+    message += ": " + reason
+  }
+  st, err := status.New(codes.Unavailable, fmt.Sprintf("%s, try again in %s", message, retryAfter)).WithDetails(
+    &errdetails.ErrorInfo{Reason: maintenanceReason, Domain: "blog", Metadata: map[string]string{"reason": reason}},
+    &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+  )
+  if err != nil {
+    return status.Errorf(codes.Unavailable, "%s", message)
+  }
+
+  return st.Err()
+}
+
+// begin counts the call as running, or returns false when it must be turned away.
+func (m *maintenanceMode) begin() bool {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  if m.enabled {
+    return false
+  }
+  m.inFlight++
+
+  return true
+}
+
+func (m *maintenanceMode) end() {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  m.inFlight--
+  m.drained.Broadcast()
+}
+
+func (m *maintenanceMode) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  if exemptFromMaintenance(info.FullMethod) {
+    return handler(ctx, req)
+  }
+
+  if !m.begin() {
+    return nil, m.unavailable()
+  }
+  defer m.end()
+
+  return handler(ctx, req)
+}
+
+func (m *maintenanceMode) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  if exemptFromMaintenance(info.FullMethod) {
+    return handler(srv, ss)
+  }
+
+  // A WatchPosts or StreamNotifications stream never finishes on its own, it is closed when maintenance starts rather than waited for. See WatchPosts.
+  if info.FullMethod == pb.Blog_WatchPosts_FullMethodName || info.FullMethod == pb.Blog_StreamNotifications_FullMethodName {
+    if m.get().Enabled {
+      return m.closeStream(ss)
+    }
+    return handler(srv, ss)
+  }
+
+  if !m.begin() {
+    return m.closeStream(ss)
+  }
+  defer m.end()
+
+  return handler(srv, ss)
+}
+
+// closeStream is what streams get during maintenance, and what WatchPosts returns when it starts.
+func (m *maintenanceMode) closeStream(stream grpc.ServerStream) error {
+  stream.SetTrailer(metadata.Pairs(closeReasonTrailer, "maintenance"))
+
+  return m.unavailable()
+}
+
+func (a *adminServer) SetMaintenance(ctx context.Context, req *pb.SetMaintenanceRequest) (*pb.Maintenance, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  retryAfter := time.Duration(req.GetRetryAfterSeconds()) * time.Second
+  if retryAfter == 0 {
+    retryAfter = defaultMaintenanceRetry
+  }
+
+  a.maintenance.set(req.GetEnabled(), req.GetReason(), retryAfter)
+  log.Printf("maintenance enabled=%t reason=%q", req.GetEnabled(), req.GetReason())
+
+  if req.GetEnabled() && req.GetWait() {
+    a.maintenance.wait(ctx)
+    // Drained, but the files are only current once the delayed saves are written.
+    if _, err := a.flush(); err != nil {
+      return nil, err
+    }
+  }
+
+  return a.maintenance.get(), nil
+}
+
+func (a *adminServer) GetMaintenance(ctx context.Context, _ *pb.GetMaintenanceRequest) (*pb.Maintenance, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  return a.maintenance.get(), nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  "io"
+  "net/http"
+  "sort"
+  "strings"
+  "sync"
+  "sync/atomic"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  METRICS
+
+  serverMetrics counts every RPC the server handles and exposes the numbers in the Prometheus text format on /metrics (see http.go). The format is simple enough that we write it by hand instead of pulling in the Prometheus client library:
+
+    # TYPE grpc_server_handled_total counter
+    grpc_server_handled_total{grpc_method="GetPosts",grpc_code="OK"} 3
+
+  Like the other cross-cutting concerns, the numbers are collected by a pair of interceptors, so the handlers don't know they are being measured. Other parts of the server add their own series with collect, like the work queue (see queue.go). The same interceptors feed the reports of StreamServerStats, see serverstats.go
+*/
+type serverMetrics struct {
+  inFlight atomic.Int64
+  // collectors write the series of collect, after the ones of the RPCs.
+  collectors []func(w io.Writer)
+
+  mu        sync.Mutex
+  handled   map[handledKey]int64
+  durations map[string]*durationStat
+  // The windows of the StreamServerStats calls, closed on shutdown.
+  windows   map[*statsWindow]struct{}
+  closed    chan struct{}
+  closeOnce sync.Once
+}
+
+type handledKey struct {
+  method string
+  code   string
+}
+
+type durationStat struct {
+  sum   float64
+  count int64
+}
+
+func newServerMetrics() *serverMetrics {
+  return &serverMetrics{
+    handled:   make(map[handledKey]int64),
+    durations: make(map[string]*durationStat),
+    windows:   make(map[*statsWindow]struct{}),
+    closed:    make(chan struct{}),
+  }
+}
+
+// collect adds the series written by write to /metrics. It must be called before the server starts.
+func (m *serverMetrics) collect(write func(w io.Writer)) {
+  m.collectors = append(m.collectors, write)
+}
+
+func (m *serverMetrics) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  start := m.begin()
+  resp, err := handler(ctx, req)
+  m.end(info.FullMethod, start, err, true)
+
+  return resp, err
+}
+
+func (m *serverMetrics) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  start := m.begin()
+  err := handler(srv, ss)
+  m.end(info.FullMethod, start, err, false)
+
+  return err
+}
+
+func (m *serverMetrics) begin() time.Time {
+  m.inFlight.Add(1)
+  return time.Now()
+}
+
+func (m *serverMetrics) end(fullMethod string, start time.Time, err error, unary bool) {
+  m.inFlight.Add(-1)
+
+  // FullMethod looks like /grpc_tutorial.Blog/GetPosts, the last segment is enough to tell the RPCs apart.
+  method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  m.handled[handledKey{method: method, code: status.Code(err).String()}]++
+
+  d, ok := m.durations[method]
+  if !ok {
+    d = &durationStat{}
+    m.durations[method] = d
+  }
+  elapsed := time.Since(start)
+  d.sum += elapsed.Seconds()
+  d.count++
+
+  for w := range m.windows {
+    w.add(method, elapsed, err, unary)
+  }
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format. Series are sorted so consecutive scrapes are easy to diff.
+func (m *serverMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+  fmt.Fprintln(w, "# HELP grpc_server_handled_total Total number of RPCs completed on the server, by method and status code.")
+  fmt.Fprintln(w, "# TYPE grpc_server_handled_total counter")
+  keys := make([]handledKey, 0, len(m.handled))
+  for k := range m.handled {
+    keys = append(keys, k)
+  }
+  sort.Slice(keys, func(i, j int) bool {
+    if keys[i].method != keys[j].method {
+      return keys[i].method < keys[j].method
+    }
+    return keys[i].code < keys[j].code
+  })
+  for _, k := range keys {
+    fmt.Fprintf(w, "grpc_server_handled_total{grpc_method=%q,grpc_code=%q} %d\n", k.method, k.code, m.handled[k])
+  }
+
+  fmt.Fprintln(w, "# HELP grpc_server_handling_seconds Time spent handling RPCs, by method.")
+  fmt.Fprintln(w, "# TYPE grpc_server_handling_seconds summary")
+  methods := make([]string, 0, len(m.durations))
+  for method := range m.durations {
+    methods = append(methods, method)
+  }
+  sort.Strings(methods)
+  for _, method := range methods {
+    d := m.durations[method]
+    fmt.Fprintf(w, "grpc_server_handling_seconds_sum{grpc_method=%q} %g\n", method, d.sum)
+    fmt.Fprintf(w, "grpc_server_handling_seconds_count{grpc_method=%q} %d\n", method, d.count)
+  }
+
+  fmt.Fprintln(w, "# HELP grpc_server_in_flight RPCs currently being handled.")
+  fmt.Fprintln(w, "# TYPE grpc_server_in_flight gauge")
+  fmt.Fprintf(w, "grpc_server_in_flight %d\n", m.inFlight.Load())
+
+  for _, write := range m.collectors {
+    write(w)
+  }
+}
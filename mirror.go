@@ -0,0 +1,247 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/reqctx"
+  "net"
+  "strings"
+  "sync"
+  "time"
+
+  "golang.org/x/time/rate"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/peer"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  READ-ONLY PUBLIC MIRROR
+
+  When the server is started with -mirror-addr a second gRPC server listens on that address. It shares the same posts.json as the primary listener but only exposes the read RPCs, caches responses aggressively and applies a per client rate limit. This makes it easy to publish a public copy of a private editorial server.
+
+    go run . -mirror-addr :3001
+
+  The mirrorServer embeds pb.UnimplementedBlogServer but, unlike our main server, only overrides the read methods. Every other RPC (CreatePost today, and any mutating RPC added to the proto in the future) falls through to the Unimplemented default, so the mirror is read-only by construction.
+*/
+type mirrorServer struct {
+  pb.UnimplementedBlogServer
+
+  ttl      time.Duration
+  renderer renderer
+  // The same sessions as the primary, so a reader keeps its viewer on both. See sessions.go
+  sessions *sessions
+
+  mu        sync.Mutex
+  cached    *pb.Posts
+  expiresAt time.Time
+}
+
+func newMirrorServer(ttl time.Duration, r renderer, sessions *sessions) *mirrorServer {
+  return &mirrorServer{ttl: ttl, renderer: r, sessions: sessions}
+}
+
+// setTTL changes how long the posts stay cached, the posts cached already keep their expiry. See config.go
+func (m *mirrorServer) setTTL(ttl time.Duration) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  m.ttl = ttl
+}
+
+/*
+  The mirror doesn't count views, RecordView is not one of the read methods it overrides: it reads the posts straight from the file and keeps them in memory until the TTL expires. Public readers get slightly stale data in exchange for never writing to the primary's storage.
+*/
+func (m *mirrorServer) GetPosts(ctx context.Context, req *pb.GetPostsRequest) (*pb.Posts, error) {
+  filter, err := parseFilter(req.GetFilter())
+  if err != nil {
+    return nil, err
+  }
+  fields, err := parseReadMask(req.GetReadMask())
+  if err != nil {
+    return nil, err
+  }
+  if err := checkOrder(req.GetOrderBy()); err != nil {
+    return nil, err
+  }
+  token, err := parsePageToken(req.GetPageToken())
+  if err != nil {
+    return nil, err
+  }
+  read, err := m.sessions.readPosts(ctx, req.GetUnreadOnly())
+  if err != nil {
+    return nil, err
+  }
+
+  posts, err := m.snapshot(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  page, _ := paginate(sortPosts(dropRead(filter.apply(publishedPosts(posts)), read), req.GetOrderBy()), req.GetPageSize(), token)
+  return fields.apply(page), nil
+}
+
+func (m *mirrorServer) SyncChanges(ctx context.Context, req *pb.SyncChangesRequest) (*pb.SyncChangesResponse, error) {
+  posts, err := m.snapshot(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  return syncFrom(posts, req.GetCursor())
+}
+
+func (m *mirrorServer) RenderPost(ctx context.Context, req *pb.RenderPostRequest) (*pb.RenderedPost, error) {
+  posts, err := m.snapshot(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  return renderPost(m.renderer, publishedPosts(posts), req.GetId(), reqctx.Locale(ctx))
+}
+
+// StartSession doesn't write anything, the token is checked with the key alone, so the mirror can hand out sessions too.
+func (m *mirrorServer) StartSession(ctx context.Context, _ *pb.StartSessionRequest) (*pb.Session, error) {
+  return m.sessions.start(ctx)
+}
+
+// snapshot returns the cached posts, reloading them from the file once the TTL has expired. All of them are cached, SyncChanges reports the deleted and archived ones, the other RPCs only see the published ones. Whether it was a cache hit ends up in the call trailers, see trailers.go
+func (m *mirrorServer) snapshot(ctx context.Context) (*pb.Posts, error) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  if m.cached != nil && serverClock.Now().Before(m.expiresAt) {
+    markCache(ctx, true)
+    return m.cached, nil
+  }
+  markCache(ctx, false)
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  m.cached = posts
+  m.expiresAt = serverClock.Now().Add(m.ttl)
+
+  return m.cached, nil
+}
+
+/*
+  INTERCEPTORS AND RATE LIMITING
+
+  An interceptor is gRPC's version of an HTTP middleware: a function that wraps every call and can inspect the request, short circuit it with an error or call the actual handler.
+
+  peerLimiter keeps one token bucket (golang.org/x/time/rate) per client IP. Each request takes a token from its bucket and once the bucket is empty the call fails with codes.ResourceExhausted, the gRPC equivalent of HTTP 429.
+
+  Readers with a session get a bucket of their own instead, so readers sharing an address don't use up each other's calls. StartSession is always limited by address, otherwise a client could start a new session whenever its bucket runs out.
+*/
+type peerLimiter struct {
+  limit rate.Limit
+  burst int
+
+  mu       sync.Mutex
+  limiters map[string]*limiterEntry
+}
+
+type limiterEntry struct {
+  limiter  *rate.Limiter
+  lastSeen time.Time
+}
+
+func newPeerLimiter(rps float64, burst int) *peerLimiter {
+  return &peerLimiter{
+    limit:    rate.Limit(rps),
+    burst:    burst,
+    limiters: make(map[string]*limiterEntry),
+  }
+}
+
+func (l *peerLimiter) allow(key string) bool {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  entry, ok := l.limiters[key]
+  if !ok {
+    entry = &limiterEntry{limiter: rate.NewLimiter(l.limit, l.burst)}
+    l.limiters[key] = entry
+  }
+  entry.lastSeen = time.Now()
+
+  return entry.limiter.Allow()
+}
+
+// setLimit changes the rate and burst of every client, including the ones already seen. See config.go
+func (l *peerLimiter) setLimit(rps float64, burst int) {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  l.limit, l.burst = rate.Limit(rps), burst
+  for _, entry := range l.limiters {
+    entry.limiter.SetLimit(l.limit)
+    entry.limiter.SetBurst(l.burst)
+  }
+}
+
+func (l *peerLimiter) limits() (float64, int) {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  return float64(l.limit), l.burst
+}
+
+// janitor drops the buckets of clients we haven't seen in a while so the map doesn't grow forever.
+func (l *peerLimiter) janitor(ctx context.Context, idle time.Duration) {
+  ticker := time.NewTicker(idle)
+  defer ticker.Stop()
+
+  for {
+    select {
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+      l.mu.Lock()
+      for key, entry := range l.limiters {
+        if time.Since(entry.lastSeen) > idle {
+          delete(l.limiters, key)
+        }
+      }
+      l.mu.Unlock()
+    }
+  }
+}
+
+func (l *peerLimiter) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  key := peerHost(ctx)
+  if identity := reqctx.Identity(ctx); strings.HasPrefix(identity, sessionIdentity+":") && info.FullMethod != pb.Blog_StartSession_FullMethodName {
+    key = identity
+  }
+  if !l.allow(key) {
+    return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, slow down")
+  }
+
+  return handler(ctx, req)
+}
+
+// peerHost returns the IP of the caller without the port, since a client opens connections from random ports.
+func peerHost(ctx context.Context) string {
+  p, ok := peer.FromContext(ctx)
+  if !ok {
+    return "unknown"
+  }
+
+  host, _, err := net.SplitHostPort(p.Addr.String())
+  if err != nil {
+    return p.Addr.String()
+  }
+
+  return host
+}
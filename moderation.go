@@ -0,0 +1,278 @@
+package main
+
+import (
+  "bufio"
+  "bytes"
+  "context"
+  "encoding/json"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "log"
+  "net/http"
+  "os"
+  "slices"
+  "strings"
+  "time"
+  "unicode"
+)
+
+/*
+  CONTENT MODERATION
+
+  Before a post is saved, by CreatePost or UpdatePost, its title, content and tags go through the moderators the server was started with. A moderator gives one of three verdicts:
+    - allow: the post is saved as usual
+    - flag: the post is saved and published, but with Flagged set and the reason in FlagReason so a human can have a look
+    - reject: the call fails with InvalidArgument and nothing is saved
+
+  Two moderators are built in, and either or both can be enabled:
+    - a word list (-moderation-wordlist): a file with a word or phrase per line, lines starting with # are comments. A post containing one of them gets the verdict of -moderation-wordlist-action, reject by default. Words are matched whole and regardless of case, so "class" doesn't match "ass".
+    - an external service (-moderation-url): the server POSTs {"kind": "post", "title": ..., "content": ..., "author": ..., "tags": [...]} to the URL and expects {"verdict": "allow|flag|reject", "reason": "..."} back. That's where spam classifiers and the moderation APIs of the big providers plug in.
+
+    go run . -moderation-wordlist badwords.txt -moderation-url http://localhost:8080/moderate
+
+  When several moderators are enabled the strictest verdict wins. A service that can't be reached, or answers with an error, gets the post flagged: blocking every author while the service is down would be worse, and flagging still gets the post looked at.
+
+  Moderators implement the moderator interface, the same way renderers do (see render.go), so adding one is a matter of implementing Moderate. The content carries a Kind: comments go through the same moderators as posts, as "comment" without a title or tags (see comments.go).
+*/
+type moderationVerdict int
+
+// The verdicts are ordered from the most lenient to the strictest.
+const (
+  verdictAllow moderationVerdict = iota
+  verdictFlag
+  verdictReject
+)
+
+const moderationTimeout = 3 * time.Second
+
+type moderationContent struct {
+  Kind    string   `json:"kind"`
+  Title   string   `json:"title"`
+  Content string   `json:"content"`
+  Author  string   `json:"author"`
+  Tags    []string `json:"tags"`
+}
+
+type moderationResult struct {
+  verdict moderationVerdict
+  reason  string
+}
+
+type moderator interface {
+  Moderate(ctx context.Context, content moderationContent) (moderationResult, error)
+}
+
+// newModerator returns the moderators enabled by the flags, nil when there are none.
+func newModerator(wordlistPath, wordlistAction, url string) (moderator, error) {
+  var chain moderatorChain
+
+  if wordlistPath != "" {
+    verdict, err := parseVerdict(wordlistAction)
+    if err != nil || verdict == verdictAllow {
+      return nil, fmt.Errorf("-moderation-wordlist-action must be flag or reject, got %q", wordlistAction)
+    }
+    wordlist, err := loadWordlist(wordlistPath, verdict)
+    if err != nil {
+      return nil, err
+    }
+    chain = append(chain, wordlist)
+  }
+
+  if url != "" {
+    chain = append(chain, &serviceModerator{url: url, client: &http.Client{Timeout: moderationTimeout}})
+  }
+
+  if len(chain) == 0 {
+    return nil, nil
+  }
+
+  return chain, nil
+}
+
+func parseVerdict(name string) (moderationVerdict, error) {
+  switch name {
+  case "allow":
+    return verdictAllow, nil
+  case "flag":
+    return verdictFlag, nil
+  case "reject":
+    return verdictReject, nil
+  }
+
+  return 0, fmt.Errorf("unknown verdict %q", name)
+}
+
+// moderatorChain asks every moderator and keeps the strictest verdict.
+type moderatorChain []moderator
+
+func (c moderatorChain) Moderate(ctx context.Context, content moderationContent) (moderationResult, error) {
+  result := moderationResult{verdict: verdictAllow}
+  for _, m := range c {
+    r, err := m.Moderate(ctx, content)
+    if err != nil {
+      return moderationResult{}, err
+    }
+    if r.verdict > result.verdict {
+      result = r
+    }
+    if result.verdict == verdictReject {
+      break
+    }
+  }
+
+  return result, nil
+}
+
+// moderationWords splits text into lowercase words, the unit the word list is matched on.
+func moderationWords(text string) []string {
+  return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+    return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+  })
+}
+
+type wordlistModerator struct {
+  verdict moderationVerdict
+  // Every entry is a word or a phrase already split into words.
+  entries [][]string
+}
+
+func loadWordlist(path string, verdict moderationVerdict) (*wordlistModerator, error) {
+  f, err := os.Open(path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read the word list: %w", err)
+  }
+  defer f.Close()
+
+  w := &wordlistModerator{verdict: verdict}
+  scanner := bufio.NewScanner(f)
+  for scanner.Scan() {
+    line := strings.TrimSpace(scanner.Text())
+    if line == "" || strings.HasPrefix(line, "#") {
+      continue
+    }
+    if entry := moderationWords(line); len(entry) > 0 {
+      w.entries = append(w.entries, entry)
+    }
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, fmt.Errorf("failed to read the word list: %w", err)
+  }
+
+  return w, nil
+}
+
+func (w *wordlistModerator) Moderate(_ context.Context, content moderationContent) (moderationResult, error) {
+  text := moderationWords(content.Title + "\n" + content.Content + "\n" + strings.Join(content.Tags, " "))
+
+  for _, entry := range w.entries {
+    for i := 0; i+len(entry) <= len(text); i++ {
+      if slices.Equal(text[i:i+len(entry)], entry) {
+        return moderationResult{verdict: w.verdict, reason: fmt.Sprintf("contains %q", strings.Join(entry, " "))}, nil
+      }
+    }
+  }
+
+  return moderationResult{verdict: verdictAllow}, nil
+}
+
+type serviceModerator struct {
+  url    string
+  client *http.Client
+}
+
+func (m *serviceModerator) Moderate(ctx context.Context, content moderationContent) (moderationResult, error) {
+  result, err := m.ask(ctx, content)
+  if err != nil {
+    log.Printf("moderation: %s failed, flagging the %s: %v", m.url, content.Kind, err)
+    return moderationResult{verdict: verdictFlag, reason: "the moderation service couldn't be reached"}, nil
+  }
+
+  return result, nil
+}
+
+func (m *serviceModerator) ask(ctx context.Context, content moderationContent) (moderationResult, error) {
+  body, err := json.Marshal(content)
+  if err != nil {
+    return moderationResult{}, err
+  }
+
+  req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewReader(body))
+  if err != nil {
+    return moderationResult{}, err
+  }
+  req.Header.Set("Content-Type", "application/json")
+  req.Header.Set("User-Agent", "blog-moderation/1.0")
+
+  res, err := m.client.Do(req)
+  if err != nil {
+    return moderationResult{}, err
+  }
+  defer res.Body.Close()
+
+  if res.StatusCode/100 != 2 {
+    return moderationResult{}, fmt.Errorf("unexpected status %s", res.Status)
+  }
+
+  var answer struct {
+    Verdict string `json:"verdict"`
+    Reason  string `json:"reason"`
+  }
+  if err := json.NewDecoder(res.Body).Decode(&answer); err != nil {
+    return moderationResult{}, fmt.Errorf("invalid answer: %w", err)
+  }
+  verdict, err := parseVerdict(answer.Verdict)
+  if err != nil {
+    return moderationResult{}, err
+  }
+
+  return moderationResult{verdict: verdict, reason: answer.Reason}, nil
+}
+
+// moderatePost runs the post through the moderators, setting Flagged and FlagReason or returning the error of a rejected post.
+func (s *server) moderatePost(ctx context.Context, post *pb.Post) error {
+  if s.moderator == nil {
+    return nil
+  }
+
+  // The service gets [] rather than null for a post without tags.
+  tags := append([]string{}, post.Tags...)
+
+  result, err := s.moderator.Moderate(ctx, moderationContent{Kind: "post", Title: post.Title, Content: post.Content, Author: post.Author, Tags: tags})
+  if err != nil {
+    return apperr.Errorf(apperr.ErrInternal, "failed to moderate the post: %w", err)
+  }
+
+  switch result.verdict {
+  case verdictReject:
+    return apperr.Errorf(apperr.ErrContentRejected, "the post was rejected by moderation: %s", result.reason)
+  case verdictFlag:
+    post.Flagged, post.FlagReason = true, result.reason
+  default:
+    // An edit that fixes a flagged post clears the flag.
+    post.Flagged, post.FlagReason = false, ""
+  }
+
+  return nil
+}
+
+// moderateComment runs a new comment through the moderators of the posts. A flagged comment waits for a moderator whatever -comment-review says, see comments.go
+func (s *server) moderateComment(ctx context.Context, comment *pb.Comment) error {
+  if s.moderator == nil {
+    return nil
+  }
+
+  result, err := s.moderator.Moderate(ctx, moderationContent{Kind: "comment", Content: comment.Content, Author: comment.Author, Tags: []string{}})
+  if err != nil {
+    return apperr.Errorf(apperr.ErrInternal, "failed to moderate the comment: %w", err)
+  }
+
+  switch result.verdict {
+  case verdictReject:
+    return apperr.Errorf(apperr.ErrContentRejected, "the comment was rejected by moderation: %s", result.reason)
+  case verdictFlag:
+    comment.Status, comment.ModerationReason = pb.CommentStatus_PENDING, result.reason
+  }
+
+  return nil
+}
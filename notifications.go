@@ -0,0 +1,287 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+  "log"
+  "slices"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  NOTIFICATIONS
+
+  Every caller known to the server, an admin, a user of the OIDC provider or a reader with a session (see auth.go and sessions.go), has an inbox. CreatePost remembers who created a post in its CreatedBy, and the inbox of the creator gets a notification when the post is published, right away or by the scheduler, and when a comment on it is approved (see comments.go).
+
+    go run ./client notifications
+    go run ./client notifications -follow
+    go run ./client notifications -read <notification id>
+
+  ListNotifications returns the inbox, the most recent first, MarkNotificationRead marks one notification or all of them as read. StreamNotifications is a server stream like WatchPosts: it stays open and sends every new notification of the caller as it is made, so a client shows them live without asking over and over. The notifications made while no stream was open are in ListNotifications.
+
+  Like the histories the inboxes are kept in memory and written to notifications.json every few seconds. An inbox keeps the last maxNotifications notifications, past that the oldest ones are dropped, read or not.
+*/
+const (
+  notificationsPath = "notifications.json"
+  maxNotifications  = 200
+)
+
+type notificationStore struct {
+  path   string
+  broker *postBroker
+
+  mu sync.Mutex
+  // inbox[identity] holds the notifications of the caller, the most recent last.
+  inbox  map[string][]*notification
+  dirty  bool
+  subs   map[string][]chan *pb.Notification
+  closed bool
+}
+
+type notification struct {
+  ID        string              `json:"id"`
+  Type      pb.NotificationType `json:"type"`
+  PostID    string              `json:"post_id"`
+  PostTitle string              `json:"post_title"`
+  CreatedAt int64               `json:"created_at"`
+  Read      bool                `json:"read,omitempty"`
+}
+
+func newNotificationStore(path string, broker *postBroker) (*notificationStore, error) {
+  n := &notificationStore{path: path, broker: broker, inbox: make(map[string][]*notification), subs: make(map[string][]chan *pb.Notification)}
+  if err := readJSON(path, &n.inbox); err != nil {
+    return nil, err
+  }
+
+  return n, nil
+}
+
+func (n *notification) proto() *pb.Notification {
+  return &pb.Notification{
+    Id:        n.ID,
+    Type:      n.Type,
+    PostId:    n.PostID,
+    PostTitle: n.PostTitle,
+    CreatedAt: time.Unix(n.CreatedAt, 0).UTC().Format(time.RFC3339),
+    Read:      n.Read,
+  }
+}
+
+// notify puts a notification about the post in the inbox of its creator and sends it to the streams the creator has open.
+func (n *notificationStore) notify(kind pb.NotificationType, post *pb.Post) {
+  if post.GetCreatedBy() == "" {
+    return
+  }
+
+  n.mu.Lock()
+  defer n.mu.Unlock()
+
+  entry := &notification{ID: store.NewID(), Type: kind, PostID: post.Id, PostTitle: post.Title, CreatedAt: serverClock.Now().Unix()}
+  inbox := append(n.inbox[post.CreatedBy], entry)
+  if len(inbox) > maxNotifications {
+    inbox = inbox[len(inbox)-maxNotifications:]
+  }
+  n.inbox[post.CreatedBy] = inbox
+  n.dirty = true
+
+  // A stream that can't keep up misses the notification, like the watches of the broker. It is still in the inbox.
+  for _, ch := range n.subs[post.CreatedBy] {
+    select {
+    case ch <- entry.proto():
+    default:
+    }
+  }
+}
+
+// list returns the notifications of the identity, the most recent first, and how many of them are unread.
+func (n *notificationStore) list(identity string, unreadOnly bool, limit int) ([]*pb.Notification, int32) {
+  n.mu.Lock()
+  defer n.mu.Unlock()
+
+  var list []*pb.Notification
+  var unread int32
+  for _, entry := range slices.Backward(n.inbox[identity]) {
+    if !entry.Read {
+      unread++
+    }
+    if (unreadOnly && entry.Read) || (limit > 0 && len(list) == limit) {
+      continue
+    }
+    list = append(list, entry.proto())
+  }
+
+  return list, unread
+}
+
+// markRead marks the notification with the ID as read, or all of them when id is empty. It returns the notifications it marked, false when the ID isn't in the inbox.
+func (n *notificationStore) markRead(identity, id string) ([]*pb.Notification, bool) {
+  n.mu.Lock()
+  defer n.mu.Unlock()
+
+  var marked []*pb.Notification
+  found := id == ""
+  for _, entry := range n.inbox[identity] {
+    if id != "" && entry.ID != id {
+      continue
+    }
+    found = true
+    if !entry.Read {
+      entry.Read = true
+      marked = append(marked, entry.proto())
+      n.dirty = true
+    }
+  }
+
+  return marked, found
+}
+
+// subscribe returns the channel the new notifications of the identity are sent on and a function that must be called to stop receiving.
+func (n *notificationStore) subscribe(identity string) (<-chan *pb.Notification, func()) {
+  ch := make(chan *pb.Notification, 16)
+
+  n.mu.Lock()
+  defer n.mu.Unlock()
+
+  if n.closed {
+    close(ch)
+    return ch, func() {}
+  }
+  n.subs[identity] = append(n.subs[identity], ch)
+
+  return ch, func() {
+    n.mu.Lock()
+    defer n.mu.Unlock()
+
+    if i := slices.Index(n.subs[identity], ch); i >= 0 {
+      n.subs[identity] = slices.Delete(n.subs[identity], i, i+1)
+      if len(n.subs[identity]) == 0 {
+        delete(n.subs, identity)
+      }
+      close(ch)
+    }
+  }
+}
+
+// close ends the streams on shutdown, like postBroker.close
+func (n *notificationStore) close() {
+  n.mu.Lock()
+  defer n.mu.Unlock()
+
+  n.closed = true
+  for identity, subs := range n.subs {
+    for _, ch := range subs {
+      close(ch)
+    }
+    delete(n.subs, identity)
+  }
+}
+
+// run is the background job turning the published posts into notifications and writing the inboxes, see jobs.go
+func (n *notificationStore) run(ctx context.Context) error {
+  events, unsubscribe := n.broker.subscribe(&pb.WatchPostsRequest{Types: []pb.PostEventType{pb.PostEventType_POST_PUBLISHED}})
+  defer unsubscribe()
+
+  ticker := time.NewTicker(viewsFlushEvery)
+  defer ticker.Stop()
+
+  for {
+    select {
+    case <-ctx.Done():
+      return n.flush()
+    case event, ok := <-events:
+      if !ok {
+        return n.flush()
+      }
+      n.notify(pb.NotificationType_NOTIFICATION_POST_PUBLISHED, event.Post)
+    case <-ticker.C:
+      if err := n.flush(); err != nil {
+        log.Printf("notifications: %v", err)
+      }
+    }
+  }
+}
+
+func (n *notificationStore) flush() error {
+  n.mu.Lock()
+  defer n.mu.Unlock()
+
+  if !n.dirty {
+    return nil
+  }
+  if err := replaceFile(n.path, n.inbox); err != nil {
+    return fmt.Errorf("failed to save the notifications: %w", err)
+  }
+  n.dirty = false
+
+  return nil
+}
+
+func (s *server) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.Notifications, error) {
+  identity, err := requireIdentity(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  list, unread := s.notifications.list(identity, req.GetUnreadOnly(), int(req.GetLimit()))
+
+  return &pb.Notifications{Notifications: list, UnreadCount: unread}, nil
+}
+
+func (s *server) MarkNotificationRead(ctx context.Context, req *pb.MarkNotificationReadRequest) (*pb.Notifications, error) {
+  identity, err := requireIdentity(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if req.GetId() == "" && !req.GetAll() {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "give the Id of a notification, or All")
+  }
+
+  id := req.GetId()
+  if req.GetAll() {
+    id = ""
+  }
+  marked, found := s.notifications.markRead(identity, id)
+  if !found {
+    return nil, apperr.Errorf(apperr.ErrNotificationNotFound, "notification %q not found", id)
+  }
+  _, unread := s.notifications.list(identity, true, 0)
+
+  return &pb.Notifications{Notifications: marked, UnreadCount: unread}, nil
+}
+
+func (s *server) StreamNotifications(_ *pb.StreamNotificationsRequest, stream grpc.ServerStreamingServer[pb.Notification]) error {
+  identity, err := requireIdentity(stream.Context())
+  if err != nil {
+    return err
+  }
+
+  notifications, unsubscribe := s.notifications.subscribe(identity)
+  defer unsubscribe()
+
+  // Closed with a hint of when to come back, like WatchPosts. See maintenance.go
+  closing := s.maintenance.closingStreams()
+
+  for {
+    select {
+    case <-stream.Context().Done():
+      return nil
+    case <-closing:
+      return s.maintenance.closeStream(stream)
+    case notification, ok := <-notifications:
+      if !ok {
+        return status.Errorf(codes.Unavailable, "server is shutting down")
+      }
+      if err := stream.Send(notification); err != nil {
+        return err
+      }
+    }
+  }
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+  "context"
+  "crypto"
+  "crypto/ecdsa"
+  "crypto/elliptic"
+  "crypto/rsa"
+  "crypto/sha256"
+  "crypto/sha512"
+  "encoding/base64"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "hash"
+  "log"
+  "math/big"
+  "net/http"
+  "net/url"
+  "slices"
+  "strings"
+  "sync"
+  "time"
+)
+
+/*
+  OPENID CONNECT
+
+  The admin token is a single secret shared by everyone allowed in, which is hard to rotate and doesn't say who did what. Organizations that already run an identity provider, Keycloak, Auth0, Okta, Google, hand out short-lived access tokens instead. Programs get them with the client credentials flow: they tell the provider their client ID and secret and get back a token signed by the provider, valid for a few minutes. gen/blogsdk has a TokenSource for it (see ClientCredentials there).
+
+  The server checks those tokens itself, without calling the provider for every call. They are JSON Web Tokens: a header, claims about the caller and a signature of both, each base64 encoded and separated by dots. The provider publishes the public keys it signs with as a JSON Web Key Set (JWKS), at an address it lists in its discovery document, <issuer>/.well-known/openid-configuration. A token is accepted when:
+    - it is signed with RS256, RS384, RS512, ES256 or ES384 by one of the keys of the set. Tokens that aren't signed ("alg": "none") or signed with a shared secret (HS256) are refused, whoever has the secret could make their own.
+    - its iss claim is -oidc-issuer and its aud claim has -oidc-audience, so a token the provider made for another service can't be replayed here
+    - it hasn't expired, give or take oidcLeeway for the clocks that drift apart
+
+  Tokens with the -oidc-admin-scope scope are admins, their identity is admin:<sub> so the audit log tells them apart (see audit.go). The others are only known by their subject, oidc:<sub>, which is who RecordView counts the view for.
+
+    go run . -oidc-issuer https://auth.example.com/realms/blog -oidc-audience blog
+
+  The keys are fetched on the first token and kept for oidcKeysTTL. Providers rotate their keys by publishing a new one in the set before signing with it, so a token signed with a key the server doesn't know fetches the set again, at most once every oidcKeysMinRefresh so a stream of bogus tokens can't turn the server against the provider. The admin token keeps working next to OIDC.
+*/
+const (
+  oidcLeeway         = time.Minute
+  oidcKeysTTL        = time.Hour
+  oidcKeysMinRefresh = time.Minute
+  oidcFetchTimeout   = 5 * time.Second
+)
+
+type oidcVerifier struct {
+  issuer     string
+  audience   string
+  adminScope string
+  client     *http.Client
+
+  mu        sync.Mutex
+  keys      map[string]crypto.PublicKey
+  fetchedAt time.Time
+  // Address of the key set, from the discovery document.
+  jwksURL string
+}
+
+// oidcClaims are the claims of the access tokens the server reads.
+type oidcClaims struct {
+  Issuer    string       `json:"iss"`
+  Subject   string       `json:"sub"`
+  Audience  oidcAudience `json:"aud"`
+  ExpiresAt int64        `json:"exp"`
+  NotBefore int64        `json:"nbf"`
+  // Space separated, like OAuth2 scopes. Some providers send a list in scp instead.
+  Scope  string   `json:"scope"`
+  Scopes []string `json:"scp"`
+}
+
+// oidcAudience is the aud claim, which is either a string or a list of them.
+type oidcAudience []string
+
+func (a *oidcAudience) UnmarshalJSON(data []byte) error {
+  var one string
+  if err := json.Unmarshal(data, &one); err == nil {
+    *a = oidcAudience{one}
+    return nil
+  }
+
+  return json.Unmarshal(data, (*[]string)(a))
+}
+
+// newOIDCVerifier returns nil without an issuer, which leaves OIDC off.
+func newOIDCVerifier(issuer, audience, adminScope string) (*oidcVerifier, error) {
+  if issuer == "" {
+    return nil, nil
+  }
+
+  u, err := url.Parse(issuer)
+  if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+    return nil, fmt.Errorf("invalid -oidc-issuer %q, expected an http or https URL", issuer)
+  }
+  if audience == "" {
+    return nil, fmt.Errorf("-oidc-issuer needs an -oidc-audience, the tokens of other services would be accepted otherwise")
+  }
+
+  v := &oidcVerifier{issuer: strings.TrimSuffix(issuer, "/"), audience: audience, adminScope: adminScope, client: &http.Client{Timeout: oidcFetchTimeout}}
+
+  // A provider that is down when the server starts shouldn't keep it from starting, the keys are asked for again with the first token.
+  ctx, cancel := context.WithTimeout(context.Background(), oidcFetchTimeout)
+  defer cancel()
+  if err := v.refresh(ctx); err != nil {
+    log.Printf("oidc: %v, trying again with the first token", err)
+    v.fetchedAt = time.Time{}
+  }
+
+  return v, nil
+}
+
+// identity returns the identity of the token described above, or an error saying why it isn't accepted.
+func (v *oidcVerifier) identity(ctx context.Context, token string) (string, error) {
+  claims, err := v.verify(ctx, token)
+  if err != nil {
+    return "", err
+  }
+
+  scopes := append(strings.Fields(claims.Scope), claims.Scopes...)
+  if v.adminScope != "" && slices.Contains(scopes, v.adminScope) {
+    return adminIdentity + ":" + claims.Subject, nil
+  }
+
+  return "oidc:" + claims.Subject, nil
+}
+
+func (v *oidcVerifier) verify(ctx context.Context, token string) (*oidcClaims, error) {
+  parts := strings.Split(token, ".")
+  if len(parts) != 3 {
+    return nil, errors.New("not a JWT")
+  }
+
+  var header struct {
+    Alg string `json:"alg"`
+    Kid string `json:"kid"`
+  }
+  if err := decodeJWTPart(parts[0], &header); err != nil {
+    return nil, fmt.Errorf("invalid header: %w", err)
+  }
+  signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+  if err != nil {
+    return nil, fmt.Errorf("invalid signature: %w", err)
+  }
+  key, err := v.key(ctx, header.Kid)
+  if err != nil {
+    return nil, err
+  }
+  if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+    return nil, err
+  }
+
+  // Only the claims of a token signed by the provider are worth reading.
+  var claims oidcClaims
+  if err := decodeJWTPart(parts[1], &claims); err != nil {
+    return nil, fmt.Errorf("invalid claims: %w", err)
+  }
+  now := time.Now()
+  switch {
+  case strings.TrimSuffix(claims.Issuer, "/") != v.issuer:
+    return nil, fmt.Errorf("issued by %q, not %q", claims.Issuer, v.issuer)
+  case !slices.Contains(claims.Audience, v.audience):
+    return nil, fmt.Errorf("meant for %v, not %q", []string(claims.Audience), v.audience)
+  case claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(oidcLeeway)):
+    return nil, errors.New("expired")
+  case claims.NotBefore != 0 && now.Add(oidcLeeway).Before(time.Unix(claims.NotBefore, 0)):
+    return nil, errors.New("not valid yet")
+  case claims.Subject == "":
+    return nil, errors.New("no subject")
+  }
+
+  return &claims, nil
+}
+
+func decodeJWTPart(part string, v any) error {
+  data, err := base64.RawURLEncoding.DecodeString(part)
+  if err != nil {
+    return err
+  }
+
+  return json.Unmarshal(data, v)
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, signature []byte) error {
+  var h hash.Hash
+  var hashID crypto.Hash
+  switch alg {
+  case "RS256", "ES256":
+    h, hashID = sha256.New(), crypto.SHA256
+  case "RS384", "ES384":
+    h, hashID = sha512.New384(), crypto.SHA384
+  case "RS512":
+    h, hashID = sha512.New(), crypto.SHA512
+  default:
+    return fmt.Errorf("unsupported signing algorithm %q", alg)
+  }
+  h.Write([]byte(signed))
+  digest := h.Sum(nil)
+
+  switch key := key.(type) {
+  case *rsa.PublicKey:
+    if !strings.HasPrefix(alg, "RS") {
+      return fmt.Errorf("%s token signed with an RSA key", alg)
+    }
+    if err := rsa.VerifyPKCS1v15(key, hashID, digest, signature); err != nil {
+      return errors.New("bad signature")
+    }
+  case *ecdsa.PublicKey:
+    // JWTs carry the two numbers of an ECDSA signature side by side, each as long as the curve.
+    size := (key.Curve.Params().BitSize + 7) / 8
+    if !strings.HasPrefix(alg, "ES") || len(signature) != 2*size {
+      return errors.New("bad signature")
+    }
+    r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+    if !ecdsa.Verify(key, digest, r, s) {
+      return errors.New("bad signature")
+    }
+  default:
+    return errors.New("unsupported key type")
+  }
+
+  return nil
+}
+
+// key returns the key of the set with the ID, fetching the set again when it is old or doesn't have the key.
+func (v *oidcVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+  v.mu.Lock()
+  defer v.mu.Unlock()
+
+  key, ok := v.keys[kid]
+  stale := time.Since(v.fetchedAt) > oidcKeysTTL
+  if ok && !stale {
+    return key, nil
+  }
+  if !stale && time.Since(v.fetchedAt) < oidcKeysMinRefresh {
+    return nil, fmt.Errorf("signed with unknown key %q", kid)
+  }
+
+  if err := v.fetchKeys(ctx); err != nil {
+    // The keys we have beat no keys at all while the provider is unreachable.
+    if ok {
+      log.Printf("oidc: %v, keeping the keys fetched at %s", err, v.fetchedAt.Format(time.RFC3339))
+      return key, nil
+    }
+    return nil, err
+  }
+  if key, ok = v.keys[kid]; !ok {
+    return nil, fmt.Errorf("signed with unknown key %q", kid)
+  }
+
+  return key, nil
+}
+
+func (v *oidcVerifier) refresh(ctx context.Context) error {
+  v.mu.Lock()
+  defer v.mu.Unlock()
+
+  return v.fetchKeys(ctx)
+}
+
+// fetchKeys reads the key set, and the discovery document the first time. The caller holds mu.
+func (v *oidcVerifier) fetchKeys(ctx context.Context) error {
+  // Failures count as fetches too, so a provider that is down isn't asked on every call.
+  v.fetchedAt = time.Now()
+
+  if v.jwksURL == "" {
+    var discovery struct {
+      JWKSURI string `json:"jwks_uri"`
+    }
+    if err := v.getJSON(ctx, v.issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+      return fmt.Errorf("failed to read the discovery document: %w", err)
+    }
+    if discovery.JWKSURI == "" {
+      return errors.New("the discovery document has no jwks_uri")
+    }
+    v.jwksURL = discovery.JWKSURI
+  }
+
+  var set struct {
+    Keys []jsonWebKey `json:"keys"`
+  }
+  if err := v.getJSON(ctx, v.jwksURL, &set); err != nil {
+    return fmt.Errorf("failed to read the key set: %w", err)
+  }
+
+  keys := make(map[string]crypto.PublicKey, len(set.Keys))
+  for _, jwk := range set.Keys {
+    // Keys meant for encryption, or of a kind we can't verify with, are skipped, the others may be all we need.
+    if jwk.Use != "" && jwk.Use != "sig" {
+      continue
+    }
+    key, err := jwk.publicKey()
+    if err != nil {
+      log.Printf("oidc: skipping key %q: %v", jwk.Kid, err)
+      continue
+    }
+    keys[jwk.Kid] = key
+  }
+  v.keys = keys
+
+  return nil
+}
+
+func (v *oidcVerifier) getJSON(ctx context.Context, url string, dst any) error {
+  req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+  if err != nil {
+    return err
+  }
+  res, err := v.client.Do(req)
+  if err != nil {
+    return err
+  }
+  defer res.Body.Close()
+
+  if res.StatusCode != http.StatusOK {
+    return fmt.Errorf("%s answered %s", url, res.Status)
+  }
+
+  return json.NewDecoder(res.Body).Decode(dst)
+}
+
+// jsonWebKey is a public key of a key set, RSA or elliptic curve.
+type jsonWebKey struct {
+  Kty string `json:"kty"`
+  Kid string `json:"kid"`
+  Use string `json:"use"`
+  // RSA
+  N string `json:"n"`
+  E string `json:"e"`
+  // EC
+  Crv string `json:"crv"`
+  X   string `json:"x"`
+  Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+  number := func(s string) (*big.Int, error) {
+    b, err := base64.RawURLEncoding.DecodeString(s)
+    if err != nil || len(b) == 0 {
+      return nil, errors.New("invalid number")
+    }
+    return new(big.Int).SetBytes(b), nil
+  }
+
+  switch k.Kty {
+  case "RSA":
+    n, err := number(k.N)
+    if err != nil {
+      return nil, err
+    }
+    e, err := number(k.E)
+    if err != nil || !e.IsInt64() {
+      return nil, errors.New("invalid exponent")
+    }
+    return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+  case "EC":
+    var curve elliptic.Curve
+    switch k.Crv {
+    case "P-256":
+      curve = elliptic.P256()
+    case "P-384":
+      curve = elliptic.P384()
+    default:
+      return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+    }
+    x, err := number(k.X)
+    if err != nil {
+      return nil, err
+    }
+    y, err := number(k.Y)
+    if err != nil {
+      return nil, err
+    }
+    if !curve.IsOnCurve(x, y) {
+      return nil, errors.New("point not on the curve")
+    }
+    return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+  default:
+    return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+  }
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+  "encoding/base64"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "strconv"
+  "strings"
+)
+
+/*
+  PAGES
+
+  GetPosts returns every post matching the request in one response, which gets slow and big once the blog has a few thousand posts. With a PageSize it returns that many at most, along with a NextPageToken when more are left. The next page is asked for with the same request and the token:
+
+    page, err := client.GetPosts(ctx, &pb.GetPostsRequest{PageSize: 50})
+    page, err = client.GetPosts(ctx, &pb.GetPostsRequest{PageSize: 50, PageToken: page.GetNextPageToken()})
+
+  until a page comes without a token. A PageSize of 0 keeps returning everything, like before pages existed. The Posts method of gen/blogsdk follows the tokens on its own.
+
+  Like the cursors of StreamPosts (see stream.go) a token points at the last post of its page rather than at a position, so a post published or deleted in between doesn't make the next page repeat or skip one. The posts listed by GetPosts are only the published ones though, so the last post of a page can drop out of the list, archived or deleted. The token keeps its position too, and in that case the next page starts where the post was.
+
+  Tokens are opaque to clients and only mean something for the filter and order of the request they came from.
+*/
+const pageTokenPrefix = "v1:"
+
+type pageToken struct {
+  // Position of the last post of the previous page and its ID, empty for the first page.
+  offset int
+  id     string
+}
+
+func parsePageToken(token string) (pageToken, error) {
+  if token == "" {
+    return pageToken{}, nil
+  }
+
+  invalid := apperr.Errorf(apperr.ErrInvalidArgument, "invalid PageToken %q", token)
+  data, err := base64.RawURLEncoding.DecodeString(token)
+  if err != nil || !strings.HasPrefix(string(data), pageTokenPrefix) {
+    return pageToken{}, invalid
+  }
+  offset, id, ok := strings.Cut(strings.TrimPrefix(string(data), pageTokenPrefix), ":")
+  n, err := strconv.Atoi(offset)
+  if !ok || err != nil || n < 0 || id == "" {
+    return pageToken{}, invalid
+  }
+
+  return pageToken{offset: n, id: id}, nil
+}
+
+func (t pageToken) String() string {
+  return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + strconv.Itoa(t.offset) + ":" + t.id))
+}
+
+// start returns the position of the first post of the page.
+func (t pageToken) start(posts []*pb.Post) int {
+  if t.id == "" {
+    return 0
+  }
+  // The post usually stays close to where it was, so the search begins there.
+  for i := min(t.offset, len(posts)-1); i >= 0; i-- {
+    if posts[i].Id == t.id {
+      return i + 1
+    }
+  }
+  for i := t.offset + 1; i < len(posts); i++ {
+    if posts[i].Id == t.id {
+      return i + 1
+    }
+  }
+
+  return min(t.offset, len(posts))
+}
+
+// paginate returns the page of posts the token points at, with the token of the next one, and the posts left out of it. Without a size every post is on the page.
+func paginate(posts *pb.Posts, size int32, token pageToken) (*pb.Posts, []*pb.Post) {
+  if size == 0 && token.id == "" {
+    return posts, nil
+  }
+
+  start := token.start(posts.Posts)
+  end := len(posts.Posts)
+  if size > 0 {
+    end = min(start+int(size), end)
+  }
+
+  page := &pb.Posts{Posts: posts.Posts[start:end]}
+  if end < len(posts.Posts) {
+    page.NextPageToken = pageToken{offset: end - 1, id: posts.Posts[end-1].Id}.String()
+  }
+
+  return page, append(posts.Posts[:start:start], posts.Posts[end:]...)
+}
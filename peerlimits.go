@@ -0,0 +1,171 @@
+package main
+
+import (
+  "context"
+  "net"
+  "sync"
+  "sync/atomic"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/peer"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  PER PEER LIMITS
+
+  -max-concurrent caps the calls of every client together (see limiter.go), so a single client opening connection after connection, or starting calls it never finishes, takes the slots of everybody else. The limits of a client IP keep it to its share:
+
+    -max-conns-per-ip      connections open at once, 32 by default
+    -max-streams-per-ip    calls running at once over all of them, WatchPosts streams included, 32 by default
+
+  A connection over the limit is closed as soon as it is accepted, before the TLS and HTTP/2 handshakes cost anything. A call over the limit fails with ResourceExhausted, like the rate limit of the mirror, before it is authenticated. 0 turns a limit off, and both change on reload (see config.go).
+
+  Clients of a Unix socket have no address and aren't limited. With -http the connections are limited too, but not the calls, which the HTTP server hands over to gRPC one request at a time (see http.go). Clients behind a NAT or a proxy share its address, the limits need to leave room for them.
+
+  How many connections and calls were turned away is in the connection stats, see connections.go
+*/
+const (
+  defaultMaxConnsPerIP   = 32
+  defaultMaxStreamsPerIP = 32
+)
+
+type peerLimits struct {
+  rejectedConns   atomic.Int64
+  rejectedStreams atomic.Int64
+
+  mu         sync.Mutex
+  maxConns   int
+  maxStreams int
+  conns      map[string]int
+  streams    map[string]int
+}
+
+func newPeerLimits(maxConns, maxStreams int) *peerLimits {
+  return &peerLimits{maxConns: maxConns, maxStreams: maxStreams, conns: map[string]int{}, streams: map[string]int{}}
+}
+
+func (l *peerLimits) setMaxConns(max int) {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  l.maxConns = max
+}
+
+func (l *peerLimits) setMaxStreams(max int) {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  l.maxStreams = max
+}
+
+// take counts one more connection or call of ip in counts, unless it already has max of them.
+func (l *peerLimits) take(counts map[string]int, max *int, ip string) bool {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  if *max > 0 && counts[ip] >= *max {
+    return false
+  }
+  counts[ip]++
+
+  return true
+}
+
+func (l *peerLimits) release(counts map[string]int, ip string) {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  if counts[ip]--; counts[ip] <= 0 {
+    delete(counts, ip)
+  }
+}
+
+// tcpIP returns the IP address of a TCP peer, empty for the others.
+func tcpIP(addr net.Addr) string {
+  tcp, ok := addr.(*net.TCPAddr)
+  if !ok {
+    return ""
+  }
+
+  return peerIP(tcp.String())
+}
+
+// listen returns the listener closing the connections over the limit of their IP.
+func (l *peerLimits) listen(lis net.Listener) net.Listener {
+  return &limitedListener{Listener: lis, limits: l}
+}
+
+type limitedListener struct {
+  net.Listener
+  limits *peerLimits
+}
+
+func (lis *limitedListener) Accept() (net.Conn, error) {
+  for {
+    conn, err := lis.Listener.Accept()
+    if err != nil {
+      return nil, err
+    }
+    ip := tcpIP(conn.RemoteAddr())
+    if ip == "" {
+      return conn, nil
+    }
+    if lis.limits.take(lis.limits.conns, &lis.limits.maxConns, ip) {
+      return &limitedConn{Conn: conn, release: func() { lis.limits.release(lis.limits.conns, ip) }}, nil
+    }
+    lis.limits.rejectedConns.Add(1)
+    conn.Close()
+  }
+}
+
+// limitedConn gives the slot of its IP back once closed, whoever closes it and however many times.
+type limitedConn struct {
+  net.Conn
+  release func()
+  once    sync.Once
+}
+
+func (c *limitedConn) Close() error {
+  c.once.Do(c.release)
+  return c.Conn.Close()
+}
+
+// acquire takes a call slot of the caller, and returns the function giving it back.
+func (l *peerLimits) acquire(ctx context.Context) (func(), error) {
+  p, ok := peer.FromContext(ctx)
+  if !ok {
+    return func() {}, nil
+  }
+  ip := tcpIP(p.Addr)
+  if ip == "" {
+    return func() {}, nil
+  }
+  if !l.take(l.streams, &l.maxStreams, ip) {
+    l.rejectedStreams.Add(1)
+    return nil, status.Errorf(codes.ResourceExhausted, "too many calls running from %s, try again once some of them are done", ip)
+  }
+
+  return func() { l.release(l.streams, ip) }, nil
+}
+
+func (l *peerLimits) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  release, err := l.acquire(ctx)
+  if err != nil {
+    return nil, err
+  }
+  defer release()
+
+  return handler(ctx, req)
+}
+
+func (l *peerLimits) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  release, err := l.acquire(ss.Context())
+  if err != nil {
+    return err
+  }
+  defer release()
+
+  return handler(srv, ss)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+  "cmp"
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "slices"
+)
+
+/*
+  PINNED POSTS
+
+  GetPosts returns the posts in the order they were written. A pinned post comes first instead, above every post that isn't pinned, like an announcement kept at the top of the blog:
+
+    go run ./client pin -token secret -id <post id>
+    go run ./client pin -token secret -id <another post id> -position 1
+    go run ./client unpin -token secret -id <post id>
+
+  The pinned posts have an order of their own, the PinPosition of each of them, 1 comes first. PinPost puts the post at Position and moves the ones from there down by one, or puts it last without a Position. Pinning a post that is pinned already moves it, which is how the pinned posts get reordered. UnpinPost takes it out and the ones below it move up.
+
+  -max-pinned caps how many posts are pinned at once, a blog where everything is pinned has nothing pinned. Past it PinPost refuses to pin one more until another one is unpinned, 0 turns pinning off:
+
+    go run . -max-pinned 5
+
+  Lowering it leaves the posts pinned already as they are, only the new pins are refused.
+
+  Only published posts can be pinned. Archiving a post unpins it, and deleting one wipes it anyway. Every post whose position changes gets a new sequence so SyncChanges hands it back to the clients keeping a copy, and the watchers get a POST_UPDATED for the post pinned or unpinned. Both RPCs require the admin token.
+*/
+func (s *server) PinPost(ctx context.Context, req *pb.PinPostRequest) (*pb.Post, error) {
+  if s.maxPinned == 0 {
+    return nil, apperr.Errorf(apperr.ErrFeatureDisabled, "pinning is turned off, start the server with -max-pinned")
+  }
+
+  return s.changePins(ctx, req.GetId(), func(pinned []*pb.Post, post *pb.Post) ([]*pb.Post, error) {
+    if post.Status != pb.PostStatus_PUBLISHED {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "post %q is %s, only published posts can be pinned", post.Id, post.Status)
+    }
+    if !post.Pinned && len(pinned) >= s.maxPinned {
+      return nil, apperr.Errorf(apperr.ErrTooManyPinned, "the server pins at most %d posts, unpin one first", s.maxPinned)
+    }
+
+    pinned = slices.DeleteFunc(pinned, func(p *pb.Post) bool { return p == post })
+    position := int(req.GetPosition())
+    if position == 0 || position > len(pinned) {
+      position = len(pinned) + 1
+    }
+
+    return slices.Insert(pinned, position-1, post), nil
+  })
+}
+
+// UnpinPost leaves posts that aren't pinned as they are.
+func (s *server) UnpinPost(ctx context.Context, req *pb.UnpinPostRequest) (*pb.Post, error) {
+  return s.changePins(ctx, req.GetId(), func(pinned []*pb.Post, post *pb.Post) ([]*pb.Post, error) {
+    return slices.DeleteFunc(pinned, func(p *pb.Post) bool { return p == post }), nil
+  })
+}
+
+// changePins gives change the pinned posts in their order, and the post of the RPC. The posts change returns are the pinned ones from then on, in their new order.
+func (s *server) changePins(ctx context.Context, id string, change func(pinned []*pb.Post, post *pb.Post) ([]*pb.Post, error)) (*pb.Post, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return nil, err
+  }
+
+  post, err := findPost(posts, id)
+  if err != nil {
+    return nil, err
+  }
+
+  pinned, err := change(pinnedPosts(posts.Posts), post)
+  if err != nil {
+    return nil, err
+  }
+
+  positions := make(map[*pb.Post]int32, len(pinned))
+  for i, p := range pinned {
+    positions[p] = int32(i + 1)
+  }
+
+  changed := false
+  sequence := nextSequence(posts)
+  for _, p := range posts.Posts {
+    position := positions[p]
+    if p.Pinned == (position > 0) && p.PinPosition == position {
+      continue
+    }
+    p.Pinned = position > 0
+    p.PinPosition = position
+    p.Sequence = sequence
+    sequence++
+    changed = true
+  }
+  if !changed {
+    return post, nil
+  }
+
+  if err := savePosts(posts); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+
+  if post.Status == pb.PostStatus_PUBLISHED {
+    s.broker.publish(pb.PostEventType_POST_UPDATED, post)
+  }
+
+  return post, nil
+}
+
+// pinnedPosts returns the pinned posts among posts, in their order.
+func pinnedPosts(posts []*pb.Post) []*pb.Post {
+  var pinned []*pb.Post
+  for _, post := range posts {
+    if post.Pinned {
+      pinned = append(pinned, post)
+    }
+  }
+  slices.SortStableFunc(pinned, func(a, b *pb.Post) int { return cmp.Compare(a.PinPosition, b.PinPosition) })
+
+  return pinned
+}
+
+// pinnedFirst moves the pinned posts to the front in their order, the others keep theirs.
+func pinnedFirst(posts *pb.Posts) *pb.Posts {
+  slices.SortStableFunc(posts.Posts, func(a, b *pb.Post) int {
+    if a.Pinned != b.Pinned {
+      if a.Pinned {
+        return -1
+      }
+      return 1
+    }
+    return cmp.Compare(a.PinPosition, b.PinPosition)
+  })
+
+  return posts
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+  "context"
+  "encoding/json"
+  "errors"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+  "io/fs"
+  "maps"
+  "os"
+  "regexp"
+  "slices"
+  "strings"
+  "sync"
+  "time"
+)
+
+/*
+  POST TEMPLATES
+
+  Some posts come back every week or every release with the same shape: a weekly digest, release notes, an interview. A template keeps that shape, a title and a content with placeholders, and CreatePostFromTemplate turns it into a post:
+
+    go run ./client templates add -token secret -name weekly -title "Weekly digest of {{date}}" -content "This week {{author}} picked {{pick}}" -tags digest
+    go run ./client templates list
+    go run ./client create-from-template -template weekly -author alice -value pick="the new gRPC release"
+
+  A placeholder is a name between double braces, like {{author}}. The server fills two of them itself: author is the Author of the post and date the day it gets published (YYYY-MM-DD), the day of PublishAt for a scheduled post. Every other one needs a value in Values, and a value for a placeholder the template doesn't have is refused too, it is more likely a typo than not. Templates list their placeholders, so clients know what to ask for.
+
+  The post is then created like CreatePost would, with the tags of the template: it is moderated, checked for duplicates, scheduled or saved as a draft. Later changes of the template don't change the posts made from it.
+
+  Templates are kept in post-templates.json, like the webhooks in webhooks.json. Anybody can read them and create posts from them, creating, changing and deleting them requires the admin token.
+*/
+const postTemplatesPath = "post-templates.json"
+
+// placeholderPattern matches a placeholder and captures its name, spaces inside the braces are allowed: {{ author }}.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+
+// serverPlaceholders are the placeholders the server fills, see above.
+var serverPlaceholders = []string{"author", "date"}
+
+type templateStore struct {
+  path string
+
+  // mu guards the templates file.
+  mu sync.Mutex
+}
+
+func newTemplateStore(path string) *templateStore {
+  return &templateStore{path: path}
+}
+
+func (s *server) CreateTemplate(ctx context.Context, req *pb.CreateTemplateRequest) (*pb.PostTemplate, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  tags, err := normalizeTags(req.GetTags())
+  if err != nil {
+    return nil, err
+  }
+
+  now := serverClock.Now().UTC().Format(time.RFC3339)
+  tmpl := &pb.PostTemplate{
+    Id:        store.NewID(),
+    Name:      strings.TrimSpace(req.GetName()),
+    Title:     req.GetTitle(),
+    Content:   req.GetContent(),
+    Tags:      tags,
+    CreatedAt: now,
+    UpdatedAt: now,
+  }
+
+  t := s.templates
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  templates, err := t.load()
+  if err != nil {
+    return nil, err
+  }
+  if err := checkTemplate(templates, tmpl); err != nil {
+    return nil, err
+  }
+
+  if err := t.save(append(templates, tmpl)); err != nil {
+    return nil, err
+  }
+
+  return withPlaceholders(tmpl), nil
+}
+
+func (s *server) GetTemplate(_ context.Context, req *pb.GetTemplateRequest) (*pb.PostTemplate, error) {
+  t := s.templates
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  templates, err := t.load()
+  if err != nil {
+    return nil, err
+  }
+  i, err := findTemplate(templates, req.GetId())
+  if err != nil {
+    return nil, err
+  }
+
+  return withPlaceholders(templates[i]), nil
+}
+
+func (s *server) ListTemplates(context.Context, *pb.ListTemplatesRequest) (*pb.PostTemplates, error) {
+  t := s.templates
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  templates, err := t.load()
+  if err != nil {
+    return nil, err
+  }
+  for _, tmpl := range templates {
+    withPlaceholders(tmpl)
+  }
+
+  return &pb.PostTemplates{Templates: templates}, nil
+}
+
+func (s *server) UpdateTemplate(ctx context.Context, req *pb.UpdateTemplateRequest) (*pb.PostTemplate, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  t := s.templates
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  templates, err := t.load()
+  if err != nil {
+    return nil, err
+  }
+  i, err := findTemplate(templates, req.GetId())
+  if err != nil {
+    return nil, err
+  }
+  tmpl := templates[i]
+
+  if name := strings.TrimSpace(req.GetName()); name != "" {
+    tmpl.Name = name
+  }
+  if req.GetTitle() != "" {
+    tmpl.Title = req.GetTitle()
+  }
+  if req.GetContent() != "" {
+    tmpl.Content = req.GetContent()
+  }
+  if len(req.GetTags()) > 0 {
+    tags, err := normalizeTags(req.GetTags())
+    if err != nil {
+      return nil, err
+    }
+    tmpl.Tags = tags
+  }
+  tmpl.UpdatedAt = serverClock.Now().UTC().Format(time.RFC3339)
+
+  if err := checkTemplate(templates, tmpl); err != nil {
+    return nil, err
+  }
+  if err := t.save(templates); err != nil {
+    return nil, err
+  }
+
+  return withPlaceholders(tmpl), nil
+}
+
+func (s *server) DeleteTemplate(ctx context.Context, req *pb.DeleteTemplateRequest) (*pb.DeleteTemplateResponse, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  t := s.templates
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  templates, err := t.load()
+  if err != nil {
+    return nil, err
+  }
+  i, err := findTemplate(templates, req.GetId())
+  if err != nil {
+    return nil, err
+  }
+
+  if err := t.save(slices.Delete(templates, i, i+1)); err != nil {
+    return nil, err
+  }
+
+  return &pb.DeleteTemplateResponse{}, nil
+}
+
+// CreatePostFromTemplate fills the template and hands the post to CreatePost, see above.
+func (s *server) CreatePostFromTemplate(ctx context.Context, req *pb.CreatePostFromTemplateRequest) (*pb.Post, error) {
+  t := s.templates
+  t.mu.Lock()
+  templates, err := t.load()
+  var tmpl *pb.PostTemplate
+  if err == nil {
+    var i int
+    if i, err = findTemplate(templates, req.GetTemplate()); err == nil {
+      tmpl = templates[i]
+    }
+  }
+  t.mu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  values, err := placeholderValues(tmpl, req)
+  if err != nil {
+    return nil, err
+  }
+
+  return s.CreatePost(ctx, &pb.CreatePostRequest{
+    Title:     fillPlaceholders(tmpl.Title, values),
+    Content:   fillPlaceholders(tmpl.Content, values),
+    Author:    req.GetAuthor(),
+    PublishAt: req.GetPublishAt(),
+    Tags:      tmpl.Tags,
+    Draft:     req.GetDraft(),
+  })
+}
+
+// placeholderValues returns the value of every placeholder of the template, or an error naming the first one missing or unknown.
+func placeholderValues(tmpl *pb.PostTemplate, req *pb.CreatePostFromTemplateRequest) (map[string]string, error) {
+  // A PublishAt that doesn't parse is left to CreatePost to refuse.
+  date := serverClock.Now().Format("2006-01-02")
+  if at, err := time.Parse(time.RFC3339, req.GetPublishAt()); err == nil {
+    date = at.Format("2006-01-02")
+  }
+  values := map[string]string{"author": req.GetAuthor(), "date": date}
+
+  names := placeholders(tmpl)
+  for _, name := range slices.Sorted(maps.Keys(req.GetValues())) {
+    if slices.Contains(serverPlaceholders, name) {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "{{%s}} is filled by the server, it can't be given in Values", name)
+    }
+    if !slices.Contains(names, name) {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "template %q has no placeholder {{%s}}", tmpl.Name, name)
+    }
+    values[name] = req.GetValues()[name]
+  }
+  for _, name := range names {
+    if _, ok := values[name]; !ok {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "template %q needs a value for {{%s}}", tmpl.Name, name)
+    }
+  }
+
+  return values, nil
+}
+
+func fillPlaceholders(text string, values map[string]string) string {
+  return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+    return values[placeholderPattern.FindStringSubmatch(placeholder)[1]]
+  })
+}
+
+// placeholders returns the names of the placeholders of the template, in the order they first appear.
+func placeholders(tmpl *pb.PostTemplate) []string {
+  var names []string
+  for _, match := range placeholderPattern.FindAllStringSubmatch(tmpl.Title+"\n"+tmpl.Content, -1) {
+    if !slices.Contains(names, match[1]) {
+      names = append(names, match[1])
+    }
+  }
+
+  return names
+}
+
+// withPlaceholders fills Placeholders, which is computed on the way out rather than stored: the templates are saved before it is called.
+func withPlaceholders(tmpl *pb.PostTemplate) *pb.PostTemplate {
+  tmpl.Placeholders = placeholders(tmpl)
+  return tmpl
+}
+
+// checkTemplate checks a new or changed template against the others.
+func checkTemplate(templates []*pb.PostTemplate, tmpl *pb.PostTemplate) error {
+  if tmpl.Name == "" {
+    return apperr.Errorf(apperr.ErrInvalidArgument, "Name can't be empty")
+  }
+  if strings.TrimSpace(tmpl.Title) == "" {
+    return apperr.Errorf(apperr.ErrInvalidTitle, "Title can't be empty")
+  }
+
+  for _, other := range templates {
+    if other.Id != tmpl.Id && other.Name == tmpl.Name {
+      return apperr.Errorf(apperr.ErrTemplateExists, "a template named %q exists already", tmpl.Name)
+    }
+  }
+
+  return nil
+}
+
+// findTemplate returns the index of the template with the given ID or name.
+func findTemplate(templates []*pb.PostTemplate, id string) (int, error) {
+  i := slices.IndexFunc(templates, func(tmpl *pb.PostTemplate) bool { return tmpl.Id == id || tmpl.Name == id })
+  if i == -1 {
+    return -1, apperr.Errorf(apperr.ErrTemplateNotFound, "template %q not found", id)
+  }
+
+  return i, nil
+}
+
+func (t *templateStore) load() ([]*pb.PostTemplate, error) {
+  var templates []*pb.PostTemplate
+
+  data, err := os.ReadFile(t.path)
+  // No file yet simply means no template has been created so far.
+  if errors.Is(err, fs.ErrNotExist) {
+    return templates, nil
+  }
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read templates file: %w", err)
+  }
+
+  if err := json.Unmarshal(data, &templates); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to parse templates: %w", err)
+  }
+
+  return templates, nil
+}
+
+func (t *templateStore) save(templates []*pb.PostTemplate) error {
+  data, err := json.MarshalIndent(templates, "", "  ")
+  if err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save templates: %w", err)
+  }
+
+  if err := os.WriteFile(t.path, data, 0644); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save templates: %w", err)
+  }
+
+  return nil
+}
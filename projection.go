@@ -0,0 +1,60 @@
+package main
+
+import (
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+
+  "google.golang.org/protobuf/reflect/protoreflect"
+  "google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+/*
+  FIELD PROJECTION
+
+  A list view only shows the titles of the posts, yet GetPosts sends every post whole, content included, and the content is by far the biggest field. The ReadMask of GetPostsRequest lets the client say which fields it wants, and the server leaves the others out:
+
+    go run ./client list -fields Id,Title
+
+  ReadMask is a google.protobuf.FieldMask, one of the well known types that come with protoc: a list of field names, the same names as in blog.proto. Google's APIs use it for exactly this, and for updates that only change some fields.
+
+  Fields are looked up through protoreflect, the reflection API of the protobuf runtime: every generated message can describe its fields at runtime, so nothing has to change here when a field is added to Post. Fields left out are simply not set, and fields that aren't set are not sent at all, that is where the savings are.
+
+  The posts may come from a cache (see redis.go and mirror.go), so the projected posts are new messages rather than the cached ones with fields cleared.
+*/
+type projection []protoreflect.FieldDescriptor
+
+// parseReadMask checks that every path names a field of Post, an empty mask projects nothing.
+func parseReadMask(mask *fieldmaskpb.FieldMask) (projection, error) {
+  fields := (&pb.Post{}).ProtoReflect().Descriptor().Fields()
+
+  var p projection
+  for _, path := range mask.GetPaths() {
+    field := fields.ByName(protoreflect.Name(path))
+    if field == nil {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "ReadMask: %q is not a field of Post", path)
+    }
+    p = append(p, field)
+  }
+
+  return p, nil
+}
+
+// apply returns copies of the posts with only the fields of the projection set.
+func (p projection) apply(posts *pb.Posts) *pb.Posts {
+  if len(p) == 0 {
+    return posts
+  }
+
+  projected := &pb.Posts{Posts: make([]*pb.Post, 0, len(posts.Posts)), NextPageToken: posts.NextPageToken}
+  for _, post := range posts.Posts {
+    src, dst := post.ProtoReflect(), (&pb.Post{}).ProtoReflect()
+    for _, field := range p {
+      if src.Has(field) {
+        dst.Set(field, src.Get(field))
+      }
+    }
+    projected.Posts = append(projected.Posts, dst.Interface().(*pb.Post))
+  }
+
+  return projected
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  "io"
+  "log"
+  "runtime/debug"
+  "slices"
+  "strconv"
+  "strings"
+  "sync"
+  "time"
+)
+
+/*
+  WORK QUEUE
+
+  The jobs of jobs.go run forever, one goroutine each. The work they produce is made of small tasks: a webhook delivery, an email, rebuilding the index of posts.json, writing the posts held back by -write-delay. Starting a goroutine per task means a burst of events starts thousands of them, all sending at once.
+
+  The work queue runs the tasks on a fixed pool of workers instead:
+
+    go run . -queue-workers 8 -queue-size 1000 -queue-concurrency webhook=4,email=2
+
+  - The queue holds at most -queue-size tasks waiting for a worker. A task submitted to a full queue is dropped and logged, the producers never block: a receiver that stopped answering slows its deliveries down, not the posts
+  - -queue-concurrency caps how many workers a kind of task takes at the same time, so slow webhooks can't hold every worker while the emails wait. Kinds that aren't listed may use every worker
+  - Tasks of a kind run in the order they were submitted, and the workers pick the oldest task of the kinds below their cap
+  - A task that panics is logged like a failed one, the worker moves on to the next
+
+  The kinds are webhook (one delivery, retries included, see webhooks.go), email (one email to one subscriber, see email.go), index (rebuilding the index of posts.json, see internal/store/index.go) and snapshot (writing the saves of -write-delay, see internal/store/batch.go).
+
+  /metrics has the depth of the queue, the running tasks, and how long tasks waited and ran, by kind. On shutdown the queue stops taking tasks and the waiting ones keep running until the shutdown deadline, then the context of the tasks is cancelled.
+*/
+var queueKinds = []string{"webhook", "email", "index", "snapshot"}
+
+type queuedTask struct {
+  kind   string
+  run    func(ctx context.Context) error
+  queued time.Time
+}
+
+type workQueue struct {
+  workers int
+  size    int
+  limits  map[string]int
+  ctx     context.Context
+  cancel  context.CancelFunc
+  done    sync.WaitGroup
+  // closing is closed when the shutdown starts, see stopping.
+  closing chan struct{}
+
+  mu sync.Mutex
+  // ready is signalled when a task is queued or finishes, which is when a waiting worker may have something to run.
+  ready   *sync.Cond
+  pending map[string][]*queuedTask
+  depth   int
+  running map[string]int
+  stats   map[string]*queueStats
+  closed  bool
+}
+
+type queueStats struct {
+  processed, failed, dropped int64
+  wait, run                  durationStat
+}
+
+// newWorkQueue starts the workers, limits are the caps of -queue-concurrency.
+func newWorkQueue(workers, size int, limits map[string]int) *workQueue {
+  ctx, cancel := context.WithCancel(context.Background())
+  q := &workQueue{
+    workers: workers,
+    size:    size,
+    limits:  limits,
+    ctx:     ctx,
+    cancel:  cancel,
+    closing: make(chan struct{}),
+    pending: map[string][]*queuedTask{},
+    running: map[string]int{},
+    stats:   map[string]*queueStats{},
+  }
+  q.ready = sync.NewCond(&q.mu)
+  for _, kind := range queueKinds {
+    q.stats[kind] = &queueStats{}
+  }
+
+  q.done.Add(workers)
+  for range workers {
+    go q.work()
+  }
+
+  return q
+}
+
+// parseQueueConcurrency reads a list like "webhook=4,email=2".
+func parseQueueConcurrency(list string) (map[string]int, error) {
+  limits := map[string]int{}
+  for _, entry := range splitList(list) {
+    kind, value, ok := strings.Cut(entry, "=")
+    if !ok {
+      return nil, fmt.Errorf("invalid queue concurrency %q, expected kind=workers", entry)
+    }
+    if !slices.Contains(queueKinds, kind) {
+      return nil, fmt.Errorf("unknown kind of task %q in -queue-concurrency, expected one of %s", kind, strings.Join(queueKinds, ", "))
+    }
+
+    n, err := strconv.Atoi(value)
+    if err != nil || n <= 0 {
+      return nil, fmt.Errorf("invalid concurrency %q for %s, expected a positive number of workers", value, kind)
+    }
+    limits[kind] = n
+  }
+
+  return limits, nil
+}
+
+// submit queues the task, or returns false when the queue is full or shut down.
+func (q *workQueue) submit(kind string, run func(ctx context.Context) error) bool {
+  q.mu.Lock()
+  defer q.mu.Unlock()
+
+  if q.closed || q.depth >= q.size {
+    q.stats[kind].dropped++
+    log.Printf("queue: dropped a %s task, %d tasks are waiting already", kind, q.depth)
+    return false
+  }
+
+  q.pending[kind] = append(q.pending[kind], &queuedTask{kind: kind, run: run, queued: time.Now()})
+  q.depth++
+  q.ready.Broadcast()
+
+  return true
+}
+
+// background adapts submit to the stores, which run their tasks without a context. See internal/store/batch.go and internal/store/index.go
+func (q *workQueue) background(kind string) func(task func()) bool {
+  return func(task func()) bool {
+    return q.submit(kind, func(context.Context) error {
+      task()
+      return nil
+    })
+  }
+}
+
+func (q *workQueue) work() {
+  defer q.done.Done()
+
+  for {
+    task := q.next()
+    if task == nil {
+      return
+    }
+
+    started := time.Now()
+    err := runTask(q.ctx, task)
+
+    q.mu.Lock()
+    stats := q.stats[task.kind]
+    stats.processed++
+    if err != nil {
+      stats.failed++
+    }
+    stats.wait.sum += started.Sub(task.queued).Seconds()
+    stats.wait.count++
+    stats.run.sum += time.Since(started).Seconds()
+    stats.run.count++
+    q.running[task.kind]--
+    q.ready.Broadcast()
+    q.mu.Unlock()
+
+    if err != nil {
+      log.Printf("queue: %s task failed: %v", task.kind, err)
+    }
+  }
+}
+
+// next waits for a task a worker may run, it returns nil once the queue is shut down and empty.
+func (q *workQueue) next() *queuedTask {
+  q.mu.Lock()
+  defer q.mu.Unlock()
+
+  for {
+    var oldest *queuedTask
+    for _, kind := range queueKinds {
+      tasks := q.pending[kind]
+      if len(tasks) == 0 || q.running[kind] >= q.limit(kind) {
+        continue
+      }
+      if oldest == nil || tasks[0].queued.Before(oldest.queued) {
+        oldest = tasks[0]
+      }
+    }
+
+    if oldest != nil {
+      q.pending[oldest.kind] = q.pending[oldest.kind][1:]
+      q.depth--
+      q.running[oldest.kind]++
+      return oldest
+    }
+    if q.closed && q.depth == 0 {
+      return nil
+    }
+    q.ready.Wait()
+  }
+}
+
+func (q *workQueue) limit(kind string) int {
+  if limit, ok := q.limits[kind]; ok {
+    return limit
+  }
+
+  return q.workers
+}
+
+// runTask turns a panic inside the task into an error, like runJob does for the jobs.
+func runTask(ctx context.Context, task *queuedTask) (err error) {
+  defer func() {
+    if r := recover(); r != nil {
+      err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+    }
+  }()
+
+  return task.run(ctx)
+}
+
+// shutdown stops taking tasks and waits for the queued ones, until ctx is done and the tasks left are cancelled.
+func (q *workQueue) shutdown(ctx context.Context) error {
+  q.mu.Lock()
+  q.closed = true
+  close(q.closing)
+  q.ready.Broadcast()
+  q.mu.Unlock()
+
+  stopped := make(chan struct{})
+  go func() {
+    q.done.Wait()
+    close(stopped)
+  }()
+
+  select {
+  case <-stopped:
+    return nil
+  case <-ctx.Done():
+    q.cancel()
+    q.mu.Lock()
+    left := q.depth
+    for _, running := range q.running {
+      left += running
+    }
+    q.mu.Unlock()
+    return fmt.Errorf("%d tasks were still waiting or running: %w", left, ctx.Err())
+  }
+}
+
+// stopping is closed when the shutdown starts. Tasks that wait, like the retries of the webhooks, give up on it rather than hold the shutdown.
+func (q *workQueue) stopping() <-chan struct{} {
+  return q.closing
+}
+
+// writeMetrics adds the queue to /metrics, see metrics.go
+func (q *workQueue) writeMetrics(w io.Writer) {
+  q.mu.Lock()
+  defer q.mu.Unlock()
+
+  fmt.Fprintln(w, "# HELP blog_queue_depth Tasks waiting for a worker, by kind.")
+  fmt.Fprintln(w, "# TYPE blog_queue_depth gauge")
+  for _, kind := range queueKinds {
+    fmt.Fprintf(w, "blog_queue_depth{kind=%q} %d\n", kind, len(q.pending[kind]))
+  }
+
+  fmt.Fprintln(w, "# HELP blog_queue_running Tasks being run by a worker, by kind.")
+  fmt.Fprintln(w, "# TYPE blog_queue_running gauge")
+  for _, kind := range queueKinds {
+    fmt.Fprintf(w, "blog_queue_running{kind=%q} %d\n", kind, q.running[kind])
+  }
+
+  fmt.Fprintln(w, "# HELP blog_queue_tasks_total Tasks of the queue, by kind and outcome: ok, failed, or dropped because the queue was full.")
+  fmt.Fprintln(w, "# TYPE blog_queue_tasks_total counter")
+  for _, kind := range queueKinds {
+    stats := q.stats[kind]
+    fmt.Fprintf(w, "blog_queue_tasks_total{kind=%q,outcome=\"ok\"} %d\n", kind, stats.processed-stats.failed)
+    fmt.Fprintf(w, "blog_queue_tasks_total{kind=%q,outcome=\"failed\"} %d\n", kind, stats.failed)
+    fmt.Fprintf(w, "blog_queue_tasks_total{kind=%q,outcome=\"dropped\"} %d\n", kind, stats.dropped)
+  }
+
+  fmt.Fprintln(w, "# HELP blog_queue_wait_seconds Time tasks waited for a worker, by kind.")
+  fmt.Fprintln(w, "# TYPE blog_queue_wait_seconds summary")
+  for _, kind := range queueKinds {
+    stats := q.stats[kind]
+    fmt.Fprintf(w, "blog_queue_wait_seconds_sum{kind=%q} %g\n", kind, stats.wait.sum)
+    fmt.Fprintf(w, "blog_queue_wait_seconds_count{kind=%q} %d\n", kind, stats.wait.count)
+  }
+
+  fmt.Fprintln(w, "# HELP blog_queue_processing_seconds Time tasks took to run, by kind.")
+  fmt.Fprintln(w, "# TYPE blog_queue_processing_seconds summary")
+  for _, kind := range queueKinds {
+    stats := q.stats[kind]
+    fmt.Fprintf(w, "blog_queue_processing_seconds_sum{kind=%q} %g\n", kind, stats.run.sum)
+    fmt.Fprintf(w, "blog_queue_processing_seconds_count{kind=%q} %d\n", kind, stats.run.count)
+  }
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+  "log"
+  "strconv"
+  "sync/atomic"
+  "time"
+
+  "github.com/redis/go-redis/v9"
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  REDIS
+
+  Every counted view (see RecordView in views.go) increments the view count of a post, which means rewriting all the posts just to add 1 to a number. With -redis-addr the server moves that work to Redis:
+    - view counts live in one Redis key per post (blog:views:<post id>) and counting a view is a single INCR, no matter how many posts there are
+    - the published posts are cached under blog:posts, so most GetPosts calls don't touch the storage at all. The x-cache trailer tells whether the cache was hit
+
+    go run . -redis-addr localhost:6379
+
+  Because the numbers live in Redis instead of in the memory of a server, several servers (replicas) behind a load balancer count views together and serve the same cached posts.
+
+  A view counter starts from the count saved with the post (SETNX only sets a key that doesn't exist yet), so switching Redis on doesn't reset anything. From then on the counts in the storage stop moving, Redis has the real numbers and GetPosts copies them into its response.
+
+  Repeat views are recognized with a key per post and viewer, blog:seen:<post id>:<viewer hash>, set with NX (only if it doesn't exist) and an expiry of -view-dedup-window: while the key is around the viewer's views don't count. New viewers are told apart with a HyperLogLog, blog:viewers:<post id>. A HyperLogLog estimates how many different values were added to it, within about 1%, in at most 12KB however many there are. PFADD answers whether the estimate changed, which is when blog:unique:<post id> is incremented.
+
+  The cache has to be dropped every time the posts change. Rather than remembering to do that in every handler, the post store is wrapped (see redisInvalidatingStore below) so every save drops the cache, whichever replica did it.
+
+  Redis is an optimization here, not the source of truth: if it goes away GetPosts keeps working from the storage and only logs that the view counts couldn't be read. RecordView needs Redis though, it fails with Unavailable until Redis is back.
+*/
+const (
+  redisPostsKey   = "blog:posts"
+  redisViewsKey   = "blog:views:"
+  redisViewedKey  = "blog:last_viewed:"
+  redisSeenKey    = "blog:seen:"
+  redisViewersKey = "blog:viewers:"
+  redisUniqueKey  = "blog:unique:"
+)
+
+type redisCache struct {
+  client *redis.Client
+  // ttl is a time.Duration, atomic since it can change while the server runs (see config.go).
+  ttl atomic.Int64
+}
+
+// A nil password connects without one. Otherwise every new connection reads it, so a rotated password is picked up without a restart (see secrets.go).
+func newRedisCache(addr string, password *secret, ttl time.Duration) *redisCache {
+  options := &redis.Options{Addr: addr}
+  if password != nil {
+    options.CredentialsProvider = func() (string, string) { return "", password.text() }
+  }
+  c := &redisCache{client: redis.NewClient(options)}
+  c.ttl.Store(int64(ttl))
+
+  return c
+}
+
+// cachedPosts returns the cached published posts, or nil on a miss.
+func (c *redisCache) cachedPosts(ctx context.Context) (*pb.Posts, error) {
+  data, err := c.client.Get(ctx, redisPostsKey).Bytes()
+  if err == redis.Nil {
+    return nil, nil
+  }
+  if err != nil {
+    return nil, err
+  }
+
+  posts := &pb.Posts{}
+  if err := proto.Unmarshal(data, posts); err != nil {
+    return nil, err
+  }
+
+  return posts, nil
+}
+
+func (c *redisCache) cachePosts(ctx context.Context, posts *pb.Posts) error {
+  data, err := proto.Marshal(posts)
+  if err != nil {
+    return err
+  }
+
+  return c.client.Set(ctx, redisPostsKey, data, time.Duration(c.ttl.Load())).Err()
+}
+
+func (c *redisCache) invalidate(ctx context.Context) error {
+  return c.client.Del(ctx, redisPostsKey).Err()
+}
+
+// viewCounts updates the posts with the counts kept in Redis, the posts nobody viewed since Redis was switched on keep the ones they were saved with.
+func (c *redisCache) viewCounts(ctx context.Context, posts []*pb.Post) error {
+  if len(posts) == 0 {
+    return nil
+  }
+
+  keys := func(prefix string) []string {
+    keys := make([]string, len(posts))
+    for i, post := range posts {
+      keys[i] = prefix + post.Id
+    }
+    return keys
+  }
+
+  // A pipeline sends all the commands in one round trip instead of one per command.
+  pipe := c.client.Pipeline()
+  views := pipe.MGet(ctx, keys(redisViewsKey)...)
+  unique := pipe.MGet(ctx, keys(redisUniqueKey)...)
+  viewed := pipe.MGet(ctx, keys(redisViewedKey)...)
+  if _, err := pipe.Exec(ctx); err != nil {
+    return err
+  }
+
+  // MGET returns nil for the keys that don't exist and strings for the others.
+  for i, post := range posts {
+    if v, ok := views.Val()[i].(string); ok {
+      post.ViewCount, _ = strconv.ParseInt(v, 10, 64)
+    }
+    if v, ok := unique.Val()[i].(string); ok {
+      post.UniqueViewers, _ = strconv.ParseInt(v, 10, 64)
+    }
+    if v, ok := viewed.Val()[i].(string); ok {
+      post.LastViewed = v
+    }
+  }
+
+  return nil
+}
+
+// recordView counts a view of the post by the viewer unless the viewer viewed it within the window.
+func (c *redisCache) recordView(ctx context.Context, post *pb.Post, viewer string, window time.Duration, at time.Time) (*pb.RecordViewResponse, error) {
+  hash := viewerHash(viewer)
+
+  // Without a window every view counts.
+  if window > 0 {
+    fresh, err := c.client.SetNX(ctx, redisSeenKey+post.Id+":"+hash, 1, window).Result()
+    if err != nil {
+      return nil, err
+    }
+    if !fresh {
+      return &pb.RecordViewResponse{ViewCount: post.ViewCount, UniqueViewers: post.UniqueViewers}, nil
+    }
+  }
+
+  pipe := c.client.Pipeline()
+  pipe.SetNX(ctx, redisViewsKey+post.Id, post.ViewCount, 0)
+  views := pipe.Incr(ctx, redisViewsKey+post.Id)
+  pipe.Set(ctx, redisViewedKey+post.Id, at.Format("2006-01-02"), 0)
+  added := pipe.PFAdd(ctx, redisViewersKey+post.Id, hash)
+  pipe.SetNX(ctx, redisUniqueKey+post.Id, post.UniqueViewers, 0)
+  if _, err := pipe.Exec(ctx); err != nil {
+    return nil, err
+  }
+
+  var unique int64
+  var err error
+  if added.Val() == 1 {
+    unique, err = c.client.Incr(ctx, redisUniqueKey+post.Id).Result()
+  } else {
+    unique, err = c.client.Get(ctx, redisUniqueKey+post.Id).Int64()
+  }
+  if err != nil {
+    return nil, err
+  }
+
+  return &pb.RecordViewResponse{Counted: true, ViewCount: views.Val(), UniqueViewers: unique}, nil
+}
+
+// getPostsFromRedis is GetPosts when Redis is enabled, the view counts kept in Redis replace the ones in the storage.
+func (s *server) getPostsFromRedis(ctx context.Context) (*pb.Posts, error) {
+  published, err := s.redis.cachedPosts(ctx)
+  if err != nil {
+    log.Printf("redis: failed to read cached posts: %v", err)
+  }
+  markCache(ctx, published != nil)
+
+  if published == nil {
+    posts := &pb.Posts{Posts: make([]*pb.Post, 0)}
+
+    // The cache is filled while holding the lock, otherwise a save happening in between could be followed by us caching the posts from before it.
+    storeMu.Lock()
+    err := loadPost(posts)
+    if err == nil {
+      published = publishedPosts(posts)
+      if err := s.redis.cachePosts(ctx, published); err != nil {
+        log.Printf("redis: failed to cache posts: %v", err)
+      }
+    }
+    storeMu.Unlock()
+    if err != nil {
+      return nil, err
+    }
+  }
+
+  if err := s.redis.viewCounts(ctx, published.Posts); err != nil {
+    log.Printf("redis: failed to read view counts: %v", err)
+  }
+
+  return published, nil
+}
+
+// recordViewInRedis is RecordView when Redis is enabled. Views are counted in Redis, so unlike the regular RecordView nothing is saved.
+func (s *server) recordViewInRedis(ctx context.Context, id, viewer string, at time.Time) (*pb.RecordViewResponse, error) {
+  published, err := s.getPostsFromRedis(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  // published only holds published posts, so scheduled and deleted ones aren't found either.
+  var post *pb.Post
+  for _, p := range published.Posts {
+    if p.Id == id {
+      post = p
+    }
+  }
+  if post == nil {
+    return nil, apperr.Errorf(apperr.ErrPostNotFound, "post %q not found", id)
+  }
+
+  res, err := s.redis.recordView(ctx, post, viewer, s.views.dedupWindow, at)
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to count the view: %w", err)
+  }
+  if res.Counted {
+    s.views.record([]*pb.Post{post}, at)
+    s.authors.viewed(post.Id, res.ViewCount)
+  }
+
+  return res, nil
+}
+
+// redisInvalidatingStore drops the cached posts every time the posts are saved.
+type redisInvalidatingStore struct {
+  store.PostStore
+  cache *redisCache
+}
+
+// Reader reads through to the wrapped store, embedding only brings the methods of PostStore along.
+func (s *redisInvalidatingStore) Reader() (store.PostReader, error) {
+  return store.OpenReader(s.PostStore)
+}
+
+func (s *redisInvalidatingStore) Lookup(q store.Query) (store.PostReader, error) {
+  return store.Lookup(s.PostStore, q)
+}
+
+func (s *redisInvalidatingStore) Save(posts []*pb.Post) error {
+  if err := s.PostStore.Save(posts); err != nil {
+    return err
+  }
+
+  // The posts are saved at this point, failing to drop the cache only means stale posts until the cache expires.
+  if err := s.cache.invalidate(context.Background()); err != nil {
+    log.Printf("redis: failed to invalidate cached posts: %v", err)
+  }
+
+  return nil
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "math"
+  "slices"
+  "sort"
+  "strings"
+  "sync"
+  "time"
+  "unicode"
+)
+
+/*
+  RELATED POSTS
+
+  GetRelatedPosts answers "if you liked this post, read these": the published posts most similar to a given one.
+
+    go run ./client related -id <post id>
+
+  Two posts are similar when they share tags (see tags.go) and when they use the same words. The tags are compared with the Jaccard index, the tags both posts have over the tags either of them has. The words are compared with TF-IDF:
+    - TF (term frequency): how often a word appears in a post, over the number of words of the post. A post about gRPC says "grpc" a lot.
+    - IDF (inverse document frequency): the logarithm of the number of posts over the number of posts using the word. A word every post uses ("the", "and") gets 0 and drops out, a word only a couple of posts use weighs a lot.
+  Every post becomes a vector with a TF × IDF weight per word, and the similarity of two posts is the cosine of the angle between their vectors: 1 for posts using the same words in the same proportions, 0 for posts without a word in common. The score of a related post is the average of both similarities.
+
+  Tokenizing every post on every call would be wasteful, so the server keeps an index with the word counts of every published post and the number of posts using every word. It is built when the server starts and kept up to date with the changes of the posts by runIndex, see watch.go. Only the IDFs are computed when a query comes in, they change with every post added.
+*/
+const (
+  relatedRebuildEvery = 10 * time.Minute
+  // How much the shared tags weigh in the score, the rest is the similarity of the words.
+  relatedTagWeight = 0.5
+)
+
+type relatedIndex struct {
+  broker *postBroker
+
+  mu   sync.Mutex
+  docs map[string]*relatedDoc
+  // postsWith[word] is the number of indexed posts using the word.
+  postsWith map[string]int
+}
+
+type relatedDoc struct {
+  tags []string
+  // tf[word] is the share of the words of the post that are this word.
+  tf map[string]float64
+}
+
+type relatedMatch struct {
+  id         string
+  score      float64
+  sharedTags []string
+}
+
+func newRelatedIndex(broker *postBroker) *relatedIndex {
+  return &relatedIndex{broker: broker, docs: make(map[string]*relatedDoc), postsWith: make(map[string]int)}
+}
+
+// words splits the text into lowercase words, leaving out the one and two letter ones.
+func words(text string) []string {
+  fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+    return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+  })
+
+  return slices.DeleteFunc(fields, func(word string) bool { return len([]rune(word)) < 3 })
+}
+
+// put indexes the post, replacing what was indexed for it before. Posts that aren't published are only removed.
+func (x *relatedIndex) put(post *pb.Post) {
+  x.mu.Lock()
+  defer x.mu.Unlock()
+
+  x.remove(post.Id)
+  if post.Status != pb.PostStatus_PUBLISHED {
+    return
+  }
+
+  doc := &relatedDoc{tags: post.Tags, tf: make(map[string]float64)}
+  all := words(post.Title + " " + post.Content)
+  for _, word := range all {
+    doc.tf[word] += 1 / float64(len(all))
+  }
+  for word := range doc.tf {
+    x.postsWith[word]++
+  }
+  x.docs[post.Id] = doc
+}
+
+// drop removes a deleted post from the index.
+func (x *relatedIndex) drop(id string) {
+  x.mu.Lock()
+  defer x.mu.Unlock()
+
+  x.remove(id)
+}
+
+// remove drops the post from the index, x.mu must be held.
+func (x *relatedIndex) remove(id string) {
+  doc, ok := x.docs[id]
+  if !ok {
+    return
+  }
+
+  for word := range doc.tf {
+    if x.postsWith[word]--; x.postsWith[word] == 0 {
+      delete(x.postsWith, word)
+    }
+  }
+  delete(x.docs, id)
+}
+
+func (x *relatedIndex) rebuild(posts []*pb.Post) {
+  x.mu.Lock()
+  x.docs, x.postsWith = make(map[string]*relatedDoc), make(map[string]int)
+  x.mu.Unlock()
+
+  for _, post := range posts {
+    x.put(post)
+  }
+}
+
+// vector returns the TF-IDF weights of the document and their norm, x.mu must be held.
+func (x *relatedIndex) vector(doc *relatedDoc) (map[string]float64, float64) {
+  weights := make(map[string]float64, len(doc.tf))
+  var norm float64
+  for word, tf := range doc.tf {
+    w := tf * math.Log(float64(len(x.docs))/float64(x.postsWith[word]))
+    weights[word] = w
+    norm += w * w
+  }
+
+  return weights, math.Sqrt(norm)
+}
+
+// related returns the posts similar to the given one, the most similar first. Posts that aren't indexed have nothing related.
+func (x *relatedIndex) related(id string) []relatedMatch {
+  x.mu.Lock()
+  defer x.mu.Unlock()
+
+  doc, ok := x.docs[id]
+  if !ok {
+    return nil
+  }
+  weights, norm := x.vector(doc)
+
+  var matches []relatedMatch
+  for otherID, other := range x.docs {
+    if otherID == id {
+      continue
+    }
+
+    var cosine float64
+    if otherWeights, otherNorm := x.vector(other); norm > 0 && otherNorm > 0 {
+      for word, w := range weights {
+        cosine += w * otherWeights[word]
+      }
+      cosine /= norm * otherNorm
+    }
+
+    var shared []string
+    for _, tag := range doc.tags {
+      if slices.Contains(other.tags, tag) {
+        shared = append(shared, tag)
+      }
+    }
+    var jaccard float64
+    if union := len(doc.tags) + len(other.tags) - len(shared); union > 0 {
+      jaccard = float64(len(shared)) / float64(union)
+    }
+
+    if score := relatedTagWeight*jaccard + (1-relatedTagWeight)*cosine; score > 0 {
+      matches = append(matches, relatedMatch{id: otherID, score: score, sharedTags: shared})
+    }
+  }
+
+  // Ties are broken by ID so the same question always gets the same answer.
+  sort.Slice(matches, func(i, j int) bool {
+    if matches[i].score != matches[j].score {
+      return matches[i].score > matches[j].score
+    }
+    return matches[i].id < matches[j].id
+  })
+
+  return matches
+}
+
+// load rebuilds the index from the storage.
+func (x *relatedIndex) load() error {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return err
+  }
+  x.rebuild(posts.Posts)
+
+  return nil
+}
+
+func (x *relatedIndex) run(ctx context.Context) error {
+  return runIndex(ctx, x.broker, x, relatedRebuildEvery)
+}
+
+func (s *server) GetRelatedPosts(_ context.Context, req *pb.GetRelatedPostsRequest) (*pb.RelatedPosts, error) {
+  limit := int(req.GetLimit())
+  if limit == 0 {
+    limit = 5
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  post, err := findPost(posts, req.GetPostId())
+  if err != nil {
+    return nil, err
+  }
+  if post.Status != pb.PostStatus_PUBLISHED {
+    return nil, apperr.Errorf(apperr.ErrPostNotFound, "post %q not found", req.GetPostId())
+  }
+
+  // The index may be a little behind the storage, the posts themselves come from the storage and only published ones are returned.
+  published := make(map[string]*pb.Post)
+  for _, p := range publishedPosts(posts).Posts {
+    published[p.Id] = p
+  }
+
+  related := &pb.RelatedPosts{}
+  for _, match := range s.related.related(post.Id) {
+    if p, ok := published[match.id]; ok && len(related.Posts) < limit {
+      related.Posts = append(related.Posts, &pb.RelatedPost{Post: p, Score: match.score, SharedTags: match.sharedTags})
+    }
+  }
+
+  return related, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+  "bytes"
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/i18n"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/store"
+  "html"
+  "strings"
+  "time"
+
+  "github.com/yuin/goldmark"
+  "github.com/yuin/goldmark/extension"
+)
+
+/*
+  MARKDOWN RENDERING
+
+  Posts are written in Markdown and RenderPost turns them into HTML on the server. The renderer is picked with the -renderer flag:
+    - commonmark: standard CommonMark through goldmark (default)
+    - gfm: CommonMark plus the GitHub flavored extensions (tables, strikethrough, autolinks, task lists)
+    - plain: no Markdown at all, the content is escaped and split into paragraphs
+
+  Sanitizing: goldmark doesn't render raw HTML or dangerous links (javascript: and friends) unless it is created with html.WithUnsafe(), which we never do. Whatever an author writes, the output can't contain their own <script> tags.
+*/
+type renderer interface {
+  Render(markdown string) (string, error)
+}
+
+func newRenderer(name string) (renderer, error) {
+  switch name {
+  case "commonmark":
+    return &goldmarkRenderer{md: goldmark.New()}, nil
+  case "gfm":
+    return &goldmarkRenderer{md: goldmark.New(goldmark.WithExtensions(extension.GFM))}, nil
+  case "plain":
+    return plainRenderer{}, nil
+  }
+
+  return nil, fmt.Errorf("unknown renderer %q", name)
+}
+
+type goldmarkRenderer struct {
+  md goldmark.Markdown
+}
+
+func (g *goldmarkRenderer) Render(markdown string) (string, error) {
+  var buf bytes.Buffer
+  if err := g.md.Convert([]byte(markdown), &buf); err != nil {
+    return "", err
+  }
+
+  return buf.String(), nil
+}
+
+type plainRenderer struct{}
+
+func (plainRenderer) Render(text string) (string, error) {
+  var b strings.Builder
+  for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+    if strings.TrimSpace(paragraph) == "" {
+      continue
+    }
+    fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(strings.TrimSpace(paragraph)))
+  }
+
+  return b.String(), nil
+}
+
+func (s *server) RenderPost(ctx context.Context, req *pb.RenderPostRequest) (*pb.RenderedPost, error) {
+  // Only the post asked for is read, see openPosts.
+  posts, err := readPosts(store.Query{IDs: []string{req.GetId()}})
+  if err != nil {
+    return nil, err
+  }
+
+  return renderPost(s.renderer, posts, req.GetId(), reqctx.Locale(ctx))
+}
+
+func renderPost(r renderer, posts *pb.Posts, id, locale string) (*pb.RenderedPost, error) {
+  post, err := findPost(posts, id)
+  if err != nil {
+    return nil, err
+  }
+
+  out, err := r.Render(post.Content)
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrInternal, "failed to render post: %w", err)
+  }
+
+  return &pb.RenderedPost{
+    Id:          post.Id,
+    Title:       post.Title,
+    Html:        out,
+    PublishedOn: publishedOn(post, locale),
+  }, nil
+}
+
+// publishedOn formats the publishing date of the post, posts from before PublishAt existed use their creation date.
+func publishedOn(post *pb.Post, locale string) string {
+  t, err := time.Parse(time.RFC3339, post.PublishAt)
+  if err != nil {
+    t, err = time.Parse("2006-01-02", post.CreatedAt)
+  }
+  if err != nil {
+    return ""
+  }
+
+  return i18n.FormatDate(locale, t)
+}
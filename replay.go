@@ -0,0 +1,479 @@
+package main
+
+import (
+  "bufio"
+  "context"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "io"
+  "log"
+  "os"
+  "os/signal"
+  "strings"
+  "sync"
+  "syscall"
+  "time"
+
+  spb "google.golang.org/genproto/googleapis/rpc/status"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/health"
+  healthpb "google.golang.org/grpc/health/grpc_health_v1"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/encoding/protojson"
+  "google.golang.org/protobuf/proto"
+  "google.golang.org/protobuf/reflect/protoreflect"
+  "google.golang.org/protobuf/reflect/protoregistry"
+)
+
+/*
+  RECORD AND REPLAY
+
+  A demo against a live server never goes the same way twice: the IDs are new, the dates move, the posts of the last demo are still there. -record writes every call of the Blog and Admin services to a file, with its requests, its responses and how it ended, and -replay serves that file back without a storage, a posts.json or anything else:
+
+    go run . -record demo.jsonl
+    go run ./client create -title "Hello" -content "First post" -author alice
+    go run ./client list
+
+    go run . -replay demo.jsonl
+    go run ./client list    the same posts, the same IDs, every time
+
+  The recording is a line of JSON per call, the messages written the way protojson writes them, so it can be read, and edited, by hand:
+
+    {"method":"/grpc_tutorial.Blog/GetPosts","requests":[{}],"responses":[{"posts":[...]}],"status":{}}
+    {"method":"/grpc_tutorial.Admin/GetConnectionStats","requests":[{}],"status":{"code":16,"message":"this RPC requires the admin token"}}
+
+  The replay answers a call with the recorded call of the same method whose requests are the same, the first one it didn't answer with yet, or the first one once it answered them all: run the same demo twice and it goes the same way twice, as long as the client starts the same way too: the offline cache of the CLI changes what it asks for (see client/cache.go). A call nothing was recorded for fails with Unimplemented, naming the method. Streams send their recorded messages one after the other, as fast as the client takes them, and end the way they did.
+
+  That makes the replay a fake server for golden files too, the output of the CLI against a recording checked into the repository doesn't change unless the CLI does:
+
+    go run ./client list > testdata/list.golden                        once, against -replay
+    diff testdata/list.golden <(go run ./client list)                   afterwards
+
+  The recording is taken before any interceptor, so calls turned away by the authentication or the limits are in it too, with their error. The metadata isn't: the tokens stay out of the file, the requests and responses don't. A recording of a real blog has its posts in it, and the passwords of the requests that carry one.
+
+  The replay serves plaintext gRPC on -addr, with the health service saying it is serving, and nothing else: no HTTP, no mirror, no background job.
+*/
+type recordedCall struct {
+  Method    string            `json:"method"`
+  Requests  []json.RawMessage `json:"requests"`
+  Responses []json.RawMessage `json:"responses,omitempty"`
+  // Status is a google.rpc.Status, its details included.
+  Status json.RawMessage `json:"status"`
+}
+
+// recordedServices are the services of the blog, the health checks and channelz aren't recorded.
+const recordedServices = "/grpc_tutorial."
+
+type callRecorder struct {
+  mu   sync.Mutex
+  file *os.File
+  enc  *json.Encoder
+}
+
+// newCallRecorder appends the calls to the file at path, without a path it records nothing.
+func newCallRecorder(path string) (*callRecorder, error) {
+  if path == "" {
+    return &callRecorder{}, nil
+  }
+  file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+  if err != nil {
+    return nil, fmt.Errorf("failed to open the recording: %w", err)
+  }
+
+  return &callRecorder{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+func (r *callRecorder) Close() error {
+  if r.file == nil {
+    return nil
+  }
+
+  return r.file.Close()
+}
+
+func (r *callRecorder) records(fullMethod string) bool {
+  return r.file != nil && strings.HasPrefix(fullMethod, recordedServices)
+}
+
+// marshalMessage writes a message of a call as protojson, at once: handlers reuse what they sent (see internal/store/pool.go).
+func marshalMessage(m any) (json.RawMessage, error) {
+  msg, ok := m.(proto.Message)
+  if !ok {
+    return nil, fmt.Errorf("%T isn't a protobuf message", m)
+  }
+
+  return protojson.Marshal(msg)
+}
+
+func (r *callRecorder) record(call *recordedCall, err error) {
+  st, merr := protojson.Marshal(status.Convert(err).Proto())
+  if merr != nil {
+    log.Printf("failed to record %s: %v", call.Method, merr)
+    return
+  }
+  call.Status = st
+
+  r.mu.Lock()
+  defer r.mu.Unlock()
+  if err := r.enc.Encode(call); err != nil {
+    log.Printf("failed to record %s: %v", call.Method, err)
+  }
+}
+
+func (r *callRecorder) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  if !r.records(info.FullMethod) {
+    return handler(ctx, req)
+  }
+
+  call := &recordedCall{Method: info.FullMethod}
+  request, err := marshalMessage(req)
+  if err != nil {
+    log.Printf("failed to record %s: %v", info.FullMethod, err)
+    return handler(ctx, req)
+  }
+  call.Requests = []json.RawMessage{request}
+
+  resp, err := handler(ctx, req)
+  if err == nil {
+    response, merr := marshalMessage(resp)
+    if merr != nil {
+      log.Printf("failed to record %s: %v", info.FullMethod, merr)
+      return resp, err
+    }
+    call.Responses = []json.RawMessage{response}
+  }
+  r.record(call, err)
+
+  return resp, err
+}
+
+func (r *callRecorder) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  if !r.records(info.FullMethod) {
+    return handler(srv, ss)
+  }
+
+  stream := &recordingStream{ServerStream: ss, call: &recordedCall{Method: info.FullMethod}}
+  err := handler(srv, stream)
+  stream.mu.Lock()
+  defer stream.mu.Unlock()
+  if stream.failed {
+    return err
+  }
+  r.record(stream.call, err)
+
+  return err
+}
+
+// recordingStream keeps the messages going through, handlers may receive and send on goroutines of their own.
+type recordingStream struct {
+  grpc.ServerStream
+
+  mu   sync.Mutex
+  call *recordedCall
+  // failed is set when a message couldn't be recorded, the call then isn't.
+  failed bool
+}
+
+func (s *recordingStream) keep(list *[]json.RawMessage, m any) {
+  data, err := marshalMessage(m)
+
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  if err != nil {
+    if !s.failed {
+      log.Printf("failed to record %s: %v", s.call.Method, err)
+    }
+    s.failed = true
+    return
+  }
+  *list = append(*list, data)
+}
+
+func (s *recordingStream) RecvMsg(m any) error {
+  if err := s.ServerStream.RecvMsg(m); err != nil {
+    return err
+  }
+  s.keep(&s.call.Requests, m)
+
+  return nil
+}
+
+func (s *recordingStream) SendMsg(m any) error {
+  // Marshaled before sending, the handler may change the message once it was sent.
+  data, merr := marshalMessage(m)
+  if err := s.ServerStream.SendMsg(m); err != nil {
+    return err
+  }
+
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  if merr != nil {
+    if !s.failed {
+      log.Printf("failed to record %s: %v", s.call.Method, merr)
+    }
+    s.failed = true
+    return nil
+  }
+  s.call.Responses = append(s.call.Responses, data)
+
+  return nil
+}
+
+// replayedCall is a recorded call, with what is needed to answer it.
+type replayedCall struct {
+  method    protoreflect.MethodDescriptor
+  requests  []proto.Message
+  responses []proto.Message
+  status    *status.Status
+  answered  bool
+}
+
+type replayServer struct {
+  mu    sync.Mutex
+  calls map[string][]*replayedCall
+}
+
+// findMethod returns the descriptor of a method written like /grpc_tutorial.Blog/GetPosts.
+func findMethod(fullMethod string) (protoreflect.MethodDescriptor, error) {
+  service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+  if !ok {
+    return nil, fmt.Errorf("invalid method %q", fullMethod)
+  }
+  desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+  if err != nil {
+    return nil, fmt.Errorf("unknown service of %s", fullMethod)
+  }
+  sd, ok := desc.(protoreflect.ServiceDescriptor)
+  if !ok {
+    return nil, fmt.Errorf("unknown service of %s", fullMethod)
+  }
+  md := sd.Methods().ByName(protoreflect.Name(method))
+  if md == nil {
+    return nil, fmt.Errorf("unknown method %s", fullMethod)
+  }
+
+  return md, nil
+}
+
+func newMessage(desc protoreflect.MessageDescriptor) (proto.Message, error) {
+  mt, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+  if err != nil {
+    return nil, err
+  }
+
+  return mt.New().Interface(), nil
+}
+
+func unmarshalMessages(desc protoreflect.MessageDescriptor, raw []json.RawMessage) ([]proto.Message, error) {
+  messages := make([]proto.Message, 0, len(raw))
+  for _, data := range raw {
+    msg, err := newMessage(desc)
+    if err != nil {
+      return nil, err
+    }
+    if err := protojson.Unmarshal(data, msg); err != nil {
+      return nil, err
+    }
+    messages = append(messages, msg)
+  }
+
+  return messages, nil
+}
+
+// loadRecording reads a file of -record, every line of it: a recording that doesn't read fails now rather than in the middle of a demo.
+func loadRecording(path string) (*replayServer, error) {
+  file, err := os.Open(path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read the recording: %w", err)
+  }
+  defer file.Close()
+
+  r := &replayServer{calls: map[string][]*replayedCall{}}
+  scanner := bufio.NewScanner(file)
+  // A line holds every message of its call, a StreamPosts of a big blog makes a long one.
+  scanner.Buffer(nil, 256<<20)
+  for line := 1; scanner.Scan(); line++ {
+    if len(strings.TrimSpace(scanner.Text())) == 0 {
+      continue
+    }
+    call, err := parseRecordedCall(scanner.Bytes())
+    if err != nil {
+      return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+    }
+    name := string(call.method.FullName())
+    r.calls[name] = append(r.calls[name], call)
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, fmt.Errorf("failed to read the recording: %w", err)
+  }
+
+  return r, nil
+}
+
+func parseRecordedCall(line []byte) (*replayedCall, error) {
+  var recorded recordedCall
+  if err := json.Unmarshal(line, &recorded); err != nil {
+    return nil, err
+  }
+  md, err := findMethod(recorded.Method)
+  if err != nil {
+    return nil, err
+  }
+
+  call := &replayedCall{method: md}
+  if call.requests, err = unmarshalMessages(md.Input(), recorded.Requests); err != nil {
+    return nil, fmt.Errorf("request of %s: %w", recorded.Method, err)
+  }
+  if call.responses, err = unmarshalMessages(md.Output(), recorded.Responses); err != nil {
+    return nil, fmt.Errorf("response of %s: %w", recorded.Method, err)
+  }
+  st := &spb.Status{}
+  if len(recorded.Status) > 0 {
+    if err := protojson.Unmarshal(recorded.Status, st); err != nil {
+      return nil, fmt.Errorf("status of %s: %w", recorded.Method, err)
+    }
+  }
+  call.status = status.FromProto(st)
+
+  return call, nil
+}
+
+// find returns the recorded call answering the requests, nil when there is none. See RECORD AND REPLAY above.
+func (r *replayServer) find(md protoreflect.MethodDescriptor, requests []proto.Message) *replayedCall {
+  r.mu.Lock()
+  defer r.mu.Unlock()
+
+  var first *replayedCall
+  for _, call := range r.calls[string(md.FullName())] {
+    if !sameMessages(call.requests, requests) {
+      continue
+    }
+    if !call.answered {
+      call.answered = true
+      return call
+    }
+    if first == nil {
+      first = call
+    }
+  }
+
+  return first
+}
+
+func sameMessages(a, b []proto.Message) bool {
+  if len(a) != len(b) {
+    return false
+  }
+  for i := range a {
+    if !proto.Equal(a[i], b[i]) {
+      return false
+    }
+  }
+
+  return true
+}
+
+// handle answers every call, from the recording: the replay has no service of its own, its calls all go to the unknown service handler.
+func (r *replayServer) handle(_ any, stream grpc.ServerStream) error {
+  fullMethod, _ := grpc.MethodFromServerStream(stream)
+  md, err := findMethod(fullMethod)
+  if err != nil {
+    return status.Errorf(codes.Unimplemented, "%v", err)
+  }
+
+  var requests []proto.Message
+  for {
+    req, err := newMessage(md.Input())
+    if err != nil {
+      return status.Errorf(codes.Internal, "%v", err)
+    }
+    err = stream.RecvMsg(req)
+    if errors.Is(err, io.EOF) {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    requests = append(requests, req)
+    if !md.IsStreamingClient() {
+      break
+    }
+  }
+
+  call := r.find(md, requests)
+  if call == nil {
+    return status.Errorf(codes.Unimplemented, "the recording has no %s with these requests", fullMethod)
+  }
+  for _, resp := range call.responses {
+    if err := stream.SendMsg(resp); err != nil {
+      return err
+    }
+  }
+  // A stream the client left, like a WatchPosts, stays open until the client leaves again.
+  if call.status.Code() == codes.Canceled {
+    <-stream.Context().Done()
+    return status.FromContextError(stream.Context().Err()).Err()
+  }
+
+  return call.status.Err()
+}
+
+// serveReplay serves the recording on the addresses of -addr until SIGINT or SIGTERM.
+func serveReplay(addrList, path string) error {
+  replay, err := loadRecording(path)
+  if err != nil {
+    return err
+  }
+  addrs, err := parseListenAddrs(addrList)
+  if err != nil {
+    return err
+  }
+  listeners, err := listen(addrs)
+  if err != nil {
+    return fmt.Errorf("failed to listen %w", err)
+  }
+
+  srv := grpc.NewServer(grpc.UnknownServiceHandler(replay.handle))
+  healthpb.RegisterHealthServer(srv, health.NewServer())
+  var calls int
+  for _, recorded := range replay.calls {
+    calls += len(recorded)
+  }
+  log.Printf("replaying %d calls of %s", calls, path)
+
+  ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+  defer stop()
+  go func() {
+    <-ctx.Done()
+    log.Printf("shutting down")
+    stopped := make(chan struct{})
+    go func() {
+      srv.GracefulStop()
+      close(stopped)
+    }()
+    // A WatchPosts keeps going until its client leaves, the replay doesn't wait for long.
+    select {
+    case <-stopped:
+    case <-time.After(5 * time.Second):
+      srv.Stop()
+    }
+  }()
+
+  var serving sync.WaitGroup
+  errs := make(chan error, len(listeners))
+  for _, lis := range listeners {
+    serving.Add(1)
+    go func() {
+      defer serving.Done()
+      if err := srv.Serve(lis); err != nil {
+        errs <- err
+      }
+    }()
+  }
+  serving.Wait()
+  close(errs)
+
+  return <-errs
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/store"
+  "sync"
+  "time"
+)
+
+/*
+  REPORTING ABUSE
+
+  Moderation (see moderation.go) only catches what a word list or a service can spot. Readers spot the rest, ReportPost and ReportComment let them tell the moderators, with a reason and some details:
+
+    go run ./client reports post -post <post id> -reason spam
+    go run ./client reports comment -comment <comment id> -reason harassment -details "keeps insulting John"
+
+  The moderators are the admins. ListReports is their queue, the open reports the oldest first, and ResolveReport closes one of them in one of three ways:
+    - DISMISSED: nothing wrong, the content stays
+    - CONTENT_HIDDEN: the post is archived like ArchivePosts does, or the comment rejected
+    - AUTHOR_BANNED: the content is hidden and its author is banned, see bans.go
+
+    go run ./client reports list -token secret
+    go run ./client reports resolve -token secret -id <report id> -action ban -note "spam bot"
+
+  A post or comment reported several times is resolved once: the other open reports of the same content get the same resolution. Only authors with an identity can be banned this way, and admins can't be. The reports don't know the address of an anonymous author, whose calls are better looked up in the audit log and banned by IP with AddBan. The reports are kept in reports.json, and every report and resolution is in the audit log (see audit.go).
+*/
+const reportsPath = "reports.json"
+
+type reportStore struct {
+  path string
+
+  // mu guards the reports file.
+  mu sync.Mutex
+}
+
+func newReportStore(path string) *reportStore {
+  return &reportStore{path: path}
+}
+
+func (s *server) ReportPost(ctx context.Context, req *pb.ReportPostRequest) (*pb.Report, error) {
+  post, err := publishedPost(req.GetPostId())
+  if err != nil {
+    return nil, err
+  }
+
+  return s.reports.add(ctx, &pb.Report{PostId: post.Id, Reason: req.GetReason(), Details: req.GetDetails()})
+}
+
+func (s *server) ReportComment(ctx context.Context, req *pb.ReportCommentRequest) (*pb.Report, error) {
+  comment, err := s.comments.get(req.GetCommentId())
+  if err != nil {
+    return nil, err
+  }
+  // Readers can only report what they can see.
+  if comment.Status != pb.CommentStatus_APPROVED {
+    return nil, apperr.Errorf(apperr.ErrCommentNotFound, "comment %q not found", req.GetCommentId())
+  }
+  if _, err := publishedPost(comment.PostId); err != nil {
+    return nil, err
+  }
+
+  return s.reports.add(ctx, &pb.Report{PostId: comment.PostId, CommentId: comment.Id, Reason: req.GetReason(), Details: req.GetDetails()})
+}
+
+func (s *server) ListReports(ctx context.Context, req *pb.ListReportsRequest) (*pb.Reports, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  r := s.reports
+  r.mu.Lock()
+  all, err := r.load()
+  r.mu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  reports := &pb.Reports{Reports: make([]*pb.Report, 0)}
+  for _, report := range all {
+    resolved := report.Resolution != pb.ReportResolution_UNRESOLVED
+    if resolved != req.GetResolved() || (req.GetPostId() != "" && report.PostId != req.GetPostId()) {
+      continue
+    }
+    reports.Reports = append(reports.Reports, report)
+  }
+
+  return reports, nil
+}
+
+func (s *server) ResolveReport(ctx context.Context, req *pb.ResolveReportRequest) (*pb.Report, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+  if req.GetResolution() == pb.ReportResolution_UNRESOLVED {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Resolution must be DISMISSED, CONTENT_HIDDEN or AUTHOR_BANNED")
+  }
+
+  r := s.reports
+  r.mu.Lock()
+  defer r.mu.Unlock()
+
+  all, err := r.load()
+  if err != nil {
+    return nil, err
+  }
+  var report *pb.Report
+  for _, candidate := range all {
+    if candidate.Id == req.GetId() {
+      report = candidate
+    }
+  }
+  if report == nil {
+    return nil, apperr.Errorf(apperr.ErrReportNotFound, "report %q not found", req.GetId())
+  }
+  if report.Resolution != pb.ReportResolution_UNRESOLVED {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "report %q was resolved already", report.Id)
+  }
+
+  if req.GetResolution() != pb.ReportResolution_DISMISSED {
+    author, err := s.reportedAuthor(report)
+    if err != nil {
+      return nil, err
+    }
+    // Checked before anything is hidden, so a ban that can't happen changes nothing.
+    if req.GetResolution() == pb.ReportResolution_AUTHOR_BANNED {
+      switch {
+      case author == "":
+        return nil, apperr.Errorf(apperr.ErrInvalidArgument, "the author of the reported content is anonymous, it can only be hidden")
+      case isAdmin(author):
+        return nil, apperr.Errorf(apperr.ErrInvalidArgument, "admins can't be banned")
+      }
+    }
+    if err := s.hideReported(report, req.GetNote()); err != nil {
+      return nil, err
+    }
+    if req.GetResolution() == pb.ReportResolution_AUTHOR_BANNED {
+      ban := &pb.Ban{Identity: author, Reason: req.GetNote(), BannedBy: reqctx.Identity(ctx), ReportId: report.Id}
+      if _, err := s.bans.add(ban); err != nil {
+        return nil, err
+      }
+    }
+  }
+
+  now := serverClock.Now().UTC().Format(time.RFC3339)
+  for _, other := range all {
+    if other.Resolution != pb.ReportResolution_UNRESOLVED || other.PostId != report.PostId || other.CommentId != report.CommentId {
+      continue
+    }
+    other.Resolution = req.GetResolution()
+    other.ResolvedBy = reqctx.Identity(ctx)
+    other.ResolvedAt = now
+    other.Note = req.GetNote()
+  }
+
+  if err := r.save(all); err != nil {
+    return nil, err
+  }
+
+  return report, nil
+}
+
+// reportedAuthor returns the identity that created the reported post or comment.
+func (s *server) reportedAuthor(report *pb.Report) (string, error) {
+  if report.CommentId != "" {
+    comment, err := s.comments.get(report.CommentId)
+    if err != nil {
+      return "", err
+    }
+    return comment.CreatedBy, nil
+  }
+
+  posts, err := readPosts(store.Query{IDs: []string{report.PostId}})
+  if err != nil {
+    return "", err
+  }
+  post, err := findPost(posts, report.PostId)
+  if err != nil {
+    return "", err
+  }
+
+  return post.CreatedBy, nil
+}
+
+// hideReported rejects the reported comment, or archives the reported post. Content hidden already stays as it is.
+func (s *server) hideReported(report *pb.Report, note string) error {
+  if report.CommentId != "" {
+    _, err := s.comments.change(report.CommentId, func(comment *pb.Comment) {
+      comment.Status, comment.ModerationReason = pb.CommentStatus_REJECTED, note
+    })
+    return err
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return err
+  }
+  post, err := findPost(posts, report.PostId)
+  if err != nil {
+    return err
+  }
+  if post.Status != pb.PostStatus_PUBLISHED && post.Status != pb.PostStatus_SCHEDULED {
+    return nil
+  }
+
+  archived := s.archive(posts, []*pb.Post{post})
+  if err := savePosts(posts); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+  archived()
+
+  return nil
+}
+
+// add saves a new report made by the caller.
+func (r *reportStore) add(ctx context.Context, report *pb.Report) (*pb.Report, error) {
+  report.Id = store.NewID()
+  report.CreatedAt = serverClock.Now().UTC().Format(time.RFC3339)
+  if identity := reqctx.Identity(ctx); identity != anonymousIdentity {
+    report.ReportedBy = identity
+  }
+
+  r.mu.Lock()
+  defer r.mu.Unlock()
+
+  all, err := r.load()
+  if err != nil {
+    return nil, err
+  }
+  if err := r.save(append(all, report)); err != nil {
+    return nil, err
+  }
+
+  return report, nil
+}
+
+func (r *reportStore) load() ([]*pb.Report, error) {
+  var all []*pb.Report
+  if err := readJSON(r.path, &all); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to load reports: %w", err)
+  }
+
+  return all, nil
+}
+
+func (r *reportStore) save(all []*pb.Report) error {
+  if err := replaceFile(r.path, all); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save reports: %w", err)
+  }
+
+  return nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+  "context"
+  "encoding/json"
+  "errors"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+  "io/fs"
+  "os"
+  "time"
+
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  REVISION HISTORY
+
+  Before UpdatePost (or RestoreRevision) changes a post, the current version is stored as a revision in revisions.json, a map from post ID to its revisions, oldest first. Only the last -max-revisions revisions of every post are kept.
+
+  Restoring a revision is itself an update, so the version being replaced becomes a new revision and a restore can always be undone. It brings back the title, the content, the author and the tags, the tags being empty for the revisions recorded before they were kept, and the moderators (see moderation.go) get to see the post as restored like any edit.
+
+  With encryption keys the titles and contents of the revisions are encrypted like the ones of the posts, see internal/store/encrypt.go
+*/
+
+var revisionsPath string = "revisions.json"
+
+// revisionKeys encrypt the revisions, nil keeps them in clear. Set by main to the keys of the storage.
+var revisionKeys *store.Keyring
+
+func (s *server) ListRevisions(_ context.Context, req *pb.ListRevisionsRequest) (*pb.Revisions, error) {
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  revisions, err := loadRevisions()
+  if err != nil {
+    return nil, err
+  }
+
+  return &pb.Revisions{Revisions: revisions[req.GetPostId()]}, nil
+}
+
+func (s *server) RestoreRevision(ctx context.Context, req *pb.RestoreRevisionRequest) (*pb.Post, error) {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return nil, err
+  }
+
+  post, err := findPost(posts, req.GetPostId())
+  if err != nil {
+    return nil, err
+  }
+
+  revisions, err := loadRevisions()
+  if err != nil {
+    return nil, err
+  }
+
+  var revision *pb.Revision
+  for _, r := range revisions[post.Id] {
+    if r.Number == req.GetNumber() {
+      revision = r
+    }
+  }
+
+  if revision == nil {
+    return nil, apperr.Errorf(apperr.ErrRevisionNotFound, "revision %d of post %q not found", req.GetNumber(), post.Id)
+  }
+
+  previous := proto.Clone(post).(*pb.Post)
+
+  post.Title = revision.Title
+  post.Content = revision.Content
+  store.SetReadingTime(post)
+  store.SetLinks(posts.Posts, post)
+  post.Author = revision.Author
+  post.Tags = revision.Tags
+  post.UpdatedAt = serverClock.Now().UTC().Format(time.RFC3339)
+
+  // The restored version goes through the moderators like any edit, so content they reject can't come back through an old revision.
+  if err := s.moderatePost(ctx, post); err != nil {
+    return nil, err
+  }
+  if err := s.recordRevision(previous); err != nil {
+    return nil, err
+  }
+
+  post.Sequence = nextSequence(posts)
+
+  if err := savePosts(posts); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+
+  if post.Status == pb.PostStatus_PUBLISHED {
+    s.broker.publish(pb.PostEventType_POST_UPDATED, post)
+  }
+
+  return post, nil
+}
+
+// recordRevision stores the given version of a post and drops the oldest revisions past the retention limit. The caller must hold storeMu.
+func (s *server) recordRevision(previous *pb.Post) error {
+  revisions, err := loadRevisions()
+  if err != nil {
+    return err
+  }
+
+  history := revisions[previous.Id]
+
+  var number int64 = 1
+  if len(history) > 0 {
+    number = history[len(history)-1].Number + 1
+  }
+
+  history = append(history, &pb.Revision{
+    Number:    number,
+    Title:     previous.Title,
+    Content:   previous.Content,
+    Author:    previous.Author,
+    Tags:      previous.Tags,
+    CreatedAt: serverClock.Now().UTC().Format(time.RFC3339),
+  })
+
+  if s.maxRevisions > 0 && len(history) > s.maxRevisions {
+    history = history[len(history)-s.maxRevisions:]
+  }
+  revisions[previous.Id] = history
+
+  return saveRevisions(revisions)
+}
+
+func loadRevisions() (map[string][]*pb.Revision, error) {
+  revisions := make(map[string][]*pb.Revision)
+
+  data, err := os.ReadFile(revisionsPath)
+  // No file yet simply means no post has been updated so far.
+  if errors.Is(err, fs.ErrNotExist) {
+    return revisions, nil
+  }
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read revisions file: %w", err)
+  }
+
+  if err := json.Unmarshal(data, &revisions); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to parse revisions: %w", err)
+  }
+
+  for id, history := range revisions {
+    for _, revision := range history {
+      if err := revisionKeys.DecryptRevision(id, revision); err != nil {
+        return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to decrypt revisions: %w", err)
+      }
+    }
+  }
+
+  return revisions, nil
+}
+
+// saveRevisions encrypts copies of the revisions, the ones of the caller stay in clear.
+func saveRevisions(revisions map[string][]*pb.Revision) error {
+  stored := make(map[string][]*pb.Revision, len(revisions))
+  for id, history := range revisions {
+    for _, revision := range history {
+      encrypted := proto.Clone(revision).(*pb.Revision)
+      if err := revisionKeys.EncryptRevision(id, encrypted); err != nil {
+        return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save revisions: %w", err)
+      }
+      stored[id] = append(stored[id], encrypted)
+    }
+  }
+
+  data, err := json.MarshalIndent(stored, "", "  ")
+  if err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save revisions: %w", err)
+  }
+
+  if err := os.WriteFile(revisionsPath, data, 0644); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save revisions: %w", err)
+  }
+
+  return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "path/filepath"
+  "slices"
+  "testing"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+func TestRestoreRevisionIsModerated(t *testing.T) {
+  useFileStore(t, []*pb.Post{{Id: "p1", Title: "Launch plan", Content: "Buy cheap spam here.", Author: "Ana", Tags: []string{"ads"}, Status: pb.PostStatus_DRAFT}})
+  previousPath := revisionsPath
+  revisionsPath = filepath.Join(t.TempDir(), "revisions.json")
+  t.Cleanup(func() { revisionsPath = previousPath })
+
+  s := &server{}
+  if err := s.recordRevision(&pb.Post{Id: "p1", Title: "Launch plan", Content: "Buy cheap spam here.", Author: "Ana", Tags: []string{"ads"}}); err != nil {
+    t.Fatal(err)
+  }
+  if err := s.recordRevision(&pb.Post{Id: "p1", Title: "Launch plan", Content: "We ship on Friday.", Author: "Ana", Tags: []string{"release", "plans"}}); err != nil {
+    t.Fatal(err)
+  }
+
+  s.moderator = &wordlistModerator{verdict: verdictReject, entries: [][]string{{"spam"}}}
+  if _, err := s.RestoreRevision(context.Background(), &pb.RestoreRevisionRequest{PostId: "p1", Number: 1}); status.Code(err) != codes.InvalidArgument {
+    t.Fatalf("got %v restoring the rejected revision, want InvalidArgument", err)
+  }
+  if revisions, err := loadRevisions(); err != nil || len(revisions["p1"]) != 2 {
+    t.Fatalf("got %v, %v, want no revision recorded for the rejected restore", revisions, err)
+  }
+
+  post, err := s.RestoreRevision(context.Background(), &pb.RestoreRevisionRequest{PostId: "p1", Number: 2})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if post.Content != "We ship on Friday." || !slices.Equal(post.Tags, []string{"release", "plans"}) {
+    t.Errorf("got %q with the tags %v, want the content and the tags of revision 2", post.Content, post.Tags)
+  }
+  if revisions, err := loadRevisions(); err != nil || !slices.Equal(revisions["p1"][2].Tags, []string{"ads"}) {
+    t.Errorf("got %v, %v, want the replaced version kept with its tags", revisions, err)
+  }
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "sort"
+  "time"
+
+  // The time zone database is usually found in the operating system, but not in minimal containers like distroless or scratch images. Importing time/tzdata embeds a copy in the binary (about 450KB) that LoadLocation falls back to.
+  _ "time/tzdata"
+)
+
+/*
+  SCHEDULED PUBLISHING
+
+  CreatePost and UpdatePost accept a PublishAt timestamp. A post with a PublishAt in the future is stored as SCHEDULED and hidden from readers, and the scheduler job flips it to PUBLISHED once its time comes, letting the WatchPosts subscribers know.
+
+  Instead of polling every few seconds the scheduler sleeps until the next post is due. When a handler schedules a post it pokes the scheduleChanged channel so the scheduler recomputes how long to sleep.
+*/
+
+// schedulePost validates publishAt and sets the status of the post accordingly. An empty publishAt means now.
+func schedulePost(post *pb.Post, publishAt string) error {
+  at := serverClock.Now()
+
+  if publishAt != "" {
+    t, err := time.Parse(time.RFC3339, publishAt)
+    if err != nil {
+      return apperr.Errorf(apperr.ErrInvalidArgument, "PublishAt must be an RFC 3339 timestamp: %w", err)
+    }
+    at = t
+  }
+
+  post.PublishAt = at.UTC().Format(time.RFC3339)
+  post.Status = pb.PostStatus_PUBLISHED
+  if at.After(serverClock.Now()) {
+    post.Status = pb.PostStatus_SCHEDULED
+  }
+
+  return nil
+}
+
+// afterSchedule is called once a created or updated post has been saved.
+func (s *server) afterSchedule(post *pb.Post) {
+  if post.Status == pb.PostStatus_PUBLISHED {
+    s.broker.publish(pb.PostEventType_POST_PUBLISHED, post)
+    return
+  }
+
+  // The channel has room for one pending wake up. If there is one already the scheduler will see this post anyway, so we don't need to block.
+  select {
+  case s.scheduleChanged <- struct{}{}:
+  default:
+  }
+}
+
+func (s *server) runScheduler(ctx context.Context) error {
+  for {
+    next, err := s.publishDuePosts()
+    if err != nil {
+      return err
+    }
+
+    // Nothing scheduled: sleep until a handler wakes us up, checking every hour just in case.
+    wait := time.Hour
+    if !next.IsZero() {
+      wait = next.Sub(serverClock.Now())
+    }
+
+    timer := serverClock.NewTimer(wait)
+    select {
+    case <-ctx.Done():
+      timer.Stop()
+      return nil
+    case <-s.scheduleChanged:
+      timer.Stop()
+    case <-timer.C():
+    }
+  }
+}
+
+// publishDuePosts publishes every scheduled post whose time has come and returns when the next one is due, or the zero time if there are none.
+func (s *server) publishDuePosts() (time.Time, error) {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return time.Time{}, err
+  }
+
+  now := serverClock.Now()
+  var next time.Time
+  var due []*pb.Post
+
+  for _, post := range posts.Posts {
+    if post.Status != pb.PostStatus_SCHEDULED {
+      continue
+    }
+
+    at, err := time.Parse(time.RFC3339, post.PublishAt)
+    if err != nil || !at.After(now) {
+      post.Status = pb.PostStatus_PUBLISHED
+      post.Sequence = nextSequence(posts)
+      due = append(due, post)
+      continue
+    }
+
+    if next.IsZero() || at.Before(next) {
+      next = at
+    }
+  }
+
+  if len(due) == 0 {
+    return next, nil
+  }
+
+  if err := savePosts(posts); err != nil {
+    return time.Time{}, err
+  }
+
+  for _, post := range due {
+    s.broker.publish(pb.PostEventType_POST_PUBLISHED, post)
+  }
+
+  return next, nil
+}
+
+/*
+  PUBLISHING CALENDAR
+
+  GetPublishingSchedule returns the scheduled posts of a range of days, grouped by day, which is what a calendar needs to draw itself.
+
+  Which day a post falls on depends on where you are: a post scheduled at 2025-06-04T23:30:00Z is published on June 4th in London but on June 5th in Paris. PublishAt is stored in UTC, so the request says which time zone the calendar is for, by its IANA name. We load the zone with time.LoadLocation and convert every PublishAt with In before taking its date. Days are then ranges of local time from midnight to midnight, and a day isn't always 24 hours long: the days clocks change are 23 or 25. That is why the range is computed with AddDate, which counts calendar days, rather than by adding 24 hours.
+*/
+const maxScheduleDays = 366
+
+func (s *server) GetPublishingSchedule(ctx context.Context, req *pb.GetPublishingScheduleRequest) (*pb.PublishingSchedule, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  zone := req.GetTimeZone()
+  if zone == "" {
+    zone = "UTC"
+  }
+  loc, err := time.LoadLocation(zone)
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "unknown time zone %q", zone)
+  }
+
+  now := serverClock.Now().In(loc)
+  from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+  if req.GetFrom() != "" {
+    if from, err = time.ParseInLocation("2006-01-02", req.GetFrom(), loc); err != nil {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "From must be a YYYY-MM-DD date: %w", err)
+    }
+  }
+
+  to := from.AddDate(0, 0, 30)
+  if req.GetTo() != "" {
+    if to, err = time.ParseInLocation("2006-01-02", req.GetTo(), loc); err != nil {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "To must be a YYYY-MM-DD date: %w", err)
+    }
+  }
+
+  if to.Before(from) {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "To can't be before From")
+  }
+  if to.After(from.AddDate(0, 0, maxScheduleDays-1)) {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "the range can't be longer than %d days", maxScheduleDays)
+  }
+  // To is included, the range ends at the midnight that follows it.
+  end := to.AddDate(0, 0, 1)
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err = loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  type entry struct {
+    at   time.Time
+    post *pb.Post
+  }
+  var scheduled []entry
+  for _, post := range posts.Posts {
+    if post.Status != pb.PostStatus_SCHEDULED {
+      continue
+    }
+
+    at, err := time.Parse(time.RFC3339, post.PublishAt)
+    if err != nil {
+      continue
+    }
+    if at = at.In(loc); at.Before(from) || !at.Before(end) {
+      continue
+    }
+    scheduled = append(scheduled, entry{at, post})
+  }
+  sort.Slice(scheduled, func(i, j int) bool { return scheduled[i].at.Before(scheduled[j].at) })
+
+  schedule := &pb.PublishingSchedule{TimeZone: loc.String()}
+  for _, e := range scheduled {
+    date := e.at.Format("2006-01-02")
+    if n := len(schedule.Days); n == 0 || schedule.Days[n-1].Date != date {
+      schedule.Days = append(schedule.Days, &pb.ScheduledDay{Date: date})
+    }
+
+    day := schedule.Days[len(schedule.Days)-1]
+    day.Posts = append(day.Posts, &pb.ScheduledPost{
+      Id:        e.post.Id,
+      Title:     e.post.Title,
+      Author:    e.post.Author,
+      PublishAt: e.at.Format(time.RFC3339),
+    })
+  }
+
+  return schedule, nil
+}
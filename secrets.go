@@ -0,0 +1,327 @@
+package main
+
+import (
+  "bytes"
+  "context"
+  "crypto/tls"
+  "encoding/json"
+  "fmt"
+  "io"
+  "log"
+  "net/http"
+  "os"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials"
+  "google.golang.org/grpc/credentials/insecure"
+)
+
+/*
+  SECRETS
+
+  The admin token, the key signing the session tokens, the TLS certificate of the server and the passwords of the SMTP and Redis servers are secrets. Given in a flag every user of the machine sees them with ps, kept in a file only read at start they can't change without a restart. Each of them can come from a secret reference instead:
+
+    file:/run/secrets/admin-token        the content of a file, without the spaces around it
+    vault:secret/data/blog#admin_token   a field of a secret in Vault
+    env:BLOG_ADMIN_TOKEN                 an environment variable
+
+  given to the flag of the secret:
+
+    -admin-token-from       the admin token, instead of -admin-token
+    -session-key-from       the session key in hex, instead of session.key (see sessions.go)
+    -tls-cert-from          the certificate of the server, in PEM, with the certificates of the chain after it
+    -tls-key-from           its private key in PEM, the server only serves TLS with both
+    -smtp-password-from     the password of -smtp-user, BLOG_SMTP_PASSWORD by default
+    -redis-password-from    the password of -redis-addr
+
+    go run . -admin-token-from vault:secret/data/blog#admin_token -tls-cert-from file:tls/cert.pem -tls-key-from file:tls/key.pem
+
+  Vault is reached at VAULT_ADDR with the token in VAULT_TOKEN, like the vault CLI. The path is the one of the HTTP API, data/ included for a KV version 2 engine, the field one of the keys of the secret.
+
+  ROTATION
+
+  Every -secrets-refresh the secrets are read again and a value that changed is used from then on, without a restart:
+
+    - a new admin token is the only one accepted from the next call on
+    - a new certificate is served to the next connections, the open ones keep the one they started with
+    - SMTP and Redis get the new password on their next connection
+    - a new session key signs the new session tokens, the ones signed with the previous key stay valid, otherwise every reader would lose their session at once
+
+  A secret that can't be read, a file halfway through being written or a Vault that is down, keeps its value until the next refresh, the failure is logged. So does a certificate that doesn't go with its key, the previous pair is served until both files match. A secret that can't be read when the server starts stops it, there is no value to keep.
+*/
+const defaultSecretsRefresh = time.Minute
+
+// secret is a value read from a secret reference, see above.
+type secret struct {
+  ref  string
+  read func() ([]byte, error)
+
+  mu    sync.RWMutex
+  value []byte
+  // onChange runs after a refresh changed the value, an error leaves whatever uses the secret as it was.
+  onChange []func([]byte) error
+}
+
+type secretStore struct {
+  vault *vaultClient
+
+  mu      sync.Mutex
+  secrets []*secret
+}
+
+func newSecretStore() *secretStore {
+  return &secretStore{vault: &vaultClient{addr: os.Getenv("VAULT_ADDR"), token: os.Getenv("VAULT_TOKEN"), client: &http.Client{Timeout: 10 * time.Second}}}
+}
+
+// open reads the secret ref points to, nil when ref is empty.
+func (s *secretStore) open(name, ref string) (*secret, error) {
+  if ref == "" {
+    return nil, nil
+  }
+
+  kind, location, _ := strings.Cut(ref, ":")
+  sec := &secret{ref: ref}
+  switch kind {
+  case "file":
+    sec.read = func() ([]byte, error) { return os.ReadFile(location) }
+  case "env":
+    sec.read = func() ([]byte, error) { return []byte(os.Getenv(location)), nil }
+  case "vault":
+    path, field, ok := strings.Cut(location, "#")
+    if !ok || path == "" || field == "" {
+      return nil, fmt.Errorf("-%s: expected vault:<path>#<field>", name)
+    }
+    sec.read = func() ([]byte, error) { return s.vault.read(path, field) }
+  default:
+    return nil, fmt.Errorf("-%s: expected file:<path>, vault:<path>#<field> or env:<name>, got %q", name, ref)
+  }
+
+  value, err := sec.read()
+  if err != nil {
+    return nil, fmt.Errorf("-%s: failed to read %s: %w", name, ref, err)
+  }
+  sec.value = bytes.TrimSpace(value)
+
+  s.mu.Lock()
+  s.secrets = append(s.secrets, sec)
+  s.mu.Unlock()
+
+  return sec, nil
+}
+
+// staticSecret is a secret given as is, like -admin-token, which never changes. Nil when value is empty.
+func staticSecret(value string) *secret {
+  if value == "" {
+    return nil
+  }
+
+  return &secret{ref: "static", value: []byte(value)}
+}
+
+// get returns the current value, nil for a nil secret.
+func (s *secret) get() []byte {
+  if s == nil {
+    return nil
+  }
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+
+  return s.value
+}
+
+func (s *secret) text() string {
+  return string(s.get())
+}
+
+// changed adds a function called with every new value.
+func (s *secret) changed(apply func([]byte) error) {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.onChange = append(s.onChange, apply)
+}
+
+func (s *secret) refresh() error {
+  value, err := s.read()
+  if err != nil {
+    return fmt.Errorf("failed to read it, keeping its value: %w", err)
+  }
+  value = bytes.TrimSpace(value)
+
+  s.mu.Lock()
+  if bytes.Equal(value, s.value) {
+    s.mu.Unlock()
+    return nil
+  }
+  s.value = value
+  onChange := s.onChange
+  s.mu.Unlock()
+  log.Printf("secret %s changed", s.ref)
+
+  for _, apply := range onChange {
+    if err := apply(value); err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
+
+// run reads the secrets again every interval, see ROTATION above.
+func (s *secretStore) run(interval time.Duration) func(ctx context.Context) error {
+  return func(ctx context.Context) error {
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+
+    for {
+      select {
+      case <-ctx.Done():
+        return nil
+      case <-ticker.C:
+        s.mu.Lock()
+        secrets := s.secrets
+        s.mu.Unlock()
+
+        for _, sec := range secrets {
+          if err := sec.refresh(); err != nil {
+            log.Printf("secret %s: %v", sec.ref, err)
+          }
+        }
+      }
+    }
+  }
+}
+
+func (s *secretStore) empty() bool {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  return len(s.secrets) == 0
+}
+
+type vaultClient struct {
+  addr   string
+  token  string
+  client *http.Client
+}
+
+// read returns a field of the secret at path, of a KV engine of version 1 or 2.
+func (v *vaultClient) read(path, field string) ([]byte, error) {
+  if v.addr == "" {
+    return nil, fmt.Errorf("VAULT_ADDR isn't set")
+  }
+
+  req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(v.addr, "/")+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+  if err != nil {
+    return nil, err
+  }
+  req.Header.Set("X-Vault-Token", v.token)
+  res, err := v.client.Do(req)
+  if err != nil {
+    return nil, err
+  }
+  defer res.Body.Close()
+
+  if res.StatusCode != http.StatusOK {
+    body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+    return nil, fmt.Errorf("vault answered %s: %s", res.Status, strings.TrimSpace(string(body)))
+  }
+
+  var secret struct {
+    Data map[string]any `json:"data"`
+  }
+  if err := json.NewDecoder(res.Body).Decode(&secret); err != nil {
+    return nil, fmt.Errorf("failed to decode the answer of vault: %w", err)
+  }
+  // Version 2 nests the fields under data, next to the metadata.
+  fields := secret.Data
+  if nested, ok := fields["data"].(map[string]any); ok {
+    if _, ok := fields["metadata"]; ok {
+      fields = nested
+    }
+  }
+  value, ok := fields[field].(string)
+  if !ok {
+    return nil, fmt.Errorf("the secret has no %q field", field)
+  }
+
+  return []byte(value), nil
+}
+
+// tlsCertificate serves the certificate of the secrets, the last pair that matched.
+type tlsCertificate struct {
+  certPEM, keyPEM *secret
+
+  mu   sync.RWMutex
+  pair *tls.Certificate
+}
+
+func newTLSCertificate(certPEM, keyPEM *secret) (*tlsCertificate, error) {
+  if (certPEM == nil) != (keyPEM == nil) {
+    return nil, fmt.Errorf("-tls-cert-from and -tls-key-from go together")
+  }
+  if certPEM == nil {
+    return nil, nil
+  }
+
+  t := &tlsCertificate{certPEM: certPEM, keyPEM: keyPEM}
+  if err := t.load(certPEM.get(), keyPEM.get()); err != nil {
+    return nil, err
+  }
+  // The cert and the key are refreshed one after the other, the first one changed fails to match until the other one changes too.
+  certPEM.changed(func(cert []byte) error { return t.reload(cert, keyPEM.get()) })
+  keyPEM.changed(func(key []byte) error { return t.reload(certPEM.get(), key) })
+
+  return t, nil
+}
+
+func (t *tlsCertificate) load(cert, key []byte) error {
+  pair, err := tls.X509KeyPair(cert, key)
+  if err != nil {
+    return fmt.Errorf("invalid TLS certificate or key: %w", err)
+  }
+
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  t.pair = &pair
+
+  return nil
+}
+
+func (t *tlsCertificate) reload(cert, key []byte) error {
+  if err := t.load(cert, key); err != nil {
+    return fmt.Errorf("%w, serving the previous pair", err)
+  }
+  log.Printf("serving the new TLS certificate")
+
+  return nil
+}
+
+// config returns nil for a nil certificate, the server then serves plain text.
+func (t *tlsCertificate) config() *tls.Config {
+  if t == nil {
+    return nil
+  }
+  return &tls.Config{
+    MinVersion: tls.VersionTLS12,
+    GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+      t.mu.RLock()
+      defer t.mu.RUnlock()
+
+      return t.pair, nil
+    },
+  }
+}
+
+// serverCredentials serves TLS with the config, plain text for a nil one.
+func serverCredentials(config *tls.Config) grpc.ServerOption {
+  if config == nil {
+    return grpc.Creds(insecure.NewCredentials())
+  }
+
+  return grpc.Creds(credentials.NewTLS(config))
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+  "context"
+  "encoding/json"
+  "errors"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+  "io/fs"
+  "os"
+  "slices"
+  "sync"
+  "time"
+)
+
+/*
+  SERIES
+
+  Some posts are meant to be read one after the other: the parts of a tutorial, the chapters of a story. A series is the ordered list of their IDs, and GetSeries returns its posts in that order, each with the ID of the post before and after it so a reader can page through:
+
+    go run ./client series create -token secret -title "gRPC from scratch" -posts <id>,<id>
+    go run ./client series add -token secret -id <series id> -post <post id> -position 2
+    go run ./client series reorder -token secret -id <series id> -posts <id>,<id>,<id>
+    go run ./client series get -id <series id>
+
+  A series holds posts whatever their status: the next part can be added while it is still scheduled or a draft. GetSeries only returns the published ones, and the positions and the previous and next posts are counted among them, so readers never get sent to a post they can't see. Deleted posts are left out the same way, the series keeps their ID until it is removed.
+
+  A post can be in several series, but only once in each. Series are kept in series.json, like the webhooks in webhooks.json. Anybody can read them, creating and changing them requires the admin token.
+*/
+const seriesPath = "series.json"
+
+type seriesStore struct {
+  path string
+
+  // mu guards the series file.
+  mu sync.Mutex
+}
+
+func newSeriesStore(path string) *seriesStore {
+  return &seriesStore{path: path}
+}
+
+func (s *server) CreateSeries(ctx context.Context, req *pb.CreateSeriesRequest) (*pb.Series, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+  now := serverClock.Now().UTC().Format(time.RFC3339)
+  series := &pb.Series{
+    Id:          store.NewID(),
+    Title:       req.GetTitle(),
+    Description: req.GetDescription(),
+    PostIds:     make([]string, 0, len(req.GetPostIds())),
+    CreatedAt:   now,
+    UpdatedAt:   now,
+  }
+  for _, id := range req.GetPostIds() {
+    if slices.Contains(series.PostIds, id) {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "post %q is in the series already", id)
+    }
+    series.PostIds = append(series.PostIds, id)
+  }
+  if err := checkPostsExist(series.PostIds); err != nil {
+    return nil, err
+  }
+
+  st := s.series
+  st.mu.Lock()
+  defer st.mu.Unlock()
+
+  all, err := st.load()
+  if err != nil {
+    return nil, err
+  }
+  if err := st.save(append(all, series)); err != nil {
+    return nil, err
+  }
+
+  return series, nil
+}
+
+func (s *server) ListSeries(context.Context, *pb.ListSeriesRequest) (*pb.SeriesList, error) {
+  st := s.series
+  st.mu.Lock()
+  defer st.mu.Unlock()
+
+  all, err := st.load()
+  if err != nil {
+    return nil, err
+  }
+
+  return &pb.SeriesList{Series: all}, nil
+}
+
+func (s *server) GetSeries(_ context.Context, req *pb.GetSeriesRequest) (*pb.SeriesPosts, error) {
+  st := s.series
+  st.mu.Lock()
+  all, err := st.load()
+  var series *pb.Series
+  if err == nil {
+    var i int
+    if i, err = findSeries(all, req.GetId()); err == nil {
+      series = all[i]
+    }
+  }
+  st.mu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  res := &pb.SeriesPosts{Series: series, Posts: make([]*pb.SeriesPost, 0, len(series.PostIds))}
+  // An empty query would read every post.
+  if len(series.PostIds) == 0 {
+    return res, nil
+  }
+
+  posts, err := readPosts(store.Query{IDs: series.PostIds})
+  if err != nil {
+    return nil, err
+  }
+  byID := make(map[string]*pb.Post, len(posts.Posts))
+  for _, post := range posts.Posts {
+    if post.Status == pb.PostStatus_PUBLISHED {
+      byID[post.Id] = post
+    }
+  }
+
+  for _, id := range series.PostIds {
+    post, ok := byID[id]
+    if !ok {
+      continue
+    }
+
+    entry := &pb.SeriesPost{Post: post, Position: int32(len(res.Posts) + 1)}
+    if len(res.Posts) > 0 {
+      previous := res.Posts[len(res.Posts)-1]
+      entry.PreviousId = previous.Post.Id
+      previous.NextId = post.Id
+    }
+    res.Posts = append(res.Posts, entry)
+  }
+
+  return res, nil
+}
+
+func (s *server) AddToSeries(ctx context.Context, req *pb.AddToSeriesRequest) (*pb.Series, error) {
+  return s.changeSeries(ctx, req.GetSeriesId(), func(series *pb.Series) error {
+    if slices.Contains(series.PostIds, req.GetPostId()) {
+      return apperr.Errorf(apperr.ErrInvalidArgument, "post %q is in the series already", req.GetPostId())
+    }
+    if err := checkPostsExist([]string{req.GetPostId()}); err != nil {
+      return err
+    }
+
+    position := int(req.GetPosition())
+    if position == 0 || position > len(series.PostIds) {
+      position = len(series.PostIds) + 1
+    }
+    series.PostIds = slices.Insert(series.PostIds, position-1, req.GetPostId())
+
+    return nil
+  })
+}
+
+func (s *server) RemoveFromSeries(ctx context.Context, req *pb.RemoveFromSeriesRequest) (*pb.Series, error) {
+  return s.changeSeries(ctx, req.GetSeriesId(), func(series *pb.Series) error {
+    i := slices.Index(series.PostIds, req.GetPostId())
+    if i == -1 {
+      return apperr.Errorf(apperr.ErrPostNotFound, "post %q isn't in the series", req.GetPostId())
+    }
+    series.PostIds = slices.Delete(series.PostIds, i, i+1)
+
+    return nil
+  })
+}
+
+func (s *server) ReorderSeries(ctx context.Context, req *pb.ReorderSeriesRequest) (*pb.Series, error) {
+  return s.changeSeries(ctx, req.GetSeriesId(), func(series *pb.Series) error {
+    current := slices.Sorted(slices.Values(series.PostIds))
+    reordered := slices.Sorted(slices.Values(req.GetPostIds()))
+    if !slices.Equal(current, reordered) {
+      return apperr.Errorf(apperr.ErrInvalidArgument, "PostIds must list every post of the series once, in their new order")
+    }
+    series.PostIds = req.GetPostIds()
+
+    return nil
+  })
+}
+
+func (s *server) DeleteSeries(ctx context.Context, req *pb.DeleteSeriesRequest) (*pb.DeleteSeriesResponse, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  st := s.series
+  st.mu.Lock()
+  defer st.mu.Unlock()
+
+  all, err := st.load()
+  if err != nil {
+    return nil, err
+  }
+  i, err := findSeries(all, req.GetId())
+  if err != nil {
+    return nil, err
+  }
+
+  if err := st.save(slices.Delete(all, i, i+1)); err != nil {
+    return nil, err
+  }
+
+  return &pb.DeleteSeriesResponse{}, nil
+}
+
+// changeSeries runs change on the series and saves it, for the RPCs changing the posts of a series.
+func (s *server) changeSeries(ctx context.Context, id string, change func(series *pb.Series) error) (*pb.Series, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  st := s.series
+  st.mu.Lock()
+  defer st.mu.Unlock()
+
+  all, err := st.load()
+  if err != nil {
+    return nil, err
+  }
+  i, err := findSeries(all, id)
+  if err != nil {
+    return nil, err
+  }
+
+  series := all[i]
+  if err := change(series); err != nil {
+    return nil, err
+  }
+  series.UpdatedAt = serverClock.Now().UTC().Format(time.RFC3339)
+
+  if err := st.save(all); err != nil {
+    return nil, err
+  }
+
+  return series, nil
+}
+
+// checkPostsExist returns the error of findPost for the first of the IDs that isn't a post.
+func checkPostsExist(ids []string) error {
+  if len(ids) == 0 {
+    return nil
+  }
+
+  posts, err := readPosts(store.Query{IDs: ids})
+  if err != nil {
+    return err
+  }
+  for _, id := range ids {
+    if _, err := findPost(posts, id); err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
+
+func findSeries(all []*pb.Series, id string) (int, error) {
+  i := slices.IndexFunc(all, func(series *pb.Series) bool { return series.Id == id })
+  if i == -1 {
+    return -1, apperr.Errorf(apperr.ErrSeriesNotFound, "series %q not found", id)
+  }
+
+  return i, nil
+}
+
+func (st *seriesStore) load() ([]*pb.Series, error) {
+  var all []*pb.Series
+
+  data, err := os.ReadFile(st.path)
+  // No file yet simply means no series has been created so far.
+  if errors.Is(err, fs.ErrNotExist) {
+    return all, nil
+  }
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read series file: %w", err)
+  }
+
+  if err := json.Unmarshal(data, &all); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to parse series: %w", err)
+  }
+
+  return all, nil
+}
+
+func (st *seriesStore) save(all []*pb.Series) error {
+  data, err := json.MarshalIndent(all, "", "  ")
+  if err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save series: %w", err)
+  }
+
+  if err := os.WriteFile(st.path, data, 0644); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save series: %w", err)
+  }
+
+  return nil
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+  "cmp"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "math/rand/v2"
+  "runtime"
+  "slices"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  LIVE STATS
+
+  /metrics is made for Prometheus, which scrapes it and keeps the history (see metrics.go). StreamServerStats is for the times there is no Prometheus around: an admin opens the stream and gets a report every few seconds, with the calls per second, their latency and the memory of the server over the last interval, which is what a dashboard draws:
+
+    go run ./client stats -token $BLOG_ADMIN_TOKEN -interval 2s
+
+  The interceptors of serverMetrics hand every call they measure to the window of each stream open, a stream has its own so two dashboards with different intervals don't take the calls from each other. Without a stream open nothing more is kept than for /metrics.
+
+  A window counts every call but keeps the durations of at most maxLatencySamples for the percentiles, picked at random among the calls of the interval (reservoir sampling), so a busy server doesn't grow the memory of a stream with its traffic. The percentiles of a busy interval are estimates, the counts are not.
+*/
+const (
+  defaultStatsInterval = 5 * time.Second
+  maxLatencySamples    = 10000
+)
+
+// statsWindow collects the calls of one interval of a StreamServerStats, guarded by the mu of serverMetrics.
+type statsWindow struct {
+  calls, errors int64
+  // unary counts the unary calls, among which latencies were sampled.
+  unary     int64
+  latencies []time.Duration
+  max       time.Duration
+  methods   map[string]*methodWindow
+}
+
+type methodWindow struct {
+  calls, errors int64
+  unary         int64
+  total         time.Duration
+}
+
+func newStatsWindow() *statsWindow {
+  return &statsWindow{methods: make(map[string]*methodWindow)}
+}
+
+// add records a call that ended, d is only kept for the unary calls, see ServerStats.
+func (w *statsWindow) add(method string, d time.Duration, err error, unary bool) {
+  mw, ok := w.methods[method]
+  if !ok {
+    mw = &methodWindow{}
+    w.methods[method] = mw
+  }
+
+  w.calls++
+  mw.calls++
+  if status.Code(err) != codes.OK {
+    w.errors++
+    mw.errors++
+  }
+  if !unary {
+    return
+  }
+
+  w.unary++
+  mw.unary++
+  mw.total += d
+  w.max = max(w.max, d)
+  if len(w.latencies) < maxLatencySamples {
+    w.latencies = append(w.latencies, d)
+  } else if i := rand.Int64N(w.unary); i < maxLatencySamples {
+    w.latencies[i] = d
+  }
+}
+
+// watch opens a window the calls are added to until the returned function closes it.
+func (m *serverMetrics) watch() (*statsWindow, func()) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  w := newStatsWindow()
+  m.windows[w] = struct{}{}
+
+  return w, func() {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    delete(m.windows, w)
+  }
+}
+
+// take returns what w collected and starts it over.
+func (m *serverMetrics) take(w *statsWindow) *statsWindow {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  taken := *w
+  *w = *newStatsWindow()
+
+  return &taken
+}
+
+// closeStreams ends the StreamServerStats calls, on shutdown.
+func (m *serverMetrics) closeStreams() {
+  m.closeOnce.Do(func() { close(m.closed) })
+}
+
+// report turns the window of an interval of elapsed into the message of the stream, with the memory of the process.
+func (m *serverMetrics) report(w *statsWindow, elapsed time.Duration, mem, previous *runtime.MemStats) *pb.ServerStats {
+  stats := &pb.ServerStats{
+    Time:            time.Now().Format(time.RFC3339),
+    IntervalSeconds: elapsed.Seconds(),
+    Calls:           w.calls,
+    Errors:          w.errors,
+    CallsPerSecond:  float64(w.calls) / elapsed.Seconds(),
+    InFlight:        m.inFlight.Load(),
+    LatencyMaxMs:    milliseconds(w.max),
+    HeapAllocBytes:  mem.HeapAlloc,
+    SysBytes:        mem.Sys,
+    Goroutines:      int32(runtime.NumGoroutine()),
+    GcRuns:          mem.NumGC - previous.NumGC,
+    GcPauseMs:       float64(mem.PauseTotalNs-previous.PauseTotalNs) / 1e6,
+  }
+
+  if len(w.latencies) > 0 {
+    slices.Sort(w.latencies)
+    percentile := func(p float64) float64 {
+      return milliseconds(w.latencies[int(p*float64(len(w.latencies)-1))])
+    }
+    stats.LatencyP50Ms, stats.LatencyP90Ms, stats.LatencyP99Ms = percentile(0.5), percentile(0.9), percentile(0.99)
+  }
+
+  for method, mw := range w.methods {
+    ms := &pb.MethodStats{Method: method, Calls: mw.calls, Errors: mw.errors}
+    if mw.unary > 0 {
+      ms.MeanLatencyMs = milliseconds(mw.total) / float64(mw.unary)
+    }
+    stats.Methods = append(stats.Methods, ms)
+  }
+  slices.SortFunc(stats.Methods, func(a, b *pb.MethodStats) int {
+    return cmp.Or(cmp.Compare(b.Calls, a.Calls), cmp.Compare(a.Method, b.Method))
+  })
+
+  return stats
+}
+
+func milliseconds(d time.Duration) float64 {
+  return float64(d) / float64(time.Millisecond)
+}
+
+func (a *adminServer) StreamServerStats(req *pb.StreamServerStatsRequest, stream grpc.ServerStreamingServer[pb.ServerStats]) error {
+  if err := a.auth.requireAdmin(stream.Context()); err != nil {
+    return err
+  }
+
+  interval := time.Duration(req.GetIntervalSeconds()) * time.Second
+  if interval == 0 {
+    interval = defaultStatsInterval
+  }
+
+  w, stop := a.metrics.watch()
+  defer stop()
+
+  ticker := time.NewTicker(interval)
+  defer ticker.Stop()
+
+  var previous runtime.MemStats
+  runtime.ReadMemStats(&previous)
+  start := time.Now()
+
+  for {
+    select {
+    case <-stream.Context().Done():
+      return nil
+    case <-a.metrics.closed:
+      return status.Errorf(codes.Unavailable, "server is shutting down")
+    case now := <-ticker.C:
+      var mem runtime.MemStats
+      runtime.ReadMemStats(&mem)
+      stats := a.metrics.report(a.metrics.take(w), now.Sub(start), &mem, &previous)
+      previous, start = mem, now
+
+      if err := stream.Send(stats); err != nil {
+        return err
+      }
+    }
+  }
+}
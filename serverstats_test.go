@@ -0,0 +1,87 @@
+package main
+
+import (
+  "errors"
+  "runtime"
+  "slices"
+  "testing"
+  "time"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+func TestServerStatsReport(t *testing.T) {
+  m := newServerMetrics()
+  w, stop := m.watch()
+  defer stop()
+
+  start := time.Now()
+  for i := 1; i <= 100; i++ {
+    // Calls of 1 to 100ms, as if they had started that long ago.
+    m.end("/grpc_tutorial.Blog/GetPosts", start.Add(-time.Duration(i)*time.Millisecond), nil, true)
+  }
+  m.end("/grpc_tutorial.Blog/CreatePost", start, status.Error(codes.InvalidArgument, "no title"), true)
+  // A stream of an hour counts as a call, not in the latency.
+  m.end("/grpc_tutorial.Blog/WatchPosts", start.Add(-time.Hour), errors.New("gone"), false)
+
+  var mem runtime.MemStats
+  runtime.ReadMemStats(&mem)
+  stats := m.report(m.take(w), 2*time.Second, &mem, &mem)
+
+  if stats.Calls != 102 || stats.Errors != 2 || stats.CallsPerSecond != 51 {
+    t.Errorf("got %d calls, %d errors and %g per second, want 102, 2 and 51", stats.Calls, stats.Errors, stats.CallsPerSecond)
+  }
+  // The durations measured include the time since start, a few microseconds.
+  for _, latency := range []struct {
+    name      string
+    got, want float64
+  }{
+    {"p50", stats.LatencyP50Ms, 50},
+    {"p90", stats.LatencyP90Ms, 90},
+    {"p99", stats.LatencyP99Ms, 99},
+    {"max", stats.LatencyMaxMs, 100},
+  } {
+    if latency.got < latency.want || latency.got > latency.want+5 {
+      t.Errorf("%s is %gms, want %gms", latency.name, latency.got, latency.want)
+    }
+  }
+
+  var methods []string
+  for _, method := range stats.Methods {
+    methods = append(methods, method.Method)
+  }
+  // The most called first, then by name.
+  if want := []string{"GetPosts", "CreatePost", "WatchPosts"}; !slices.Equal(methods, want) {
+    t.Fatalf("got the methods %v, want %v", methods, want)
+  }
+  if stats.Methods[2].MeanLatencyMs != 0 {
+    t.Errorf("WatchPosts has a latency of %gms, want none", stats.Methods[2].MeanLatencyMs)
+  }
+
+  // The next interval starts empty.
+  if next := m.report(m.take(w), time.Second, &mem, &mem); next.Calls != 0 || len(next.Methods) != 0 {
+    t.Errorf("the next report has %d calls, want none", next.Calls)
+  }
+}
+
+func TestServerStatsSampleBusyIntervals(t *testing.T) {
+  w := newStatsWindow()
+  for i := range 3 * maxLatencySamples {
+    w.add("GetPosts", time.Duration(i), nil, true)
+  }
+
+  if len(w.latencies) != maxLatencySamples || w.unary != 3*maxLatencySamples {
+    t.Fatalf("kept %d latencies of %d calls, want %d of %d", len(w.latencies), w.unary, maxLatencySamples, 3*maxLatencySamples)
+  }
+  // Sampled from the whole interval, not only its beginning.
+  late := 0
+  for _, d := range w.latencies {
+    if d >= maxLatencySamples {
+      late++
+    }
+  }
+  if late < maxLatencySamples/2 {
+    t.Errorf("only %d of the samples come after the first %d calls", late, maxLatencySamples)
+  }
+}
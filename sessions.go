@@ -0,0 +1,423 @@
+package main
+
+import (
+  "context"
+  "crypto/hmac"
+  "crypto/rand"
+  "crypto/sha256"
+  "encoding/hex"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/store"
+  "io/fs"
+  "os"
+  "slices"
+  "strconv"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  SESSIONS
+
+  Readers without an account are anonymous, and anonymous callers are only told apart by their IP address. That lumps together everybody behind the same office or mobile network: their views count once between them, they share one rate limit on the mirror, and there is nowhere to keep what each of them read.
+
+  StartSession gives a reader a viewer of its own. It answers with a token that the client keeps and sends in the x-session metadata of its next calls:
+
+    go run ./client session
+    go run ./client view -id <post id>
+    go run ./client history
+
+  With a valid token the identity of the caller is session:<viewer ID> (see auth.go), which is what:
+    - RecordView counts the view for, so views are deduplicated by reader rather than by address (see views.go)
+    - the mirror keeps a rate limit for, instead of the one of the address (see mirror.go). StartSession itself is limited by address, so starting sessions doesn't buy more calls than the address had.
+    - GetReadingHistory returns the views of, the last maxHistory posts the reader viewed
+
+  A token is <viewer ID>.<expiry>.<signature>, where the signature is an HMAC-SHA256 of the rest with a key only the server knows, kept in session.key. The server checks a token without looking anything up, and nobody else can make one up or change the viewer in it. Servers sharing session.key accept each other's tokens. With -session-key-from the key comes from elsewhere, Vault for instance, and can be rotated while the server runs, the tokens signed with the previous key stay valid (see secrets.go). Tokens last -session-ttl, StartSession called with a valid token returns a new one for the same viewer, so a reader coming back regularly keeps its history.
+
+  Viewing a post, even for a few seconds, puts it in the history. Readers done with a post tell it with MarkAsRead, and GetPosts with UnreadOnly lists the posts left to read. The posts of the history not marked as read are the ones the reader opened and may want to come back to, GetReadingHistory with InProgress only returns those:
+
+    go run ./client read -id <post id>
+    go run ./client list -unread
+    go run ./client history -in-progress
+
+  The histories are kept in memory and written to history.json every few seconds, like the views (see views.go). The history of a viewer not seen for -session-ttl is dropped, its token expired and nobody can ask for it anymore. Callers known otherwise, the admin or the users of an OIDC provider (see oidc.go), get a history too.
+*/
+const (
+  sessionHeader   = "x-session"
+  sessionIdentity = "session"
+  sessionKeyPath  = "session.key"
+  historyPath     = "history.json"
+  maxHistory      = 100
+)
+
+type sessions struct {
+  ttl         time.Duration
+  historyPath string
+
+  mu sync.Mutex
+  // history[identity] holds the posts the caller viewed, the most recent last.
+  history map[string][]historyEntry
+  dirty   bool
+
+  keyMu sync.RWMutex
+  key   []byte
+  // previousKey is the key before the last rotation, the tokens it signed are still valid.
+  previousKey []byte
+}
+
+type historyEntry struct {
+  PostID   string `json:"post_id"`
+  ViewedAt int64  `json:"viewed_at"`
+  Read     bool   `json:"read,omitempty"`
+}
+
+func newSessions(key []byte, historyPath string, ttl time.Duration) (*sessions, error) {
+  if ttl < time.Minute {
+    return nil, errors.New("-session-ttl must be at least a minute")
+  }
+
+  s := &sessions{key: key, ttl: ttl, historyPath: historyPath, history: make(map[string][]historyEntry)}
+  if err := readJSON(historyPath, &s.history); err != nil {
+    return nil, err
+  }
+
+  return s, nil
+}
+
+// loadSessionKey reads the key, making one the first time the server starts.
+func loadSessionKey(path string) ([]byte, error) {
+  data, err := os.ReadFile(path)
+  if errors.Is(err, fs.ErrNotExist) {
+    key := make([]byte, 32)
+    if _, err := rand.Read(key); err != nil {
+      return nil, err
+    }
+    if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0o600); err != nil {
+      return nil, fmt.Errorf("failed to save the session key: %w", err)
+    }
+    return key, nil
+  }
+  if err != nil {
+    return nil, fmt.Errorf("failed to read the session key: %w", err)
+  }
+
+  return parseSessionKey(data, path)
+}
+
+func parseSessionKey(data []byte, source string) ([]byte, error) {
+  key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+  if err != nil || len(key) < 16 {
+    return nil, fmt.Errorf("%s must hold at least 16 hex encoded bytes", source)
+  }
+
+  return key, nil
+}
+
+// rotateKey signs the new tokens with key, the tokens of the current key stay valid until the next rotation.
+func (s *sessions) rotateKey(key []byte) {
+  s.keyMu.Lock()
+  defer s.keyMu.Unlock()
+
+  s.key, s.previousKey = key, s.key
+}
+
+func (s *sessions) sign(payload string) string {
+  s.keyMu.RLock()
+  defer s.keyMu.RUnlock()
+
+  return signWith(s.key, payload)
+}
+
+// signedBy tells whether the signature is the one of payload, with the current key or the previous one. Compared in constant time, like the admin token, so a signature can't be guessed one byte at a time.
+func (s *sessions) signedBy(payload, signature string) bool {
+  s.keyMu.RLock()
+  defer s.keyMu.RUnlock()
+
+  if hmac.Equal([]byte(signature), []byte(signWith(s.key, payload))) {
+    return true
+  }
+
+  return s.previousKey != nil && hmac.Equal([]byte(signature), []byte(signWith(s.previousKey, payload)))
+}
+
+func signWith(key []byte, payload string) string {
+  mac := hmac.New(sha256.New, key)
+  mac.Write([]byte(payload))
+
+  return hex.EncodeToString(mac.Sum(nil))
+}
+
+// issue returns a session for the viewer, a new viewer when it is empty.
+func (s *sessions) issue(viewer string) *pb.Session {
+  if viewer == "" {
+    viewer = store.NewID()
+  }
+  expiresAt := serverClock.Now().Add(s.ttl).UTC().Truncate(time.Second)
+  payload := viewer + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+
+  return &pb.Session{Token: payload + "." + s.sign(payload), ViewerId: viewer, ExpiresAt: expiresAt.Format(time.RFC3339)}
+}
+
+// viewer returns the viewer of a token, false when the token isn't one of ours or expired.
+func (s *sessions) viewer(token string) (string, bool) {
+  i := strings.LastIndex(token, ".")
+  if i < 0 {
+    return "", false
+  }
+  payload, signature := token[:i], token[i+1:]
+  if !s.signedBy(payload, signature) {
+    return "", false
+  }
+
+  viewer, expiry, ok := strings.Cut(payload, ".")
+  unix, err := strconv.ParseInt(expiry, 10, 64)
+  if !ok || err != nil || serverClock.Now().After(time.Unix(unix, 0)) {
+    return "", false
+  }
+
+  return viewer, true
+}
+
+// identity returns the identity of the session token in the metadata, false without a valid one.
+func (s *sessions) identity(md metadata.MD) (string, bool) {
+  for _, token := range md.Get(sessionHeader) {
+    if viewer, ok := s.viewer(token); ok {
+      return sessionIdentity + ":" + viewer, true
+    }
+  }
+
+  return "", false
+}
+
+// viewed adds the post to the history of the caller, moving it to the end if it was there already.
+func (s *sessions) viewed(identity, postID string, at time.Time) {
+  if identity == anonymousIdentity || identity == "" {
+    return
+  }
+
+  s.update(identity, postID, at, func(*historyEntry) {})
+}
+
+// mark tells whether the caller read the post, adding it to the history when it wasn't there.
+func (s *sessions) mark(identity, postID string, read bool, at time.Time) historyEntry {
+  return s.update(identity, postID, at, func(entry *historyEntry) { entry.Read = read })
+}
+
+// update moves the entry of the post to the end of the history and changes it, a post viewed again stays read.
+func (s *sessions) update(identity, postID string, at time.Time, change func(*historyEntry)) historyEntry {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  entry := historyEntry{PostID: postID}
+  entries := slices.DeleteFunc(s.history[identity], func(e historyEntry) bool {
+    if e.PostID != postID {
+      return false
+    }
+    entry = e
+    return true
+  })
+  entry.ViewedAt = at.Unix()
+  change(&entry)
+
+  entries = append(entries, entry)
+  if len(entries) > maxHistory {
+    entries = entries[len(entries)-maxHistory:]
+  }
+  s.history[identity] = entries
+  s.dirty = true
+
+  return entry
+}
+
+// readPosts returns the posts the caller marked as read when GetPosts only wants the unread ones, nil otherwise.
+func (s *sessions) readPosts(ctx context.Context, unreadOnly bool) (map[string]bool, error) {
+  if !unreadOnly {
+    return nil, nil
+  }
+  identity, err := requireIdentity(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  read := make(map[string]bool)
+  for _, entry := range s.history[identity] {
+    if entry.Read {
+      read[entry.PostID] = true
+    }
+  }
+
+  return read, nil
+}
+
+// dropRead leaves out the posts of readPosts.
+func dropRead(posts *pb.Posts, read map[string]bool) *pb.Posts {
+  if len(read) == 0 {
+    return posts
+  }
+
+  unread := &pb.Posts{Posts: make([]*pb.Post, 0, len(posts.Posts))}
+  for _, post := range posts.Posts {
+    if !read[post.Id] {
+      unread.Posts = append(unread.Posts, post)
+    }
+  }
+
+  return unread
+}
+
+// requireIdentity returns who the history or the notifications of the call are kept for, anonymous callers have none.
+func requireIdentity(ctx context.Context) (string, error) {
+  identity := reqctx.Identity(ctx)
+  if identity == anonymousIdentity || identity == "" {
+    return "", status.Errorf(codes.Unauthenticated, "this RPC needs a session or another identity, see StartSession")
+  }
+
+  return identity, nil
+}
+
+// run is the background job writing the histories, see jobs.go
+func (s *sessions) run(ctx context.Context) error {
+  ticker := time.NewTicker(viewsFlushEvery)
+  defer ticker.Stop()
+
+  for {
+    select {
+    case <-ctx.Done():
+      return s.flush()
+    case <-ticker.C:
+      if err := s.flush(); err != nil {
+        return err
+      }
+    }
+  }
+}
+
+func (s *sessions) flush() error {
+  s.mu.Lock()
+  // The last entry is the last view of the caller.
+  expired := serverClock.Now().Add(-s.ttl).Unix()
+  for identity, entries := range s.history {
+    if len(entries) == 0 || entries[len(entries)-1].ViewedAt < expired {
+      delete(s.history, identity)
+      s.dirty = true
+    }
+  }
+  if !s.dirty {
+    s.mu.Unlock()
+    return nil
+  }
+  err := replaceFile(s.historyPath, s.history)
+  s.dirty = err != nil
+  s.mu.Unlock()
+
+  if err != nil {
+    return fmt.Errorf("failed to save the reading history: %w", err)
+  }
+
+  return nil
+}
+
+func (s *server) StartSession(ctx context.Context, _ *pb.StartSessionRequest) (*pb.Session, error) {
+  return s.sessions.start(ctx)
+}
+
+// start renews the session of the caller, or starts a new one. The mirror hands out sessions too.
+func (s *sessions) start(ctx context.Context) (*pb.Session, error) {
+  viewer, renew := strings.CutPrefix(reqctx.Identity(ctx), sessionIdentity+":")
+  if !renew {
+    viewer = ""
+  }
+
+  return s.issue(viewer), nil
+}
+
+func (s *server) GetReadingHistory(ctx context.Context, req *pb.GetReadingHistoryRequest) (*pb.ReadingHistory, error) {
+  identity, err := requireIdentity(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  s.sessions.mu.Lock()
+  entries := slices.Clone(s.sessions.history[identity])
+  s.sessions.mu.Unlock()
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err = loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  byID := make(map[string]*pb.Post, len(posts.Posts))
+  for _, post := range publishedPosts(posts).Posts {
+    byID[post.Id] = post
+  }
+
+  // The posts that aren't published anymore are left out, readers can't open them.
+  history := &pb.ReadingHistory{}
+  for _, entry := range slices.Backward(entries) {
+    post, ok := byID[entry.PostID]
+    if !ok || (req.GetInProgress() && entry.Read) {
+      continue
+    }
+    history.Entries = append(history.Entries, entry.proto(post))
+    if req.GetLimit() > 0 && len(history.Entries) == int(req.GetLimit()) {
+      break
+    }
+  }
+
+  return history, nil
+}
+
+func (s *server) MarkAsRead(ctx context.Context, req *pb.MarkAsReadRequest) (*pb.HistoryEntry, error) {
+  identity, err := requireIdentity(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err = loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  post, err := findPost(posts, req.GetPostId())
+  if err != nil {
+    return nil, err
+  }
+  // Like RecordView, readers can't have read a post they can't open.
+  if post.Status != pb.PostStatus_PUBLISHED {
+    return nil, apperr.Errorf(apperr.ErrPostNotFound, "post %q not found", req.GetPostId())
+  }
+
+  entry := s.sessions.mark(identity, post.Id, !req.GetUnread(), serverClock.Now())
+
+  return entry.proto(post), nil
+}
+
+func (e historyEntry) proto(post *pb.Post) *pb.HistoryEntry {
+  return &pb.HistoryEntry{Post: post, ViewedAt: time.Unix(e.ViewedAt, 0).UTC().Format(time.RFC3339), Read: e.Read}
+}
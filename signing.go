@@ -0,0 +1,213 @@
+package main
+
+import (
+  "bufio"
+  "container/heap"
+  "context"
+  "crypto/hmac"
+  "encoding/hex"
+  "fmt"
+  "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen/blogsdk"
+  "go/tutorial/grpc/internal/reqctx"
+  "os"
+  "strconv"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  SIGNED REQUESTS
+
+  Machine clients, a CI job or an import script, can sign their calls instead of sending a token: the secret of a signing key never travels, each call carries an HMAC of its method, its request and its time (see signing.go in blogsdk, which defines the format for both ends). It is the lighter alternative to TLS client certificates, no certificate authority to run, and works over plain text connections too, although nothing hides the calls themselves then.
+
+  The keys are in the file of -signing-keys, one per line: an ID, the secret in hex and optionally "admin" for the keys allowed to call the admin RPCs. Lines starting with # are comments:
+
+    # openssl rand -hex 32
+    release-bot 6f1c0e...  admin
+    importer    93ab47...
+
+    go run . -signing-keys signing.keys
+
+  The identity of a signed call is hmac:<key ID>, admin:hmac:<key ID> for the admin keys. A call with a wrong signature, an unknown key or a timestamp more than signatureMaxSkew away from the server's clock is refused with Unauthenticated, it doesn't fall back to anonymous. Within that window a signature is accepted once: the nonces seen are remembered until their timestamp is too old anyway, so a recorded call can't be played again.
+
+  The signature is checked in an interceptor ahead of the one finding the identity (see reqctx), which then finds the key in the context. Streams are signed without their messages, see blogsdk.
+*/
+const signatureMaxSkew = 5 * time.Minute
+
+type signingKey struct {
+  secret []byte
+  admin  bool
+}
+
+type requestVerifier struct {
+  keys map[string]signingKey
+
+  mu sync.Mutex
+  // seen[<key ID> <nonce>] is when the nonce can be forgotten.
+  seen map[string]time.Time
+  // The same nonces, the first one to expire on top.
+  expiries nonceExpiries
+}
+
+// nonceExpiries is a heap (see container/heap) of the nonces seen by when they expire. They aren't seen in that order, a call signed a minute ago can arrive after one signed now.
+type nonceExpiries []seenNonce
+
+type seenNonce struct {
+  nonce string
+  until time.Time
+}
+
+func (h nonceExpiries) Len() int           { return len(h) }
+func (h nonceExpiries) Less(i, j int) bool { return h[i].until.Before(h[j].until) }
+func (h nonceExpiries) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nonceExpiries) Push(x any)        { *h = append(*h, x.(seenNonce)) }
+
+func (h *nonceExpiries) Pop() any {
+  old := *h
+  last := old[len(old)-1]
+  *h = old[:len(old)-1]
+  return last
+}
+
+// signedKey is the context key under which the interceptor leaves the identity of a signed call.
+type signedKey struct{}
+
+func loadSigningKeys(path string) (*requestVerifier, error) {
+  v := &requestVerifier{keys: make(map[string]signingKey), seen: make(map[string]time.Time)}
+  if path == "" {
+    return v, nil
+  }
+
+  f, err := os.Open(path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read the signing keys: %w", err)
+  }
+  defer f.Close()
+
+  scanner := bufio.NewScanner(f)
+  for n := 1; scanner.Scan(); n++ {
+    line := strings.TrimSpace(scanner.Text())
+    if line == "" || strings.HasPrefix(line, "#") {
+      continue
+    }
+    fields := strings.Fields(line)
+    if len(fields) < 2 || len(fields) > 3 || (len(fields) == 3 && fields[2] != "admin") {
+      return nil, fmt.Errorf("%s:%d: expected <key ID> <hex secret> [admin]", path, n)
+    }
+    secret, err := hex.DecodeString(fields[1])
+    if err != nil || len(secret) < 16 {
+      return nil, fmt.Errorf("%s:%d: the secret must be at least 16 hex encoded bytes", path, n)
+    }
+    if _, ok := v.keys[fields[0]]; ok {
+      return nil, fmt.Errorf("%s:%d: key %q is listed twice", path, n, fields[0])
+    }
+    v.keys[fields[0]] = signingKey{secret: secret, admin: len(fields) == 3}
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, fmt.Errorf("failed to read the signing keys: %w", err)
+  }
+
+  return v, nil
+}
+
+// signedIdentity returns the identity of a call the interceptor checked the signature of.
+func signedIdentity(ctx context.Context) (string, bool) {
+  identity, ok := ctx.Value(signedKey{}).(string)
+  return identity, ok
+}
+
+// verify checks the signature of a call, when it has one, and returns the context with its identity.
+func (v *requestVerifier) verify(ctx context.Context, method string, req any) (context.Context, error) {
+  md, _ := metadata.FromIncomingContext(ctx)
+  signature := firstValue(md, blogsdk.SignatureHeader)
+  if signature == "" {
+    return ctx, nil
+  }
+
+  id := firstValue(md, blogsdk.SignatureKeyHeader)
+  key, ok := v.keys[id]
+  if !ok {
+    return nil, status.Errorf(codes.Unauthenticated, "unknown signing key %q", id)
+  }
+  timestamp, err := strconv.ParseInt(firstValue(md, blogsdk.SignatureTimestampHeader), 10, 64)
+  if err != nil {
+    return nil, status.Errorf(codes.Unauthenticated, "%s must be a Unix time", blogsdk.SignatureTimestampHeader)
+  }
+  signedAt := time.Unix(timestamp, 0)
+  if skew := serverClock.Now().Sub(signedAt); skew > signatureMaxSkew || skew < -signatureMaxSkew {
+    return nil, status.Errorf(codes.Unauthenticated, "the signature is too old or too far ahead of the server's clock")
+  }
+  nonce := firstValue(md, blogsdk.SignatureNonceHeader)
+  if nonce == "" || len(nonce) > 64 {
+    return nil, status.Errorf(codes.Unauthenticated, "%s must be 1 to 64 characters", blogsdk.SignatureNonceHeader)
+  }
+
+  body, err := blogsdk.SignedBytes(req)
+  if err != nil {
+    return nil, status.Errorf(codes.Internal, "failed to encode the request: %v", err)
+  }
+  // In constant time, like the admin token.
+  if !hmac.Equal([]byte(signature), []byte(blogsdk.Sign(key.secret, method, body, timestamp, nonce))) {
+    return nil, status.Errorf(codes.Unauthenticated, "the signature doesn't match the call")
+  }
+  if !v.remember(id+" "+nonce, signedAt.Add(signatureMaxSkew)) {
+    return nil, status.Errorf(codes.Unauthenticated, "the signature was used already")
+  }
+
+  identity := "hmac:" + id
+  if key.admin {
+    identity = adminIdentity + ":" + identity
+  }
+
+  return context.WithValue(ctx, signedKey{}, identity), nil
+}
+
+// remember keeps the nonce until it expires, false when it was seen already. Only the expired nonces are looked at to forget them, not every one seen.
+func (v *requestVerifier) remember(nonce string, expiry time.Time) bool {
+  v.mu.Lock()
+  defer v.mu.Unlock()
+
+  now := serverClock.Now()
+  for len(v.expiries) > 0 && now.After(v.expiries[0].until) {
+    delete(v.seen, heap.Pop(&v.expiries).(seenNonce).nonce)
+  }
+  if _, ok := v.seen[nonce]; ok {
+    return false
+  }
+  v.seen[nonce] = expiry
+  heap.Push(&v.expiries, seenNonce{nonce: nonce, until: expiry})
+
+  return true
+}
+
+func (v *requestVerifier) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  ctx, err := v.verify(ctx, info.FullMethod, req)
+  if err != nil {
+    return nil, err
+  }
+
+  return handler(ctx, req)
+}
+
+func (v *requestVerifier) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  ctx, err := v.verify(ss.Context(), info.FullMethod, nil)
+  if err != nil {
+    return err
+  }
+
+  return handler(srv, reqctx.WrapStream(ss, ctx))
+}
+
+func firstValue(md metadata.MD, key string) string {
+  if values := md.Get(key); len(values) > 0 {
+    return values[0]
+  }
+
+  return ""
+}
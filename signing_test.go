@@ -0,0 +1,33 @@
+package main
+
+import (
+  "testing"
+  "time"
+)
+
+func TestRememberForgetsExpiredNonces(t *testing.T) {
+  fake := useFakeClock(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+  v, err := loadSigningKeys("")
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  // The second call was signed earlier, so its nonce expires first.
+  if !v.remember("k1 a", fake.Now().Add(signatureMaxSkew)) || !v.remember("k1 b", fake.Now().Add(time.Minute)) {
+    t.Fatal("refused a nonce never seen")
+  }
+  if v.remember("k1 a", fake.Now().Add(signatureMaxSkew)) {
+    t.Error("accepted the same nonce twice")
+  }
+
+  fake.Advance(2 * time.Minute)
+  if !v.remember("k1 c", fake.Now().Add(signatureMaxSkew)) {
+    t.Fatal("refused a nonce never seen")
+  }
+  if _, ok := v.seen["k1 b"]; ok || len(v.seen) != len(v.expiries) || len(v.seen) != 2 {
+    t.Errorf("got %d nonces remembered, want the 2 that haven't expired", len(v.seen))
+  }
+  if v.remember("k1 a", fake.Now().Add(signatureMaxSkew)) {
+    t.Error("forgot a nonce before it expired")
+  }
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+  "context"
+  "encoding/xml"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "net/http"
+  "net/url"
+  "sort"
+  "strings"
+  "sync"
+  "time"
+)
+
+/*
+  SITEMAP
+
+  A sitemap tells search engines which pages a site has and when they last changed, so they crawl the new and edited posts first instead of the whole blog. It is an XML file listing one URL per published post, with its pretty URL (see internal/store/slug.go) and the time of its last change:
+
+    <urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+      <url>
+        <loc>https://blog.example.com/posts/getting-started-with-grpc</loc>
+        <lastmod>2025-06-04T09:00:00Z</lastmod>
+      </url>
+    </urlset>
+
+  The server only knows its gRPC address, the URLs need the address readers see, which is given with -site-url. Without it the sitemap is turned off. It is served by GetSitemap, and at /sitemap.xml when the server also speaks HTTP (see http.go):
+
+    go run . -site-url https://blog.example.com -http
+    curl localhost:3000/sitemap.xml
+    go run ./client sitemap
+
+  The lastmod is the UpdatedAt of the post, or the day it was created for the posts written before UpdatedAt existed.
+
+  The entries are kept up to date by runIndex (see watch.go): a published or updated post changes its own entry, a deleted or archived one drops it. The XML is only written again when a sitemap is asked for after a change.
+*/
+const (
+  sitemapRebuildEvery = 10 * time.Minute
+  sitemapNamespace    = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+type sitemap struct {
+  broker *postBroker
+  // Address of the site, without a trailing slash.
+  siteURL string
+
+  mu      sync.Mutex
+  entries map[string]sitemapURL
+  // The XML of the entries, nil when they changed since it was written.
+  rendered []byte
+}
+
+type sitemapURL struct {
+  Loc     string `xml:"loc"`
+  LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapURLSet struct {
+  XMLName xml.Name     `xml:"urlset"`
+  Xmlns   string       `xml:"xmlns,attr"`
+  URLs    []sitemapURL `xml:"url"`
+}
+
+// newSitemap returns nil without a site URL, which turns the sitemap off.
+func newSitemap(siteURL string, broker *postBroker) (*sitemap, error) {
+  if siteURL == "" {
+    return nil, nil
+  }
+
+  u, err := url.Parse(siteURL)
+  if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+    return nil, fmt.Errorf("invalid -site-url %q, expected an http or https URL like https://blog.example.com", siteURL)
+  }
+
+  return &sitemap{broker: broker, siteURL: strings.TrimSuffix(siteURL, "/"), entries: make(map[string]sitemapURL)}, nil
+}
+
+// put adds or replaces the entry of the post, posts that aren't published are only removed.
+func (m *sitemap) put(post *pb.Post) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  m.rendered = nil
+  if post.Status != pb.PostStatus_PUBLISHED || post.Slug == "" {
+    delete(m.entries, post.Id)
+    return
+  }
+
+  lastMod := post.UpdatedAt
+  if lastMod == "" {
+    lastMod = post.CreatedAt
+  }
+  m.entries[post.Id] = sitemapURL{Loc: m.siteURL + "/posts/" + url.PathEscape(post.Slug), LastMod: lastMod}
+}
+
+func (m *sitemap) drop(id string) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  m.rendered = nil
+  delete(m.entries, id)
+}
+
+// load rebuilds the entries from the storage.
+func (m *sitemap) load() error {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return err
+  }
+
+  m.mu.Lock()
+  m.entries, m.rendered = make(map[string]sitemapURL), nil
+  m.mu.Unlock()
+
+  for _, post := range posts.Posts {
+    m.put(post)
+  }
+
+  return nil
+}
+
+func (m *sitemap) run(ctx context.Context) error {
+  return runIndex(ctx, m.broker, m, sitemapRebuildEvery)
+}
+
+// xml returns the sitemap and the number of URLs in it, writing the XML again if the entries changed.
+func (m *sitemap) xml() ([]byte, int, error) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  if m.rendered != nil {
+    return m.rendered, len(m.entries), nil
+  }
+
+  set := sitemapURLSet{Xmlns: sitemapNamespace, URLs: make([]sitemapURL, 0, len(m.entries))}
+  for _, entry := range m.entries {
+    set.URLs = append(set.URLs, entry)
+  }
+  // Sorted so the same posts always give the same file.
+  sort.Slice(set.URLs, func(i, j int) bool { return set.URLs[i].Loc < set.URLs[j].Loc })
+
+  body, err := xml.MarshalIndent(set, "", "  ")
+  if err != nil {
+    return nil, 0, err
+  }
+  m.rendered = append([]byte(xml.Header), append(body, '\n')...)
+
+  return m.rendered, len(m.entries), nil
+}
+
+func (s *server) GetSitemap(context.Context, *pb.GetSitemapRequest) (*pb.Sitemap, error) {
+  if s.sitemap == nil {
+    return nil, apperr.Errorf(apperr.ErrFeatureDisabled, "the sitemap is turned off, start the server with -site-url")
+  }
+
+  data, urls, err := s.sitemap.xml()
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrInternal, "failed to write the sitemap: %w", err)
+  }
+
+  return &pb.Sitemap{Xml: string(data), UrlCount: int32(urls)}, nil
+}
+
+// ServeHTTP serves the sitemap at /sitemap.xml, see http.go
+func (m *sitemap) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+  data, _, err := m.xml()
+  if err != nil {
+    http.Error(w, "failed to write the sitemap", http.StatusInternalServerError)
+    return
+  }
+
+  w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+  w.Write(data)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  "go/tutorial/grpc/internal/reqctx"
+  "io"
+  "log"
+  "os"
+  "path/filepath"
+  "runtime"
+  "sort"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  SLOW CALLS
+
+  A call that takes ten seconds instead of ten milliseconds is usually stuck on something: the storage lock held by a big save, a database that doesn't answer, a webhook called inline. The watchdog logs every unary call still running after -slow-call-threshold, with who made it, and once more when it is done:
+
+    slow call /grpc_tutorial.Blog/CreatePost (request 1f3c..., session:abcd, 127.0.0.1:53210) still running after 2s
+    slow call /grpc_tutorial.Blog/CreatePost (request 1f3c...) done after 7.2s: OK
+
+  The first line comes while the call is stuck, not after, which is when it is worth looking. /metrics counts the slow calls by method in blog_slow_calls_total, for an alert.
+
+  What the call is stuck on is in the goroutine stacks. With -slow-call-stacks the watchdog writes the stack of every goroutine of the server to a file of that directory when a call turns slow, goroutine-20261014T080102.txt, the one of the call among them, waiting on a mutex or a read. Stacks are big and slow calls come in bunches, so there is one file a minute at most.
+
+  Streams aren't watched, WatchPosts or a StreamPosts of a big blog run as long as they need to. The threshold changes on reload (see config.go), 0 turns the watchdog off.
+*/
+const (
+  defaultSlowCallThreshold = 2 * time.Second
+  stackDumpInterval        = time.Minute
+)
+
+type slowCallWatchdog struct {
+  // stacksDir is where the goroutine stacks are written, empty to not write them.
+  stacksDir string
+
+  mu        sync.Mutex
+  threshold time.Duration
+  slow      map[string]int64
+  lastDump  time.Time
+}
+
+func newSlowCallWatchdog(threshold time.Duration, stacksDir string) *slowCallWatchdog {
+  return &slowCallWatchdog{threshold: threshold, stacksDir: stacksDir, slow: map[string]int64{}}
+}
+
+func (w *slowCallWatchdog) setThreshold(threshold time.Duration) {
+  w.mu.Lock()
+  defer w.mu.Unlock()
+
+  w.threshold = threshold
+}
+
+func (w *slowCallWatchdog) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  w.mu.Lock()
+  threshold := w.threshold
+  w.mu.Unlock()
+  if threshold <= 0 {
+    return handler(ctx, req)
+  }
+
+  start := time.Now()
+  timer := time.AfterFunc(threshold, func() { w.stillRunning(ctx, info.FullMethod, threshold) })
+  resp, err := handler(ctx, req)
+  if !timer.Stop() {
+    log.Printf("slow call %s (request %s) done after %s: %s", info.FullMethod, reqctx.RequestID(ctx), time.Since(start).Round(time.Millisecond), status.Code(err))
+  }
+
+  return resp, err
+}
+
+// stillRunning logs a call running for longer than the threshold, counts it and writes the stacks.
+func (w *slowCallWatchdog) stillRunning(ctx context.Context, fullMethod string, threshold time.Duration) {
+  log.Printf("slow call %s (request %s, %s, %s) still running after %s", fullMethod, reqctx.RequestID(ctx), reqctx.Identity(ctx), reqctx.Peer(ctx), threshold)
+
+  method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+  w.mu.Lock()
+  w.slow[method]++
+  dump := w.stacksDir != "" && time.Since(w.lastDump) >= stackDumpInterval
+  if dump {
+    w.lastDump = time.Now()
+  }
+  w.mu.Unlock()
+
+  if dump {
+    path, err := w.dumpStacks()
+    if err != nil {
+      log.Printf("slow call %s: failed to write the goroutine stacks: %v", fullMethod, err)
+      return
+    }
+    log.Printf("slow call %s: goroutine stacks written to %s", fullMethod, path)
+  }
+}
+
+func (w *slowCallWatchdog) dumpStacks() (string, error) {
+  // runtime.Stack truncates to the buffer, grown until everything fits.
+  buf := make([]byte, 1<<20)
+  for {
+    n := runtime.Stack(buf, true)
+    if n < len(buf) {
+      buf = buf[:n]
+      break
+    }
+    buf = make([]byte, 2*len(buf))
+  }
+
+  if err := os.MkdirAll(w.stacksDir, 0o755); err != nil {
+    return "", err
+  }
+  path := filepath.Join(w.stacksDir, "goroutine-"+time.Now().UTC().Format("20060102T150405")+".txt")
+
+  return path, os.WriteFile(path, buf, 0o644)
+}
+
+func (w *slowCallWatchdog) writeMetrics(out io.Writer) {
+  w.mu.Lock()
+  defer w.mu.Unlock()
+
+  fmt.Fprintln(out, "# HELP blog_slow_calls_total Calls still running after -slow-call-threshold, by method.")
+  fmt.Fprintln(out, "# TYPE blog_slow_calls_total counter")
+  methods := make([]string, 0, len(w.slow))
+  for method := range w.slow {
+    methods = append(methods, method)
+  }
+  sort.Strings(methods)
+  for _, method := range methods {
+    fmt.Fprintf(out, "blog_slow_calls_total{grpc_method=%q} %d\n", method, w.slow[method])
+  }
+}
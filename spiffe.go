@@ -0,0 +1,178 @@
+package main
+
+import (
+  "bufio"
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/reqctx"
+  "go/tutorial/grpc/internal/spiffe"
+  "os"
+  "strings"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/credentials"
+  "google.golang.org/grpc/peer"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  SPIFFE WORKLOAD IDENTITY
+
+  In a cluster run with SPIFFE every workload, the server and the programs calling it alike, gets an identity and a certificate carrying it from the agent next to it (see internal/spiffe). With -spiffe-socket the server serves TLS with its own SVID and checks the ones its callers present, mutual TLS without a certificate to provision nor a secret to share:
+
+    go run . -spiffe-socket $SPIFFE_ENDPOINT_SOCKET -spiffe-policy spiffe.policy
+
+  blogctl presents its SVID with the spiffe section of its profile (see config.go in the client). A caller with a valid SVID is known by its SPIFFE ID, spiffe://example.org/ci for instance. Callers without one still get through the handshake and are known the usual way, by their token or their session, so readers don't need an SVID.
+
+  The policy of -spiffe-policy says which SPIFFE IDs may call what, one rule per line: an ID, or a prefix ending with /*, the RPCs it may call, * for all of them or Blog/* for all those of a service, and optionally "admin" for the IDs allowed to call the admin RPCs. The first rule matching the ID applies, lines starting with # are comments:
+
+    spiffe://example.org/ops/deployer  *                                admin
+    spiffe://example.org/ci/*          Blog/CreatePost,Blog/UpdatePost
+    spiffe://example.org/frontend      Blog/*
+
+  An SVID no rule matches, or a call its rule doesn't list, is refused with PermissionDenied before the call goes any further. The ID of an admin rule is admin:<SPIFFE ID>. Without -spiffe-policy every SVID of the trust domain may call everything but the admin RPCs.
+
+  The check is an interceptor ahead of the one finding the identity (see reqctx), like the one of the signed requests (see signing.go), which then finds the ID in the context.
+*/
+type spiffePolicy struct {
+  // nil without -spiffe-policy, which allows everything but the admin RPCs.
+  rules []spiffeRule
+}
+
+type spiffeRule struct {
+  pattern string
+  // Service/Method, Service/* or *.
+  methods []string
+  admin   bool
+}
+
+// spiffeKey is the context key under which the interceptor leaves the identity of a caller with an SVID.
+type spiffeKey struct{}
+
+// spiffeMethods are the Service/Method names a rule may list.
+func spiffeMethods() map[string]bool {
+  methods := map[string]bool{}
+  for _, desc := range []grpc.ServiceDesc{pb.Blog_ServiceDesc, pb.Admin_ServiceDesc} {
+    service := desc.ServiceName[strings.LastIndex(desc.ServiceName, ".")+1:]
+    methods[service+"/*"] = true
+    for _, m := range desc.Methods {
+      methods[service+"/"+m.MethodName] = true
+    }
+    for _, s := range desc.Streams {
+      methods[service+"/"+s.StreamName] = true
+    }
+  }
+
+  return methods
+}
+
+func loadSPIFFEPolicy(path string) (*spiffePolicy, error) {
+  p := &spiffePolicy{}
+  if path == "" {
+    return p, nil
+  }
+
+  f, err := os.Open(path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read the SPIFFE policy: %w", err)
+  }
+  defer f.Close()
+
+  known := spiffeMethods()
+  p.rules = []spiffeRule{}
+  scanner := bufio.NewScanner(f)
+  for n := 1; scanner.Scan(); n++ {
+    line := strings.TrimSpace(scanner.Text())
+    if line == "" || strings.HasPrefix(line, "#") {
+      continue
+    }
+    fields := strings.Fields(line)
+    if len(fields) < 2 || len(fields) > 3 || (len(fields) == 3 && fields[2] != "admin") || !strings.HasPrefix(fields[0], "spiffe://") {
+      return nil, fmt.Errorf("%s:%d: expected <SPIFFE ID> <methods> [admin]", path, n)
+    }
+    rule := spiffeRule{pattern: fields[0], methods: splitList(fields[1]), admin: len(fields) == 3}
+    for _, method := range rule.methods {
+      if method != "*" && !known[method] {
+        return nil, fmt.Errorf("%s:%d: unknown method %q, expected Service/Method, Service/* or *", path, n, method)
+      }
+    }
+    p.rules = append(p.rules, rule)
+  }
+  if err := scanner.Err(); err != nil {
+    return nil, fmt.Errorf("failed to read the SPIFFE policy: %w", err)
+  }
+
+  return p, nil
+}
+
+// spiffeIdentity returns the identity of a caller the interceptor found an SVID on.
+func spiffeIdentity(ctx context.Context) (string, bool) {
+  identity, ok := ctx.Value(spiffeKey{}).(string)
+  return identity, ok
+}
+
+// authorize checks the SVID of the caller, when it has one, against the policy and returns the context with its identity.
+func (p *spiffePolicy) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+  id, ok := peerSPIFFEID(ctx)
+  if !ok {
+    return ctx, nil
+  }
+  if p.rules == nil {
+    return context.WithValue(ctx, spiffeKey{}, id), nil
+  }
+
+  // /grpc_tutorial.Blog/GetPosts is Blog/GetPosts in the rules.
+  service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+  service = service[strings.LastIndex(service, ".")+1:]
+  for _, rule := range p.rules {
+    if !spiffe.Match(rule.pattern, id) {
+      continue
+    }
+    for _, allowed := range rule.methods {
+      if allowed == "*" || allowed == service+"/*" || allowed == service+"/"+method {
+        identity := id
+        if rule.admin {
+          identity = adminIdentity + ":" + id
+        }
+        return context.WithValue(ctx, spiffeKey{}, identity), nil
+      }
+    }
+    return nil, status.Errorf(codes.PermissionDenied, "%s may not call %s/%s", id, service, method)
+  }
+
+  return nil, status.Errorf(codes.PermissionDenied, "%s isn't allowed by the SPIFFE policy of the server", id)
+}
+
+// peerSPIFFEID returns the SPIFFE ID of the SVID the caller presented, the TLS handshake verified it.
+func peerSPIFFEID(ctx context.Context) (string, bool) {
+  p, ok := peer.FromContext(ctx)
+  if !ok {
+    return "", false
+  }
+  info, ok := p.AuthInfo.(credentials.TLSInfo)
+  if !ok || len(info.State.VerifiedChains) == 0 {
+    return "", false
+  }
+
+  return spiffe.IDFromCertificate(info.State.VerifiedChains[0][0])
+}
+
+func (p *spiffePolicy) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  ctx, err := p.authorize(ctx, info.FullMethod)
+  if err != nil {
+    return nil, err
+  }
+
+  return handler(ctx, req)
+}
+
+func (p *spiffePolicy) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  ctx, err := p.authorize(ss.Context(), info.FullMethod)
+  if err != nil {
+    return err
+  }
+
+  return handler(srv, reqctx.WrapStream(ss, ctx))
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+)
+
+/*
+  STORAGE STATS
+
+  GetStorageStats is the Admin RPC telling how much room the posts take and how much -compress-content-over saves, which is what to look at before picking a threshold:
+
+    go run ./client storage-stats -token secret
+*/
+func (a *adminServer) GetStorageStats(ctx context.Context, _ *pb.GetStorageStatsRequest) (*pb.StorageStats, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  // Loading brings the content stats up to date, they are computed on every load and save.
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return nil, err
+  }
+
+  size, err := store.Size(a.storage)
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to measure the storage: %w", err)
+  }
+
+  stats := a.storage.Stats()
+  var encryptionKey string
+  if a.encryption.Keys != nil {
+    encryptionKey = a.encryption.Keys.Current()
+  }
+
+  return &pb.StorageStats{
+    Backend:            storageBackend,
+    StorageBytes:       size,
+    Posts:              int32(stats.Posts),
+    CompressedPosts:    int32(stats.CompressedPosts),
+    ContentBytes:       stats.ContentBytes,
+    StoredContentBytes: stats.StoredBytes,
+    CompressThreshold:  int64(a.storage.Threshold),
+    PendingSaves:       int32(a.pending()),
+    EncryptedPosts:     int32(a.encryption.Stats().Encrypted()),
+    EncryptionKey:      encryptionKey,
+  }, nil
+}
+
+/*
+  FLUSHING
+
+  With -write-delay the saves are held back and written together, see internal/store/batch.go. FlushStorage writes them right away, before a backup for instance:
+
+    go run ./client flush-storage -token secret
+
+  Without -write-delay every save is already written, it answers with 0 saves.
+*/
+func (a *adminServer) FlushStorage(ctx context.Context, _ *pb.FlushStorageRequest) (*pb.StorageFlush, error) {
+  if err := a.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  saves, err := a.flush()
+  if err != nil {
+    return nil, err
+  }
+
+  return &pb.StorageFlush{Saves: int32(saves)}, nil
+}
+
+func (a *adminServer) flush() (int, error) {
+  if a.batching == nil {
+    return 0, nil
+  }
+
+  saves, err := a.batching.Flush()
+  if err != nil {
+    return 0, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to write the pending posts: %w", err)
+  }
+
+  return saves, nil
+}
+
+func (a *adminServer) pending() int {
+  if a.batching == nil {
+    return 0
+  }
+
+  return a.batching.Pending()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+  "encoding/base64"
+  "errors"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+  "io"
+  "strings"
+
+  "google.golang.org/grpc"
+)
+
+/*
+  RESUMABLE STREAMS
+
+  StreamPosts sends the same posts as GetPosts, one message per post, so a client can start showing them before the last one arrives. Streams can break halfway, a flaky connection or a server restart is enough, and starting over would send every post again.
+
+  Instead, every message comes with a cursor. A client that loses the stream calls StreamPosts again with the cursor of the last post it got and the server picks up right after that post:
+
+    go run ./client stream -cursor <cursor>
+
+  The cursor points at the ID of the post, not at its position in the list or at anything kept in memory, so it stays valid across server restarts. Deleted posts stay behind as tombstones (see DeletePost), so even the cursor of a post deleted in the meantime keeps its place.
+
+  Cursors are opaque to clients: base64 of a version prefix and the ID. Clients shouldn't build or parse them, which leaves us free to change what goes inside, the prefix tells the versions apart.
+
+  Like GetPosts, StreamPosts doesn't count views, readers call RecordView for the posts they actually open (see views.go).
+
+  StreamPosts is the hot path of the server for clients that read everything, so it allocates as little as it can: it reads the posts one at a time (see internal/store/reader.go), Send marshals the message before returning so a single response and cursor buffer do for all of them, and once sent every post goes back to the storage to decode the next one into (see internal/store/pool.go). stream_test.go has the benchmark.
+*/
+const streamCursorPrefix = "v1:"
+
+func (s *server) StreamPosts(req *pb.StreamPostsRequest, stream grpc.ServerStreamingServer[pb.StreamPostsResponse]) error {
+  after := ""
+  if req.GetCursor() != "" {
+    id, err := decodeStreamCursor(req.GetCursor())
+    if err != nil {
+      return err
+    }
+    after = id
+  }
+
+  // The posts are read one at a time, a stream never holds more than the post it is sending.
+  r, err := openPosts(store.Query{})
+  if err != nil {
+    return err
+  }
+  defer r.Close()
+
+  res := &pb.StreamPostsResponse{}
+  var cursor streamCursorEncoder
+  found := after == ""
+  for {
+    post, err := r.Next()
+    if errors.Is(err, io.EOF) {
+      break
+    }
+    if err != nil {
+      return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to load posts: %w", err)
+    }
+
+    // Posts up to the one of the cursor were sent already. Only published ones are sent, like publishedPosts does.
+    send := found && post.Status == pb.PostStatus_PUBLISHED
+    found = found || post.Id == after
+    if send {
+      res.Post, res.Cursor = post, cursor.encode(post.Id)
+      err = stream.Send(res)
+    }
+    // Nothing holds on to the post once it is sent, the next one can be decoded into it.
+    store.Release(post)
+    if err != nil {
+      return err
+    }
+  }
+
+  if !found {
+    return apperr.Errorf(apperr.ErrInvalidArgument, "cursor points at post %s, which doesn't exist", after)
+  }
+
+  return nil
+}
+
+// streamCursorEncoder encodes the cursors of a stream in buffers it reuses, only the string of each cursor is new.
+type streamCursorEncoder struct {
+  raw, encoded []byte
+}
+
+func (e *streamCursorEncoder) encode(id string) string {
+  e.raw = append(append(e.raw[:0], streamCursorPrefix...), id...)
+  e.encoded = base64.RawURLEncoding.AppendEncode(e.encoded[:0], e.raw)
+
+  return string(e.encoded)
+}
+
+func decodeStreamCursor(cursor string) (string, error) {
+  data, err := base64.RawURLEncoding.DecodeString(cursor)
+  if err != nil || !strings.HasPrefix(string(data), streamCursorPrefix) {
+    return "", apperr.Errorf(apperr.ErrInvalidArgument, "invalid cursor %q", cursor)
+  }
+
+  return strings.TrimPrefix(string(data), streamCursorPrefix), nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/store"
+  "path/filepath"
+  "testing"
+
+  "google.golang.org/grpc"
+  "google.golang.org/protobuf/proto"
+)
+
+// marshalingStream stands in for the gRPC stream of StreamPosts: Send marshals the message like the proto codec does and keeps the posts it got.
+type marshalingStream struct {
+  grpc.ServerStream
+  buf     []byte
+  ids     []string
+  cursors []string
+}
+
+func (s *marshalingStream) Context() context.Context {
+  return context.Background()
+}
+
+func (s *marshalingStream) Send(res *pb.StreamPostsResponse) error {
+  var err error
+  s.buf, err = proto.MarshalOptions{}.MarshalAppend(s.buf[:0], res)
+  if s.ids != nil {
+    s.ids = append(s.ids, res.GetPost().GetId())
+    s.cursors = append(s.cursors, res.GetCursor())
+  }
+
+  return err
+}
+
+// useFileStore stores the posts in a posts.json of its own, like the server does by default.
+func useFileStore(tb testing.TB, posts []*pb.Post) {
+  tb.Helper()
+
+  previous := postStore
+  postStore = &store.FileStore{Path: filepath.Join(tb.TempDir(), "posts.json")}
+  tb.Cleanup(func() { postStore = previous })
+
+  if err := postStore.Save(posts); err != nil {
+    tb.Fatal(err)
+  }
+}
+
+func TestStreamPostsResume(t *testing.T) {
+  posts := largePosts(10)
+  posts[3].Status = pb.PostStatus_SCHEDULED
+  useFileStore(t, posts)
+  s := &server{}
+
+  stream := &marshalingStream{ids: []string{}}
+  if err := s.StreamPosts(&pb.StreamPostsRequest{}, stream); err != nil {
+    t.Fatalf("StreamPosts: %v", err)
+  }
+  if len(stream.ids) != 9 {
+    t.Fatalf("got %d posts, want the 9 published ones", len(stream.ids))
+  }
+  // Every message must carry its own post and cursor, even when the messages are reused.
+  for i, id := range stream.ids {
+    if cursor, _ := decodeStreamCursor(stream.cursors[i]); cursor != id {
+      t.Fatalf("post %s came with the cursor of %s", id, cursor)
+    }
+  }
+
+  resumed := &marshalingStream{ids: []string{}}
+  if err := s.StreamPosts(&pb.StreamPostsRequest{Cursor: stream.cursors[4]}, resumed); err != nil {
+    t.Fatalf("StreamPosts from cursor: %v", err)
+  }
+  if len(resumed.ids) != 4 || resumed.ids[0] != stream.ids[5] {
+    t.Fatalf("resuming after %s sent %v, want the 4 posts after it", stream.ids[4], resumed.ids)
+  }
+}
+
+// BenchmarkStreamPosts streams 200 posts out of posts.json, run it with -benchmem to see the allocations.
+func BenchmarkStreamPosts(b *testing.B) {
+  useFileStore(b, largePosts(200))
+  s := &server{}
+  stream := &marshalingStream{}
+
+  b.ReportAllocs()
+  for range b.N {
+    if err := s.StreamPosts(&pb.StreamPostsRequest{}, stream); err != nil {
+      b.Fatalf("StreamPosts: %v", err)
+    }
+  }
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+  "cmp"
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "slices"
+  "sync"
+  "time"
+)
+
+/*
+  DAILY SUMMARIES
+
+  The views of the minutes are only kept for -views-retention (see views.go), enough for trending and for the charts of the last days, not to tell whether the blog is read more than a month ago. The rollup-summaries task (see cron.go) sums the views of every day that ended, for every post and every author, into summaries.json, and GetDailySummary answers from there:
+
+    go run ./client summary                   # yesterday, next to the day before
+    go run ./client summary -date 2025-06-02 -week -n 5
+
+  A summary is the views of every post of a day or an ISO week, Monday to Sunday, with the top posts and authors and how many more (or fewer) views they had than the period before. The days are kept for -summaries-retention, 90 days by default, the weeks forever: a post takes 52 entries a year.
+
+  The task rolls up the days after the last one it rolled up, so a server that was stopped for a few days catches up as long as the views of those days are still kept. A day is rolled up once, with the authors the posts had then, and doesn't change afterwards. The days not rolled up yet, today and the days before the next run, are summed from the views when asked for, the summary of today is then the views so far.
+*/
+const (
+  summariesPath = "summaries.json"
+  // dayLayout is the format of the days of the summaries, and of their keys in summaries.json.
+  dayLayout = time.DateOnly
+)
+
+// periodViews is what is kept of a day or a week.
+type periodViews struct {
+  Posts   map[string]int64 `json:"posts"`
+  Authors map[string]int64 `json:"authors"`
+}
+
+func newPeriodViews() *periodViews {
+  return &periodViews{Posts: make(map[string]int64), Authors: make(map[string]int64)}
+}
+
+// add counts views of posts, authors[post ID] being the author of the post.
+func (p *periodViews) add(views map[string]int64, authors map[string]string) {
+  for id, n := range views {
+    p.Posts[id] += n
+    p.Authors[authors[id]] += n
+  }
+}
+
+func (p *periodViews) merge(other *periodViews) {
+  for id, n := range other.Posts {
+    p.Posts[id] += n
+  }
+  for author, n := range other.Authors {
+    p.Authors[author] += n
+  }
+}
+
+func (p *periodViews) total() int64 {
+  var total int64
+  for _, n := range p.Posts {
+    total += n
+  }
+
+  return total
+}
+
+// summariesFile is the format of summaries.json, weeks are keyed by their Monday.
+type summariesFile struct {
+  // Through is the last day rolled up, empty before the first rollup.
+  Through string                  `json:"through"`
+  Days    map[string]*periodViews `json:"days"`
+  Weeks   map[string]*periodViews `json:"weeks"`
+}
+
+type summaryLog struct {
+  path      string
+  retention time.Duration
+
+  mu   sync.Mutex
+  file summariesFile
+}
+
+func newSummaryLog(path string, retention time.Duration) (*summaryLog, error) {
+  s := &summaryLog{path: path, retention: retention}
+  if err := readJSON(path, &s.file); err != nil {
+    return nil, err
+  }
+  if s.file.Days == nil {
+    s.file.Days = make(map[string]*periodViews)
+  }
+  if s.file.Weeks == nil {
+    s.file.Weeks = make(map[string]*periodViews)
+  }
+
+  return s, nil
+}
+
+// dayOf returns the midnight UTC starting the day of t.
+func dayOf(t time.Time) time.Time {
+  return t.UTC().Truncate(24 * time.Hour)
+}
+
+// weekOf returns the Monday of the week of day.
+func weekOf(day time.Time) time.Time {
+  return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// through returns the last day rolled up, the zero time before the first rollup. s.mu must be held.
+func (s *summaryLog) through() time.Time {
+  through, _ := time.Parse(dayLayout, s.file.Through)
+  return through
+}
+
+// postAuthors returns the author of every post, the deleted ones too, their views were counted.
+func postAuthors() (map[string]string, error) {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  authors := make(map[string]string, len(posts.Posts))
+  for _, post := range posts.Posts {
+    authors[post.Id] = post.Author
+  }
+
+  return authors, nil
+}
+
+// rollupSummaries is the rollup-summaries task, see above.
+func (s *server) rollupSummaries(context.Context) (string, error) {
+  authors, err := postAuthors()
+  if err != nil {
+    return "", err
+  }
+  today := dayOf(serverClock.Now())
+
+  s.summaries.mu.Lock()
+  defer s.summaries.mu.Unlock()
+
+  // The first day whose views are all still kept.
+  first := dayOf(serverClock.Now().Add(-s.views.retention)).AddDate(0, 0, 1)
+  if through := s.summaries.through(); !through.IsZero() && through.AddDate(0, 0, 1).After(first) {
+    first = through.AddDate(0, 0, 1)
+  }
+
+  rolled := 0
+  for day := first; day.Before(today); day = day.AddDate(0, 0, 1) {
+    views := newPeriodViews()
+    views.add(s.views.between(day, day.AddDate(0, 0, 1)), authors)
+    s.summaries.file.Days[day.Format(dayLayout)] = views
+
+    week := weekOf(day).Format(dayLayout)
+    if s.summaries.file.Weeks[week] == nil {
+      s.summaries.file.Weeks[week] = newPeriodViews()
+    }
+    s.summaries.file.Weeks[week].merge(views)
+    rolled++
+  }
+  if rolled == 0 {
+    return "no day to roll up", nil
+  }
+  s.summaries.file.Through = today.AddDate(0, 0, -1).Format(dayLayout)
+
+  oldest := dayOf(serverClock.Now().Add(-s.summaries.retention))
+  for key := range s.summaries.file.Days {
+    if day, _ := time.Parse(dayLayout, key); day.Before(oldest) {
+      delete(s.summaries.file.Days, key)
+    }
+  }
+
+  if err := replaceFile(s.summaries.path, s.summaries.file); err != nil {
+    return "", apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save summaries: %w", err)
+  }
+
+  return fmt.Sprintf("rolled up %d days through %s", rolled, s.summaries.file.Through), nil
+}
+
+// collect returns the views of the days from start on, from the summaries for the days rolled up and from the views for the others. s.summaries.mu must be held.
+func (s *server) collect(start time.Time, days int, authors map[string]string) *periodViews {
+  views := newPeriodViews()
+  through := s.summaries.through()
+  // A whole week rolled up, or the part of it that is, is summed already.
+  if days == 7 && !through.Before(start) {
+    if week := s.summaries.file.Weeks[start.Format(dayLayout)]; week != nil {
+      views.merge(week)
+    }
+  }
+
+  for i := range days {
+    day := start.AddDate(0, 0, i)
+    switch {
+    case day.After(through):
+      views.add(s.views.between(day, day.AddDate(0, 0, 1)), authors)
+    case days == 1:
+      if summary := s.summaries.file.Days[day.Format(dayLayout)]; summary != nil {
+        views.merge(summary)
+      }
+    }
+  }
+
+  return views
+}
+
+func (s *server) GetDailySummary(_ context.Context, req *pb.GetDailySummaryRequest) (*pb.DailySummary, error) {
+  today := dayOf(serverClock.Now())
+  day := today.AddDate(0, 0, -1)
+  if req.GetDate() != "" {
+    t, err := time.Parse(dayLayout, req.GetDate())
+    if err != nil {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Date must be a day like 2025-06-01: %w", err)
+    }
+    if t.After(today) {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Date %s hasn't come yet", req.GetDate())
+    }
+    day = t
+  }
+
+  start, days := day, 1
+  if req.GetPeriod() == pb.SummaryPeriod_SUMMARY_WEEK {
+    start, days = weekOf(day), 7
+  }
+  end := start.AddDate(0, 0, days-1)
+
+  limit := int(req.GetLimit())
+  if limit == 0 {
+    limit = 10
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  authors := make(map[string]string, len(posts.Posts))
+  titles := make(map[string]string, len(posts.Posts))
+  for _, post := range posts.Posts {
+    authors[post.Id] = post.Author
+    if post.Status != pb.PostStatus_DELETED {
+      titles[post.Id] = post.Title
+    }
+  }
+
+  s.summaries.mu.Lock()
+  current := s.collect(start, days, authors)
+  previous := s.collect(start.AddDate(0, 0, -days), days, authors)
+  complete := !s.summaries.through().Before(end)
+  s.summaries.mu.Unlock()
+
+  summary := &pb.DailySummary{
+    Period:        req.GetPeriod(),
+    Start:         start.Format(dayLayout),
+    End:           end.Format(dayLayout),
+    Views:         current.total(),
+    PreviousViews: previous.total(),
+    Complete:      complete,
+  }
+  summary.Delta = summary.Views - summary.PreviousViews
+  if summary.PreviousViews > 0 {
+    summary.DeltaPercent = float64(summary.Delta) * 100 / float64(summary.PreviousViews)
+  }
+
+  for id, views := range current.Posts {
+    summary.TopPosts = append(summary.TopPosts, &pb.PostSummary{
+      PostId:        id,
+      Title:         titles[id],
+      Author:        authors[id],
+      Views:         views,
+      PreviousViews: previous.Posts[id],
+      Delta:         views - previous.Posts[id],
+    })
+  }
+  slices.SortFunc(summary.TopPosts, func(a, b *pb.PostSummary) int {
+    return cmp.Or(cmp.Compare(b.Views, a.Views), cmp.Compare(a.PostId, b.PostId))
+  })
+  summary.TopPosts = summary.TopPosts[:min(limit, len(summary.TopPosts))]
+
+  for author, views := range current.Authors {
+    summary.TopAuthors = append(summary.TopAuthors, &pb.AuthorSummary{
+      Author:        author,
+      Views:         views,
+      PreviousViews: previous.Authors[author],
+      Delta:         views - previous.Authors[author],
+    })
+  }
+  slices.SortFunc(summary.TopAuthors, func(a, b *pb.AuthorSummary) int {
+    return cmp.Or(cmp.Compare(b.Views, a.Views), cmp.Compare(a.Author, b.Author))
+  })
+  summary.TopAuthors = summary.TopAuthors[:min(limit, len(summary.TopAuthors))]
+
+  return summary, nil
+}
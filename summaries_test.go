@@ -0,0 +1,91 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "path/filepath"
+  "testing"
+  "time"
+)
+
+func TestDailySummaries(t *testing.T) {
+  // A Wednesday, the week started on Monday the 2nd.
+  fake := useFakeClock(t, time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC))
+  useFileStore(t, []*pb.Post{
+    {Id: "p1", Title: "Hello gRPC", Author: "Ana"},
+    {Id: "p2", Title: "Streaming", Author: "Chen"},
+  })
+
+  dir := t.TempDir()
+  views, err := newViewLog(filepath.Join(dir, "views.json"), filepath.Join(dir, "viewers.json"), 7*24*time.Hour, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  summaries, err := newSummaryLog(filepath.Join(dir, "summaries.json"), 90*24*time.Hour)
+  if err != nil {
+    t.Fatal(err)
+  }
+  s := &server{views: views, summaries: summaries}
+
+  p1, p2 := []*pb.Post{{Id: "p1"}}, []*pb.Post{{Id: "p2"}}
+  for _, view := range []struct {
+    posts []*pb.Post
+    at    string
+  }{
+    {p1, "2025-06-02T08:00:00Z"}, {p1, "2025-06-02T12:00:00Z"}, {p1, "2025-06-02T23:59:00Z"},
+    {p1, "2025-06-03T00:00:00Z"}, {p2, "2025-06-03T09:00:00Z"}, {p2, "2025-06-03T18:00:00Z"},
+    {p2, "2025-06-04T09:00:00Z"},
+  } {
+    at, _ := time.Parse(time.RFC3339, view.at)
+    views.record(view.posts, at)
+  }
+
+  // The 7 days of views kept, the day of the oldest one cut short is left out.
+  if result, err := s.rollupSummaries(context.Background()); err != nil || result != "rolled up 6 days through 2025-06-03" {
+    t.Fatalf("rollup-summaries: %q, %v", result, err)
+  }
+
+  day, err := s.GetDailySummary(context.Background(), &pb.GetDailySummaryRequest{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if day.Start != "2025-06-03" || !day.Complete || day.Views != 3 || day.PreviousViews != 3 || day.Delta != 0 {
+    t.Errorf("got %v, want yesterday complete with 3 views like the day before", day)
+  }
+  if len(day.TopPosts) != 2 || day.TopPosts[0].PostId != "p2" || day.TopPosts[1].Delta != -2 {
+    t.Errorf("got the top posts %v, want p2 then p1 with 2 views fewer", day.TopPosts)
+  }
+  if len(day.TopAuthors) != 2 || day.TopAuthors[0].Author != "Chen" || day.TopAuthors[0].Delta != 2 {
+    t.Errorf("got the top authors %v, want Chen first with 2 more views", day.TopAuthors)
+  }
+
+  // The days rolled up and today, which isn't yet.
+  week, err := s.GetDailySummary(context.Background(), &pb.GetDailySummaryRequest{Date: "2025-06-04", Period: pb.SummaryPeriod_SUMMARY_WEEK})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if week.Start != "2025-06-02" || week.End != "2025-06-08" || week.Complete || week.Views != 7 {
+    t.Errorf("got %v, want the week of the 2nd so far with 7 views", week)
+  }
+
+  // The summaries outlive the views.
+  fake.Advance(30 * 24 * time.Hour)
+  if err := views.flush(); err != nil {
+    t.Fatal(err)
+  }
+  if result, err := s.rollupSummaries(context.Background()); err != nil || result != "rolled up 6 days through 2025-07-03" {
+    t.Fatalf("rollup-summaries a month later: %q, %v", result, err)
+  }
+  reloaded, err := newSummaryLog(filepath.Join(dir, "summaries.json"), 90*24*time.Hour)
+  if err != nil {
+    t.Fatal(err)
+  }
+  s.summaries = reloaded
+  old, err := s.GetDailySummary(context.Background(), &pb.GetDailySummaryRequest{Date: "2025-06-02"})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if old.Views != 3 || old.TopPosts[0].Title != "Hello gRPC" {
+    t.Errorf("got %v for the 2nd a month later, want its 3 views of p1", old)
+  }
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+  "go/tutorial/grpc/internal/apperr"
+  "slices"
+  "strings"
+)
+
+/*
+  TAGS
+
+  Tags group posts by topic: a post about streaming RPCs could be tagged grpc, streaming and go. They are set with CreatePost and UpdatePost and used to find related posts (see related.go).
+
+    go run ./client create -title "Streaming RPCs" -tags grpc,streaming,go
+
+  Tags are compared as they are stored, so the server normalizes them on the way in: "gRPC" and " grpc " are the same tag. Limiting them to letters, digits and dashes keeps them usable in URLs and cheap to compare.
+*/
+const (
+  maxTags      = 10
+  maxTagLength = 32
+)
+
+// normalizeTags lowercases and trims the tags, drops the duplicates and checks what's left.
+func normalizeTags(tags []string) ([]string, error) {
+  normalized := make([]string, 0, len(tags))
+  for _, tag := range tags {
+    tag = strings.ToLower(strings.TrimSpace(tag))
+    if tag == "" || slices.Contains(normalized, tag) {
+      continue
+    }
+
+    if len(tag) > maxTagLength {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "tag %q is longer than %d characters", tag, maxTagLength)
+    }
+    if strings.IndexFunc(tag, func(r rune) bool { return !isTagRune(r) }) >= 0 {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "tag %q must be made of letters, digits and dashes", tag)
+    }
+
+    normalized = append(normalized, tag)
+  }
+
+  if len(normalized) > maxTags {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "a post can have at most %d tags", maxTags)
+  }
+
+  return normalized, nil
+}
+
+func isTagRune(r rune) bool {
+  return r == '-' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9'
+}
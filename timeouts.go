@@ -0,0 +1,150 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/reqctx"
+  "path"
+  "strings"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+/*
+  SERVER TIMEOUTS
+
+  A client deadline protects the client, not the server: a client that sets none, or one of an hour, can keep a handler busy for as long as the handler wants to run. -method-timeouts puts an upper bound on every call of a method, whatever the client asked for:
+
+    go run . -method-timeouts GetPosts=2s,CreatePost=5s
+
+  Methods that aren't listed have no limit other than the client's deadline. When a client sends a shorter deadline than ours, its deadline wins, context.WithTimeout never extends a deadline.
+
+  Once the time is up the handler's context is cancelled, and the handler is waited for: answering DeadlineExceeded while it keeps running would tell the client a call failed that may still save, and the retry of the client would then create the post twice. The interceptors around it would also be done with a call that is still running, the concurrency limit of limiter.go first. So a call only fails once its handler gave up, and a handler that saved anyway answers with what it saved, late.
+
+  Handlers waiting for storeMu don't see the context while they wait, the ones that save posts check it once they have the lock and once more right before saving, with checkCallDone. Past that point the change is made and the call succeeds.
+*/
+type methodTimeouts map[string]time.Duration
+
+// serverTimeouts holds the timeouts the interceptors apply, which can be replaced while the server runs (see config.go).
+type serverTimeouts struct {
+  mu       sync.RWMutex
+  timeouts methodTimeouts
+}
+
+func newServerTimeouts(timeouts methodTimeouts) *serverTimeouts {
+  return &serverTimeouts{timeouts: timeouts}
+}
+
+func (t *serverTimeouts) set(timeouts methodTimeouts) {
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  t.timeouts = timeouts
+}
+
+func (t *serverTimeouts) lookup(fullMethod string) (time.Duration, bool) {
+  t.mu.RLock()
+  defer t.mu.RUnlock()
+
+  timeout, ok := t.timeouts[path.Base(fullMethod)]
+  return timeout, ok
+}
+
+// parseMethodTimeouts reads a list like "GetPosts=2s,CreatePost=5s". Method names are checked against the Blog service so a typo doesn't silently disable a timeout.
+func parseMethodTimeouts(list string) (methodTimeouts, error) {
+  known := map[string]bool{}
+  for _, m := range pb.Blog_ServiceDesc.Methods {
+    known[m.MethodName] = true
+  }
+  for _, s := range pb.Blog_ServiceDesc.Streams {
+    known[s.StreamName] = true
+  }
+
+  timeouts := methodTimeouts{}
+  for _, entry := range splitList(list) {
+    method, value, ok := strings.Cut(entry, "=")
+    if !ok {
+      return nil, fmt.Errorf("invalid method timeout %q, expected Method=duration", entry)
+    }
+    if !known[method] {
+      return nil, fmt.Errorf("unknown method %q in -method-timeouts", method)
+    }
+
+    d, err := time.ParseDuration(value)
+    if err != nil || d <= 0 {
+      return nil, fmt.Errorf("invalid timeout %q for %s, expected a positive duration like 2s", value, method)
+    }
+    timeouts[method] = d
+  }
+
+  return timeouts, nil
+}
+
+// splitList splits a comma separated list, ignoring spaces and empty entries.
+func splitList(list string) []string {
+  var items []string
+  for _, item := range strings.Split(list, ",") {
+    if item = strings.TrimSpace(item); item != "" {
+      items = append(items, item)
+    }
+  }
+
+  return items
+}
+
+func (t *serverTimeouts) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  timeout, ok := t.lookup(info.FullMethod)
+  if !ok {
+    return handler(ctx, req)
+  }
+
+  ctx, cancel := context.WithTimeout(ctx, timeout)
+  defer cancel()
+
+  res, err := handler(ctx, req)
+  if err != nil && ctx.Err() != nil {
+    return nil, timeoutError(ctx, info.FullMethod, timeout)
+  }
+
+  return res, err
+}
+
+func (t *serverTimeouts) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  timeout, ok := t.lookup(info.FullMethod)
+  if !ok {
+    return handler(srv, ss)
+  }
+
+  ctx, cancel := context.WithTimeout(ss.Context(), timeout)
+  defer cancel()
+
+  err := handler(srv, reqctx.WrapStream(ss, ctx))
+  if err != nil && ctx.Err() != nil {
+    return timeoutError(ctx, info.FullMethod, timeout)
+  }
+
+  return err
+}
+
+// checkCallDone returns the error of a call whose time is up or whose client went away, for the handlers to check before they change anything.
+func checkCallDone(ctx context.Context) error {
+  if err := ctx.Err(); err != nil {
+    return status.FromContextError(err).Err()
+  }
+
+  return nil
+}
+
+// timeoutError reports a call that ran out of time. A client that went away gets Canceled, which it won't see anyway.
+func timeoutError(ctx context.Context, method string, timeout time.Duration) error {
+  if ctx.Err() == context.Canceled {
+    return status.Errorf(codes.Canceled, "%s was cancelled", method)
+  }
+
+  return status.Errorf(codes.DeadlineExceeded, "%s ran out of time, the server allows it %s", method, timeout)
+}
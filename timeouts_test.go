@@ -0,0 +1,37 @@
+package main
+
+import (
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "testing"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+func TestTimeoutWaitsForTheHandler(t *testing.T) {
+  timeouts := newServerTimeouts(methodTimeouts{"CreatePost": 10 * time.Millisecond})
+  info := &grpc.UnaryServerInfo{FullMethod: pb.Blog_CreatePost_FullMethodName}
+
+  // A handler that saves past its deadline succeeded, the client must hear it.
+  res, err := timeouts.unaryInterceptor(context.Background(), nil, info, func(ctx context.Context, _ any) (any, error) {
+    <-ctx.Done()
+    return &pb.Post{Id: "saved"}, nil
+  })
+  if err != nil || res.(*pb.Post).Id != "saved" {
+    t.Errorf("got %v, %v from a handler that saved late, want its post", res, err)
+  }
+
+  returned := false
+  _, err = timeouts.unaryInterceptor(context.Background(), nil, info, func(ctx context.Context, _ any) (any, error) {
+    <-ctx.Done()
+    time.Sleep(10 * time.Millisecond)
+    returned = true
+    return nil, checkCallDone(ctx)
+  })
+  if status.Code(err) != codes.DeadlineExceeded || !returned {
+    t.Errorf("got %v before the handler returned %v, want DeadlineExceeded once it gave up", err, returned)
+  }
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+  "context"
+  "go/tutorial/grpc/internal/reqctx"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+)
+
+/*
+  TRAILERS
+
+  Trailers are metadata sent after the response, together with the status code. That makes them the right place for things only known once the handler is done, like how long it took. The stats interceptor attaches these to every call:
+    - x-processing-time: time spent in the handler, e.g. 1.52ms
+    - x-storage-backend: where the server keeps its posts
+    - x-cache: hit or miss, only when the call went through a cache (the mirror's for instance)
+
+  Handlers report cache lookups through the context with markCache, the interceptor then copies the result into the trailers.
+*/
+
+const (
+  processingTimeTrailer = "x-processing-time"
+  storageBackendTrailer = "x-storage-backend"
+  cacheTrailer          = "x-cache"
+)
+
+// storageBackend names the storage the posts live in.
+var storageBackend = "file"
+
+type callStats struct {
+  mu    sync.Mutex
+  cache string
+}
+
+type callStatsKey struct{}
+
+// markCache records whether the call was answered from a cache. Calling it on a context without stats does nothing.
+func markCache(ctx context.Context, hit bool) {
+  stats, ok := ctx.Value(callStatsKey{}).(*callStats)
+  if !ok {
+    return
+  }
+
+  stats.mu.Lock()
+  defer stats.mu.Unlock()
+
+  stats.cache = "miss"
+  if hit {
+    stats.cache = "hit"
+  }
+}
+
+func (c *callStats) trailer(started time.Time) metadata.MD {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  md := metadata.Pairs(
+    processingTimeTrailer, time.Since(started).String(),
+    storageBackendTrailer, storageBackend,
+  )
+  if c.cache != "" {
+    md.Set(cacheTrailer, c.cache)
+  }
+
+  return md
+}
+
+func statsUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  started := time.Now()
+  stats := &callStats{}
+  ctx = context.WithValue(ctx, callStatsKey{}, stats)
+
+  resp, err := handler(ctx, req)
+  grpc.SetTrailer(ctx, stats.trailer(started))
+
+  return resp, err
+}
+
+func statsStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  started := time.Now()
+  stats := &callStats{}
+  ctx := context.WithValue(ss.Context(), callStatsKey{}, stats)
+
+  err := handler(srv, reqctx.WrapStream(ss, ctx))
+  ss.SetTrailer(stats.trailer(started))
+
+  return err
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "log"
+  "strings"
+
+  "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+  "buf.build/go/protovalidate"
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc"
+  "google.golang.org/protobuf/proto"
+  "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+/*
+  VALIDATION
+
+  Most handlers used to start by checking their request: the title can't be empty, the limit can't be above 50, the position can't be negative. Those checks only look at one field at a time, so they are now declared next to the fields in blog.proto, with the rules of buf's protovalidate (https://protovalidate.com):
+
+    message CreatePostRequest {
+      string Title = 1 [(buf.validate.field).required = true, (buf.validate.field).string.max_len = 200];
+    }
+
+  and an interceptor checks every request before it reaches its handler, along with every message a client streams in. Clients can read the constraints in the proto file instead of finding them out one error at a time, and the ones written in another language can check them too with the protovalidate library of theirs.
+
+  The rules are field options of buf/validate/validate.proto, a dependency of the proto module declared in buf.yaml, whose Go code is the buf.build/gen/go/bufbuild/protovalidate package the stubs of gen/ import. protovalidate.Validate reads them back from the descriptors, compiles them into CEL programs the first time it sees a message and runs them. It goes into the messages nested in the request, so the fields of a Filter are checked too, and names them by their path, like Filter.Since or Tags[2]. A rule the standard ones can't express is a CEL expression of its own, like the not_blank of the titles.
+
+  A request breaking a rule is answered with InvalidArgument before the handler runs. The message tells about the first field in the wrong, and a google.rpc BadRequest in the details lists every one of them, so a form can mark all its fields at once:
+
+    st, _ := status.FromError(err)
+    for _, detail := range st.Details() {
+      if bad, ok := detail.(*errdetails.BadRequest); ok { ... }
+    }
+
+  Rather than the English messages of protovalidate, the violations of the rules blog.proto uses get messages of ours, which can be translated like the other errors (see localize.go). The others keep the one of protovalidate.
+
+  The checks that need more than the field itself stay in the handlers: whether Until comes after Since, whether a post exists, whether a PublishAt parses.
+*/
+
+// requestValidator has the rules of every message of blog.proto compiled up front: a rule that doesn't compile stops the server right away rather than failing the first call.
+var requestValidator = newRequestValidator(pb.File_blog_proto.Messages())
+
+type violation struct {
+  field string
+  // Kept apart like the ones of apperr.Errorf, so the message of the first violation can be translated.
+  format string
+  args   []any
+}
+
+func validateUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+  if err := validateRequest(req); err != nil {
+    return nil, err
+  }
+
+  return handler(ctx, req)
+}
+
+func validateStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  return handler(srv, &validatingStream{ServerStream: ss})
+}
+
+// validatingStream checks every message the client sends.
+type validatingStream struct {
+  grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+  if err := s.ServerStream.RecvMsg(m); err != nil {
+    return err
+  }
+
+  return validateRequest(m)
+}
+
+// validateRequest returns the error described above when the request breaks one of the rules of its fields.
+func validateRequest(req any) error {
+  msg, ok := req.(proto.Message)
+  if !ok {
+    return nil
+  }
+
+  err := requestValidator.Validate(msg)
+  if err == nil {
+    return nil
+  }
+
+  invalid, ok := err.(*protovalidate.ValidationError)
+  if !ok {
+    return apperr.Errorf(apperr.ErrInternal, "failed to validate the request: %w", err)
+  }
+
+  bad := &errdetails.BadRequest{}
+  violations := make([]violation, 0, len(invalid.Violations))
+  for _, v := range invalid.Violations {
+    described := describeViolation(v)
+    violations = append(violations, described)
+    bad.FieldViolations = append(bad.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: described.field, Description: fmt.Sprintf(described.format, described.args...)})
+  }
+
+  return apperr.WithDetails(apperr.Errorf(apperr.ErrInvalidArgument, violations[0].format, violations[0].args...), bad)
+}
+
+// describeViolation gives the violation the message of ours of its rule, see above.
+func describeViolation(v *protovalidate.Violation) violation {
+  path := protovalidate.FieldPathString(v.Proto.GetField())
+  rule := v.Proto.GetRuleId()
+
+  switch {
+  case rule == "required" || rule == "not_blank":
+    return violation{path, "%s can't be empty", []any{path}}
+  case rule == "string.min_len":
+    return violation{path, "%s must be at least %d characters long", []any{path, v.RuleValue.Uint()}}
+  case rule == "string.max_len":
+    return violation{path, "%s can't be longer than %d characters", []any{path, v.RuleValue.Uint()}}
+  case rule == "string.pattern":
+    return violation{path, "%s %q doesn't match %s", []any{path, v.FieldValue.String(), v.RuleValue.String()}}
+  case strings.HasPrefix(rule, "int32.") || strings.HasPrefix(rule, "int64."):
+    // With both bounds the rule is a single gte_lte one, the value tells which of them it is on the wrong side of.
+    gte, lte := intBounds(v.FieldDescriptor)
+    if gte != nil && v.FieldValue.Int() < *gte {
+      return violation{path, "%s can't be less than %d", []any{path, *gte}}
+    }
+    if lte != nil {
+      return violation{path, "%s can't be more than %d", []any{path, *lte}}
+    }
+  }
+
+  return violation{path, "%s: %s", []any{path, v.Proto.GetMessage()}}
+}
+
+// intBounds returns the gte and lte rules of an int32 or int64 field, nil for the ones it doesn't have.
+func intBounds(fd protoreflect.FieldDescriptor) (gte, lte *int64) {
+  rules, _ := proto.GetExtension(fd.Options(), validate.E_Field).(*validate.FieldRules)
+
+  switch {
+  case rules.GetInt32() != nil:
+    r := rules.GetInt32()
+    if r.HasGte() {
+      gte = proto.Int64(int64(r.GetGte()))
+    }
+    if r.HasLte() {
+      lte = proto.Int64(int64(r.GetLte()))
+    }
+  case rules.GetInt64() != nil:
+    r := rules.GetInt64()
+    if r.HasGte() {
+      gte = proto.Int64(r.GetGte())
+    }
+    if r.HasLte() {
+      lte = proto.Int64(r.GetLte())
+    }
+  }
+
+  return gte, lte
+}
+
+func newRequestValidator(messages protoreflect.MessageDescriptors) protovalidate.Validator {
+  descriptors := make([]protoreflect.MessageDescriptor, 0, messages.Len())
+  for i := 0; i < messages.Len(); i++ {
+    descriptors = append(descriptors, messages.Get(i))
+  }
+
+  validator, err := protovalidate.New(protovalidate.WithMessageDescriptors(descriptors...))
+  if err != nil {
+    log.Fatalf("failed to compile the rules of blog.proto: %v", err)
+  }
+
+  return validator
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "testing"
+
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+func TestValidateRequest(t *testing.T) {
+  tests := []struct {
+    req    any
+    fields []string
+  }{
+    {&pb.CreatePostRequest{Title: "Launch plan"}, nil},
+    {&pb.CreatePostRequest{Title: "   "}, []string{"Title"}},
+    {&pb.GetPostsRequest{PageSize: 101}, []string{"PageSize"}},
+    {&pb.GetPostBySlugRequest{Slug: "Launch Plan"}, []string{"Slug"}},
+    {&pb.AddCommentRequest{Content: "Nice post"}, []string{"PostId", "Author"}},
+  }
+
+  for _, test := range tests {
+    err := validateRequest(test.req)
+    if test.fields == nil {
+      if err != nil {
+        t.Errorf("%T: got %v, want no error", test.req, err)
+      }
+      continue
+    }
+
+    st, _ := status.FromError(err)
+    if st.Code() != codes.InvalidArgument {
+      t.Errorf("%T: got %v, want InvalidArgument", test.req, err)
+      continue
+    }
+    var fields []string
+    for _, detail := range st.Details() {
+      if bad, ok := detail.(*errdetails.BadRequest); ok {
+        for _, v := range bad.GetFieldViolations() {
+          fields = append(fields, v.GetField())
+        }
+      }
+    }
+    if len(fields) != len(test.fields) || fields[0] != test.fields[0] {
+      t.Errorf("%T: got the violations of %v, want %v", test.req, fields, test.fields)
+    }
+  }
+}
@@ -0,0 +1,509 @@
+package main
+
+import (
+  "context"
+  "crypto/sha256"
+  "encoding/hex"
+  "encoding/json"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/reqctx"
+  "io/fs"
+  "os"
+  "sort"
+  "sync"
+  "time"
+)
+
+/*
+  VIEW ANALYTICS
+
+  ViewCount only says how many times a post was viewed since it was written, it can't tell a post read a thousand times today from one read a thousand times last year. To answer questions about time ("what is popular right now?", "when do people read this post?") we also keep a time series of the views: for every post, the number of views in every minute it was viewed.
+
+  Minutes are the resolution: a view is only known to have happened during a given minute. That keeps the series small, a post viewed a hundred times in a minute takes a single entry, and only the minutes with views are stored at all. Everything coarser is a sum of minutes, so GetPostAnalytics can answer with any bucket size that is a whole number of minutes.
+
+  The series is kept in memory and written to views.json every few seconds by a background job (see jobs.go), one write for however many views happened in between. The job also drops the minutes older than -views-retention (a week by default), which is as far back as the RPCs can look. A crash loses the views of the last few seconds, a price worth paying for not writing a file on every GetPosts.
+
+  The views of the last day are what trending looks at, older views are mostly read by the day. The rollup-views task (see cron.go) merges the minutes older than a day into the first minute of their hour, so a post read all day long takes 24 entries a day rather than 1440. GetPostAnalytics is then only exact to the hour past the last day.
+
+  The series lives in the memory of a server, so replicas behind a load balancer each only know the views they served. ViewCount, counted in the storage (or in Redis, see redis.go), stays the shared total.
+
+  COUNTING VIEWS
+
+  Listing posts is not reading them, so GetPosts doesn't count anything. Clients call RecordView when a post is actually shown, with a ViewerId telling readers apart:
+
+    go run ./client view -id <post id> -viewer alice
+
+  Somebody refreshing the page ten times hasn't read the post ten times. A view of a viewer that already viewed the post less than -view-dedup-window ago (30 minutes by default) is ignored, so ViewCount, the time series above and the trending posts all count the same views. On top of that UniqueViewers counts how many different viewers a post had.
+
+  Both need to remember who viewed what, which is kept in viewers.json next to views.json and written by the same job: for every post, the viewers with the time of the last view that counted. Viewer IDs may be e-mail addresses or user names, so only the first 16 hex digits of their SHA-256 are stored. Telling whether a viewer is new means remembering every viewer forever, the file grows with the readers of the blog. With Redis the viewers are kept in a HyperLogLog instead, which counts them approximately in a few kilobytes per post (see redis.go).
+*/
+const (
+  viewsPath           = "views.json"
+  viewersPath         = "viewers.json"
+  viewsFlushEvery     = 10 * time.Second
+  maxAnalyticsBuckets = 10000
+  viewsRollupAfter    = 24 * time.Hour
+)
+
+type viewLog struct {
+  path, viewersPath string
+  retention         time.Duration
+  dedupWindow       time.Duration
+
+  mu sync.Mutex
+  // minutes[post ID][minute] is the number of views the post got during that minute, minutes are counted since the Unix epoch.
+  minutes map[string]map[int64]int64
+  // viewers[post ID][viewer hash] is the Unix time of the last view of the viewer that counted.
+  viewers map[string]map[string]int64
+  // dirty is set when there are views that haven't been written yet.
+  dirty bool
+}
+
+// viewsFile is the format of views.json: the [minute, views] pairs of every post, oldest first.
+type viewsFile map[string][][2]int64
+
+func newViewLog(path, viewersPath string, retention, dedupWindow time.Duration) (*viewLog, error) {
+  v := &viewLog{
+    path:        path,
+    viewersPath: viewersPath,
+    retention:   retention,
+    dedupWindow: dedupWindow,
+    minutes:     make(map[string]map[int64]int64),
+    viewers:     make(map[string]map[string]int64),
+  }
+
+  file := viewsFile{}
+  if err := readJSON(path, &file); err != nil {
+    return nil, err
+  }
+  if err := readJSON(viewersPath, &v.viewers); err != nil {
+    return nil, err
+  }
+  for id, points := range file {
+    v.minutes[id] = make(map[int64]int64, len(points))
+    for _, point := range points {
+      v.minutes[id][point[0]] = point[1]
+    }
+  }
+  v.prune(serverClock.Now())
+
+  return v, nil
+}
+
+// readJSON parses the file into value, a missing file leaves value as it is.
+func readJSON(path string, value any) error {
+  data, err := os.ReadFile(path)
+  if errors.Is(err, fs.ErrNotExist) {
+    return nil
+  }
+  if err != nil {
+    return fmt.Errorf("failed to read %s: %w", path, err)
+  }
+
+  if err := json.Unmarshal(data, value); err != nil {
+    return fmt.Errorf("failed to parse %s: %w", path, err)
+  }
+
+  return nil
+}
+
+// viewerHash is what is kept of a viewer ID.
+func viewerHash(viewer string) string {
+  sum := sha256.Sum256([]byte(viewer))
+  return hex.EncodeToString(sum[:8])
+}
+
+// see tells whether a view of the viewer at the given time counts, and whether the viewer never viewed the post before. A view that counts is remembered.
+func (v *viewLog) see(id, viewer string, at time.Time) (counted, unique bool) {
+  hash := viewerHash(viewer)
+
+  v.mu.Lock()
+  defer v.mu.Unlock()
+
+  seen := v.viewers[id]
+  if seen == nil {
+    seen = make(map[string]int64)
+    v.viewers[id] = seen
+  }
+
+  last, known := seen[hash]
+  if known && at.Sub(time.Unix(last, 0)) < v.dedupWindow {
+    return false, false
+  }
+
+  seen[hash] = at.Unix()
+  v.dirty = true
+
+  return true, !known
+}
+
+func minuteOf(t time.Time) int64 {
+  return t.Unix() / 60
+}
+
+// record counts a view of every post at the given time.
+func (v *viewLog) record(posts []*pb.Post, at time.Time) {
+  minute := minuteOf(at)
+
+  v.mu.Lock()
+  defer v.mu.Unlock()
+
+  for _, post := range posts {
+    series := v.minutes[post.Id]
+    if series == nil {
+      series = make(map[int64]int64)
+      v.minutes[post.Id] = series
+    }
+    series[minute]++
+  }
+  v.dirty = len(posts) > 0 || v.dirty
+}
+
+// prune drops the minutes older than the retention, v.mu must be held.
+func (v *viewLog) prune(now time.Time) {
+  oldest := minuteOf(now.Add(-v.retention))
+
+  for id, series := range v.minutes {
+    for minute := range series {
+      if minute < oldest {
+        delete(series, minute)
+        v.dirty = true
+      }
+    }
+    if len(series) == 0 {
+      delete(v.minutes, id)
+    }
+  }
+}
+
+// rollup is the rollup-views task, see above. The next flush writes the merged series.
+func (v *viewLog) rollup(context.Context) (string, error) {
+  newest := minuteOf(serverClock.Now().Add(-viewsRollupAfter))
+
+  v.mu.Lock()
+  defer v.mu.Unlock()
+
+  merged := 0
+  for _, series := range v.minutes {
+    for minute, views := range series {
+      hour := minute - minute%60
+      if minute >= newest || minute == hour {
+        continue
+      }
+      series[hour] += views
+      delete(series, minute)
+      merged++
+    }
+  }
+  v.dirty = v.dirty || merged > 0
+
+  return fmt.Sprintf("merged %d minutes into their hour", merged), nil
+}
+
+// run is the background job that writes the views to disk and prunes them, with a last write on shutdown.
+func (v *viewLog) run(ctx context.Context) error {
+  ticker := time.NewTicker(viewsFlushEvery)
+  defer ticker.Stop()
+
+  for {
+    select {
+    case <-ctx.Done():
+      return v.flush()
+    case <-ticker.C:
+      if err := v.flush(); err != nil {
+        return err
+      }
+    }
+  }
+}
+
+func (v *viewLog) flush() error {
+  v.mu.Lock()
+  v.prune(serverClock.Now())
+  if !v.dirty {
+    v.mu.Unlock()
+    return nil
+  }
+
+  file := make(viewsFile, len(v.minutes))
+  for id, series := range v.minutes {
+    points := make([][2]int64, 0, len(series))
+    for minute, views := range series {
+      points = append(points, [2]int64{minute, views})
+    }
+    sort.Slice(points, func(i, j int) bool { return points[i][0] < points[j][0] })
+    file[id] = points
+  }
+  // The viewers are encoded while holding the lock, views keep changing them.
+  viewers, err := json.Marshal(v.viewers)
+  v.dirty = false
+  v.mu.Unlock()
+
+  if err == nil {
+    err = replaceFile(v.path, file)
+  }
+  if err == nil {
+    err = replaceFile(v.viewersPath, json.RawMessage(viewers))
+  }
+  if err != nil {
+    v.mu.Lock()
+    v.dirty = true
+    v.mu.Unlock()
+    return fmt.Errorf("failed to save views: %w", err)
+  }
+
+  return nil
+}
+
+func replaceFile(path string, value any) error {
+  data, err := json.Marshal(value)
+  if err != nil {
+    return err
+  }
+
+  // Written next to the file and renamed over it, so a crash halfway through the write leaves the previous version in place.
+  if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+    return err
+  }
+
+  return os.Rename(path+".tmp", path)
+}
+
+// since returns the views of every post from the given time on.
+func (v *viewLog) since(t time.Time) map[string]int64 {
+  first := minuteOf(t)
+
+  v.mu.Lock()
+  defer v.mu.Unlock()
+
+  counts := make(map[string]int64)
+  for id, series := range v.minutes {
+    for minute, views := range series {
+      if minute >= first {
+        counts[id] += views
+      }
+    }
+  }
+
+  return counts
+}
+
+// between returns the views of every post from start to end, end excluded.
+func (v *viewLog) between(start, end time.Time) map[string]int64 {
+  first, last := minuteOf(start), minuteOf(end)
+
+  v.mu.Lock()
+  defer v.mu.Unlock()
+
+  counts := make(map[string]int64)
+  for id, series := range v.minutes {
+    for minute, views := range series {
+      if minute >= first && minute < last {
+        counts[id] += views
+      }
+    }
+  }
+
+  return counts
+}
+
+// buckets returns the views of a post in n buckets of the given width starting at start, which is a whole minute.
+func (v *viewLog) buckets(id string, start time.Time, width time.Duration, n int) []int64 {
+  first, perBucket := minuteOf(start), int64(width/time.Minute)
+  counts := make([]int64, n)
+
+  v.mu.Lock()
+  defer v.mu.Unlock()
+
+  for minute, views := range v.minutes[id] {
+    if i := (minute - first) / perBucket; minute >= first && i < int64(n) {
+      counts[i] += views
+    }
+  }
+
+  return counts
+}
+
+func (s *server) GetTrendingPosts(_ context.Context, req *pb.GetTrendingPostsRequest) (*pb.TrendingPosts, error) {
+  window := time.Duration(req.GetWindowSeconds()) * time.Second
+  if window == 0 {
+    window = 24 * time.Hour
+  }
+  if window < 0 || window > s.views.retention {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "WindowSeconds must be between 1 and %d, the seconds views are kept for", int64(s.views.retention/time.Second))
+  }
+
+  limit := int(req.GetLimit())
+  if limit == 0 {
+    limit = 10
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  counts := s.views.since(serverClock.Now().Add(-window))
+
+  trending := &pb.TrendingPosts{}
+  // Deleted and scheduled posts may still have views in the window, only the published ones can trend.
+  for _, post := range publishedPosts(posts).Posts {
+    if views := counts[post.Id]; views > 0 {
+      trending.Posts = append(trending.Posts, &pb.TrendingPost{Post: post, Views: views})
+    }
+  }
+
+  sort.SliceStable(trending.Posts, func(i, j int) bool { return trending.Posts[i].Views > trending.Posts[j].Views })
+  if len(trending.Posts) > limit {
+    trending.Posts = trending.Posts[:limit]
+  }
+
+  return trending, nil
+}
+
+func (s *server) GetPostAnalytics(_ context.Context, req *pb.GetPostAnalyticsRequest) (*pb.PostAnalytics, error) {
+  until := serverClock.Now()
+  if req.GetUntil() != "" {
+    t, err := time.Parse(time.RFC3339, req.GetUntil())
+    if err != nil {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Until must be an RFC 3339 timestamp: %w", err)
+    }
+    until = t
+  }
+
+  since := until.Add(-24 * time.Hour)
+  if req.GetSince() != "" {
+    t, err := time.Parse(time.RFC3339, req.GetSince())
+    if err != nil {
+      return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Since must be an RFC 3339 timestamp: %w", err)
+    }
+    since = t
+  }
+  if !since.Before(until) {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Since must be before Until")
+  }
+
+  width := time.Duration(req.GetBucketSeconds()) * time.Second
+  if width == 0 {
+    width = time.Hour
+  }
+  if width <= 0 || width%time.Minute != 0 {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "BucketSeconds must be a whole number of minutes")
+  }
+
+  // Buckets start on a whole minute, the resolution views are recorded at.
+  since = since.Truncate(time.Minute)
+  n := int((until.Sub(since) + width - 1) / width)
+  if n > maxAnalyticsBuckets {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "the range has %d buckets, at most %d are returned", n, maxAnalyticsBuckets)
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, err
+  }
+
+  if _, err := findPost(posts, req.GetPostId()); err != nil {
+    return nil, err
+  }
+
+  analytics := &pb.PostAnalytics{PostId: req.GetPostId()}
+  for i, views := range s.views.buckets(req.GetPostId(), since, width, n) {
+    analytics.Views += views
+    analytics.Buckets = append(analytics.Buckets, &pb.ViewBucket{
+      Start: since.Add(time.Duration(i) * width).UTC().Format(time.RFC3339),
+      Views: views,
+    })
+  }
+
+  return analytics, nil
+}
+
+// viewerOf returns who a view is counted for: the ViewerId of the request, or the caller when there is none.
+func viewerOf(ctx context.Context, req *pb.RecordViewRequest) string {
+  if req.GetViewerId() != "" {
+    return req.GetViewerId()
+  }
+  if identity := reqctx.Identity(ctx); identity != anonymousIdentity {
+    return identity
+  }
+
+  // Anonymous callers are told apart by their address, without the port which changes with every connection.
+  return peerHost(ctx)
+}
+
+func (s *server) RecordView(ctx context.Context, req *pb.RecordViewRequest) (*pb.RecordViewResponse, error) {
+  now := serverClock.Now()
+  res, err := s.countView(ctx, req, now)
+  // A repeat view doesn't count again but still moves the post to the top of the reading history, see sessions.go
+  if err == nil {
+    s.sessions.viewed(reqctx.Identity(ctx), req.GetPostId(), now)
+  }
+
+  return res, err
+}
+
+func (s *server) countView(ctx context.Context, req *pb.RecordViewRequest, now time.Time) (*pb.RecordViewResponse, error) {
+  viewer := viewerOf(ctx, req)
+
+  // With Redis views are counted over there, see redis.go
+  if s.redis != nil {
+    return s.recordViewInRedis(ctx, req.GetPostId(), viewer, now)
+  }
+
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  defer storeMu.Unlock()
+
+  if err := loadPost(posts); err != nil {
+    return nil, err
+  }
+
+  post, err := findPost(posts, req.GetPostId())
+  if err != nil {
+    return nil, err
+  }
+  // Scheduled posts can't be read yet, as far as readers are concerned they don't exist.
+  if post.Status != pb.PostStatus_PUBLISHED {
+    return nil, apperr.Errorf(apperr.ErrPostNotFound, "post %q not found", req.GetPostId())
+  }
+
+  counted, unique := s.views.see(post.Id, viewer, now)
+  if !counted {
+    return &pb.RecordViewResponse{ViewCount: post.ViewCount, UniqueViewers: post.UniqueViewers}, nil
+  }
+
+  post.ViewCount += 1
+  post.LastViewed = now.Format("2006-01-02")
+  if unique {
+    post.UniqueViewers += 1
+  }
+
+  if err := savePosts(posts); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save posts: %w", err)
+  }
+  s.views.record([]*pb.Post{post}, now)
+  s.authors.viewed(post.Id, post.ViewCount)
+
+  return &pb.RecordViewResponse{Counted: true, ViewCount: post.ViewCount, UniqueViewers: post.UniqueViewers}, nil
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+  "cmp"
+  "context"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "slices"
+  "strconv"
+  "sync"
+  "time"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/encoding/protojson"
+  "google.golang.org/protobuf/proto"
+)
+
+/*
+  WATCHING POSTS
+
+  WatchPosts is a server streaming RPC that never finishes on its own: the stream stays open and the server sends an event every time a post changes, until the client cancels its context or goes away. Events have a type (POST_CREATED, POST_UPDATED, POST_DELETED, POST_PUBLISHED, POST_ARCHIVED or POST_UNPUBLISHED) and carry the post, and clients can ask for only some types or only the posts of some authors:
+
+    go run ./client watch -types created,deleted -authors "Jane McFarland"
+
+  Readers only hear about what they could see with GetPosts: a scheduled post produces no events until it gets published, and then POST_PUBLISHED is the first one. A published post that UpdatePost schedules again gets POST_UNPUBLISHED, carrying only its ID and author, its new version stays hidden until it is published.
+
+  The postBroker is a tiny publish/subscribe hub. Each WatchPosts call subscribes a buffered channel together with its filters and handlers publish events to all the subscribers that want them. A subscriber that can't keep up misses events instead of slowing down the handler that is publishing.
+
+  RESUMING A WATCH
+
+  A watch lasts as long as its stream, and the changes made while a client reconnects would be lost. Every event has a Cursor though, the sequence its change got in the change log of SyncChanges, and a client sending the Cursor of the last event it got has the changes made since replayed before the new ones:
+
+    go run ./client watch -cursor 42
+
+  The broker keeps no history, the replay comes from the posts themselves, which only remember their last change. A post updated three times while the client was away is replayed once, as POST_UPDATED with its current version. Deleted posts only have their ID left, so they are replayed to the watches that aren't filtered by author. Like SyncChanges, archived and deleted posts may be ones the client never heard of, scheduled or drafts, and scheduled posts are replayed as POST_UNPUBLISHED, they may have been published before.
+
+  A client that hasn't got any event yet has no cursor to resume from, so the stream starts with the cursor of the last change in an x-watch-cursor header.
+*/
+const watchCursorHeader = "x-watch-cursor"
+
+type postBroker struct {
+  mu     sync.Mutex
+  subs   map[chan *pb.PostEvent]*pb.WatchPostsRequest
+  closed bool
+}
+
+func newPostBroker() *postBroker {
+  return &postBroker{subs: make(map[chan *pb.PostEvent]*pb.WatchPostsRequest)}
+}
+
+// subscribe returns the channel to read the events matching filter from and a function that must be called to stop receiving.
+func (b *postBroker) subscribe(filter *pb.WatchPostsRequest) (<-chan *pb.PostEvent, func()) {
+  ch := make(chan *pb.PostEvent, 16)
+
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  if b.closed {
+    close(ch)
+    return ch, func() {}
+  }
+  b.subs[ch] = filter
+
+  return ch, func() {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+
+    if _, ok := b.subs[ch]; ok {
+      delete(b.subs, ch)
+      close(ch)
+    }
+  }
+}
+
+func (b *postBroker) publish(eventType pb.PostEventType, post *pb.Post) {
+  // Handlers keep modifying their posts after publishing, so subscribers get a copy nobody else writes to.
+  event := &pb.PostEvent{Type: eventType, Post: proto.Clone(post).(*pb.Post), Cursor: post.Sequence}
+
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  for ch, filter := range b.subs {
+    if !wantsEvent(filter, event) {
+      continue
+    }
+
+    select {
+    case ch <- event:
+    default:
+    }
+  }
+}
+
+// marshalPostEvent encodes an event as JSON for the receivers outside of gRPC, the webhooks and the event bus.
+func marshalPostEvent(event *pb.PostEvent) ([]byte, error) {
+  // POST_CREATED is the zero value of the enum, which protojson leaves out unless told otherwise.
+  return protojson.MarshalOptions{EmitDefaultValues: true}.Marshal(event)
+}
+
+func wantsEvent(filter *pb.WatchPostsRequest, event *pb.PostEvent) bool {
+  if len(filter.GetTypes()) > 0 && !slices.Contains(filter.GetTypes(), event.Type) {
+    return false
+  }
+  if len(filter.GetAuthors()) > 0 && !slices.Contains(filter.GetAuthors(), event.Post.GetAuthor()) {
+    return false
+  }
+
+  return true
+}
+
+/*
+  close ends every subscription. Watch streams never end on their own, so without this GracefulStop would wait for them forever when the server shuts down.
+*/
+func (b *postBroker) close() {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  b.closed = true
+  for ch := range b.subs {
+    delete(b.subs, ch)
+    close(ch)
+  }
+}
+
+/*
+  INDEXES OF THE POSTS
+
+  Some answers are kept ready instead of being computed from every post on every call: the index of related.go, the sitemap of sitemap.go and the author profiles of authors.go. Each is built from the storage when the server starts, then runIndex keeps it up to date with the events of the broker: a created, updated, published or archived post is put again, the index deciding whether it still belongs, a deleted one is dropped. The broker drops events for subscribers that fall behind, so the whole index is also rebuilt every few minutes, a missed event only leaves it a little off for a while.
+*/
+type postIndex interface {
+  // load rebuilds the whole index from the storage.
+  load() error
+  put(post *pb.Post)
+  drop(id string)
+}
+
+// runIndex is the background job keeping the index in sync with the posts, rebuilt every period, see above.
+func runIndex(ctx context.Context, broker *postBroker, index postIndex, every time.Duration) error {
+  // Subscribing before loading means no change falls in between, at worst a post is put twice.
+  events, unsubscribe := broker.subscribe(&pb.WatchPostsRequest{})
+  defer unsubscribe()
+
+  if err := index.load(); err != nil {
+    return err
+  }
+
+  ticker := time.NewTicker(every)
+  defer ticker.Stop()
+
+  for {
+    select {
+    case <-ctx.Done():
+      return nil
+    case <-ticker.C:
+      if err := index.load(); err != nil {
+        return err
+      }
+    case event, ok := <-events:
+      if !ok {
+        return nil
+      }
+
+      // Deleted events carry the post as it was before, still marked as published.
+      if event.Type == pb.PostEventType_POST_DELETED {
+        index.drop(event.Post.Id)
+      } else {
+        index.put(event.Post)
+      }
+    }
+  }
+}
+
+func (s *server) WatchPosts(req *pb.WatchPostsRequest, stream grpc.ServerStreamingServer[pb.PostEvent]) error {
+  // Subscribing before reading the change log means no change falls in between. The ones that are both replayed and published are only sent once.
+  events, unsubscribe := s.broker.subscribe(req)
+  defer unsubscribe()
+
+  replay, cursor, err := replayEvents(req)
+  if err != nil {
+    return err
+  }
+  if err := stream.SendHeader(metadata.Pairs(watchCursorHeader, strconv.FormatInt(cursor, 10))); err != nil {
+    return err
+  }
+  for _, event := range replay {
+    if err := stream.Send(event); err != nil {
+      return err
+    }
+  }
+  // Without a cursor nothing was replayed, every event is new.
+  replayed := int64(0)
+  if req.GetCursor() > 0 {
+    replayed = cursor
+  }
+
+  // Maintenance closes the stream with a hint of when to come back, see maintenance.go
+  closing := s.maintenance.closingStreams()
+
+  // The stream context is cancelled when the client disconnects or the server shuts down.
+  for {
+    select {
+    case <-stream.Context().Done():
+      return nil
+    case <-closing:
+      return s.maintenance.closeStream(stream)
+    case event, ok := <-events:
+      if !ok {
+        return status.Errorf(codes.Unavailable, "server is shutting down")
+      }
+      if event.Cursor <= replayed {
+        continue
+      }
+      if err := stream.Send(event); err != nil {
+        return err
+      }
+    }
+  }
+}
+
+// replayEvents returns the events of the changes after the cursor of the request, see above, along with the cursor of the last change.
+func replayEvents(req *pb.WatchPostsRequest) ([]*pb.PostEvent, int64, error) {
+  posts := &pb.Posts{
+    Posts: make([]*pb.Post, 0),
+  }
+
+  storeMu.Lock()
+  err := loadPost(posts)
+  storeMu.Unlock()
+
+  if err != nil {
+    return nil, 0, err
+  }
+
+  cursor := nextSequence(posts) - 1
+  if req.GetCursor() == 0 {
+    return nil, cursor, nil
+  }
+
+  var events []*pb.PostEvent
+  for _, post := range posts.Posts {
+    if post.Sequence <= req.GetCursor() {
+      continue
+    }
+
+    event := &pb.PostEvent{Post: post, Cursor: post.Sequence}
+    switch post.Status {
+    case pb.PostStatus_PUBLISHED:
+      event.Type = pb.PostEventType_POST_UPDATED
+    case pb.PostStatus_ARCHIVED:
+      event.Type = pb.PostEventType_POST_ARCHIVED
+    case pb.PostStatus_DELETED:
+      event.Type = pb.PostEventType_POST_DELETED
+    // Like SyncChanges, a scheduled post may have been published before, see syncFrom.
+    case pb.PostStatus_SCHEDULED:
+      event = &pb.PostEvent{Type: pb.PostEventType_POST_UNPUBLISHED, Post: &pb.Post{Id: post.Id, Author: post.Author, Status: post.Status, Sequence: post.Sequence}, Cursor: post.Sequence}
+    default:
+      continue
+    }
+    if wantsEvent(req, event) {
+      events = append(events, event)
+    }
+  }
+  // In the order the changes were made, which is how the cursor moves forward.
+  slices.SortFunc(events, func(a, b *pb.PostEvent) int { return cmp.Compare(a.Cursor, b.Cursor) })
+
+  return events, cursor, nil
+}
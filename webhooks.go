@@ -0,0 +1,296 @@
+package main
+
+import (
+  "bytes"
+  "context"
+  "crypto/hmac"
+  "crypto/rand"
+  "crypto/sha256"
+  "encoding/hex"
+  "encoding/json"
+  "errors"
+  "fmt"
+  pb "github.com/eduardopoleoflipp/go-flipp-tutorial-grpc/gen"
+  "go/tutorial/grpc/internal/apperr"
+  "go/tutorial/grpc/internal/store"
+  "io"
+  "io/fs"
+  "log"
+  "net/http"
+  "net/url"
+  "os"
+  "slices"
+  "sync"
+  "time"
+)
+
+/*
+  WEBHOOKS
+
+  WatchPosts needs the client to keep a stream open. Webhooks turn that around for services that would rather be called: the server sends an HTTP POST to every registered URL when a post event it subscribed to happens. blogctl subscribes new webhooks to POST_CREATED and POST_PUBLISHED unless told otherwise, an empty list of types gets every event.
+
+    go run ./client webhooks add -token secret -url https://example.com/hooks/blog
+    go run ./client webhooks list -token secret
+    go run ./client webhooks remove -token secret -id <webhook id>
+
+  The body is the PostEvent of WatchPosts encoded as JSON, and every request carries a few headers:
+    - X-Blog-Event: the event type, e.g. POST_PUBLISHED
+    - X-Blog-Delivery: an ID that stays the same across retries, so receivers can drop duplicates
+    - X-Blog-Signature: sha256=<hex HMAC-SHA256 of the body keyed with the webhook's secret>
+
+  Anybody could POST to a public URL, the signature is how the receiver knows the request comes from us: it computes the same HMAC with its copy of the secret and compares. The secret is only returned once, by RegisterWebhook.
+
+  The dispatcher is a background job (see jobs.go) subscribed to the same broker as WatchPosts. Every delivery is a webhook task of the work queue (see queue.go), so a slow receiver doesn't hold up the others, and a delivery that fails (network error or an answer other than 2xx) is retried with exponential backoff, on the same worker. Once the server shuts down, a delivery that fails isn't retried anymore.
+*/
+const (
+  webhooksPath             = "webhooks.json"
+  webhookMaxAttempts       = 5
+  webhookFirstRetryBackoff = time.Second
+)
+
+type webhookDispatcher struct {
+  path   string
+  broker *postBroker
+  queue  *workQueue
+  client *http.Client
+
+  // mu guards the webhooks file.
+  mu sync.Mutex
+}
+
+func newWebhookDispatcher(path string, broker *postBroker, queue *workQueue) *webhookDispatcher {
+  return &webhookDispatcher{
+    path:   path,
+    broker: broker,
+    queue:  queue,
+    client: &http.Client{Timeout: 10 * time.Second},
+  }
+}
+
+func (s *server) RegisterWebhook(ctx context.Context, req *pb.RegisterWebhookRequest) (*pb.Webhook, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  u, err := url.Parse(req.GetUrl())
+  if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+    return nil, apperr.Errorf(apperr.ErrInvalidArgument, "Url must be an absolute http or https URL, got %q", req.GetUrl())
+  }
+
+  secret := req.GetSecret()
+  if secret == "" {
+    b := make([]byte, 32)
+    rand.Read(b)
+    secret = hex.EncodeToString(b)
+  }
+
+  hook := &pb.Webhook{
+    Id:        store.NewID(),
+    Url:       u.String(),
+    Secret:    secret,
+    Types:     req.GetTypes(),
+    CreatedAt: serverClock.Now().UTC().Format(time.RFC3339),
+  }
+
+  d := s.webhooks
+  d.mu.Lock()
+  defer d.mu.Unlock()
+
+  hooks, err := d.load()
+  if err != nil {
+    return nil, err
+  }
+
+  if err := d.save(append(hooks, hook)); err != nil {
+    return nil, err
+  }
+
+  return hook, nil
+}
+
+func (s *server) UnregisterWebhook(ctx context.Context, req *pb.UnregisterWebhookRequest) (*pb.UnregisterWebhookResponse, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  d := s.webhooks
+  d.mu.Lock()
+  defer d.mu.Unlock()
+
+  hooks, err := d.load()
+  if err != nil {
+    return nil, err
+  }
+
+  i := slices.IndexFunc(hooks, func(h *pb.Webhook) bool { return h.Id == req.GetId() })
+  if i == -1 {
+    return nil, apperr.Errorf(apperr.ErrWebhookNotFound, "webhook %q not found", req.GetId())
+  }
+
+  if err := d.save(slices.Delete(hooks, i, i+1)); err != nil {
+    return nil, err
+  }
+
+  return &pb.UnregisterWebhookResponse{}, nil
+}
+
+func (s *server) ListWebhooks(ctx context.Context, _ *pb.ListWebhooksRequest) (*pb.Webhooks, error) {
+  if err := s.auth.requireAdmin(ctx); err != nil {
+    return nil, err
+  }
+
+  d := s.webhooks
+  d.mu.Lock()
+  defer d.mu.Unlock()
+
+  hooks, err := d.load()
+  if err != nil {
+    return nil, err
+  }
+
+  // Secrets are only handed out once, when the webhook is registered.
+  for _, hook := range hooks {
+    hook.Secret = ""
+  }
+
+  return &pb.Webhooks{Webhooks: hooks}, nil
+}
+
+// run is the background job that turns post events into deliveries. It returns once the broker is closed or the job is stopped, the queued deliveries are left to the queue.
+func (d *webhookDispatcher) run(ctx context.Context) error {
+  // Receivers that only care about some events are filtered per webhook in dispatch, so we subscribe to all of them.
+  events, unsubscribe := d.broker.subscribe(&pb.WatchPostsRequest{})
+  defer unsubscribe()
+
+  for {
+    select {
+    case <-ctx.Done():
+      return nil
+    case event, ok := <-events:
+      if !ok {
+        return nil
+      }
+      d.dispatch(event)
+    }
+  }
+}
+
+func (d *webhookDispatcher) dispatch(event *pb.PostEvent) {
+  d.mu.Lock()
+  hooks, err := d.load()
+  d.mu.Unlock()
+  if err != nil {
+    log.Printf("webhooks: %v", err)
+    return
+  }
+
+  payload, err := marshalPostEvent(event)
+  if err != nil {
+    log.Printf("webhooks: failed to encode event: %v", err)
+    return
+  }
+
+  for _, hook := range hooks {
+    if !wantsEvent(&pb.WatchPostsRequest{Types: hook.Types}, event) {
+      continue
+    }
+
+    d.queue.submit("webhook", func(ctx context.Context) error {
+      return d.deliver(ctx, hook, event.Type, payload)
+    })
+  }
+}
+
+func (d *webhookDispatcher) deliver(ctx context.Context, hook *pb.Webhook, eventType pb.PostEventType, payload []byte) error {
+  deliveryID := store.NewID()
+  backoff := webhookFirstRetryBackoff
+
+  for attempt := 1; ; attempt++ {
+    err := d.post(ctx, hook, deliveryID, eventType, payload)
+    if err == nil {
+      return nil
+    }
+
+    if attempt == webhookMaxAttempts {
+      return fmt.Errorf("webhook %s: giving up on delivery %s after %d attempts: %w", hook.Id, deliveryID, attempt, err)
+    }
+
+    select {
+    case <-ctx.Done():
+      return fmt.Errorf("webhook %s: delivery %s cancelled: %w", hook.Id, deliveryID, err)
+    case <-d.queue.stopping():
+      return fmt.Errorf("webhook %s: dropping delivery %s, the server is shutting down: %w", hook.Id, deliveryID, err)
+    case <-time.After(backoff):
+    }
+    backoff *= 2
+  }
+}
+
+func (d *webhookDispatcher) post(ctx context.Context, hook *pb.Webhook, deliveryID string, eventType pb.PostEventType, payload []byte) error {
+  req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Url, bytes.NewReader(payload))
+  if err != nil {
+    return err
+  }
+
+  req.Header.Set("Content-Type", "application/json")
+  req.Header.Set("User-Agent", "blog-webhooks/1.0")
+  req.Header.Set("X-Blog-Event", eventType.String())
+  req.Header.Set("X-Blog-Delivery", deliveryID)
+  req.Header.Set("X-Blog-Signature", signPayload(hook.Secret, payload))
+
+  res, err := d.client.Do(req)
+  if err != nil {
+    return err
+  }
+  defer res.Body.Close()
+
+  // Reading the body lets the client reuse the connection for the next delivery.
+  io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+  if res.StatusCode < 200 || res.StatusCode > 299 {
+    return fmt.Errorf("receiver answered %s", res.Status)
+  }
+
+  return nil
+}
+
+func signPayload(secret string, payload []byte) string {
+  mac := hmac.New(sha256.New, []byte(secret))
+  mac.Write(payload)
+
+  return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// load reads the registered webhooks, the caller must hold d.mu.
+func (d *webhookDispatcher) load() ([]*pb.Webhook, error) {
+  var hooks []*pb.Webhook
+
+  data, err := os.ReadFile(d.path)
+  // No file yet simply means no webhook has been registered so far.
+  if errors.Is(err, fs.ErrNotExist) {
+    return hooks, nil
+  }
+  if err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to read webhooks file: %w", err)
+  }
+
+  if err := json.Unmarshal(data, &hooks); err != nil {
+    return nil, apperr.Errorf(apperr.ErrStorageUnavailable, "failed to parse webhooks: %w", err)
+  }
+
+  return hooks, nil
+}
+
+func (d *webhookDispatcher) save(hooks []*pb.Webhook) error {
+  data, err := json.MarshalIndent(hooks, "", "  ")
+  if err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save webhooks: %w", err)
+  }
+
+  // The file holds the signing secrets, so only the owner can read it.
+  if err := os.WriteFile(d.path, data, 0600); err != nil {
+    return apperr.Errorf(apperr.ErrStorageUnavailable, "failed to save webhooks: %w", err)
+  }
+
+  return nil
+}